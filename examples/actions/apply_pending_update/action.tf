@@ -0,0 +1,11 @@
+# Apply the update staged on the selected train and reboot into it.
+# Invoke during a maintenance window with:
+#   terraform apply -invoke=action.truenas_apply_pending_update.maintenance
+#
+# Set wait_for_system_ready = true on the provider so the next run waits
+# for the system to finish booting the new version.
+action "truenas_apply_pending_update" "maintenance" {
+  config {
+    reboot = true
+  }
+}
@@ -0,0 +1,13 @@
+# Copy a dataset to the backup pool before a risky change.
+# Invoke from a pipeline with:
+#   terraform apply -invoke=action.truenas_replication_run.pre_upgrade
+action "truenas_replication_run" "pre_upgrade" {
+  config {
+    source_datasets = [truenas_dataset.data.id]
+    target_dataset  = "backup/data"
+    recursive       = true
+
+    # Replicate the snapshot taken for this change
+    name_regex = "pre-upgrade"
+  }
+}
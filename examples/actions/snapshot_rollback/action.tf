@@ -0,0 +1,12 @@
+# Roll a dataset back to a known-good snapshot.
+# Invoke from a recovery runbook with:
+#   terraform apply -invoke=action.truenas_snapshot_rollback.data
+action "truenas_snapshot_rollback" "data" {
+  config {
+    dataset_id    = truenas_dataset.data.id
+    snapshot_name = truenas_snapshot.before_upgrade.name
+
+    # Destroy any snapshots taken after before-upgrade
+    recursive = true
+  }
+}
@@ -0,0 +1,20 @@
+# Look up the versions an installed app can be upgraded to
+data "truenas_app_available_versions" "immich" {
+  name = "immich"
+}
+
+output "immich_upgrade_versions" {
+  value = [for v in data.truenas_app_available_versions.immich.available_versions : v.human_version]
+}
+
+# Fail the plan if the default upgrade would cross a major chart version
+check "immich_upgrade_is_minor" {
+  assert {
+    condition = (
+      !data.truenas_app_available_versions.immich.upgrade_available ||
+      split(".", data.truenas_app_available_versions.immich.upgrade_version)[0] ==
+      split(".", data.truenas_app_available_versions.immich.version)[0]
+    )
+    error_message = "The pending immich upgrade changes the major version; review the changelog first."
+  }
+}
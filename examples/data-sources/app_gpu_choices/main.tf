@@ -0,0 +1,38 @@
+# List the GPUs apps can use on this host
+data "truenas_app_gpu_choices" "this" {}
+
+output "app_gpus" {
+  value = data.truenas_app_gpu_choices.this.gpus
+}
+
+# Give a custom app every usable NVIDIA GPU
+locals {
+  nvidia_uuids = [
+    for gpu in data.truenas_app_gpu_choices.this.gpus : gpu.uuid
+    if gpu.vendor == "NVIDIA" && gpu.error == null
+  ]
+}
+
+resource "truenas_app" "jellyfin" {
+  name       = "jellyfin"
+  custom_app = true
+
+  compose_config = yamlencode({
+    services = {
+      jellyfin = {
+        image = "jellyfin/jellyfin:latest"
+        deploy = {
+          resources = {
+            reservations = {
+              devices = [{
+                driver       = "nvidia"
+                device_ids   = local.nvidia_uuids
+                capabilities = ["gpu"]
+              }]
+            }
+          }
+        }
+      }
+    }
+  })
+}
@@ -0,0 +1,12 @@
+# Read the runtime status of an installed app
+data "truenas_app_status" "immich" {
+  name = "immich"
+}
+
+output "immich_running" {
+  value = data.truenas_app_status.immich.state == "RUNNING"
+}
+
+output "immich_host_ports" {
+  value = [for p in data.truenas_app_status.immich.used_ports : p.host_port]
+}
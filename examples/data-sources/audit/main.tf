@@ -0,0 +1,17 @@
+# Export failed SMB authentications from the last day
+data "truenas_audit" "smb_auth" {
+  services = ["SMB"]
+  event    = "AUTHENTICATION"
+  since    = timeadd(plantimestamp(), "-24h")
+  limit    = 500
+}
+
+output "failed_smb_logins" {
+  value = [
+    for e in data.truenas_audit.smb_auth.entries : {
+      time    = e.timestamp
+      user    = e.username
+      address = e.address
+    } if !e.success
+  ]
+}
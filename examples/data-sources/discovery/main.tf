@@ -0,0 +1,10 @@
+data "truenas_discovery" "existing" {
+  include = ["vm", "dataset", "webdav_share"]
+}
+
+# Write import blocks for everything found, then run:
+#   terraform plan -generate-config-out=generated.tf
+resource "local_file" "imports" {
+  filename = "${path.module}/imports.tf"
+  content  = data.truenas_discovery.existing.import_blocks
+}
@@ -0,0 +1,13 @@
+data "truenas_enclosure" "head" {
+  name = "iX 4024Sp e001"
+}
+
+# Reference disks by physical slot rather than by device name
+resource "truenas_disk_wipe" "slot_5" {
+  disk    = data.truenas_enclosure.head.slot_disks["5"]
+  confirm = data.truenas_enclosure.head.slot_disks["5"]
+}
+
+output "empty_slots" {
+  value = [for s in data.truenas_enclosure.head.slots : s.slot if s.disk == null]
+}
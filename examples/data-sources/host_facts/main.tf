@@ -0,0 +1,17 @@
+# Read a snapshot of the host in a single data source
+data "truenas_host_facts" "this" {}
+
+output "truenas_version" {
+  value = data.truenas_host_facts.this.version
+}
+
+# Names of all pools that are not healthy
+output "degraded_pools" {
+  value = [for p in data.truenas_host_facts.this.pools : p.name if p.status != "ONLINE"]
+}
+
+# Place app data on the pool the apps service already uses
+resource "truenas_dataset" "app_data" {
+  pool = data.truenas_host_facts.this.app_pool
+  path = "appdata"
+}
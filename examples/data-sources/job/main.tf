@@ -0,0 +1,9 @@
+# Find scrubs of the data pool that are still running
+data "truenas_job" "scrubs" {
+  method = "pool.scrub.scrub"
+  state  = "RUNNING"
+}
+
+output "running_scrub_ids" {
+  value = data.truenas_job.scrubs.jobs[*].id
+}
@@ -0,0 +1,8 @@
+# List enabled NFS exports to cross-reference with cluster storage classes
+data "truenas_nfs_shares" "enabled" {
+  enabled = true
+}
+
+output "nfs_export_paths" {
+  value = data.truenas_nfs_shares.enabled.shares[*].path
+}
@@ -0,0 +1,35 @@
+# Stop the pipeline before making changes when the target pool is not healthy
+data "truenas_pool_status" "tank" {
+  name = "tank"
+}
+
+check "pool_healthy" {
+  assert {
+    condition     = data.truenas_pool_status.tank.status == "ONLINE"
+    error_message = "Pool tank is ${data.truenas_pool_status.tank.status}: ${coalesce(data.truenas_pool_status.tank.status_detail, "no detail")}"
+  }
+}
+
+resource "truenas_dataset" "apps" {
+  pool = "tank"
+  path = "apps"
+
+  lifecycle {
+    precondition {
+      condition     = data.truenas_pool_status.tank.healthy
+      error_message = "Refusing to change datasets on an unhealthy pool."
+    }
+  }
+}
+
+# Devices reporting errors, e.g. to alert on a failing disk
+output "failing_devices" {
+  value = [
+    for d in data.truenas_pool_status.tank.devices : d.name
+    if d.read_errors + d.write_errors + d.checksum_errors > 0
+  ]
+}
+
+output "last_scrub" {
+  value = try(data.truenas_pool_status.tank.scrub_history[length(data.truenas_pool_status.tank.scrub_history) - 1], null)
+}
@@ -0,0 +1,12 @@
+data "truenas_provider_health" "this" {
+  samples            = 5
+  latency_warning_ms = 250
+}
+
+output "truenas_latency" {
+  value = {
+    average_ms = data.truenas_provider_health.this.average_rtt_ms
+    max_ms     = data.truenas_provider_health.this.max_rtt_ms
+    missed     = data.truenas_provider_health.this.missed
+  }
+}
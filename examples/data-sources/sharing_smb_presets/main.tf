@@ -0,0 +1,18 @@
+# List the SMB share purposes this TrueNAS version offers
+data "truenas_sharing_smb_presets" "this" {}
+
+output "smb_purposes" {
+  value = data.truenas_sharing_smb_presets.this.names
+}
+
+# Look up the auxiliary parameters a purpose implies, so a share module can
+# keep its own aux parameters in line with the server version
+locals {
+  smb_presets = {
+    for preset in data.truenas_sharing_smb_presets.this.presets : preset.name => preset
+  }
+}
+
+output "timemachine_auxsmbconf" {
+  value = local.smb_presets["ENHANCED_TIMEMACHINE"].auxsmbconf
+}
@@ -0,0 +1,8 @@
+# Check for existing SMB shares under a dataset before creating a new one
+data "truenas_smb_shares" "media" {
+  path_prefix = "/mnt/tank/media"
+}
+
+output "media_share_names" {
+  value = data.truenas_smb_shares.media.shares[*].name
+}
@@ -0,0 +1,18 @@
+# List the CPU models VMs can emulate on this host
+data "truenas_vm_cpu_models" "this" {}
+
+output "cpu_models" {
+  value = data.truenas_vm_cpu_models.this.models
+}
+
+# Prefer a specific model but fall back to host-model when the host can't emulate it
+locals {
+  use_haswell = contains(data.truenas_vm_cpu_models.this.models, "Haswell")
+}
+
+resource "truenas_vm" "build" {
+  name      = "build"
+  memory    = 4096
+  cpu_mode  = local.use_haswell ? "CUSTOM" : "HOST-MODEL"
+  cpu_model = local.use_haswell ? "Haswell" : null
+}
@@ -0,0 +1,10 @@
+provider "truenas" {
+  alias = "old"
+  # ...
+}
+
+# Export a VM from the old host, for truenas_vm_migration on another
+data "truenas_vm_definition" "web" {
+  provider = truenas.old
+  name     = "web"
+}
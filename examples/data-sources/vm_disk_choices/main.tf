@@ -0,0 +1,27 @@
+# List the zvols a VM disk can attach, as the UI's disk dropdown shows them
+data "truenas_vm_disk_choices" "this" {}
+
+output "vm_disk_paths" {
+  value = data.truenas_vm_disk_choices.this.paths
+}
+
+# Fail the plan early when a module is handed a zvol the host doesn't offer
+variable "disk_path" {
+  type = string
+}
+
+resource "truenas_vm" "app" {
+  name   = "app"
+  memory = 2048
+
+  disk {
+    path = var.disk_path
+  }
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.truenas_vm_disk_choices.this.paths, var.disk_path)
+      error_message = "${var.disk_path} is not an attachable zvol on this host."
+    }
+  }
+}
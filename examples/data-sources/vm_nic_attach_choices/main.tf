@@ -0,0 +1,22 @@
+# List the interfaces VM NICs can attach to on this host
+data "truenas_vm_nic_attach_choices" "this" {}
+
+output "nic_attach_choices" {
+  value = data.truenas_vm_nic_attach_choices.this.interfaces
+}
+
+# Attach to br0 when the host has it, otherwise to the first interface
+locals {
+  interfaces = data.truenas_vm_nic_attach_choices.this.interfaces
+  web_nic    = contains(local.interfaces, "br0") ? "br0" : local.interfaces[0]
+}
+
+resource "truenas_vm" "web" {
+  name   = "web"
+  memory = 2048
+
+  nic {
+    type       = "VIRTIO"
+    nic_attach = local.web_nic
+  }
+}
@@ -0,0 +1,22 @@
+# List the OVMF firmware files available for VMs on this host
+data "truenas_vm_ovmf_firmwares" "this" {}
+
+output "ovmf_firmwares" {
+  value = data.truenas_vm_ovmf_firmwares.this.firmwares
+}
+
+# Boot with secure boot firmware when the host ships it
+locals {
+  secboot_firmware = "OVMF_CODE_4M.secboot.fd"
+}
+
+resource "truenas_vm" "windows" {
+  name   = "windows"
+  memory = 8192
+  tpm    = true
+  bootloader_ovmf = (
+    contains(data.truenas_vm_ovmf_firmwares.this.firmwares, local.secboot_firmware)
+    ? local.secboot_firmware
+    : "OVMF_CODE.fd"
+  )
+}
@@ -0,0 +1,17 @@
+# Assert after apply that a VM actually came up
+resource "truenas_vm" "web" {
+  name   = "web"
+  memory = 4096
+  state  = "RUNNING"
+}
+
+check "web_vm_running" {
+  data "truenas_vm_status" "web" {
+    name = truenas_vm.web.name
+  }
+
+  assert {
+    condition     = data.truenas_vm_status.web.running
+    error_message = "VM ${data.truenas_vm_status.web.name} is ${data.truenas_vm_status.web.state}, expected RUNNING."
+  }
+}
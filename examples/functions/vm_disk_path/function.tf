@@ -0,0 +1,25 @@
+# Name each disk zvol once and reuse it for the VM's disk devices
+locals {
+  web_disks = [for i in range(2) : provider::truenas::vm_disk_path("tank", "web", i)]
+}
+
+resource "truenas_zvol" "web_disk" {
+  count = length(local.web_disks)
+
+  pool    = local.web_disks[count.index].pool
+  path    = local.web_disks[count.index].path
+  volsize = count.index == 0 ? "32G" : "100G"
+}
+
+resource "truenas_vm" "web" {
+  name   = "web"
+  memory = 4096
+
+  dynamic "disk" {
+    for_each = truenas_zvol.web_disk
+    content {
+      path = local.web_disks[disk.key].device
+      type = "VIRTIO"
+    }
+  }
+}
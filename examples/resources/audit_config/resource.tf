@@ -0,0 +1,8 @@
+# Keep audit records for 30 days and cap the audit dataset at 20 GiB
+resource "truenas_audit_config" "example" {
+  retention           = 30
+  reservation         = 5
+  quota               = 20
+  quota_fill_warning  = 70
+  quota_fill_critical = 90
+}
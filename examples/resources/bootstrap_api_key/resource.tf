@@ -0,0 +1,36 @@
+# On a fresh install only SSH access is available, so an SSH-only
+# provider creates the API key that the main provider authenticates with.
+provider "truenas" {
+  alias       = "bootstrap"
+  host        = "192.168.1.100"
+  auth_method = "ssh"
+
+  ssh {
+    user                 = "root"
+    private_key          = file("~/.ssh/truenas_ed25519")
+    host_key_fingerprint = "SHA256:..."
+  }
+}
+
+resource "truenas_bootstrap_api_key" "terraform" {
+  provider = truenas.bootstrap
+
+  name     = "terraform"
+  username = "truenas_admin"
+}
+
+provider "truenas" {
+  host        = "192.168.1.100"
+  auth_method = "websocket"
+
+  websocket {
+    username = truenas_bootstrap_api_key.terraform.username
+    api_key  = truenas_bootstrap_api_key.terraform.key
+  }
+
+  ssh {
+    user                 = "root"
+    private_key          = file("~/.ssh/truenas_ed25519")
+    host_key_fingerprint = "SHA256:..."
+  }
+}
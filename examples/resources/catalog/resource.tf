@@ -0,0 +1,4 @@
+# Enable the community train alongside stable so community apps can be installed
+resource "truenas_catalog" "example" {
+  preferred_trains = ["stable", "community"]
+}
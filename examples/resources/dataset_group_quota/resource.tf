@@ -0,0 +1,6 @@
+# Limit the members of a group to 1 TiB on a project dataset
+resource "truenas_dataset_group_quota" "engineering" {
+  dataset = truenas_dataset.projects.id
+  gid     = 3000
+  quota   = 1099511627776
+}
@@ -0,0 +1,7 @@
+# Limit a user to 50 GiB and 100k files on a shared home dataset
+resource "truenas_dataset_user_quota" "alice" {
+  dataset      = truenas_dataset.home.id
+  uid          = 1000
+  quota        = 53687091200
+  object_quota = 100000
+}
@@ -0,0 +1,7 @@
+# Quick-wipe a disk before adding it to a new pool.
+# confirm must repeat the disk name; all data on the disk is destroyed.
+resource "truenas_disk_wipe" "sdb" {
+  disk    = "sdb"
+  mode    = "QUICK"
+  confirm = "sdb"
+}
@@ -0,0 +1,16 @@
+# Set a ZFS user property that the middleware API does not expose.
+# The command re-runs whenever the dataset is replaced or the
+# property value changes.
+resource "truenas_exec" "backup_tag" {
+  command = "sudo zfs set com.example:backup=nightly ${truenas_dataset.data.id}"
+
+  triggers = {
+    dataset = truenas_dataset.data.id
+  }
+}
+
+# Guard an apply: fail unless the maintenance flag file is absent.
+resource "truenas_exec" "not_in_maintenance" {
+  command            = "test -e /mnt/tank/.maintenance"
+  expected_exit_code = 1
+}
@@ -0,0 +1,15 @@
+# Expose an existing FC-mode target on a Fibre Channel host port
+# (TrueNAS Enterprise 25.04 or later with Fibre Channel hardware)
+variable "fc_target_id" {
+  type        = number
+  description = "ID of an iSCSI target created with mode FC or BOTH."
+}
+
+resource "truenas_fc_port" "primary" {
+  port   = "fc0"
+  target = var.fc_target_id
+}
+
+output "zoning_wwpns" {
+  value = compact([truenas_fc_port.primary.wwpn, truenas_fc_port.primary.wwpn_b])
+}
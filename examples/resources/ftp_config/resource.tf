@@ -0,0 +1,15 @@
+# Configure the FTP service with TLS and a fixed passive port range
+resource "truenas_ftp_config" "example" {
+  port            = 21
+  clients         = 32
+  ipconnections   = 4
+  defaultroot     = true
+  onlylocal       = true
+  passiveportsmin = 49152
+  passiveportsmax = 49200
+  masqaddress     = "ftp.example.com"
+
+  tls                = true
+  tls_policy         = "ctrl+data"
+  ssltls_certificate = 1
+}
@@ -0,0 +1,5 @@
+# Manage membership of a local group whose users come from a directory service
+resource "truenas_group_membership" "media" {
+  group_id = 42
+  users    = [1000, 1001]
+}
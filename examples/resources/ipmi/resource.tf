@@ -0,0 +1,9 @@
+# Give the BMC a static address on the management VLAN
+resource "truenas_ipmi" "bmc" {
+  channel    = 1
+  ip_address = "10.0.10.21"
+  netmask    = "255.255.255.0"
+  gateway    = "10.0.10.1"
+  vlan       = 10
+  password   = var.bmc_password
+}
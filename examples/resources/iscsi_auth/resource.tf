@@ -0,0 +1,26 @@
+variable "chap_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "chap_peer_secret" {
+  type      = string
+  sensitive = true
+}
+
+# One-way CHAP: initiators authenticate to the target
+resource "truenas_iscsi_auth" "initiators" {
+  tag    = 1
+  user   = "initiator1"
+  secret = var.chap_secret
+}
+
+# Mutual CHAP: the target also authenticates back to the initiator.
+# Targets select these credentials by setting their group's auth tag to 2.
+resource "truenas_iscsi_auth" "mutual" {
+  tag         = 2
+  user        = "initiator2"
+  secret      = var.chap_secret
+  peer_user   = "target2"
+  peer_secret = var.chap_peer_secret
+}
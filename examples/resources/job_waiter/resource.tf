@@ -0,0 +1,12 @@
+# Wait for a scrub started outside Terraform before replacing disks
+data "truenas_job" "scrub" {
+  method = "pool.scrub.scrub"
+  state  = "RUNNING"
+}
+
+resource "truenas_job_waiter" "scrub" {
+  for_each = { for job in data.truenas_job.scrub.jobs : tostring(job.id) => job }
+
+  job_id  = each.value.id
+  timeout = 7200
+}
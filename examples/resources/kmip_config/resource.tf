@@ -0,0 +1,10 @@
+# Store ZFS and SED keys on an external KMIP server
+resource "truenas_kmip_config" "example" {
+  server                = "kmip.example.com"
+  port                  = 5696
+  certificate           = 3 # client certificate ID
+  certificate_authority = 2 # CA certificate ID
+
+  manage_zfs_keys  = true
+  manage_sed_disks = true
+}
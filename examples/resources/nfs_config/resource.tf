@@ -0,0 +1,11 @@
+# Serve NFSv4 over RDMA with a fixed ID mapping domain, and pin the helper
+# daemons to fixed ports so the firewall only needs to allow those
+resource "truenas_nfs_config" "example" {
+  protocols = ["NFSV4"]
+  v4_domain = "example.com"
+  rdma      = true
+
+  mountd_port   = 618
+  rpcstatd_port = 871
+  rpclockd_port = 32803
+}
@@ -0,0 +1,7 @@
+# Prioritize resilvers overnight on weekends only
+resource "truenas_pool_resilver_priority" "example" {
+  enabled  = true
+  begin    = "22:00"
+  end      = "06:00"
+  weekdays = [6, 7]
+}
@@ -0,0 +1,8 @@
+# Delegate share administration to a local group, and read-only access to a
+# directory service group (by GID or SID)
+resource "truenas_privilege" "sharing_admins" {
+  name         = "sharing-admins"
+  local_groups = [3000]
+  ds_groups    = ["S-1-5-21-1004336348-1177238915-682003330-512"]
+  roles        = ["SHARING_ADMIN", "READONLY_ADMIN"]
+}
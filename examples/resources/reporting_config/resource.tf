@@ -0,0 +1,6 @@
+# Keep two years of reporting history at 5 minute resolution
+resource "truenas_reporting_config" "example" {
+  tier0_days            = 7
+  tier1_days            = 730
+  tier1_update_interval = 300
+}
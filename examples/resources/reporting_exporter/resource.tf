@@ -0,0 +1,12 @@
+# Ship metrics to a Graphite server
+resource "truenas_reporting_exporter" "graphite" {
+  name = "graphite"
+
+  graphite {
+    destination_ip   = "graphite.example.com"
+    destination_port = 2003
+    prefix           = "truenas"
+    namespace        = "nas01"
+    update_every     = 10
+  }
+}
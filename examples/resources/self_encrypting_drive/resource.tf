@@ -0,0 +1,10 @@
+# Set the global SED password and override it for one disk
+resource "truenas_self_encrypting_drive" "example" {
+  sed_user = "USER"
+  password = var.sed_password
+
+  disk {
+    identifier = "{serial_lunid}5000C500A1B2C3D4"
+    password   = var.sed_disk_password
+  }
+}
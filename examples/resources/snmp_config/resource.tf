@@ -0,0 +1,16 @@
+# Configure the SNMP service
+resource "truenas_snmp_config" "example" {
+  location  = "Server Room"
+  contact   = "admin@example.com"
+  community = "monitoring"
+}
+
+# SNMPv3 with authentication and privacy
+resource "truenas_snmp_config" "v3" {
+  v3                = true
+  v3_username       = "snmpuser"
+  v3_authtype       = "SHA"
+  v3_password       = var.snmp_password
+  v3_privproto      = "AES"
+  v3_privpassphrase = var.snmp_privpassphrase
+}
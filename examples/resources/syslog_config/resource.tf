@@ -0,0 +1,7 @@
+# Forward logs to a central collector over TLS, authenticating with a client certificate
+resource "truenas_syslog_config" "example" {
+  server          = "logs.example.com:6514"
+  transport       = "TLS"
+  tls_certificate = var.syslog_client_certificate_id
+  level           = "F_NOTICE"
+}
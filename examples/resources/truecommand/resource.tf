@@ -0,0 +1,4 @@
+# Enroll this system into TrueCommand
+resource "truenas_truecommand" "example" {
+  api_key = var.truecommand_api_key
+}
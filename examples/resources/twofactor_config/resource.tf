@@ -0,0 +1,6 @@
+# Require two-factor authentication, including for SSH
+resource "truenas_twofactor_config" "example" {
+  enabled = true
+  window  = 1
+  ssh     = true
+}
@@ -0,0 +1,5 @@
+# Follow the Fangtooth train and stage updates in the background
+resource "truenas_update_config" "example" {
+  train         = "TrueNAS-SCALE-Fangtooth"
+  auto_download = true
+}
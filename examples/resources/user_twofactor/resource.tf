@@ -0,0 +1,9 @@
+# Provision a TOTP secret for a local user
+resource "truenas_user_twofactor" "alice" {
+  username = "alice"
+}
+
+output "alice_otp_uri" {
+  value     = truenas_user_twofactor.alice.provisioning_uri
+  sensitive = true
+}
@@ -47,6 +47,10 @@ resource "truenas_vm" "with_installer" {
   }
 }
 
+output "installer_console" {
+  value = truenas_vm.with_installer.display[0].web_url
+}
+
 # Windows VM with TPM and Hyper-V enlightenments
 resource "truenas_vm" "windows" {
   name              = "windows-11"
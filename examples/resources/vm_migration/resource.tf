@@ -0,0 +1,25 @@
+provider "truenas" {
+  alias = "old"
+  # ...
+}
+
+provider "truenas" {
+  alias = "new"
+  # ...
+}
+
+data "truenas_vm_definition" "web" {
+  provider = truenas.old
+  name     = "web"
+}
+
+# Recreate the VM on the new host. Its zvols were replicated from tank/vms
+# on the old host to fast/vms on the new one beforehand.
+resource "truenas_vm_migration" "web" {
+  provider   = truenas.new
+  definition = data.truenas_vm_definition.web.definition
+
+  zvol_map = {
+    "tank/vms" = "fast/vms"
+  }
+}
@@ -0,0 +1,8 @@
+# Serve WebDAV over HTTPS only (TrueNAS 24.04 and earlier)
+resource "truenas_webdav_config" "this" {
+  protocol   = "HTTPS"
+  tcpportssl = 8443
+  htauth     = "DIGEST"
+  password   = var.webdav_password
+  certssl    = 1
+}
@@ -0,0 +1,7 @@
+# Share a dataset over WebDAV (TrueNAS 24.04 and earlier)
+resource "truenas_webdav_share" "docs" {
+  name    = "docs"
+  path    = "/mnt/tank/docs"
+  comment = "Shared documents"
+  ro      = true
+}
@@ -0,0 +1,146 @@
+package acctest
+
+// Fixtures seeds a Server's store. Collections are CRUD namespaces keyed by
+// middleware namespace (e.g. "group"); Configs are singleton namespaces
+// served by <namespace>.config and <namespace>.update.
+type Fixtures struct {
+	Collections map[string][]map[string]any
+	Configs     map[string]map[string]any
+}
+
+// LoadFixtures merges f into the store. Collection items are appended and
+// config objects replace any existing config for their namespace.
+func (s *Server) LoadFixtures(f Fixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, items := range f.Collections {
+		c, ok := s.collections[name]
+		if !ok {
+			c = newCollection(name)
+			s.collections[name] = c
+		}
+		for _, item := range items {
+			c.add(item)
+		}
+	}
+	for name, config := range f.Configs {
+		s.configs[name] = copyObject(config)
+	}
+}
+
+// DefaultFixtures returns the state of a freshly installed TrueNAS system:
+// service configs at their defaults and the built-in users and groups.
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		Collections: map[string][]map[string]any{
+			"group": {
+				{"id": 1, "gid": 0, "group": "root", "builtin": true, "users": []any{1}},
+				{"id": 41, "gid": 568, "group": "apps", "builtin": true, "users": []any{}},
+			},
+			"user": {
+				{"id": 1, "uid": 0, "username": "root", "builtin": true, "twofactor_auth_configured": false},
+				{"id": 41, "uid": 568, "username": "apps", "builtin": true, "twofactor_auth_configured": false},
+			},
+			"disk":                {},
+			"vm":                  {},
+			"reporting.exporters": {},
+			"sharing.webdav":      {},
+		},
+		Configs: map[string]map[string]any{
+			"snmp": {
+				"id":           1,
+				"location":     "",
+				"contact":      "",
+				"community":    "public",
+				"traps":        false,
+				"v3":           false,
+				"v3_username":  "",
+				"v3_authtype":  "SHA",
+				"v3_privproto": nil,
+				"loglevel":     3,
+				"options":      "",
+				"zilstat":      false,
+			},
+			"ftp": {
+				"id":                     1,
+				"port":                   21,
+				"clients":                5,
+				"ipconnections":          2,
+				"loginattempt":           1,
+				"timeout":                600,
+				"timeout_notransfer":     300,
+				"onlyanonymous":          false,
+				"anonpath":               nil,
+				"onlylocal":              false,
+				"banner":                 "",
+				"filemask":               "077",
+				"dirmask":                "022",
+				"fxp":                    false,
+				"resume":                 false,
+				"defaultroot":            true,
+				"ident":                  false,
+				"reversedns":             false,
+				"masqaddress":            "",
+				"passiveportsmin":        0,
+				"passiveportsmax":        0,
+				"localuserbw":            0,
+				"localuserdownbandwidth": 0,
+				"anonuserbw":             0,
+				"anonuserdownbandwidth":  0,
+				"tls":                    false,
+				"tls_policy":             "on",
+				"ssltls_certificate":     nil,
+				"options":                "",
+			},
+			"truecommand": {
+				"id":                1,
+				"enabled":           false,
+				"status":            "DISABLED",
+				"status_reason":     "TrueCommand service is disabled.",
+				"remote_url":        nil,
+				"remote_ip_address": nil,
+			},
+			"kmip": {
+				"id":                    1,
+				"enabled":               false,
+				"server":                nil,
+				"port":                  5696,
+				"certificate":           nil,
+				"certificate_authority": nil,
+				"manage_sed_disks":      false,
+				"manage_zfs_keys":       false,
+			},
+			"auth.twofactor": {
+				"id":       1,
+				"enabled":  false,
+				"window":   0,
+				"services": map[string]any{"ssh": false},
+			},
+			"pool.resilver": {
+				"id":      1,
+				"enabled": true,
+				"begin":   "18:00",
+				"end":     "09:00",
+				"weekday": []any{1, 2, 3, 4, 5, 6, 7},
+			},
+			"webdav": {
+				"id":         1,
+				"protocol":   "HTTP",
+				"tcpport":    8080,
+				"tcpportssl": 8081,
+				"password":   "davtest",
+				"htauth":     "DIGEST",
+				"certssl":    nil,
+			},
+			"system.general": {
+				"id":               1,
+				"ui_address":       []any{"0.0.0.0"},
+				"ui_port":          80,
+				"ui_httpsport":     443,
+				"ui_httpsredirect": false,
+				"ui_certificate":   nil,
+			},
+		},
+	}
+}
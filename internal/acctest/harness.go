@@ -0,0 +1,586 @@
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/provider"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// PreCheck skips the test unless TF_ACC is set, matching the convention of
+// Terraform provider acceptance tests.
+func PreCheck(t testing.TB) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+}
+
+// mockFactory connects the provider's WebSocket transport to a Server. The
+// SSH transport is replaced by a mock that only reports the server version.
+type mockFactory struct {
+	server *Server
+}
+
+func (f *mockFactory) NewSSHClient(cfg *client.SSHConfig) (client.Client, error) {
+	return &client.MockClient{VersionVal: f.server.Version()}, nil
+}
+
+func (f *mockFactory) NewWebSocketClient(cfg client.WebSocketConfig) (client.Client, error) {
+	return client.NewWebSocketClient(cfg)
+}
+
+// Harness drives the provider over the Terraform plugin protocol, the same
+// way Terraform core does, against a Server.
+type Harness struct {
+	t      testing.TB
+	ctx    context.Context
+	Server *Server
+
+	provider tfprotov6.ProviderServer
+	schemas  map[string]*tfprotov6.Schema
+}
+
+// NewHarness starts a Server with opts and returns a Harness whose provider
+// is configured to use it.
+func NewHarness(t testing.TB, opts ...Option) *Harness {
+	t.Helper()
+
+	srv := NewServer(t, opts...)
+	h := &Harness{
+		t:      t,
+		ctx:    context.Background(),
+		Server: srv,
+	}
+
+	factory := providerserver.NewProtocol6WithError(provider.NewWithFactory("test", &mockFactory{server: srv})())
+	ps, err := factory()
+	if err != nil {
+		t.Fatalf("creating provider server: %v", err)
+	}
+	h.provider = ps
+
+	schemaResp, err := ps.GetProviderSchema(h.ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	h.checkDiags("GetProviderSchema", schemaResp.Diagnostics)
+	h.schemas = schemaResp.ResourceSchemas
+
+	providerConfig := map[string]any{
+		"host":        srv.Host(),
+		"auth_method": "websocket",
+		"websocket": map[string]any{
+			"username":             "root",
+			"api_key":              "1-acctest",
+			"port":                 srv.Port(),
+			"insecure_skip_verify": true,
+		},
+		// Required by the provider for fallback operations; never dialled.
+		"ssh": map[string]any{
+			"private_key":          "unused",
+			"host_key_fingerprint": "SHA256:unused",
+		},
+	}
+	config := h.dynamicValue(schemaResp.Provider.ValueType(), providerConfig)
+	configureResp, err := ps.ConfigureProvider(h.ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.14.0",
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %v", err)
+	}
+	h.checkDiags("ConfigureProvider", configureResp.Diagnostics)
+
+	return h
+}
+
+// Resource returns a handle for managing a single instance of typeName.
+func (h *Harness) Resource(typeName string) *Resource {
+	h.t.Helper()
+	schema, ok := h.schemas[typeName]
+	if !ok {
+		h.t.Fatalf("provider has no resource %q", typeName)
+	}
+	return &Resource{h: h, typeName: typeName, schema: schema, typ: schema.ValueType()}
+}
+
+// Resource is a single resource instance managed through the harness. It
+// tracks state between steps like a one-resource Terraform workspace.
+type Resource struct {
+	h        *Harness
+	typeName string
+	schema   *tfprotov6.Schema
+	typ      tftypes.Type
+
+	state   tftypes.Value
+	private []byte
+}
+
+// State returns the current state of the resource.
+func (r *Resource) State() *State {
+	return &State{t: r.h.t, value: r.currentState()}
+}
+
+func (r *Resource) currentState() tftypes.Value {
+	if r.state.Type() == nil {
+		return tftypes.NewValue(r.typ, nil)
+	}
+	return r.state
+}
+
+// Apply plans config against the current state and applies the result,
+// replacing the resource when the plan requires it.
+func (r *Resource) Apply(config map[string]any) *State {
+	r.h.t.Helper()
+
+	configValue := r.config(config)
+	planned, requiresReplace, plannedPrivate := r.plan(r.currentState(), configValue)
+
+	if len(requiresReplace) > 0 && !r.currentState().IsNull() {
+		r.Destroy()
+		planned, _, plannedPrivate = r.plan(r.currentState(), configValue)
+	}
+
+	r.apply(planned, configValue, plannedPrivate)
+	return r.State()
+}
+
+// Refresh reads the resource and updates the tracked state.
+func (r *Resource) Refresh() *State {
+	r.h.t.Helper()
+
+	resp, err := r.h.provider.ReadResource(r.h.ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: r.h.dynamicValue(r.typ, r.currentState()),
+		Private:      r.private,
+	})
+	if err != nil {
+		r.h.t.Fatalf("ReadResource(%s): %v", r.typeName, err)
+	}
+	r.h.checkDiags("ReadResource", resp.Diagnostics)
+
+	r.state = r.h.unmarshal(r.typ, resp.NewState)
+	r.private = resp.Private
+	return r.State()
+}
+
+// ExpectNoChanges refreshes the resource and fails the test if planning
+// config would change it, like a second `terraform plan` after apply.
+func (r *Resource) ExpectNoChanges(config map[string]any) {
+	r.h.t.Helper()
+
+	r.Refresh()
+	if r.currentState().IsNull() {
+		r.h.t.Fatalf("%s: resource was removed from state on refresh", r.typeName)
+	}
+
+	planned, requiresReplace, _ := r.plan(r.currentState(), r.config(config))
+	if len(requiresReplace) > 0 {
+		r.h.t.Fatalf("%s: expected no changes, plan requires replacement of %v", r.typeName, requiresReplace)
+	}
+
+	diffs, err := r.currentState().Diff(planned)
+	if err != nil {
+		r.h.t.Fatalf("%s: diffing plan: %v", r.typeName, err)
+	}
+	if len(diffs) > 0 {
+		var lines []string
+		for _, d := range diffs {
+			if d.Value1 == nil || d.Value2 == nil {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s => %s", d.Path, d.Value1, d.Value2))
+		}
+		r.h.t.Fatalf("%s: expected no changes, plan has diffs:\n%s", r.typeName, strings.Join(lines, "\n"))
+	}
+}
+
+// Import imports the resource by ID and reads it, replacing the tracked state.
+func (r *Resource) Import(id string) *State {
+	r.h.t.Helper()
+
+	resp, err := r.h.provider.ImportResourceState(r.h.ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: r.typeName,
+		ID:       id,
+	})
+	if err != nil {
+		r.h.t.Fatalf("ImportResourceState(%s): %v", r.typeName, err)
+	}
+	r.h.checkDiags("ImportResourceState", resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		r.h.t.Fatalf("%s: expected 1 imported resource, got %d", r.typeName, len(resp.ImportedResources))
+	}
+
+	r.state = r.h.unmarshal(r.typ, resp.ImportedResources[0].State)
+	r.private = resp.ImportedResources[0].Private
+	return r.Refresh()
+}
+
+// Destroy deletes the resource.
+func (r *Resource) Destroy() {
+	r.h.t.Helper()
+
+	if r.currentState().IsNull() {
+		return
+	}
+	r.apply(tftypes.NewValue(r.typ, nil), tftypes.NewValue(r.typ, nil), r.private)
+}
+
+func (r *Resource) config(config map[string]any) tftypes.Value {
+	r.h.t.Helper()
+
+	value := r.h.value(r.typ, config)
+	resp, err := r.h.provider.ValidateResourceConfig(r.h.ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.typeName,
+		Config:   r.h.dynamicValue(r.typ, value),
+	})
+	if err != nil {
+		r.h.t.Fatalf("ValidateResourceConfig(%s): %v", r.typeName, err)
+	}
+	r.h.checkDiags("ValidateResourceConfig", resp.Diagnostics)
+	return value
+}
+
+func (r *Resource) plan(prior, config tftypes.Value) (tftypes.Value, []*tftypes.AttributePath, []byte) {
+	r.h.t.Helper()
+
+	resp, err := r.h.provider.PlanResourceChange(r.h.ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.h.dynamicValue(r.typ, prior),
+		ProposedNewState: r.h.dynamicValue(r.typ, proposedNewState(r.schema.Block, prior, config)),
+		Config:           r.h.dynamicValue(r.typ, config),
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		r.h.t.Fatalf("PlanResourceChange(%s): %v", r.typeName, err)
+	}
+	r.h.checkDiags("PlanResourceChange", resp.Diagnostics)
+
+	return r.h.unmarshal(r.typ, resp.PlannedState), resp.RequiresReplace, resp.PlannedPrivate
+}
+
+func (r *Resource) apply(planned, config tftypes.Value, plannedPrivate []byte) {
+	r.h.t.Helper()
+
+	resp, err := r.h.provider.ApplyResourceChange(r.h.ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.h.dynamicValue(r.typ, r.currentState()),
+		PlannedState:   r.h.dynamicValue(r.typ, planned),
+		Config:         r.h.dynamicValue(r.typ, config),
+		PlannedPrivate: plannedPrivate,
+	})
+	if err != nil {
+		r.h.t.Fatalf("ApplyResourceChange(%s): %v", r.typeName, err)
+	}
+	r.h.checkDiags("ApplyResourceChange", resp.Diagnostics)
+
+	r.state = r.h.unmarshal(r.typ, resp.NewState)
+	r.private = resp.Private
+}
+
+// proposedNewState approximates Terraform core's proposed new state: the
+// configuration, with computed attributes left unset in config taking their
+// prior values.
+func proposedNewState(block *tfprotov6.SchemaBlock, prior, config tftypes.Value) tftypes.Value {
+	if config.IsNull() {
+		return config
+	}
+
+	var configAttrs, priorAttrs map[string]tftypes.Value
+	_ = config.As(&configAttrs)
+	if !prior.IsNull() && prior.IsKnown() {
+		_ = prior.As(&priorAttrs)
+	}
+
+	out := make(map[string]tftypes.Value, len(configAttrs))
+	for name, v := range configAttrs {
+		out[name] = v
+	}
+	for _, attr := range block.Attributes {
+		v := configAttrs[attr.Name]
+		if attr.Computed && v.IsNull() {
+			if p, ok := priorAttrs[attr.Name]; ok {
+				out[attr.Name] = p
+			}
+		}
+	}
+	for _, nb := range block.BlockTypes {
+		if nb.Nesting != tfprotov6.SchemaNestedBlockNestingModeSingle {
+			continue
+		}
+		priorBlock, ok := priorAttrs[nb.TypeName]
+		if !ok {
+			priorBlock = tftypes.NewValue(nb.Block.ValueType(), nil)
+		}
+		out[nb.TypeName] = proposedNewState(nb.Block, priorBlock, configAttrs[nb.TypeName])
+	}
+
+	return tftypes.NewValue(config.Type(), out)
+}
+
+func (h *Harness) checkDiags(op string, diags []*tfprotov6.Diagnostic) {
+	h.t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			h.t.Fatalf("%s: %s: %s", op, d.Summary, d.Detail)
+		}
+	}
+}
+
+func (h *Harness) dynamicValue(typ tftypes.Type, v any) *tfprotov6.DynamicValue {
+	h.t.Helper()
+
+	value, ok := v.(tftypes.Value)
+	if !ok {
+		value = h.value(typ, v)
+	}
+	dv, err := tfprotov6.NewDynamicValue(typ, value)
+	if err != nil {
+		h.t.Fatalf("encoding value: %v", err)
+	}
+	return &dv
+}
+
+func (h *Harness) unmarshal(typ tftypes.Type, dv *tfprotov6.DynamicValue) tftypes.Value {
+	h.t.Helper()
+
+	if dv == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	v, err := dv.Unmarshal(typ)
+	if err != nil {
+		h.t.Fatalf("decoding value: %v", err)
+	}
+	return v
+}
+
+func (h *Harness) value(typ tftypes.Type, v any) tftypes.Value {
+	h.t.Helper()
+
+	value, err := toValue(typ, v)
+	if err != nil {
+		h.t.Fatalf("converting config: %v", err)
+	}
+	return value
+}
+
+// toValue converts a Go value built from maps, slices and scalars into a
+// tftypes.Value of typ. Object attributes absent from the map are null.
+func toValue(typ tftypes.Type, v any) (tftypes.Value, error) {
+	if v == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		s, ok := v.(string)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected string, got %T", v)
+		}
+		return tftypes.NewValue(typ, s), nil
+	case typ.Is(tftypes.Bool):
+		b, ok := v.(bool)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected bool, got %T", v)
+		}
+		return tftypes.NewValue(typ, b), nil
+	case typ.Is(tftypes.Number):
+		switch n := v.(type) {
+		case int:
+			return tftypes.NewValue(typ, big.NewFloat(float64(n))), nil
+		case int64:
+			return tftypes.NewValue(typ, new(big.Float).SetInt64(n)), nil
+		case float64:
+			return tftypes.NewValue(typ, big.NewFloat(n)), nil
+		}
+		return tftypes.Value{}, fmt.Errorf("expected number, got %T", v)
+	}
+
+	switch t := typ.(type) {
+	case tftypes.List, tftypes.Set:
+		var elemType tftypes.Type
+		if l, ok := t.(tftypes.List); ok {
+			elemType = l.ElementType
+		} else {
+			elemType = t.(tftypes.Set).ElementType
+		}
+		items, err := toSlice(v)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		elems := make([]tftypes.Value, 0, len(items))
+		for _, item := range items {
+			e, err := toValue(elemType, item)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems = append(elems, e)
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case tftypes.Map:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected map[string]any, got %T", v)
+		}
+		elems := make(map[string]tftypes.Value, len(m))
+		for k, item := range m {
+			e, err := toValue(t.ElementType, item)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", k, err)
+			}
+			elems[k] = e
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case tftypes.Object:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected map[string]any, got %T", v)
+		}
+		attrs := make(map[string]tftypes.Value, len(t.AttributeTypes))
+		for name, attrType := range t.AttributeTypes {
+			a, err := toValue(attrType, m[name])
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			attrs[name] = a
+		}
+		for name := range m {
+			if _, ok := t.AttributeTypes[name]; !ok {
+				return tftypes.Value{}, fmt.Errorf("unknown attribute %q", name)
+			}
+		}
+		return tftypes.NewValue(typ, attrs), nil
+	}
+
+	return tftypes.Value{}, fmt.Errorf("unsupported type %s", typ)
+}
+
+func toSlice(v any) ([]any, error) {
+	switch s := v.(type) {
+	case []any:
+		return s, nil
+	case []string:
+		out := make([]any, len(s))
+		for i, e := range s {
+			out[i] = e
+		}
+		return out, nil
+	case []int:
+		out := make([]any, len(s))
+		for i, e := range s {
+			out[i] = e
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("expected a slice, got %T", v)
+}
+
+// State is a snapshot of a resource's state.
+type State struct {
+	t     testing.TB
+	value tftypes.Value
+}
+
+// IsNull reports whether the resource is absent.
+func (s *State) IsNull() bool {
+	return s.value.IsNull()
+}
+
+// Get returns the attribute at a dotted path (e.g. "attributes.prefix") as
+// a Go value: string, bool, int64 or float64, []any, map[string]any or nil.
+func (s *State) Get(path string) any {
+	s.t.Helper()
+
+	v := s.value
+	for _, part := range strings.Split(path, ".") {
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil || attrs == nil {
+			s.t.Fatalf("state path %q: %s is not an object", path, part)
+		}
+		next, ok := attrs[part]
+		if !ok {
+			s.t.Fatalf("state path %q: no attribute %q", path, part)
+		}
+		v = next
+	}
+
+	out, err := fromValue(v)
+	if err != nil {
+		s.t.Fatalf("state path %q: %v", path, err)
+	}
+	return out
+}
+
+func fromValue(v tftypes.Value) (any, error) {
+	if !v.IsKnown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return nil, err
+		}
+		if n.IsInt() {
+			i, _ := n.Int64()
+			return i, nil
+		}
+		f, _ := n.Float64()
+		return f, nil
+	}
+
+	switch typ.(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(elems))
+		for _, e := range elems {
+			ev, err := fromValue(e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ev)
+		}
+		return out, nil
+	case tftypes.Map, tftypes.Object:
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		out := make(map[string]any, len(elems))
+		for k, e := range elems {
+			ev, err := fromValue(e)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = ev
+		}
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
@@ -0,0 +1,391 @@
+// Package acctest provides an in-memory TrueNAS middleware and a Terraform
+// protocol harness for end-to-end tests of the provider without hardware.
+//
+// Server speaks the same JSON-RPC 2.0 over WebSocket protocol as TrueNAS
+// 25.0+ at /api/current. Generic CRUD namespaces (create, query,
+// get_instance, update, delete) and singleton config namespaces (config,
+// update) are served from an in-memory store, so most resources work without
+// any setup beyond the fixtures in DefaultFixtures. Resources whose responses
+// need a specific shape can install a handler with Handle or HandleJob.
+package acctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultVersion is the TrueNAS version reported by a Server unless
+// overridden with WithVersion. WebSocket transport requires 25.0+.
+var DefaultVersion = truenas.Version{Major: 25, Minor: 4, Patch: 0, Build: 0, Flavor: truenas.FlavorCommunity, Raw: "25.04.0"}
+
+// HandlerFunc handles a middleware method. params holds the positional
+// JSON-RPC arguments. The returned value is marshalled as the result;
+// return an *Error to produce a middleware error.
+type HandlerFunc func(params []json.RawMessage) (any, error)
+
+// Call records a method invocation received by the server.
+type Call struct {
+	Method string
+	Params []json.RawMessage
+}
+
+// Error is a middleware error returned to the client as a JSON-RPC error.
+type Error struct {
+	// Errno is the symbolic errno, e.g. "ENOENT" or "EINVAL".
+	Errno   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Errno, e.Message)
+}
+
+// NotFound returns an ENOENT error, which the provider treats as a missing object.
+func NotFound(format string, args ...any) *Error {
+	return &Error{Errno: "ENOENT", Message: fmt.Sprintf(format, args...)}
+}
+
+// Invalid returns an EINVAL validation error.
+func Invalid(format string, args ...any) *Error {
+	return &Error{Errno: "EINVAL", Message: fmt.Sprintf(format, args...)}
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithVersion sets the TrueNAS version reported by the server.
+func WithVersion(v truenas.Version) Option {
+	return func(s *Server) {
+		s.version = v
+	}
+}
+
+// WithFixtures loads fixtures into the server's store.
+func WithFixtures(f Fixtures) Option {
+	return func(s *Server) {
+		s.LoadFixtures(f)
+	}
+}
+
+// Server is an in-memory TrueNAS middleware.
+type Server struct {
+	version  truenas.Version
+	http     *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	configs     map[string]map[string]any
+	handlers    map[string]HandlerFunc
+	jobMethods  map[string]bool
+	calls       []Call
+	nextJobID   int64
+	conns       map[*websocket.Conn]bool
+}
+
+// NewServer starts a Server on a local TLS listener and stops it when the test ends.
+// DefaultFixtures are loaded before any options are applied.
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	s := &Server{
+		version:     DefaultVersion,
+		collections: make(map[string]*collection),
+		configs:     make(map[string]map[string]any),
+		handlers:    make(map[string]HandlerFunc),
+		jobMethods:  make(map[string]bool),
+		conns:       make(map[*websocket.Conn]bool),
+	}
+	s.LoadFixtures(DefaultFixtures())
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/current", s.serveWebSocket)
+	s.http = httptest.NewTLSServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Close disconnects all clients and stops the server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+	s.http.Close()
+}
+
+// Host returns the host the server listens on.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.http.Listener.Addr().String())
+	return host
+}
+
+// Port returns the port the server listens on.
+func (s *Server) Port() int {
+	_, port, _ := net.SplitHostPort(s.http.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return p
+}
+
+// Version returns the TrueNAS version reported by the server.
+func (s *Server) Version() truenas.Version {
+	return s.version
+}
+
+// Handle installs a handler for method, taking precedence over the generic store.
+func (s *Server) Handle(method string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = h
+}
+
+// HandleJob installs a handler for a method that TrueNAS runs as a job. The
+// client receives a job ID and the handler's result arrives as a job event.
+func (s *Server) HandleJob(method string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = h
+	s.jobMethods[method] = true
+}
+
+// RunAsJob makes a method served by the generic store behave as a job.
+func (s *Server) RunAsJob(methods ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range methods {
+		s.jobMethods[m] = true
+	}
+}
+
+// Calls returns the recorded invocations of method, or all calls if method is empty.
+func (s *Server) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var calls []Call
+	for _, c := range s.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// rpcRequest is a JSON-RPC 2.0 request.
+type rpcRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	ID     string            `json:"id"`
+}
+
+// rpcError is a JSON-RPC 2.0 error in the shape TrueNAS returns.
+type rpcError struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    *rpcErrorData `json:"data,omitempty"`
+}
+
+type rpcErrorData struct {
+	Reason string `json:"reason"`
+	Error  int    `json:"error"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	JSONRPC string    `json:"jsonrpc"`
+	Result  any       `json:"result"`
+	Error   *rpcError `json:"error,omitempty"`
+	ID      string    `json:"id"`
+}
+
+// errMethodNotFound is returned for methods neither handled nor served by the store.
+type errMethodNotFound string
+
+func (e errMethodNotFound) Error() string {
+	return fmt.Sprintf("Method %q not found", string(e))
+}
+
+// connWriter serializes writes to a WebSocket connection.
+type connWriter struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (w *connWriter) write(v any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.WriteJSON(v)
+}
+
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.conns[conn] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	out := &connWriter{conn: conn}
+	for {
+		var req rpcRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		s.handleRequest(out, req)
+	}
+}
+
+func (s *Server) handleRequest(out *connWriter, req rpcRequest) {
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: req.Method, Params: req.Params})
+	handler, hasHandler := s.handlers[req.Method]
+	isJob := s.jobMethods[req.Method]
+	s.mu.Unlock()
+
+	var result any
+	var err error
+	if hasHandler {
+		result, err = handler(req.Params)
+	} else {
+		result, err = s.dispatch(req.Method, req.Params)
+	}
+
+	if !isJob {
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+		if err != nil {
+			resp.Result = nil
+			resp.Error = toRPCError(err)
+		}
+		_ = out.write(resp)
+		return
+	}
+
+	// Jobs: reply with the job ID, then push the terminal state as a
+	// core.get_jobs collection_update event.
+	s.mu.Lock()
+	s.nextJobID++
+	jobID := s.nextJobID
+	s.mu.Unlock()
+
+	_ = out.write(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: jobID})
+
+	fields := map[string]any{"state": "SUCCESS", "result": result}
+	if err != nil {
+		fields = map[string]any{"state": "FAILED", "result": nil, "error": err.Error()}
+	}
+	_ = out.write(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "collection_update",
+		"params": map[string]any{
+			"msg":        "changed",
+			"collection": "core.get_jobs",
+			"id":         jobID,
+			"fields":     fields,
+		},
+	})
+}
+
+func toRPCError(err error) *rpcError {
+	switch e := err.(type) {
+	case errMethodNotFound:
+		return &rpcError{Code: -32601, Message: e.Error()}
+	case *Error:
+		return &rpcError{
+			Code:    -32001,
+			Message: "Method call error",
+			Data:    &rpcErrorData{Reason: e.Error()},
+		}
+	default:
+		return &rpcError{
+			Code:    -32001,
+			Message: "Method call error",
+			Data:    &rpcErrorData{Reason: err.Error()},
+		}
+	}
+}
+
+// dispatch serves built-in methods and the generic store.
+func (s *Server) dispatch(method string, params []json.RawMessage) (any, error) {
+	switch method {
+	case "auth.login_ex":
+		return map[string]any{"response_type": "SUCCESS"}, nil
+	case "core.subscribe", "core.unsubscribe":
+		return nil, nil
+	case "core.ping":
+		return "pong", nil
+	case "system.version":
+		return "TrueNAS-SCALE-" + s.version.Raw, nil
+	case "core.get_jobs":
+		return []any{}, nil
+	}
+
+	i := strings.LastIndex(method, ".")
+	if i < 0 {
+		return nil, errMethodNotFound(method)
+	}
+	namespace, op := method[:i], method[i+1:]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if config, ok := s.configs[namespace]; ok {
+		switch op {
+		case "config":
+			return config, nil
+		case "update":
+			var patch map[string]any
+			if len(params) == 0 || json.Unmarshal(params[0], &patch) != nil {
+				return nil, Invalid("%s: expected a single object argument", method)
+			}
+			for k, v := range patch {
+				config[k] = v
+			}
+			return config, nil
+		}
+	}
+
+	c, ok := s.collections[namespace]
+	if !ok {
+		return nil, errMethodNotFound(method)
+	}
+
+	switch op {
+	case "create":
+		return c.create(params)
+	case "query":
+		return c.query(params)
+	case "get_instance":
+		return c.getInstance(params)
+	case "update":
+		return c.update(params)
+	case "delete":
+		return c.delete(params)
+	}
+
+	return nil, errMethodNotFound(method)
+}
@@ -0,0 +1,172 @@
+package acctest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+func connectClient(t *testing.T, s *Server) client.Client {
+	t.Helper()
+
+	c, err := client.NewWebSocketClient(client.WebSocketConfig{
+		Host:               s.Host(),
+		Port:               s.Port(),
+		Username:           "root",
+		APIKey:             "1-acctest",
+		InsecureSkipVerify: true,
+		Fallback:           &client.MockClient{VersionVal: s.Version()},
+	})
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connecting: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestServer_Config(t *testing.T) {
+	s := NewServer(t)
+	c := connectClient(t, s)
+	ctx := context.Background()
+
+	if _, err := c.Call(ctx, "snmp.update", map[string]any{"location": "rack 1"}); err != nil {
+		t.Fatalf("snmp.update: %v", err)
+	}
+
+	result, err := c.Call(ctx, "snmp.config", nil)
+	if err != nil {
+		t.Fatalf("snmp.config: %v", err)
+	}
+
+	var config struct {
+		Location  string `json:"location"`
+		Community string `json:"community"`
+	}
+	if err := json.Unmarshal(result, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Location != "rack 1" {
+		t.Errorf("expected location 'rack 1', got %q", config.Location)
+	}
+	if config.Community != "public" {
+		t.Errorf("expected default community 'public', got %q", config.Community)
+	}
+}
+
+func TestServer_CRUD(t *testing.T) {
+	s := NewServer(t)
+	c := connectClient(t, s)
+	ctx := context.Background()
+
+	result, err := c.Call(ctx, "reporting.exporters.create", map[string]any{"name": "graphite", "enabled": true})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(result, &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 {
+		t.Fatal("expected a non-zero id")
+	}
+
+	if _, err := c.Call(ctx, "reporting.exporters.update", []any{created.ID, map[string]any{"enabled": false}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	result, err = c.Call(ctx, "reporting.exporters.query", []any{[]any{[]any{"id", "=", created.ID}}})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	var items []struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	}
+	if err := json.Unmarshal(result, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].Name != "graphite" || items[0].Enabled {
+		t.Errorf("unexpected query result: %+v", items)
+	}
+
+	if _, err := c.Call(ctx, "reporting.exporters.delete", created.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	_, err = c.Call(ctx, "reporting.exporters.get_instance", created.ID)
+	if err == nil {
+		t.Fatal("expected error for deleted object")
+	}
+	if !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected not-found error, got %q", err.Error())
+	}
+}
+
+func TestServer_QueryGet(t *testing.T) {
+	s := NewServer(t)
+	c := connectClient(t, s)
+
+	result, err := c.Call(context.Background(), "group.query", []any{
+		[]any{[]any{"group", "=", "apps"}},
+		map[string]any{"get": true},
+	})
+	if err != nil {
+		t.Fatalf("group.query: %v", err)
+	}
+
+	var group struct {
+		GID int64 `json:"gid"`
+	}
+	if err := json.Unmarshal(result, &group); err != nil {
+		t.Fatal(err)
+	}
+	if group.GID != 568 {
+		t.Errorf("expected gid 568, got %d", group.GID)
+	}
+}
+
+func TestServer_HandleJob(t *testing.T) {
+	s := NewServer(t)
+	s.HandleJob("pool.scrub.run", func(params []json.RawMessage) (any, error) {
+		return "done", nil
+	})
+	s.HandleJob("pool.scrub.fail", func(params []json.RawMessage) (any, error) {
+		return nil, Invalid("pool is busy")
+	})
+	c := connectClient(t, s)
+	ctx := context.Background()
+
+	result, err := c.CallAndWait(ctx, "pool.scrub.run", "tank")
+	if err != nil {
+		t.Fatalf("CallAndWait: %v", err)
+	}
+	if string(result) != `"done"` {
+		t.Errorf("expected job result \"done\", got %s", result)
+	}
+
+	_, err = c.CallAndWait(ctx, "pool.scrub.fail", "tank")
+	if err == nil || !strings.Contains(err.Error(), "pool is busy") {
+		t.Errorf("expected job failure, got %v", err)
+	}
+
+	if calls := s.Calls("pool.scrub.run"); len(calls) != 1 {
+		t.Errorf("expected 1 recorded call, got %d", len(calls))
+	}
+}
+
+func TestServer_UnknownMethod(t *testing.T) {
+	s := NewServer(t)
+	c := connectClient(t, s)
+
+	if _, err := c.Call(context.Background(), "bogus.method", nil); err == nil {
+		t.Fatal("expected error for unknown method")
+	}
+}
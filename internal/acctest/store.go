@@ -0,0 +1,216 @@
+package acctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// collection is an in-memory CRUD namespace such as "group" or
+// "reporting.exporters". Items are kept as decoded JSON objects keyed by
+// their "id" field, which is assigned sequentially on create when absent.
+type collection struct {
+	name   string
+	items  []map[string]any
+	nextID float64
+}
+
+func newCollection(name string) *collection {
+	return &collection{name: name, nextID: 1}
+}
+
+func (c *collection) add(item map[string]any) map[string]any {
+	item = copyObject(item)
+	if _, ok := item["id"]; !ok {
+		item["id"] = c.nextID
+	}
+	if id, ok := item["id"].(float64); ok && id >= c.nextID {
+		c.nextID = id + 1
+	}
+	c.items = append(c.items, item)
+	return item
+}
+
+func (c *collection) find(id any) (int, bool) {
+	for i, item := range c.items {
+		if valuesEqual(item["id"], id) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (c *collection) notFound(id any) *Error {
+	return NotFound("%s %v does not exist", c.name, id)
+}
+
+func (c *collection) create(params []json.RawMessage) (any, error) {
+	var item map[string]any
+	if len(params) == 0 || json.Unmarshal(params[0], &item) != nil {
+		return nil, Invalid("%s.create: expected an object argument", c.name)
+	}
+	return c.add(item), nil
+}
+
+func (c *collection) query(params []json.RawMessage) (any, error) {
+	var filters [][]any
+	if len(params) > 0 {
+		if err := json.Unmarshal(params[0], &filters); err != nil {
+			return nil, Invalid("%s.query: invalid filters: %v", c.name, err)
+		}
+	}
+	var options struct {
+		Get bool `json:"get"`
+	}
+	if len(params) > 1 {
+		_ = json.Unmarshal(params[1], &options)
+	}
+
+	matches := []any{}
+	for _, item := range c.items {
+		ok, err := matchFilters(item, filters)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, item)
+		}
+	}
+
+	if options.Get {
+		if len(matches) == 0 {
+			return nil, NotFound("%s: no object matches the query", c.name)
+		}
+		return matches[0], nil
+	}
+	return matches, nil
+}
+
+func (c *collection) getInstance(params []json.RawMessage) (any, error) {
+	id, err := idParam(c.name+".get_instance", params)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := c.find(id)
+	if !ok {
+		return nil, c.notFound(id)
+	}
+	return c.items[i], nil
+}
+
+func (c *collection) update(params []json.RawMessage) (any, error) {
+	id, err := idParam(c.name+".update", params)
+	if err != nil {
+		return nil, err
+	}
+	var patch map[string]any
+	if len(params) < 2 || json.Unmarshal(params[1], &patch) != nil {
+		return nil, Invalid("%s.update: expected an object as the second argument", c.name)
+	}
+	i, ok := c.find(id)
+	if !ok {
+		return nil, c.notFound(id)
+	}
+	for k, v := range patch {
+		if k == "id" {
+			continue
+		}
+		c.items[i][k] = v
+	}
+	return c.items[i], nil
+}
+
+func (c *collection) delete(params []json.RawMessage) (any, error) {
+	id, err := idParam(c.name+".delete", params)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := c.find(id)
+	if !ok {
+		return nil, c.notFound(id)
+	}
+	c.items = append(c.items[:i], c.items[i+1:]...)
+	return true, nil
+}
+
+func idParam(method string, params []json.RawMessage) (any, error) {
+	var id any
+	if len(params) == 0 || json.Unmarshal(params[0], &id) != nil || id == nil {
+		return nil, Invalid("%s: expected an id as the first argument", method)
+	}
+	return id, nil
+}
+
+// matchFilters reports whether item satisfies every query filter. Only the
+// operators the provider uses are supported.
+func matchFilters(item map[string]any, filters [][]any) (bool, error) {
+	for _, f := range filters {
+		if len(f) != 3 {
+			return false, Invalid("unsupported query filter %v", f)
+		}
+		field, _ := f[0].(string)
+		op, _ := f[1].(string)
+		got := lookupField(item, field)
+
+		var ok bool
+		switch op {
+		case "=":
+			ok = valuesEqual(got, f[2])
+		case "!=":
+			ok = !valuesEqual(got, f[2])
+		case "in":
+			values, _ := f[2].([]any)
+			for _, v := range values {
+				if valuesEqual(got, v) {
+					ok = true
+					break
+				}
+			}
+		case "^":
+			s, _ := got.(string)
+			prefix, _ := f[2].(string)
+			ok = strings.HasPrefix(s, prefix)
+		default:
+			return false, Invalid("unsupported query operator %q", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// lookupField resolves dotted field paths such as "attributes.exporter_type".
+func lookupField(item map[string]any, field string) any {
+	var v any = item
+	for _, part := range strings.Split(field, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[part]
+	}
+	return v
+}
+
+func valuesEqual(a, b any) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	// IDs may arrive as numbers or strings depending on the caller.
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// copyObject deep-copies a JSON object so fixtures are never shared between servers.
+func copyObject(m map[string]any) map[string]any {
+	data, err := json.Marshal(m)
+	if err != nil {
+		panic(fmt.Sprintf("acctest: fixture is not JSON-serializable: %v", err))
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(fmt.Sprintf("acctest: fixture is not a JSON object: %v", err))
+	}
+	return out
+}
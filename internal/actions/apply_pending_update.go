@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.Action = &ApplyPendingUpdateAction{}
+var _ action.ActionWithConfigure = &ApplyPendingUpdateAction{}
+
+// updateStatusAvailable is the update.check_available status when the
+// selected train has a newer version.
+const updateStatusAvailable = "AVAILABLE"
+
+// ApplyPendingUpdateAction defines the action implementation.
+type ApplyPendingUpdateAction struct {
+	services *services.TrueNASServices
+}
+
+// ApplyPendingUpdateActionModel describes the action data model.
+type ApplyPendingUpdateActionModel struct {
+	Reboot types.Bool `tfsdk:"reboot"`
+}
+
+// updateCheckResponse is the subset of update.check_available used here.
+type updateCheckResponse struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+// NewApplyPendingUpdateAction creates a new ApplyPendingUpdateAction.
+func NewApplyPendingUpdateAction() action.Action {
+	return &ApplyPendingUpdateAction{}
+}
+
+func (a *ApplyPendingUpdateAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apply_pending_update"
+}
+
+func (a *ApplyPendingUpdateAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Applies the update available on the selected train (update.update) and waits for it to " +
+			"finish. Does nothing when the system is up to date. The new version only runs after a reboot; set " +
+			"reboot, and wait_for_system_ready on the provider so later runs wait for the system to come back. " +
+			"Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"reboot": schema.BoolAttribute{
+				Description: "Reboot into the new version once the update is applied. Defaults to false.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *ApplyPendingUpdateAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.services = s
+}
+
+func (a *ApplyPendingUpdateAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data ApplyPendingUpdateActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := a.services.Client.Call(ctx, "update.check_available", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Check for Updates",
+			fmt.Sprintf("Unable to check for available updates: %s", err.Error()),
+		)
+		return
+	}
+
+	var check updateCheckResponse
+	if err := json.Unmarshal(result, &check); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse update check response: %s", err.Error()),
+		)
+		return
+	}
+
+	if check.Status != updateStatusAvailable {
+		if resp.SendProgress != nil {
+			resp.SendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("No update to apply (status: %s)", check.Status),
+			})
+		}
+		return
+	}
+
+	reboot := data.Reboot.ValueBool()
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Applying update %s", check.Version),
+		})
+	}
+
+	// The middleware schedules the reboot itself once the job succeeds, so
+	// the job result is returned before the connection drops.
+	if _, err := a.services.Client.CallAndWait(ctx, "update.update", []any{map[string]any{"reboot": reboot}}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Apply Update",
+			fmt.Sprintf("Unable to apply update %s: %s", check.Version, err.Error()),
+		)
+		return
+	}
+
+	if resp.SendProgress != nil {
+		message := fmt.Sprintf("Applied update %s; reboot to activate it", check.Version)
+		if reboot {
+			message = fmt.Sprintf("Applied update %s; the system is rebooting", check.Version)
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: message})
+	}
+}
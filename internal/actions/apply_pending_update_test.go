@@ -0,0 +1,244 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewApplyPendingUpdateAction(t *testing.T) {
+	a := NewApplyPendingUpdateAction()
+	if a == nil {
+		t.Fatal("expected non-nil action")
+	}
+
+	// Verify it implements the required interfaces
+	var _ action.ActionWithConfigure = a.(*ApplyPendingUpdateAction)
+}
+
+func TestApplyPendingUpdateAction_Metadata(t *testing.T) {
+	a := NewApplyPendingUpdateAction()
+
+	req := action.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &action.MetadataResponse{}
+
+	a.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_apply_pending_update" {
+		t.Errorf("expected TypeName 'truenas_apply_pending_update', got %q", resp.TypeName)
+	}
+}
+
+func TestApplyPendingUpdateAction_Schema(t *testing.T) {
+	a := NewApplyPendingUpdateAction()
+
+	resp := &action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attr, ok := resp.Schema.Attributes["reboot"]
+	if !ok {
+		t.Fatal("expected 'reboot' attribute")
+	}
+	if !attr.IsOptional() {
+		t.Error("expected 'reboot' to be optional")
+	}
+}
+
+func TestApplyPendingUpdateAction_Configure_WrongType(t *testing.T) {
+	a := NewApplyPendingUpdateAction().(*ApplyPendingUpdateAction)
+
+	resp := &action.ConfigureResponse{}
+	a.Configure(context.Background(), action.ConfigureRequest{ProviderData: "not services"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+func createApplyPendingUpdateConfig(t *testing.T, reboot interface{}) tfsdk.Config {
+	t.Helper()
+
+	a := NewApplyPendingUpdateAction()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, schemaResp)
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"reboot": tftypes.Bool,
+		},
+	}, map[string]tftypes.Value{
+		"reboot": tftypes.NewValue(tftypes.Bool, reboot),
+	})
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    configValue,
+	}
+}
+
+func TestApplyPendingUpdateAction_Invoke_AppliesAndReboots(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+	a := &ApplyPendingUpdateAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					if method != "update.check_available" {
+						t.Errorf("expected method 'update.check_available', got %q", method)
+					}
+					return json.RawMessage(`{"status": "AVAILABLE", "version": "25.04.1"}`), nil
+				},
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					capturedParams = params
+					return json.RawMessage(`true`), nil
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createApplyPendingUpdateConfig(t, true)}
+
+	var progress []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			progress = append(progress, event.Message)
+		},
+	}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "update.update" {
+		t.Errorf("expected method 'update.update', got %q", capturedMethod)
+	}
+	args, ok := capturedParams.([]any)
+	if !ok || len(args) != 1 {
+		t.Fatalf("expected [params], got %#v", capturedParams)
+	}
+	if params := args[0].(map[string]any); params["reboot"] != true {
+		t.Errorf("expected reboot true, got %v", params["reboot"])
+	}
+
+	if len(progress) != 2 {
+		t.Fatalf("expected 2 progress events, got %v", progress)
+	}
+	if progress[1] != "Applied update 25.04.1; the system is rebooting" {
+		t.Errorf("unexpected final progress message %q", progress[1])
+	}
+}
+
+func TestApplyPendingUpdateAction_Invoke_NoUpdate(t *testing.T) {
+	a := &ApplyPendingUpdateAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`{"status": "UNAVAILABLE"}`), nil
+				},
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					t.Errorf("expected no job, got %q", method)
+					return nil, nil
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createApplyPendingUpdateConfig(t, nil)}
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestApplyPendingUpdateAction_Invoke_DefaultsToNoReboot(t *testing.T) {
+	var capturedParams map[string]any
+	a := &ApplyPendingUpdateAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`{"status": "AVAILABLE", "version": "25.04.1"}`), nil
+				},
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedParams = params.([]any)[0].(map[string]any)
+					return json.RawMessage(`true`), nil
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createApplyPendingUpdateConfig(t, nil)}
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["reboot"] != false {
+		t.Errorf("expected reboot false, got %v", capturedParams["reboot"])
+	}
+}
+
+func TestApplyPendingUpdateAction_Invoke_CheckError(t *testing.T) {
+	a := &ApplyPendingUpdateAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("update server unreachable")
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createApplyPendingUpdateConfig(t, nil)}
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for failed update check")
+	}
+}
+
+func TestApplyPendingUpdateAction_Invoke_JobError(t *testing.T) {
+	a := &ApplyPendingUpdateAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`{"status": "AVAILABLE", "version": "25.04.1"}`), nil
+				},
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("insufficient space on boot pool")
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createApplyPendingUpdateConfig(t, true)}
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for failed update job")
+	}
+}
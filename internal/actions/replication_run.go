@@ -0,0 +1,268 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.Action = &ReplicationRunAction{}
+var _ action.ActionWithConfigure = &ReplicationRunAction{}
+var _ action.ActionWithValidateConfig = &ReplicationRunAction{}
+
+const (
+	replicationDirectionPush  = "PUSH"
+	replicationDirectionPull  = "PULL"
+	replicationTransportLocal = "LOCAL"
+)
+
+// ReplicationRunAction defines the action implementation.
+type ReplicationRunAction struct {
+	services *services.TrueNASServices
+}
+
+// ReplicationRunActionModel describes the action data model.
+type ReplicationRunActionModel struct {
+	SourceDatasets   types.List   `tfsdk:"source_datasets"`
+	TargetDataset    types.String `tfsdk:"target_dataset"`
+	Direction        types.String `tfsdk:"direction"`
+	Transport        types.String `tfsdk:"transport"`
+	SSHCredentials   types.Int64  `tfsdk:"ssh_credentials"`
+	Recursive        types.Bool   `tfsdk:"recursive"`
+	NamingSchema     types.List   `tfsdk:"naming_schema"`
+	NameRegex        types.String `tfsdk:"name_regex"`
+	RetentionPolicy  types.String `tfsdk:"retention_policy"`
+	AllowFromScratch types.Bool   `tfsdk:"allow_from_scratch"`
+}
+
+// NewReplicationRunAction creates a new ReplicationRunAction.
+func NewReplicationRunAction() action.Action {
+	return &ReplicationRunAction{}
+}
+
+func (a *ReplicationRunAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_replication_run"
+}
+
+func (a *ReplicationRunAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a one-time ZFS replication (replication.run_onetime) and waits for it to finish, without " +
+			"creating a replication task. Useful for taking a backup before a risky change. " +
+			"Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"source_datasets": schema.ListAttribute{
+				Description: "Datasets to replicate (e.g. ['tank/data']).",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"target_dataset": schema.StringAttribute{
+				Description: "Dataset to replicate into (e.g. 'backup/data').",
+				Required:    true,
+			},
+			"direction": schema.StringAttribute{
+				Description: "Replication direction: 'PUSH' or 'PULL'. Defaults to 'PUSH'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(replicationDirectionPush, replicationDirectionPull),
+				},
+			},
+			"transport": schema.StringAttribute{
+				Description: "Transport: 'LOCAL' (another pool on this system), 'SSH' or 'SSH+NETCAT'. Defaults to 'LOCAL'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(replicationTransportLocal, "SSH", "SSH+NETCAT"),
+				},
+			},
+			"ssh_credentials": schema.Int64Attribute{
+				Description: "ID of the SSH keychain credential used to reach the remote system. Required for SSH transports.",
+				Optional:    true,
+			},
+			"recursive": schema.BoolAttribute{
+				Description: "Also replicate child datasets. Defaults to false.",
+				Optional:    true,
+			},
+			"naming_schema": schema.ListAttribute{
+				Description: "strftime patterns of the snapshot names to replicate (e.g. 'auto-%Y-%m-%d_%H-%M'). " +
+					"Sent as also_include_naming_schema for PUSH and naming_schema for PULL. Conflicts with name_regex.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Regular expression matching the names of the snapshots to replicate. Conflicts with naming_schema.",
+				Optional:    true,
+			},
+			"retention_policy": schema.StringAttribute{
+				Description: "How snapshots on the target are pruned: 'SOURCE' (mirror the source) or 'NONE'. Defaults to 'NONE'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("SOURCE", "NONE"),
+				},
+			},
+			"allow_from_scratch": schema.BoolAttribute{
+				Description: "Destroy the target's snapshots and replicate from scratch when the source and target share no common snapshot. Defaults to false.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *ReplicationRunAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.services = s
+}
+
+func (a *ReplicationRunAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var data ReplicationRunActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip checks on unknown values; the middleware validates again on invoke.
+	if !data.Transport.IsUnknown() && !data.SSHCredentials.IsUnknown() {
+		local := replicationTransport(&data) == replicationTransportLocal
+		if local && !data.SSHCredentials.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_credentials"),
+				"Invalid Replication Configuration",
+				"ssh_credentials cannot be used with the LOCAL transport.",
+			)
+		}
+		if !local && data.SSHCredentials.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_credentials"),
+				"Invalid Replication Configuration",
+				fmt.Sprintf("ssh_credentials is required for the %s transport.", data.Transport.ValueString()),
+			)
+		}
+	}
+
+	if data.NamingSchema.IsUnknown() || data.NameRegex.IsUnknown() {
+		return
+	}
+	hasSchema := !data.NamingSchema.IsNull() && len(data.NamingSchema.Elements()) > 0
+	hasRegex := !data.NameRegex.IsNull()
+	if hasSchema == hasRegex {
+		resp.Diagnostics.AddError(
+			"Invalid Replication Configuration",
+			"Exactly one of naming_schema or name_regex must be set to select the snapshots to replicate.",
+		)
+	}
+}
+
+func (a *ReplicationRunAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data ReplicationRunActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := buildReplicationRunParams(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources := strings.Join(params["source_datasets"].([]string), ", ")
+	target := data.TargetDataset.ValueString()
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Replicating %s to %s", sources, target),
+		})
+	}
+
+	if _, err := a.services.Client.CallAndWait(ctx, "replication.run_onetime", []any{params}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Run Replication",
+			fmt.Sprintf("Unable to replicate %s to %q: %s", sources, target, err.Error()),
+		)
+		return
+	}
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Replicated %s to %s", sources, target),
+		})
+	}
+}
+
+// replicationTransport returns the configured transport or the LOCAL default.
+func replicationTransport(data *ReplicationRunActionModel) string {
+	if data.Transport.IsNull() {
+		return replicationTransportLocal
+	}
+	return data.Transport.ValueString()
+}
+
+// buildReplicationRunParams builds the replication.run_onetime payload; unset options use the documented defaults.
+func buildReplicationRunParams(ctx context.Context, data *ReplicationRunActionModel) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var sources []string
+	diags.Append(data.SourceDatasets.ElementsAs(ctx, &sources, false)...)
+
+	direction := replicationDirectionPush
+	if !data.Direction.IsNull() {
+		direction = data.Direction.ValueString()
+	}
+	retention := "NONE"
+	if !data.RetentionPolicy.IsNull() {
+		retention = data.RetentionPolicy.ValueString()
+	}
+
+	params := map[string]any{
+		"direction":          direction,
+		"transport":          replicationTransport(data),
+		"source_datasets":    sources,
+		"target_dataset":     data.TargetDataset.ValueString(),
+		"recursive":          data.Recursive.ValueBool(),
+		"retention_policy":   retention,
+		"allow_from_scratch": data.AllowFromScratch.ValueBool(),
+	}
+
+	if !data.SSHCredentials.IsNull() {
+		params["ssh_credentials"] = data.SSHCredentials.ValueInt64()
+	}
+	if !data.NameRegex.IsNull() {
+		params["name_regex"] = data.NameRegex.ValueString()
+	}
+	if !data.NamingSchema.IsNull() {
+		var schemas []string
+		diags.Append(data.NamingSchema.ElementsAs(ctx, &schemas, false)...)
+		if direction == replicationDirectionPull {
+			params["naming_schema"] = schemas
+		} else {
+			params["also_include_naming_schema"] = schemas
+		}
+	}
+
+	return params, diags
+}
@@ -0,0 +1,360 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewReplicationRunAction(t *testing.T) {
+	a := NewReplicationRunAction()
+	if a == nil {
+		t.Fatal("expected non-nil action")
+	}
+
+	// Verify it implements the required interfaces
+	var _ action.ActionWithConfigure = a.(*ReplicationRunAction)
+	var _ action.ActionWithValidateConfig = a.(*ReplicationRunAction)
+}
+
+func TestReplicationRunAction_Metadata(t *testing.T) {
+	a := NewReplicationRunAction()
+
+	req := action.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &action.MetadataResponse{}
+
+	a.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_replication_run" {
+		t.Errorf("expected TypeName 'truenas_replication_run', got %q", resp.TypeName)
+	}
+}
+
+func TestReplicationRunAction_Schema(t *testing.T) {
+	a := NewReplicationRunAction()
+
+	resp := &action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"source_datasets", "target_dataset"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("expected '%s' attribute", name)
+		}
+		if !attr.IsRequired() {
+			t.Errorf("expected '%s' to be required", name)
+		}
+	}
+
+	for _, name := range []string{"direction", "transport", "ssh_credentials", "recursive", "naming_schema", "name_regex", "retention_policy", "allow_from_scratch"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("expected '%s' attribute", name)
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' to be optional", name)
+		}
+	}
+}
+
+func TestReplicationRunAction_Configure_WrongType(t *testing.T) {
+	a := NewReplicationRunAction().(*ReplicationRunAction)
+
+	resp := &action.ConfigureResponse{}
+	a.Configure(context.Background(), action.ConfigureRequest{ProviderData: "not services"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// replicationRunParams holds config values for test requests.
+// Use nil for unset optional attributes.
+type replicationRunParams struct {
+	SourceDatasets   []string
+	TargetDataset    string
+	Direction        interface{}
+	Transport        interface{}
+	SSHCredentials   interface{}
+	Recursive        interface{}
+	NamingSchema     []string
+	NameRegex        interface{}
+	RetentionPolicy  interface{}
+	AllowFromScratch interface{}
+}
+
+func stringListValue(values []string) tftypes.Value {
+	if values == nil {
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+	}
+	elems := make([]tftypes.Value, len(values))
+	for i, v := range values {
+		elems[i] = tftypes.NewValue(tftypes.String, v)
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+}
+
+func createReplicationRunConfig(t *testing.T, p replicationRunParams) tfsdk.Config {
+	t.Helper()
+
+	a := NewReplicationRunAction()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, schemaResp)
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"source_datasets":    tftypes.List{ElementType: tftypes.String},
+			"target_dataset":     tftypes.String,
+			"direction":          tftypes.String,
+			"transport":          tftypes.String,
+			"ssh_credentials":    tftypes.Number,
+			"recursive":          tftypes.Bool,
+			"naming_schema":      tftypes.List{ElementType: tftypes.String},
+			"name_regex":         tftypes.String,
+			"retention_policy":   tftypes.String,
+			"allow_from_scratch": tftypes.Bool,
+		},
+	}, map[string]tftypes.Value{
+		"source_datasets":    stringListValue(p.SourceDatasets),
+		"target_dataset":     tftypes.NewValue(tftypes.String, p.TargetDataset),
+		"direction":          tftypes.NewValue(tftypes.String, p.Direction),
+		"transport":          tftypes.NewValue(tftypes.String, p.Transport),
+		"ssh_credentials":    tftypes.NewValue(tftypes.Number, p.SSHCredentials),
+		"recursive":          tftypes.NewValue(tftypes.Bool, p.Recursive),
+		"naming_schema":      stringListValue(p.NamingSchema),
+		"name_regex":         tftypes.NewValue(tftypes.String, p.NameRegex),
+		"retention_policy":   tftypes.NewValue(tftypes.String, p.RetentionPolicy),
+		"allow_from_scratch": tftypes.NewValue(tftypes.Bool, p.AllowFromScratch),
+	})
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    configValue,
+	}
+}
+
+func TestReplicationRunAction_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  replicationRunParams
+		wantErr bool
+	}{
+		{
+			name: "local with name regex",
+			params: replicationRunParams{
+				SourceDatasets: []string{"tank/data"},
+				TargetDataset:  "backup/data",
+				NameRegex:      "pre-.*",
+			},
+		},
+		{
+			name: "ssh with credentials",
+			params: replicationRunParams{
+				SourceDatasets: []string{"tank/data"},
+				TargetDataset:  "backup/data",
+				Transport:      "SSH",
+				SSHCredentials: 3,
+				NamingSchema:   []string{"auto-%Y-%m-%d_%H-%M"},
+			},
+		},
+		{
+			name: "ssh without credentials",
+			params: replicationRunParams{
+				SourceDatasets: []string{"tank/data"},
+				TargetDataset:  "backup/data",
+				Transport:      "SSH",
+				NameRegex:      "pre-.*",
+			},
+			wantErr: true,
+		},
+		{
+			name: "local with credentials",
+			params: replicationRunParams{
+				SourceDatasets: []string{"tank/data"},
+				TargetDataset:  "backup/data",
+				SSHCredentials: 3,
+				NameRegex:      "pre-.*",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no snapshot selection",
+			params: replicationRunParams{
+				SourceDatasets: []string{"tank/data"},
+				TargetDataset:  "backup/data",
+			},
+			wantErr: true,
+		},
+		{
+			name: "both snapshot selections",
+			params: replicationRunParams{
+				SourceDatasets: []string{"tank/data"},
+				TargetDataset:  "backup/data",
+				NamingSchema:   []string{"auto-%Y-%m-%d_%H-%M"},
+				NameRegex:      "pre-.*",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewReplicationRunAction().(*ReplicationRunAction)
+
+			req := action.ValidateConfigRequest{Config: createReplicationRunConfig(t, tt.params)}
+			resp := &action.ValidateConfigResponse{}
+
+			a.ValidateConfig(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestReplicationRunAction_Invoke_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+	a := &ReplicationRunAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					capturedParams = params
+					return json.RawMessage(`null`), nil
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createReplicationRunConfig(t, replicationRunParams{
+		SourceDatasets: []string{"tank/data"},
+		TargetDataset:  "backup/data",
+		Recursive:      true,
+		NamingSchema:   []string{"auto-%Y-%m-%d_%H-%M"},
+	})}
+
+	var progress []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			progress = append(progress, event.Message)
+		},
+	}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "replication.run_onetime" {
+		t.Errorf("expected method 'replication.run_onetime', got %q", capturedMethod)
+	}
+
+	args, ok := capturedParams.([]any)
+	if !ok || len(args) != 1 {
+		t.Fatalf("expected [params], got %#v", capturedParams)
+	}
+	params := args[0].(map[string]any)
+	if params["direction"] != "PUSH" || params["transport"] != "LOCAL" || params["retention_policy"] != "NONE" {
+		t.Errorf("expected PUSH/LOCAL/NONE defaults, got %v", params)
+	}
+	if params["recursive"] != true {
+		t.Errorf("expected recursive true, got %v", params["recursive"])
+	}
+	if params["target_dataset"] != "backup/data" {
+		t.Errorf("expected target_dataset 'backup/data', got %v", params["target_dataset"])
+	}
+	if schemas, ok := params["also_include_naming_schema"].([]string); !ok || len(schemas) != 1 {
+		t.Errorf("expected also_include_naming_schema for PUSH, got %v", params["also_include_naming_schema"])
+	}
+	if _, ok := params["ssh_credentials"]; ok {
+		t.Error("expected ssh_credentials to be omitted for LOCAL transport")
+	}
+
+	if len(progress) != 2 {
+		t.Errorf("expected 2 progress events, got %d", len(progress))
+	}
+}
+
+func TestReplicationRunAction_Invoke_PullOverSSH(t *testing.T) {
+	var capturedParams map[string]any
+	a := &ReplicationRunAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedParams = params.([]any)[0].(map[string]any)
+					return json.RawMessage(`null`), nil
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createReplicationRunConfig(t, replicationRunParams{
+		SourceDatasets:  []string{"remote/data"},
+		TargetDataset:   "tank/replica",
+		Direction:       "PULL",
+		Transport:       "SSH",
+		SSHCredentials:  3,
+		NamingSchema:    []string{"auto-%Y-%m-%d_%H-%M"},
+		RetentionPolicy: "SOURCE",
+	})}
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["ssh_credentials"] != int64(3) {
+		t.Errorf("expected ssh_credentials 3, got %v", capturedParams["ssh_credentials"])
+	}
+	if _, ok := capturedParams["naming_schema"]; !ok {
+		t.Error("expected naming_schema for PULL")
+	}
+	if _, ok := capturedParams["also_include_naming_schema"]; ok {
+		t.Error("expected also_include_naming_schema to be omitted for PULL")
+	}
+	if capturedParams["retention_policy"] != "SOURCE" {
+		t.Errorf("expected retention_policy 'SOURCE', got %v", capturedParams["retention_policy"])
+	}
+}
+
+func TestReplicationRunAction_Invoke_JobError(t *testing.T) {
+	a := &ReplicationRunAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("target dataset does not have snapshots")
+				},
+			},
+		},
+	}
+
+	req := action.InvokeRequest{Config: createReplicationRunConfig(t, replicationRunParams{
+		SourceDatasets: []string{"tank/data"},
+		TargetDataset:  "backup/data",
+		NameRegex:      "pre-.*",
+	})}
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for failed replication job")
+	}
+}
@@ -0,0 +1,130 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.Action = &SnapshotRollbackAction{}
+var _ action.ActionWithConfigure = &SnapshotRollbackAction{}
+
+// SnapshotRollbackAction defines the action implementation.
+type SnapshotRollbackAction struct {
+	services *services.TrueNASServices
+}
+
+// SnapshotRollbackActionModel describes the action data model.
+type SnapshotRollbackActionModel struct {
+	DatasetID         types.String `tfsdk:"dataset_id"`
+	SnapshotName      types.String `tfsdk:"snapshot_name"`
+	Recursive         types.Bool   `tfsdk:"recursive"`
+	RecursiveClones   types.Bool   `tfsdk:"recursive_clones"`
+	RecursiveRollback types.Bool   `tfsdk:"recursive_rollback"`
+	Force             types.Bool   `tfsdk:"force"`
+}
+
+// NewSnapshotRollbackAction creates a new SnapshotRollbackAction.
+func NewSnapshotRollbackAction() action.Action {
+	return &SnapshotRollbackAction{}
+}
+
+func (a *SnapshotRollbackAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_rollback"
+}
+
+func (a *SnapshotRollbackAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Rolls a dataset back to a ZFS snapshot. All changes made since the snapshot are discarded. " +
+			"Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"dataset_id": schema.StringAttribute{
+				Description: "Dataset ID to roll back (e.g. 'tank/data').",
+				Required:    true,
+			},
+			"snapshot_name": schema.StringAttribute{
+				Description: "Name of the snapshot to roll back to (the part after '@').",
+				Required:    true,
+			},
+			"recursive": schema.BoolAttribute{
+				Description: "Destroy snapshots newer than the target snapshot. Required when the target is not the most recent snapshot. Defaults to false.",
+				Optional:    true,
+			},
+			"recursive_clones": schema.BoolAttribute{
+				Description: "Also destroy newer snapshots' clones. Implies recursive. Defaults to false.",
+				Optional:    true,
+			},
+			"recursive_rollback": schema.BoolAttribute{
+				Description: "Also roll back child datasets that have a snapshot with the same name. Defaults to false.",
+				Optional:    true,
+			},
+			"force": schema.BoolAttribute{
+				Description: "Force unmount of any clones before destroying them. Defaults to false.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *SnapshotRollbackAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.services = s
+}
+
+func (a *SnapshotRollbackAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data SnapshotRollbackActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshotID := data.DatasetID.ValueString() + "@" + data.SnapshotName.ValueString()
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Rolling back %s", snapshotID),
+		})
+	}
+
+	c := a.services.Client
+	method := "zfs.snapshot.rollback"
+	if c.Version().AtLeast(25, 10) {
+		method = "pool.snapshot.rollback"
+	}
+
+	if _, err := c.Call(ctx, method, []any{snapshotID, buildSnapshotRollbackOptions(&data)}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Roll Back Snapshot",
+			fmt.Sprintf("Unable to roll back to snapshot %q: %s", snapshotID, err.Error()),
+		)
+		return
+	}
+}
+
+// buildSnapshotRollbackOptions builds the rollback options; unset flags are sent as false.
+func buildSnapshotRollbackOptions(data *SnapshotRollbackActionModel) map[string]any {
+	return map[string]any{
+		"recursive":          data.Recursive.ValueBool(),
+		"recursive_clones":   data.RecursiveClones.ValueBool(),
+		"recursive_rollback": data.RecursiveRollback.ValueBool(),
+		"force":              data.Force.ValueBool(),
+	}
+}
@@ -0,0 +1,266 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewSnapshotRollbackAction(t *testing.T) {
+	a := NewSnapshotRollbackAction()
+	if a == nil {
+		t.Fatal("expected non-nil action")
+	}
+
+	// Verify it implements the required interfaces
+	var _ action.ActionWithConfigure = a.(*SnapshotRollbackAction)
+}
+
+func TestSnapshotRollbackAction_Metadata(t *testing.T) {
+	a := NewSnapshotRollbackAction()
+
+	req := action.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &action.MetadataResponse{}
+
+	a.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_snapshot_rollback" {
+		t.Errorf("expected TypeName 'truenas_snapshot_rollback', got %q", resp.TypeName)
+	}
+}
+
+func TestSnapshotRollbackAction_Schema(t *testing.T) {
+	a := NewSnapshotRollbackAction()
+
+	resp := &action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"dataset_id", "snapshot_name"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("expected '%s' attribute", name)
+		}
+		if !attr.IsRequired() {
+			t.Errorf("expected '%s' to be required", name)
+		}
+	}
+
+	for _, name := range []string{"recursive", "recursive_clones", "recursive_rollback", "force"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("expected '%s' attribute", name)
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' to be optional", name)
+		}
+	}
+}
+
+func TestSnapshotRollbackAction_Configure_Success(t *testing.T) {
+	a := NewSnapshotRollbackAction().(*SnapshotRollbackAction)
+	svc := &services.TrueNASServices{}
+
+	resp := &action.ConfigureResponse{}
+	a.Configure(context.Background(), action.ConfigureRequest{ProviderData: svc}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if a.services != svc {
+		t.Error("expected services to be set")
+	}
+}
+
+func TestSnapshotRollbackAction_Configure_NilProviderData(t *testing.T) {
+	a := NewSnapshotRollbackAction().(*SnapshotRollbackAction)
+
+	resp := &action.ConfigureResponse{}
+	a.Configure(context.Background(), action.ConfigureRequest{ProviderData: nil}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestSnapshotRollbackAction_Configure_WrongType(t *testing.T) {
+	a := NewSnapshotRollbackAction().(*SnapshotRollbackAction)
+
+	resp := &action.ConfigureResponse{}
+	a.Configure(context.Background(), action.ConfigureRequest{ProviderData: "not services"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// snapshotRollbackParams holds config values for test requests.
+// Use nil for unset optional flags.
+type snapshotRollbackParams struct {
+	DatasetID         string
+	SnapshotName      string
+	Recursive         interface{}
+	RecursiveClones   interface{}
+	RecursiveRollback interface{}
+	Force             interface{}
+}
+
+func createSnapshotRollbackInvokeRequest(t *testing.T, p snapshotRollbackParams) action.InvokeRequest {
+	t.Helper()
+
+	a := NewSnapshotRollbackAction()
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, schemaResp)
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"dataset_id":         tftypes.String,
+			"snapshot_name":      tftypes.String,
+			"recursive":          tftypes.Bool,
+			"recursive_clones":   tftypes.Bool,
+			"recursive_rollback": tftypes.Bool,
+			"force":              tftypes.Bool,
+		},
+	}, map[string]tftypes.Value{
+		"dataset_id":         tftypes.NewValue(tftypes.String, p.DatasetID),
+		"snapshot_name":      tftypes.NewValue(tftypes.String, p.SnapshotName),
+		"recursive":          tftypes.NewValue(tftypes.Bool, p.Recursive),
+		"recursive_clones":   tftypes.NewValue(tftypes.Bool, p.RecursiveClones),
+		"recursive_rollback": tftypes.NewValue(tftypes.Bool, p.RecursiveRollback),
+		"force":              tftypes.NewValue(tftypes.Bool, p.Force),
+	})
+
+	return action.InvokeRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func TestSnapshotRollbackAction_Invoke_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+	a := &SnapshotRollbackAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				VersionVal: truenas.Version{Major: 25, Minor: 4},
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					capturedParams = params
+					return json.RawMessage(`true`), nil
+				},
+			},
+		},
+	}
+
+	req := createSnapshotRollbackInvokeRequest(t, snapshotRollbackParams{
+		DatasetID:    "tank/data",
+		SnapshotName: "before-upgrade",
+		Recursive:    true,
+	})
+
+	var progress []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			progress = append(progress, event.Message)
+		},
+	}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "zfs.snapshot.rollback" {
+		t.Errorf("expected method 'zfs.snapshot.rollback', got %q", capturedMethod)
+	}
+
+	args, ok := capturedParams.([]any)
+	if !ok || len(args) != 2 {
+		t.Fatalf("expected [id, options] params, got %#v", capturedParams)
+	}
+	if args[0] != "tank/data@before-upgrade" {
+		t.Errorf("expected snapshot id 'tank/data@before-upgrade', got %v", args[0])
+	}
+	opts := args[1].(map[string]any)
+	if opts["recursive"] != true {
+		t.Errorf("expected recursive true, got %v", opts["recursive"])
+	}
+	if opts["force"] != false {
+		t.Errorf("expected unset force to be sent as false, got %v", opts["force"])
+	}
+
+	if len(progress) != 1 {
+		t.Errorf("expected 1 progress event, got %d", len(progress))
+	}
+}
+
+func TestSnapshotRollbackAction_Invoke_PoolSnapshotMethod(t *testing.T) {
+	var capturedMethod string
+	a := &SnapshotRollbackAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				VersionVal: truenas.Version{Major: 25, Minor: 10},
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					return json.RawMessage(`true`), nil
+				},
+			},
+		},
+	}
+
+	req := createSnapshotRollbackInvokeRequest(t, snapshotRollbackParams{
+		DatasetID:    "tank/data",
+		SnapshotName: "before-upgrade",
+	})
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "pool.snapshot.rollback" {
+		t.Errorf("expected method 'pool.snapshot.rollback', got %q", capturedMethod)
+	}
+}
+
+func TestSnapshotRollbackAction_Invoke_APIError(t *testing.T) {
+	a := &SnapshotRollbackAction{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				VersionVal: truenas.Version{Major: 25, Minor: 4},
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("more recent snapshots exist")
+				},
+			},
+		},
+	}
+
+	req := createSnapshotRollbackInvokeRequest(t, snapshotRollbackParams{
+		DatasetID:    "tank/data",
+		SnapshotName: "before-upgrade",
+	})
+	resp := &action.InvokeResponse{}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
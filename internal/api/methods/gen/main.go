@@ -0,0 +1,444 @@
+// Command gen generates typed request and response structs for the methods
+// package from the TrueNAS middleware method schema embedded in truenas-go.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generated lists the methods to generate wrappers for, with the Go name
+// used for the wrapper function and its Params/Result types. ResultOf
+// names an earlier entry whose result type the method shares, so config and
+// update responses decode into the same struct.
+var generated = []struct {
+	Method   string
+	Name     string
+	ResultOf string
+}{
+	{"app.gpu_choices", "AppGPUChoices", ""},
+	{"catalog.config", "CatalogConfig", ""},
+	{"catalog.sync", "CatalogSync", ""},
+	{"catalog.update", "CatalogUpdate", "CatalogConfig"},
+	{"sharing.smb.presets", "SharingSMBPresets", ""},
+	{"snmp.config", "SNMPConfig", ""},
+	{"snmp.update", "SNMPUpdate", "SNMPConfig"},
+	{"vm.bootloader_ovmf_choices", "VMBootloaderOVMFChoices", ""},
+	{"vm.cpu_model_choices", "VMCPUModelChoices", ""},
+	{"vm.device.disk_choices", "VMDeviceDiskChoices", ""},
+	{"vm.device.nic_attach_choices", "VMDeviceNICAttachChoices", ""},
+}
+
+// initialisms are field name parts spelled in upper case, matching the
+// naming used by the hand-written response structs in internal/resources.
+var initialisms = map[string]string{
+	"api":    "API",
+	"dns":    "DNS",
+	"ftp":    "FTP",
+	"id":     "ID",
+	"ip":     "IP",
+	"kmip":   "KMIP",
+	"sed":    "SED",
+	"ssl":    "SSL",
+	"ssltls": "SSLTLS",
+	"tls":    "TLS",
+	"url":    "URL",
+	"v3":     "V3",
+	"zfs":    "ZFS",
+}
+
+const truenasModule = "github.com/deevus/truenas-go"
+
+type node = map[string]any
+
+type methodDef struct {
+	Description string `json:"description"`
+	Job         bool   `json:"job"`
+	Accepts     []node `json:"accepts"`
+	Returns     []node `json:"returns"`
+}
+
+// generator accumulates the struct declarations needed by the wrappers.
+type generator struct {
+	buf     bytes.Buffer
+	structs []string
+	seen    map[string]bool
+}
+
+func main() {
+	version := flag.String("version", "", "TrueNAS API version (e.g. 25.04)")
+	out := flag.String("out", "zz_generated.go", "output file")
+	flag.Parse()
+
+	if *version == "" {
+		log.Fatal("-version is required")
+	}
+
+	methods, err := loadMethods(*version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(*version, methods)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadMethods reads methods.json for version from the truenas-go module
+// in the module cache.
+func loadMethods(version string) (map[string]methodDef, error) {
+	dir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", truenasModule).Output()
+	if err != nil {
+		return nil, fmt.Errorf("locate %s: %w", truenasModule, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(strings.TrimSpace(string(dir)), "api", version, "methods.json"))
+	if err != nil {
+		return nil, fmt.Errorf("read method schema: %w", err)
+	}
+
+	var methods map[string]methodDef
+	if err := json.Unmarshal(data, &methods); err != nil {
+		return nil, fmt.Errorf("parse method schema: %w", err)
+	}
+	return methods, nil
+}
+
+func generate(version string, methods map[string]methodDef) ([]byte, error) {
+	g := &generator{seen: map[string]bool{}}
+
+	fmt.Fprintf(&g.buf, "// Code generated by gen from the TrueNAS %s API schema. DO NOT EDIT.\n\n", version)
+	g.buf.WriteString("package methods\n\n")
+	g.buf.WriteString("import (\n\t\"context\"\n\n\t\"github.com/deevus/truenas-go/client\"\n)\n\n")
+	g.buf.WriteString("// SchemaVersion is the TrueNAS API version the types were generated from.\n")
+	fmt.Fprintf(&g.buf, "const SchemaVersion = %q\n\n", version)
+
+	for _, m := range generated {
+		def, ok := methods[m.Method]
+		if !ok {
+			return nil, fmt.Errorf("method %s not found in %s schema", m.Method, version)
+		}
+		result := m.Name + "Result"
+		if m.ResultOf != "" {
+			result = m.ResultOf + "Result"
+		}
+		if err := g.method(m.Method, m.Name, result, def); err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Method, err)
+		}
+	}
+
+	for _, s := range g.structs {
+		g.buf.WriteString(s)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, g.buf.String())
+	}
+	return src, nil
+}
+
+// method writes the wrapper function for a method and queues its types.
+func (g *generator) method(method, name, resultName string, def methodDef) error {
+	var args, names []string
+	for i, accept := range def.Accepts {
+		argName, typeName := "params", name+"Params"
+		if len(def.Accepts) > 1 {
+			argName = lowerCamel(stringValue(accept, "_name_"))
+			if argName == "" {
+				argName = fmt.Sprintf("arg%d", i)
+			}
+			typeName = name + exportName(stringValue(accept, "_name_"))
+		}
+		goType, _ := g.goType(accept, typeName, fmt.Sprintf("is argument %d of %s", i+1, method), true)
+		args = append(args, argName+" "+goType)
+		names = append(names, argName)
+	}
+
+	var params string
+	switch len(names) {
+	case 0:
+		params = "nil"
+	case 1:
+		params = names[0]
+	default:
+		params = "[]any{" + strings.Join(names, ", ") + "}"
+	}
+
+	result := "any"
+	switch {
+	case g.seen[resultName]:
+		result = resultName
+	case len(def.Returns) > 0:
+		result, _ = g.goType(def.Returns[0], resultName, "is the response of "+method, false)
+	}
+	if g.seen[result] {
+		result = "*" + result
+	}
+
+	fmt.Fprintf(&g.buf, "// %s calls %s.", name, method)
+	if summary := summarize(def.Description); summary != "" {
+		fmt.Fprintf(&g.buf, "\n//\n// %s", summary)
+	}
+	if def.Job {
+		g.buf.WriteString("\n//\n// The method is a job; the call waits for it to complete.")
+	}
+	g.buf.WriteString("\n")
+
+	fmt.Fprintf(&g.buf, "func %s(ctx context.Context, c client.Client", name)
+	for _, a := range args {
+		g.buf.WriteString(", " + a)
+	}
+
+	if returnsNull(def.Returns) {
+		g.buf.WriteString(") error {\n")
+		fmt.Fprintf(&g.buf, "\tvar result any\n\treturn call(ctx, c, %q, %t, %s, &result)\n}\n\n", method, def.Job, params)
+		return nil
+	}
+	value, zero, ret := result, "result", "result"
+	if strings.HasPrefix(result, "*") {
+		value, zero, ret = result[1:], "nil", "&result"
+	}
+	fmt.Fprintf(&g.buf, ") (%s, error) {\n", result)
+	fmt.Fprintf(&g.buf, "\tvar result %s\n", value)
+	fmt.Fprintf(&g.buf, "\tif err := call(ctx, c, %q, %t, %s, &result); err != nil {\n\t\treturn %s, err\n\t}\n", method, def.Job, params, zero)
+	fmt.Fprintf(&g.buf, "\treturn %s, nil\n}\n\n", ret)
+	return nil
+}
+
+// returnsNull reports whether a method's only documented result is null.
+func returnsNull(returns []node) bool {
+	if len(returns) == 0 {
+		return false
+	}
+	types, nullable := schemaTypes(returns[0])
+	return len(types) == 0 && nullable
+}
+
+// goType returns the Go type for a schema node and whether it is nullable.
+// Objects with properties become named structs; request structs wrap
+// optional and nullable fields in Field.
+func (g *generator) goType(n node, typeName, doc string, request bool) (string, bool) {
+	types, nullable := schemaTypes(n)
+	if len(types) != 1 {
+		return "any", nullable
+	}
+
+	switch types[0] {
+	case "string":
+		return "string", nullable
+	case "integer":
+		return "int64", nullable
+	case "number":
+		return "float64", nullable
+	case "boolean":
+		return "bool", nullable
+	case "array":
+		items, ok := n["items"].(node)
+		if list, isList := n["items"].([]any); isList && len(list) == 1 {
+			// The middleware describes homogeneous lists as a one-element tuple.
+			items, ok = list[0].(node)
+		}
+		if !ok {
+			return "[]any", nullable
+		}
+		elem, elemNullable := g.goType(items, typeName+"Item", "is an item of "+typeName, request)
+		if elemNullable && elem != "any" {
+			elem = "*" + elem
+		}
+		return "[]" + elem, nullable
+	case "object":
+		props, ok := n["properties"].(node)
+		if !ok || len(props) == 0 {
+			return "map[string]any", nullable
+		}
+		g.object(props, n, typeName, doc, request)
+		return typeName, nullable
+	}
+	return "any", nullable
+}
+
+// object queues a struct declaration for an object node.
+func (g *generator) object(props, n node, typeName, doc string, request bool) {
+	if g.seen[typeName] {
+		return
+	}
+	g.seen[typeName] = true
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s %s.", typeName, doc)
+	if request {
+		b.WriteString(" Unset fields are left out of the request.")
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "type %s struct {\n", typeName)
+
+	for _, key := range propertyOrder(n, props) {
+		prop, ok := props[key].(node)
+		if !ok {
+			continue
+		}
+		field := exportName(key)
+		goType, nullable := g.goType(prop, typeName+field, "is the "+key+" field of "+typeName, request)
+
+		if summary := summarize(stringValue(prop, "description")); summary != "" {
+			fmt.Fprintf(&b, "\t// %s\n", strings.TrimPrefix(summary, "`"+key+"` - "))
+		}
+
+		required, _ := prop["_required_"].(bool)
+		switch {
+		case request && (!required || nullable):
+			fmt.Fprintf(&b, "\t%s Field[%s] `json:\"%s,omitzero\"`\n", field, goType, key)
+		case !request && nullable && goType != "any" && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map["):
+			fmt.Fprintf(&b, "\t%s *%s `json:\"%s\"`\n", field, goType, key)
+		default:
+			fmt.Fprintf(&b, "\t%s %s `json:\"%s\"`\n", field, goType, key)
+		}
+	}
+	b.WriteString("}\n\n")
+
+	g.structs = append(g.structs, b.String())
+}
+
+// schemaTypes returns the JSON types a node allows, excluding null, and
+// whether null is allowed. anyOf branches and enum values are folded in.
+func schemaTypes(n node) ([]string, bool) {
+	set := map[string]bool{}
+	nullable := false
+
+	add := func(t string) {
+		if t == "null" {
+			nullable = true
+			return
+		}
+		set[t] = true
+	}
+
+	switch t := n["type"].(type) {
+	case string:
+		add(t)
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				add(s)
+			}
+		}
+	}
+
+	if enum, ok := n["enum"].([]any); ok && n["type"] == nil {
+		for _, v := range enum {
+			add(jsonType(v))
+		}
+	}
+
+	if anyOf, ok := n["anyOf"].([]any); ok {
+		for _, branch := range anyOf {
+			b, ok := branch.(node)
+			if !ok {
+				continue
+			}
+			types, null := schemaTypes(b)
+			if null {
+				nullable = true
+			}
+			for _, t := range types {
+				set[t] = true
+			}
+		}
+	}
+
+	types := make([]string, 0, len(set))
+	for t := range set {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types, nullable
+}
+
+// jsonType returns the schema type name of a decoded JSON value.
+func jsonType(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if x == float64(int64(x)) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "object"
+}
+
+// propertyOrder returns property names in schema order.
+func propertyOrder(n, props node) []string {
+	if order, ok := n["_attrs_order_"].([]any); ok && len(order) == len(props) {
+		keys := make([]string, 0, len(order))
+		for _, k := range order {
+			if s, ok := k.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+		if len(keys) == len(props) {
+			return keys
+		}
+	}
+
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// exportName converts a snake_case API name to an exported Go identifier.
+func exportName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '.' || r == '-' }) {
+		if upper, ok := initialisms[part]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// lowerCamel converts a snake_case API name to an unexported Go identifier.
+func lowerCamel(name string) string {
+	first, rest, _ := strings.Cut(name, "_")
+	return strings.ToLower(first) + exportName(rest)
+}
+
+// summarize returns the first sentence of a schema description.
+func summarize(description string) string {
+	paragraph, _, _ := strings.Cut(strings.TrimSpace(description), "\n\n")
+	sentence := strings.Join(strings.Fields(paragraph), " ")
+	if i := strings.Index(sentence, ". "); i >= 0 {
+		sentence = sentence[:i+1]
+	}
+	return sentence
+}
+
+func stringValue(n node, key string) string {
+	s, _ := n[key].(string)
+	return s
+}
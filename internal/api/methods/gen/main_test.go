@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestGenerated_UpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../zz_generated.go")
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+
+	methods, err := loadMethods("25.04")
+	if err != nil {
+		t.Skipf("method schema unavailable: %v", err)
+	}
+
+	src, err := generate("25.04", methods)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	if !bytes.Equal(src, committed) {
+		t.Error("zz_generated.go is out of date; run go generate ./internal/api/methods")
+	}
+}
+
+func TestSchemaTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     node
+		types    []string
+		nullable bool
+	}{
+		{"plain", node{"type": "string"}, []string{"string"}, false},
+		{"type list", node{"type": []any{"integer", "null"}}, []string{"integer"}, true},
+		{
+			"anyOf with null",
+			node{"anyOf": []any{node{"type": "string"}, node{"type": "null"}}},
+			[]string{"string"}, true,
+		},
+		{
+			"anyOf of enum",
+			node{"anyOf": []any{node{"enum": []any{nil, "AES", "DES"}}, node{"type": "null"}}},
+			[]string{"string"}, true,
+		},
+		{
+			"mixed",
+			node{"anyOf": []any{node{"type": "string"}, node{"type": "integer"}}},
+			[]string{"integer", "string"}, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types, nullable := schemaTypes(tt.node)
+			if !slices.Equal(types, tt.types) || nullable != tt.nullable {
+				t.Errorf("expected %v nullable=%v, got %v nullable=%v", tt.types, tt.nullable, types, nullable)
+			}
+		})
+	}
+}
+
+func TestGoType_TupleItems(t *testing.T) {
+	g := &generator{seen: map[string]bool{}}
+
+	got, _ := g.goType(node{"type": "array", "items": []any{node{"type": "string"}}}, "Trains", "", false)
+	if got != "[]string" {
+		t.Errorf("expected []string, got %q", got)
+	}
+}
+
+func TestReturnsNull(t *testing.T) {
+	if !returnsNull([]node{{"type": "null"}}) {
+		t.Error("expected null result to be detected")
+	}
+	if returnsNull([]node{{"type": []any{"string", "null"}}}) || returnsNull(nil) {
+		t.Error("expected only a null-only result to be detected")
+	}
+}
+
+func TestExportName(t *testing.T) {
+	tests := map[string]string{
+		"id":                  "ID",
+		"v3_privproto":        "V3Privproto",
+		"ssltls_certificate":  "SSLTLSCertificate",
+		"remote_ip_address":   "RemoteIPAddress",
+		"quota_fill_critical": "QuotaFillCritical",
+	}
+
+	for in, want := range tests {
+		if got := exportName(in); got != want {
+			t.Errorf("exportName(%q): expected %q, got %q", in, want, got)
+		}
+	}
+
+	if got := lowerCamel("snmp_update"); got != "snmpUpdate" {
+		t.Errorf("lowerCamel: expected 'snmpUpdate', got %q", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	got := summarize("`retention` - number of days to\nretain messages. Older ones are removed.\n\nMore detail.")
+	want := "`retention` - number of days to retain messages."
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,86 @@
+// Package methods provides typed wrappers for TrueNAS API methods that the
+// provider calls directly rather than through truenas-go services.
+//
+// Request and response structs are generated from the middleware method
+// schema embedded in truenas-go. To add a method, list it in gen/main.go and
+// run go generate.
+package methods
+
+//go:generate go run ./gen -version 25.04 -out zz_generated.go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+// Field is an optional request field. The zero value is left out of the
+// request so the middleware keeps its current value; Null sends an explicit
+// null to clear a nullable field.
+type Field[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Set returns a field that sends v.
+func Set[T any](v T) Field[T] {
+	return Field[T]{value: v, set: true}
+}
+
+// Null returns a field that sends null.
+func Null[T any]() Field[T] {
+	return Field[T]{set: true, null: true}
+}
+
+// SetOrNull returns a field that sends *v, or null when v is nil.
+func SetOrNull[T any](v *T) Field[T] {
+	if v == nil {
+		return Null[T]()
+	}
+	return Set(*v)
+}
+
+// Get returns the value and whether a non-null value is set.
+func (f Field[T]) Get() (T, bool) {
+	return f.value, f.set && !f.null
+}
+
+// IsNull reports whether the field sends null.
+func (f Field[T]) IsNull() bool {
+	return f.null
+}
+
+// IsZero reports whether the field is unset, so omitzero leaves it out.
+func (f Field[T]) IsZero() bool {
+	return !f.set
+}
+
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if !f.set || f.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.value)
+}
+
+// call invokes method, waiting for the job when the method is a job, and
+// decodes the result into out.
+func call(ctx context.Context, c client.Client, method string, job bool, params any, out any) error {
+	var result json.RawMessage
+	var err error
+	if job {
+		result, err = c.CallAndWait(ctx, method, params)
+	} else {
+		result, err = c.Call(ctx, method, params)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(result, out); err != nil {
+		return fmt.Errorf("parse %s response: %w", method, err)
+	}
+	return nil
+}
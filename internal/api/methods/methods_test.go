@@ -0,0 +1,137 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestField_MarshalJSON(t *testing.T) {
+	type params struct {
+		Location Field[string] `json:"location,omitzero"`
+		Privacy  Field[string] `json:"privacy,omitzero"`
+		LogLevel Field[int64]  `json:"loglevel,omitzero"`
+		Traps    Field[bool]   `json:"traps,omitzero"`
+	}
+
+	data, err := json.Marshal(params{
+		Location: Set("rack 4"),
+		Privacy:  Null[string](),
+		Traps:    Set(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"location":"rack 4","privacy":null,"traps":false}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+func TestField_Get(t *testing.T) {
+	if v, ok := Set(int64(3)).Get(); !ok || v != 3 {
+		t.Errorf("expected set value 3, got %v %v", v, ok)
+	}
+	if _, ok := Null[int64]().Get(); ok {
+		t.Error("expected null field to report no value")
+	}
+	if !Null[int64]().IsNull() {
+		t.Error("expected IsNull for a null field")
+	}
+
+	var unset Field[int64]
+	if _, ok := unset.Get(); ok || !unset.IsZero() {
+		t.Error("expected zero field to be unset")
+	}
+}
+
+func TestSetOrNull(t *testing.T) {
+	v := "AES"
+	if got, ok := SetOrNull(&v).Get(); !ok || got != "AES" {
+		t.Errorf("expected 'AES', got %q", got)
+	}
+	if !SetOrNull[string](nil).IsNull() {
+		t.Error("expected nil pointer to produce a null field")
+	}
+}
+
+func TestSNMPUpdate(t *testing.T) {
+	var capturedMethod string
+	var capturedParams []byte
+
+	c := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedMethod = method
+			capturedParams, _ = json.Marshal(params)
+			return json.RawMessage(`{"id": 1, "community": "monitoring", "v3_privproto": null, "loglevel": 3}`), nil
+		},
+	}
+
+	result, err := SNMPUpdate(context.Background(), c, SNMPUpdateParams{
+		Community:   Set("monitoring"),
+		V3Privproto: Null[string](),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if capturedMethod != "snmp.update" {
+		t.Errorf("expected method 'snmp.update', got %q", capturedMethod)
+	}
+	if want := `{"community":"monitoring","v3_privproto":null}`; string(capturedParams) != want {
+		t.Errorf("expected params %s, got %s", want, capturedParams)
+	}
+	if result.Community != "monitoring" || result.Loglevel != 3 || result.V3Privproto != nil {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestCall_WaitsForJob(t *testing.T) {
+	var waited bool
+
+	c := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			t.Errorf("expected CallAndWait for a job method, got Call(%q)", method)
+			return nil, nil
+		},
+		CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			waited = method == "kmip.update"
+			return json.RawMessage(`{"enabled": true}`), nil
+		},
+	}
+
+	var result struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := call(context.Background(), c, "kmip.update", true, map[string]any{"enabled": true}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !waited {
+		t.Error("expected kmip.update to be called with CallAndWait")
+	}
+	if !result.Enabled {
+		t.Error("expected enabled in result")
+	}
+}
+
+func TestCall_Errors(t *testing.T) {
+	c := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	if _, err := SNMPConfig(context.Background(), c); err == nil || err.Error() != "connection refused" {
+		t.Errorf("expected API error to be returned unchanged, got %v", err)
+	}
+
+	c.CallFunc = func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return json.RawMessage(`not json`), nil
+	}
+	if _, err := SNMPConfig(context.Background(), c); err == nil {
+		t.Error("expected parse error")
+	}
+}
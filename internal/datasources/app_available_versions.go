@@ -0,0 +1,216 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AppAvailableVersionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AppAvailableVersionsDataSource{}
+
+// AppAvailableVersionsDataSource defines the data source implementation.
+type AppAvailableVersionsDataSource struct {
+	services *services.TrueNASServices
+}
+
+// AppAvailableVersionsDataSourceModel describes the data source data model.
+type AppAvailableVersionsDataSourceModel struct {
+	ID                  types.String      `tfsdk:"id"`
+	Name                types.String      `tfsdk:"name"`
+	Version             types.String      `tfsdk:"version"`
+	HumanVersion        types.String      `tfsdk:"human_version"`
+	UpgradeAvailable    types.Bool        `tfsdk:"upgrade_available"`
+	LatestVersion       types.String      `tfsdk:"latest_version"`
+	LatestHumanVersion  types.String      `tfsdk:"latest_human_version"`
+	UpgradeVersion      types.String      `tfsdk:"upgrade_version"`
+	UpgradeHumanVersion types.String      `tfsdk:"upgrade_human_version"`
+	Changelog           types.String      `tfsdk:"changelog"`
+	AvailableVersions   []AppVersionModel `tfsdk:"available_versions"`
+}
+
+// AppVersionModel represents a version an app can be upgraded to.
+type AppVersionModel struct {
+	Version      types.String `tfsdk:"version"`
+	HumanVersion types.String `tfsdk:"human_version"`
+}
+
+// NewAppAvailableVersionsDataSource creates a new AppAvailableVersionsDataSource.
+func NewAppAvailableVersionsDataSource() datasource.DataSource {
+	return &AppAvailableVersionsDataSource{}
+}
+
+func (d *AppAvailableVersionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_available_versions"
+}
+
+func (d *AppAvailableVersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the versions an installed TrueNAS app can be upgraded to, so upgrades can be gated " +
+			"on version constraints.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "App identifier (the app name).",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "App name.",
+				Required:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Installed app version.",
+				Computed:    true,
+			},
+			"human_version": schema.StringAttribute{
+				Description: "Human-readable installed version.",
+				Computed:    true,
+			},
+			"upgrade_available": schema.BoolAttribute{
+				Description: "Whether an upgrade is available.",
+				Computed:    true,
+			},
+			"latest_version": schema.StringAttribute{
+				Description: "Latest available app version.",
+				Computed:    true,
+			},
+			"latest_human_version": schema.StringAttribute{
+				Description: "Human-readable latest version. Null when no upgrade is available.",
+				Computed:    true,
+			},
+			"upgrade_version": schema.StringAttribute{
+				Description: "Version the app would be upgraded to by default. Null when no upgrade is available.",
+				Computed:    true,
+			},
+			"upgrade_human_version": schema.StringAttribute{
+				Description: "Human-readable upgrade version. Null when no upgrade is available.",
+				Computed:    true,
+			},
+			"changelog": schema.StringAttribute{
+				Description: "Changelog of the upgrade version. Null when no upgrade is available.",
+				Computed:    true,
+			},
+			"available_versions": schema.ListNestedAttribute{
+				Description: "Versions the app can be upgraded to. Empty when no upgrade is available.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							Description: "App version.",
+							Computed:    true,
+						},
+						"human_version": schema.StringAttribute{
+							Description: "Human-readable version.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppAvailableVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *AppAvailableVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppAvailableVersionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	app, err := d.services.App.GetApp(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read App",
+			fmt.Sprintf("Unable to read app %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	if app == nil {
+		resp.Diagnostics.AddError(
+			"App Not Found",
+			fmt.Sprintf("App %q was not found.", name),
+		)
+		return
+	}
+
+	// app.upgrade_summary fails for apps without an upgrade (including
+	// custom apps), so only ask for it when one is available.
+	var summary *truenas.AppUpgradeSummary
+	if app.UpgradeAvailable {
+		summary, err = d.services.App.UpgradeSummary(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read App Upgrade Summary",
+				fmt.Sprintf("Unable to read upgrade summary for app %q: %s", name, err.Error()),
+			)
+			return
+		}
+	}
+
+	mapAppAvailableVersionsToModel(app, summary, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapAppAvailableVersionsToModel maps an app and its upgrade summary to the
+// data source model. summary is nil when no upgrade is available.
+func mapAppAvailableVersionsToModel(app *truenas.App, summary *truenas.AppUpgradeSummary, data *AppAvailableVersionsDataSourceModel) {
+	data.ID = types.StringValue(app.Name)
+	data.Name = types.StringValue(app.Name)
+	data.Version = types.StringValue(app.Version)
+	data.HumanVersion = types.StringValue(app.HumanVersion)
+	data.UpgradeAvailable = types.BoolValue(app.UpgradeAvailable)
+	data.LatestVersion = types.StringValue(app.LatestVersion)
+	data.AvailableVersions = []AppVersionModel{}
+
+	if summary == nil {
+		data.LatestHumanVersion = types.StringNull()
+		data.UpgradeVersion = types.StringNull()
+		data.UpgradeHumanVersion = types.StringNull()
+		data.Changelog = types.StringNull()
+		return
+	}
+
+	if summary.LatestVersion != "" {
+		data.LatestVersion = types.StringValue(summary.LatestVersion)
+	}
+	data.LatestHumanVersion = types.StringValue(summary.LatestHumanVersion)
+	data.UpgradeVersion = types.StringValue(summary.UpgradeVersion)
+	data.UpgradeHumanVersion = types.StringValue(summary.UpgradeHumanVersion)
+	data.Changelog = types.StringValue(summary.Changelog)
+
+	for _, v := range summary.AvailableVersions {
+		data.AvailableVersions = append(data.AvailableVersions, AppVersionModel{
+			Version:      types.StringValue(v.Version),
+			HumanVersion: types.StringValue(v.HumanVersion),
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package datasources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewAppAvailableVersionsDataSource(t *testing.T) {
+	ds := NewAppAvailableVersionsDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	// Verify it implements the required interfaces
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*AppAvailableVersionsDataSource)
+}
+
+func TestAppAvailableVersionsDataSource_Metadata(t *testing.T) {
+	ds := NewAppAvailableVersionsDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_app_available_versions" {
+		t.Errorf("expected TypeName 'truenas_app_available_versions', got %q", resp.TypeName)
+	}
+}
+
+func TestAppAvailableVersionsDataSource_Schema(t *testing.T) {
+	ds := NewAppAvailableVersionsDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	nameAttr, ok := resp.Schema.Attributes["name"]
+	if !ok {
+		t.Fatal("expected 'name' attribute in schema")
+	}
+	if !nameAttr.IsRequired() {
+		t.Error("expected 'name' attribute to be required")
+	}
+
+	for _, name := range []string{"version", "upgrade_available", "latest_version", "upgrade_version", "changelog", "available_versions"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// createAppAvailableVersionsTestReadRequest creates a datasource.ReadRequest for the given app name
+func createAppAvailableVersionsTestReadRequest(t *testing.T, name string) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewAppAvailableVersionsDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	versionType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"version":       tftypes.String,
+			"human_version": tftypes.String,
+		},
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                    tftypes.String,
+			"name":                  tftypes.String,
+			"version":               tftypes.String,
+			"human_version":         tftypes.String,
+			"upgrade_available":     tftypes.Bool,
+			"latest_version":        tftypes.String,
+			"latest_human_version":  tftypes.String,
+			"upgrade_version":       tftypes.String,
+			"upgrade_human_version": tftypes.String,
+			"changelog":             tftypes.String,
+			"available_versions":    tftypes.List{ElementType: versionType},
+		},
+	}, map[string]tftypes.Value{
+		"id":                    tftypes.NewValue(tftypes.String, nil),
+		"name":                  tftypes.NewValue(tftypes.String, name),
+		"version":               tftypes.NewValue(tftypes.String, nil),
+		"human_version":         tftypes.NewValue(tftypes.String, nil),
+		"upgrade_available":     tftypes.NewValue(tftypes.Bool, nil),
+		"latest_version":        tftypes.NewValue(tftypes.String, nil),
+		"latest_human_version":  tftypes.NewValue(tftypes.String, nil),
+		"upgrade_version":       tftypes.NewValue(tftypes.String, nil),
+		"upgrade_human_version": tftypes.NewValue(tftypes.String, nil),
+		"changelog":             tftypes.NewValue(tftypes.String, nil),
+		"available_versions":    tftypes.NewValue(tftypes.List{ElementType: versionType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func readAppAvailableVersions(t *testing.T, app *truenas.MockAppService, name string) (*datasource.ReadResponse, AppAvailableVersionsDataSourceModel) {
+	t.Helper()
+
+	ds := &AppAvailableVersionsDataSource{
+		services: &services.TrueNASServices{App: app},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), createAppAvailableVersionsTestReadRequest(t, name), resp)
+
+	var model AppAvailableVersionsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestAppAvailableVersionsDataSource_Read_UpgradeAvailable(t *testing.T) {
+	resp, model := readAppAvailableVersions(t, &truenas.MockAppService{
+		GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+			return &truenas.App{
+				Name:             "immich",
+				Version:          "1.2.3",
+				HumanVersion:     "v1.120.0_1.2.3",
+				LatestVersion:    "1.2.5",
+				UpgradeAvailable: true,
+			}, nil
+		},
+		UpgradeSummaryFunc: func(ctx context.Context, name string) (*truenas.AppUpgradeSummary, error) {
+			if name != "immich" {
+				t.Errorf("expected name 'immich', got %q", name)
+			}
+			return &truenas.AppUpgradeSummary{
+				LatestVersion:       "1.2.5",
+				LatestHumanVersion:  "v1.121.0_1.2.5",
+				UpgradeVersion:      "1.2.5",
+				UpgradeHumanVersion: "v1.121.0_1.2.5",
+				Changelog:           "Bug fixes",
+				AvailableVersions: []truenas.AppAvailableVersion{
+					{Version: "1.2.5", HumanVersion: "v1.121.0_1.2.5"},
+					{Version: "1.2.4", HumanVersion: "v1.120.1_1.2.4"},
+				},
+			}, nil
+		},
+	}, "immich")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "immich" {
+		t.Errorf("expected ID 'immich', got %q", model.ID.ValueString())
+	}
+	if model.Version.ValueString() != "1.2.3" {
+		t.Errorf("expected Version '1.2.3', got %q", model.Version.ValueString())
+	}
+	if model.UpgradeVersion.ValueString() != "1.2.5" {
+		t.Errorf("expected UpgradeVersion '1.2.5', got %q", model.UpgradeVersion.ValueString())
+	}
+	if model.LatestHumanVersion.ValueString() != "v1.121.0_1.2.5" {
+		t.Errorf("expected LatestHumanVersion 'v1.121.0_1.2.5', got %q", model.LatestHumanVersion.ValueString())
+	}
+	if model.Changelog.ValueString() != "Bug fixes" {
+		t.Errorf("expected Changelog 'Bug fixes', got %q", model.Changelog.ValueString())
+	}
+	if len(model.AvailableVersions) != 2 {
+		t.Fatalf("expected 2 available versions, got %d", len(model.AvailableVersions))
+	}
+	if model.AvailableVersions[1].Version.ValueString() != "1.2.4" {
+		t.Errorf("expected second version '1.2.4', got %q", model.AvailableVersions[1].Version.ValueString())
+	}
+}
+
+func TestAppAvailableVersionsDataSource_Read_NoUpgrade(t *testing.T) {
+	resp, model := readAppAvailableVersions(t, &truenas.MockAppService{
+		GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+			return &truenas.App{
+				Name:          "immich",
+				Version:       "1.2.5",
+				HumanVersion:  "v1.121.0_1.2.5",
+				LatestVersion: "1.2.5",
+			}, nil
+		},
+		UpgradeSummaryFunc: func(ctx context.Context, name string) (*truenas.AppUpgradeSummary, error) {
+			t.Error("expected upgrade summary not to be requested")
+			return nil, errors.New("no upgrade available")
+		},
+	}, "immich")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.UpgradeAvailable.ValueBool() {
+		t.Error("expected UpgradeAvailable to be false")
+	}
+	if model.LatestVersion.ValueString() != "1.2.5" {
+		t.Errorf("expected LatestVersion '1.2.5', got %q", model.LatestVersion.ValueString())
+	}
+	if !model.UpgradeVersion.IsNull() || !model.Changelog.IsNull() {
+		t.Error("expected upgrade_version and changelog to be null")
+	}
+	if model.AvailableVersions == nil || len(model.AvailableVersions) != 0 {
+		t.Errorf("expected empty available_versions list, got %v", model.AvailableVersions)
+	}
+}
+
+func TestAppAvailableVersionsDataSource_Read_AppNotFound(t *testing.T) {
+	resp, _ := readAppAvailableVersions(t, &truenas.MockAppService{
+		GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+			return nil, nil
+		},
+	}, "missing")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for app not found")
+	}
+}
+
+func TestAppAvailableVersionsDataSource_Read_UpgradeSummaryError(t *testing.T) {
+	resp, _ := readAppAvailableVersions(t, &truenas.MockAppService{
+		GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+			return &truenas.App{Name: "immich", UpgradeAvailable: true}, nil
+		},
+		UpgradeSummaryFunc: func(ctx context.Context, name string) (*truenas.AppUpgradeSummary, error) {
+			return nil, errors.New("connection failed")
+		},
+	}, "immich")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for upgrade summary failure")
+	}
+}
@@ -0,0 +1,161 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AppGPUChoicesDataSource{}
+var _ datasource.DataSourceWithConfigure = &AppGPUChoicesDataSource{}
+
+// AppGPUChoicesDataSource defines the data source implementation.
+type AppGPUChoicesDataSource struct {
+	services *services.TrueNASServices
+}
+
+// AppGPUChoicesDataSourceModel describes the data source data model.
+type AppGPUChoicesDataSourceModel struct {
+	ID       types.String        `tfsdk:"id"`
+	GPUs     []AppGPUChoiceModel `tfsdk:"gpus"`
+	PCISlots []types.String      `tfsdk:"pci_slots"`
+}
+
+// AppGPUChoiceModel describes a GPU offered to apps.
+type AppGPUChoiceModel struct {
+	PCISlot     types.String `tfsdk:"pci_slot"`
+	Vendor      types.String `tfsdk:"vendor"`
+	Description types.String `tfsdk:"description"`
+	UUID        types.String `tfsdk:"uuid"`
+	Error       types.String `tfsdk:"error"`
+}
+
+// NewAppGPUChoicesDataSource creates a new AppGPUChoicesDataSource.
+func NewAppGPUChoicesDataSource() datasource.DataSource {
+	return &AppGPUChoicesDataSource{}
+}
+
+func (d *AppGPUChoicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_gpu_choices"
+}
+
+func (d *AppGPUChoicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the GPUs on this host that apps can be given, as offered by the apps UI's GPU configuration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always 'app_gpu_choices').",
+				Computed:    true,
+			},
+			"gpus": schema.ListNestedAttribute{
+				Description: "GPUs offered to apps, sorted by PCI slot.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"pci_slot": schema.StringAttribute{
+							Description: "PCI slot of the GPU (e.g., 0000:01:00.0).",
+							Computed:    true,
+						},
+						"vendor": schema.StringAttribute{
+							Description: "GPU vendor (e.g., NVIDIA, AMD, INTEL), or null if not recognised.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "GPU model as reported by the host.",
+							Computed:    true,
+						},
+						"uuid": schema.StringAttribute{
+							Description: "NVIDIA GPU UUID, for use in a compose file's device_ids. Null for other vendors.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Why apps cannot use the GPU (e.g., a missing driver), or null if they can.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"pci_slots": schema.ListAttribute{
+				Description: "PCI slots of the GPUs apps can use, i.e. those without an error, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *AppGPUChoicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *AppGPUChoicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppGPUChoicesDataSourceModel
+
+	choices, err := methods.AppGPUChoices(ctx, d.services.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read GPU Choices",
+			fmt.Sprintf("Unable to read app GPU choices: %s", err.Error()),
+		)
+		return
+	}
+
+	// The choices are keyed by PCI slot.
+	slots := make([]string, 0, len(choices))
+	for slot := range choices {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+
+	data.ID = types.StringValue("app_gpu_choices")
+	data.GPUs = make([]AppGPUChoiceModel, len(slots))
+	data.PCISlots = []types.String{}
+	for i, slot := range slots {
+		gpu, _ := choices[slot].(map[string]any)
+		vendorConfig, _ := gpu["vendor_specific_config"].(map[string]any)
+
+		data.GPUs[i] = AppGPUChoiceModel{
+			PCISlot:     types.StringValue(slot),
+			Vendor:      gpuChoiceString(gpu["vendor"]),
+			Description: gpuChoiceString(gpu["description"]),
+			UUID:        gpuChoiceString(vendorConfig["uuid"]),
+			Error:       gpuChoiceString(gpu["error"]),
+		}
+		if data.GPUs[i].Error.IsNull() {
+			data.PCISlots = append(data.PCISlots, types.StringValue(slot))
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// gpuChoiceString maps a nullable string field of app.gpu_choices, treating
+// an empty string as null.
+func gpuChoiceString(v any) types.String {
+	s, _ := v.(string)
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,169 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestNewAppGPUChoicesDataSource(t *testing.T) {
+	ds := NewAppGPUChoicesDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*AppGPUChoicesDataSource)
+}
+
+func TestAppGPUChoicesDataSource_Metadata(t *testing.T) {
+	ds := NewAppGPUChoicesDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_app_gpu_choices" {
+		t.Errorf("expected TypeName 'truenas_app_gpu_choices', got %q", resp.TypeName)
+	}
+}
+
+func readAppGPUChoices(t *testing.T, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, AppGPUChoicesDataSourceModel) {
+	t.Helper()
+
+	ds := &AppGPUChoicesDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+
+	var model AppGPUChoicesDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestAppGPUChoicesDataSource_Read_Success(t *testing.T) {
+	resp, model := readAppGPUChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method != "app.gpu_choices" {
+			t.Errorf("expected method 'app.gpu_choices', got %q", method)
+		}
+		return json.RawMessage(`{
+			"0000:03:00.0": {
+				"vendor": "INTEL",
+				"description": "Intel Corporation DG2 [Arc A380]",
+				"error": null,
+				"vendor_specific_config": {},
+				"gpu_details": {},
+				"pci_slot": "0000:03:00.0"
+			},
+			"0000:01:00.0": {
+				"vendor": "NVIDIA",
+				"description": "NVIDIA GeForce RTX 3060",
+				"error": null,
+				"vendor_specific_config": {"uuid": "GPU-5f2e1a9c-0b7d-4c1e-9a3f-2d8e6b4c1a70"},
+				"gpu_details": {},
+				"pci_slot": "0000:01:00.0"
+			},
+			"0000:02:00.0": {
+				"vendor": "NVIDIA",
+				"description": "NVIDIA Quadro P400",
+				"error": "NVIDIA drivers are not installed",
+				"vendor_specific_config": {},
+				"gpu_details": {},
+				"pci_slot": "0000:02:00.0"
+			}
+		}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "app_gpu_choices" {
+		t.Errorf("expected ID 'app_gpu_choices', got %q", model.ID.ValueString())
+	}
+	if len(model.GPUs) != 3 {
+		t.Fatalf("expected 3 GPUs, got %d", len(model.GPUs))
+	}
+
+	nvidia := model.GPUs[0]
+	if nvidia.PCISlot.ValueString() != "0000:01:00.0" {
+		t.Errorf("expected GPUs sorted by PCI slot, got %q first", nvidia.PCISlot.ValueString())
+	}
+	if nvidia.Vendor.ValueString() != "NVIDIA" {
+		t.Errorf("expected vendor 'NVIDIA', got %q", nvidia.Vendor.ValueString())
+	}
+	if nvidia.Description.ValueString() != "NVIDIA GeForce RTX 3060" {
+		t.Errorf("expected description 'NVIDIA GeForce RTX 3060', got %q", nvidia.Description.ValueString())
+	}
+	if nvidia.UUID.ValueString() != "GPU-5f2e1a9c-0b7d-4c1e-9a3f-2d8e6b4c1a70" {
+		t.Errorf("expected NVIDIA UUID, got %q", nvidia.UUID.ValueString())
+	}
+	if !nvidia.Error.IsNull() {
+		t.Errorf("expected null error, got %q", nvidia.Error.ValueString())
+	}
+
+	if !model.GPUs[2].UUID.IsNull() {
+		t.Errorf("expected null UUID for Intel GPU, got %q", model.GPUs[2].UUID.ValueString())
+	}
+	if model.GPUs[1].Error.ValueString() != "NVIDIA drivers are not installed" {
+		t.Errorf("expected driver error, got %q", model.GPUs[1].Error.ValueString())
+	}
+
+	want := []string{"0000:01:00.0", "0000:03:00.0"}
+	if len(model.PCISlots) != len(want) {
+		t.Fatalf("expected %d usable PCI slots, got %v", len(want), model.PCISlots)
+	}
+	for i, slot := range want {
+		if model.PCISlots[i].ValueString() != slot {
+			t.Errorf("expected PCI slot %d to be %q, got %q", i, slot, model.PCISlots[i].ValueString())
+		}
+	}
+}
+
+func TestAppGPUChoicesDataSource_Read_NoGPUs(t *testing.T) {
+	resp, model := readAppGPUChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return json.RawMessage(`{}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(model.GPUs) != 0 || len(model.PCISlots) != 0 {
+		t.Errorf("expected no GPUs, got %v and %v", model.GPUs, model.PCISlots)
+	}
+}
+
+func TestAppGPUChoicesDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readAppGPUChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
@@ -0,0 +1,250 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AppStatusDataSource{}
+var _ datasource.DataSourceWithConfigure = &AppStatusDataSource{}
+
+// AppStatusDataSource defines the data source implementation.
+type AppStatusDataSource struct {
+	services *services.TrueNASServices
+}
+
+// AppStatusDataSourceModel describes the data source data model.
+type AppStatusDataSourceModel struct {
+	ID               types.String        `tfsdk:"id"`
+	Name             types.String        `tfsdk:"name"`
+	State            types.String        `tfsdk:"state"`
+	CustomApp        types.Bool          `tfsdk:"custom_app"`
+	Version          types.String        `tfsdk:"version"`
+	HumanVersion     types.String        `tfsdk:"human_version"`
+	LatestVersion    types.String        `tfsdk:"latest_version"`
+	UpgradeAvailable types.Bool          `tfsdk:"upgrade_available"`
+	ContainerCount   types.Int64         `tfsdk:"container_count"`
+	Images           []types.String      `tfsdk:"images"`
+	Containers       []AppContainerModel `tfsdk:"containers"`
+	UsedPorts        []AppUsedPortModel  `tfsdk:"used_ports"`
+}
+
+// AppContainerModel represents a container of the app.
+type AppContainerModel struct {
+	ID          types.String `tfsdk:"id"`
+	ServiceName types.String `tfsdk:"service_name"`
+	Image       types.String `tfsdk:"image"`
+	State       types.String `tfsdk:"state"`
+}
+
+// AppUsedPortModel represents a port published by the app.
+type AppUsedPortModel struct {
+	ContainerPort types.Int64  `tfsdk:"container_port"`
+	HostPort      types.Int64  `tfsdk:"host_port"`
+	Protocol      types.String `tfsdk:"protocol"`
+}
+
+// NewAppStatusDataSource creates a new AppStatusDataSource.
+func NewAppStatusDataSource() datasource.DataSource {
+	return &AppStatusDataSource{}
+}
+
+func (d *AppStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_status"
+}
+
+func (d *AppStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the runtime status of an installed TrueNAS app, including its containers, images and published ports.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "App identifier (the app name).",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "App name.",
+				Required:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "App state (e.g. RUNNING, STOPPED, DEPLOYING, CRASHED).",
+				Computed:    true,
+			},
+			"custom_app": schema.BoolAttribute{
+				Description: "Whether the app is a custom (compose) app.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Installed app version.",
+				Computed:    true,
+			},
+			"human_version": schema.StringAttribute{
+				Description: "Human-readable installed version.",
+				Computed:    true,
+			},
+			"latest_version": schema.StringAttribute{
+				Description: "Latest available app version.",
+				Computed:    true,
+			},
+			"upgrade_available": schema.BoolAttribute{
+				Description: "Whether an upgrade is available.",
+				Computed:    true,
+			},
+			"container_count": schema.Int64Attribute{
+				Description: "Number of active containers.",
+				Computed:    true,
+			},
+			"images": schema.ListAttribute{
+				Description: "Distinct images used by the app's containers, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"containers": schema.ListNestedAttribute{
+				Description: "Containers of the app.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Container ID.",
+							Computed:    true,
+						},
+						"service_name": schema.StringAttribute{
+							Description: "Compose service name.",
+							Computed:    true,
+						},
+						"image": schema.StringAttribute{
+							Description: "Container image.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "Container state (e.g. running, exited, starting).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"used_ports": schema.ListNestedAttribute{
+				Description: "Ports published on the host by the app.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"container_port": schema.Int64Attribute{
+							Description: "Port inside the container.",
+							Computed:    true,
+						},
+						"host_port": schema.Int64Attribute{
+							Description: "Port published on the host.",
+							Computed:    true,
+						},
+						"protocol": schema.StringAttribute{
+							Description: "Port protocol (tcp or udp).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *AppStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	app, err := d.services.App.GetApp(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read App",
+			fmt.Sprintf("Unable to read app %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	if app == nil {
+		resp.Diagnostics.AddError(
+			"App Not Found",
+			fmt.Sprintf("App %q was not found.", name),
+		)
+		return
+	}
+
+	mapAppStatusToModel(app, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapAppStatusToModel maps an app and its active workloads to the data source model.
+func mapAppStatusToModel(app *truenas.App, data *AppStatusDataSourceModel) {
+	data.ID = types.StringValue(app.Name)
+	data.Name = types.StringValue(app.Name)
+	data.State = types.StringValue(app.State)
+	data.CustomApp = types.BoolValue(app.CustomApp)
+	data.Version = types.StringValue(app.Version)
+	data.HumanVersion = types.StringValue(app.HumanVersion)
+	data.LatestVersion = types.StringValue(app.LatestVersion)
+	data.UpgradeAvailable = types.BoolValue(app.UpgradeAvailable)
+	data.ContainerCount = types.Int64Value(int64(app.ActiveWorkloads.Containers))
+
+	seen := make(map[string]bool)
+	images := []string{}
+	data.Containers = []AppContainerModel{}
+	for _, c := range app.ActiveWorkloads.ContainerDetails {
+		data.Containers = append(data.Containers, AppContainerModel{
+			ID:          types.StringValue(c.ID),
+			ServiceName: types.StringValue(c.ServiceName),
+			Image:       types.StringValue(c.Image),
+			State:       types.StringValue(string(c.State)),
+		})
+		if c.Image != "" && !seen[c.Image] {
+			seen[c.Image] = true
+			images = append(images, c.Image)
+		}
+	}
+
+	sort.Strings(images)
+	data.Images = make([]types.String, len(images))
+	for i, image := range images {
+		data.Images[i] = types.StringValue(image)
+	}
+
+	data.UsedPorts = []AppUsedPortModel{}
+	for _, p := range app.ActiveWorkloads.UsedPorts {
+		data.UsedPorts = append(data.UsedPorts, AppUsedPortModel{
+			ContainerPort: types.Int64Value(int64(p.ContainerPort)),
+			HostPort:      types.Int64Value(int64(p.HostPort)),
+			Protocol:      types.StringValue(p.Protocol),
+		})
+	}
+}
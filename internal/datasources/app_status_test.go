@@ -0,0 +1,333 @@
+package datasources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewAppStatusDataSource(t *testing.T) {
+	ds := NewAppStatusDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	// Verify it implements the required interfaces
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*AppStatusDataSource)
+}
+
+func TestAppStatusDataSource_Metadata(t *testing.T) {
+	ds := NewAppStatusDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_app_status" {
+		t.Errorf("expected TypeName 'truenas_app_status', got %q", resp.TypeName)
+	}
+}
+
+func TestAppStatusDataSource_Schema(t *testing.T) {
+	ds := NewAppStatusDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	nameAttr, ok := resp.Schema.Attributes["name"]
+	if !ok {
+		t.Fatal("expected 'name' attribute in schema")
+	}
+	if !nameAttr.IsRequired() {
+		t.Error("expected 'name' attribute to be required")
+	}
+
+	for _, name := range []string{"state", "containers", "used_ports", "images", "container_count"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+func TestAppStatusDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewAppStatusDataSource().(*AppStatusDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createAppStatusTestReadRequest creates a datasource.ReadRequest for the given app name
+func createAppStatusTestReadRequest(t *testing.T, name string) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewAppStatusDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	containerType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":           tftypes.String,
+			"service_name": tftypes.String,
+			"image":        tftypes.String,
+			"state":        tftypes.String,
+		},
+	}
+	portType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"container_port": tftypes.Number,
+			"host_port":      tftypes.Number,
+			"protocol":       tftypes.String,
+		},
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                tftypes.String,
+			"name":              tftypes.String,
+			"state":             tftypes.String,
+			"custom_app":        tftypes.Bool,
+			"version":           tftypes.String,
+			"human_version":     tftypes.String,
+			"latest_version":    tftypes.String,
+			"upgrade_available": tftypes.Bool,
+			"container_count":   tftypes.Number,
+			"images":            tftypes.List{ElementType: tftypes.String},
+			"containers":        tftypes.List{ElementType: containerType},
+			"used_ports":        tftypes.List{ElementType: portType},
+		},
+	}, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, nil),
+		"name":              tftypes.NewValue(tftypes.String, name),
+		"state":             tftypes.NewValue(tftypes.String, nil),
+		"custom_app":        tftypes.NewValue(tftypes.Bool, nil),
+		"version":           tftypes.NewValue(tftypes.String, nil),
+		"human_version":     tftypes.NewValue(tftypes.String, nil),
+		"latest_version":    tftypes.NewValue(tftypes.String, nil),
+		"upgrade_available": tftypes.NewValue(tftypes.Bool, nil),
+		"container_count":   tftypes.NewValue(tftypes.Number, nil),
+		"images":            tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"containers":        tftypes.NewValue(tftypes.List{ElementType: containerType}, nil),
+		"used_ports":        tftypes.NewValue(tftypes.List{ElementType: portType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func TestAppStatusDataSource_Read_Success(t *testing.T) {
+	ds := &AppStatusDataSource{
+		services: &services.TrueNASServices{
+			App: &truenas.MockAppService{
+				GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+					if name != "immich" {
+						t.Errorf("expected name 'immich', got %q", name)
+					}
+					return &truenas.App{
+						Name:             "immich",
+						State:            "RUNNING",
+						Version:          "1.2.3",
+						HumanVersion:     "v1.120.0_1.2.3",
+						LatestVersion:    "1.2.4",
+						UpgradeAvailable: true,
+						ActiveWorkloads: truenas.AppActiveWorkloads{
+							Containers: 3,
+							UsedPorts: []truenas.AppUsedPort{
+								{ContainerPort: 2283, HostPort: 30041, Protocol: "tcp"},
+							},
+							ContainerDetails: []truenas.AppContainerDetails{
+								{ID: "c1", ServiceName: "server", Image: "ghcr.io/immich-app/immich-server:v1.120.0", State: truenas.ContainerStateRunning},
+								{ID: "c2", ServiceName: "redis", Image: "redis:7", State: truenas.ContainerStateRunning},
+								{ID: "c3", ServiceName: "worker", Image: "ghcr.io/immich-app/immich-server:v1.120.0", State: truenas.ContainerStateStarting},
+							},
+						},
+					}, nil
+				},
+			},
+		},
+	}
+
+	req := createAppStatusTestReadRequest(t, "immich")
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model AppStatusDataSourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	if model.ID.ValueString() != "immich" {
+		t.Errorf("expected ID 'immich', got %q", model.ID.ValueString())
+	}
+	if model.State.ValueString() != "RUNNING" {
+		t.Errorf("expected State 'RUNNING', got %q", model.State.ValueString())
+	}
+	if !model.UpgradeAvailable.ValueBool() {
+		t.Error("expected UpgradeAvailable to be true")
+	}
+	if model.ContainerCount.ValueInt64() != 3 {
+		t.Errorf("expected ContainerCount 3, got %d", model.ContainerCount.ValueInt64())
+	}
+	if len(model.Containers) != 3 {
+		t.Fatalf("expected 3 containers, got %d", len(model.Containers))
+	}
+	if model.Containers[2].State.ValueString() != "starting" {
+		t.Errorf("expected third container state 'starting', got %q", model.Containers[2].State.ValueString())
+	}
+	if len(model.Images) != 2 {
+		t.Fatalf("expected 2 distinct images, got %v", model.Images)
+	}
+	if model.Images[0].ValueString() != "ghcr.io/immich-app/immich-server:v1.120.0" || model.Images[1].ValueString() != "redis:7" {
+		t.Errorf("expected sorted distinct images, got %v", model.Images)
+	}
+	if len(model.UsedPorts) != 1 || model.UsedPorts[0].HostPort.ValueInt64() != 30041 {
+		t.Errorf("expected host port 30041, got %v", model.UsedPorts)
+	}
+}
+
+func TestAppStatusDataSource_Read_NoWorkloads(t *testing.T) {
+	ds := &AppStatusDataSource{
+		services: &services.TrueNASServices{
+			App: &truenas.MockAppService{
+				GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+					return &truenas.App{Name: "immich", State: "STOPPED"}, nil
+				},
+			},
+		},
+	}
+
+	req := createAppStatusTestReadRequest(t, "immich")
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model AppStatusDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Containers == nil || len(model.Containers) != 0 {
+		t.Errorf("expected empty containers list, got %v", model.Containers)
+	}
+	if model.UsedPorts == nil || len(model.UsedPorts) != 0 {
+		t.Errorf("expected empty used_ports list, got %v", model.UsedPorts)
+	}
+}
+
+func TestAppStatusDataSource_Read_AppNotFound(t *testing.T) {
+	ds := &AppStatusDataSource{
+		services: &services.TrueNASServices{
+			App: &truenas.MockAppService{
+				GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+					return nil, nil
+				},
+			},
+		},
+	}
+
+	req := createAppStatusTestReadRequest(t, "missing")
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for app not found")
+	}
+}
+
+func TestAppStatusDataSource_Read_APIError(t *testing.T) {
+	ds := &AppStatusDataSource{
+		services: &services.TrueNASServices{
+			App: &truenas.MockAppService{
+				GetAppFunc: func(ctx context.Context, name string) (*truenas.App, error) {
+					return nil, errors.New("connection failed")
+				},
+			},
+		},
+	}
+
+	req := createAppStatusTestReadRequest(t, "immich")
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
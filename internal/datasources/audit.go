@@ -0,0 +1,263 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AuditDataSource{}
+var _ datasource.DataSourceWithConfigure = &AuditDataSource{}
+
+// auditServices are the services whose events are recorded in the audit log.
+var auditServices = []string{"MIDDLEWARE", "SMB", "SUDO"}
+
+// defaultAuditLimit is the number of entries returned when limit is not set.
+const defaultAuditLimit = 100
+
+// AuditDataSource defines the data source implementation.
+type AuditDataSource struct {
+	services *services.TrueNASServices
+}
+
+// AuditDataSourceModel describes the data source data model.
+type AuditDataSourceModel struct {
+	Services []types.String `tfsdk:"services"`
+	Username types.String   `tfsdk:"username"`
+	Event    types.String   `tfsdk:"event"`
+	Since    types.String   `tfsdk:"since"`
+	Limit    types.Int64    `tfsdk:"limit"`
+	Entries  []AuditModel   `tfsdk:"entries"`
+}
+
+// AuditModel represents an audit log entry in the list.
+type AuditModel struct {
+	AuditID   types.String `tfsdk:"audit_id"`
+	Timestamp types.String `tfsdk:"timestamp"`
+	Service   types.String `tfsdk:"service"`
+	Event     types.String `tfsdk:"event"`
+	Username  types.String `tfsdk:"username"`
+	Address   types.String `tfsdk:"address"`
+	Success   types.Bool   `tfsdk:"success"`
+	EventData types.String `tfsdk:"event_data"`
+}
+
+// auditEntryResponse is the subset of audit.query fields used by the data source.
+type auditEntryResponse struct {
+	AuditID          string          `json:"audit_id"`
+	MessageTimestamp int64           `json:"message_timestamp"`
+	Service          string          `json:"service"`
+	Event            string          `json:"event"`
+	Username         *string         `json:"username"`
+	Address          *string         `json:"address"`
+	Success          bool            `json:"success"`
+	EventData        json.RawMessage `json:"event_data"`
+}
+
+// NewAuditDataSource creates a new AuditDataSource.
+func NewAuditDataSource() datasource.DataSource {
+	return &AuditDataSource{}
+}
+
+func (d *AuditDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit"
+}
+
+func (d *AuditDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves recent audit log entries (audit.query), newest first, optionally filtered by " +
+			"service, user, event and time. Useful for exporting audit records to external systems.",
+		Attributes: map[string]schema.Attribute{
+			"services": schema.ListAttribute{
+				Description: "Services to query: MIDDLEWARE, SMB, SUDO. Defaults to all.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(auditServices...)),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Only return entries for this user.",
+				Optional:    true,
+			},
+			"event": schema.StringAttribute{
+				Description: "Only return entries of this event type (e.g. 'METHOD_CALL', 'AUTHENTICATION').",
+				Optional:    true,
+			},
+			"since": schema.StringAttribute{
+				Description: "Only return entries recorded at or after this RFC 3339 timestamp (e.g. '2026-01-01T00:00:00Z').",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of entries to return. Defaults to %d. Range: 1-10000.", defaultAuditLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 10000),
+				},
+			},
+			"entries": schema.ListNestedAttribute{
+				Description: "Matching audit entries, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"audit_id": schema.StringAttribute{
+							Description: "Unique ID of the entry.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "RFC 3339 time the event was recorded.",
+							Computed:    true,
+						},
+						"service": schema.StringAttribute{
+							Description: "Service that recorded the event.",
+							Computed:    true,
+						},
+						"event": schema.StringAttribute{
+							Description: "Event type.",
+							Computed:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "User that caused the event, or null.",
+							Computed:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "Remote address of the session, or null.",
+							Computed:    true,
+						},
+						"success": schema.BoolAttribute{
+							Description: "Whether the audited operation succeeded.",
+							Computed:    true,
+						},
+						"event_data": schema.StringAttribute{
+							Description: "JSON-encoded event details, or null.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AuditDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *AuditDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuditDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters := []any{}
+	if !data.Username.IsNull() {
+		filters = append(filters, []any{"username", "=", data.Username.ValueString()})
+	}
+	if !data.Event.IsNull() {
+		filters = append(filters, []any{"event", "=", data.Event.ValueString()})
+	}
+	if !data.Since.IsNull() {
+		since, err := time.Parse(time.RFC3339, data.Since.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Since Timestamp",
+				fmt.Sprintf("Unable to parse since %q as RFC 3339: %s", data.Since.ValueString(), err.Error()),
+			)
+			return
+		}
+		filters = append(filters, []any{"message_timestamp", ">=", since.Unix()})
+	}
+
+	queryServices := auditServices
+	if len(data.Services) > 0 {
+		queryServices = make([]string, len(data.Services))
+		for i, s := range data.Services {
+			queryServices[i] = s.ValueString()
+		}
+	}
+
+	limit := int64(defaultAuditLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	params := map[string]any{
+		"services":      queryServices,
+		"query-filters": filters,
+		"query-options": map[string]any{
+			"order_by": []string{"-message_timestamp"},
+			"limit":    limit,
+		},
+	}
+
+	result, err := d.services.Client.Call(ctx, "audit.query", []any{params})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Audit Log",
+			fmt.Sprintf("Unable to query audit log: %s", err.Error()),
+		)
+		return
+	}
+
+	var entries []auditEntryResponse
+	if err := json.Unmarshal(result, &entries); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse audit log response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Entries = make([]AuditModel, len(entries))
+	for i := range entries {
+		data.Entries[i] = mapAuditEntryToModel(&entries[i])
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapAuditEntryToModel maps an audit.query entry to the audit model.
+func mapAuditEntryToModel(entry *auditEntryResponse) AuditModel {
+	model := AuditModel{
+		AuditID:   types.StringValue(entry.AuditID),
+		Timestamp: types.StringValue(time.Unix(entry.MessageTimestamp, 0).UTC().Format(time.RFC3339)),
+		Service:   types.StringValue(entry.Service),
+		Event:     types.StringValue(entry.Event),
+		Username:  types.StringPointerValue(entry.Username),
+		Address:   types.StringPointerValue(entry.Address),
+		Success:   types.BoolValue(entry.Success),
+		EventData: types.StringNull(),
+	}
+	if len(entry.EventData) > 0 && string(entry.EventData) != "null" {
+		model.EventData = types.StringValue(string(entry.EventData))
+	}
+	return model
+}
@@ -0,0 +1,311 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewAuditDataSource(t *testing.T) {
+	ds := NewAuditDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	_ = datasource.DataSourceWithConfigure(ds.(*AuditDataSource))
+}
+
+func TestAuditDataSource_Metadata(t *testing.T) {
+	ds := NewAuditDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_audit" {
+		t.Errorf("expected TypeName 'truenas_audit', got %q", resp.TypeName)
+	}
+}
+
+func TestAuditDataSource_Schema(t *testing.T) {
+	ds := NewAuditDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"services", "username", "event", "since", "limit"} {
+		if !resp.Schema.Attributes[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	if !resp.Schema.Attributes["entries"].IsComputed() {
+		t.Error("expected 'entries' attribute to be computed")
+	}
+}
+
+// auditTestConfig holds the data source filters for a test read (nil for unset).
+type auditTestConfig struct {
+	Services []string
+	Username interface{}
+	Event    interface{}
+	Since    interface{}
+	Limit    interface{}
+}
+
+// createAuditTestReadRequest creates a datasource.ReadRequest with the given filters.
+func createAuditTestReadRequest(t *testing.T, c auditTestConfig) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewAuditDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	entryType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"audit_id":   tftypes.String,
+			"timestamp":  tftypes.String,
+			"service":    tftypes.String,
+			"event":      tftypes.String,
+			"username":   tftypes.String,
+			"address":    tftypes.String,
+			"success":    tftypes.Bool,
+			"event_data": tftypes.String,
+		},
+	}
+
+	servicesValue := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+	if c.Services != nil {
+		values := make([]tftypes.Value, len(c.Services))
+		for i, s := range c.Services {
+			values[i] = tftypes.NewValue(tftypes.String, s)
+		}
+		servicesValue = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"services": tftypes.List{ElementType: tftypes.String},
+			"username": tftypes.String,
+			"event":    tftypes.String,
+			"since":    tftypes.String,
+			"limit":    tftypes.Number,
+			"entries":  tftypes.List{ElementType: entryType},
+		},
+	}, map[string]tftypes.Value{
+		"services": servicesValue,
+		"username": tftypes.NewValue(tftypes.String, c.Username),
+		"event":    tftypes.NewValue(tftypes.String, c.Event),
+		"since":    tftypes.NewValue(tftypes.String, c.Since),
+		"limit":    tftypes.NewValue(tftypes.Number, c.Limit),
+		"entries":  tftypes.NewValue(tftypes.List{ElementType: entryType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func newAuditTestDataSource(t *testing.T, response string, err error, params *map[string]any) *AuditDataSource {
+	t.Helper()
+
+	return &AuditDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+					if method != "audit.query" {
+						t.Errorf("expected method 'audit.query', got %q", method)
+					}
+					if params != nil {
+						*params = p.([]any)[0].(map[string]any)
+					}
+					if err != nil {
+						return nil, err
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+}
+
+func readAudit(t *testing.T, ds *AuditDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+// testAuditJSON holds a middleware call and a sudo entry without address.
+// 1767225600 is 2026-01-01T00:00:00Z.
+const testAuditJSON = `[
+	{
+		"audit_id": "5c3b1f2e-0c1d-4b6e-9a7f-2d8e4f6a1b3c",
+		"message_timestamp": 1767229200,
+		"timestamp": {"$date": 1767229200000},
+		"address": "192.168.1.10",
+		"username": "admin",
+		"session": "7f0c",
+		"service": "MIDDLEWARE",
+		"service_data": {"vers": {"major": 0, "minor": 1}},
+		"event": "METHOD_CALL",
+		"event_data": {"method": "user.update", "authenticated": true},
+		"success": true
+	},
+	{
+		"audit_id": "9e8d7c6b-5a49-4382-8170-6f5e4d3c2b1a",
+		"message_timestamp": 1767225600,
+		"timestamp": {"$date": 1767225600000},
+		"address": null,
+		"username": null,
+		"session": null,
+		"service": "SUDO",
+		"service_data": null,
+		"event": "ACCEPT",
+		"event_data": null,
+		"success": false
+	}
+]`
+
+func TestAuditDataSource_Read_Defaults(t *testing.T) {
+	var params map[string]any
+	ds := newAuditTestDataSource(t, testAuditJSON, nil, &params)
+
+	resp := readAudit(t, ds, createAuditTestReadRequest(t, auditTestConfig{}))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if svcs := params["services"].([]string); len(svcs) != 3 {
+		t.Errorf("expected all services, got %v", svcs)
+	}
+	if filters := params["query-filters"].([]any); len(filters) != 0 {
+		t.Errorf("expected no filters, got %v", filters)
+	}
+	if options := params["query-options"].(map[string]any); options["limit"] != int64(defaultAuditLimit) {
+		t.Errorf("expected default limit, got %v", options["limit"])
+	}
+
+	var model AuditDataSourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	if len(model.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(model.Entries))
+	}
+
+	call := model.Entries[0]
+	if call.Timestamp.ValueString() != "2026-01-01T01:00:00Z" {
+		t.Errorf("expected timestamp '2026-01-01T01:00:00Z', got %q", call.Timestamp.ValueString())
+	}
+	if call.Username.ValueString() != "admin" || call.Address.ValueString() != "192.168.1.10" {
+		t.Errorf("unexpected user or address: %+v", call)
+	}
+	if call.EventData.ValueString() != `{"method": "user.update", "authenticated": true}` {
+		t.Errorf("expected JSON event data, got %q", call.EventData.ValueString())
+	}
+	if !call.Success.ValueBool() {
+		t.Error("expected success to be true")
+	}
+
+	sudo := model.Entries[1]
+	if !sudo.Username.IsNull() || !sudo.Address.IsNull() || !sudo.EventData.IsNull() {
+		t.Errorf("expected null username, address and event data, got %+v", sudo)
+	}
+}
+
+func TestAuditDataSource_Read_Filters(t *testing.T) {
+	var params map[string]any
+	ds := newAuditTestDataSource(t, `[]`, nil, &params)
+
+	resp := readAudit(t, ds, createAuditTestReadRequest(t, auditTestConfig{
+		Services: []string{"SMB"},
+		Username: "alice",
+		Event:    "AUTHENTICATION",
+		Since:    "2026-01-01T00:00:00Z",
+		Limit:    float64(10),
+	}))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if svcs := params["services"].([]string); len(svcs) != 1 || svcs[0] != "SMB" {
+		t.Errorf("expected services [SMB], got %v", svcs)
+	}
+	filters := params["query-filters"].([]any)
+	if len(filters) != 3 {
+		t.Fatalf("expected 3 filters, got %v", filters)
+	}
+	if f := filters[0].([]any); f[0] != "username" || f[2] != "alice" {
+		t.Errorf("unexpected username filter: %v", f)
+	}
+	if f := filters[1].([]any); f[0] != "event" || f[2] != "AUTHENTICATION" {
+		t.Errorf("unexpected event filter: %v", f)
+	}
+	if f := filters[2].([]any); f[0] != "message_timestamp" || f[1] != ">=" || f[2] != int64(1767225600) {
+		t.Errorf("unexpected since filter: %v", f)
+	}
+	if options := params["query-options"].(map[string]any); options["limit"] != int64(10) {
+		t.Errorf("expected limit 10, got %v", options["limit"])
+	}
+
+	var model AuditDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Entries == nil || len(model.Entries) != 0 {
+		t.Errorf("expected empty entry list, got %v", model.Entries)
+	}
+}
+
+func TestAuditDataSource_Read_InvalidSince(t *testing.T) {
+	ds := newAuditTestDataSource(t, testAuditJSON, nil, nil)
+
+	resp := readAudit(t, ds, createAuditTestReadRequest(t, auditTestConfig{Since: "yesterday"}))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid since timestamp")
+	}
+}
+
+func TestAuditDataSource_Read_APIError(t *testing.T) {
+	ds := newAuditTestDataSource(t, "", errors.New("connection refused"), nil)
+
+	resp := readAudit(t, ds, createAuditTestReadRequest(t, auditTestConfig{}))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
@@ -0,0 +1,333 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &DiscoveryDataSource{}
+var _ datasource.DataSourceWithConfigure = &DiscoveryDataSource{}
+
+// Object kinds that the discovery data source can enumerate.
+const (
+	discoveryKindVM          = "vm"
+	discoveryKindDataset     = "dataset"
+	discoveryKindZvol        = "zvol"
+	discoveryKindApp         = "app"
+	discoveryKindWebDAVShare = "webdav_share"
+)
+
+// discoveryKinds lists all kinds in the order they are emitted.
+var discoveryKinds = []string{
+	discoveryKindVM,
+	discoveryKindDataset,
+	discoveryKindZvol,
+	discoveryKindApp,
+	discoveryKindWebDAVShare,
+}
+
+// DiscoveryDataSource defines the data source implementation.
+type DiscoveryDataSource struct {
+	services *services.TrueNASServices
+}
+
+// DiscoveryDataSourceModel describes the data source data model.
+type DiscoveryDataSourceModel struct {
+	ID           types.String              `tfsdk:"id"`
+	Include      []types.String            `tfsdk:"include"`
+	Resources    []DiscoveredResourceModel `tfsdk:"resources"`
+	ImportBlocks types.String              `tfsdk:"import_blocks"`
+}
+
+// DiscoveredResourceModel represents an existing object that can be imported.
+type DiscoveredResourceModel struct {
+	Type        types.String `tfsdk:"type"`
+	Name        types.String `tfsdk:"name"`
+	ImportID    types.String `tfsdk:"import_id"`
+	Address     types.String `tfsdk:"address"`
+	ImportBlock types.String `tfsdk:"import_block"`
+}
+
+// discoveredObject is an object found on the system, before it is mapped to the model.
+type discoveredObject struct {
+	resourceType string
+	name         string
+	importID     string
+}
+
+// NewDiscoveryDataSource creates a new DiscoveryDataSource.
+func NewDiscoveryDataSource() datasource.DataSource {
+	return &DiscoveryDataSource{}
+}
+
+func (d *DiscoveryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_discovery"
+}
+
+func (d *DiscoveryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enumerates existing objects on a TrueNAS system that this provider can manage and emits " +
+			"Terraform import blocks for them, to speed up adopting an existing system. Write `import_blocks` to a " +
+			"file and run `terraform plan -generate-config-out=generated.tf` to generate matching resource configuration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier.",
+				Computed:    true,
+			},
+			"include": schema.ListAttribute{
+				Description: "Kinds of objects to discover: vm, dataset, zvol, app, webdav_share. Defaults to all.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(discoveryKinds...)),
+				},
+			},
+			"resources": schema.ListNestedAttribute{
+				Description: "Discovered objects, grouped by kind in the order listed above and sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Resource type that manages the object (e.g. truenas_vm).",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Object name on the system.",
+							Computed:    true,
+						},
+						"import_id": schema.StringAttribute{
+							Description: "ID to pass to terraform import.",
+							Computed:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "Suggested resource address, derived from the object name.",
+							Computed:    true,
+						},
+						"import_block": schema.StringAttribute{
+							Description: "Terraform import block for the object.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"import_blocks": schema.StringAttribute{
+				Description: "All import blocks, ready to be written to a .tf file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DiscoveryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *DiscoveryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DiscoveryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	included := map[string]bool{}
+	for _, kind := range data.Include {
+		included[kind.ValueString()] = true
+	}
+
+	var objects []discoveredObject
+	for _, kind := range discoveryKinds {
+		if len(included) > 0 && !included[kind] {
+			continue
+		}
+
+		found, err := d.discover(ctx, kind)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Discover Resources",
+				fmt.Sprintf("Unable to list %s objects: %s", kind, err.Error()),
+			)
+			return
+		}
+
+		sort.Slice(found, func(i, j int) bool {
+			return found[i].name < found[j].name
+		})
+		objects = append(objects, found...)
+	}
+
+	data.ID = types.StringValue("discovery")
+	data.Resources = []DiscoveredResourceModel{}
+
+	var blocks []string
+	used := map[string]int{}
+	for _, obj := range objects {
+		address := obj.resourceType + "." + discoveryResourceName(obj.name)
+		used[address]++
+		if used[address] > 1 {
+			address = fmt.Sprintf("%s_%d", address, used[address])
+		}
+		block := fmt.Sprintf("import {\n  to = %s\n  id = %s\n}\n", address, strconv.Quote(obj.importID))
+
+		data.Resources = append(data.Resources, DiscoveredResourceModel{
+			Type:        types.StringValue(obj.resourceType),
+			Name:        types.StringValue(obj.name),
+			ImportID:    types.StringValue(obj.importID),
+			Address:     types.StringValue(address),
+			ImportBlock: types.StringValue(block),
+		})
+		blocks = append(blocks, block)
+	}
+	data.ImportBlocks = types.StringValue(strings.Join(blocks, "\n"))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// discover lists the objects of a single kind.
+func (d *DiscoveryDataSource) discover(ctx context.Context, kind string) ([]discoveredObject, error) {
+	switch kind {
+	case discoveryKindVM:
+		var vms []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := d.query(ctx, "vm.query", nil, &vms); err != nil {
+			return nil, err
+		}
+		objects := make([]discoveredObject, 0, len(vms))
+		for _, vm := range vms {
+			objects = append(objects, discoveredObject{"truenas_vm", vm.Name, strconv.FormatInt(vm.ID, 10)})
+		}
+		return objects, nil
+
+	case discoveryKindDataset, discoveryKindZvol:
+		datasetType, resourceType := "FILESYSTEM", "truenas_dataset"
+		if kind == discoveryKindZvol {
+			datasetType, resourceType = "VOLUME", "truenas_zvol"
+		}
+		params := []any{
+			[]any{[]any{"type", "=", datasetType}},
+			map[string]any{"extra": map[string]any{"flat": true, "retrieve_children": false}},
+		}
+		var datasets []struct {
+			ID string `json:"id"`
+		}
+		if err := d.query(ctx, "pool.dataset.query", params, &datasets); err != nil {
+			return nil, err
+		}
+		objects := make([]discoveredObject, 0, len(datasets))
+		for _, ds := range datasets {
+			if !discoveryManagedDataset(ds.ID) {
+				continue
+			}
+			objects = append(objects, discoveredObject{resourceType, ds.ID, ds.ID})
+		}
+		return objects, nil
+
+	case discoveryKindApp:
+		var apps []struct {
+			Name string `json:"name"`
+		}
+		if err := d.query(ctx, "app.query", nil, &apps); err != nil {
+			return nil, err
+		}
+		objects := make([]discoveredObject, 0, len(apps))
+		for _, app := range apps {
+			objects = append(objects, discoveredObject{"truenas_app", app.Name, app.Name})
+		}
+		return objects, nil
+
+	case discoveryKindWebDAVShare:
+		var shares []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := d.query(ctx, "sharing.webdav.query", nil, &shares); err != nil {
+			return nil, err
+		}
+		objects := make([]discoveredObject, 0, len(shares))
+		for _, share := range shares {
+			objects = append(objects, discoveredObject{"truenas_webdav_share", share.Name, strconv.FormatInt(share.ID, 10)})
+		}
+		return objects, nil
+	}
+
+	return nil, fmt.Errorf("unknown kind %q", kind)
+}
+
+// query calls a *.query method and decodes the result into v.
+func (d *DiscoveryDataSource) query(ctx context.Context, method string, params any, v any) error {
+	result, err := d.services.Client.Call(ctx, method, params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(result, v); err != nil {
+		return fmt.Errorf("parse %s response: %w", method, err)
+	}
+
+	return nil
+}
+
+// discoveryManagedDataset reports whether a dataset should be offered for import.
+// Pool root datasets belong to the pool, and hidden datasets (e.g. tank/.system)
+// and the apps datasets (tank/ix-apps) are managed by the middleware itself.
+func discoveryManagedDataset(name string) bool {
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts[1:] {
+		if strings.HasPrefix(part, ".") || part == "ix-apps" || part == "ix-applications" {
+			return false
+		}
+	}
+	return true
+}
+
+// discoveryResourceName converts an object name into a valid Terraform resource name.
+func discoveryResourceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	result := b.String()
+	if result == "" || (result[0] >= '0' && result[0] <= '9') || result[0] == '-' {
+		result = "_" + result
+	}
+	return result
+}
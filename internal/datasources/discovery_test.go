@@ -0,0 +1,325 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewDiscoveryDataSource(t *testing.T) {
+	ds := NewDiscoveryDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	// Verify it implements the required interfaces
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*DiscoveryDataSource)
+}
+
+func TestDiscoveryDataSource_Metadata(t *testing.T) {
+	ds := NewDiscoveryDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_discovery" {
+		t.Errorf("expected TypeName 'truenas_discovery', got %q", resp.TypeName)
+	}
+}
+
+func TestDiscoveryDataSource_Schema(t *testing.T) {
+	ds := NewDiscoveryDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	if !resp.Schema.Attributes["include"].IsOptional() {
+		t.Error("expected 'include' attribute to be optional")
+	}
+	for _, name := range []string{"id", "resources", "import_blocks"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+func TestDiscoveryDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewDiscoveryDataSource().(*DiscoveryDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createDiscoveryTestReadRequest creates a datasource.ReadRequest with the given include list (nil for unset).
+func createDiscoveryTestReadRequest(t *testing.T, include []string) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewDiscoveryDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resourceType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"type":         tftypes.String,
+			"name":         tftypes.String,
+			"import_id":    tftypes.String,
+			"address":      tftypes.String,
+			"import_block": tftypes.String,
+		},
+	}
+
+	var includeValue tftypes.Value
+	if include == nil {
+		includeValue = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+	} else {
+		values := make([]tftypes.Value, len(include))
+		for i, kind := range include {
+			values[i] = tftypes.NewValue(tftypes.String, kind)
+		}
+		includeValue = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":            tftypes.String,
+			"include":       tftypes.List{ElementType: tftypes.String},
+			"resources":     tftypes.List{ElementType: resourceType},
+			"import_blocks": tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, nil),
+		"include":       includeValue,
+		"resources":     tftypes.NewValue(tftypes.List{ElementType: resourceType}, nil),
+		"import_blocks": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+// newDiscoveryTestDataSource returns a data source whose client answers each
+// query method from responses and records the methods called.
+func newDiscoveryTestDataSource(t *testing.T, responses map[string]string, calls *[]string) *DiscoveryDataSource {
+	t.Helper()
+
+	return &DiscoveryDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					if calls != nil {
+						*calls = append(*calls, method)
+					}
+					if method == "pool.dataset.query" {
+						// Answer by dataset type so datasets and zvols can be told apart
+						filter := params.([]any)[0].([]any)[0].([]any)
+						method += ":" + filter[2].(string)
+					}
+					response, ok := responses[method]
+					if !ok {
+						return nil, errors.New("unexpected method " + method)
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+}
+
+func readDiscovery(t *testing.T, ds *DiscoveryDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+var testDiscoveryResponses = map[string]string{
+	"vm.query": `[{"id": 2, "name": "web"}, {"id": 1, "name": "DB Server"}]`,
+	"pool.dataset.query:FILESYSTEM": `[
+		{"id": "tank"},
+		{"id": "tank/apps"},
+		{"id": "tank/.system"},
+		{"id": "tank/.system/cores"},
+		{"id": "tank/ix-apps"},
+		{"id": "tank/media"}
+	]`,
+	"pool.dataset.query:VOLUME": `[{"id": "tank/vms/web-disk0"}]`,
+	"app.query":                 `[{"name": "web"}]`,
+	"sharing.webdav.query":      `[{"id": 3, "name": "media"}]`,
+}
+
+func TestDiscoveryDataSource_Read_All(t *testing.T) {
+	ds := newDiscoveryTestDataSource(t, testDiscoveryResponses, nil)
+
+	resp := readDiscovery(t, ds, createDiscoveryTestReadRequest(t, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model DiscoveryDataSourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	expected := []struct {
+		address  string
+		importID string
+	}{
+		{"truenas_vm.db_server", "1"},
+		{"truenas_vm.web", "2"},
+		{"truenas_dataset.tank_apps", "tank/apps"},
+		{"truenas_dataset.tank_media", "tank/media"},
+		{"truenas_zvol.tank_vms_web-disk0", "tank/vms/web-disk0"},
+		{"truenas_app.web", "web"},
+		{"truenas_webdav_share.media", "3"},
+	}
+
+	if len(model.Resources) != len(expected) {
+		t.Fatalf("expected %d resources, got %d: %v", len(expected), len(model.Resources), model.Resources)
+	}
+	for i, want := range expected {
+		got := model.Resources[i]
+		if got.Address.ValueString() != want.address {
+			t.Errorf("resource %d: expected address %q, got %q", i, want.address, got.Address.ValueString())
+		}
+		if got.ImportID.ValueString() != want.importID {
+			t.Errorf("resource %d: expected import_id %q, got %q", i, want.importID, got.ImportID.ValueString())
+		}
+	}
+
+	wantBlock := "import {\n  to = truenas_vm.db_server\n  id = \"1\"\n}\n"
+	if model.Resources[0].ImportBlock.ValueString() != wantBlock {
+		t.Errorf("expected import block %q, got %q", wantBlock, model.Resources[0].ImportBlock.ValueString())
+	}
+	if model.Resources[0].Type.ValueString() != "truenas_vm" {
+		t.Errorf("expected type 'truenas_vm', got %q", model.Resources[0].Type.ValueString())
+	}
+	if model.Resources[0].Name.ValueString() != "DB Server" {
+		t.Errorf("expected name 'DB Server', got %q", model.Resources[0].Name.ValueString())
+	}
+	if model.ImportBlocks.ValueString() == "" {
+		t.Error("expected non-empty import_blocks")
+	}
+}
+
+func TestDiscoveryDataSource_Read_Include(t *testing.T) {
+	var calls []string
+	ds := newDiscoveryTestDataSource(t, testDiscoveryResponses, &calls)
+
+	resp := readDiscovery(t, ds, createDiscoveryTestReadRequest(t, []string{"webdav_share"}))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(calls) != 1 || calls[0] != "sharing.webdav.query" {
+		t.Errorf("expected only sharing.webdav.query to be called, got %v", calls)
+	}
+
+	var model DiscoveryDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	want := "import {\n  to = truenas_webdav_share.media\n  id = \"3\"\n}\n"
+	if model.ImportBlocks.ValueString() != want {
+		t.Errorf("expected import_blocks %q, got %q", want, model.ImportBlocks.ValueString())
+	}
+}
+
+func TestDiscoveryDataSource_Read_DuplicateAddresses(t *testing.T) {
+	ds := newDiscoveryTestDataSource(t, map[string]string{
+		"vm.query": `[{"id": 1, "name": "web.1"}, {"id": 2, "name": "web 1"}]`,
+	}, nil)
+
+	resp := readDiscovery(t, ds, createDiscoveryTestReadRequest(t, []string{"vm"}))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model DiscoveryDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if len(model.Resources) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(model.Resources))
+	}
+	if model.Resources[0].Address.ValueString() != "truenas_vm.web_1" {
+		t.Errorf("expected address 'truenas_vm.web_1', got %q", model.Resources[0].Address.ValueString())
+	}
+	if model.Resources[1].Address.ValueString() != "truenas_vm.web_1_2" {
+		t.Errorf("expected address 'truenas_vm.web_1_2', got %q", model.Resources[1].Address.ValueString())
+	}
+}
+
+func TestDiscoveryDataSource_Read_APIError(t *testing.T) {
+	ds := newDiscoveryTestDataSource(t, map[string]string{}, nil)
+
+	resp := readDiscovery(t, ds, createDiscoveryTestReadRequest(t, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestDiscoveryResourceName(t *testing.T) {
+	tests := map[string]string{
+		"web":        "web",
+		"DB Server":  "db_server",
+		"tank/media": "tank_media",
+		"web-01":     "web-01",
+		"1password":  "_1password",
+		"":           "_",
+	}
+
+	for name, want := range tests {
+		if got := discoveryResourceName(name); got != want {
+			t.Errorf("discoveryResourceName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &EnclosureDataSource{}
+var _ datasource.DataSourceWithConfigure = &EnclosureDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &EnclosureDataSource{}
+
+// enclosureSlotElementType is the enclosure2.query element group that holds disk slots.
+const enclosureSlotElementType = "Array Device Slot"
+
+// EnclosureDataSource defines the data source implementation.
+type EnclosureDataSource struct {
+	services *services.TrueNASServices
+}
+
+// EnclosureDataSourceModel describes the data source data model.
+type EnclosureDataSourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Name       types.String            `tfsdk:"name"`
+	Model      types.String            `tfsdk:"model"`
+	Controller types.Bool              `tfsdk:"controller"`
+	Status     []types.String          `tfsdk:"status"`
+	Slots      []EnclosureSlotModel    `tfsdk:"slots"`
+	SlotDisks  map[string]types.String `tfsdk:"slot_disks"`
+	Elements   []EnclosureElementModel `tfsdk:"elements"`
+}
+
+// EnclosureSlotModel represents a disk slot of the enclosure.
+type EnclosureSlotModel struct {
+	Slot          types.Int64  `tfsdk:"slot"`
+	Descriptor    types.String `tfsdk:"descriptor"`
+	Status        types.String `tfsdk:"status"`
+	Disk          types.String `tfsdk:"disk"`
+	Pool          types.String `tfsdk:"pool"`
+	IdentifyLight types.String `tfsdk:"identify_light"`
+}
+
+// EnclosureElementModel represents a non-slot element such as a fan or power supply.
+type EnclosureElementModel struct {
+	Type       types.String `tfsdk:"type"`
+	Key        types.String `tfsdk:"key"`
+	Descriptor types.String `tfsdk:"descriptor"`
+	Status     types.String `tfsdk:"status"`
+	Value      types.String `tfsdk:"value"`
+}
+
+// enclosureResponse is the subset of enclosure2.query fields used by the data source.
+type enclosureResponse struct {
+	ID         string                                         `json:"id"`
+	Name       string                                         `json:"name"`
+	Model      string                                         `json:"model"`
+	Controller bool                                           `json:"controller"`
+	Status     []string                                       `json:"status"`
+	Elements   map[string]map[string]enclosureElementResponse `json:"elements"`
+}
+
+type enclosureElementResponse struct {
+	Descriptor          string  `json:"descriptor"`
+	Status              string  `json:"status"`
+	Value               *string `json:"value"`
+	Dev                 *string `json:"dev"`
+	DriveBayLightStatus *string `json:"drive_bay_light_status"`
+	PoolInfo            *struct {
+		PoolName string `json:"pool_name"`
+	} `json:"pool_info"`
+}
+
+// NewEnclosureDataSource creates a new EnclosureDataSource.
+func NewEnclosureDataSource() datasource.DataSource {
+	return &EnclosureDataSource{}
+}
+
+func (d *EnclosureDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_enclosure"
+}
+
+func (d *EnclosureDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves a disk enclosure on TrueNAS hardware, mapping physical slots to disks so pools can be " +
+			"built by slot position. Selects the enclosure by id or name; when neither is set, the system must have " +
+			"exactly one enclosure.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Enclosure ID as reported by enclosure2.query.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Enclosure name (e.g. 'iX 4024Sp e001').",
+				Optional:    true,
+				Computed:    true,
+			},
+			"model": schema.StringAttribute{
+				Description: "Enclosure model.",
+				Computed:    true,
+			},
+			"controller": schema.BoolAttribute{
+				Description: "Whether this enclosure is the head unit containing the controller.",
+				Computed:    true,
+			},
+			"status": schema.ListAttribute{
+				Description: "Overall enclosure status values (e.g. OK, CRITICAL).",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"slots": schema.ListNestedAttribute{
+				Description: "Disk slots, sorted by slot number.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slot": schema.Int64Attribute{
+							Description: "Slot number.",
+							Computed:    true,
+						},
+						"descriptor": schema.StringAttribute{
+							Description: "Slot descriptor reported by the enclosure.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Element status (e.g. OK, Not installed).",
+							Computed:    true,
+						},
+						"disk": schema.StringAttribute{
+							Description: "Disk device name in the slot (e.g. 'sda'), or null if empty.",
+							Computed:    true,
+						},
+						"pool": schema.StringAttribute{
+							Description: "Pool the disk belongs to, or null.",
+							Computed:    true,
+						},
+						"identify_light": schema.StringAttribute{
+							Description: "Drive bay identification light state (ON or OFF), or null if unsupported.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"slot_disks": schema.MapAttribute{
+				Description: "Map of slot number to disk device name for occupied slots.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"elements": schema.ListNestedAttribute{
+				Description: "Other enclosure elements (cooling, power supplies, temperature sensors, ...), sorted by type and key.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Element type (e.g. Cooling, Power Supply).",
+							Computed:    true,
+						},
+						"key": schema.StringAttribute{
+							Description: "Element key within its type.",
+							Computed:    true,
+						},
+						"descriptor": schema.StringAttribute{
+							Description: "Element descriptor.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Element status.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Element reading (e.g. '4900 RPM'), or null.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EnclosureDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *EnclosureDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *EnclosureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EnclosureDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.services.Client.Call(ctx, "enclosure2.query", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Enclosures",
+			fmt.Sprintf("Unable to query enclosures: %s", err.Error()),
+		)
+		return
+	}
+
+	var enclosures []enclosureResponse
+	if err := json.Unmarshal(result, &enclosures); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse enclosure response: %s", err.Error()),
+		)
+		return
+	}
+
+	var enclosure *enclosureResponse
+	switch {
+	case !data.ID.IsNull():
+		for i := range enclosures {
+			if enclosures[i].ID == data.ID.ValueString() {
+				enclosure = &enclosures[i]
+				break
+			}
+		}
+	case !data.Name.IsNull():
+		for i := range enclosures {
+			if enclosures[i].Name == data.Name.ValueString() {
+				enclosure = &enclosures[i]
+				break
+			}
+		}
+	default:
+		if len(enclosures) != 1 {
+			resp.Diagnostics.AddError(
+				"Ambiguous Enclosure",
+				fmt.Sprintf("Found %d enclosures. Set id or name to select one.", len(enclosures)),
+			)
+			return
+		}
+		enclosure = &enclosures[0]
+	}
+
+	if enclosure == nil {
+		resp.Diagnostics.AddError(
+			"Enclosure Not Found",
+			"No enclosure matches the given id or name.",
+		)
+		return
+	}
+
+	mapEnclosureToModel(enclosure, &data)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapEnclosureToModel maps an enclosure2.query entry to the data source model.
+func mapEnclosureToModel(enc *enclosureResponse, data *EnclosureDataSourceModel) {
+	data.ID = types.StringValue(enc.ID)
+	data.Name = types.StringValue(enc.Name)
+	data.Model = types.StringValue(enc.Model)
+	data.Controller = types.BoolValue(enc.Controller)
+
+	data.Status = make([]types.String, len(enc.Status))
+	for i, s := range enc.Status {
+		data.Status[i] = types.StringValue(s)
+	}
+
+	data.Slots = []EnclosureSlotModel{}
+	data.SlotDisks = map[string]types.String{}
+	for key, el := range enc.Elements[enclosureSlotElementType] {
+		slot, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		model := EnclosureSlotModel{
+			Slot:          types.Int64Value(slot),
+			Descriptor:    types.StringValue(el.Descriptor),
+			Status:        types.StringValue(el.Status),
+			Disk:          types.StringPointerValue(el.Dev),
+			Pool:          types.StringNull(),
+			IdentifyLight: types.StringPointerValue(el.DriveBayLightStatus),
+		}
+		if el.PoolInfo != nil && el.PoolInfo.PoolName != "" {
+			model.Pool = types.StringValue(el.PoolInfo.PoolName)
+		}
+		if el.Dev != nil && *el.Dev != "" {
+			data.SlotDisks[key] = types.StringValue(*el.Dev)
+		}
+		data.Slots = append(data.Slots, model)
+	}
+	sort.Slice(data.Slots, func(i, j int) bool {
+		return data.Slots[i].Slot.ValueInt64() < data.Slots[j].Slot.ValueInt64()
+	})
+
+	data.Elements = []EnclosureElementModel{}
+	for elementType, elements := range enc.Elements {
+		if elementType == enclosureSlotElementType {
+			continue
+		}
+		for key, el := range elements {
+			data.Elements = append(data.Elements, EnclosureElementModel{
+				Type:       types.StringValue(elementType),
+				Key:        types.StringValue(key),
+				Descriptor: types.StringValue(el.Descriptor),
+				Status:     types.StringValue(el.Status),
+				Value:      types.StringPointerValue(el.Value),
+			})
+		}
+	}
+	sort.Slice(data.Elements, func(i, j int) bool {
+		a, b := data.Elements[i], data.Elements[j]
+		if a.Type.ValueString() != b.Type.ValueString() {
+			return a.Type.ValueString() < b.Type.ValueString()
+		}
+		return a.Key.ValueString() < b.Key.ValueString()
+	})
+}
@@ -0,0 +1,338 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewEnclosureDataSource(t *testing.T) {
+	ds := NewEnclosureDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	// Verify it implements the required interfaces
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*EnclosureDataSource)
+	var _ datasource.DataSourceWithConfigValidators = ds.(*EnclosureDataSource)
+}
+
+func TestEnclosureDataSource_Metadata(t *testing.T) {
+	ds := NewEnclosureDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_enclosure" {
+		t.Errorf("expected TypeName 'truenas_enclosure', got %q", resp.TypeName)
+	}
+}
+
+func TestEnclosureDataSource_Schema(t *testing.T) {
+	ds := NewEnclosureDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"id", "name"} {
+		if !resp.Schema.Attributes[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	for _, name := range []string{"model", "controller", "status", "slots", "slot_disks", "elements"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+func TestEnclosureDataSource_ConfigValidators(t *testing.T) {
+	ds := NewEnclosureDataSource().(*EnclosureDataSource)
+
+	if len(ds.ConfigValidators(context.Background())) == 0 {
+		t.Error("expected config validators")
+	}
+}
+
+func TestEnclosureDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewEnclosureDataSource().(*EnclosureDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createEnclosureTestReadRequest creates a datasource.ReadRequest selecting by id and/or name (nil for unset).
+func createEnclosureTestReadRequest(t *testing.T, id, name interface{}) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewEnclosureDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	slotType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"slot":           tftypes.Number,
+			"descriptor":     tftypes.String,
+			"status":         tftypes.String,
+			"disk":           tftypes.String,
+			"pool":           tftypes.String,
+			"identify_light": tftypes.String,
+		},
+	}
+	elementType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"type":       tftypes.String,
+			"key":        tftypes.String,
+			"descriptor": tftypes.String,
+			"status":     tftypes.String,
+			"value":      tftypes.String,
+		},
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"name":       tftypes.String,
+			"model":      tftypes.String,
+			"controller": tftypes.Bool,
+			"status":     tftypes.List{ElementType: tftypes.String},
+			"slots":      tftypes.List{ElementType: slotType},
+			"slot_disks": tftypes.Map{ElementType: tftypes.String},
+			"elements":   tftypes.List{ElementType: elementType},
+		},
+	}, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, id),
+		"name":       tftypes.NewValue(tftypes.String, name),
+		"model":      tftypes.NewValue(tftypes.String, nil),
+		"controller": tftypes.NewValue(tftypes.Bool, nil),
+		"status":     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"slots":      tftypes.NewValue(tftypes.List{ElementType: slotType}, nil),
+		"slot_disks": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		"elements":   tftypes.NewValue(tftypes.List{ElementType: elementType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+const testEnclosureQueryJSON = `[
+	{
+		"id": "5b0bd6d1a30714bf",
+		"name": "iX 4024Sp e001",
+		"model": "M40",
+		"controller": true,
+		"status": ["OK"],
+		"elements": {
+			"Array Device Slot": {
+				"10": {"descriptor": "slot10", "status": "Not installed", "value": "None", "dev": null, "pool_info": null, "drive_bay_light_status": null},
+				"2": {"descriptor": "slot02", "status": "OK", "value": "None", "dev": "sdb", "pool_info": null, "drive_bay_light_status": "OFF"},
+				"1": {"descriptor": "slot01", "status": "OK", "value": "None", "dev": "sda", "pool_info": {"pool_name": "tank", "disk_status": "ONLINE"}, "drive_bay_light_status": "ON"}
+			},
+			"Cooling": {
+				"3": {"descriptor": "Fan2", "status": "OK", "value": "4900 RPM"},
+				"2": {"descriptor": "Fan1", "status": "OK", "value": "4850 RPM"}
+			}
+		}
+	},
+	{
+		"id": "5b0bd6d1a30714c0",
+		"name": "iX ES24 e002",
+		"model": "ES24",
+		"controller": false,
+		"status": ["OK"],
+		"elements": {}
+	}
+]`
+
+func newEnclosureTestDataSource(t *testing.T, response string, err error) *EnclosureDataSource {
+	t.Helper()
+
+	return &EnclosureDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					if method != "enclosure2.query" {
+						t.Errorf("expected method 'enclosure2.query', got %q", method)
+					}
+					if err != nil {
+						return nil, err
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+}
+
+func readEnclosure(t *testing.T, ds *EnclosureDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+func TestEnclosureDataSource_Read_ByID(t *testing.T) {
+	ds := newEnclosureTestDataSource(t, testEnclosureQueryJSON, nil)
+
+	resp := readEnclosure(t, ds, createEnclosureTestReadRequest(t, "5b0bd6d1a30714bf", nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model EnclosureDataSourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	if model.Name.ValueString() != "iX 4024Sp e001" {
+		t.Errorf("expected Name 'iX 4024Sp e001', got %q", model.Name.ValueString())
+	}
+	if !model.Controller.ValueBool() {
+		t.Error("expected Controller to be true")
+	}
+	if len(model.Slots) != 3 {
+		t.Fatalf("expected 3 slots, got %d", len(model.Slots))
+	}
+	if model.Slots[0].Slot.ValueInt64() != 1 || model.Slots[1].Slot.ValueInt64() != 2 || model.Slots[2].Slot.ValueInt64() != 10 {
+		t.Errorf("expected slots sorted numerically, got %v", model.Slots)
+	}
+	if model.Slots[0].Disk.ValueString() != "sda" {
+		t.Errorf("expected slot 1 disk 'sda', got %q", model.Slots[0].Disk.ValueString())
+	}
+	if model.Slots[0].Pool.ValueString() != "tank" {
+		t.Errorf("expected slot 1 pool 'tank', got %q", model.Slots[0].Pool.ValueString())
+	}
+	if model.Slots[0].IdentifyLight.ValueString() != "ON" {
+		t.Errorf("expected slot 1 identify light 'ON', got %q", model.Slots[0].IdentifyLight.ValueString())
+	}
+	if !model.Slots[1].Pool.IsNull() {
+		t.Errorf("expected slot 2 pool to be null, got %q", model.Slots[1].Pool.ValueString())
+	}
+	if !model.Slots[2].Disk.IsNull() {
+		t.Errorf("expected empty slot disk to be null, got %q", model.Slots[2].Disk.ValueString())
+	}
+	if len(model.SlotDisks) != 2 || model.SlotDisks["2"].ValueString() != "sdb" {
+		t.Errorf("expected slot_disks {1: sda, 2: sdb}, got %v", model.SlotDisks)
+	}
+	if len(model.Elements) != 2 {
+		t.Fatalf("expected 2 non-slot elements, got %d", len(model.Elements))
+	}
+	if model.Elements[0].Key.ValueString() != "2" || model.Elements[0].Value.ValueString() != "4850 RPM" {
+		t.Errorf("expected elements sorted by key, got %v", model.Elements)
+	}
+}
+
+func TestEnclosureDataSource_Read_ByName(t *testing.T) {
+	ds := newEnclosureTestDataSource(t, testEnclosureQueryJSON, nil)
+
+	resp := readEnclosure(t, ds, createEnclosureTestReadRequest(t, nil, "iX ES24 e002"))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model EnclosureDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if model.ID.ValueString() != "5b0bd6d1a30714c0" {
+		t.Errorf("expected ID '5b0bd6d1a30714c0', got %q", model.ID.ValueString())
+	}
+	if len(model.Slots) != 0 {
+		t.Errorf("expected no slots, got %d", len(model.Slots))
+	}
+}
+
+func TestEnclosureDataSource_Read_SingleEnclosureDefault(t *testing.T) {
+	ds := newEnclosureTestDataSource(t, `[{"id": "only", "name": "Mini", "model": "MINI-3.0-XL+", "controller": true, "status": ["OK"], "elements": {}}]`, nil)
+
+	resp := readEnclosure(t, ds, createEnclosureTestReadRequest(t, nil, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model EnclosureDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if model.ID.ValueString() != "only" {
+		t.Errorf("expected ID 'only', got %q", model.ID.ValueString())
+	}
+}
+
+func TestEnclosureDataSource_Read_Ambiguous(t *testing.T) {
+	ds := newEnclosureTestDataSource(t, testEnclosureQueryJSON, nil)
+
+	resp := readEnclosure(t, ds, createEnclosureTestReadRequest(t, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when multiple enclosures exist and none is selected")
+	}
+}
+
+func TestEnclosureDataSource_Read_NotFound(t *testing.T) {
+	ds := newEnclosureTestDataSource(t, testEnclosureQueryJSON, nil)
+
+	resp := readEnclosure(t, ds, createEnclosureTestReadRequest(t, "missing", nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for unknown enclosure")
+	}
+}
+
+func TestEnclosureDataSource_Read_APIError(t *testing.T) {
+	ds := newEnclosureTestDataSource(t, "", errors.New("connection refused"))
+
+	resp := readEnclosure(t, ds, createEnclosureTestReadRequest(t, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
@@ -0,0 +1,295 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &HostFactsDataSource{}
+var _ datasource.DataSourceWithConfigure = &HostFactsDataSource{}
+
+// HostFactsDataSource defines the data source implementation.
+type HostFactsDataSource struct {
+	services *services.TrueNASServices
+}
+
+// HostFactsDataSourceModel describes the data source data model.
+type HostFactsDataSourceModel struct {
+	ID            types.String              `tfsdk:"id"`
+	Hostname      types.String              `tfsdk:"hostname"`
+	Version       types.String              `tfsdk:"version"`
+	Model         types.String              `tfsdk:"model"`
+	Cores         types.Int64               `tfsdk:"cores"`
+	PhysicalCores types.Int64               `tfsdk:"physical_cores"`
+	ECCMemory     types.Bool                `tfsdk:"ecc_memory"`
+	UptimeSeconds types.Int64               `tfsdk:"uptime_seconds"`
+	AppPool       types.String              `tfsdk:"app_pool"`
+	Pools         []HostFactsPoolModel      `tfsdk:"pools"`
+	Interfaces    []HostFactsInterfaceModel `tfsdk:"interfaces"`
+}
+
+// HostFactsPoolModel represents a storage pool summary.
+type HostFactsPoolModel struct {
+	Name           types.String `tfsdk:"name"`
+	Path           types.String `tfsdk:"path"`
+	Status         types.String `tfsdk:"status"`
+	SizeBytes      types.Int64  `tfsdk:"size_bytes"`
+	UsedBytes      types.Int64  `tfsdk:"used_bytes"`
+	AvailableBytes types.Int64  `tfsdk:"available_bytes"`
+}
+
+// HostFactsInterfaceModel represents a network interface summary.
+type HostFactsInterfaceModel struct {
+	Name        types.String   `tfsdk:"name"`
+	Type        types.String   `tfsdk:"type"`
+	Description types.String   `tfsdk:"description"`
+	MTU         types.Int64    `tfsdk:"mtu"`
+	LinkState   types.String   `tfsdk:"link_state"`
+	Addresses   []types.String `tfsdk:"addresses"`
+}
+
+// NewHostFactsDataSource creates a new HostFactsDataSource.
+func NewHostFactsDataSource() datasource.DataSource {
+	return &HostFactsDataSource{}
+}
+
+func (d *HostFactsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_facts"
+}
+
+func (d *HostFactsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a broad snapshot of the TrueNAS host in one read: system information, version, " +
+			"pool summaries, network interfaces and the apps pool. Use it instead of several smaller data " +
+			"sources when a module needs general host facts.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The host name of the system.",
+				Computed:    true,
+			},
+			"hostname": schema.StringAttribute{
+				Description: "The host name of the system.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "The TrueNAS version string (e.g., 'TrueNAS-25.04.2.4').",
+				Computed:    true,
+			},
+			"model": schema.StringAttribute{
+				Description: "The CPU model.",
+				Computed:    true,
+			},
+			"cores": schema.Int64Attribute{
+				Description: "The number of logical CPU cores.",
+				Computed:    true,
+			},
+			"physical_cores": schema.Int64Attribute{
+				Description: "The number of physical CPU cores.",
+				Computed:    true,
+			},
+			"ecc_memory": schema.BoolAttribute{
+				Description: "Whether the system has ECC memory.",
+				Computed:    true,
+			},
+			"uptime_seconds": schema.Int64Attribute{
+				Description: "Seconds since the system booted.",
+				Computed:    true,
+			},
+			"app_pool": schema.StringAttribute{
+				Description: "The pool used for apps, or null if apps are not configured.",
+				Computed:    true,
+			},
+			"pools": schema.ListNestedAttribute{
+				Description: "Storage pools on the system.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Pool name.",
+							Computed:    true,
+						},
+						"path": schema.StringAttribute{
+							Description: "Mount path of the pool.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Pool status (e.g., ONLINE, DEGRADED, OFFLINE).",
+							Computed:    true,
+						},
+						"size_bytes": schema.Int64Attribute{
+							Description: "Total size of the pool in bytes.",
+							Computed:    true,
+						},
+						"used_bytes": schema.Int64Attribute{
+							Description: "Used space in bytes.",
+							Computed:    true,
+						},
+						"available_bytes": schema.Int64Attribute{
+							Description: "Available space in bytes.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"interfaces": schema.ListNestedAttribute{
+				Description: "Network interfaces on the system.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Interface name.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Interface type (PHYSICAL, BRIDGE, LINK_AGGREGATION or VLAN).",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Interface description.",
+							Computed:    true,
+						},
+						"mtu": schema.Int64Attribute{
+							Description: "Interface MTU.",
+							Computed:    true,
+						},
+						"link_state": schema.StringAttribute{
+							Description: "Link state (e.g., LINK_STATE_UP).",
+							Computed:    true,
+						},
+						"addresses": schema.ListAttribute{
+							Description: "Configured addresses in CIDR notation.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostFactsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *HostFactsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostFactsDataSourceModel
+
+	info, err := d.services.System.GetInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Host Facts",
+			fmt.Sprintf("Unable to read system information: %s", err.Error()),
+		)
+		return
+	}
+
+	version, err := d.services.System.GetVersion(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Host Facts",
+			fmt.Sprintf("Unable to read system version: %s", err.Error()),
+		)
+		return
+	}
+
+	pools, err := d.services.Dataset.ListPools(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Host Facts",
+			fmt.Sprintf("Unable to list pools: %s", err.Error()),
+		)
+		return
+	}
+
+	ifaces, err := d.services.Interface.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Host Facts",
+			fmt.Sprintf("Unable to list network interfaces: %s", err.Error()),
+		)
+		return
+	}
+
+	docker, err := d.services.Docker.GetConfig(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Host Facts",
+			fmt.Sprintf("Unable to read apps configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(info.Hostname)
+	data.Hostname = types.StringValue(info.Hostname)
+	data.Version = types.StringValue(version)
+	data.Model = types.StringValue(info.Model)
+	data.Cores = types.Int64Value(int64(info.Cores))
+	data.PhysicalCores = types.Int64Value(int64(info.PhysicalCores))
+	data.ECCMemory = types.BoolValue(info.EccMemory)
+	data.UptimeSeconds = types.Int64Value(int64(info.UptimeSeconds))
+
+	// docker.config reports an empty pool until apps are configured
+	if docker.Pool != "" {
+		data.AppPool = types.StringValue(docker.Pool)
+	} else {
+		data.AppPool = types.StringNull()
+	}
+
+	data.Pools = make([]HostFactsPoolModel, 0, len(pools))
+	for _, pool := range pools {
+		data.Pools = append(data.Pools, HostFactsPoolModel{
+			Name:           types.StringValue(pool.Name),
+			Path:           types.StringValue(pool.Path),
+			Status:         types.StringValue(pool.Status),
+			SizeBytes:      types.Int64Value(pool.Size),
+			UsedBytes:      types.Int64Value(pool.Allocated),
+			AvailableBytes: types.Int64Value(pool.Free),
+		})
+	}
+
+	data.Interfaces = make([]HostFactsInterfaceModel, 0, len(ifaces))
+	for _, iface := range ifaces {
+		data.Interfaces = append(data.Interfaces, hostFactsInterface(iface))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hostFactsInterface maps a network interface to its summary model.
+func hostFactsInterface(iface truenas.NetworkInterface) HostFactsInterfaceModel {
+	addresses := make([]types.String, 0, len(iface.Aliases))
+	for _, alias := range iface.Aliases {
+		addresses = append(addresses, types.StringValue(fmt.Sprintf("%s/%d", alias.Address, alias.Netmask)))
+	}
+
+	return HostFactsInterfaceModel{
+		Name:        types.StringValue(iface.Name),
+		Type:        types.StringValue(string(iface.Type)),
+		Description: types.StringValue(iface.Description),
+		MTU:         types.Int64Value(int64(iface.MTU)),
+		LinkState:   types.StringValue(string(iface.State.LinkState)),
+		Addresses:   addresses,
+	}
+}
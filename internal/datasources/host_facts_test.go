@@ -0,0 +1,225 @@
+package datasources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestNewHostFactsDataSource(t *testing.T) {
+	ds := NewHostFactsDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	_ = datasource.DataSourceWithConfigure(ds.(*HostFactsDataSource))
+}
+
+func TestHostFactsDataSource_Metadata(t *testing.T) {
+	ds := NewHostFactsDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_host_facts" {
+		t.Errorf("expected TypeName 'truenas_host_facts', got %q", resp.TypeName)
+	}
+}
+
+func TestHostFactsDataSource_Schema(t *testing.T) {
+	ds := NewHostFactsDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for name, attr := range resp.Schema.Attributes {
+		if !attr.IsComputed() || attr.IsOptional() || attr.IsRequired() {
+			t.Errorf("expected '%s' attribute to be computed only", name)
+		}
+	}
+}
+
+func TestHostFactsDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewHostFactsDataSource().(*HostFactsDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// newHostFactsTestServices returns services whose mocks report a small host.
+func newHostFactsTestServices() *services.TrueNASServices {
+	return &services.TrueNASServices{
+		System: &truenas.MockSystemService{
+			GetInfoFunc: func(ctx context.Context) (*truenas.SystemInfo, error) {
+				return &truenas.SystemInfo{
+					Model:         "AMD Ryzen 5 5600G",
+					Cores:         12,
+					PhysicalCores: 6,
+					Hostname:      "nas01",
+					UptimeSeconds: 3600.7,
+					EccMemory:     true,
+				}, nil
+			},
+			GetVersionFunc: func(ctx context.Context) (string, error) {
+				return "TrueNAS-25.04.2.4", nil
+			},
+		},
+		Dataset: &truenas.MockDatasetService{
+			ListPoolsFunc: func(ctx context.Context) ([]truenas.Pool, error) {
+				return []truenas.Pool{
+					{ID: 1, Name: "tank", Path: "/mnt/tank", Status: "ONLINE", Size: 3000, Allocated: 1000, Free: 2000},
+				}, nil
+			},
+		},
+		Interface: &truenas.MockInterfaceService{
+			ListFunc: func(ctx context.Context) ([]truenas.NetworkInterface, error) {
+				return []truenas.NetworkInterface{
+					{
+						ID:    "enp1s0",
+						Name:  "enp1s0",
+						Type:  truenas.InterfaceTypePhysical,
+						MTU:   1500,
+						State: truenas.InterfaceState{LinkState: truenas.LinkStateUp},
+						Aliases: []truenas.InterfaceAlias{
+							{Type: truenas.AliasTypeINET, Address: "192.168.1.10", Netmask: 24},
+						},
+					},
+				}, nil
+			},
+		},
+		Docker: &truenas.MockDockerService{
+			GetConfigFunc: func(ctx context.Context) (*truenas.DockerConfig, error) {
+				return &truenas.DockerConfig{Pool: "tank"}, nil
+			},
+		},
+	}
+}
+
+func readHostFacts(t *testing.T, ds *HostFactsDataSource) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+	return resp
+}
+
+func TestHostFactsDataSource_Read_Success(t *testing.T) {
+	ds := &HostFactsDataSource{services: newHostFactsTestServices()}
+
+	resp := readHostFacts(t, ds)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model HostFactsDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if model.ID.ValueString() != "nas01" || model.Hostname.ValueString() != "nas01" {
+		t.Errorf("expected id and hostname 'nas01', got %q and %q", model.ID.ValueString(), model.Hostname.ValueString())
+	}
+	if model.Version.ValueString() != "TrueNAS-25.04.2.4" {
+		t.Errorf("expected version 'TrueNAS-25.04.2.4', got %q", model.Version.ValueString())
+	}
+	if model.Cores.ValueInt64() != 12 || model.PhysicalCores.ValueInt64() != 6 {
+		t.Errorf("expected 12/6 cores, got %d/%d", model.Cores.ValueInt64(), model.PhysicalCores.ValueInt64())
+	}
+	if !model.ECCMemory.ValueBool() {
+		t.Error("expected ecc_memory true")
+	}
+	if model.UptimeSeconds.ValueInt64() != 3600 {
+		t.Errorf("expected uptime_seconds 3600, got %d", model.UptimeSeconds.ValueInt64())
+	}
+	if model.AppPool.ValueString() != "tank" {
+		t.Errorf("expected app_pool 'tank', got %q", model.AppPool.ValueString())
+	}
+
+	if len(model.Pools) != 1 {
+		t.Fatalf("expected 1 pool, got %d", len(model.Pools))
+	}
+	pool := model.Pools[0]
+	if pool.Name.ValueString() != "tank" || pool.SizeBytes.ValueInt64() != 3000 ||
+		pool.UsedBytes.ValueInt64() != 1000 || pool.AvailableBytes.ValueInt64() != 2000 {
+		t.Errorf("unexpected pool: %+v", pool)
+	}
+
+	if len(model.Interfaces) != 1 {
+		t.Fatalf("expected 1 interface, got %d", len(model.Interfaces))
+	}
+	iface := model.Interfaces[0]
+	if iface.Type.ValueString() != "PHYSICAL" || iface.LinkState.ValueString() != "LINK_STATE_UP" {
+		t.Errorf("unexpected interface: %+v", iface)
+	}
+	if len(iface.Addresses) != 1 || iface.Addresses[0].ValueString() != "192.168.1.10/24" {
+		t.Errorf("expected addresses [192.168.1.10/24], got %v", iface.Addresses)
+	}
+}
+
+func TestHostFactsDataSource_Read_AppsUnconfigured(t *testing.T) {
+	svc := newHostFactsTestServices()
+	svc.Docker = &truenas.MockDockerService{
+		GetConfigFunc: func(ctx context.Context) (*truenas.DockerConfig, error) {
+			return &truenas.DockerConfig{}, nil
+		},
+	}
+	ds := &HostFactsDataSource{services: svc}
+
+	resp := readHostFacts(t, ds)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model HostFactsDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if !model.AppPool.IsNull() {
+		t.Errorf("expected null app_pool, got %v", model.AppPool)
+	}
+}
+
+func TestHostFactsDataSource_Read_APIError(t *testing.T) {
+	svc := newHostFactsTestServices()
+	svc.Interface = &truenas.MockInterfaceService{
+		ListFunc: func(ctx context.Context) ([]truenas.NetworkInterface, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	ds := &HostFactsDataSource{services: svc}
+
+	resp := readHostFacts(t, ds)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
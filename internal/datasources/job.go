@@ -0,0 +1,257 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &JobDataSource{}
+var _ datasource.DataSourceWithConfigure = &JobDataSource{}
+
+// JobDataSource defines the data source implementation.
+type JobDataSource struct {
+	services *services.TrueNASServices
+}
+
+// JobDataSourceModel describes the data source data model.
+type JobDataSourceModel struct {
+	Method types.String `tfsdk:"method"`
+	State  types.String `tfsdk:"state"`
+	Since  types.String `tfsdk:"since"`
+	Jobs   []JobModel   `tfsdk:"jobs"`
+}
+
+// JobModel represents a middleware job in the list.
+type JobModel struct {
+	ID                  types.Int64   `tfsdk:"id"`
+	Method              types.String  `tfsdk:"method"`
+	State               types.String  `tfsdk:"state"`
+	ProgressPercent     types.Float64 `tfsdk:"progress_percent"`
+	ProgressDescription types.String  `tfsdk:"progress_description"`
+	Error               types.String  `tfsdk:"error"`
+	Result              types.String  `tfsdk:"result"`
+	TimeStarted         types.String  `tfsdk:"time_started"`
+	TimeFinished        types.String  `tfsdk:"time_finished"`
+}
+
+// jobResponse is the subset of core.get_jobs fields used by the data source.
+type jobResponse struct {
+	ID       int64  `json:"id"`
+	Method   string `json:"method"`
+	State    string `json:"state"`
+	Progress struct {
+		Percent     *float64 `json:"percent"`
+		Description *string  `json:"description"`
+	} `json:"progress"`
+	Error        *string         `json:"error"`
+	Result       json.RawMessage `json:"result"`
+	TimeStarted  *jobTime        `json:"time_started"`
+	TimeFinished *jobTime        `json:"time_finished"`
+}
+
+// jobTime decodes the {"$date": <milliseconds>} timestamps returned by the middleware.
+type jobTime struct {
+	time.Time
+}
+
+func (t *jobTime) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Date int64 `json:"$date"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	t.Time = time.UnixMilli(v.Date).UTC()
+	return nil
+}
+
+// NewJobDataSource creates a new JobDataSource.
+func NewJobDataSource() datasource.DataSource {
+	return &JobDataSource{}
+}
+
+func (d *JobDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job"
+}
+
+func (d *JobDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves middleware jobs (core.get_jobs), optionally filtered by method, state and start time. " +
+			"Useful for coordinating with operations started outside Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: "Only return jobs of this method (e.g. 'pool.scrub.scrub').",
+				Optional:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "Only return jobs in this state: WAITING, RUNNING, SUCCESS, FAILED or ABORTED.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("WAITING", "RUNNING", "SUCCESS", "FAILED", "ABORTED"),
+				},
+			},
+			"since": schema.StringAttribute{
+				Description: "Only return jobs started at or after this RFC 3339 timestamp (e.g. '2026-01-01T00:00:00Z').",
+				Optional:    true,
+			},
+			"jobs": schema.ListNestedAttribute{
+				Description: "Matching jobs, ordered by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "Job ID.",
+							Computed:    true,
+						},
+						"method": schema.StringAttribute{
+							Description: "Method that started the job.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "Job state.",
+							Computed:    true,
+						},
+						"progress_percent": schema.Float64Attribute{
+							Description: "Reported progress in percent, or null.",
+							Computed:    true,
+						},
+						"progress_description": schema.StringAttribute{
+							Description: "Reported progress description, or null.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Error message of a failed job, or null.",
+							Computed:    true,
+						},
+						"result": schema.StringAttribute{
+							Description: "JSON-encoded job result, or null.",
+							Computed:    true,
+						},
+						"time_started": schema.StringAttribute{
+							Description: "RFC 3339 time the job started, or null if it is waiting.",
+							Computed:    true,
+						},
+						"time_finished": schema.StringAttribute{
+							Description: "RFC 3339 time the job finished, or null if it has not.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *JobDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *JobDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JobDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var since time.Time
+	if !data.Since.IsNull() {
+		t, err := time.Parse(time.RFC3339, data.Since.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Since Timestamp",
+				fmt.Sprintf("Unable to parse since %q as RFC 3339: %s", data.Since.ValueString(), err.Error()),
+			)
+			return
+		}
+		since = t
+	}
+
+	filters := []any{}
+	if !data.Method.IsNull() {
+		filters = append(filters, []any{"method", "=", data.Method.ValueString()})
+	}
+	if !data.State.IsNull() {
+		filters = append(filters, []any{"state", "=", data.State.ValueString()})
+	}
+
+	result, err := d.services.Client.Call(ctx, "core.get_jobs", []any{filters})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Jobs",
+			fmt.Sprintf("Unable to query jobs: %s", err.Error()),
+		)
+		return
+	}
+
+	var jobs []jobResponse
+	if err := json.Unmarshal(result, &jobs); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse jobs response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Jobs = []JobModel{}
+	for i := range jobs {
+		job := &jobs[i]
+		// Waiting jobs have no start time and are kept, as they have not started before since.
+		if !since.IsZero() && job.TimeStarted != nil && job.TimeStarted.Before(since) {
+			continue
+		}
+		data.Jobs = append(data.Jobs, mapJobToModel(job))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapJobToModel maps a core.get_jobs entry to the job model.
+func mapJobToModel(job *jobResponse) JobModel {
+	model := JobModel{
+		ID:                  types.Int64Value(job.ID),
+		Method:              types.StringValue(job.Method),
+		State:               types.StringValue(job.State),
+		ProgressPercent:     types.Float64PointerValue(job.Progress.Percent),
+		ProgressDescription: types.StringPointerValue(job.Progress.Description),
+		Error:               types.StringPointerValue(job.Error),
+		Result:              types.StringNull(),
+		TimeStarted:         types.StringNull(),
+		TimeFinished:        types.StringNull(),
+	}
+	if len(job.Result) > 0 && string(job.Result) != "null" {
+		model.Result = types.StringValue(string(job.Result))
+	}
+	if job.TimeStarted != nil {
+		model.TimeStarted = types.StringValue(job.TimeStarted.Format(time.RFC3339))
+	}
+	if job.TimeFinished != nil {
+		model.TimeFinished = types.StringValue(job.TimeFinished.Format(time.RFC3339))
+	}
+	return model
+}
@@ -0,0 +1,319 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewJobDataSource(t *testing.T) {
+	ds := NewJobDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	_ = datasource.DataSourceWithConfigure(ds.(*JobDataSource))
+}
+
+func TestJobDataSource_Metadata(t *testing.T) {
+	ds := NewJobDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_job" {
+		t.Errorf("expected TypeName 'truenas_job', got %q", resp.TypeName)
+	}
+}
+
+func TestJobDataSource_Schema(t *testing.T) {
+	ds := NewJobDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"method", "state", "since"} {
+		if !resp.Schema.Attributes[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	if !resp.Schema.Attributes["jobs"].IsComputed() {
+		t.Error("expected 'jobs' attribute to be computed")
+	}
+}
+
+func TestJobDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewJobDataSource().(*JobDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createJobTestReadRequest creates a datasource.ReadRequest with the given filters (nil for unset).
+func createJobTestReadRequest(t *testing.T, method, state, since interface{}) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewJobDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	jobType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                   tftypes.Number,
+			"method":               tftypes.String,
+			"state":                tftypes.String,
+			"progress_percent":     tftypes.Number,
+			"progress_description": tftypes.String,
+			"error":                tftypes.String,
+			"result":               tftypes.String,
+			"time_started":         tftypes.String,
+			"time_finished":        tftypes.String,
+		},
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"method": tftypes.String,
+			"state":  tftypes.String,
+			"since":  tftypes.String,
+			"jobs":   tftypes.List{ElementType: jobType},
+		},
+	}, map[string]tftypes.Value{
+		"method": tftypes.NewValue(tftypes.String, method),
+		"state":  tftypes.NewValue(tftypes.String, state),
+		"since":  tftypes.NewValue(tftypes.String, since),
+		"jobs":   tftypes.NewValue(tftypes.List{ElementType: jobType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func newJobTestDataSource(t *testing.T, response string, err error, params *any) *JobDataSource {
+	t.Helper()
+
+	return &JobDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+					if method != "core.get_jobs" {
+						t.Errorf("expected method 'core.get_jobs', got %q", method)
+					}
+					if params != nil {
+						*params = p
+					}
+					if err != nil {
+						return nil, err
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+}
+
+func readJob(t *testing.T, ds *JobDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+// testJobsJSON holds a finished, a failed and a waiting job.
+// 1767225600000 is 2026-01-01T00:00:00Z.
+const testJobsJSON = `[
+	{
+		"id": 10,
+		"method": "pool.scrub.scrub",
+		"state": "SUCCESS",
+		"progress": {"percent": 100, "description": "Scrub finished", "extra": null},
+		"result": {"errors": 0},
+		"error": null,
+		"time_started": {"$date": 1767225600000},
+		"time_finished": {"$date": 1767229200000}
+	},
+	{
+		"id": 11,
+		"method": "replication.run",
+		"state": "FAILED",
+		"progress": {"percent": null, "description": null, "extra": null},
+		"result": null,
+		"error": "[EFAULT] No snapshots to replicate",
+		"time_started": {"$date": 1767312000000},
+		"time_finished": {"$date": 1767312005000}
+	},
+	{
+		"id": 12,
+		"method": "replication.run",
+		"state": "WAITING",
+		"progress": {"percent": null, "description": null, "extra": null},
+		"result": null,
+		"error": null,
+		"time_started": null,
+		"time_finished": null
+	}
+]`
+
+func TestJobDataSource_Read_All(t *testing.T) {
+	var params any
+	ds := newJobTestDataSource(t, testJobsJSON, nil, &params)
+
+	resp := readJob(t, ds, createJobTestReadRequest(t, nil, nil, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if filters := params.([]any)[0].([]any); len(filters) != 0 {
+		t.Errorf("expected no filters, got %v", filters)
+	}
+
+	var model JobDataSourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	if len(model.Jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(model.Jobs))
+	}
+
+	done := model.Jobs[0]
+	if done.ID.ValueInt64() != 10 || done.State.ValueString() != "SUCCESS" {
+		t.Errorf("unexpected first job: %+v", done)
+	}
+	if done.ProgressPercent.ValueFloat64() != 100 {
+		t.Errorf("expected progress 100, got %f", done.ProgressPercent.ValueFloat64())
+	}
+	if done.Result.ValueString() != `{"errors": 0}` {
+		t.Errorf("expected JSON result, got %q", done.Result.ValueString())
+	}
+	if done.TimeStarted.ValueString() != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected time_started '2026-01-01T00:00:00Z', got %q", done.TimeStarted.ValueString())
+	}
+	if done.TimeFinished.ValueString() != "2026-01-01T01:00:00Z" {
+		t.Errorf("expected time_finished '2026-01-01T01:00:00Z', got %q", done.TimeFinished.ValueString())
+	}
+
+	failed := model.Jobs[1]
+	if failed.Error.ValueString() != "[EFAULT] No snapshots to replicate" {
+		t.Errorf("expected error message, got %q", failed.Error.ValueString())
+	}
+	if !failed.Result.IsNull() || !failed.ProgressPercent.IsNull() {
+		t.Error("expected null result and progress for failed job")
+	}
+
+	waiting := model.Jobs[2]
+	if !waiting.TimeStarted.IsNull() || !waiting.TimeFinished.IsNull() {
+		t.Error("expected null times for waiting job")
+	}
+}
+
+func TestJobDataSource_Read_Filters(t *testing.T) {
+	var params any
+	ds := newJobTestDataSource(t, `[]`, nil, &params)
+
+	resp := readJob(t, ds, createJobTestReadRequest(t, "replication.run", "RUNNING", nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	filters := params.([]any)[0].([]any)
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 filters, got %v", filters)
+	}
+	if f := filters[0].([]any); f[0] != "method" || f[2] != "replication.run" {
+		t.Errorf("unexpected method filter: %v", f)
+	}
+	if f := filters[1].([]any); f[0] != "state" || f[2] != "RUNNING" {
+		t.Errorf("unexpected state filter: %v", f)
+	}
+
+	var model JobDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Jobs == nil || len(model.Jobs) != 0 {
+		t.Errorf("expected empty job list, got %v", model.Jobs)
+	}
+}
+
+func TestJobDataSource_Read_Since(t *testing.T) {
+	ds := newJobTestDataSource(t, testJobsJSON, nil, nil)
+
+	resp := readJob(t, ds, createJobTestReadRequest(t, nil, nil, "2026-01-02T00:00:00Z"))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model JobDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if len(model.Jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(model.Jobs))
+	}
+	if model.Jobs[0].ID.ValueInt64() != 11 || model.Jobs[1].ID.ValueInt64() != 12 {
+		t.Errorf("expected jobs 11 and 12, got %d and %d", model.Jobs[0].ID.ValueInt64(), model.Jobs[1].ID.ValueInt64())
+	}
+}
+
+func TestJobDataSource_Read_InvalidSince(t *testing.T) {
+	ds := newJobTestDataSource(t, testJobsJSON, nil, nil)
+
+	resp := readJob(t, ds, createJobTestReadRequest(t, nil, nil, "yesterday"))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid since timestamp")
+	}
+}
+
+func TestJobDataSource_Read_APIError(t *testing.T) {
+	ds := newJobTestDataSource(t, "", errors.New("connection refused"), nil)
+
+	resp := readJob(t, ds, createJobTestReadRequest(t, nil, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
@@ -0,0 +1,187 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &NFSSharesDataSource{}
+var _ datasource.DataSourceWithConfigure = &NFSSharesDataSource{}
+
+// NFSSharesDataSource defines the data source implementation.
+type NFSSharesDataSource struct {
+	services *services.TrueNASServices
+}
+
+// NFSSharesDataSourceModel describes the data source data model.
+type NFSSharesDataSourceModel struct {
+	Path       types.String    `tfsdk:"path"`
+	PathPrefix types.String    `tfsdk:"path_prefix"`
+	Enabled    types.Bool      `tfsdk:"enabled"`
+	Shares     []NFSShareModel `tfsdk:"shares"`
+}
+
+// NFSShareModel represents an NFS export in the list.
+type NFSShareModel struct {
+	ID       types.Int64    `tfsdk:"id"`
+	Path     types.String   `tfsdk:"path"`
+	Comment  types.String   `tfsdk:"comment"`
+	Enabled  types.Bool     `tfsdk:"enabled"`
+	ReadOnly types.Bool     `tfsdk:"readonly"`
+	Networks []types.String `tfsdk:"networks"`
+	Hosts    []types.String `tfsdk:"hosts"`
+	Locked   types.Bool     `tfsdk:"locked"`
+}
+
+// nfsShareResponse is the subset of sharing.nfs.query fields used by the data source.
+type nfsShareResponse struct {
+	ID       int64    `json:"id"`
+	Path     string   `json:"path"`
+	Comment  string   `json:"comment"`
+	Enabled  bool     `json:"enabled"`
+	ReadOnly bool     `json:"ro"`
+	Networks []string `json:"networks"`
+	Hosts    []string `json:"hosts"`
+	Locked   *bool    `json:"locked"`
+}
+
+// NewNFSSharesDataSource creates a new NFSSharesDataSource.
+func NewNFSSharesDataSource() datasource.DataSource {
+	return &NFSSharesDataSource{}
+}
+
+func (d *NFSSharesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nfs_shares"
+}
+
+func (d *NFSSharesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := shareFilterAttributes()
+	attributes["shares"] = schema.ListNestedAttribute{
+		Description: "Matching NFS exports, ordered by ID.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.Int64Attribute{
+					Description: "Share ID.",
+					Computed:    true,
+				},
+				"path": schema.StringAttribute{
+					Description: "Exported path.",
+					Computed:    true,
+				},
+				"comment": schema.StringAttribute{
+					Description: "Share description.",
+					Computed:    true,
+				},
+				"enabled": schema.BoolAttribute{
+					Description: "Whether the export is enabled.",
+					Computed:    true,
+				},
+				"readonly": schema.BoolAttribute{
+					Description: "Whether the export is read-only.",
+					Computed:    true,
+				},
+				"networks": schema.ListAttribute{
+					Description: "Networks (CIDR) allowed to mount the export. Empty allows all.",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+				"hosts": schema.ListAttribute{
+					Description: "Hosts allowed to mount the export. Empty allows all.",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+				"locked": schema.BoolAttribute{
+					Description: "Whether the export's dataset is locked, or null if unknown.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Retrieves NFS exports (sharing.nfs.query), optionally filtered by path and enabled state. " +
+			"Useful for adopting existing exports and checking for path collisions.",
+		Attributes: attributes,
+	}
+}
+
+func (d *NFSSharesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *NFSSharesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NFSSharesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters := shareQueryFilters(data.Path, data.PathPrefix, data.Enabled)
+
+	result, err := d.services.Client.Call(ctx, "sharing.nfs.query", []any{filters})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read NFS Shares",
+			fmt.Sprintf("Unable to query NFS shares: %s", err.Error()),
+		)
+		return
+	}
+
+	var shares []nfsShareResponse
+	if err := json.Unmarshal(result, &shares); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse NFS shares response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Shares = make([]NFSShareModel, 0, len(shares))
+	for _, share := range shares {
+		data.Shares = append(data.Shares, NFSShareModel{
+			ID:       types.Int64Value(share.ID),
+			Path:     types.StringValue(share.Path),
+			Comment:  types.StringValue(share.Comment),
+			Enabled:  types.BoolValue(share.Enabled),
+			ReadOnly: types.BoolValue(share.ReadOnly),
+			Networks: stringValues(share.Networks),
+			Hosts:    stringValues(share.Hosts),
+			Locked:   types.BoolPointerValue(share.Locked),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// stringValues converts s to a list of string values, never nil.
+func stringValues(s []string) []types.String {
+	values := make([]types.String, 0, len(s))
+	for _, v := range s {
+		values = append(values, types.StringValue(v))
+	}
+	return values
+}
@@ -0,0 +1,266 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewNFSSharesDataSource(t *testing.T) {
+	ds := NewNFSSharesDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	_ = datasource.DataSourceWithConfigure(ds.(*NFSSharesDataSource))
+}
+
+func TestNFSSharesDataSource_Metadata(t *testing.T) {
+	ds := NewNFSSharesDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_nfs_shares" {
+		t.Errorf("expected TypeName 'truenas_nfs_shares', got %q", resp.TypeName)
+	}
+}
+
+func TestNFSSharesDataSource_Schema(t *testing.T) {
+	ds := NewNFSSharesDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"path", "path_prefix", "enabled"} {
+		if !resp.Schema.Attributes[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	if _, ok := resp.Schema.Attributes["name"]; ok {
+		t.Error("expected no 'name' filter on NFS shares")
+	}
+	if !resp.Schema.Attributes["shares"].IsComputed() {
+		t.Error("expected 'shares' attribute to be computed")
+	}
+}
+
+func TestNFSSharesDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewNFSSharesDataSource().(*NFSSharesDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createNFSSharesTestReadRequest creates a datasource.ReadRequest with the given filters (nil for unset).
+func createNFSSharesTestReadRequest(t *testing.T, path, pathPrefix, enabled interface{}) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewNFSSharesDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	shareType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.Number,
+			"path":     tftypes.String,
+			"comment":  tftypes.String,
+			"enabled":  tftypes.Bool,
+			"readonly": tftypes.Bool,
+			"networks": tftypes.List{ElementType: tftypes.String},
+			"hosts":    tftypes.List{ElementType: tftypes.String},
+			"locked":   tftypes.Bool,
+		},
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"path":        tftypes.String,
+			"path_prefix": tftypes.String,
+			"enabled":     tftypes.Bool,
+			"shares":      tftypes.List{ElementType: shareType},
+		},
+	}, map[string]tftypes.Value{
+		"path":        tftypes.NewValue(tftypes.String, path),
+		"path_prefix": tftypes.NewValue(tftypes.String, pathPrefix),
+		"enabled":     tftypes.NewValue(tftypes.Bool, enabled),
+		"shares":      tftypes.NewValue(tftypes.List{ElementType: shareType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func newNFSSharesTestDataSource(t *testing.T, response string, err error, params *any) *NFSSharesDataSource {
+	t.Helper()
+
+	return &NFSSharesDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+					if method != "sharing.nfs.query" {
+						t.Errorf("expected method 'sharing.nfs.query', got %q", method)
+					}
+					if params != nil {
+						*params = p
+					}
+					if err != nil {
+						return nil, err
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+}
+
+func readNFSShares(t *testing.T, ds *NFSSharesDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+const testNFSSharesJSON = `[
+	{
+		"id": 3,
+		"path": "/mnt/tank/k8s",
+		"aliases": [],
+		"comment": "Cluster volumes",
+		"networks": ["10.0.0.0/24"],
+		"hosts": [],
+		"ro": false,
+		"maproot_user": "root",
+		"maproot_group": "root",
+		"mapall_user": null,
+		"mapall_group": null,
+		"security": [],
+		"enabled": true,
+		"locked": false
+	}
+]`
+
+func TestNFSSharesDataSource_Read_PathPrefix(t *testing.T) {
+	var params any
+	ds := newNFSSharesTestDataSource(t, testNFSSharesJSON, nil, &params)
+
+	resp := readNFSShares(t, ds, createNFSSharesTestReadRequest(t, nil, "/mnt/tank/k8s", nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	want := []any{[]any{
+		[]any{"OR", []any{
+			[]any{"path", "=", "/mnt/tank/k8s"},
+			[]any{"path", "^", "/mnt/tank/k8s/"},
+		}},
+	}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("expected params %v, got %v", want, params)
+	}
+
+	var model NFSSharesDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if len(model.Shares) != 1 {
+		t.Fatalf("expected 1 share, got %d", len(model.Shares))
+	}
+
+	share := model.Shares[0]
+	if share.ID.ValueInt64() != 3 {
+		t.Errorf("expected id 3, got %d", share.ID.ValueInt64())
+	}
+	if share.Path.ValueString() != "/mnt/tank/k8s" {
+		t.Errorf("expected path '/mnt/tank/k8s', got %q", share.Path.ValueString())
+	}
+	if share.Comment.ValueString() != "Cluster volumes" {
+		t.Errorf("expected comment 'Cluster volumes', got %q", share.Comment.ValueString())
+	}
+	if !share.Enabled.ValueBool() || share.ReadOnly.ValueBool() {
+		t.Errorf("unexpected flags: enabled=%v readonly=%v", share.Enabled, share.ReadOnly)
+	}
+	if len(share.Networks) != 1 || share.Networks[0].ValueString() != "10.0.0.0/24" {
+		t.Errorf("expected networks [10.0.0.0/24], got %v", share.Networks)
+	}
+	if share.Hosts == nil || len(share.Hosts) != 0 {
+		t.Errorf("expected empty hosts, got %v", share.Hosts)
+	}
+}
+
+func TestNFSSharesDataSource_Read_PathAndEnabled(t *testing.T) {
+	var params any
+	ds := newNFSSharesTestDataSource(t, `[]`, nil, &params)
+
+	resp := readNFSShares(t, ds, createNFSSharesTestReadRequest(t, "/mnt/tank/k8s", nil, false))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	want := []any{[]any{
+		[]any{"path", "=", "/mnt/tank/k8s"},
+		[]any{"enabled", "=", false},
+	}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("expected params %v, got %v", want, params)
+	}
+}
+
+func TestNFSSharesDataSource_Read_APIError(t *testing.T) {
+	ds := newNFSSharesTestDataSource(t, "", errors.New("connection refused"), nil)
+
+	resp := readNFSShares(t, ds, createNFSSharesTestReadRequest(t, nil, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
+
+func TestNFSSharesDataSource_Read_InvalidJSON(t *testing.T) {
+	ds := newNFSSharesTestDataSource(t, "not json", nil, nil)
+
+	resp := readNFSShares(t, ds, createNFSSharesTestReadRequest(t, nil, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,444 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PoolStatusDataSource{}
+var _ datasource.DataSourceWithConfigure = &PoolStatusDataSource{}
+
+// poolTopologyRoles are the pool.query topology sections, in the order devices are listed.
+var poolTopologyRoles = []string{"data", "log", "cache", "spare", "special", "dedup"}
+
+// PoolStatusDataSource defines the data source implementation.
+type PoolStatusDataSource struct {
+	services *services.TrueNASServices
+}
+
+// PoolStatusDataSourceModel describes the data source data model.
+type PoolStatusDataSourceModel struct {
+	ID             types.String      `tfsdk:"id"`
+	Name           types.String      `tfsdk:"name"`
+	Status         types.String      `tfsdk:"status"`
+	Healthy        types.Bool        `tfsdk:"healthy"`
+	Warning        types.Bool        `tfsdk:"warning"`
+	StatusCode     types.String      `tfsdk:"status_code"`
+	StatusDetail   types.String      `tfsdk:"status_detail"`
+	ReadErrors     types.Int64       `tfsdk:"read_errors"`
+	WriteErrors    types.Int64       `tfsdk:"write_errors"`
+	ChecksumErrors types.Int64       `tfsdk:"checksum_errors"`
+	ScanFunction   types.String      `tfsdk:"scan_function"`
+	ScanState      types.String      `tfsdk:"scan_state"`
+	ScanStartTime  types.String      `tfsdk:"scan_start_time"`
+	ScanEndTime    types.String      `tfsdk:"scan_end_time"`
+	ScanPercentage types.Float64     `tfsdk:"scan_percentage"`
+	ScanErrors     types.Int64       `tfsdk:"scan_errors"`
+	Devices        []PoolDeviceModel `tfsdk:"devices"`
+	ScrubHistory   []PoolScrubModel  `tfsdk:"scrub_history"`
+}
+
+// PoolDeviceModel represents a vdev or disk in the pool topology.
+type PoolDeviceModel struct {
+	Role           types.String `tfsdk:"role"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	Parent         types.String `tfsdk:"parent"`
+	Disk           types.String `tfsdk:"disk"`
+	Status         types.String `tfsdk:"status"`
+	ReadErrors     types.Int64  `tfsdk:"read_errors"`
+	WriteErrors    types.Int64  `tfsdk:"write_errors"`
+	ChecksumErrors types.Int64  `tfsdk:"checksum_errors"`
+}
+
+// PoolScrubModel represents a scrub job in the scrub history.
+type PoolScrubModel struct {
+	JobID        types.Int64  `tfsdk:"job_id"`
+	State        types.String `tfsdk:"state"`
+	Error        types.String `tfsdk:"error"`
+	TimeStarted  types.String `tfsdk:"time_started"`
+	TimeFinished types.String `tfsdk:"time_finished"`
+}
+
+// poolStatusResponse is the subset of pool.query fields used by the data source.
+type poolStatusResponse struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	Healthy      bool    `json:"healthy"`
+	Warning      bool    `json:"warning"`
+	StatusCode   *string `json:"status_code"`
+	StatusDetail *string `json:"status_detail"`
+	Scan         *struct {
+		Function   *string  `json:"function"`
+		State      *string  `json:"state"`
+		StartTime  *jobTime `json:"start_time"`
+		EndTime    *jobTime `json:"end_time"`
+		Percentage *float64 `json:"percentage"`
+		Errors     *int64   `json:"errors"`
+	} `json:"scan"`
+	Topology map[string][]poolVdevResponse `json:"topology"`
+}
+
+// poolVdevResponse is a node of the pool.query topology.
+type poolVdevResponse struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Disk   *string `json:"disk"`
+	Status string  `json:"status"`
+	Stats  struct {
+		ReadErrors     int64 `json:"read_errors"`
+		WriteErrors    int64 `json:"write_errors"`
+		ChecksumErrors int64 `json:"checksum_errors"`
+	} `json:"stats"`
+	Children []poolVdevResponse `json:"children"`
+}
+
+// poolScrubJobResponse is the subset of core.get_jobs fields used for the scrub history.
+type poolScrubJobResponse struct {
+	ID           int64             `json:"id"`
+	State        string            `json:"state"`
+	Arguments    []json.RawMessage `json:"arguments"`
+	Error        *string           `json:"error"`
+	TimeStarted  *jobTime          `json:"time_started"`
+	TimeFinished *jobTime          `json:"time_finished"`
+}
+
+// NewPoolStatusDataSource creates a new PoolStatusDataSource.
+func NewPoolStatusDataSource() datasource.DataSource {
+	return &PoolStatusDataSource{}
+}
+
+func (d *PoolStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pool_status"
+}
+
+func (d *PoolStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	errorAttributes := func(of string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"read_errors": schema.Int64Attribute{
+				Description: "Read errors reported " + of + ".",
+				Computed:    true,
+			},
+			"write_errors": schema.Int64Attribute{
+				Description: "Write errors reported " + of + ".",
+				Computed:    true,
+			},
+			"checksum_errors": schema.Int64Attribute{
+				Description: "Checksum errors reported " + of + ".",
+				Computed:    true,
+			},
+		}
+	}
+
+	deviceAttributes := map[string]schema.Attribute{
+		"role": schema.StringAttribute{
+			Description: "Topology section: data, log, cache, spare, special or dedup.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "Vdev or device name (e.g., mirror-0 or sda1).",
+			Computed:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "Vdev type (e.g., MIRROR, RAIDZ1, DISK).",
+			Computed:    true,
+		},
+		"parent": schema.StringAttribute{
+			Description: "Name of the vdev containing this device, or null for top-level vdevs.",
+			Computed:    true,
+		},
+		"disk": schema.StringAttribute{
+			Description: "Disk name for DISK devices (e.g., sda), or null.",
+			Computed:    true,
+		},
+		"status": schema.StringAttribute{
+			Description: "Device state (e.g., ONLINE, DEGRADED, FAULTED, UNAVAIL).",
+			Computed:    true,
+		},
+	}
+	for name, attr := range errorAttributes("by the device") {
+		deviceAttributes[name] = attr
+	}
+
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The unique identifier of the pool.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "The name of the pool to look up.",
+			Required:    true,
+		},
+		"status": schema.StringAttribute{
+			Description: "Pool state (e.g., ONLINE, DEGRADED, FAULTED, OFFLINE).",
+			Computed:    true,
+		},
+		"healthy": schema.BoolAttribute{
+			Description: "Whether TrueNAS considers the pool healthy.",
+			Computed:    true,
+		},
+		"warning": schema.BoolAttribute{
+			Description: "Whether the pool has a warning, such as features that need a pool upgrade.",
+			Computed:    true,
+		},
+		"status_code": schema.StringAttribute{
+			Description: "ZFS status code explaining an unhealthy pool, or null.",
+			Computed:    true,
+		},
+		"status_detail": schema.StringAttribute{
+			Description: "Human-readable explanation of the pool status, or null.",
+			Computed:    true,
+		},
+		"scan_function": schema.StringAttribute{
+			Description: "Function of the last or current scan (SCRUB or RESILVER), or null if the pool was never scanned.",
+			Computed:    true,
+		},
+		"scan_state": schema.StringAttribute{
+			Description: "State of the last or current scan (SCANNING, FINISHED or CANCELED), or null.",
+			Computed:    true,
+		},
+		"scan_start_time": schema.StringAttribute{
+			Description: "RFC 3339 time the last or current scan started, or null.",
+			Computed:    true,
+		},
+		"scan_end_time": schema.StringAttribute{
+			Description: "RFC 3339 time the last scan ended, or null while it runs.",
+			Computed:    true,
+		},
+		"scan_percentage": schema.Float64Attribute{
+			Description: "Progress of the last or current scan in percent, or null.",
+			Computed:    true,
+		},
+		"scan_errors": schema.Int64Attribute{
+			Description: "Errors found by the last or current scan, or null.",
+			Computed:    true,
+		},
+		"devices": schema.ListNestedAttribute{
+			Description: "Every vdev and disk in the pool topology, parents before their children.",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: deviceAttributes,
+			},
+		},
+		"scrub_history": schema.ListNestedAttribute{
+			Description: "Scrub jobs of this pool that the middleware still remembers (jobs are kept in " +
+				"memory, so the history starts at the last middleware restart), ordered by job ID.",
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"job_id": schema.Int64Attribute{
+						Description: "Job ID.",
+						Computed:    true,
+					},
+					"state": schema.StringAttribute{
+						Description: "Job state: WAITING, RUNNING, SUCCESS, FAILED or ABORTED.",
+						Computed:    true,
+					},
+					"error": schema.StringAttribute{
+						Description: "Error message of a failed scrub, or null.",
+						Computed:    true,
+					},
+					"time_started": schema.StringAttribute{
+						Description: "RFC 3339 time the scrub started, or null if it is waiting.",
+						Computed:    true,
+					},
+					"time_finished": schema.StringAttribute{
+						Description: "RFC 3339 time the scrub finished, or null if it has not.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+	for name, attr := range errorAttributes("by the pool's disks in total") {
+		attributes[name] = attr
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Fetches the health of a TrueNAS storage pool: its state, per-device errors, the last scan " +
+			"and recent scrubs. Use it in check blocks or preconditions to stop a pipeline when the pool is degraded.",
+		Attributes: attributes,
+	}
+}
+
+func (d *PoolStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *PoolStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PoolStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	result, err := d.services.Client.Call(ctx, "pool.query", []any{[]any{[]any{"name", "=", name}}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Pool",
+			fmt.Sprintf("Unable to read pool %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	var pools []poolStatusResponse
+	if err := json.Unmarshal(result, &pools); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse pool query response: %s", err.Error()),
+		)
+		return
+	}
+
+	if len(pools) == 0 {
+		resp.Diagnostics.AddError(
+			"Pool Not Found",
+			fmt.Sprintf("Pool %q was not found.", name),
+		)
+		return
+	}
+
+	result, err = d.services.Client.Call(ctx, "core.get_jobs", []any{
+		[]any{[]any{"method", "=", "pool.scrub.scrub"}},
+		map[string]any{"order_by": []string{"id"}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Scrub History",
+			fmt.Sprintf("Unable to query scrub jobs: %s", err.Error()),
+		)
+		return
+	}
+
+	var jobs []poolScrubJobResponse
+	if err := json.Unmarshal(result, &jobs); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse jobs response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapPoolStatusToModel(&pools[0], &data)
+	data.ScrubHistory = poolScrubHistory(jobs, name)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapPoolStatusToModel maps a pool.query entry to the data source model.
+func mapPoolStatusToModel(pool *poolStatusResponse, data *PoolStatusDataSourceModel) {
+	data.ID = types.StringValue(fmt.Sprintf("%d", pool.ID))
+	data.Name = types.StringValue(pool.Name)
+	data.Status = types.StringValue(pool.Status)
+	data.Healthy = types.BoolValue(pool.Healthy)
+	data.Warning = types.BoolValue(pool.Warning)
+	data.StatusCode = types.StringPointerValue(pool.StatusCode)
+	data.StatusDetail = types.StringPointerValue(pool.StatusDetail)
+
+	data.ScanFunction = types.StringNull()
+	data.ScanState = types.StringNull()
+	data.ScanStartTime = types.StringNull()
+	data.ScanEndTime = types.StringNull()
+	data.ScanPercentage = types.Float64Null()
+	data.ScanErrors = types.Int64Null()
+	if scan := pool.Scan; scan != nil && scan.Function != nil {
+		data.ScanFunction = types.StringPointerValue(scan.Function)
+		data.ScanState = types.StringPointerValue(scan.State)
+		data.ScanStartTime = formatJobTime(scan.StartTime)
+		data.ScanEndTime = formatJobTime(scan.EndTime)
+		data.ScanPercentage = types.Float64PointerValue(scan.Percentage)
+		data.ScanErrors = types.Int64PointerValue(scan.Errors)
+	}
+
+	var readErrors, writeErrors, checksumErrors int64
+	data.Devices = []PoolDeviceModel{}
+	var walk func(role string, parent *string, vdevs []poolVdevResponse)
+	walk = func(role string, parent *string, vdevs []poolVdevResponse) {
+		for i := range vdevs {
+			vdev := &vdevs[i]
+			data.Devices = append(data.Devices, PoolDeviceModel{
+				Role:           types.StringValue(role),
+				Name:           types.StringValue(vdev.Name),
+				Type:           types.StringValue(vdev.Type),
+				Parent:         types.StringPointerValue(parent),
+				Disk:           types.StringPointerValue(vdev.Disk),
+				Status:         types.StringValue(vdev.Status),
+				ReadErrors:     types.Int64Value(vdev.Stats.ReadErrors),
+				WriteErrors:    types.Int64Value(vdev.Stats.WriteErrors),
+				ChecksumErrors: types.Int64Value(vdev.Stats.ChecksumErrors),
+			})
+			// Totals count leaf devices only; vdev counters repeat their children's.
+			if len(vdev.Children) == 0 {
+				readErrors += vdev.Stats.ReadErrors
+				writeErrors += vdev.Stats.WriteErrors
+				checksumErrors += vdev.Stats.ChecksumErrors
+			}
+			walk(role, &vdev.Name, vdev.Children)
+		}
+	}
+	for _, role := range poolTopologyRoles {
+		walk(role, nil, pool.Topology[role])
+	}
+
+	data.ReadErrors = types.Int64Value(readErrors)
+	data.WriteErrors = types.Int64Value(writeErrors)
+	data.ChecksumErrors = types.Int64Value(checksumErrors)
+}
+
+// poolScrubHistory returns the scrub jobs whose first argument is pool.
+func poolScrubHistory(jobs []poolScrubJobResponse, pool string) []PoolScrubModel {
+	history := []PoolScrubModel{}
+	for i := range jobs {
+		job := &jobs[i]
+		if len(job.Arguments) == 0 {
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(job.Arguments[0], &name); err != nil || name != pool {
+			continue
+		}
+		history = append(history, PoolScrubModel{
+			JobID:        types.Int64Value(job.ID),
+			State:        types.StringValue(job.State),
+			Error:        types.StringPointerValue(job.Error),
+			TimeStarted:  formatJobTime(job.TimeStarted),
+			TimeFinished: formatJobTime(job.TimeFinished),
+		})
+	}
+	return history
+}
+
+// formatJobTime formats an optional middleware timestamp as RFC 3339.
+func formatJobTime(t *jobTime) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
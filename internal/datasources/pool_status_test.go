@@ -0,0 +1,265 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testDegradedPoolJSON = `[{
+	"id": 1,
+	"name": "tank",
+	"status": "DEGRADED",
+	"healthy": false,
+	"warning": false,
+	"status_code": "DEGRADED",
+	"status_detail": "One or more devices are faulted.",
+	"scan": {
+		"function": "SCRUB",
+		"state": "FINISHED",
+		"start_time": {"$date": 1767225600000},
+		"end_time": {"$date": 1767229200000},
+		"percentage": 100.0,
+		"errors": 2
+	},
+	"topology": {
+		"data": [{
+			"name": "mirror-0",
+			"type": "MIRROR",
+			"disk": null,
+			"status": "DEGRADED",
+			"stats": {"read_errors": 3, "write_errors": 0, "checksum_errors": 2},
+			"children": [
+				{"name": "sda1", "type": "DISK", "disk": "sda", "status": "ONLINE", "stats": {"read_errors": 0, "write_errors": 0, "checksum_errors": 0}, "children": []},
+				{"name": "sdb1", "type": "DISK", "disk": "sdb", "status": "FAULTED", "stats": {"read_errors": 3, "write_errors": 0, "checksum_errors": 2}, "children": []}
+			]
+		}],
+		"log": [],
+		"cache": [{"name": "nvme0n1p1", "type": "DISK", "disk": "nvme0n1", "status": "ONLINE", "stats": {"read_errors": 0, "write_errors": 1, "checksum_errors": 0}, "children": []}],
+		"spare": [],
+		"special": [],
+		"dedup": []
+	}
+}]`
+
+func TestNewPoolStatusDataSource(t *testing.T) {
+	ds := NewPoolStatusDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*PoolStatusDataSource)
+}
+
+func TestPoolStatusDataSource_Metadata(t *testing.T) {
+	ds := NewPoolStatusDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_pool_status" {
+		t.Errorf("expected TypeName 'truenas_pool_status', got %q", resp.TypeName)
+	}
+}
+
+func TestPoolStatusDataSource_Schema(t *testing.T) {
+	ds := NewPoolStatusDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+	if !resp.Schema.Attributes["name"].IsRequired() {
+		t.Error("expected 'name' attribute to be required")
+	}
+	for _, name := range []string{"status", "healthy", "read_errors", "scan_function", "devices", "scrub_history"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+func readPoolStatus(t *testing.T, name string, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, PoolStatusDataSourceModel) {
+	t.Helper()
+
+	ds := &PoolStatusDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	// Only name is configured; every other attribute is null
+	configType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	configValues := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+	for attr, typ := range configType.AttributeTypes {
+		configValues[attr] = tftypes.NewValue(typ, nil)
+	}
+	configValues["name"] = tftypes.NewValue(tftypes.String, name)
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(configType, configValues),
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+
+	var model PoolStatusDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestPoolStatusDataSource_Read_Degraded(t *testing.T) {
+	resp, model := readPoolStatus(t, "tank", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		switch method {
+		case "pool.query":
+			return json.RawMessage(testDegradedPoolJSON), nil
+		case "core.get_jobs":
+			return json.RawMessage(`[
+				{"id": 40, "state": "SUCCESS", "arguments": ["tank", "START"], "error": null, "time_started": {"$date": 1767225600000}, "time_finished": {"$date": 1767229200000}},
+				{"id": 41, "state": "FAILED", "arguments": ["backup", "START"], "error": "pool is busy", "time_started": {"$date": 1767225600000}, "time_finished": null},
+				{"id": 42, "state": "RUNNING", "arguments": ["tank", "START"], "error": null, "time_started": {"$date": 1767312000000}, "time_finished": null}
+			]`), nil
+		}
+		return nil, errors.New("unexpected method " + method)
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.Status.ValueString() != "DEGRADED" || model.Healthy.ValueBool() {
+		t.Errorf("expected unhealthy DEGRADED pool, got %q (healthy=%v)", model.Status.ValueString(), model.Healthy.ValueBool())
+	}
+	if model.StatusDetail.ValueString() != "One or more devices are faulted." {
+		t.Errorf("unexpected status_detail %q", model.StatusDetail.ValueString())
+	}
+
+	// Totals count leaf devices only, not the mirror's repeated counters
+	if model.ReadErrors.ValueInt64() != 3 || model.WriteErrors.ValueInt64() != 1 || model.ChecksumErrors.ValueInt64() != 2 {
+		t.Errorf("expected 3/1/2 errors, got %d/%d/%d",
+			model.ReadErrors.ValueInt64(), model.WriteErrors.ValueInt64(), model.ChecksumErrors.ValueInt64())
+	}
+
+	if model.ScanFunction.ValueString() != "SCRUB" || model.ScanErrors.ValueInt64() != 2 {
+		t.Errorf("unexpected scan: %s with %d errors", model.ScanFunction.ValueString(), model.ScanErrors.ValueInt64())
+	}
+	if model.ScanEndTime.ValueString() != "2026-01-01T01:00:00Z" {
+		t.Errorf("expected scan_end_time '2026-01-01T01:00:00Z', got %q", model.ScanEndTime.ValueString())
+	}
+
+	wantDevices := []struct{ role, name, parent, status string }{
+		{"data", "mirror-0", "", "DEGRADED"},
+		{"data", "sda1", "mirror-0", "ONLINE"},
+		{"data", "sdb1", "mirror-0", "FAULTED"},
+		{"cache", "nvme0n1p1", "", "ONLINE"},
+	}
+	if len(model.Devices) != len(wantDevices) {
+		t.Fatalf("expected %d devices, got %d", len(wantDevices), len(model.Devices))
+	}
+	for i, want := range wantDevices {
+		got := model.Devices[i]
+		if got.Role.ValueString() != want.role || got.Name.ValueString() != want.name ||
+			got.Parent.ValueString() != want.parent || got.Status.ValueString() != want.status {
+			t.Errorf("device %d: expected %+v, got %s/%s/%s/%s", i, want,
+				got.Role.ValueString(), got.Name.ValueString(), got.Parent.ValueString(), got.Status.ValueString())
+		}
+	}
+	if !model.Devices[0].Parent.IsNull() || !model.Devices[0].Disk.IsNull() {
+		t.Error("expected top-level vdev to have null parent and disk")
+	}
+	if model.Devices[2].Disk.ValueString() != "sdb" {
+		t.Errorf("expected disk 'sdb', got %q", model.Devices[2].Disk.ValueString())
+	}
+
+	if len(model.ScrubHistory) != 2 {
+		t.Fatalf("expected 2 scrubs of tank, got %d", len(model.ScrubHistory))
+	}
+	if model.ScrubHistory[0].JobID.ValueInt64() != 40 || model.ScrubHistory[1].State.ValueString() != "RUNNING" {
+		t.Errorf("unexpected scrub history: %+v", model.ScrubHistory)
+	}
+	if !model.ScrubHistory[1].TimeFinished.IsNull() {
+		t.Error("expected running scrub to have null time_finished")
+	}
+}
+
+func TestPoolStatusDataSource_Read_NeverScanned(t *testing.T) {
+	resp, model := readPoolStatus(t, "tank", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method == "pool.query" {
+			return json.RawMessage(`[{"id": 1, "name": "tank", "status": "ONLINE", "healthy": true, "warning": false,
+				"status_code": null, "status_detail": null,
+				"scan": {"function": null, "state": null, "start_time": null, "end_time": null, "percentage": null, "errors": null},
+				"topology": {"data": []}}]`), nil
+		}
+		return json.RawMessage(`[]`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if !model.ScanFunction.IsNull() || !model.ScanStartTime.IsNull() || !model.ScanPercentage.IsNull() {
+		t.Error("expected scan attributes to be null for a pool that was never scanned")
+	}
+	if !model.StatusCode.IsNull() {
+		t.Error("expected null status_code for a healthy pool")
+	}
+	if model.Devices == nil || len(model.Devices) != 0 || model.ScrubHistory == nil || len(model.ScrubHistory) != 0 {
+		t.Error("expected empty devices and scrub_history lists")
+	}
+}
+
+func TestPoolStatusDataSource_Read_PoolNotFound(t *testing.T) {
+	resp, _ := readPoolStatus(t, "missing", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return json.RawMessage(`[]`), nil
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for pool not found")
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Pool Not Found" {
+		t.Errorf("expected 'Pool Not Found', got %q", resp.Diagnostics.Errors()[0].Summary())
+	}
+}
+
+func TestPoolStatusDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readPoolStatus(t, "tank", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
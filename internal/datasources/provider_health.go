@@ -0,0 +1,195 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ProviderHealthDataSource{}
+var _ datasource.DataSourceWithConfigure = &ProviderHealthDataSource{}
+
+const (
+	defaultHealthSamples          = 3
+	defaultHealthLatencyWarningMS = 500
+)
+
+// ProviderHealthDataSource defines the data source implementation.
+type ProviderHealthDataSource struct {
+	services *services.TrueNASServices
+}
+
+// ProviderHealthDataSourceModel describes the data source data model.
+type ProviderHealthDataSourceModel struct {
+	ID                types.String  `tfsdk:"id"`
+	Samples           types.Int64   `tfsdk:"samples"`
+	LatencyWarningMS  types.Int64   `tfsdk:"latency_warning_ms"`
+	Pings             types.Int64   `tfsdk:"pings"`
+	Missed            types.Int64   `tfsdk:"missed"`
+	ConsecutiveMissed types.Int64   `tfsdk:"consecutive_missed"`
+	LastRTTMS         types.Float64 `tfsdk:"last_rtt_ms"`
+	AverageRTTMS      types.Float64 `tfsdk:"average_rtt_ms"`
+	MaxRTTMS          types.Float64 `tfsdk:"max_rtt_ms"`
+	LastError         types.String  `tfsdk:"last_error"`
+}
+
+// NewProviderHealthDataSource creates a new ProviderHealthDataSource.
+func NewProviderHealthDataSource() datasource.DataSource {
+	return &ProviderHealthDataSource{}
+}
+
+func (d *ProviderHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_health"
+}
+
+func (d *ProviderHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the health of the provider's connection to TrueNAS: core.ping round-trip times and " +
+			"missed heartbeats, combining the background WebSocket heartbeats with samples taken on read. Emits " +
+			"warning diagnostics when latency exceeds a threshold or heartbeats are missed, which helps when " +
+			"debugging flaky applies over WAN links.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier.",
+				Computed:    true,
+			},
+			"samples": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of heartbeats to send when the data source is read. Defaults to %d.", defaultHealthSamples),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"latency_warning_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("Average round-trip time in milliseconds above which a warning is emitted. Defaults to %d.", defaultHealthLatencyWarningMS),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"pings": schema.Int64Attribute{
+				Description: "Number of heartbeats sent since the provider was configured.",
+				Computed:    true,
+			},
+			"missed": schema.Int64Attribute{
+				Description: "Number of heartbeats that failed or received no response in time.",
+				Computed:    true,
+			},
+			"consecutive_missed": schema.Int64Attribute{
+				Description: "Number of heartbeats missed since the last successful one.",
+				Computed:    true,
+			},
+			"last_rtt_ms": schema.Float64Attribute{
+				Description: "Round-trip time of the last successful heartbeat in milliseconds.",
+				Computed:    true,
+			},
+			"average_rtt_ms": schema.Float64Attribute{
+				Description: "Average round-trip time of successful heartbeats in milliseconds.",
+				Computed:    true,
+			},
+			"max_rtt_ms": schema.Float64Attribute{
+				Description: "Highest round-trip time of successful heartbeats in milliseconds.",
+				Computed:    true,
+			},
+			"last_error": schema.StringAttribute{
+				Description: "Error of the most recent missed heartbeat, or null if none were missed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ProviderHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *ProviderHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProviderHealthDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.services.Health == nil {
+		resp.Diagnostics.AddError(
+			"Connection Health Unavailable",
+			"The provider is not tracking connection health.",
+		)
+		return
+	}
+
+	samples := int64(defaultHealthSamples)
+	if !data.Samples.IsNull() {
+		samples = data.Samples.ValueInt64()
+	}
+	threshold := int64(defaultHealthLatencyWarningMS)
+	if !data.LatencyWarningMS.IsNull() {
+		threshold = data.LatencyWarningMS.ValueInt64()
+	}
+
+	// Missed samples are recorded in the stats and reported below.
+	for i := int64(0); i < samples; i++ {
+		_, _ = d.services.Health.Ping(ctx)
+	}
+
+	stats := d.services.Health.Stats()
+
+	data.ID = types.StringValue("provider_health")
+	data.Pings = types.Int64Value(stats.Pings)
+	data.Missed = types.Int64Value(stats.Missed)
+	data.ConsecutiveMissed = types.Int64Value(stats.ConsecutiveMissed)
+	data.LastRTTMS = types.Float64Value(durationMS(stats.LastRTT))
+	data.AverageRTTMS = types.Float64Value(durationMS(stats.AverageRTT))
+	data.MaxRTTMS = types.Float64Value(durationMS(stats.MaxRTT))
+	data.LastError = types.StringNull()
+	if stats.LastError != "" {
+		data.LastError = types.StringValue(stats.LastError)
+	}
+
+	if stats.Pings > stats.Missed && stats.AverageRTT > time.Duration(threshold)*time.Millisecond {
+		resp.Diagnostics.AddWarning(
+			"High TrueNAS Latency",
+			fmt.Sprintf("Average core.ping round-trip time is %.1fms (max %.1fms), above the %dms threshold. "+
+				"Operations may time out; consider raising connect_timeout or max_retries.",
+				durationMS(stats.AverageRTT), durationMS(stats.MaxRTT), threshold),
+		)
+	}
+	if stats.Missed > 0 {
+		resp.Diagnostics.AddWarning(
+			"Missed TrueNAS Heartbeats",
+			fmt.Sprintf("%d of %d heartbeats were missed (%d in a row). Last error: %s",
+				stats.Missed, stats.Pings, stats.ConsecutiveMissed, stats.LastError),
+		)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// durationMS converts d to fractional milliseconds.
+func durationMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
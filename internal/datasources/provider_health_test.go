@@ -0,0 +1,263 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewProviderHealthDataSource(t *testing.T) {
+	ds := NewProviderHealthDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	// Verify it implements the required interfaces
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*ProviderHealthDataSource)
+}
+
+func TestProviderHealthDataSource_Metadata(t *testing.T) {
+	ds := NewProviderHealthDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_provider_health" {
+		t.Errorf("expected TypeName 'truenas_provider_health', got %q", resp.TypeName)
+	}
+}
+
+func TestProviderHealthDataSource_Schema(t *testing.T) {
+	ds := NewProviderHealthDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"samples", "latency_warning_ms"} {
+		if !resp.Schema.Attributes[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	for _, name := range []string{"id", "pings", "missed", "consecutive_missed", "last_rtt_ms", "average_rtt_ms", "max_rtt_ms", "last_error"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+func TestProviderHealthDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewProviderHealthDataSource().(*ProviderHealthDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createProviderHealthTestReadRequest creates a datasource.ReadRequest with the given inputs (nil for unset).
+func createProviderHealthTestReadRequest(t *testing.T, samples, latencyWarningMS interface{}) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewProviderHealthDataSource()
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                 tftypes.String,
+			"samples":            tftypes.Number,
+			"latency_warning_ms": tftypes.Number,
+			"pings":              tftypes.Number,
+			"missed":             tftypes.Number,
+			"consecutive_missed": tftypes.Number,
+			"last_rtt_ms":        tftypes.Number,
+			"average_rtt_ms":     tftypes.Number,
+			"max_rtt_ms":         tftypes.Number,
+			"last_error":         tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, nil),
+		"samples":            tftypes.NewValue(tftypes.Number, samples),
+		"latency_warning_ms": tftypes.NewValue(tftypes.Number, latencyWarningMS),
+		"pings":              tftypes.NewValue(tftypes.Number, nil),
+		"missed":             tftypes.NewValue(tftypes.Number, nil),
+		"consecutive_missed": tftypes.NewValue(tftypes.Number, nil),
+		"last_rtt_ms":        tftypes.NewValue(tftypes.Number, nil),
+		"average_rtt_ms":     tftypes.NewValue(tftypes.Number, nil),
+		"max_rtt_ms":         tftypes.NewValue(tftypes.Number, nil),
+		"last_error":         tftypes.NewValue(tftypes.String, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func newProviderHealthTestDataSource(callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) *ProviderHealthDataSource {
+	return &ProviderHealthDataSource{
+		services: &services.TrueNASServices{
+			Health: services.NewHealthMonitor(&client.MockClient{CallFunc: callFunc}, 0),
+		},
+	}
+}
+
+func readProviderHealth(t *testing.T, ds *ProviderHealthDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaReq := datasource.SchemaRequest{}
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), schemaReq, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+func TestProviderHealthDataSource_Read_Healthy(t *testing.T) {
+	calls := 0
+	ds := newProviderHealthTestDataSource(func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		calls++
+		if method != "core.ping" {
+			t.Errorf("expected method 'core.ping', got %q", method)
+		}
+		return json.RawMessage(`"pong"`), nil
+	})
+
+	resp := readProviderHealth(t, ds, createProviderHealthTestReadRequest(t, nil, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("expected no warnings, got %v", resp.Diagnostics)
+	}
+	if calls != defaultHealthSamples {
+		t.Errorf("expected %d heartbeats, got %d", defaultHealthSamples, calls)
+	}
+
+	var model ProviderHealthDataSourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	if model.Pings.ValueInt64() != defaultHealthSamples {
+		t.Errorf("expected %d pings, got %d", defaultHealthSamples, model.Pings.ValueInt64())
+	}
+	if model.Missed.ValueInt64() != 0 {
+		t.Errorf("expected 0 missed, got %d", model.Missed.ValueInt64())
+	}
+	if !model.LastError.IsNull() {
+		t.Errorf("expected null last_error, got %q", model.LastError.ValueString())
+	}
+}
+
+func TestProviderHealthDataSource_Read_HighLatency(t *testing.T) {
+	ds := newProviderHealthTestDataSource(func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		time.Sleep(2 * time.Millisecond)
+		return json.RawMessage(`"pong"`), nil
+	})
+
+	resp := readProviderHealth(t, ds, createProviderHealthTestReadRequest(t, 1, 1))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "High TrueNAS Latency" {
+		t.Errorf("expected a high latency warning, got %v", resp.Diagnostics)
+	}
+
+	var model ProviderHealthDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if model.AverageRTTMS.ValueFloat64() < 2 {
+		t.Errorf("expected average RTT of at least 2ms, got %f", model.AverageRTTMS.ValueFloat64())
+	}
+}
+
+func TestProviderHealthDataSource_Read_Missed(t *testing.T) {
+	ds := newProviderHealthTestDataSource(func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection reset")
+	})
+
+	resp := readProviderHealth(t, ds, createProviderHealthTestReadRequest(t, 2, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Missed TrueNAS Heartbeats" {
+		t.Errorf("expected a missed heartbeats warning, got %v", resp.Diagnostics)
+	}
+
+	var model ProviderHealthDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if model.Missed.ValueInt64() != 2 || model.ConsecutiveMissed.ValueInt64() != 2 {
+		t.Errorf("expected 2 missed and 2 consecutive, got %d and %d", model.Missed.ValueInt64(), model.ConsecutiveMissed.ValueInt64())
+	}
+	if model.LastError.ValueString() != "connection reset" {
+		t.Errorf("expected last_error 'connection reset', got %q", model.LastError.ValueString())
+	}
+}
+
+func TestProviderHealthDataSource_Read_NoSamples(t *testing.T) {
+	ds := newProviderHealthTestDataSource(func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		t.Fatal("expected no heartbeats")
+		return nil, nil
+	})
+
+	resp := readProviderHealth(t, ds, createProviderHealthTestReadRequest(t, 0, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderHealthDataSource_Read_NoMonitor(t *testing.T) {
+	ds := &ProviderHealthDataSource{services: &services.TrueNASServices{}}
+
+	resp := readProviderHealth(t, ds, createProviderHealthTestReadRequest(t, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when health is not tracked")
+	}
+}
@@ -0,0 +1,49 @@
+package datasources
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// shareFilterAttributes returns the filter attributes shared by the share
+// listing data sources.
+func shareFilterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"path": schema.StringAttribute{
+			Description: "Only return shares of exactly this path (e.g. '/mnt/tank/media').",
+			Optional:    true,
+		},
+		"path_prefix": schema.StringAttribute{
+			Description: "Only return shares of this path or paths beneath it. Useful for finding shares that " +
+				"would collide with a new one.",
+			Optional: true,
+		},
+		"enabled": schema.BoolAttribute{
+			Description: "Only return enabled (true) or disabled (false) shares.",
+			Optional:    true,
+		},
+	}
+}
+
+// shareQueryFilters builds sharing.*.query filters from the shared filter
+// attributes. path_prefix matches the path itself and anything beneath it,
+// but not siblings that merely share the prefix.
+func shareQueryFilters(path, pathPrefix types.String, enabled types.Bool) []any {
+	filters := []any{}
+	if !path.IsNull() {
+		filters = append(filters, []any{"path", "=", path.ValueString()})
+	}
+	if !pathPrefix.IsNull() {
+		prefix := strings.TrimSuffix(pathPrefix.ValueString(), "/")
+		filters = append(filters, []any{"OR", []any{
+			[]any{"path", "=", prefix},
+			[]any{"path", "^", prefix + "/"},
+		}})
+	}
+	if !enabled.IsNull() {
+		filters = append(filters, []any{"enabled", "=", enabled.ValueBool()})
+	}
+	return filters
+}
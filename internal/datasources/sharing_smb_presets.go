@@ -0,0 +1,204 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SharingSMBPresetsDataSource{}
+var _ datasource.DataSourceWithConfigure = &SharingSMBPresetsDataSource{}
+
+// SharingSMBPresetsDataSource defines the data source implementation.
+type SharingSMBPresetsDataSource struct {
+	services *services.TrueNASServices
+}
+
+// SharingSMBPresetsDataSourceModel describes the data source data model.
+type SharingSMBPresetsDataSourceModel struct {
+	ID      types.String          `tfsdk:"id"`
+	Presets []SMBSharePresetModel `tfsdk:"presets"`
+	Names   []types.String        `tfsdk:"names"`
+}
+
+// SMBSharePresetModel describes the share parameters one purpose implies.
+type SMBSharePresetModel struct {
+	Name        types.String            `tfsdk:"name"`
+	VerboseName types.String            `tfsdk:"verbose_name"`
+	AuxSMBConf  types.String            `tfsdk:"auxsmbconf"`
+	Params      map[string]types.String `tfsdk:"params"`
+}
+
+// smbSharePresetResponse is one entry of the sharing.smb.presets response.
+type smbSharePresetResponse struct {
+	VerboseName string         `json:"verbose_name"`
+	Params      map[string]any `json:"params"`
+}
+
+// NewSharingSMBPresetsDataSource creates a new SharingSMBPresetsDataSource.
+func NewSharingSMBPresetsDataSource() datasource.DataSource {
+	return &SharingSMBPresetsDataSource{}
+}
+
+func (d *SharingSMBPresetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sharing_smb_presets"
+}
+
+func (d *SharingSMBPresetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the SMB share purposes this TrueNAS version offers and the share parameters each one implies.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always 'sharing_smb_presets').",
+				Computed:    true,
+			},
+			"presets": schema.ListNestedAttribute{
+				Description: "Share presets, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Preset name, as used for a share's purpose (e.g., DEFAULT_SHARE).",
+							Computed:    true,
+						},
+						"verbose_name": schema.StringAttribute{
+							Description: "Name shown in the UI.",
+							Computed:    true,
+						},
+						"auxsmbconf": schema.StringAttribute{
+							Description: "Auxiliary smb.conf parameters the preset sets, or null if it sets none.",
+							Computed:    true,
+						},
+						"params": schema.MapAttribute{
+							Description: "Share parameters the preset sets, keyed by share attribute. Booleans and numbers " +
+								"are rendered as strings, and lists and objects as JSON.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				Description: "Preset names, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *SharingSMBPresetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *SharingSMBPresetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SharingSMBPresetsDataSourceModel
+
+	result, err := methods.SharingSMBPresets(ctx, d.services.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SMB Presets",
+			fmt.Sprintf("Unable to read SMB share presets: %s", err.Error()),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(result))
+	for name := range result {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	data.ID = types.StringValue("sharing_smb_presets")
+	data.Presets = make([]SMBSharePresetModel, len(names))
+	data.Names = make([]types.String, len(names))
+	for i, name := range names {
+		preset, err := parseSMBSharePreset(result[name])
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Parse SMB Presets",
+				fmt.Sprintf("Unable to parse SMB share preset %q: %s", name, err.Error()),
+			)
+			return
+		}
+
+		model := SMBSharePresetModel{
+			Name:        types.StringValue(name),
+			VerboseName: types.StringValue(preset.VerboseName),
+			AuxSMBConf:  types.StringNull(),
+			Params:      make(map[string]types.String, len(preset.Params)),
+		}
+		for key, value := range preset.Params {
+			s, err := presetParamString(value)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Parse SMB Presets",
+					fmt.Sprintf("Unable to parse parameter %q of SMB share preset %q: %s", key, name, err.Error()),
+				)
+				return
+			}
+			model.Params[key] = types.StringValue(s)
+		}
+		if aux, ok := preset.Params["auxsmbconf"].(string); ok {
+			model.AuxSMBConf = types.StringValue(aux)
+		}
+
+		data.Presets[i] = model
+		data.Names[i] = types.StringValue(name)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseSMBSharePreset decodes one value of the sharing.smb.presets response.
+func parseSMBSharePreset(raw any) (smbSharePresetResponse, error) {
+	var preset smbSharePresetResponse
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return preset, err
+	}
+	err = json.Unmarshal(data, &preset)
+	return preset, err
+}
+
+// presetParamString renders a preset parameter as a string: strings as is,
+// booleans and numbers in their Terraform form, anything else as JSON.
+func presetParamString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
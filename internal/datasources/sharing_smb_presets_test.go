@@ -0,0 +1,164 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestNewSharingSMBPresetsDataSource(t *testing.T) {
+	ds := NewSharingSMBPresetsDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*SharingSMBPresetsDataSource)
+}
+
+func TestSharingSMBPresetsDataSource_Metadata(t *testing.T) {
+	ds := NewSharingSMBPresetsDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_sharing_smb_presets" {
+		t.Errorf("expected TypeName 'truenas_sharing_smb_presets', got %q", resp.TypeName)
+	}
+}
+
+func readSharingSMBPresets(t *testing.T, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, SharingSMBPresetsDataSourceModel) {
+	t.Helper()
+
+	ds := &SharingSMBPresetsDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+
+	var model SharingSMBPresetsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestSharingSMBPresetsDataSource_Read_Success(t *testing.T) {
+	resp, model := readSharingSMBPresets(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method != "sharing.smb.presets" {
+			t.Errorf("expected method 'sharing.smb.presets', got %q", method)
+		}
+		return json.RawMessage(`{
+			"NO_PRESET": {"verbose_name": "No presets", "params": {}},
+			"ENHANCED_TIMEMACHINE": {
+				"verbose_name": "Multi-user time machine",
+				"params": {
+					"timemachine": true,
+					"path_suffix": "%U",
+					"timemachine_quota": 0,
+					"auxsmbconf": "zfs_core:zfs_auto_create=true"
+				}
+			}
+		}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "sharing_smb_presets" {
+		t.Errorf("expected ID 'sharing_smb_presets', got %q", model.ID.ValueString())
+	}
+	if len(model.Presets) != 2 || len(model.Names) != 2 {
+		t.Fatalf("expected 2 presets, got %v and names %v", model.Presets, model.Names)
+	}
+	if model.Names[0].ValueString() != "ENHANCED_TIMEMACHINE" || model.Names[1].ValueString() != "NO_PRESET" {
+		t.Errorf("expected sorted names, got %v", model.Names)
+	}
+
+	tm := model.Presets[0]
+	if tm.VerboseName.ValueString() != "Multi-user time machine" {
+		t.Errorf("expected verbose name 'Multi-user time machine', got %q", tm.VerboseName.ValueString())
+	}
+	if tm.AuxSMBConf.ValueString() != "zfs_core:zfs_auto_create=true" {
+		t.Errorf("expected auxsmbconf from params, got %v", tm.AuxSMBConf)
+	}
+	wantParams := map[string]string{
+		"timemachine":       "true",
+		"path_suffix":       "%U",
+		"timemachine_quota": "0",
+		"auxsmbconf":        "zfs_core:zfs_auto_create=true",
+	}
+	if len(tm.Params) != len(wantParams) {
+		t.Fatalf("expected %d params, got %v", len(wantParams), tm.Params)
+	}
+	for key, want := range wantParams {
+		if got := tm.Params[key].ValueString(); got != want {
+			t.Errorf("expected param %q to be %q, got %q", key, want, got)
+		}
+	}
+
+	none := model.Presets[1]
+	if !none.AuxSMBConf.IsNull() {
+		t.Errorf("expected null auxsmbconf, got %v", none.AuxSMBConf)
+	}
+	if len(none.Params) != 0 {
+		t.Errorf("expected no params, got %v", none.Params)
+	}
+}
+
+func TestSharingSMBPresetsDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readSharingSMBPresets(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
+
+func TestPresetParamString(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"%U", "%U"},
+		{true, "true"},
+		{float64(1024), "1024"},
+		{[]any{"a", "b"}, `["a","b"]`},
+		{nil, "null"},
+	}
+
+	for _, tt := range tests {
+		got, err := presetParamString(tt.value)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", tt.value, err)
+		}
+		if got != tt.want {
+			t.Errorf("expected %q for %v, got %q", tt.want, tt.value, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SMBSharesDataSource{}
+var _ datasource.DataSourceWithConfigure = &SMBSharesDataSource{}
+
+// SMBSharesDataSource defines the data source implementation.
+type SMBSharesDataSource struct {
+	services *services.TrueNASServices
+}
+
+// SMBSharesDataSourceModel describes the data source data model.
+type SMBSharesDataSourceModel struct {
+	Name       types.String    `tfsdk:"name"`
+	Path       types.String    `tfsdk:"path"`
+	PathPrefix types.String    `tfsdk:"path_prefix"`
+	Enabled    types.Bool      `tfsdk:"enabled"`
+	Shares     []SMBShareModel `tfsdk:"shares"`
+}
+
+// SMBShareModel represents an SMB share in the list.
+type SMBShareModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Path      types.String `tfsdk:"path"`
+	Comment   types.String `tfsdk:"comment"`
+	Purpose   types.String `tfsdk:"purpose"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	ReadOnly  types.Bool   `tfsdk:"readonly"`
+	Browsable types.Bool   `tfsdk:"browsable"`
+	Locked    types.Bool   `tfsdk:"locked"`
+}
+
+// smbShareResponse is the subset of sharing.smb.query fields used by the data source.
+type smbShareResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Comment   string `json:"comment"`
+	Purpose   string `json:"purpose"`
+	Enabled   bool   `json:"enabled"`
+	ReadOnly  bool   `json:"ro"`
+	Browsable bool   `json:"browsable"`
+	Locked    *bool  `json:"locked"`
+}
+
+// NewSMBSharesDataSource creates a new SMBSharesDataSource.
+func NewSMBSharesDataSource() datasource.DataSource {
+	return &SMBSharesDataSource{}
+}
+
+func (d *SMBSharesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smb_shares"
+}
+
+func (d *SMBSharesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := shareFilterAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Description: "Only return the share with this name.",
+		Optional:    true,
+	}
+	attributes["shares"] = schema.ListNestedAttribute{
+		Description: "Matching SMB shares, ordered by ID.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.Int64Attribute{
+					Description: "Share ID.",
+					Computed:    true,
+				},
+				"name": schema.StringAttribute{
+					Description: "Share name clients connect to.",
+					Computed:    true,
+				},
+				"path": schema.StringAttribute{
+					Description: "Shared path.",
+					Computed:    true,
+				},
+				"comment": schema.StringAttribute{
+					Description: "Share description.",
+					Computed:    true,
+				},
+				"purpose": schema.StringAttribute{
+					Description: "Share purpose preset (e.g. 'DEFAULT_SHARE', 'TIMEMACHINE_SHARE').",
+					Computed:    true,
+				},
+				"enabled": schema.BoolAttribute{
+					Description: "Whether the share is enabled.",
+					Computed:    true,
+				},
+				"readonly": schema.BoolAttribute{
+					Description: "Whether the share is read-only.",
+					Computed:    true,
+				},
+				"browsable": schema.BoolAttribute{
+					Description: "Whether the share is listed when browsing the server.",
+					Computed:    true,
+				},
+				"locked": schema.BoolAttribute{
+					Description: "Whether the share's dataset is locked, or null if unknown.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Retrieves SMB shares (sharing.smb.query), optionally filtered by name, path and enabled " +
+			"state. Useful for adopting existing shares and checking for path collisions.",
+		Attributes: attributes,
+	}
+}
+
+func (d *SMBSharesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *SMBSharesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SMBSharesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters := shareQueryFilters(data.Path, data.PathPrefix, data.Enabled)
+	if !data.Name.IsNull() {
+		filters = append(filters, []any{"name", "=", data.Name.ValueString()})
+	}
+
+	result, err := d.services.Client.Call(ctx, "sharing.smb.query", []any{filters})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SMB Shares",
+			fmt.Sprintf("Unable to query SMB shares: %s", err.Error()),
+		)
+		return
+	}
+
+	var shares []smbShareResponse
+	if err := json.Unmarshal(result, &shares); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse SMB shares response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Shares = make([]SMBShareModel, 0, len(shares))
+	for _, share := range shares {
+		data.Shares = append(data.Shares, SMBShareModel{
+			ID:        types.Int64Value(share.ID),
+			Name:      types.StringValue(share.Name),
+			Path:      types.StringValue(share.Path),
+			Comment:   types.StringValue(share.Comment),
+			Purpose:   types.StringValue(share.Purpose),
+			Enabled:   types.BoolValue(share.Enabled),
+			ReadOnly:  types.BoolValue(share.ReadOnly),
+			Browsable: types.BoolValue(share.Browsable),
+			Locked:    types.BoolPointerValue(share.Locked),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
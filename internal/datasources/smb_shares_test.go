@@ -0,0 +1,286 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewSMBSharesDataSource(t *testing.T) {
+	ds := NewSMBSharesDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	_ = datasource.DataSourceWithConfigure(ds.(*SMBSharesDataSource))
+}
+
+func TestSMBSharesDataSource_Metadata(t *testing.T) {
+	ds := NewSMBSharesDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_smb_shares" {
+		t.Errorf("expected TypeName 'truenas_smb_shares', got %q", resp.TypeName)
+	}
+}
+
+func TestSMBSharesDataSource_Schema(t *testing.T) {
+	ds := NewSMBSharesDataSource()
+
+	resp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	for _, name := range []string{"name", "path", "path_prefix", "enabled"} {
+		if !resp.Schema.Attributes[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	if !resp.Schema.Attributes["shares"].IsComputed() {
+		t.Error("expected 'shares' attribute to be computed")
+	}
+}
+
+func TestSMBSharesDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewSMBSharesDataSource().(*SMBSharesDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createSMBSharesTestReadRequest creates a datasource.ReadRequest with the given filters (nil for unset).
+func createSMBSharesTestReadRequest(t *testing.T, name, path, pathPrefix, enabled interface{}) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewSMBSharesDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	shareType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":        tftypes.Number,
+			"name":      tftypes.String,
+			"path":      tftypes.String,
+			"comment":   tftypes.String,
+			"purpose":   tftypes.String,
+			"enabled":   tftypes.Bool,
+			"readonly":  tftypes.Bool,
+			"browsable": tftypes.Bool,
+			"locked":    tftypes.Bool,
+		},
+	}
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":        tftypes.String,
+			"path":        tftypes.String,
+			"path_prefix": tftypes.String,
+			"enabled":     tftypes.Bool,
+			"shares":      tftypes.List{ElementType: shareType},
+		},
+	}, map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, name),
+		"path":        tftypes.NewValue(tftypes.String, path),
+		"path_prefix": tftypes.NewValue(tftypes.String, pathPrefix),
+		"enabled":     tftypes.NewValue(tftypes.Bool, enabled),
+		"shares":      tftypes.NewValue(tftypes.List{ElementType: shareType}, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+func newSMBSharesTestDataSource(t *testing.T, response string, err error, params *any) *SMBSharesDataSource {
+	t.Helper()
+
+	return &SMBSharesDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+					if method != "sharing.smb.query" {
+						t.Errorf("expected method 'sharing.smb.query', got %q", method)
+					}
+					if params != nil {
+						*params = p
+					}
+					if err != nil {
+						return nil, err
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+}
+
+func readSMBShares(t *testing.T, ds *SMBSharesDataSource, req datasource.ReadRequest) *datasource.ReadResponse {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), req, resp)
+	return resp
+}
+
+const testSMBSharesJSON = `[
+	{
+		"id": 1,
+		"purpose": "DEFAULT_SHARE",
+		"path": "/mnt/tank/media",
+		"name": "media",
+		"comment": "Family media",
+		"enabled": true,
+		"ro": false,
+		"browsable": true,
+		"locked": false
+	},
+	{
+		"id": 2,
+		"purpose": "TIMEMACHINE_SHARE",
+		"path": "/mnt/tank/media/backups",
+		"name": "timemachine",
+		"comment": "",
+		"enabled": false,
+		"ro": false,
+		"browsable": false,
+		"locked": null
+	}
+]`
+
+func TestSMBSharesDataSource_Read_All(t *testing.T) {
+	var params any
+	ds := newSMBSharesTestDataSource(t, testSMBSharesJSON, nil, &params)
+
+	resp := readSMBShares(t, ds, createSMBSharesTestReadRequest(t, nil, nil, nil, nil))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if want := []any{[]any{}}; !reflect.DeepEqual(params, want) {
+		t.Errorf("expected params %v, got %v", want, params)
+	}
+
+	var model SMBSharesDataSourceModel
+	resp.State.Get(context.Background(), &model)
+
+	if len(model.Shares) != 2 {
+		t.Fatalf("expected 2 shares, got %d", len(model.Shares))
+	}
+
+	media := model.Shares[0]
+	if media.ID.ValueInt64() != 1 {
+		t.Errorf("expected id 1, got %d", media.ID.ValueInt64())
+	}
+	if media.Name.ValueString() != "media" {
+		t.Errorf("expected name 'media', got %q", media.Name.ValueString())
+	}
+	if media.Path.ValueString() != "/mnt/tank/media" {
+		t.Errorf("expected path '/mnt/tank/media', got %q", media.Path.ValueString())
+	}
+	if media.Comment.ValueString() != "Family media" {
+		t.Errorf("expected comment 'Family media', got %q", media.Comment.ValueString())
+	}
+	if !media.Enabled.ValueBool() || media.ReadOnly.ValueBool() || !media.Browsable.ValueBool() {
+		t.Errorf("unexpected flags: enabled=%v readonly=%v browsable=%v", media.Enabled, media.ReadOnly, media.Browsable)
+	}
+	if media.Locked.IsNull() || media.Locked.ValueBool() {
+		t.Errorf("expected locked false, got %v", media.Locked)
+	}
+
+	tm := model.Shares[1]
+	if tm.Purpose.ValueString() != "TIMEMACHINE_SHARE" {
+		t.Errorf("expected purpose 'TIMEMACHINE_SHARE', got %q", tm.Purpose.ValueString())
+	}
+	if !tm.Locked.IsNull() {
+		t.Errorf("expected null locked, got %v", tm.Locked)
+	}
+}
+
+func TestSMBSharesDataSource_Read_Filters(t *testing.T) {
+	var params any
+	ds := newSMBSharesTestDataSource(t, `[]`, nil, &params)
+
+	req := createSMBSharesTestReadRequest(t, "media", "/mnt/tank/media", "/mnt/tank/", true)
+	resp := readSMBShares(t, ds, req)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	want := []any{[]any{
+		[]any{"path", "=", "/mnt/tank/media"},
+		[]any{"OR", []any{
+			[]any{"path", "=", "/mnt/tank"},
+			[]any{"path", "^", "/mnt/tank/"},
+		}},
+		[]any{"enabled", "=", true},
+		[]any{"name", "=", "media"},
+	}}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("expected params %v, got %v", want, params)
+	}
+
+	var model SMBSharesDataSourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Shares == nil || len(model.Shares) != 0 {
+		t.Errorf("expected empty shares list, got %v", model.Shares)
+	}
+}
+
+func TestSMBSharesDataSource_Read_APIError(t *testing.T) {
+	ds := newSMBSharesTestDataSource(t, "", errors.New("connection refused"), nil)
+
+	resp := readSMBShares(t, ds, createSMBSharesTestReadRequest(t, nil, nil, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
+
+func TestSMBSharesDataSource_Read_InvalidJSON(t *testing.T) {
+	ds := newSMBSharesTestDataSource(t, "not json", nil, nil)
+
+	resp := readSMBShares(t, ds, createSMBSharesTestReadRequest(t, nil, nil, nil, nil))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
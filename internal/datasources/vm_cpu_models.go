@@ -0,0 +1,106 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &VMCPUModelsDataSource{}
+var _ datasource.DataSourceWithConfigure = &VMCPUModelsDataSource{}
+
+// VMCPUModelsDataSource defines the data source implementation.
+type VMCPUModelsDataSource struct {
+	services *services.TrueNASServices
+}
+
+// VMCPUModelsDataSourceModel describes the data source data model.
+type VMCPUModelsDataSourceModel struct {
+	ID     types.String   `tfsdk:"id"`
+	Models []types.String `tfsdk:"models"`
+}
+
+// NewVMCPUModelsDataSource creates a new VMCPUModelsDataSource.
+func NewVMCPUModelsDataSource() datasource.DataSource {
+	return &VMCPUModelsDataSource{}
+}
+
+func (d *VMCPUModelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_cpu_models"
+}
+
+func (d *VMCPUModelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the CPU models a VM can emulate on this host, for use as cpu_model when cpu_mode is CUSTOM.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always 'vm_cpu_models').",
+				Computed:    true,
+			},
+			"models": schema.ListAttribute{
+				Description: "CPU model names, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *VMCPUModelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *VMCPUModelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VMCPUModelsDataSourceModel
+
+	choices, err := methods.VMCPUModelChoices(ctx, d.services.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read CPU Models",
+			fmt.Sprintf("Unable to read VM CPU model choices: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("vm_cpu_models")
+	data.Models = vmChoiceNames(choices)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// vmChoiceNames returns the sorted keys of a vm.*_choices response. The
+// choices map each name to itself, so only the keys matter.
+func vmChoiceNames(choices map[string]any) []types.String {
+	names := make([]string, 0, len(choices))
+	for name := range choices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]types.String, len(names))
+	for i, name := range names {
+		values[i] = types.StringValue(name)
+	}
+	return values
+}
@@ -0,0 +1,103 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestNewVMCPUModelsDataSource(t *testing.T) {
+	ds := NewVMCPUModelsDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*VMCPUModelsDataSource)
+}
+
+func TestVMCPUModelsDataSource_Metadata(t *testing.T) {
+	ds := NewVMCPUModelsDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_vm_cpu_models" {
+		t.Errorf("expected TypeName 'truenas_vm_cpu_models', got %q", resp.TypeName)
+	}
+}
+
+func readVMCPUModels(t *testing.T, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, VMCPUModelsDataSourceModel) {
+	t.Helper()
+
+	ds := &VMCPUModelsDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+
+	var model VMCPUModelsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestVMCPUModelsDataSource_Read_Success(t *testing.T) {
+	resp, model := readVMCPUModels(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method != "vm.cpu_model_choices" {
+			t.Errorf("expected method 'vm.cpu_model_choices', got %q", method)
+		}
+		return json.RawMessage(`{"Skylake-Client": "Skylake-Client", "Haswell": "Haswell", "EPYC": "EPYC"}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "vm_cpu_models" {
+		t.Errorf("expected ID 'vm_cpu_models', got %q", model.ID.ValueString())
+	}
+	want := []string{"EPYC", "Haswell", "Skylake-Client"}
+	if len(model.Models) != len(want) {
+		t.Fatalf("expected %d models, got %v", len(want), model.Models)
+	}
+	for i, m := range want {
+		if model.Models[i].ValueString() != m {
+			t.Errorf("expected model %d to be %q, got %q", i, m, model.Models[i].ValueString())
+		}
+	}
+}
+
+func TestVMCPUModelsDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readVMCPUModels(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
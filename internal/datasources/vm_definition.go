@@ -0,0 +1,177 @@
+package datasources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &VMDefinitionDataSource{}
+var _ datasource.DataSourceWithConfigure = &VMDefinitionDataSource{}
+
+// vmDefinitionOmittedFields are the vm.query fields that describe a VM on
+// its current host rather than its configuration, so they are left out of
+// an exported definition.
+var vmDefinitionOmittedFields = []string{"id", "status", "display_available"}
+
+// vmDefinitionOmittedDeviceFields are the device fields tied to the current
+// host's database.
+var vmDefinitionOmittedDeviceFields = []string{"id", "vm"}
+
+// VMDefinitionDataSource defines the data source implementation.
+type VMDefinitionDataSource struct {
+	services *services.TrueNASServices
+}
+
+// VMDefinitionDataSourceModel describes the data source data model.
+type VMDefinitionDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Definition types.String `tfsdk:"definition"`
+}
+
+// NewVMDefinitionDataSource creates a new VMDefinitionDataSource.
+func NewVMDefinitionDataSource() datasource.DataSource {
+	return &VMDefinitionDataSource{}
+}
+
+func (d *VMDefinitionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_definition"
+}
+
+func (d *VMDefinitionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports the configuration and devices of a TrueNAS virtual machine as JSON, " +
+			"for recreating it on another host with truenas_vm_migration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "VM ID on the source host.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "VM name.",
+				Required:    true,
+			},
+			"definition": schema.StringAttribute{
+				Description: "The VM's vm.query object without host-specific fields (id, status, device IDs), " +
+					"as JSON. Sensitive because display devices carry their password.",
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (d *VMDefinitionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *VMDefinitionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VMDefinitionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	filter := []any{[]any{[]any{"name", "=", name}}}
+	result, err := d.services.Client.Call(ctx, "vm.query", filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read VM",
+			fmt.Sprintf("Unable to read VM %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	// Keep numbers as sent, so the definition round-trips exactly
+	var vms []map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(result))
+	decoder.UseNumber()
+	if err := decoder.Decode(&vms); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse VM response: %s", err.Error()),
+		)
+		return
+	}
+
+	if len(vms) == 0 {
+		resp.Diagnostics.AddError(
+			"VM Not Found",
+			fmt.Sprintf("VM %q was not found.", name),
+		)
+		return
+	}
+
+	vm := vms[0]
+	id := vm["id"]
+	definition, err := json.Marshal(vmDefinition(vm))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Export VM",
+			fmt.Sprintf("Unable to encode the definition of VM %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprint(id))
+	data.Definition = types.StringValue(string(definition))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// vmDefinition strips the host-specific fields from a vm.query object.
+func vmDefinition(vm map[string]any) map[string]any {
+	def := make(map[string]any, len(vm))
+	for k, v := range vm {
+		def[k] = v
+	}
+	for _, k := range vmDefinitionOmittedFields {
+		delete(def, k)
+	}
+
+	devices, _ := vm["devices"].([]any)
+	exported := make([]any, 0, len(devices))
+	for _, raw := range devices {
+		device, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		copied := make(map[string]any, len(device))
+		for k, v := range device {
+			copied[k] = v
+		}
+		for _, k := range vmDefinitionOmittedDeviceFields {
+			delete(copied, k)
+		}
+		exported = append(exported, copied)
+	}
+	def["devices"] = exported
+
+	return def
+}
@@ -0,0 +1,112 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewVMDefinitionDataSource(t *testing.T) {
+	ds := NewVMDefinitionDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*VMDefinitionDataSource)
+}
+
+func TestVMDefinitionDataSource_Metadata(t *testing.T) {
+	ds := NewVMDefinitionDataSource()
+
+	resp := &datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "truenas"}, resp)
+
+	if resp.TypeName != "truenas_vm_definition" {
+		t.Errorf("expected TypeName 'truenas_vm_definition', got %q", resp.TypeName)
+	}
+}
+
+func readVMDefinition(t *testing.T, response string) (*datasource.ReadResponse, VMDefinitionDataSourceModel) {
+	t.Helper()
+
+	ds := &VMDefinitionDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					if method != "vm.query" {
+						t.Errorf("expected method 'vm.query', got %q", method)
+					}
+					return json.RawMessage(response), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	config := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"name":       tftypes.String,
+			"definition": tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, nil),
+		"name":       tftypes.NewValue(tftypes.String, "web"),
+		"definition": tftypes.NewValue(tftypes.String, nil),
+	})
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	ds.Read(context.Background(), datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}, resp)
+
+	var model VMDefinitionDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestVMDefinitionDataSource_Read_Success(t *testing.T) {
+	resp, model := readVMDefinition(t, `[{
+		"id": 12,
+		"name": "web",
+		"memory": 4096,
+		"status": {"state": "RUNNING"},
+		"display_available": true,
+		"devices": [{"id": 40, "vm": 12, "order": 1001, "attributes": {"dtype": "DISK", "path": "/dev/zvol/tank/vms/web-disk0"}}]
+	}]`)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if model.ID.ValueString() != "12" {
+		t.Errorf("expected ID '12', got %q", model.ID.ValueString())
+	}
+
+	want := `{"devices":[{"attributes":{"dtype":"DISK","path":"/dev/zvol/tank/vms/web-disk0"},"order":1001}],"memory":4096,"name":"web"}`
+	if got := model.Definition.ValueString(); got != want {
+		t.Errorf("unexpected definition:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestVMDefinitionDataSource_Read_NotFound(t *testing.T) {
+	resp, _ := readVMDefinition(t, `[]`)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a missing VM")
+	}
+}
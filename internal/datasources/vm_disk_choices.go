@@ -0,0 +1,130 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &VMDiskChoicesDataSource{}
+var _ datasource.DataSourceWithConfigure = &VMDiskChoicesDataSource{}
+
+// VMDiskChoicesDataSource defines the data source implementation.
+type VMDiskChoicesDataSource struct {
+	services *services.TrueNASServices
+}
+
+// VMDiskChoicesDataSourceModel describes the data source data model.
+type VMDiskChoicesDataSourceModel struct {
+	ID    types.String        `tfsdk:"id"`
+	Disks []VMDiskChoiceModel `tfsdk:"disks"`
+	Paths []types.String      `tfsdk:"paths"`
+}
+
+// VMDiskChoiceModel describes a zvol a VM disk can attach.
+type VMDiskChoiceModel struct {
+	Path types.String `tfsdk:"path"`
+	Zvol types.String `tfsdk:"zvol"`
+}
+
+// NewVMDiskChoicesDataSource creates a new VMDiskChoicesDataSource.
+func NewVMDiskChoicesDataSource() datasource.DataSource {
+	return &VMDiskChoicesDataSource{}
+}
+
+func (d *VMDiskChoicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_disk_choices"
+}
+
+func (d *VMDiskChoicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the zvols a VM DISK device can attach on this host, as offered by the UI's disk dropdown.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always 'vm_disk_choices').",
+				Computed:    true,
+			},
+			"disks": schema.ListNestedAttribute{
+				Description: "Attachable zvols, sorted by path.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description: "Device path, for use as a disk's path (e.g., /dev/zvol/tank/vms/disk0).",
+							Computed:    true,
+						},
+						"zvol": schema.StringAttribute{
+							Description: "Name of the zvol (e.g., tank/vms/disk0).",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"paths": schema.ListAttribute{
+				Description: "Device paths of the attachable zvols, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *VMDiskChoicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *VMDiskChoicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VMDiskChoicesDataSourceModel
+
+	choices, err := methods.VMDeviceDiskChoices(ctx, d.services.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Disk Choices",
+			fmt.Sprintf("Unable to read VM disk choices: %s", err.Error()),
+		)
+		return
+	}
+
+	// Unlike the other vm.*_choices, the keys are device paths and the
+	// values the zvol names shown in the UI.
+	paths := make([]string, 0, len(choices))
+	for p := range choices {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	data.ID = types.StringValue("vm_disk_choices")
+	data.Disks = make([]VMDiskChoiceModel, len(paths))
+	data.Paths = make([]types.String, len(paths))
+	for i, p := range paths {
+		zvol, _ := choices[p].(string)
+		data.Disks[i] = VMDiskChoiceModel{
+			Path: types.StringValue(p),
+			Zvol: types.StringValue(zvol),
+		}
+		data.Paths[i] = types.StringValue(p)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
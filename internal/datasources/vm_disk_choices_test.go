@@ -0,0 +1,110 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewVMDiskChoicesDataSource(t *testing.T) {
+	ds := NewVMDiskChoicesDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*VMDiskChoicesDataSource)
+}
+
+func TestVMDiskChoicesDataSource_Metadata(t *testing.T) {
+	ds := NewVMDiskChoicesDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_vm_disk_choices" {
+		t.Errorf("expected TypeName 'truenas_vm_disk_choices', got %q", resp.TypeName)
+	}
+}
+
+func readVMDiskChoices(t *testing.T, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, VMDiskChoicesDataSourceModel) {
+	t.Helper()
+
+	ds := &VMDiskChoicesDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+
+	var model VMDiskChoicesDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestVMDiskChoicesDataSource_Read_Success(t *testing.T) {
+	resp, model := readVMDiskChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method != "vm.device.disk_choices" {
+			t.Errorf("expected method 'vm.device.disk_choices', got %q", method)
+		}
+		return json.RawMessage(`{"/dev/zvol/tank/vms/web0": "tank/vms/web0", "/dev/zvol/tank/vms/db0": "tank/vms/db0"}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "vm_disk_choices" {
+		t.Errorf("expected ID 'vm_disk_choices', got %q", model.ID.ValueString())
+	}
+	want := []VMDiskChoiceModel{
+		{Path: types.StringValue("/dev/zvol/tank/vms/db0"), Zvol: types.StringValue("tank/vms/db0")},
+		{Path: types.StringValue("/dev/zvol/tank/vms/web0"), Zvol: types.StringValue("tank/vms/web0")},
+	}
+	if len(model.Disks) != len(want) || len(model.Paths) != len(want) {
+		t.Fatalf("expected %d disks, got %v and paths %v", len(want), model.Disks, model.Paths)
+	}
+	for i, disk := range want {
+		if model.Disks[i] != disk {
+			t.Errorf("expected disk %d to be %v, got %v", i, disk, model.Disks[i])
+		}
+		if !model.Paths[i].Equal(disk.Path) {
+			t.Errorf("expected path %d to be %v, got %v", i, disk.Path, model.Paths[i])
+		}
+	}
+}
+
+func TestVMDiskChoicesDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readVMDiskChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
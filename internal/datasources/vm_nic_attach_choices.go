@@ -0,0 +1,89 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &VMNICAttachChoicesDataSource{}
+var _ datasource.DataSourceWithConfigure = &VMNICAttachChoicesDataSource{}
+
+// VMNICAttachChoicesDataSource defines the data source implementation.
+type VMNICAttachChoicesDataSource struct {
+	services *services.TrueNASServices
+}
+
+// VMNICAttachChoicesDataSourceModel describes the data source data model.
+type VMNICAttachChoicesDataSourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	Interfaces []types.String `tfsdk:"interfaces"`
+}
+
+// NewVMNICAttachChoicesDataSource creates a new VMNICAttachChoicesDataSource.
+func NewVMNICAttachChoicesDataSource() datasource.DataSource {
+	return &VMNICAttachChoicesDataSource{}
+}
+
+func (d *VMNICAttachChoicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_nic_attach_choices"
+}
+
+func (d *VMNICAttachChoicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the interfaces a VM NIC can attach to on this host, for use as a NIC's nic_attach.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always 'vm_nic_attach_choices').",
+				Computed:    true,
+			},
+			"interfaces": schema.ListAttribute{
+				Description: "Interface names, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *VMNICAttachChoicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *VMNICAttachChoicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VMNICAttachChoicesDataSourceModel
+
+	choices, err := methods.VMDeviceNICAttachChoices(ctx, d.services.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read NIC Attach Choices",
+			fmt.Sprintf("Unable to read VM NIC attach choices: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("vm_nic_attach_choices")
+	data.Interfaces = vmChoiceNames(choices)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,103 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestNewVMNICAttachChoicesDataSource(t *testing.T) {
+	ds := NewVMNICAttachChoicesDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*VMNICAttachChoicesDataSource)
+}
+
+func TestVMNICAttachChoicesDataSource_Metadata(t *testing.T) {
+	ds := NewVMNICAttachChoicesDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_vm_nic_attach_choices" {
+		t.Errorf("expected TypeName 'truenas_vm_nic_attach_choices', got %q", resp.TypeName)
+	}
+}
+
+func readVMNICAttachChoices(t *testing.T, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, VMNICAttachChoicesDataSourceModel) {
+	t.Helper()
+
+	ds := &VMNICAttachChoicesDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+
+	var model VMNICAttachChoicesDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestVMNICAttachChoicesDataSource_Read_Success(t *testing.T) {
+	resp, model := readVMNICAttachChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method != "vm.device.nic_attach_choices" {
+			t.Errorf("expected method 'vm.device.nic_attach_choices', got %q", method)
+		}
+		return json.RawMessage(`{"enp1s0": "enp1s0", "br0": "br0"}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "vm_nic_attach_choices" {
+		t.Errorf("expected ID 'vm_nic_attach_choices', got %q", model.ID.ValueString())
+	}
+	want := []string{"br0", "enp1s0"}
+	if len(model.Interfaces) != len(want) {
+		t.Fatalf("expected %d interfaces, got %v", len(want), model.Interfaces)
+	}
+	for i, name := range want {
+		if model.Interfaces[i].ValueString() != name {
+			t.Errorf("expected interface %d to be %q, got %q", i, name, model.Interfaces[i].ValueString())
+		}
+	}
+}
+
+func TestVMNICAttachChoicesDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readVMNICAttachChoices(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
@@ -0,0 +1,89 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &VMOVMFFirmwaresDataSource{}
+var _ datasource.DataSourceWithConfigure = &VMOVMFFirmwaresDataSource{}
+
+// VMOVMFFirmwaresDataSource defines the data source implementation.
+type VMOVMFFirmwaresDataSource struct {
+	services *services.TrueNASServices
+}
+
+// VMOVMFFirmwaresDataSourceModel describes the data source data model.
+type VMOVMFFirmwaresDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Firmwares []types.String `tfsdk:"firmwares"`
+}
+
+// NewVMOVMFFirmwaresDataSource creates a new VMOVMFFirmwaresDataSource.
+func NewVMOVMFFirmwaresDataSource() datasource.DataSource {
+	return &VMOVMFFirmwaresDataSource{}
+}
+
+func (d *VMOVMFFirmwaresDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_ovmf_firmwares"
+}
+
+func (d *VMOVMFFirmwaresDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the OVMF firmware files available on this host, for use as a VM's bootloader_ovmf.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always 'vm_ovmf_firmwares').",
+				Computed:    true,
+			},
+			"firmwares": schema.ListAttribute{
+				Description: "OVMF firmware file names, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *VMOVMFFirmwaresDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *VMOVMFFirmwaresDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VMOVMFFirmwaresDataSourceModel
+
+	choices, err := methods.VMBootloaderOVMFChoices(ctx, d.services.Client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read OVMF Firmwares",
+			fmt.Sprintf("Unable to read VM bootloader OVMF choices: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("vm_ovmf_firmwares")
+	data.Firmwares = vmChoiceNames(choices)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,103 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func TestNewVMOVMFFirmwaresDataSource(t *testing.T) {
+	ds := NewVMOVMFFirmwaresDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*VMOVMFFirmwaresDataSource)
+}
+
+func TestVMOVMFFirmwaresDataSource_Metadata(t *testing.T) {
+	ds := NewVMOVMFFirmwaresDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_vm_ovmf_firmwares" {
+		t.Errorf("expected TypeName 'truenas_vm_ovmf_firmwares', got %q", resp.TypeName)
+	}
+}
+
+func readVMOVMFFirmwares(t *testing.T, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) (*datasource.ReadResponse, VMOVMFFirmwaresDataSourceModel) {
+	t.Helper()
+
+	ds := &VMOVMFFirmwaresDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{CallFunc: callFunc},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), datasource.ReadRequest{}, resp)
+
+	var model VMOVMFFirmwaresDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+func TestVMOVMFFirmwaresDataSource_Read_Success(t *testing.T) {
+	resp, model := readVMOVMFFirmwares(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method != "vm.bootloader_ovmf_choices" {
+			t.Errorf("expected method 'vm.bootloader_ovmf_choices', got %q", method)
+		}
+		return json.RawMessage(`{"OVMF_CODE_4M.secboot.fd": "OVMF_CODE_4M.secboot.fd", "OVMF_CODE.fd": "OVMF_CODE.fd"}`), nil
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if model.ID.ValueString() != "vm_ovmf_firmwares" {
+		t.Errorf("expected ID 'vm_ovmf_firmwares', got %q", model.ID.ValueString())
+	}
+	want := []string{"OVMF_CODE.fd", "OVMF_CODE_4M.secboot.fd"}
+	if len(model.Firmwares) != len(want) {
+		t.Fatalf("expected %d firmwares, got %v", len(want), model.Firmwares)
+	}
+	for i, f := range want {
+		if model.Firmwares[i].ValueString() != f {
+			t.Errorf("expected firmware %d to be %q, got %q", i, f, model.Firmwares[i].ValueString())
+		}
+	}
+}
+
+func TestVMOVMFFirmwaresDataSource_Read_APIError(t *testing.T) {
+	resp, _ := readVMOVMFFirmwares(t, func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
@@ -0,0 +1,179 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &VMStatusDataSource{}
+var _ datasource.DataSourceWithConfigure = &VMStatusDataSource{}
+
+// VMStatusDataSource defines the data source implementation.
+type VMStatusDataSource struct {
+	services *services.TrueNASServices
+}
+
+// VMStatusDataSourceModel describes the data source data model.
+type VMStatusDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	State       types.String `tfsdk:"state"`
+	Running     types.Bool   `tfsdk:"running"`
+	PID         types.Int64  `tfsdk:"pid"`
+	DomainState types.String `tfsdk:"domain_state"`
+	Memory      types.Int64  `tfsdk:"memory"`
+	MemoryUsage types.Int64  `tfsdk:"memory_usage"`
+}
+
+// NewVMStatusDataSource creates a new VMStatusDataSource.
+func NewVMStatusDataSource() datasource.DataSource {
+	return &VMStatusDataSource{}
+}
+
+func (d *VMStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_status"
+}
+
+func (d *VMStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the runtime status of a TrueNAS virtual machine. Intended for check blocks that assert VMs are running after apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "VM ID.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "VM name.",
+				Required:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "VM state (e.g. RUNNING, STOPPED, SUSPENDED).",
+				Computed:    true,
+			},
+			"running": schema.BoolAttribute{
+				Description: "Whether the VM state is RUNNING.",
+				Computed:    true,
+			},
+			"pid": schema.Int64Attribute{
+				Description: "Process ID of the VM's hypervisor process. Null when the VM is not running.",
+				Computed:    true,
+			},
+			"domain_state": schema.StringAttribute{
+				Description: "State of the underlying libvirt domain (e.g. RUNNING, SHUTOFF, PAUSED).",
+				Computed:    true,
+			},
+			"memory": schema.Int64Attribute{
+				Description: "Memory allocated to the VM in MiB.",
+				Computed:    true,
+			},
+			"memory_usage": schema.Int64Attribute{
+				Description: "Memory currently used by the running VM in MiB. Null when the VM is not running.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *VMStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	s, ok := req.ProviderData.(*services.TrueNASServices)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *services.TrueNASServices, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.services = s
+}
+
+func (d *VMStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VMStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	filter := []any{[]any{[]any{"name", "=", name}}}
+	result, err := d.services.Client.Call(ctx, "vm.query", filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read VM",
+			fmt.Sprintf("Unable to read VM %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	var vms []truenas.VMResponse
+	if err := json.Unmarshal(result, &vms); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse VM response: %s", err.Error()),
+		)
+		return
+	}
+
+	if len(vms) == 0 {
+		resp.Diagnostics.AddError(
+			"VM Not Found",
+			fmt.Sprintf("VM %q was not found.", name),
+		)
+		return
+	}
+
+	vm := &vms[0]
+	mapVMStatusToModel(vm, &data)
+
+	if data.Running.ValueBool() {
+		result, err := d.services.Client.Call(ctx, "vm.get_memory_usage", vm.ID)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Read VM Memory Usage",
+				fmt.Sprintf("Unable to read memory usage of VM %q, memory_usage is left unset: %s", name, err.Error()),
+			)
+		} else {
+			var usage int64
+			if err := json.Unmarshal(result, &usage); err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Parse Response",
+					fmt.Sprintf("Unable to parse VM memory usage response: %s", err.Error()),
+				)
+				return
+			}
+			data.MemoryUsage = types.Int64Value(usage / (1 << 20))
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapVMStatusToModel maps a vm.query entry to the data source model.
+func mapVMStatusToModel(vm *truenas.VMResponse, data *VMStatusDataSourceModel) {
+	data.ID = types.StringValue(strconv.FormatInt(vm.ID, 10))
+	data.Name = types.StringValue(vm.Name)
+	data.State = types.StringValue(vm.Status.State)
+	data.Running = types.BoolValue(vm.Status.State == "RUNNING")
+	data.PID = types.Int64PointerValue(vm.Status.PID)
+	data.DomainState = types.StringValue(vm.Status.DomainState)
+	data.Memory = types.Int64Value(customtypes.NormalizeMemoryMiB(vm.Memory))
+	data.MemoryUsage = types.Int64Null()
+}
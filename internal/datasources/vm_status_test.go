@@ -0,0 +1,300 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewVMStatusDataSource(t *testing.T) {
+	ds := NewVMStatusDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil data source")
+	}
+
+	_ = datasource.DataSource(ds)
+	var _ datasource.DataSourceWithConfigure = ds.(*VMStatusDataSource)
+}
+
+func TestVMStatusDataSource_Metadata(t *testing.T) {
+	ds := NewVMStatusDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_vm_status" {
+		t.Errorf("expected TypeName 'truenas_vm_status', got %q", resp.TypeName)
+	}
+}
+
+func TestVMStatusDataSource_Schema(t *testing.T) {
+	ds := NewVMStatusDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.Background(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	nameAttr, ok := resp.Schema.Attributes["name"]
+	if !ok {
+		t.Fatal("expected 'name' attribute in schema")
+	}
+	if !nameAttr.IsRequired() {
+		t.Error("expected 'name' attribute to be required")
+	}
+
+	for _, name := range []string{"id", "state", "running", "pid", "domain_state", "memory", "memory_usage"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute in schema", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+func TestVMStatusDataSource_Configure_WrongType(t *testing.T) {
+	ds := NewVMStatusDataSource().(*VMStatusDataSource)
+
+	req := datasource.ConfigureRequest{
+		ProviderData: "not a services",
+	}
+	resp := &datasource.ConfigureResponse{}
+
+	ds.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for wrong ProviderData type")
+	}
+}
+
+// createVMStatusTestReadRequest creates a datasource.ReadRequest for the given VM name
+func createVMStatusTestReadRequest(t *testing.T, name string) datasource.ReadRequest {
+	t.Helper()
+
+	ds := NewVMStatusDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	configValue := tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":           tftypes.String,
+			"name":         tftypes.String,
+			"state":        tftypes.String,
+			"running":      tftypes.Bool,
+			"pid":          tftypes.Number,
+			"domain_state": tftypes.String,
+			"memory":       tftypes.Number,
+			"memory_usage": tftypes.Number,
+		},
+	}, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, nil),
+		"name":         tftypes.NewValue(tftypes.String, name),
+		"state":        tftypes.NewValue(tftypes.String, nil),
+		"running":      tftypes.NewValue(tftypes.Bool, nil),
+		"pid":          tftypes.NewValue(tftypes.Number, nil),
+		"domain_state": tftypes.NewValue(tftypes.String, nil),
+		"memory":       tftypes.NewValue(tftypes.Number, nil),
+		"memory_usage": tftypes.NewValue(tftypes.Number, nil),
+	})
+
+	return datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    configValue,
+		},
+	}
+}
+
+// newVMStatusTestDataSource returns a data source whose client answers
+// vm.query with vmJSON and vm.get_memory_usage with usage or usageErr.
+func newVMStatusTestDataSource(t *testing.T, vmJSON string, usage string, usageErr error, calls *[]string) *VMStatusDataSource {
+	t.Helper()
+
+	return &VMStatusDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					if calls != nil {
+						*calls = append(*calls, method)
+					}
+					switch method {
+					case "vm.query":
+						return json.RawMessage(vmJSON), nil
+					case "vm.get_memory_usage":
+						if usageErr != nil {
+							return nil, usageErr
+						}
+						return json.RawMessage(usage), nil
+					}
+					t.Errorf("unexpected method %q", method)
+					return nil, nil
+				},
+			},
+		},
+	}
+}
+
+func readVMStatus(t *testing.T, ds *VMStatusDataSource, name string) (*datasource.ReadResponse, VMStatusDataSourceModel) {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, schemaResp)
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+		},
+	}
+
+	ds.Read(context.Background(), createVMStatusTestReadRequest(t, name), resp)
+
+	var model VMStatusDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to get state: %v", diags)
+		}
+	}
+	return resp, model
+}
+
+const testRunningVMJSON = `[{
+	"id": 7,
+	"name": "web",
+	"memory": 4096,
+	"status": {"state": "RUNNING", "pid": 4242, "domain_state": "RUNNING"}
+}]`
+
+const testStoppedVMJSON = `[{
+	"id": 7,
+	"name": "web",
+	"memory": 4096,
+	"status": {"state": "STOPPED", "pid": null, "domain_state": "SHUTOFF"}
+}]`
+
+func TestVMStatusDataSource_Read_Running(t *testing.T) {
+	var calls []string
+	ds := newVMStatusTestDataSource(t, testRunningVMJSON, "2147483648", nil, &calls)
+
+	resp, model := readVMStatus(t, ds, "web")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(calls) != 2 || calls[0] != "vm.query" || calls[1] != "vm.get_memory_usage" {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+
+	if model.ID.ValueString() != "7" {
+		t.Errorf("expected id '7', got %q", model.ID.ValueString())
+	}
+	if model.State.ValueString() != "RUNNING" || !model.Running.ValueBool() {
+		t.Errorf("expected running VM, got state %q running %v", model.State.ValueString(), model.Running.ValueBool())
+	}
+	if model.PID.ValueInt64() != 4242 {
+		t.Errorf("expected pid 4242, got %d", model.PID.ValueInt64())
+	}
+	if model.DomainState.ValueString() != "RUNNING" {
+		t.Errorf("expected domain_state 'RUNNING', got %q", model.DomainState.ValueString())
+	}
+	if model.Memory.ValueInt64() != 4096 {
+		t.Errorf("expected memory 4096, got %d", model.Memory.ValueInt64())
+	}
+	if model.MemoryUsage.ValueInt64() != 2048 {
+		t.Errorf("expected memory_usage 2048, got %d", model.MemoryUsage.ValueInt64())
+	}
+}
+
+func TestVMStatusDataSource_Read_Stopped(t *testing.T) {
+	var calls []string
+	ds := newVMStatusTestDataSource(t, testStoppedVMJSON, "", nil, &calls)
+
+	resp, model := readVMStatus(t, ds, "web")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected only vm.query to be called, got %v", calls)
+	}
+
+	if model.Running.ValueBool() {
+		t.Error("expected running to be false")
+	}
+	if !model.PID.IsNull() {
+		t.Errorf("expected null pid, got %d", model.PID.ValueInt64())
+	}
+	if model.DomainState.ValueString() != "SHUTOFF" {
+		t.Errorf("expected domain_state 'SHUTOFF', got %q", model.DomainState.ValueString())
+	}
+	if !model.MemoryUsage.IsNull() {
+		t.Errorf("expected null memory_usage, got %d", model.MemoryUsage.ValueInt64())
+	}
+}
+
+func TestVMStatusDataSource_Read_MemoryUsageError(t *testing.T) {
+	ds := newVMStatusTestDataSource(t, testRunningVMJSON, "", errors.New("permission denied"), nil)
+
+	resp, model := readVMStatus(t, ds, "web")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning, got %v", resp.Diagnostics)
+	}
+	if !model.Running.ValueBool() {
+		t.Error("expected running to be true")
+	}
+	if !model.MemoryUsage.IsNull() {
+		t.Errorf("expected null memory_usage, got %d", model.MemoryUsage.ValueInt64())
+	}
+}
+
+func TestVMStatusDataSource_Read_NotFound(t *testing.T) {
+	ds := newVMStatusTestDataSource(t, `[]`, "", nil, nil)
+
+	resp, _ := readVMStatus(t, ds, "missing")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for missing VM")
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "VM Not Found" {
+		t.Errorf("unexpected error: %v", resp.Diagnostics)
+	}
+}
+
+func TestVMStatusDataSource_Read_APIError(t *testing.T) {
+	ds := &VMStatusDataSource{
+		services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+		},
+	}
+
+	resp, _ := readVMStatus(t, ds, "web")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API failure")
+	}
+}
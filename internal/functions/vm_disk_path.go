@@ -0,0 +1,106 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &VMDiskPathFunction{}
+
+// vmDiskParent is the dataset, relative to the pool, that holds VM disk zvols.
+const vmDiskParent = "vms"
+
+// zfsComponentRegex matches a single ZFS dataset name component.
+var zfsComponentRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]*$`)
+
+// VMDiskPathFunction defines the function implementation.
+type VMDiskPathFunction struct{}
+
+// VMDiskPathModel describes the object returned by the function.
+type VMDiskPathModel struct {
+	Pool    types.String `tfsdk:"pool"`
+	Path    types.String `tfsdk:"path"`
+	Dataset types.String `tfsdk:"dataset"`
+	Device  types.String `tfsdk:"device"`
+}
+
+// NewVMDiskPathFunction creates a new VMDiskPathFunction.
+func NewVMDiskPathFunction() function.Function {
+	return &VMDiskPathFunction{}
+}
+
+func (f *VMDiskPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "vm_disk_path"
+}
+
+func (f *VMDiskPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the zvol name and device path for a VM disk.",
+		Description: "Returns the conventional names for disk `disk_index` of VM `vm_name`, stored as the zvol " +
+			"`<pool>/vms/<vm_name>-disk<disk_index>`. Use `pool` and `path` on a truenas_zvol resource and `device` " +
+			"as the DISK device path of the truenas_vm resource so both always agree.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pool",
+				Description: "Pool that holds the VM disks (e.g. 'tank').",
+				Validators: []function.StringParameterValidator{
+					stringvalidator.RegexMatches(zfsComponentRegex, "must be a pool name, without slashes"),
+				},
+			},
+			function.StringParameter{
+				Name:        "vm_name",
+				Description: "VM name.",
+				Validators: []function.StringParameterValidator{
+					stringvalidator.RegexMatches(zfsComponentRegex, "must be a valid ZFS dataset name component"),
+				},
+			},
+			function.Int64Parameter{
+				Name:        "disk_index",
+				Description: "Zero-based index of the disk on the VM.",
+				Validators: []function.Int64ParameterValidator{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"pool":    types.StringType,
+				"path":    types.StringType,
+				"dataset": types.StringType,
+				"device":  types.StringType,
+			},
+		},
+	}
+}
+
+func (f *VMDiskPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pool, vmName string
+	var diskIndex int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &pool, &vmName, &diskIndex))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, vmDiskPath(pool, vmName, diskIndex)))
+}
+
+// vmDiskPath builds the zvol and device names for a VM disk.
+func vmDiskPath(pool, vmName string, diskIndex int64) VMDiskPathModel {
+	path := fmt.Sprintf("%s/%s-disk%d", vmDiskParent, vmName, diskIndex)
+	dataset := pool + "/" + path
+
+	return VMDiskPathModel{
+		Pool:    types.StringValue(pool),
+		Path:    types.StringValue(path),
+		Dataset: types.StringValue(dataset),
+		Device:  types.StringValue("/dev/zvol/" + dataset),
+	}
+}
@@ -0,0 +1,118 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testVMDiskPathAttrTypes = map[string]attr.Type{
+	"pool":    types.StringType,
+	"path":    types.StringType,
+	"dataset": types.StringType,
+	"device":  types.StringType,
+}
+
+func TestNewVMDiskPathFunction(t *testing.T) {
+	f := NewVMDiskPathFunction()
+	if f == nil {
+		t.Fatal("expected non-nil function")
+	}
+}
+
+func TestVMDiskPathFunction_Metadata(t *testing.T) {
+	f := NewVMDiskPathFunction()
+
+	resp := &function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+
+	if resp.Name != "vm_disk_path" {
+		t.Errorf("expected Name 'vm_disk_path', got %q", resp.Name)
+	}
+}
+
+func TestVMDiskPathFunction_Definition(t *testing.T) {
+	f := NewVMDiskPathFunction()
+
+	resp := &function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, resp)
+
+	if resp.Definition.Summary == "" || resp.Definition.Description == "" {
+		t.Error("expected non-empty summary and description")
+	}
+
+	want := []string{"pool", "vm_name", "disk_index"}
+	if len(resp.Definition.Parameters) != len(want) {
+		t.Fatalf("expected %d parameters, got %d", len(want), len(resp.Definition.Parameters))
+	}
+	for i, name := range want {
+		if got := resp.Definition.Parameters[i].GetName(); got != name {
+			t.Errorf("parameter %d: expected %q, got %q", i, name, got)
+		}
+	}
+
+	ret, ok := resp.Definition.Return.(function.ObjectReturn)
+	if !ok {
+		t.Fatalf("expected object return, got %T", resp.Definition.Return)
+	}
+	for name := range testVMDiskPathAttrTypes {
+		if _, ok := ret.AttributeTypes[name]; !ok {
+			t.Errorf("expected return attribute %q", name)
+		}
+	}
+}
+
+func TestVMDiskPathFunction_Run(t *testing.T) {
+	f := NewVMDiskPathFunction()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("tank"),
+			types.StringValue("web"),
+			types.Int64Value(1),
+		}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(testVMDiskPathAttrTypes)),
+	}
+
+	f.Run(context.Background(), req, resp)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	want := types.ObjectValueMust(testVMDiskPathAttrTypes, map[string]attr.Value{
+		"pool":    types.StringValue("tank"),
+		"path":    types.StringValue("vms/web-disk1"),
+		"dataset": types.StringValue("tank/vms/web-disk1"),
+		"device":  types.StringValue("/dev/zvol/tank/vms/web-disk1"),
+	})
+	if !resp.Result.Value().Equal(want) {
+		t.Errorf("expected %s, got %s", want, resp.Result.Value())
+	}
+}
+
+func TestZFSComponentRegex(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"tank", true},
+		{"ubuntu-22.04", true},
+		{"win_11", true},
+		{"tank/vms", false},
+		{"-web", false},
+		{"my vm", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := zfsComponentRegex.MatchString(tt.value); got != tt.valid {
+			t.Errorf("%q: expected valid=%v, got %v", tt.value, tt.valid, got)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultApplyNotificationSubject = `Terraform changed {{ len .Changes }} object(s) on {{ .Host }}`
+
+const defaultApplyNotificationTemplate = `Terraform finished changing {{ .Host }} at {{ .Time.Format "2006-01-02 15:04:05 MST" }}.
+
+{{ range .Changes }}- {{ . }}
+{{ end }}`
+
+// ApplyNotificationBlockModel describes the apply_notification block.
+type ApplyNotificationBlockModel struct {
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	To       types.List   `tfsdk:"to"`
+	Subject  types.String `tfsdk:"subject"`
+	Template types.String `tfsdk:"template"`
+}
+
+// applyNotificationData is the data passed to the subject and body templates.
+type applyNotificationData struct {
+	Host    string
+	Time    time.Time
+	Changes []string
+}
+
+// applyNotifier records the changes a provider instance makes while applying
+// and mails a summary of them through the NAS's mail.send.
+type applyNotifier struct {
+	host    string
+	to      []string
+	subject *template.Template
+	body    *template.Template
+
+	mu      sync.Mutex
+	client  client.Client
+	changes []string
+}
+
+// newApplyNotifier builds a notifier from the apply_notification block. It
+// returns nil when the block is absent or not enabled.
+func newApplyNotifier(ctx context.Context, host string, block *ApplyNotificationBlockModel) (*applyNotifier, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if block == nil || !block.Enabled.ValueBool() {
+		return nil, diags
+	}
+
+	n := &applyNotifier{host: host}
+
+	if !block.To.IsNull() && !block.To.IsUnknown() {
+		diags.Append(block.To.ElementsAs(ctx, &n.to, false)...)
+	}
+
+	for _, f := range []struct {
+		name     string
+		value    types.String
+		fallback string
+		dest     **template.Template
+	}{
+		{"subject", block.Subject, defaultApplyNotificationSubject, &n.subject},
+		{"template", block.Template, defaultApplyNotificationTemplate, &n.body},
+	} {
+		text := f.fallback
+		if !f.value.IsNull() && !f.value.IsUnknown() {
+			text = f.value.ValueString()
+		}
+		tmpl, err := template.New(f.name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("apply_notification").AtName(f.name),
+				"Invalid Apply Notification Template",
+				fmt.Sprintf("apply_notification.%s is not a valid Go template: %s", f.name, err.Error()),
+			)
+			continue
+		}
+		*f.dest = tmpl
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+	return n, diags
+}
+
+// wrap returns a client that records the changes made through c. The summary
+// is later sent with c itself so the mail is not recorded as a change.
+func (n *applyNotifier) wrap(c client.Client) client.Client {
+	n.client = c
+	return &recordingClient{Client: c, notifier: n}
+}
+
+// record adds a change to the summary.
+func (n *applyNotifier) record(change string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.changes = append(n.changes, change)
+}
+
+// send mails the summary of the recorded changes and clears them. Nothing is
+// sent when nothing changed. mail.send runs as a job on the NAS; send only
+// starts it and does not wait for delivery.
+func (n *applyNotifier) send(ctx context.Context) error {
+	n.mu.Lock()
+	changes := n.changes
+	n.changes = nil
+	n.mu.Unlock()
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	data := applyNotificationData{
+		Host:    n.host,
+		Time:    time.Now(),
+		Changes: changes,
+	}
+
+	var subject, body bytes.Buffer
+	if err := n.subject.Execute(&subject, data); err != nil {
+		return fmt.Errorf("rendering apply_notification.subject: %w", err)
+	}
+	if err := n.body.Execute(&body, data); err != nil {
+		return fmt.Errorf("rendering apply_notification.template: %w", err)
+	}
+
+	message := map[string]any{
+		"subject": strings.TrimSpace(subject.String()),
+		"text":    body.String(),
+	}
+	// Without recipients the NAS mails its local administrators
+	if len(n.to) > 0 {
+		message["to"] = n.to
+	}
+
+	if _, err := n.client.Call(ctx, "mail.send", []any{message}); err != nil {
+		return fmt.Errorf("sending apply notification for %s: %w", n.host, err)
+	}
+	return nil
+}
+
+// applyingKey marks the context of an ApplyResourceChange call.
+type applyingKey struct{}
+
+// withApplying marks ctx as belonging to a resource change being applied.
+func withApplying(ctx context.Context) context.Context {
+	return context.WithValue(ctx, applyingKey{}, true)
+}
+
+// isApplying reports whether ctx belongs to a resource change being applied.
+// Plans, refreshes and data source reads are never applying.
+func isApplying(ctx context.Context) bool {
+	applying, _ := ctx.Value(applyingKey{}).(bool)
+	return applying
+}
+
+// Server is the provider's protocol version 6 server. It wraps the framework
+// server so apply_notification can tell resource changes from plans and
+// refreshes, and reports on each apply once it is done.
+type Server struct {
+	tfprotov6.ProviderServer
+	notifier func() *applyNotifier
+	timing   func() *callTiming
+
+	mu  sync.Mutex
+	ctx context.Context
+}
+
+// NewServer returns the provider's protocol version 6 server. Close must be
+// called once Terraform is done with it.
+func NewServer(version string) *Server {
+	p := &TrueNASProvider{version: version}
+	return &Server{
+		ProviderServer: providerserver.NewProtocol6(p)(),
+		notifier:       func() *applyNotifier { return p.notifier },
+		timing:         func() *callTiming { return p.timing },
+	}
+}
+
+func (s *Server) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	// Keep the logger of a request to report from Close, which runs after
+	// the last one has returned
+	s.mu.Lock()
+	s.ctx = context.WithoutCancel(ctx)
+	s.mu.Unlock()
+
+	return s.ProviderServer.ApplyResourceChange(withApplying(ctx), req)
+}
+
+// Close finishes the run. Terraform closes a provider once every resource
+// using it is done, so the changes of the whole apply are mailed in a single
+// summary, however many dependency levels it had, and the API call totals
+// are logged. The response of the last change has already been returned, so
+// a failure to send is only logged.
+func (s *Server) Close() {
+	s.mu.Lock()
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	if ctx == nil {
+		return
+	}
+	if n := s.notifier(); n != nil {
+		if err := n.send(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to send apply notification", map[string]any{"error": err.Error()})
+		}
+	}
+	if t := s.timing(); t != nil {
+		if summary := t.summary(); summary != "" {
+			tflog.Info(ctx, summary)
+		}
+	}
+}
+
+// recordingClient records every successful API call and file operation that
+// modifies the system while a resource change is applied, using the same
+// classification as read_only.
+type recordingClient struct {
+	client.Client
+	notifier *applyNotifier
+}
+
+func (c *recordingClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	result, err := c.Client.Call(ctx, method, params)
+	if err == nil && isApplying(ctx) && !isReadOnlyMethod(method) {
+		c.notifier.record(describeChange(method, params))
+	}
+	return result, err
+}
+
+func (c *recordingClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	result, err := c.Client.CallAndWait(ctx, method, params)
+	if err == nil && isApplying(ctx) && !isReadOnlyMethod(method) {
+		c.notifier.record(describeChange(method, params))
+	}
+	return result, err
+}
+
+func (c *recordingClient) WriteFile(ctx context.Context, path string, params truenas.WriteFileParams) error {
+	err := c.Client.WriteFile(ctx, path, params)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("wrote " + path)
+	}
+	return err
+}
+
+func (c *recordingClient) DeleteFile(ctx context.Context, path string) error {
+	err := c.Client.DeleteFile(ctx, path)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("deleted " + path)
+	}
+	return err
+}
+
+func (c *recordingClient) RemoveDir(ctx context.Context, path string) error {
+	err := c.Client.RemoveDir(ctx, path)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("removed " + path)
+	}
+	return err
+}
+
+func (c *recordingClient) RemoveAll(ctx context.Context, path string) error {
+	err := c.Client.RemoveAll(ctx, path)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("removed " + path)
+	}
+	return err
+}
+
+func (c *recordingClient) Chown(ctx context.Context, path string, uid, gid int) error {
+	err := c.Client.Chown(ctx, path, uid, gid)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("changed ownership of " + path)
+	}
+	return err
+}
+
+func (c *recordingClient) ChmodRecursive(ctx context.Context, path string, mode fs.FileMode) error {
+	err := c.Client.ChmodRecursive(ctx, path, mode)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("changed permissions of " + path)
+	}
+	return err
+}
+
+func (c *recordingClient) MkdirAll(ctx context.Context, path string, mode fs.FileMode) error {
+	err := c.Client.MkdirAll(ctx, path, mode)
+	if err == nil && isApplying(ctx) {
+		c.notifier.record("created " + path)
+	}
+	return err
+}
+
+// describeChange names the object an API call changed: the method followed
+// by the ID or name it targets. Other parameters are left out since they can
+// hold secrets.
+func describeChange(method string, params any) string {
+	var id any
+	if firstParam(params, &id) {
+		switch v := id.(type) {
+		case string:
+			return method + " " + v
+		case float64:
+			return fmt.Sprintf("%s %v", method, v)
+		case map[string]any:
+			for _, key := range []string{"name", "app_name", "path", "id"} {
+				if s, ok := v[key].(string); ok && s != "" {
+					return method + " " + s
+				}
+			}
+		}
+	}
+	return method
+}
@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func applyNotificationBlock(to []string, subject, tmpl types.String) *ApplyNotificationBlockModel {
+	recipients := types.ListNull(types.StringType)
+	if to != nil {
+		elems := make([]attr.Value, len(to))
+		for i, addr := range to {
+			elems[i] = types.StringValue(addr)
+		}
+		recipients = types.ListValueMust(types.StringType, elems)
+	}
+	return &ApplyNotificationBlockModel{
+		Enabled:  types.BoolValue(true),
+		To:       recipients,
+		Subject:  subject,
+		Template: tmpl,
+	}
+}
+
+func TestNewApplyNotifier_Disabled(t *testing.T) {
+	for name, block := range map[string]*ApplyNotificationBlockModel{
+		"absent":   nil,
+		"disabled": {Enabled: types.BoolValue(false), To: types.ListNull(types.StringType)},
+	} {
+		n, diags := newApplyNotifier(context.Background(), "nas.local", block)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected errors: %v", name, diags)
+		}
+		if n != nil {
+			t.Errorf("%s: expected no notifier", name)
+		}
+	}
+}
+
+func TestNewApplyNotifier_InvalidTemplate(t *testing.T) {
+	block := applyNotificationBlock(nil, types.StringNull(), types.StringValue("{{ range .Changes }}"))
+
+	_, diags := newApplyNotifier(context.Background(), "nas.local", block)
+	if !diags.HasError() {
+		t.Fatal("expected error for unterminated template")
+	}
+	if diags.Errors()[0].Summary() != "Invalid Apply Notification Template" {
+		t.Errorf("unexpected summary %q", diags.Errors()[0].Summary())
+	}
+}
+
+func TestRecordingClient_RecordsSuccessfulChanges(t *testing.T) {
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if method == "sharing.smb.delete" {
+				return nil, errors.New("share is in use")
+			}
+			return json.RawMessage(`{}`), nil
+		},
+		CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return json.RawMessage(`{}`), nil
+		},
+	}
+
+	n, diags := newApplyNotifier(context.Background(), "nas.local", applyNotificationBlock(nil, types.StringNull(), types.StringNull()))
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	c := n.wrap(mock)
+	ctx := withApplying(context.Background())
+
+	// Plans and refreshes change nothing, even if they call a method that could
+	_, _ = c.Call(context.Background(), "pool.dataset.update", []any{"tank/apps", map[string]any{}})
+	_ = c.MkdirAll(context.Background(), "/mnt/tank/apps", 0o755)
+
+	_, _ = c.Call(ctx, "pool.dataset.query", []any{})
+	_, _ = c.Call(ctx, "filesystem.stat", "/mnt/tank/apps")
+	_, _ = c.Call(ctx, "pool.dataset.create", map[string]any{"name": "tank/apps"})
+	_, _ = c.Call(ctx, "sharing.smb.update", []any{4, map[string]any{"comment": "media"}})
+	_, _ = c.Call(ctx, "sharing.smb.delete", []any{5})
+	_, _ = c.CallAndWait(ctx, "app.create", map[string]any{"app_name": "immich"})
+	_ = c.WriteFile(ctx, "/mnt/tank/apps/compose.yaml", truenas.WriteFileParams{})
+
+	want := []string{
+		"pool.dataset.create tank/apps",
+		"sharing.smb.update 4",
+		"app.create immich",
+		"wrote /mnt/tank/apps/compose.yaml",
+	}
+	if strings.Join(n.changes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected changes %q, got %q", want, n.changes)
+	}
+}
+
+func TestApplyNotifier_Send(t *testing.T) {
+	var method string
+	var message map[string]any
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, m string, params any) (json.RawMessage, error) {
+			method = m
+			message = params.([]any)[0].(map[string]any)
+			return json.RawMessage(`12`), nil
+		},
+	}
+
+	block := applyNotificationBlock(
+		[]string{"storage@example.com"},
+		types.StringNull(),
+		types.StringValue("{{ .Host }}: {{ range .Changes }}[{{ . }}]{{ end }}"),
+	)
+	n, diags := newApplyNotifier(context.Background(), "nas.local", block)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	n.client = mock
+	n.record("pool.dataset.create tank/apps")
+	n.record("sharing.nfs.delete 3")
+
+	if err := n.send(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if method != "mail.send" {
+		t.Errorf("expected method 'mail.send', got %q", method)
+	}
+	if message["subject"] != "Terraform changed 2 object(s) on nas.local" {
+		t.Errorf("unexpected subject %q", message["subject"])
+	}
+	if message["text"] != "nas.local: [pool.dataset.create tank/apps][sharing.nfs.delete 3]" {
+		t.Errorf("unexpected text %q", message["text"])
+	}
+	if to, ok := message["to"].([]string); !ok || len(to) != 1 || to[0] != "storage@example.com" {
+		t.Errorf("unexpected recipients %v", message["to"])
+	}
+
+	// The changes were reported, so a second send has nothing to do
+	method = ""
+	if err := n.send(context.Background()); err != nil || method != "" {
+		t.Errorf("expected no second mail, got method %q and error %v", method, err)
+	}
+}
+
+func TestApplyNotifier_SendNothingChanged(t *testing.T) {
+	n, _ := newApplyNotifier(context.Background(), "nas.local", applyNotificationBlock(nil, types.StringNull(), types.StringNull()))
+	n.client = &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			t.Errorf("unexpected call to %s", method)
+			return nil, nil
+		},
+	}
+
+	if err := n.send(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestApplyNotifier_SendDefaultRecipients(t *testing.T) {
+	var message map[string]any
+	n, _ := newApplyNotifier(context.Background(), "nas.local", applyNotificationBlock(nil, types.StringNull(), types.StringNull()))
+	n.client = &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			message = params.([]any)[0].(map[string]any)
+			return json.RawMessage(`12`), nil
+		},
+	}
+	n.record("pool.dataset.delete tank/old")
+
+	if err := n.send(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := message["to"]; ok {
+		t.Error("expected 'to' to be omitted so the NAS mails its administrators")
+	}
+	if !strings.Contains(message["text"].(string), "- pool.dataset.delete tank/old\n") {
+		t.Errorf("expected default body to list the change, got %q", message["text"])
+	}
+}
+
+func TestDescribeChange(t *testing.T) {
+	tests := []struct {
+		method string
+		params any
+		want   string
+	}{
+		{"pool.dataset.delete", []any{"tank/apps", map[string]any{"recursive": true}}, "pool.dataset.delete tank/apps"},
+		{"sharing.nfs.update", []any{7, map[string]any{"path": "/mnt/tank/media"}}, "sharing.nfs.update 7"},
+		{"user.create", map[string]any{"username": "svc", "password": "secret"}, "user.create"},
+		{"sharing.smb.create", map[string]any{"name": "media", "path": "/mnt/tank/media"}, "sharing.smb.create media"},
+		{"service.restart", nil, "service.restart"},
+	}
+
+	for _, tt := range tests {
+		if got := describeChange(tt.method, tt.params); got != tt.want {
+			t.Errorf("describeChange(%q, %v) = %q; want %q", tt.method, tt.params, got, tt.want)
+		}
+	}
+}
+
+// fakeProviderServer is a tfprotov6.ProviderServer whose ApplyResourceChange
+// runs apply; it implements no other method.
+type fakeProviderServer struct {
+	tfprotov6.ProviderServer
+	apply func(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest)
+}
+
+func (f *fakeProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	f.apply(ctx, req)
+	return &tfprotov6.ApplyResourceChangeResponse{}, nil
+}
+
+func TestServer_SendsOneMailPerApply(t *testing.T) {
+	var mails []string
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if method == "mail.send" {
+				mails = append(mails, params.([]any)[0].(map[string]any)["text"].(string))
+			}
+			return json.RawMessage(`{}`), nil
+		},
+	}
+
+	n, _ := newApplyNotifier(context.Background(), "nas.local", applyNotificationBlock(nil, types.StringNull(),
+		types.StringValue("{{ range .Changes }}[{{ . }}]{{ end }}")))
+	c := n.wrap(mock)
+	server := &Server{
+		notifier: func() *applyNotifier { return n },
+		timing:   func() *callTiming { return nil },
+		ProviderServer: &fakeProviderServer{apply: func(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) {
+			_, _ = c.Call(ctx, "pool.dataset.create", map[string]any{"name": "tank/" + req.TypeName})
+		}},
+	}
+
+	// "b" depends on "a", so Terraform applies it once "a" has returned
+	_, _ = server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: "a"})
+	_, _ = server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: "b"})
+	if len(mails) != 0 {
+		t.Errorf("expected no mail before the provider is closed, got %q", mails)
+	}
+
+	server.Close()
+	server.Close()
+
+	if len(mails) != 1 || mails[0] != "[pool.dataset.create tank/a][pool.dataset.create tank/b]" {
+		t.Errorf("expected one summary of both changes, got %q", mails)
+	}
+}
+
+func TestServer_CloseWithoutApply(t *testing.T) {
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			t.Errorf("unexpected call to %s", method)
+			return nil, nil
+		},
+	}
+
+	n, _ := newApplyNotifier(context.Background(), "nas.local", applyNotificationBlock(nil, types.StringNull(), types.StringNull()))
+	n.wrap(mock)
+	server := &Server{notifier: func() *applyNotifier { return n }, timing: func() *callTiming { return nil }}
+
+	server.Close()
+}
+
+func TestServer_NoNotifier(t *testing.T) {
+	applied := false
+	server := &Server{
+		notifier: func() *applyNotifier { return nil },
+		timing:   func() *callTiming { return nil },
+		ProviderServer: &fakeProviderServer{apply: func(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) {
+			applied = isApplying(ctx)
+		}},
+	}
+
+	if _, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !applied {
+		t.Error("expected the apply context to be marked")
+	}
+	server.Close()
+}
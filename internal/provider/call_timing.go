@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// callTimingTopMethods is how many methods the summary lists by total time.
+const callTimingTopMethods = 5
+
+// methodTiming aggregates the calls made to one API method.
+type methodTiming struct {
+	calls   int
+	retries int
+	total   time.Duration
+	max     time.Duration
+	jobWait time.Duration
+}
+
+// callTiming records how long a provider instance spends in API calls, so
+// slow middleware calls can be found when an apply takes minutes. Each call
+// is logged at DEBUG as it finishes; the totals are logged at INFO by the
+// Server once the apply is done.
+type callTiming struct {
+	host string
+
+	mu      sync.Mutex
+	methods map[string]*methodTiming
+}
+
+// newCallTiming returns an empty callTiming for host.
+func newCallTiming(host string) *callTiming {
+	return &callTiming{host: host, methods: make(map[string]*methodTiming)}
+}
+
+// attemptsKey is the context key of the attempt counter shared between
+// timedClient and attemptCountingClient.
+type attemptsKey struct{}
+
+// timedClient times every API call made through it.
+type timedClient struct {
+	client.Client
+	timing *callTiming
+}
+
+// withCallTiming wraps c so its calls are timed into timing.
+func withCallTiming(c client.Client, timing *callTiming) client.Client {
+	return &timedClient{Client: c, timing: timing}
+}
+
+func (c *timedClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	var attempts atomic.Int32
+	start := time.Now()
+	result, err := c.Client.Call(context.WithValue(ctx, attemptsKey{}, &attempts), method, params)
+	c.timing.record(ctx, method, false, time.Since(start), retriesFrom(&attempts), err)
+	return result, err
+}
+
+func (c *timedClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	var attempts atomic.Int32
+	start := time.Now()
+	result, err := c.Client.CallAndWait(context.WithValue(ctx, attemptsKey{}, &attempts), method, params)
+	c.timing.record(ctx, method, true, time.Since(start), retriesFrom(&attempts), err)
+	return result, err
+}
+
+// retriesFrom converts the attempts counted below the retrying client into
+// a retry count. Clients that retry internally count a single attempt.
+func retriesFrom(attempts *atomic.Int32) int {
+	if n := int(attempts.Load()); n > 1 {
+		return n - 1
+	}
+	return 0
+}
+
+// record adds a finished call to the totals and logs it. The whole duration
+// of a call that waits on a job counts as job wait time, since the job runs
+// on the NAS for most of it.
+func (t *callTiming) record(ctx context.Context, method string, job bool, d time.Duration, retries int, err error) {
+	t.mu.Lock()
+	m, ok := t.methods[method]
+	if !ok {
+		m = &methodTiming{}
+		t.methods[method] = m
+	}
+	m.calls++
+	m.retries += retries
+	m.total += d
+	m.max = max(m.max, d)
+	if job {
+		m.jobWait += d
+	}
+	t.mu.Unlock()
+
+	fields := map[string]any{
+		"method":      method,
+		"duration_ms": d.Milliseconds(),
+		"retries":     retries,
+		"job":         job,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.Debug(ctx, "TrueNAS API call timing", fields)
+}
+
+// summary describes the totals recorded so far, or returns "" when no calls
+// were made.
+func (t *callTiming) summary() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.methods) == 0 {
+		return ""
+	}
+
+	var total methodTiming
+	names := make([]string, 0, len(t.methods))
+	for name, m := range t.methods {
+		total.calls += m.calls
+		total.retries += m.retries
+		total.total += m.total
+		total.jobWait += m.jobWait
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := t.methods[names[i]], t.methods[names[j]]
+		if a.total != b.total {
+			return a.total > b.total
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > callTimingTopMethods {
+		names = names[:callTimingTopMethods]
+	}
+
+	slowest := make([]string, len(names))
+	for i, name := range names {
+		m := t.methods[name]
+		slowest[i] = fmt.Sprintf("%s (%d calls, %s total, %s max)", name, m.calls, roundDuration(m.total), roundDuration(m.max))
+	}
+
+	return fmt.Sprintf("TrueNAS API timing for %s: %d calls, %s in calls, %s waiting on jobs, %d retries; slowest: %s",
+		t.host, total.calls, roundDuration(total.total), roundDuration(total.jobWait), total.retries, strings.Join(slowest, ", "))
+}
+
+// roundDuration rounds d for display.
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
+// attemptCountingClient counts the attempts a retrying client makes, for the
+// timedClient above it to report as retries.
+type attemptCountingClient struct {
+	client.Client
+}
+
+// countAttempts wraps c, the client a retrying client calls, so its attempts
+// are counted.
+func countAttempts(c client.Client) client.Client {
+	return &attemptCountingClient{Client: c}
+}
+
+func (c *attemptCountingClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	countAttempt(ctx)
+	return c.Client.Call(ctx, method, params)
+}
+
+func (c *attemptCountingClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	countAttempt(ctx)
+	return c.Client.CallAndWait(ctx, method, params)
+}
+
+// countAttempt increments the attempt counter carried by ctx, if any.
+func countAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*atomic.Int32); ok {
+		attempts.Add(1)
+	}
+}
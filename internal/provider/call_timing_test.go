@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+// retryingClient stands in for the rate limited client: it retries a call
+// once when the first attempt fails.
+type retryingClient struct {
+	client.Client
+}
+
+func (c *retryingClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	result, err := c.Client.Call(ctx, method, params)
+	if err != nil {
+		return c.Client.Call(ctx, method, params)
+	}
+	return result, nil
+}
+
+func TestTimedClient_RecordsCalls(t *testing.T) {
+	failures := 1
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if method == "pool.query" && failures > 0 {
+				failures--
+				return nil, errors.New("connection reset")
+			}
+			return json.RawMessage(`[]`), nil
+		},
+		CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			time.Sleep(5 * time.Millisecond)
+			return json.RawMessage(`{}`), nil
+		},
+	}
+
+	timing := &callTiming{host: "nas.local", methods: make(map[string]*methodTiming)}
+	c := withCallTiming(&retryingClient{Client: countAttempts(mock)}, timing)
+	ctx := context.Background()
+
+	_, _ = c.Call(ctx, "pool.query", nil)
+	_, _ = c.Call(ctx, "pool.query", nil)
+	_, _ = c.CallAndWait(ctx, "app.create", nil)
+
+	query := timing.methods["pool.query"]
+	if query == nil || query.calls != 2 || query.retries != 1 || query.jobWait != 0 {
+		t.Errorf("unexpected pool.query timing %+v", query)
+	}
+	create := timing.methods["app.create"]
+	if create == nil || create.calls != 1 || create.retries != 0 || create.jobWait < 5*time.Millisecond {
+		t.Errorf("unexpected app.create timing %+v", create)
+	}
+}
+
+func TestCallTiming_Summary(t *testing.T) {
+	timing := &callTiming{host: "nas.local", methods: map[string]*methodTiming{
+		"pool.query": {calls: 3, total: 300 * time.Millisecond, max: 200 * time.Millisecond},
+		"app.create": {calls: 1, retries: 2, total: 90 * time.Second, max: 90 * time.Second, jobWait: 90 * time.Second},
+	}}
+
+	got := timing.summary()
+	want := "TrueNAS API timing for nas.local: 4 calls, 1m30.3s in calls, 1m30s waiting on jobs, 2 retries; " +
+		"slowest: app.create (1 calls, 1m30s total, 1m30s max), pool.query (3 calls, 300ms total, 200ms max)"
+	if got != want {
+		t.Errorf("unexpected summary:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestCallTiming_SummaryListsTopMethods(t *testing.T) {
+	timing := &callTiming{host: "nas.local", methods: make(map[string]*methodTiming)}
+	for i, method := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		d := time.Duration(i+1) * time.Second
+		timing.methods[method] = &methodTiming{calls: 1, total: d, max: d}
+	}
+
+	got := timing.summary()
+	if strings.Contains(got, "a (") || strings.Contains(got, "b (") || !strings.Contains(got, "slowest: g (") {
+		t.Errorf("expected the %d slowest methods, got %q", callTimingTopMethods, got)
+	}
+}
+
+func TestCallTiming_SummaryNoCalls(t *testing.T) {
+	timing := &callTiming{host: "nas.local", methods: make(map[string]*methodTiming)}
+	if got := timing.summary(); got != "" {
+		t.Errorf("expected no summary without calls, got %q", got)
+	}
+}
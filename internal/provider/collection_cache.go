@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cachedMethods maps the lookups served from the collection cache to the
+// collection they read.
+var cachedMethods = map[string]string{
+	"vm.query":           "vm.query",
+	"vm.get_instance":    "vm.query",
+	"vm.device.query":    "vm.device.query",
+	"pool.dataset.query": "pool.dataset.query",
+}
+
+// cachedCollection is the cached contents of one collection, in the order the
+// full query returned them.
+type cachedCollection struct {
+	// fill serializes loading, so concurrent lookups share one query
+	fill sync.Mutex
+
+	loaded      bool
+	unsupported bool
+	objects     []map[string]any
+	done        chan struct{}
+	close       func()
+}
+
+// collectionCache serves vm.query, vm.get_instance, vm.device.query and
+// pool.dataset.query from one full query per collection, kept current by a
+// core.subscribe subscription, instead of one query per resource during
+// refresh. Lookups it cannot answer locally, such as filters with query
+// options or operators other than =, !=, in and nin, go to the server.
+//
+// Once the provider makes a change the cache is dropped for the rest of the
+// run, so applies read what the NAS reports rather than what the events have
+// caught up with.
+type collectionCache struct {
+	client.Client
+
+	mu          sync.Mutex
+	disabled    bool
+	collections map[string]*cachedCollection
+}
+
+// withCollectionCache wraps c so collection lookups are served from a cache.
+func withCollectionCache(c client.Client) client.Client {
+	return &collectionCache{Client: c, collections: make(map[string]*cachedCollection)}
+}
+
+func (c *collectionCache) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if !isReadOnlyMethod(method) {
+		c.disable(ctx)
+		return c.Client.Call(ctx, method, params)
+	}
+
+	if result, ok := c.lookup(ctx, method, params); ok {
+		return result, nil
+	}
+	return c.Client.Call(ctx, method, params)
+}
+
+func (c *collectionCache) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if !isReadOnlyMethod(method) {
+		c.disable(ctx)
+	}
+	return c.Client.CallAndWait(ctx, method, params)
+}
+
+// lookup answers method from the cache, reporting false when the call has to
+// go to the server.
+func (c *collectionCache) lookup(ctx context.Context, method string, params any) (json.RawMessage, bool) {
+	name, ok := cachedMethods[method]
+	if !ok {
+		return nil, false
+	}
+	args, ok := callArgs(params)
+	if !ok {
+		return nil, false
+	}
+
+	objects, ok := c.load(ctx, name)
+	if !ok {
+		return nil, false
+	}
+
+	if method == "vm.get_instance" {
+		if len(args) != 1 {
+			return nil, false
+		}
+		for _, obj := range objects {
+			if reflect.DeepEqual(obj["id"], args[0]) {
+				return marshalCached(obj)
+			}
+		}
+		// Possibly created since the cache was filled
+		return nil, false
+	}
+
+	var filters []any
+	if len(args) > 0 && args[0] != nil {
+		if filters, ok = args[0].([]any); !ok {
+			return nil, false
+		}
+	}
+	if len(args) > 1 {
+		if options, ok := args[1].(map[string]any); !ok || len(options) > 0 {
+			return nil, false
+		}
+	}
+	if len(args) > 2 {
+		return nil, false
+	}
+
+	matched := []map[string]any{}
+	for _, obj := range objects {
+		match, ok := matchFilters(obj, filters)
+		if !ok {
+			return nil, false
+		}
+		if match {
+			matched = append(matched, obj)
+		}
+	}
+	return marshalCached(matched)
+}
+
+// load returns the cached objects of collection, filling the cache on first
+// use. It reports false when the collection cannot be cached, in which case
+// callers fall back to the server.
+func (c *collectionCache) load(ctx context.Context, name string) ([]map[string]any, bool) {
+	c.mu.Lock()
+	if c.disabled {
+		c.mu.Unlock()
+		return nil, false
+	}
+	col, ok := c.collections[name]
+	if !ok {
+		col = &cachedCollection{}
+		c.collections[name] = col
+	}
+	c.mu.Unlock()
+
+	col.fill.Lock()
+	defer col.fill.Unlock()
+
+	c.mu.Lock()
+	if c.disabled || col.unsupported {
+		c.mu.Unlock()
+		return nil, false
+	}
+	if col.loaded {
+		objects := col.objects
+		c.mu.Unlock()
+		return objects, true
+	}
+	c.mu.Unlock()
+
+	// Subscribe before querying so no change falls between the two
+	sub, err := c.Client.Subscribe(ctx, name, nil)
+	if err != nil {
+		tflog.Debug(ctx, "Not caching collection, subscribe failed", map[string]any{
+			"collection": name,
+			"error":      err.Error(),
+		})
+		c.mu.Lock()
+		col.unsupported = true
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	result, err := c.Client.Call(ctx, name, nil)
+	var objects []map[string]any
+	if err == nil {
+		err = json.Unmarshal(result, &objects)
+	}
+	if err != nil {
+		sub.Close()
+		tflog.Debug(ctx, "Not caching collection, query failed", map[string]any{
+			"collection": name,
+			"error":      err.Error(),
+		})
+		return nil, false
+	}
+
+	done := make(chan struct{})
+	c.mu.Lock()
+	if c.disabled {
+		c.mu.Unlock()
+		sub.Close()
+		return nil, false
+	}
+	col.loaded = true
+	col.objects = objects
+	col.done = done
+	col.close = sub.Close
+	c.mu.Unlock()
+
+	tflog.Debug(ctx, "Cached collection", map[string]any{
+		"collection": name,
+		"objects":    len(objects),
+	})
+
+	go c.follow(col, sub.C, done)
+	return objects, true
+}
+
+// follow applies the events of a collection's subscription to its cache
+// until the subscription ends or the cache drops it.
+func (c *collectionCache) follow(col *cachedCollection, events <-chan json.RawMessage, done chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case fields, ok := <-events:
+			if !ok {
+				c.mu.Lock()
+				if col.done == done {
+					c.reset(col)
+				}
+				c.mu.Unlock()
+				return
+			}
+			c.apply(col, fields, done)
+		}
+	}
+}
+
+// apply upserts the object an event carries. Events that do not identify an
+// object, such as removals, reset the cache so the next lookup queries
+// again.
+func (c *collectionCache) apply(col *cachedCollection, fields json.RawMessage, done chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if col.done != done {
+		return
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(fields, &obj); err != nil || obj == nil || obj["id"] == nil {
+		c.reset(col)
+		return
+	}
+
+	// Copy on write: lookups may still hold the previous slice
+	objects := make([]map[string]any, 0, len(col.objects)+1)
+	found := false
+	for _, existing := range col.objects {
+		if !found && reflect.DeepEqual(existing["id"], obj["id"]) {
+			merged := make(map[string]any, len(existing)+len(obj))
+			for k, v := range existing {
+				merged[k] = v
+			}
+			for k, v := range obj {
+				merged[k] = v
+			}
+			existing = merged
+			found = true
+		}
+		objects = append(objects, existing)
+	}
+	if !found {
+		objects = append(objects, obj)
+	}
+	col.objects = objects
+}
+
+// reset drops a collection's cached objects and subscription. c.mu must be
+// held.
+func (c *collectionCache) reset(col *cachedCollection) {
+	if col.done != nil {
+		close(col.done)
+		col.close()
+	}
+	col.loaded = false
+	col.objects = nil
+	col.done = nil
+	col.close = nil
+}
+
+// disable drops every cached collection for the rest of the run.
+func (c *collectionCache) disable(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disabled {
+		return
+	}
+	c.disabled = true
+	for _, col := range c.collections {
+		c.reset(col)
+	}
+	tflog.Debug(ctx, "Dropped collection cache after a change")
+}
+
+// callArgs normalizes params into the argument list sent to the API, the
+// same way the clients do: a slice is the argument list, anything else a
+// single argument. It reports false for params that cannot be round-tripped
+// through JSON.
+func callArgs(params any) ([]any, bool) {
+	if params == nil {
+		return nil, true
+	}
+	if _, ok := params.([]any); !ok {
+		params = []any{params}
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, false
+	}
+	var args []any
+	if err := json.Unmarshal(data, &args); err != nil {
+		return nil, false
+	}
+	return args, true
+}
+
+// matchFilters evaluates query filters against obj. It reports false as its
+// second result when a filter cannot be evaluated locally.
+func matchFilters(obj map[string]any, filters []any) (bool, bool) {
+	match := true
+	for _, f := range filters {
+		filter, ok := f.([]any)
+		if !ok || len(filter) != 3 {
+			return false, false
+		}
+		field, ok := filter[0].(string)
+		if !ok {
+			return false, false
+		}
+		op, ok := filter[1].(string)
+		if !ok {
+			return false, false
+		}
+
+		value, _ := fieldValue(obj, field)
+		var result bool
+		switch op {
+		case "=":
+			result = reflect.DeepEqual(value, filter[2])
+		case "!=":
+			result = !reflect.DeepEqual(value, filter[2])
+		case "in", "nin":
+			list, ok := filter[2].([]any)
+			if !ok {
+				return false, false
+			}
+			result = false
+			for _, v := range list {
+				if reflect.DeepEqual(value, v) {
+					result = true
+					break
+				}
+			}
+			if op == "nin" {
+				result = !result
+			}
+		default:
+			return false, false
+		}
+		match = match && result
+	}
+	return match, true
+}
+
+// fieldValue returns the value at a dotted field path in obj.
+func fieldValue(obj map[string]any, field string) (any, bool) {
+	var value any = obj
+	for _, part := range strings.Split(field, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// marshalCached encodes a cached result, reporting false if it cannot be
+// encoded so the call goes to the server instead.
+func marshalCached(v any) (json.RawMessage, bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+)
+
+// cacheTestClient serves a fixed collection and counts the calls that reach
+// it. Events sent on events are delivered to the subscription.
+type cacheTestClient struct {
+	mock    *client.MockClient
+	calls   map[string]int
+	events  chan json.RawMessage
+	closed  bool
+	objects string
+}
+
+func newCacheTestClient(objects string) *cacheTestClient {
+	tc := &cacheTestClient{
+		calls:   make(map[string]int),
+		events:  make(chan json.RawMessage),
+		objects: objects,
+	}
+	tc.mock = &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			tc.calls[method]++
+			if params == nil {
+				return json.RawMessage(tc.objects), nil
+			}
+			return json.RawMessage(`"server"`), nil
+		},
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return truenas.NewSubscription[json.RawMessage](tc.events, func() { tc.closed = true }), nil
+		},
+	}
+	return tc
+}
+
+// waitForCached polls until the cached answer to a vm.query by name matches
+// want, since events are applied in the background.
+func waitForCached(t *testing.T, c client.Client, name, want string) {
+	t.Helper()
+	var got string
+	for i := 0; i < 100; i++ {
+		result, err := c.Call(context.Background(), "vm.query", []any{[]any{[]any{"name", "=", name}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got = string(result); got == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %s, got %s", want, got)
+}
+
+func TestCollectionCache_ServesFilteredQueries(t *testing.T) {
+	tc := newCacheTestClient(`[{"id":1,"name":"web","status":{"state":"RUNNING"}},{"id":2,"name":"db","status":{"state":"STOPPED"}}]`)
+	c := withCollectionCache(tc.mock)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		method string
+		params any
+		want   string
+	}{
+		{"by name", "vm.query", []any{[]any{[]any{"name", "=", "db"}}}, `[{"id":2,"name":"db","status":{"state":"STOPPED"}}]`},
+		{"dotted field", "vm.query", []any{[]any{[]any{"status.state", "=", "RUNNING"}}}, `[{"id":1,"name":"web","status":{"state":"RUNNING"}}]`},
+		{"not equal", "vm.query", []any{[]any{[]any{"id", "!=", 1}}}, `[{"id":2,"name":"db","status":{"state":"STOPPED"}}]`},
+		{"in", "vm.query", []any{[]any{[]any{"name", "in", []string{"web", "db"}}}}, `[{"id":1,"name":"web","status":{"state":"RUNNING"}},{"id":2,"name":"db","status":{"state":"STOPPED"}}]`},
+		{"nin", "vm.query", []any{[]any{[]any{"name", "nin", []string{"web"}}}}, `[{"id":2,"name":"db","status":{"state":"STOPPED"}}]`},
+		{"no match", "vm.query", []any{[]any{[]any{"name", "=", "missing"}}}, `[]`},
+		{"get_instance", "vm.get_instance", int64(1), `{"id":1,"name":"web","status":{"state":"RUNNING"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.Call(ctx, tt.method, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(result) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, result)
+			}
+		})
+	}
+
+	if tc.calls["vm.query"] != 1 {
+		t.Errorf("expected one full vm.query, got %d", tc.calls["vm.query"])
+	}
+	if tc.calls["vm.get_instance"] != 0 {
+		t.Errorf("expected vm.get_instance to be served from the cache, got %d calls", tc.calls["vm.get_instance"])
+	}
+}
+
+func TestCollectionCache_VMDevices(t *testing.T) {
+	tc := newCacheTestClient(`[{"id":10,"vm":1,"attributes":{"dtype":"DISK"}},{"id":11,"vm":2,"attributes":{"dtype":"NIC"}},{"id":12,"vm":1,"attributes":{"dtype":"NIC"}}]`)
+	c := withCollectionCache(tc.mock)
+	vms := truenas.NewVMService(c, truenas.Version{})
+
+	for vmID, want := range map[int64][]int64{1: {10, 12}, 2: {11}} {
+		devices, err := vms.ListDevices(context.Background(), vmID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []int64
+		for _, d := range devices {
+			ids = append(ids, d.ID)
+		}
+		if !slices.Equal(ids, want) {
+			t.Errorf("expected devices %v for VM %d, got %v", want, vmID, ids)
+		}
+	}
+
+	if tc.calls["vm.device.query"] != 1 {
+		t.Errorf("expected one full vm.device.query, got %d", tc.calls["vm.device.query"])
+	}
+}
+
+func TestCollectionCache_BareFilter(t *testing.T) {
+	tc := newCacheTestClient(`[{"id":"tank/a","type":"FILESYSTEM"},{"id":"tank/b","type":"VOLUME"}]`)
+	c := withCollectionCache(tc.mock)
+
+	// truenas-go passes the filter list as params
+	result, err := c.Call(context.Background(), "pool.dataset.query", [][]any{{"id", "=", "tank/b"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `[{"id":"tank/b","type":"VOLUME"}]`; string(result) != want {
+		t.Errorf("expected %s, got %s", want, result)
+	}
+}
+
+func TestCollectionCache_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		params any
+	}{
+		{"options", "vm.query", []any{[]any{}, map[string]any{"get": true}}},
+		{"unsupported operator", "vm.query", []any{[]any{[]any{"name", "^", "w"}}}},
+		{"or filter", "vm.query", []any{[]any{[]any{"OR", []any{}}}}},
+		{"unknown instance", "vm.get_instance", int64(9)},
+		{"uncached collection", "app.query", []any{[]any{[]any{"name", "=", "web"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newCacheTestClient(`[{"id":1,"name":"web"}]`)
+			c := withCollectionCache(tc.mock)
+
+			result, err := c.Call(context.Background(), tt.method, tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(result) != `"server"` {
+				t.Errorf("expected the server's answer, got %s", result)
+			}
+		})
+	}
+}
+
+func TestCollectionCache_AppliesEvents(t *testing.T) {
+	tc := newCacheTestClient(`[{"id":1,"name":"web","autostart":true}]`)
+	c := withCollectionCache(tc.mock)
+
+	waitForCached(t, c, "web", `[{"autostart":true,"id":1,"name":"web"}]`)
+
+	tc.events <- json.RawMessage(`{"id":1,"name":"web","autostart":false}`)
+	waitForCached(t, c, "web", `[{"autostart":false,"id":1,"name":"web"}]`)
+
+	tc.events <- json.RawMessage(`{"id":2,"name":"db"}`)
+	waitForCached(t, c, "db", `[{"id":2,"name":"db"}]`)
+
+	if tc.calls["vm.query"] != 1 {
+		t.Errorf("expected one full vm.query, got %d", tc.calls["vm.query"])
+	}
+}
+
+func TestCollectionCache_RemovalReloads(t *testing.T) {
+	tc := newCacheTestClient(`[{"id":1,"name":"web"}]`)
+	c := withCollectionCache(tc.mock)
+
+	waitForCached(t, c, "web", `[{"id":1,"name":"web"}]`)
+
+	tc.objects = `[]`
+	tc.events <- json.RawMessage(`null`)
+	waitForCached(t, c, "web", `[]`)
+
+	if !tc.closed {
+		t.Error("expected the subscription to be closed")
+	}
+}
+
+func TestCollectionCache_DisabledByChange(t *testing.T) {
+	tc := newCacheTestClient(`[{"id":1,"name":"web"}]`)
+	c := withCollectionCache(tc.mock)
+	ctx := context.Background()
+	params := []any{[]any{[]any{"name", "=", "web"}}}
+
+	if _, err := c.Call(ctx, "vm.query", params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Call(ctx, "vm.update", []any{1, map[string]any{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tc.closed {
+		t.Error("expected the subscription to be closed")
+	}
+
+	result, err := c.Call(ctx, "vm.query", params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(result) != `"server"` {
+		t.Errorf("expected the server's answer after a change, got %s", result)
+	}
+}
+
+func TestCollectionCache_SubscribeUnsupported(t *testing.T) {
+	calls := 0
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			calls++
+			return json.RawMessage(`[]`), nil
+		},
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return nil, client.ErrUnsupportedOperation
+		},
+	}
+	c := withCollectionCache(mock)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Call(context.Background(), "vm.query", []any{[]any{[]any{"name", "=", "web"}}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected every lookup to reach the server, got %d calls", calls)
+	}
+}
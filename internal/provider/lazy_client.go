@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"sync"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// connectError is a failure to connect to TrueNAS, with the diagnostic
+// summary Configure reports it under when connecting eagerly.
+type connectError struct {
+	summary string
+	err     error
+}
+
+func (e *connectError) Error() string {
+	return e.summary + ": " + e.err.Error()
+}
+
+func (e *connectError) Unwrap() error {
+	return e.err
+}
+
+// addConnectError adds the diagnostic for a failed connection.
+func addConnectError(diags *diag.Diagnostics, err error) {
+	var ce *connectError
+	if errors.As(err, &ce) {
+		diags.AddError(ce.summary, ce.err.Error())
+		return
+	}
+	diags.AddError("Unable to Connect to TrueNAS", err.Error())
+}
+
+// lazyClient defers connecting to TrueNAS until the first API call, so a
+// configured provider alias that nothing in the plan uses does not dial the
+// host. It is safe for
+// concurrent use: callers racing on the first call share one connection
+// attempt, and its outcome is kept for every later call.
+type lazyClient struct {
+	client.Client
+
+	connect func(ctx context.Context) error
+	// onConnect runs once after the connection is established.
+	onConnect func()
+
+	mu        sync.Mutex
+	connected bool
+	err       error
+}
+
+func newLazyClient(c client.Client, connect func(ctx context.Context) error) *lazyClient {
+	return &lazyClient{Client: c, connect: connect}
+}
+
+// ensureConnected connects on first use. An attempt cut short by its caller's
+// context is not kept, so the next call tries again.
+func (c *lazyClient) ensureConnected(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connected {
+		return c.err
+	}
+
+	err := c.connect(ctx)
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+	c.connected = true
+	c.err = err
+	if err == nil && c.onConnect != nil {
+		c.onConnect()
+	}
+	return err
+}
+
+func (c *lazyClient) Connect(ctx context.Context) error {
+	return c.ensureConnected(ctx)
+}
+
+// Version connects if needed. The version of a host that cannot be reached
+// is reported as undetected; the connection error surfaces on the next call.
+func (c *lazyClient) Version() truenas.Version {
+	if err := c.ensureConnected(context.Background()); err != nil {
+		return truenas.Version{}
+	}
+	return c.Client.Version()
+}
+
+func (c *lazyClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.Call(ctx, method, params)
+}
+
+func (c *lazyClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.CallAndWait(ctx, method, params)
+}
+
+func (c *lazyClient) WriteFile(ctx context.Context, path string, params truenas.WriteFileParams) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.WriteFile(ctx, path, params)
+}
+
+func (c *lazyClient) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.ReadFile(ctx, path)
+}
+
+func (c *lazyClient) DeleteFile(ctx context.Context, path string) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.DeleteFile(ctx, path)
+}
+
+func (c *lazyClient) RemoveDir(ctx context.Context, path string) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.RemoveDir(ctx, path)
+}
+
+func (c *lazyClient) RemoveAll(ctx context.Context, path string) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.RemoveAll(ctx, path)
+}
+
+func (c *lazyClient) FileExists(ctx context.Context, path string) (bool, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return false, err
+	}
+	return c.Client.FileExists(ctx, path)
+}
+
+func (c *lazyClient) Chown(ctx context.Context, path string, uid, gid int) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.Chown(ctx, path, uid, gid)
+}
+
+func (c *lazyClient) ChmodRecursive(ctx context.Context, path string, mode fs.FileMode) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.ChmodRecursive(ctx, path, mode)
+}
+
+func (c *lazyClient) MkdirAll(ctx context.Context, path string, mode fs.FileMode) error {
+	if err := c.ensureConnected(ctx); err != nil {
+		return err
+	}
+	return c.Client.MkdirAll(ctx, path, mode)
+}
+
+func (c *lazyClient) Subscribe(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.Subscribe(ctx, collection, params)
+}
+
+// versionedService builds a service that depends on the TrueNAS version the
+// first time it is used, once a lazy client has connected and detected it.
+type versionedService[T any] struct {
+	client client.Client
+	build  func(truenas.Version) T
+
+	once sync.Once
+	svc  T
+}
+
+func (s *versionedService[T]) get() T {
+	s.once.Do(func() {
+		s.svc = s.build(s.client.Version())
+	})
+	return s.svc
+}
+
+// lazySnapshotService resolves the snapshot methods, which were renamed in
+// 25.10, on first use.
+type lazySnapshotService struct {
+	versionedService[*truenas.SnapshotService]
+}
+
+var _ truenas.SnapshotServiceAPI = (*lazySnapshotService)(nil)
+
+func newLazySnapshotService(c client.Client) *lazySnapshotService {
+	return &lazySnapshotService{versionedService[*truenas.SnapshotService]{
+		client: c,
+		build:  func(v truenas.Version) *truenas.SnapshotService { return truenas.NewSnapshotService(c, v) },
+	}}
+}
+
+func (s *lazySnapshotService) Create(ctx context.Context, opts truenas.CreateSnapshotOpts) (*truenas.Snapshot, error) {
+	return s.get().Create(ctx, opts)
+}
+
+func (s *lazySnapshotService) Get(ctx context.Context, id string) (*truenas.Snapshot, error) {
+	return s.get().Get(ctx, id)
+}
+
+func (s *lazySnapshotService) List(ctx context.Context) ([]truenas.Snapshot, error) {
+	return s.get().List(ctx)
+}
+
+func (s *lazySnapshotService) Delete(ctx context.Context, id string) error {
+	return s.get().Delete(ctx, id)
+}
+
+func (s *lazySnapshotService) Hold(ctx context.Context, id string) error {
+	return s.get().Hold(ctx, id)
+}
+
+func (s *lazySnapshotService) Release(ctx context.Context, id string) error {
+	return s.get().Release(ctx, id)
+}
+
+func (s *lazySnapshotService) Query(ctx context.Context, filters [][]any) ([]truenas.Snapshot, error) {
+	return s.get().Query(ctx, filters)
+}
+
+func (s *lazySnapshotService) Rollback(ctx context.Context, id string) error {
+	return s.get().Rollback(ctx, id)
+}
+
+func (s *lazySnapshotService) Clone(ctx context.Context, snapshot, datasetDst string) error {
+	return s.get().Clone(ctx, snapshot, datasetDst)
+}
+
+// lazyCloudSyncService picks the credential format, which changed in
+// TrueNAS 25, on first use.
+type lazyCloudSyncService struct {
+	versionedService[*truenas.CloudSyncService]
+}
+
+var _ truenas.CloudSyncServiceAPI = (*lazyCloudSyncService)(nil)
+
+func newLazyCloudSyncService(c client.Client) *lazyCloudSyncService {
+	return &lazyCloudSyncService{versionedService[*truenas.CloudSyncService]{
+		client: c,
+		build:  func(v truenas.Version) *truenas.CloudSyncService { return truenas.NewCloudSyncService(c, v) },
+	}}
+}
+
+func (s *lazyCloudSyncService) CreateCredential(ctx context.Context, opts truenas.CreateCredentialOpts) (*truenas.CloudSyncCredential, error) {
+	return s.get().CreateCredential(ctx, opts)
+}
+
+func (s *lazyCloudSyncService) GetCredential(ctx context.Context, id int64) (*truenas.CloudSyncCredential, error) {
+	return s.get().GetCredential(ctx, id)
+}
+
+func (s *lazyCloudSyncService) ListCredentials(ctx context.Context) ([]truenas.CloudSyncCredential, error) {
+	return s.get().ListCredentials(ctx)
+}
+
+func (s *lazyCloudSyncService) UpdateCredential(ctx context.Context, id int64, opts truenas.UpdateCredentialOpts) (*truenas.CloudSyncCredential, error) {
+	return s.get().UpdateCredential(ctx, id, opts)
+}
+
+func (s *lazyCloudSyncService) DeleteCredential(ctx context.Context, id int64) error {
+	return s.get().DeleteCredential(ctx, id)
+}
+
+func (s *lazyCloudSyncService) CreateTask(ctx context.Context, opts truenas.CreateCloudSyncTaskOpts) (*truenas.CloudSyncTask, error) {
+	return s.get().CreateTask(ctx, opts)
+}
+
+func (s *lazyCloudSyncService) GetTask(ctx context.Context, id int64) (*truenas.CloudSyncTask, error) {
+	return s.get().GetTask(ctx, id)
+}
+
+func (s *lazyCloudSyncService) ListTasks(ctx context.Context) ([]truenas.CloudSyncTask, error) {
+	return s.get().ListTasks(ctx)
+}
+
+func (s *lazyCloudSyncService) UpdateTask(ctx context.Context, id int64, opts truenas.UpdateCloudSyncTaskOpts) (*truenas.CloudSyncTask, error) {
+	return s.get().UpdateTask(ctx, id, opts)
+}
+
+func (s *lazyCloudSyncService) DeleteTask(ctx context.Context, id int64) error {
+	return s.get().DeleteTask(ctx, id)
+}
+
+func (s *lazyCloudSyncService) Sync(ctx context.Context, id int64) error {
+	return s.get().Sync(ctx, id)
+}
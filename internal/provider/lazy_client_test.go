@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestLazyClient_ConnectsOnFirstCall(t *testing.T) {
+	var connects atomic.Int32
+	var calls atomic.Int32
+	mock := &client.MockClient{
+		VersionVal: truenas.Version{Major: 25, Minor: 4},
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			calls.Add(1)
+			return json.RawMessage(`true`), nil
+		},
+	}
+	started := 0
+	c := newLazyClient(mock, func(ctx context.Context) error {
+		connects.Add(1)
+		return nil
+	})
+	c.onConnect = func() { started++ }
+
+	if connects.Load() != 0 {
+		t.Fatal("expected no connection before the first call")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Call(context.Background(), "system.ready", nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if connects.Load() != 1 {
+		t.Errorf("expected one connection for concurrent calls, got %d", connects.Load())
+	}
+	if started != 1 {
+		t.Errorf("expected onConnect to run once, got %d", started)
+	}
+	if calls.Load() != 10 {
+		t.Errorf("expected 10 calls to reach the client, got %d", calls.Load())
+	}
+	if v := c.Version(); !v.AtLeast(25, 4) {
+		t.Errorf("expected the connected version, got %v", v)
+	}
+}
+
+func TestLazyClient_KeepsConnectError(t *testing.T) {
+	connects := 0
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			t.Errorf("unexpected call to %q", method)
+			return nil, nil
+		},
+	}
+	c := newLazyClient(mock, func(ctx context.Context) error {
+		connects++
+		return &connectError{summary: "Unable to Connect to TrueNAS", err: errors.New("connection refused")}
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Call(context.Background(), "system.ready", nil)
+		if err == nil || err.Error() != "Unable to Connect to TrueNAS: connection refused" {
+			t.Fatalf("expected connect error, got %v", err)
+		}
+	}
+	if connects != 1 {
+		t.Errorf("expected a failed connection not to be retried, got %d attempts", connects)
+	}
+	if !c.Version().IsZero() {
+		t.Error("expected an undetected version when the host cannot be reached")
+	}
+}
+
+func TestLazyClient_RetriesAfterCanceledAttempt(t *testing.T) {
+	connects := 0
+	c := newLazyClient(&client.MockClient{}, func(ctx context.Context) error {
+		connects++
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Connect(ctx); err == nil {
+		t.Fatal("expected the canceled attempt to fail")
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("expected the next attempt to connect, got %v", err)
+	}
+	if connects != 2 {
+		t.Errorf("expected 2 attempts, got %d", connects)
+	}
+}
+
+func TestAddConnectError(t *testing.T) {
+	var diags diag.Diagnostics
+	addConnectError(&diags, &connectError{summary: "TrueNAS System Not Ready", err: errors.New("state BOOTING")})
+	if diags[0].Summary() != "TrueNAS System Not Ready" || diags[0].Detail() != "state BOOTING" {
+		t.Errorf("unexpected diagnostic %q: %q", diags[0].Summary(), diags[0].Detail())
+	}
+}
+
+func TestLazySnapshotService_UsesConnectedVersion(t *testing.T) {
+	var method string
+	mock := &client.MockClient{
+		VersionVal: truenas.Version{Major: 25, Minor: 10},
+		CallFunc: func(ctx context.Context, m string, params any) (json.RawMessage, error) {
+			method = m
+			return json.RawMessage(`[]`), nil
+		},
+	}
+	connected := false
+	c := newLazyClient(mock, func(ctx context.Context) error {
+		connected = true
+		return nil
+	})
+
+	svc := newLazySnapshotService(c)
+	if connected {
+		t.Fatal("expected no connection before the service is used")
+	}
+
+	if _, err := svc.List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if method != "pool.snapshot.query" {
+		t.Errorf("expected the 25.10 snapshot method, got %q", method)
+	}
+}
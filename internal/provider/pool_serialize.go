@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// diskKeyPrefix keys disk wipes by disk. Wiped disks never belong to a pool
+// (truenas_disk_wipe refuses pool members), and pool names cannot contain a
+// slash, so disk keys never collide with a pool name.
+const diskKeyPrefix = "/dev/"
+
+// poolEncryptionMethods change the encryption state of a dataset and are
+// serialized per pool. Their first parameter is the dataset ID.
+var poolEncryptionMethods = map[string]bool{
+	"pool.dataset.change_key":                           true,
+	"pool.dataset.inherit_parent_encryption_properties": true,
+	"pool.dataset.lock":                                 true,
+	"pool.dataset.unlock":                               true,
+}
+
+// poolLocks hands out one lock per pool name.
+type poolLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// lock blocks until the lock for pool is free or ctx ends, and returns the
+// function that releases it.
+func (l *poolLocks) lock(ctx context.Context, pool string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]chan struct{})
+	}
+	sem, ok := l.locks[pool]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		l.locks[pool] = sem
+	}
+	l.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	default:
+	}
+
+	tflog.Debug(ctx, "Waiting for another operation on the same pool", map[string]any{
+		"pool": pool,
+	})
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// poolSerializedClient runs operations that should not overlap on one pool
+// one at a time: pool creation, creating encrypted datasets and changing dataset
+// encryption. Wipes of the same disk are serialized too. Parallel resources targeting the same pool
+// queue behind each other; everything else passes straight through.
+type poolSerializedClient struct {
+	client.Client
+	locks *poolLocks
+}
+
+// withPoolSerialization wraps c so pool-wide operations against the same pool
+// are serialized.
+func withPoolSerialization(c client.Client) client.Client {
+	return &poolSerializedClient{Client: c, locks: &poolLocks{}}
+}
+
+func (c *poolSerializedClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if pool, ok := serializedPool(method, params); ok {
+		unlock, err := c.locks.lock(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	return c.Client.Call(ctx, method, params)
+}
+
+func (c *poolSerializedClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if pool, ok := serializedPool(method, params); ok {
+		unlock, err := c.locks.lock(ctx, pool)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	return c.Client.CallAndWait(ctx, method, params)
+}
+
+// serializedPool returns the pool whose lock method must hold, and false if
+// method runs without one.
+func serializedPool(method string, params any) (string, bool) {
+	switch {
+	case method == "disk.wipe":
+		var disk string
+		if !firstParam(params, &disk) || disk == "" {
+			return "", false
+		}
+		return diskKeyPrefix + disk, true
+	case method == "pool.create":
+		var args struct {
+			Name string `json:"name"`
+		}
+		if !firstParam(params, &args) || args.Name == "" {
+			return "", false
+		}
+		return args.Name, true
+	case method == "pool.dataset.create":
+		var args struct {
+			Name       string `json:"name"`
+			Encryption bool   `json:"encryption"`
+		}
+		if !firstParam(params, &args) || !args.Encryption {
+			return "", false
+		}
+		return poolOf(args.Name)
+	case poolEncryptionMethods[method]:
+		var id string
+		if !firstParam(params, &id) {
+			return "", false
+		}
+		return poolOf(id)
+	}
+	return "", false
+}
+
+// firstParam decodes the first API parameter into dest. Params are either a
+// list of parameters or a single parameter.
+func firstParam(params any, dest any) bool {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return false
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		if len(list) == 0 {
+			return false
+		}
+		raw = list[0]
+	}
+	return json.Unmarshal(raw, dest) == nil
+}
+
+// poolOf returns the pool name of a dataset ID such as "tank/apps".
+func poolOf(dataset string) (string, bool) {
+	pool, _, _ := strings.Cut(dataset, "/")
+	return pool, pool != ""
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestSerializedPool(t *testing.T) {
+	tests := []struct {
+		method string
+		params any
+		pool   string
+		ok     bool
+	}{
+		{"pool.create", map[string]any{"name": "tank", "topology": map[string]any{}}, "tank", true},
+		{"pool.dataset.create", map[string]any{"name": "tank/secure", "encryption": true}, "tank", true},
+		{"pool.dataset.create", map[string]any{"name": "tank/plain"}, "", false},
+		{"pool.dataset.change_key", []any{"tank/secure", map[string]any{"generate_key": true}}, "tank", true},
+		{"pool.dataset.unlock", []any{"backup/secure"}, "backup", true},
+		{"disk.wipe", []any{"sdb", "QUICK"}, "/dev/sdb", true},
+		{"disk.wipe", []any{}, "", false},
+		{"pool.dataset.delete", []any{"tank/apps"}, "", false},
+		{"pool.create", []any{}, "", false},
+	}
+
+	for _, tt := range tests {
+		pool, ok := serializedPool(tt.method, tt.params)
+		if pool != tt.pool || ok != tt.ok {
+			t.Errorf("serializedPool(%q, %v) = %q, %v; want %q, %v", tt.method, tt.params, pool, ok, tt.pool, tt.ok)
+		}
+	}
+}
+
+// concurrencyClient records the highest number of calls in flight at once.
+func concurrencyClient(maxInFlight *int32) *client.MockClient {
+	var inFlight int32
+	call := func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	}
+	return &client.MockClient{CallFunc: call, CallAndWaitFunc: call}
+}
+
+func runConcurrently(calls ...func()) {
+	var wg sync.WaitGroup
+	for _, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithPoolSerialization_SamePoolSerialized(t *testing.T) {
+	var maxInFlight int32
+	c := withPoolSerialization(concurrencyClient(&maxInFlight))
+	ctx := context.Background()
+
+	runConcurrently(
+		func() { _, _ = c.CallAndWait(ctx, "pool.create", map[string]any{"name": "tank"}) },
+		func() {
+			_, _ = c.Call(ctx, "pool.dataset.create", map[string]any{"name": "tank/a", "encryption": true})
+		},
+		func() { _, _ = c.Call(ctx, "pool.dataset.change_key", []any{"tank/b"}) },
+	)
+
+	if maxInFlight != 1 {
+		t.Errorf("expected operations on one pool to run one at a time, got %d in flight", maxInFlight)
+	}
+}
+
+func TestWithPoolSerialization_OtherCallsParallel(t *testing.T) {
+	var maxInFlight int32
+	c := withPoolSerialization(concurrencyClient(&maxInFlight))
+	ctx := context.Background()
+
+	runConcurrently(
+		func() {
+			_, _ = c.Call(ctx, "pool.dataset.create", map[string]any{"name": "tank/a", "encryption": true})
+		},
+		func() {
+			_, _ = c.Call(ctx, "pool.dataset.create", map[string]any{"name": "backup/a", "encryption": true})
+		},
+		func() { _, _ = c.Call(ctx, "pool.dataset.create", map[string]any{"name": "tank/plain"}) },
+		func() { _, _ = c.CallAndWait(ctx, "disk.wipe", []any{"sdb", "QUICK"}) },
+		func() { _, _ = c.CallAndWait(ctx, "disk.wipe", []any{"sdc", "QUICK"}) },
+	)
+
+	if maxInFlight != 5 {
+		t.Errorf("expected calls on different pools and unserialized calls to overlap, got %d in flight", maxInFlight)
+	}
+}
+
+func TestWithPoolSerialization_ContextCancelledWhileWaiting(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	c := withPoolSerialization(&client.MockClient{
+		CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			close(started)
+			<-release
+			return nil, nil
+		},
+	})
+
+	go func() { _, _ = c.CallAndWait(context.Background(), "pool.create", map[string]any{"name": "tank"}) }()
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.CallAndWait(ctx, "pool.create", map[string]any{"name": "tank"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the queued pool create to give up with its context, got %v", err)
+	}
+}
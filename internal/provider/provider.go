@@ -7,26 +7,47 @@ import (
 
 	truenas "github.com/deevus/truenas-go"
 	"github.com/deevus/truenas-go/client"
+	"github.com/deevus/terraform-provider-truenas/internal/actions"
 	"github.com/deevus/terraform-provider-truenas/internal/datasources"
+	"github.com/deevus/terraform-provider-truenas/internal/functions"
 	"github.com/deevus/terraform-provider-truenas/internal/resources"
 	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &TrueNASProvider{}
+var _ provider.ProviderWithActions = &TrueNASProvider{}
+var _ provider.ProviderWithFunctions = &TrueNASProvider{}
+
+// defaultHeartbeatInterval is the interval between connection health heartbeats
+// when the websocket block does not set heartbeat_interval.
+const defaultHeartbeatInterval = 30 * time.Second
 
 // TrueNASProviderModel describes the provider data model.
 type TrueNASProviderModel struct {
-	Host       types.String         `tfsdk:"host"`
-	AuthMethod types.String         `tfsdk:"auth_method"`
-	SSH        *SSHBlockModel       `tfsdk:"ssh"`
-	WebSocket  *WebSocketBlockModel `tfsdk:"websocket"`
-	RateLimit  types.Int64          `tfsdk:"rate_limit"`
-	MaxRetries types.Int64          `tfsdk:"max_retries"`
+	Host               types.String                 `tfsdk:"host"`
+	AuthMethod         types.String                 `tfsdk:"auth_method"`
+	SSH                *SSHBlockModel               `tfsdk:"ssh"`
+	WebSocket          *WebSocketBlockModel         `tfsdk:"websocket"`
+	RateLimit          types.Int64                  `tfsdk:"rate_limit"`
+	MaxRetries         types.Int64                  `tfsdk:"max_retries"`
+	WaitForSystemReady types.Bool                   `tfsdk:"wait_for_system_ready"`
+	SystemReadyTimeout types.Int64                  `tfsdk:"system_ready_timeout"`
+	EagerConnect       types.Bool                   `tfsdk:"eager_connect"`
+	ReadOnly           types.Bool                   `tfsdk:"read_only"`
+	ReportDrift        types.Bool                   `tfsdk:"report_drift"`
+	MaintenanceGuard   types.Bool                   `tfsdk:"maintenance_guard"`
+	MaintenanceTimeout types.Int64                  `tfsdk:"maintenance_guard_timeout"`
+	DefaultTimeouts    *DefaultTimeoutsBlockModel   `tfsdk:"default_timeouts"`
+	ApplyNotification  *ApplyNotificationBlockModel `tfsdk:"apply_notification"`
 }
 
 // SSHBlockModel describes the SSH configuration block.
@@ -47,11 +68,20 @@ type WebSocketBlockModel struct {
 	MaxConcurrent      types.Int64  `tfsdk:"max_concurrent"`
 	ConnectTimeout     types.Int64  `tfsdk:"connect_timeout"`
 	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	HeartbeatInterval  types.Int64  `tfsdk:"heartbeat_interval"`
+	CacheCollections   types.Bool   `tfsdk:"cache_collections"`
 }
 
 type TrueNASProvider struct {
 	version string
 	factory ClientFactory
+
+	// notifier reports the changes made by applies when apply_notification
+	// is enabled.
+	notifier *applyNotifier
+
+	// timing totals the API calls made by this instance.
+	timing *callTiming
 }
 
 func New(version string) func() provider.Provider {
@@ -62,6 +92,17 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// NewWithFactory returns a provider that creates its clients with factory.
+// It lets acceptance tests point the provider at a mock middleware.
+func NewWithFactory(version string, factory ClientFactory) func() provider.Provider {
+	return func() provider.Provider {
+		return &TrueNASProvider{
+			version: version,
+			factory: factory,
+		}
+	}
+}
+
 func (p *TrueNASProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "truenas"
 	resp.Version = p.version
@@ -89,8 +130,100 @@ func (p *TrueNASProvider) Schema(ctx context.Context, req provider.SchemaRequest
 					"Set to 0 to disable retries.",
 				Optional: true,
 			},
+			"wait_for_system_ready": schema.BoolAttribute{
+				Description: "Wait for TrueNAS to finish booting before managing resources: connection errors " +
+					"are retried and system.ready is polled until it reports true. Useful when applies run " +
+					"right after a reboot. Defaults to false.",
+				Optional: true,
+			},
+			"system_ready_timeout": schema.Int64Attribute{
+				Description: "Seconds to wait for the system when wait_for_system_ready is enabled. Defaults to 600.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"eager_connect": schema.BoolAttribute{
+				Description: "Connect to TrueNAS when the provider is configured, so bad credentials or an " +
+					"unreachable host fail straight away. By default the connection is made by the first API " +
+					"call, which keeps plans with many unused provider aliases fast. " +
+					"Defaults to false.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Reject every operation that would modify the TrueNAS host. Reads and data sources work " +
+					"normally, while create, update, delete, actions and SSH commands fail with a read-only error. " +
+					"Use with a restricted API key to run plans and drift checks safely. Defaults to false.",
+				Optional: true,
+			},
+			"report_drift": schema.BoolAttribute{
+				Description: "Emit a warning for each configured attribute found changed outside Terraform (for example " +
+					"in the TrueNAS UI) when resources are refreshed, so out-of-band edits are called out in plan " +
+					"output instead of being silently reverted on the next apply. Defaults to false.",
+				Optional: true,
+			},
+			"maintenance_guard": schema.BoolAttribute{
+				Description: "Refuse destructive pool and dataset operations (dataset and zvol destroys, disk wipes) " +
+					"while a pool scrub or resilver or a system update is running, with an error listing the blocking " +
+					"operation. Defaults to false.",
+				Optional: true,
+			},
+			"maintenance_guard_timeout": schema.Int64Attribute{
+				Description: "Seconds a guarded operation waits for blocking maintenance to finish before it is " +
+					"refused. Defaults to 0, which refuses immediately.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
+			"apply_notification": schema.SingleNestedBlock{
+				Description: "Mail a summary of the changes an apply made through the NAS's own mail settings, so " +
+					"administrators who do not use Terraform learn about them. Sent once per apply, when Terraform " +
+					"is done with the provider, and only if something changed.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Send the summary. Defaults to false.",
+						Optional:    true,
+					},
+					"to": schema.ListAttribute{
+						Description: "Recipients of the summary. Defaults to the e-mail addresses of the NAS's local administrators.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"subject": schema.StringAttribute{
+						Description: "Go template for the subject. Defaults to \"Terraform changed {{ len .Changes }} object(s) on {{ .Host }}\".",
+						Optional:    true,
+					},
+					"template": schema.StringAttribute{
+						Description: "Go template for the message body. It receives .Host, .Time and .Changes, the list of " +
+							"changed objects. Defaults to a list of the changes.",
+						Optional: true,
+					},
+				},
+			},
+			"default_timeouts": schema.SingleNestedBlock{
+				Description: "Provider-wide deadlines for API operations, as durations such as \"30s\", \"2m\" or \"1h\". " +
+					"Resource-specific timeouts still apply when they are shorter.",
+				Attributes: map[string]schema.Attribute{
+					"job": schema.StringAttribute{
+						Description: "Deadline for an API call that starts a job and waits for it to finish, such as " +
+							"creating an app or wiping a disk. No deadline when unset.",
+						Optional: true,
+					},
+					"call": schema.StringAttribute{
+						Description: "Deadline for a single API call that does not wait on a job. No deadline when unset.",
+						Optional:    true,
+					},
+					"connect": schema.StringAttribute{
+						Description: "Deadline for establishing a WebSocket or SSH command connection. " +
+							"websocket.connect_timeout takes precedence for the WebSocket connection. " +
+							"Defaults to 30s for WebSocket connections and no deadline for SSH.",
+						Optional: true,
+					},
+				},
+			},
 			"ssh": schema.SingleNestedBlock{
 				Description: "SSH connection configuration.",
 				Attributes: map[string]schema.Attribute{
@@ -152,6 +285,17 @@ func (p *TrueNASProvider) Schema(ctx context.Context, req provider.SchemaRequest
 						Description: "Maximum retry attempts for transient errors. Defaults to 3.",
 						Optional:    true,
 					},
+					"heartbeat_interval": schema.Int64Attribute{
+						Description: "Interval in seconds between core.ping heartbeats used to track connection health " +
+							"(see the truenas_provider_health data source). Defaults to 30. Set to 0 to disable.",
+						Optional: true,
+					},
+					"cache_collections": schema.BoolAttribute{
+						Description: "Serve VM, VM device and dataset lookups from one query per collection, kept current with " +
+							"core.subscribe events, instead of one query per resource. Speeds up plans with many VMs " +
+							"or datasets. The cache is dropped once the provider makes a change. Defaults to false.",
+						Optional: true,
+					},
 				},
 			},
 		},
@@ -167,6 +311,26 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	timeouts, diags := parseDefaultTimeouts(config.DefaultTimeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notifier, diags := newApplyNotifier(ctx, config.Host.ValueString(), config.ApplyNotification)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retry connecting until the system is up when asked to wait for it. The
+	// waiter is created when connecting, which may be well after Configure.
+	var waiter *systemWaiter
+	systemReadyTimeout := defaultSystemReadyTimeout
+	if !config.SystemReadyTimeout.IsNull() {
+		systemReadyTimeout = time.Duration(config.SystemReadyTimeout.ValueInt64()) * time.Second
+	}
+
 	// Resolve factory (use default if not set)
 	factory := p.factory
 	if factory == nil {
@@ -174,6 +338,14 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	var finalClient client.Client
+	var execConfig *client.SSHConfig
+	// Background heartbeats are only sent over WebSocket; over SSH every call
+	// spawns midclt, so health is only sampled on demand.
+	var heartbeatInterval time.Duration
+	// Collections can only be cached where core.subscribe is available
+	var cacheCollections bool
+	// connect dials the host and detects its version
+	var connect func(ctx context.Context) error
 
 	switch config.AuthMethod.ValueString() {
 	case "websocket":
@@ -235,25 +407,7 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 			)
 			return
 		}
-
-		// Connect SSH client to detect version
-		if err := sshClient.Connect(ctx); err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Connect to TrueNAS",
-				err.Error(),
-			)
-			return
-		}
-
-		// Validate version for WebSocket mode
-		if !sshClient.Version().AtLeast(25, 0) {
-			resp.Diagnostics.AddError(
-				"WebSocket Transport Requires TrueNAS 25.0+",
-				fmt.Sprintf("Detected version %s. Use auth_method = \"ssh\" instead.",
-					sshClient.Version().Raw),
-			)
-			return
-		}
+		execConfig = sshConfig
 
 		// Create WebSocket client
 		wsConfig := client.WebSocketConfig{
@@ -271,6 +425,7 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 		if !config.WebSocket.MaxConcurrent.IsNull() {
 			wsConfig.MaxConcurrent = int(config.WebSocket.MaxConcurrent.ValueInt64())
 		}
+		wsConfig.ConnectTimeout = timeouts.connect
 		if !config.WebSocket.ConnectTimeout.IsNull() {
 			wsConfig.ConnectTimeout = time.Duration(config.WebSocket.ConnectTimeout.ValueInt64()) * time.Second
 		}
@@ -287,17 +442,36 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 			return
 		}
 
-		// Connect WebSocket client (caches version from fallback)
-		if err := wsClient.Connect(ctx); err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Connect WebSocket Client",
-				err.Error(),
-			)
-			return
+		connect = func(ctx context.Context) error {
+			// Connect SSH client to detect version
+			if err := waiter.retry(ctx, sshClient.Connect); err != nil {
+				return &connectError{summary: "Unable to Connect to TrueNAS", err: err}
+			}
+
+			// Validate version for WebSocket mode
+			if !sshClient.Version().AtLeast(25, 0) {
+				return &connectError{
+					summary: "WebSocket Transport Requires TrueNAS 25.0+",
+					err: fmt.Errorf("Detected version %s. Use auth_method = \"ssh\" instead.",
+						sshClient.Version().Raw),
+				}
+			}
+
+			// Connect WebSocket client (caches version from fallback)
+			if err := waiter.retry(ctx, wsClient.Connect); err != nil {
+				return &connectError{summary: "Unable to Connect WebSocket Client", err: err}
+			}
+			return nil
 		}
 
 		finalClient = wsClient
 
+		heartbeatInterval = defaultHeartbeatInterval
+		if !config.WebSocket.HeartbeatInterval.IsNull() {
+			heartbeatInterval = time.Duration(config.WebSocket.HeartbeatInterval.ValueInt64()) * time.Second
+		}
+		cacheCollections = config.WebSocket.CacheCollections.ValueBool()
+
 	case "ssh", "":
 		// Validate SSH block is provided
 		if config.SSH == nil {
@@ -335,14 +509,14 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 			)
 			return
 		}
-
-		// Connect SSH client to detect version
-		if err := sshClient.Connect(ctx); err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Connect to TrueNAS",
-				err.Error(),
-			)
-			return
+		execConfig = sshConfig
+
+		connect = func(ctx context.Context) error {
+			// Connect SSH client to detect version
+			if err := waiter.retry(ctx, sshClient.Connect); err != nil {
+				return &connectError{summary: "Unable to Connect to TrueNAS", err: err}
+			}
+			return nil
 		}
 
 		// Apply rate limiting defaults
@@ -358,7 +532,7 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 		// Wrap client with rate limiting and retry
 		finalClient = client.NewRateLimitedClient(
-			sshClient,
+			countAttempts(sshClient),
 			rateLimit,
 			maxRetries,
 			&client.SSHRetryClassifier{},
@@ -372,22 +546,107 @@ func (p *TrueNASProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	transport := finalClient
+	establish := func(ctx context.Context) error {
+		if config.WaitForSystemReady.ValueBool() {
+			waiter = newSystemWaiter(systemReadyTimeout)
+		}
+		if err := connect(ctx); err != nil {
+			return err
+		}
+		if waiter != nil {
+			if err := waiter.waitReady(ctx, transport); err != nil {
+				return &connectError{summary: "TrueNAS System Not Ready", err: err}
+			}
+		}
+		return nil
+	}
+
+	// default_timeouts bound the API calls themselves. Applied inside the lazy
+	// client, they leave connecting and waiting for the system to
+	// connect_timeout and system_ready_timeout.
+	finalClient = withTimeouts(finalClient, timeouts)
+
+	// Unless asked to fail fast, the first API call connects, so providers
+	// that are configured but never used do not dial the host
+	var lazy *lazyClient
+	if config.EagerConnect.ValueBool() {
+		if err := establish(ctx); err != nil {
+			addConnectError(&resp.Diagnostics, err)
+			return
+		}
+	} else {
+		lazy = newLazyClient(finalClient, establish)
+		finalClient = lazy
+	}
+
+	// Heartbeats are not counted, so a long apply's totals are not padded
+	// with core.ping calls
+	untimed := finalClient
+	p.timing = newCallTiming(config.Host.ValueString())
+	finalClient = withCallTiming(finalClient, p.timing)
+	finalClient = withPoolSerialization(finalClient)
+	finalClient = withVMDeviceBatch(finalClient)
+	if cacheCollections {
+		finalClient = withCollectionCache(finalClient)
+	}
+	// A read-only provider changes nothing, so there is nothing to report
+	if notifier != nil && !config.ReadOnly.ValueBool() {
+		finalClient = notifier.wrap(finalClient)
+		p.notifier = notifier
+	}
+	finalClient = withReadOnly(finalClient, config.ReadOnly.ValueBool())
+
+	health := services.NewHealthMonitor(untimed, 0)
+	if heartbeatInterval > 0 {
+		if lazy != nil {
+			lazy.onConnect = func() { health.Start(heartbeatInterval) }
+		} else {
+			health.Start(heartbeatInterval)
+		}
+	}
+
+	// Of the services, only snapshot and cloud sync read the version, so with
+	// a lazy connection they are built once it is known and the rest get an
+	// undetected version
+	var version truenas.Version
+	var snapshot truenas.SnapshotServiceAPI
+	var cloudSync truenas.CloudSyncServiceAPI
+	if lazy != nil {
+		snapshot = newLazySnapshotService(finalClient)
+		cloudSync = newLazyCloudSyncService(finalClient)
+	} else {
+		version = finalClient.Version()
+		snapshot = truenas.NewSnapshotService(finalClient, version)
+		cloudSync = truenas.NewCloudSyncService(finalClient, version)
+	}
+
 	// Build service registry
-	version := finalClient.Version()
 	svc := &services.TrueNASServices{
+		Host:       config.Host.ValueString(),
 		Client:     finalClient,
 		App:        truenas.NewAppService(finalClient, version),
-		CloudSync:  truenas.NewCloudSyncService(finalClient, version),
+		CloudSync:  cloudSync,
 		Cron:       truenas.NewCronService(finalClient, version),
 		Dataset:    truenas.NewDatasetService(finalClient, version),
+		Docker:     truenas.NewDockerService(finalClient, version),
 		Filesystem: truenas.NewFilesystemService(finalClient, version),
-		Snapshot:   truenas.NewSnapshotService(finalClient, version),
+		Interface:  truenas.NewInterfaceService(finalClient, version),
+		Snapshot:   snapshot,
+		System:     truenas.NewSystemService(finalClient, version),
 		Virt:       truenas.NewVirtService(finalClient, version),
 		VM:         truenas.NewVMService(finalClient, version),
+		Exec:       withReadOnlyRunner(newSSHCommandRunner(execConfig, timeouts.connect), config.ReadOnly.ValueBool()),
+		Health:     health,
+
+		ReportDrift:        config.ReportDrift.ValueBool(),
+		MaintenanceGuard:   config.MaintenanceGuard.ValueBool(),
+		MaintenanceTimeout: time.Duration(config.MaintenanceTimeout.ValueInt64()) * time.Second,
 	}
 
 	resp.DataSourceData = svc
 	resp.ResourceData = svc
+	resp.ActionData = svc
 }
 
 func (p *TrueNASProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -397,6 +656,25 @@ func (p *TrueNASProvider) DataSources(ctx context.Context) []func() datasource.D
 		datasources.NewSnapshotsDataSource,
 		datasources.NewCloudSyncCredentialsDataSource,
 		datasources.NewVirtConfigDataSource,
+		datasources.NewAppStatusDataSource,
+		datasources.NewEnclosureDataSource,
+		datasources.NewDiscoveryDataSource,
+		datasources.NewProviderHealthDataSource,
+		datasources.NewJobDataSource,
+		datasources.NewAuditDataSource,
+		datasources.NewSMBSharesDataSource,
+		datasources.NewNFSSharesDataSource,
+		datasources.NewHostFactsDataSource,
+		datasources.NewVMStatusDataSource,
+		datasources.NewAppAvailableVersionsDataSource,
+		datasources.NewVMCPUModelsDataSource,
+		datasources.NewVMOVMFFirmwaresDataSource,
+		datasources.NewPoolStatusDataSource,
+		datasources.NewVMDiskChoicesDataSource,
+		datasources.NewVMNICAttachChoicesDataSource,
+		datasources.NewSharingSMBPresetsDataSource,
+		datasources.NewVMDefinitionDataSource,
+		datasources.NewAppGPUChoicesDataSource,
 	}
 }
 
@@ -415,5 +693,48 @@ func (p *TrueNASProvider) Resources(ctx context.Context) []func() resource.Resou
 		resources.NewAppRegistryResource,
 		resources.NewVMResource,
 		resources.NewZvolResource,
+		resources.NewReportingExporterResource,
+		resources.NewSNMPConfigResource,
+		resources.NewFTPConfigResource,
+		resources.NewTrueCommandResource,
+		resources.NewTwoFactorConfigResource,
+		resources.NewUserTwoFactorResource,
+		resources.NewKMIPConfigResource,
+		resources.NewSelfEncryptingDriveResource,
+		resources.NewGroupMembershipResource,
+		resources.NewWebDAVConfigResource,
+		resources.NewWebDAVShareResource,
+		resources.NewExecResource,
+		resources.NewPoolResilverPriorityResource,
+		resources.NewDiskWipeResource,
+		resources.NewDatasetUserQuotaResource,
+		resources.NewDatasetGroupQuotaResource,
+		resources.NewJobWaiterResource,
+		resources.NewAuditConfigResource,
+		resources.NewSyslogConfigResource,
+		resources.NewIPMIResource,
+		resources.NewUpdateConfigResource,
+		resources.NewISCSIAuthResource,
+		resources.NewFCPortResource,
+		resources.NewCatalogResource,
+		resources.NewBootstrapAPIKeyResource,
+		resources.NewNFSConfigResource,
+		resources.NewVMMigrationResource,
+		resources.NewPrivilegeResource,
+		resources.NewReportingConfigResource,
+	}
+}
+
+func (p *TrueNASProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		actions.NewSnapshotRollbackAction,
+		actions.NewReplicationRunAction,
+		actions.NewApplyPendingUpdateAction,
+	}
+}
+
+func (p *TrueNASProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewVMDiskPathFunction,
 	}
 }
@@ -2,13 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/deevus/terraform-provider-truenas/internal/services"
 	truenas "github.com/deevus/truenas-go"
 	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -226,7 +232,7 @@ func TestProvider_Schema_WebSocketBlock(t *testing.T) {
 	}
 
 	// Check optional attributes
-	optionalAttrs := []string{"port", "insecure_skip_verify", "max_concurrent", "connect_timeout", "max_retries"}
+	optionalAttrs := []string{"port", "insecure_skip_verify", "max_concurrent", "connect_timeout", "max_retries", "heartbeat_interval", "cache_collections"}
 	for _, attr := range optionalAttrs {
 		a, ok := singleBlock.Attributes[attr]
 		if !ok {
@@ -267,6 +273,25 @@ func TestProvider_DataSources(t *testing.T) {
 		"truenas_snapshots",
 		"truenas_cloudsync_credentials",
 		"truenas_virt_config",
+		"truenas_app_status",
+		"truenas_enclosure",
+		"truenas_discovery",
+		"truenas_provider_health",
+		"truenas_job",
+		"truenas_audit",
+		"truenas_smb_shares",
+		"truenas_nfs_shares",
+		"truenas_host_facts",
+		"truenas_vm_status",
+		"truenas_app_available_versions",
+		"truenas_vm_cpu_models",
+		"truenas_vm_ovmf_firmwares",
+		"truenas_pool_status",
+		"truenas_vm_disk_choices",
+		"truenas_vm_nic_attach_choices",
+		"truenas_sharing_smb_presets",
+		"truenas_vm_definition",
+		"truenas_app_gpu_choices",
 	}
 	for _, name := range expected {
 		if !registered[name] {
@@ -310,6 +335,35 @@ func TestProvider_Resources(t *testing.T) {
 		"truenas_cron_job",
 		"truenas_virt_config",
 		"truenas_virt_instance",
+		"truenas_reporting_exporter",
+		"truenas_snmp_config",
+		"truenas_ftp_config",
+		"truenas_truecommand",
+		"truenas_twofactor_config",
+		"truenas_user_twofactor",
+		"truenas_kmip_config",
+		"truenas_self_encrypting_drive",
+		"truenas_group_membership",
+		"truenas_webdav_config",
+		"truenas_webdav_share",
+		"truenas_exec",
+		"truenas_pool_resilver_priority",
+		"truenas_disk_wipe",
+		"truenas_dataset_user_quota",
+		"truenas_dataset_group_quota",
+		"truenas_job_waiter",
+		"truenas_audit_config",
+		"truenas_syslog_config",
+		"truenas_ipmi",
+		"truenas_update_config",
+		"truenas_iscsi_auth",
+		"truenas_fc_port",
+		"truenas_catalog",
+		"truenas_bootstrap_api_key",
+		"truenas_nfs_config",
+		"truenas_vm_migration",
+		"truenas_privilege",
+		"truenas_reporting_config",
 	}
 	for _, name := range expected {
 		if !registered[name] {
@@ -318,6 +372,59 @@ func TestProvider_Resources(t *testing.T) {
 	}
 }
 
+func TestProvider_Actions(t *testing.T) {
+	p := &TrueNASProvider{version: "1.0.0"}
+
+	actions := p.Actions(context.Background())
+
+	// Collect all registered action type names
+	registered := make(map[string]bool)
+	for _, factory := range actions {
+		a := factory()
+		req := action.MetadataRequest{ProviderTypeName: "truenas"}
+		resp := &action.MetadataResponse{}
+		a.Metadata(context.Background(), req, resp)
+		registered[resp.TypeName] = true
+	}
+
+	// Verify expected actions are registered
+	expected := []string{
+		"truenas_snapshot_rollback",
+		"truenas_replication_run",
+		"truenas_apply_pending_update",
+	}
+	for _, name := range expected {
+		if !registered[name] {
+			t.Errorf("expected action %q to be registered", name)
+		}
+	}
+}
+
+func TestProvider_Functions(t *testing.T) {
+	p := &TrueNASProvider{version: "1.0.0"}
+
+	functions := p.Functions(context.Background())
+
+	// Collect all registered function names
+	registered := make(map[string]bool)
+	for _, factory := range functions {
+		f := factory()
+		resp := &function.MetadataResponse{}
+		f.Metadata(context.Background(), function.MetadataRequest{}, resp)
+		registered[resp.Name] = true
+	}
+
+	// Verify expected functions are registered
+	expected := []string{
+		"vm_disk_path",
+	}
+	for _, name := range expected {
+		if !registered[name] {
+			t.Errorf("expected function %q to be registered", name)
+		}
+	}
+}
+
 // Test ED25519 key for testing (same as in client tests)
 const testHostKeyFingerprint = "SHA256:uVW+XYZ0123456789ABCDEFghijklmnopqrstuv"
 
@@ -329,6 +436,25 @@ AAAEARU6QyekrrGEM7eyo5JKVU08PPAbbO19sp/dB3xMSpaq3CzzM2uY1YPH6fFvbrMDaJ
 NVOfhNlDIrXIA2bReJrCAAAAEnRlc3RAZXhhbXBsZS5sb2NhbAECAw==
 -----END OPENSSH PRIVATE KEY-----`
 
+// defaultTimeoutsObjectType is the type of the default_timeouts block in test configs.
+var defaultTimeoutsObjectType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"job":     tftypes.String,
+		"call":    tftypes.String,
+		"connect": tftypes.String,
+	},
+}
+
+// applyNotificationObjectType is the type of the apply_notification block in test configs.
+var applyNotificationObjectType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"enabled":  tftypes.Bool,
+		"to":       tftypes.List{ElementType: tftypes.String},
+		"subject":  tftypes.String,
+		"template": tftypes.String,
+	},
+}
+
 // createTestConfigureRequest creates a provider.ConfigureRequest with the given config values
 func createTestConfigureRequest(t *testing.T, host, authMethod string, ssh *SSHBlockModel) provider.ConfigureRequest {
 	t.Helper()
@@ -406,6 +532,8 @@ func createTestConfigureRequest(t *testing.T, host, authMethod string, ssh *SSHB
 			"max_concurrent":       tftypes.Number,
 			"connect_timeout":      tftypes.Number,
 			"max_retries":          tftypes.Number,
+			"heartbeat_interval":   tftypes.Number,
+			"cache_collections":    tftypes.Bool,
 		},
 	}
 	websocketValue := tftypes.NewValue(websocketObjectType, nil)
@@ -413,20 +541,38 @@ func createTestConfigureRequest(t *testing.T, host, authMethod string, ssh *SSHB
 	// Build config value
 	configValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"host":        tftypes.String,
-			"auth_method": tftypes.String,
-			"ssh":         sshObjectType,
-			"websocket":   websocketObjectType,
-			"rate_limit":  tftypes.Number,
-			"max_retries": tftypes.Number,
+			"host":                      tftypes.String,
+			"auth_method":               tftypes.String,
+			"ssh":                       sshObjectType,
+			"websocket":                 websocketObjectType,
+			"rate_limit":                tftypes.Number,
+			"max_retries":               tftypes.Number,
+			"wait_for_system_ready":     tftypes.Bool,
+			"system_ready_timeout":      tftypes.Number,
+			"eager_connect":             tftypes.Bool,
+			"read_only":                 tftypes.Bool,
+			"report_drift":              tftypes.Bool,
+			"maintenance_guard":         tftypes.Bool,
+			"maintenance_guard_timeout": tftypes.Number,
+			"default_timeouts":          defaultTimeoutsObjectType,
+			"apply_notification":        applyNotificationObjectType,
 		},
 	}, map[string]tftypes.Value{
-		"host":        tftypes.NewValue(tftypes.String, host),
-		"auth_method": tftypes.NewValue(tftypes.String, authMethod),
-		"ssh":         sshValue,
-		"websocket":   websocketValue,
-		"rate_limit":  tftypes.NewValue(tftypes.Number, nil),
-		"max_retries": tftypes.NewValue(tftypes.Number, nil),
+		"host":                      tftypes.NewValue(tftypes.String, host),
+		"auth_method":               tftypes.NewValue(tftypes.String, authMethod),
+		"ssh":                       sshValue,
+		"websocket":                 websocketValue,
+		"rate_limit":                tftypes.NewValue(tftypes.Number, nil),
+		"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+		"wait_for_system_ready":     tftypes.NewValue(tftypes.Bool, nil),
+		"system_ready_timeout":      tftypes.NewValue(tftypes.Number, nil),
+		"eager_connect":             tftypes.NewValue(tftypes.Bool, nil),
+		"read_only":                 tftypes.NewValue(tftypes.Bool, nil),
+		"report_drift":              tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard":         tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard_timeout": tftypes.NewValue(tftypes.Number, nil),
+		"default_timeouts":          tftypes.NewValue(defaultTimeoutsObjectType, nil),
+		"apply_notification":        tftypes.NewValue(applyNotificationObjectType, nil),
 	})
 
 	config, diags := tfsdk.Config{
@@ -521,6 +667,9 @@ func TestProvider_Configure_Success(t *testing.T) {
 	if resp.ResourceData == nil {
 		t.Error("expected ResourceData to be set")
 	}
+	if resp.ActionData == nil {
+		t.Error("expected ActionData to be set")
+	}
 }
 
 func TestProvider_Configure_WithCustomPortAndUser(t *testing.T) {
@@ -575,16 +724,27 @@ func TestProvider_Configure_ConfigParseError(t *testing.T) {
 			"max_concurrent":       tftypes.Number,
 			"connect_timeout":      tftypes.Number,
 			"max_retries":          tftypes.Number,
+			"heartbeat_interval":   tftypes.Number,
+			"cache_collections":    tftypes.Bool,
 		},
 	}
 	invalidConfigValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"host":        tftypes.Number, // Wrong type!
-			"auth_method": tftypes.String,
-			"ssh":         sshObjectType,
-			"websocket":   websocketObjectType,
-			"rate_limit":  tftypes.Number,
-			"max_retries": tftypes.Number,
+			"host":                      tftypes.Number, // Wrong type!
+			"auth_method":               tftypes.String,
+			"ssh":                       sshObjectType,
+			"websocket":                 websocketObjectType,
+			"rate_limit":                tftypes.Number,
+			"max_retries":               tftypes.Number,
+			"wait_for_system_ready":     tftypes.Bool,
+			"system_ready_timeout":      tftypes.Number,
+			"eager_connect":             tftypes.Bool,
+			"read_only":                 tftypes.Bool,
+			"report_drift":              tftypes.Bool,
+			"maintenance_guard":         tftypes.Bool,
+			"maintenance_guard_timeout": tftypes.Number,
+			"default_timeouts":          defaultTimeoutsObjectType,
+			"apply_notification":        applyNotificationObjectType,
 		},
 	}, map[string]tftypes.Value{
 		"host":        tftypes.NewValue(tftypes.Number, 123), // Wrong type!
@@ -596,9 +756,18 @@ func TestProvider_Configure_ConfigParseError(t *testing.T) {
 			"host_key_fingerprint": tftypes.NewValue(tftypes.String, testHostKeyFingerprint),
 			"max_sessions":         tftypes.NewValue(tftypes.Number, nil),
 		}),
-		"websocket":   tftypes.NewValue(websocketObjectType, nil),
-		"rate_limit":  tftypes.NewValue(tftypes.Number, nil),
-		"max_retries": tftypes.NewValue(tftypes.Number, nil),
+		"websocket":                 tftypes.NewValue(websocketObjectType, nil),
+		"rate_limit":                tftypes.NewValue(tftypes.Number, nil),
+		"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+		"wait_for_system_ready":     tftypes.NewValue(tftypes.Bool, nil),
+		"system_ready_timeout":      tftypes.NewValue(tftypes.Number, nil),
+		"eager_connect":             tftypes.NewValue(tftypes.Bool, nil),
+		"read_only":                 tftypes.NewValue(tftypes.Bool, nil),
+		"report_drift":              tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard":         tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard_timeout": tftypes.NewValue(tftypes.Number, nil),
+		"default_timeouts":          tftypes.NewValue(defaultTimeoutsObjectType, nil),
+		"apply_notification":        tftypes.NewValue(applyNotificationObjectType, nil),
 	})
 
 	config := tfsdk.Config{
@@ -647,16 +816,27 @@ func TestProvider_Configure_InvalidSSHClient(t *testing.T) {
 			"max_concurrent":       tftypes.Number,
 			"connect_timeout":      tftypes.Number,
 			"max_retries":          tftypes.Number,
+			"heartbeat_interval":   tftypes.Number,
+			"cache_collections":    tftypes.Bool,
 		},
 	}
 	configValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"host":        tftypes.String,
-			"auth_method": tftypes.String,
-			"ssh":         sshObjectType,
-			"websocket":   websocketObjectType,
-			"rate_limit":  tftypes.Number,
-			"max_retries": tftypes.Number,
+			"host":                      tftypes.String,
+			"auth_method":               tftypes.String,
+			"ssh":                       sshObjectType,
+			"websocket":                 websocketObjectType,
+			"rate_limit":                tftypes.Number,
+			"max_retries":               tftypes.Number,
+			"wait_for_system_ready":     tftypes.Bool,
+			"system_ready_timeout":      tftypes.Number,
+			"eager_connect":             tftypes.Bool,
+			"read_only":                 tftypes.Bool,
+			"report_drift":              tftypes.Bool,
+			"maintenance_guard":         tftypes.Bool,
+			"maintenance_guard_timeout": tftypes.Number,
+			"default_timeouts":          defaultTimeoutsObjectType,
+			"apply_notification":        applyNotificationObjectType,
 		},
 	}, map[string]tftypes.Value{
 		"host":        tftypes.NewValue(tftypes.String, "truenas.local"),
@@ -668,9 +848,18 @@ func TestProvider_Configure_InvalidSSHClient(t *testing.T) {
 			"host_key_fingerprint": tftypes.NewValue(tftypes.String, testHostKeyFingerprint),
 			"max_sessions":         tftypes.NewValue(tftypes.Number, nil),
 		}),
-		"websocket":   tftypes.NewValue(websocketObjectType, nil),
-		"rate_limit":  tftypes.NewValue(tftypes.Number, nil),
-		"max_retries": tftypes.NewValue(tftypes.Number, nil),
+		"websocket":                 tftypes.NewValue(websocketObjectType, nil),
+		"rate_limit":                tftypes.NewValue(tftypes.Number, nil),
+		"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+		"wait_for_system_ready":     tftypes.NewValue(tftypes.Bool, nil),
+		"system_ready_timeout":      tftypes.NewValue(tftypes.Number, nil),
+		"eager_connect":             tftypes.NewValue(tftypes.Bool, nil),
+		"read_only":                 tftypes.NewValue(tftypes.Bool, nil),
+		"report_drift":              tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard":         tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard_timeout": tftypes.NewValue(tftypes.Number, nil),
+		"default_timeouts":          tftypes.NewValue(defaultTimeoutsObjectType, nil),
+		"apply_notification":        tftypes.NewValue(applyNotificationObjectType, nil),
 	})
 
 	config := tfsdk.Config{
@@ -852,6 +1041,8 @@ func createTestConfigureRequestWithWebSocket(t *testing.T, host, authMethod stri
 			"max_concurrent":       tftypes.Number,
 			"connect_timeout":      tftypes.Number,
 			"max_retries":          tftypes.Number,
+			"heartbeat_interval":   tftypes.Number,
+			"cache_collections":    tftypes.Bool,
 		},
 	}
 	if ws == nil {
@@ -914,26 +1105,46 @@ func createTestConfigureRequestWithWebSocket(t *testing.T, host, authMethod stri
 			"max_concurrent":       maxConcurrentValue,
 			"connect_timeout":      connectTimeoutValue,
 			"max_retries":          maxRetriesValue,
+			"heartbeat_interval":   tftypes.NewValue(tftypes.Number, nil),
+			"cache_collections":    tftypes.NewValue(tftypes.Bool, nil),
 		})
 	}
 
 	// Build config value
 	configValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"host":        tftypes.String,
-			"auth_method": tftypes.String,
-			"ssh":         sshObjectType,
-			"websocket":   websocketObjectType,
-			"rate_limit":  tftypes.Number,
-			"max_retries": tftypes.Number,
+			"host":                      tftypes.String,
+			"auth_method":               tftypes.String,
+			"ssh":                       sshObjectType,
+			"websocket":                 websocketObjectType,
+			"rate_limit":                tftypes.Number,
+			"max_retries":               tftypes.Number,
+			"wait_for_system_ready":     tftypes.Bool,
+			"system_ready_timeout":      tftypes.Number,
+			"eager_connect":             tftypes.Bool,
+			"read_only":                 tftypes.Bool,
+			"report_drift":              tftypes.Bool,
+			"maintenance_guard":         tftypes.Bool,
+			"maintenance_guard_timeout": tftypes.Number,
+			"default_timeouts":          defaultTimeoutsObjectType,
+			"apply_notification":        applyNotificationObjectType,
 		},
 	}, map[string]tftypes.Value{
-		"host":        tftypes.NewValue(tftypes.String, host),
-		"auth_method": tftypes.NewValue(tftypes.String, authMethod),
-		"ssh":         sshValue,
-		"websocket":   websocketValue,
-		"rate_limit":  tftypes.NewValue(tftypes.Number, nil),
-		"max_retries": tftypes.NewValue(tftypes.Number, nil),
+		"host":                      tftypes.NewValue(tftypes.String, host),
+		"auth_method":               tftypes.NewValue(tftypes.String, authMethod),
+		"ssh":                       sshValue,
+		"websocket":                 websocketValue,
+		"rate_limit":                tftypes.NewValue(tftypes.Number, nil),
+		"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+		"wait_for_system_ready":     tftypes.NewValue(tftypes.Bool, nil),
+		"system_ready_timeout":      tftypes.NewValue(tftypes.Number, nil),
+		"eager_connect":             tftypes.NewValue(tftypes.Bool, nil),
+		"read_only":                 tftypes.NewValue(tftypes.Bool, nil),
+		"report_drift":              tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard":         tftypes.NewValue(tftypes.Bool, nil),
+		"maintenance_guard_timeout": tftypes.NewValue(tftypes.Number, nil),
+		"default_timeouts":          tftypes.NewValue(defaultTimeoutsObjectType, nil),
+		"apply_notification":        tftypes.NewValue(applyNotificationObjectType, nil),
 	})
 
 	config, diags := tfsdk.Config{
@@ -1255,7 +1466,7 @@ func TestProvider_Configure_WebSocketAuthMethod_OldVersionRejected(t *testing.T)
 		MaxRetries:         types.Int64Null(),
 	}
 
-	req := createTestConfigureRequestWithWebSocket(t, "truenas.local", "websocket", ssh, ws)
+	req := withEagerConnect(t, createTestConfigureRequestWithWebSocket(t, "truenas.local", "websocket", ssh, ws))
 	resp := &provider.ConfigureResponse{}
 
 	p.Configure(context.Background(), req, resp)
@@ -1297,7 +1508,7 @@ func TestProvider_Configure_SSHConnectError(t *testing.T) {
 		MaxSessions:        types.Int64Null(),
 	}
 
-	req := createTestConfigureRequest(t, "truenas.local", "ssh", ssh)
+	req := withEagerConnect(t, createTestConfigureRequest(t, "truenas.local", "ssh", ssh))
 	resp := &provider.ConfigureResponse{}
 
 	p.Configure(context.Background(), req, resp)
@@ -1319,6 +1530,167 @@ func TestProvider_Configure_SSHConnectError(t *testing.T) {
 	}
 }
 
+// withEagerConnect returns req with eager_connect enabled, so Configure
+// connects and reports connection errors itself.
+func withEagerConnect(t *testing.T, req provider.ConfigureRequest) provider.ConfigureRequest {
+	t.Helper()
+
+	raw, err := tftypes.Transform(req.Config.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(tftypes.NewAttributePath().WithAttributeName("eager_connect")) {
+			return tftypes.NewValue(tftypes.Bool, true), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error enabling eager connect: %v", err)
+	}
+
+	req.Config.Raw = raw
+	return req
+}
+
+// withDefaultCallTimeout returns req with default_timeouts.call set to call.
+func withDefaultCallTimeout(t *testing.T, req provider.ConfigureRequest, call string) provider.ConfigureRequest {
+	t.Helper()
+
+	raw, err := tftypes.Transform(req.Config.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(tftypes.NewAttributePath().WithAttributeName("default_timeouts")) {
+			return tftypes.NewValue(defaultTimeoutsObjectType, map[string]tftypes.Value{
+				"job":     tftypes.NewValue(tftypes.String, nil),
+				"call":    tftypes.NewValue(tftypes.String, call),
+				"connect": tftypes.NewValue(tftypes.String, nil),
+			}), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error setting default_timeouts: %v", err)
+	}
+
+	req.Config.Raw = raw
+	return req
+}
+
+// withSystemReadyWait returns req with wait_for_system_ready enabled and the
+// given system_ready_timeout in seconds.
+func withSystemReadyWait(t *testing.T, req provider.ConfigureRequest, timeout int64) provider.ConfigureRequest {
+	t.Helper()
+
+	raw, err := tftypes.Transform(req.Config.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		switch {
+		case p.Equal(tftypes.NewAttributePath().WithAttributeName("wait_for_system_ready")):
+			return tftypes.NewValue(tftypes.Bool, true), nil
+		case p.Equal(tftypes.NewAttributePath().WithAttributeName("system_ready_timeout")):
+			return tftypes.NewValue(tftypes.Number, timeout), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error enabling system ready wait: %v", err)
+	}
+
+	req.Config.Raw = raw
+	return req
+}
+
+func TestProvider_Configure_WaitForSystemReady(t *testing.T) {
+	connects := 0
+	readyChecks := 0
+	mock := &client.MockClient{
+		VersionVal: truenas.Version{Major: 24, Minor: 10},
+		ConnectFunc: func(ctx context.Context) error {
+			connects++
+			if connects < 2 {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			switch method {
+			case "system.ready":
+				readyChecks++
+				return json.RawMessage(fmt.Sprint(readyChecks >= 2)), nil
+			case "system.state":
+				return json.RawMessage(`"BOOTING"`), nil
+			}
+			t.Errorf("unexpected method %q", method)
+			return nil, nil
+		},
+	}
+
+	p := &TrueNASProvider{
+		version: "1.0.0",
+		factory: &mockClientFactory{sshClient: mock},
+	}
+
+	ssh := &SSHBlockModel{
+		Port:               types.Int64Null(),
+		User:               types.StringNull(),
+		PrivateKey:         types.StringValue(testPrivateKey),
+		HostKeyFingerprint: types.StringValue(testHostKeyFingerprint),
+		MaxSessions:        types.Int64Null(),
+	}
+
+	req := withEagerConnect(t, withSystemReadyWait(t, createTestConfigureRequest(t, "truenas.local", "ssh", ssh), 2))
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if connects != 2 {
+		t.Errorf("expected 2 connection attempts, got %d", connects)
+	}
+	if readyChecks != 2 {
+		t.Errorf("expected 2 readiness checks, got %d", readyChecks)
+	}
+	if resp.ResourceData == nil {
+		t.Error("expected ResourceData to be set")
+	}
+}
+
+func TestProvider_Configure_WaitForSystemReady_Timeout(t *testing.T) {
+	mock := &client.MockClient{
+		VersionVal:  truenas.Version{Major: 24, Minor: 10},
+		ConnectFunc: func(ctx context.Context) error { return nil },
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if method == "system.state" {
+				return json.RawMessage(`"BOOTING"`), nil
+			}
+			return json.RawMessage(`false`), nil
+		},
+	}
+
+	p := &TrueNASProvider{
+		version: "1.0.0",
+		factory: &mockClientFactory{sshClient: mock},
+	}
+
+	ssh := &SSHBlockModel{
+		Port:               types.Int64Null(),
+		User:               types.StringNull(),
+		PrivateKey:         types.StringValue(testPrivateKey),
+		HostKeyFingerprint: types.StringValue(testHostKeyFingerprint),
+		MaxSessions:        types.Int64Null(),
+	}
+
+	req := withEagerConnect(t, withSystemReadyWait(t, createTestConfigureRequest(t, "truenas.local", "ssh", ssh), 1))
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the system does not become ready")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "TrueNAS System Not Ready" {
+		t.Errorf("expected 'TrueNAS System Not Ready', got %q", summary)
+	}
+	if !containsString(resp.Diagnostics.Errors()[0].Detail(), "BOOTING") {
+		t.Errorf("expected detail to name the boot state, got %q", resp.Diagnostics.Errors()[0].Detail())
+	}
+}
+
 func TestProvider_Configure_WebSocketConnectError(t *testing.T) {
 	sshMock := newTestMockClient(truenas.Version{Major: 25, Minor: 0})
 	wsMock := &client.MockClient{
@@ -1353,7 +1725,7 @@ func TestProvider_Configure_WebSocketConnectError(t *testing.T) {
 		MaxRetries:         types.Int64Null(),
 	}
 
-	req := createTestConfigureRequestWithWebSocket(t, "truenas.local", "websocket", ssh, ws)
+	req := withEagerConnect(t, createTestConfigureRequestWithWebSocket(t, "truenas.local", "websocket", ssh, ws))
 	resp := &provider.ConfigureResponse{}
 
 	p.Configure(context.Background(), req, resp)
@@ -1406,7 +1778,7 @@ func TestProvider_Configure_WebSocketSSHConnectError(t *testing.T) {
 		MaxRetries:         types.Int64Null(),
 	}
 
-	req := createTestConfigureRequestWithWebSocket(t, "truenas.local", "websocket", ssh, ws)
+	req := withEagerConnect(t, createTestConfigureRequestWithWebSocket(t, "truenas.local", "websocket", ssh, ws))
 	resp := &provider.ConfigureResponse{}
 
 	p.Configure(context.Background(), req, resp)
@@ -1427,3 +1799,132 @@ func TestProvider_Configure_WebSocketSSHConnectError(t *testing.T) {
 		t.Error("expected error detail to contain 'ssh connection refused'")
 	}
 }
+
+func TestProvider_Configure_CallTimeoutExcludesLazyConnect(t *testing.T) {
+	mock := &client.MockClient{
+		ConnectFunc: func(ctx context.Context) error {
+			// Slower than default_timeouts.call
+			select {
+			case <-time.After(100 * time.Millisecond):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return json.RawMessage(`{}`), nil
+		},
+	}
+
+	p := &TrueNASProvider{
+		version: "1.0.0",
+		factory: &mockClientFactory{sshClient: mock},
+	}
+
+	ssh := &SSHBlockModel{
+		Port:               types.Int64Null(),
+		User:               types.StringNull(),
+		PrivateKey:         types.StringValue(testPrivateKey),
+		HostKeyFingerprint: types.StringValue(testHostKeyFingerprint),
+		MaxSessions:        types.Int64Null(),
+	}
+
+	req := withDefaultCallTimeout(t, createTestConfigureRequest(t, "truenas.local", "ssh", ssh), "20ms")
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	svc := resp.ResourceData.(*services.TrueNASServices)
+	if _, err := svc.Client.Call(context.Background(), "system.info", nil); err != nil {
+		t.Fatalf("expected the call timeout not to cover connecting, got %v", err)
+	}
+}
+
+func TestProvider_Configure_CallTimingExcludesHeartbeat(t *testing.T) {
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return json.RawMessage(`{}`), nil
+		},
+	}
+
+	p := &TrueNASProvider{
+		version: "1.0.0",
+		factory: &mockClientFactory{sshClient: mock},
+	}
+
+	ssh := &SSHBlockModel{
+		Port:               types.Int64Null(),
+		User:               types.StringNull(),
+		PrivateKey:         types.StringValue(testPrivateKey),
+		HostKeyFingerprint: types.StringValue(testHostKeyFingerprint),
+		MaxSessions:        types.Int64Null(),
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(context.Background(), createTestConfigureRequest(t, "truenas.local", "ssh", ssh), resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	svc := resp.ResourceData.(*services.TrueNASServices)
+	if _, err := svc.Health.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected ping error: %v", err)
+	}
+	if _, err := svc.Client.Call(context.Background(), "system.info", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := p.timing.methods["core.ping"]; ok {
+		t.Error("expected heartbeats not to be timed")
+	}
+	if m := p.timing.methods["system.info"]; m == nil || m.calls != 1 {
+		t.Errorf("expected system.info to be timed once, got %+v", m)
+	}
+}
+
+func TestProvider_Configure_LazyConnect(t *testing.T) {
+	connects := 0
+	mock := &client.MockClient{
+		ConnectFunc: func(ctx context.Context) error {
+			connects++
+			return errors.New("connection refused")
+		},
+	}
+
+	p := &TrueNASProvider{
+		version: "1.0.0",
+		factory: &mockClientFactory{sshClient: mock},
+	}
+
+	ssh := &SSHBlockModel{
+		Port:               types.Int64Null(),
+		User:               types.StringNull(),
+		PrivateKey:         types.StringValue(testPrivateKey),
+		HostKeyFingerprint: types.StringValue(testHostKeyFingerprint),
+		MaxSessions:        types.Int64Null(),
+	}
+
+	req := createTestConfigureRequest(t, "truenas.local", "ssh", ssh)
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected Configure not to connect, got: %v", resp.Diagnostics)
+	}
+	if connects != 0 {
+		t.Errorf("expected no connection attempts during Configure, got %d", connects)
+	}
+
+	svc := resp.ResourceData.(*services.TrueNASServices)
+	_, err := svc.Client.Call(context.Background(), "system.info", nil)
+	if err == nil || !containsString(err.Error(), "connection refused") {
+		t.Fatalf("expected the first call to report the connection error, got %v", err)
+	}
+	if connects != 1 {
+		t.Errorf("expected the first call to connect, got %d attempts", connects)
+	}
+}
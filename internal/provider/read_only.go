@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+)
+
+// readOnlyMethods are the final segments of API methods that only read
+// state. Any other method is assumed to modify the system.
+var readOnlyMethods = map[string]bool{
+	"query":                      true,
+	"config":                     true,
+	"get_instance":               true,
+	"ping":                       true,
+	"info":                       true,
+	"version":                    true,
+	"state":                      true,
+	"ready":                      true,
+	"capable":                    true,
+	"status":                     true,
+	"check_available":            true,
+	"provisioning_uri":           true,
+	"sed_global_password_is_set": true,
+	"has_pending_changes":        true,
+	"subscribe":                  true,
+	"unsubscribe":                true,
+}
+
+// readOnlyFullMethods are complete API method names that only read state
+// but whose final segment doesn't identify them as reads on its own.
+var readOnlyFullMethods = map[string]bool{
+	"filesystem.stat":            true,
+	"filesystem.listdir":         true,
+	"app.upgrade_summary":        true,
+	"app.used_ports":             true,
+	"app.available_space":        true,
+	"catalog.trains":             true,
+	"sharing.smb.presets":        true,
+	"network.general.summary":    true,
+	"interface.checkin_waiting":  true,
+	"virt.instance.device_list":  true,
+	"reporting.netdata_graphs":   true,
+	"reporting.netdata_get_data": true,
+}
+
+// isReadOnlyMethod reports whether method only reads state.
+func isReadOnlyMethod(method string) bool {
+	if readOnlyFullMethods[method] {
+		return true
+	}
+	name := method[strings.LastIndex(method, ".")+1:]
+	return readOnlyMethods[name] ||
+		strings.HasPrefix(name, "get_") ||
+		strings.HasSuffix(name, "_choices")
+}
+
+// readOnlyError reports an operation blocked by read_only.
+func readOnlyError(operation string) error {
+	return fmt.Errorf("%s is not allowed because the provider is configured with read_only = true", operation)
+}
+
+// readOnlyClient rejects API calls and file operations that would modify
+// the system, so plans can run with restricted credentials without any risk
+// of an apply changing the host. Reads pass through unchanged.
+type readOnlyClient struct {
+	client.Client
+}
+
+// withReadOnly wraps c so it only allows reads. If readOnly is false, c is
+// returned unchanged.
+func withReadOnly(c client.Client, readOnly bool) client.Client {
+	if !readOnly {
+		return c
+	}
+	return &readOnlyClient{Client: c}
+}
+
+func (c *readOnlyClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if !isReadOnlyMethod(method) {
+		return nil, readOnlyError(fmt.Sprintf("API method %q", method))
+	}
+	return c.Client.Call(ctx, method, params)
+}
+
+func (c *readOnlyClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if !isReadOnlyMethod(method) {
+		return nil, readOnlyError(fmt.Sprintf("API method %q", method))
+	}
+	return c.Client.CallAndWait(ctx, method, params)
+}
+
+func (c *readOnlyClient) WriteFile(ctx context.Context, path string, params truenas.WriteFileParams) error {
+	return readOnlyError(fmt.Sprintf("writing %s", path))
+}
+
+func (c *readOnlyClient) DeleteFile(ctx context.Context, path string) error {
+	return readOnlyError(fmt.Sprintf("deleting %s", path))
+}
+
+func (c *readOnlyClient) RemoveDir(ctx context.Context, path string) error {
+	return readOnlyError(fmt.Sprintf("removing %s", path))
+}
+
+func (c *readOnlyClient) RemoveAll(ctx context.Context, path string) error {
+	return readOnlyError(fmt.Sprintf("removing %s", path))
+}
+
+func (c *readOnlyClient) Chown(ctx context.Context, path string, uid, gid int) error {
+	return readOnlyError(fmt.Sprintf("changing ownership of %s", path))
+}
+
+func (c *readOnlyClient) ChmodRecursive(ctx context.Context, path string, mode fs.FileMode) error {
+	return readOnlyError(fmt.Sprintf("changing permissions of %s", path))
+}
+
+func (c *readOnlyClient) MkdirAll(ctx context.Context, path string, mode fs.FileMode) error {
+	return readOnlyError(fmt.Sprintf("creating %s", path))
+}
+
+// readOnlyRunner rejects every command; arbitrary shell commands cannot be
+// classified as reads.
+type readOnlyRunner struct{}
+
+func (readOnlyRunner) Run(ctx context.Context, command string) (*services.CommandResult, error) {
+	return nil, readOnlyError("running commands over SSH")
+}
+
+// withReadOnlyRunner returns a runner that rejects all commands when
+// readOnly is set, and r otherwise.
+func withReadOnlyRunner(r services.CommandRunner, readOnly bool) services.CommandRunner {
+	if !readOnly {
+		return r
+	}
+	return readOnlyRunner{}
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected bool
+	}{
+		{"pool.dataset.query", true},
+		{"system.advanced.config", true},
+		{"reporting.exporters.get_instance", true},
+		{"core.get_jobs", true},
+		{"core.ping", true},
+		{"system.info", true},
+		{"fcport.port_choices", true},
+		{"update.check_available", true},
+		{"filesystem.stat", true},
+		{"filesystem.listdir", true},
+		{"app.upgrade_summary", true},
+		{"app.used_ports", true},
+		{"app.available_space", true},
+		{"catalog.trains", true},
+		{"sharing.smb.presets", true},
+		{"network.general.summary", true},
+		{"interface.checkin_waiting", true},
+		{"virt.instance.device_list", true},
+		{"reporting.netdata_graphs", true},
+		{"vm.get_available_memory", true},
+		{"vm.device.disk_choices", true},
+		{"filesystem.setperm", false},
+		{"filesystem.mkdir", false},
+		{"app.upgrade", false},
+		{"catalog.sync", false},
+		{"pool.dataset.create", false},
+		{"vm.update", false},
+		{"vm.start", false},
+		{"disk.wipe", false},
+		{"update.set_train", false},
+		{"pool.snapshot.rollback", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := isReadOnlyMethod(tt.method); got != tt.expected {
+				t.Errorf("isReadOnlyMethod(%q) = %v, want %v", tt.method, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithReadOnly_Disabled(t *testing.T) {
+	mock := &client.MockClient{}
+
+	if c := withReadOnly(mock, false); c != mock {
+		t.Errorf("expected client to be returned unwrapped, got %T", c)
+	}
+}
+
+func TestWithReadOnly_BlocksWrites(t *testing.T) {
+	var methods []string
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			methods = append(methods, method)
+			return json.RawMessage(`[]`), nil
+		},
+		CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			methods = append(methods, method)
+			return json.RawMessage(`null`), nil
+		},
+		WriteFileFunc: func(ctx context.Context, path string, params truenas.WriteFileParams) error {
+			t.Error("expected WriteFile to be blocked")
+			return nil
+		},
+	}
+
+	c := withReadOnly(mock, true)
+
+	if _, err := c.Call(context.Background(), "vm.query", nil); err != nil {
+		t.Fatalf("unexpected error for read: %v", err)
+	}
+
+	_, err := c.Call(context.Background(), "vm.update", nil)
+	if err == nil || !strings.Contains(err.Error(), "read_only = true") {
+		t.Errorf("expected read-only error, got %v", err)
+	}
+
+	if _, err := c.CallAndWait(context.Background(), "app.create", nil); err == nil {
+		t.Error("expected read-only error for job")
+	}
+
+	if err := c.WriteFile(context.Background(), "/mnt/tank/file", truenas.WriteFileParams{}); err == nil {
+		t.Error("expected read-only error for WriteFile")
+	}
+
+	if len(methods) != 1 || methods[0] != "vm.query" {
+		t.Errorf("expected only vm.query to reach the client, got %v", methods)
+	}
+}
+
+func TestWithReadOnly_AllowsStat(t *testing.T) {
+	// Host path and dataset permission reads stat the path on refresh; a
+	// blocked stat would be treated as "not found" and drop the resource.
+	var called bool
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			called = method == "filesystem.stat"
+			return json.RawMessage(`{"mode": 16877, "uid": 0, "gid": 0}`), nil
+		},
+	}
+
+	c := withReadOnly(mock, true)
+
+	if _, err := c.Call(context.Background(), "filesystem.stat", "/mnt/tank/data"); err != nil {
+		t.Fatalf("unexpected error for filesystem.stat: %v", err)
+	}
+	if !called {
+		t.Error("expected filesystem.stat to reach the client")
+	}
+}
+
+func TestWithReadOnlyRunner(t *testing.T) {
+	runner := &services.MockCommandRunner{}
+
+	if r := withReadOnlyRunner(runner, false); r != runner {
+		t.Errorf("expected runner to be returned unwrapped, got %T", r)
+	}
+
+	_, err := withReadOnlyRunner(runner, true).Run(context.Background(), "zpool status")
+	if err == nil || !strings.Contains(err.Error(), "read_only = true") {
+		t.Errorf("expected read-only error, got %v", err)
+	}
+}
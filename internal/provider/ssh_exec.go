@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshCommandRunner implements services.CommandRunner with a dedicated SSH
+// connection per command, authenticated and host-key-verified the same way
+// as the SSH transport.
+type sshCommandRunner struct {
+	config *client.SSHConfig
+
+	// connectTimeout bounds dialing and the SSH handshake; zero means no limit.
+	connectTimeout time.Duration
+}
+
+var _ services.CommandRunner = (*sshCommandRunner)(nil)
+
+func newSSHCommandRunner(config *client.SSHConfig, connectTimeout time.Duration) *sshCommandRunner {
+	return &sshCommandRunner{config: config, connectTimeout: connectTimeout}
+}
+
+func (r *sshCommandRunner) Run(ctx context.Context, command string) (*services.CommandResult, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(r.config.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	port := r.config.Port
+	if port == 0 {
+		port = 22
+	}
+	user := r.config.User
+	if user == "" {
+		user = "root"
+	}
+
+	expected := r.config.HostKeyFingerprint
+	sshConfig := &ssh.ClientConfig{
+		User: user,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if got := ssh.FingerprintSHA256(key); got != expected {
+				return fmt.Errorf("host key mismatch for %s: expected %s, got %s", hostname, expected, got)
+			}
+			return nil
+		},
+	}
+
+	addr := net.JoinHostPort(r.config.Host, fmt.Sprint(port))
+	d := net.Dialer{Timeout: r.connectTimeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", addr, err)
+	}
+
+	if r.connectTimeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(r.connectTimeout))
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, sshConfig)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("ssh handshake with %s: %w", addr, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	defer func() { _ = sshClient.Close() }()
+
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("open ssh session: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(command) }()
+
+	select {
+	case <-ctx.Done():
+		// Closing the connection unblocks session.Run
+		_ = sshClient.Close()
+		<-done
+		return nil, ctx.Err()
+	case err = <-done:
+	}
+
+	result := &services.CommandResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+
+	var exitErr *ssh.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	default:
+		return nil, fmt.Errorf("run command: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"golang.org/x/crypto/ssh"
+)
+
+// startTestSSHServer starts an SSH server on localhost that answers exec
+// requests by echoing the command to stdout and exiting with the status
+// given by a trailing "exit N" (0 otherwise). It returns the address, the
+// host key fingerprint and a PEM private key accepted by the server.
+func startTestSSHServer(t *testing.T) (string, string, string) {
+	t.Helper()
+
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userPub, userPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(userPriv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorized, err := ssh.NewPublicKey(userPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) == string(authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+
+	return ln.Addr().String(), ssh.FingerprintSHA256(hostSigner.PublicKey()), string(pem.EncodeToMemory(block))
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChan := range chans {
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer func() { _ = ch.Close() }()
+			for req := range requests {
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				_ = req.Reply(true, nil)
+
+				cmd := string(req.Payload[4:])
+				_, _ = ch.Write([]byte(cmd))
+				_, _ = ch.Stderr().Write([]byte("stderr output"))
+
+				var status uint32
+				if i := strings.LastIndex(cmd, "exit "); i >= 0 {
+					n, _ := strconv.Atoi(strings.TrimSpace(cmd[i+5:]))
+					status = uint32(n)
+				}
+				payload := make([]byte, 4)
+				binary.BigEndian.PutUint32(payload, status)
+				_, _ = ch.SendRequest("exit-status", false, payload)
+				return
+			}
+		}()
+	}
+}
+
+func testSSHConfig(t *testing.T, addr, fingerprint, key string) *client.SSHConfig {
+	t.Helper()
+
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	return &client.SSHConfig{
+		Host:               host,
+		Port:               port,
+		User:               "terraform",
+		PrivateKey:         key,
+		HostKeyFingerprint: fingerprint,
+	}
+}
+
+func TestSSHCommandRunner_Run_Success(t *testing.T) {
+	addr, fingerprint, key := startTestSSHServer(t)
+	r := newSSHCommandRunner(testSSHConfig(t, addr, fingerprint, key), 0)
+
+	result, err := r.Run(context.Background(), "echo hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d", result.ExitCode)
+	}
+	if result.Stdout != "echo hello" {
+		t.Errorf("expected stdout 'echo hello', got %q", result.Stdout)
+	}
+	if result.Stderr != "stderr output" {
+		t.Errorf("expected stderr 'stderr output', got %q", result.Stderr)
+	}
+}
+
+func TestSSHCommandRunner_Run_NonZeroExit(t *testing.T) {
+	addr, fingerprint, key := startTestSSHServer(t)
+	r := newSSHCommandRunner(testSSHConfig(t, addr, fingerprint, key), 0)
+
+	result, err := r.Run(context.Background(), "false; exit 3")
+	if err != nil {
+		t.Fatalf("expected non-zero exit to be reported in the result, got error: %v", err)
+	}
+
+	if result.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %d", result.ExitCode)
+	}
+}
+
+func TestSSHCommandRunner_Run_HostKeyMismatch(t *testing.T) {
+	addr, _, key := startTestSSHServer(t)
+	r := newSSHCommandRunner(testSSHConfig(t, addr, "SHA256:wrong", key), 0)
+
+	_, err := r.Run(context.Background(), "echo hello")
+	if err == nil {
+		t.Fatal("expected error for host key mismatch")
+	}
+	if !strings.Contains(err.Error(), "host key mismatch") {
+		t.Errorf("expected host key mismatch error, got %q", err.Error())
+	}
+}
+
+func TestSSHCommandRunner_Run_InvalidPrivateKey(t *testing.T) {
+	r := newSSHCommandRunner(&client.SSHConfig{
+		Host:               "127.0.0.1",
+		PrivateKey:         "not a key",
+		HostKeyFingerprint: "SHA256:abc",
+	}, 0)
+
+	_, err := r.Run(context.Background(), "echo hello")
+	if err == nil {
+		t.Fatal("expected error for invalid private key")
+	}
+}
+
+func TestSSHCommandRunner_Run_ConnectionRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	_, _, key := startTestSSHServer(t)
+	r := newSSHCommandRunner(testSSHConfig(t, addr, "SHA256:abc", key), 0)
+
+	_, err = r.Run(context.Background(), "echo hello")
+	if err == nil {
+		t.Fatal("expected connection error")
+	}
+}
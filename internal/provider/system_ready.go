@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSystemReadyTimeout is how long Configure waits for the system when
+// wait_for_system_ready is set and system_ready_timeout is not.
+const defaultSystemReadyTimeout = 10 * time.Minute
+
+// systemReadyPollInterval is the delay between attempts while waiting for the
+// system. Shorter timeouts poll proportionally more often.
+const systemReadyPollInterval = 5 * time.Second
+
+// systemWaiter retries connecting to TrueNAS and checking that it has finished
+// booting until the timeout expires. A nil waiter tries everything once, which
+// is the behavior without wait_for_system_ready.
+type systemWaiter struct {
+	timeout  time.Duration
+	interval time.Duration
+	deadline time.Time
+}
+
+// newSystemWaiter returns a waiter whose timeout starts now, so connecting and
+// the readiness check share it.
+func newSystemWaiter(timeout time.Duration) *systemWaiter {
+	interval := systemReadyPollInterval
+	if timeout < interval {
+		interval = timeout / 10
+	}
+
+	return &systemWaiter{
+		timeout:  timeout,
+		interval: interval,
+		deadline: time.Now().Add(timeout),
+	}
+}
+
+// retry calls fn until it succeeds or the timeout expires, returning the last error.
+func (w *systemWaiter) retry(ctx context.Context, fn func(context.Context) error) error {
+	for {
+		err := fn(ctx)
+		if err == nil || w == nil {
+			return err
+		}
+		if time.Now().Add(w.interval).After(w.deadline) {
+			return fmt.Errorf("system not ready after %v: %w", w.timeout, err)
+		}
+
+		tflog.Info(ctx, "Waiting for TrueNAS system to become ready", map[string]any{
+			"error": err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(w.interval):
+		}
+	}
+}
+
+// waitReady polls system.ready until the middleware reports the system has
+// finished booting, or the timeout expires.
+func (w *systemWaiter) waitReady(ctx context.Context, c client.Client) error {
+	return w.retry(ctx, func(ctx context.Context) error {
+		return checkSystemReady(ctx, c)
+	})
+}
+
+// checkSystemReady returns an error naming the boot state (system.state) if
+// the system is not ready yet.
+func checkSystemReady(ctx context.Context, c client.Client) error {
+	result, err := c.Call(ctx, "system.ready", nil)
+	if err != nil {
+		return err
+	}
+
+	var ready bool
+	if err := json.Unmarshal(result, &ready); err != nil {
+		return fmt.Errorf("parse system.ready response: %w", err)
+	}
+	if ready {
+		return nil
+	}
+
+	state := "unknown"
+	if result, err := c.Call(ctx, "system.state", nil); err == nil {
+		_ = json.Unmarshal(result, &state)
+	}
+	return fmt.Errorf("system is not ready (state: %s)", state)
+}
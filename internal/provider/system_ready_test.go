@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestSystemWaiter_NilTriesOnce(t *testing.T) {
+	var w *systemWaiter
+	calls := 0
+
+	err := w.retry(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	})
+
+	if err == nil || err.Error() != "connection refused" {
+		t.Errorf("expected the original error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestSystemWaiter_RetriesUntilSuccess(t *testing.T) {
+	w := newSystemWaiter(time.Second)
+	calls := 0
+
+	err := w.retry(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestSystemWaiter_Timeout(t *testing.T) {
+	w := newSystemWaiter(200 * time.Millisecond)
+
+	err := w.retry(context.Background(), func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	if err == nil {
+		t.Fatal("expected error after timeout")
+	}
+	if !strings.Contains(err.Error(), "system not ready after") || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected timeout error wrapping the last error, got %v", err)
+	}
+}
+
+func TestSystemWaiter_ContextCanceled(t *testing.T) {
+	w := newSystemWaiter(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := w.retry(ctx, func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCheckSystemReady_Ready(t *testing.T) {
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if method != "system.ready" {
+				t.Errorf("expected method 'system.ready', got %q", method)
+			}
+			return json.RawMessage(`true`), nil
+		},
+	}
+
+	if err := checkSystemReady(context.Background(), mock); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckSystemReady_Booting(t *testing.T) {
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if method == "system.state" {
+				return json.RawMessage(`"BOOTING"`), nil
+			}
+			return json.RawMessage(`false`), nil
+		},
+	}
+
+	err := checkSystemReady(context.Background(), mock)
+
+	if err == nil || !strings.Contains(err.Error(), "BOOTING") {
+		t.Errorf("expected error naming the BOOTING state, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultTimeoutsBlockModel describes the default_timeouts block.
+type DefaultTimeoutsBlockModel struct {
+	Job     types.String `tfsdk:"job"`
+	Call    types.String `tfsdk:"call"`
+	Connect types.String `tfsdk:"connect"`
+}
+
+// defaultTimeouts holds the parsed default_timeouts block. A zero duration
+// leaves that category without a provider-wide deadline.
+type defaultTimeouts struct {
+	job     time.Duration
+	call    time.Duration
+	connect time.Duration
+}
+
+// parseDefaultTimeouts parses the duration strings of the default_timeouts
+// block, which may be nil.
+func parseDefaultTimeouts(block *DefaultTimeoutsBlockModel) (defaultTimeouts, diag.Diagnostics) {
+	var timeouts defaultTimeouts
+	var diags diag.Diagnostics
+
+	if block == nil {
+		return timeouts, diags
+	}
+
+	for _, f := range []struct {
+		name  string
+		value types.String
+		dest  *time.Duration
+	}{
+		{"job", block.Job, &timeouts.job},
+		{"call", block.Call, &timeouts.call},
+		{"connect", block.Connect, &timeouts.connect},
+	} {
+		if f.value.IsNull() || f.value.IsUnknown() {
+			continue
+		}
+		d, err := time.ParseDuration(f.value.ValueString())
+		if err == nil && d <= 0 {
+			err = errors.New("must be greater than zero")
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("default_timeouts").AtName(f.name),
+				"Invalid Default Timeout",
+				fmt.Sprintf("default_timeouts.%s must be a duration such as \"30s\", \"2m\" or \"1h30m\": %s", f.name, err.Error()),
+			)
+			continue
+		}
+		*f.dest = d
+	}
+
+	return timeouts, diags
+}
+
+// timeoutClient bounds every API call with the default_timeouts deadlines:
+// call for plain calls and job for calls that wait on a job. A deadline
+// already set on the context by the caller still applies if it is earlier.
+type timeoutClient struct {
+	client.Client
+	call time.Duration
+	job  time.Duration
+}
+
+// withTimeouts wraps c so its calls honour timeouts. If neither the call nor
+// the job timeout is set, c is returned unchanged.
+func withTimeouts(c client.Client, timeouts defaultTimeouts) client.Client {
+	if timeouts.call == 0 && timeouts.job == 0 {
+		return c
+	}
+	return &timeoutClient{Client: c, call: timeouts.call, job: timeouts.job}
+}
+
+func (c *timeoutClient) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	callCtx, cancel := withOptionalTimeout(ctx, c.call)
+	defer cancel()
+
+	result, err := c.Client.Call(callCtx, method, params)
+	return result, timeoutError(ctx, callCtx, err, method, "call", c.call)
+}
+
+func (c *timeoutClient) CallAndWait(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	callCtx, cancel := withOptionalTimeout(ctx, c.job)
+	defer cancel()
+
+	result, err := c.Client.CallAndWait(callCtx, method, params)
+	return result, timeoutError(ctx, callCtx, err, method, "job", c.job)
+}
+
+// withOptionalTimeout returns ctx bounded by d, or ctx unchanged if d is zero.
+func withOptionalTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// timeoutError names the default_timeouts setting in err when the call was
+// cut short by that setting rather than by the caller's own context.
+func timeoutError(parent, callCtx context.Context, err error, method, category string, d time.Duration) error {
+	if err == nil || parent.Err() != nil || !errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%s did not finish within default_timeouts.%s (%s): %w", method, category, d, err)
+}
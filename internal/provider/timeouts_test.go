@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseDefaultTimeouts(t *testing.T) {
+	timeouts, diags := parseDefaultTimeouts(&DefaultTimeoutsBlockModel{
+		Job:     types.StringValue("30m"),
+		Call:    types.StringValue("2m"),
+		Connect: types.StringNull(),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if timeouts.job != 30*time.Minute || timeouts.call != 2*time.Minute || timeouts.connect != 0 {
+		t.Errorf("unexpected timeouts: %+v", timeouts)
+	}
+}
+
+func TestParseDefaultTimeouts_NilBlock(t *testing.T) {
+	timeouts, diags := parseDefaultTimeouts(nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if timeouts != (defaultTimeouts{}) {
+		t.Errorf("expected no timeouts, got %+v", timeouts)
+	}
+}
+
+func TestParseDefaultTimeouts_Invalid(t *testing.T) {
+	for _, value := range []string{"30", "soon", "0s", "-1m"} {
+		_, diags := parseDefaultTimeouts(&DefaultTimeoutsBlockModel{
+			Job:     types.StringNull(),
+			Call:    types.StringValue(value),
+			Connect: types.StringNull(),
+		})
+		if !diags.HasError() {
+			t.Errorf("expected error for call = %q", value)
+			continue
+		}
+		if !strings.Contains(diags.Errors()[0].Detail(), "default_timeouts.call") {
+			t.Errorf("expected detail to name the attribute, got %q", diags.Errors()[0].Detail())
+		}
+	}
+}
+
+func TestWithTimeouts_Unset(t *testing.T) {
+	mock := &client.MockClient{}
+
+	if c := withTimeouts(mock, defaultTimeouts{connect: time.Second}); c != mock {
+		t.Errorf("expected client to be returned unwrapped, got %T", c)
+	}
+}
+
+// blockingClient returns a mock whose calls wait until their context ends.
+func blockingClient() *client.MockClient {
+	wait := func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &client.MockClient{CallFunc: wait, CallAndWaitFunc: wait}
+}
+
+func TestWithTimeouts_CallDeadline(t *testing.T) {
+	c := withTimeouts(blockingClient(), defaultTimeouts{call: 10 * time.Millisecond})
+
+	_, err := c.Call(context.Background(), "pool.query", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "default_timeouts.call") {
+		t.Errorf("expected error to name default_timeouts.call, got %q", err.Error())
+	}
+
+	// Job calls are not bounded by the call timeout
+	var deadlineSet bool
+	c = withTimeouts(&client.MockClient{
+		CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			_, deadlineSet = ctx.Deadline()
+			return nil, nil
+		},
+	}, defaultTimeouts{call: time.Minute})
+	if _, err := c.CallAndWait(context.Background(), "app.create", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deadlineSet {
+		t.Error("expected no deadline on job calls")
+	}
+}
+
+func TestWithTimeouts_JobDeadline(t *testing.T) {
+	c := withTimeouts(blockingClient(), defaultTimeouts{job: 10 * time.Millisecond})
+
+	_, err := c.CallAndWait(context.Background(), "app.create", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "default_timeouts.job") {
+		t.Errorf("expected error to name default_timeouts.job, got %q", err.Error())
+	}
+}
+
+func TestWithTimeouts_CallerDeadlineKept(t *testing.T) {
+	c := withTimeouts(blockingClient(), defaultTimeouts{call: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Call(ctx, "pool.query", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the caller's deadline to apply, got %v", err)
+	}
+	if strings.Contains(err.Error(), "default_timeouts") {
+		t.Errorf("expected the caller's own timeout not to be attributed to default_timeouts, got %q", err.Error())
+	}
+}
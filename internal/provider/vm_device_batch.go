@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// vmDeviceBatchWindow is how long a device lookup waits for the lookups of
+// the other VMs Terraform is refreshing alongside it.
+const vmDeviceBatchWindow = 20 * time.Millisecond
+
+// vmDeviceBatch joins the per-VM vm.device.query lookups made while
+// refreshing into one query filtered by the VMs that asked, so refreshing
+// many truenas_vm resources costs one device query per batch rather than one
+// per VM. The query is sent after every lookup in the batch was made, so each
+// gets data at least as fresh as its own query would have. Lookups made while
+// applying go straight to the server.
+type vmDeviceBatch struct {
+	client.Client
+	window time.Duration
+
+	mu      sync.Mutex
+	pending *deviceBatch
+}
+
+// deviceBatch is one vm.device.query shared by the lookups that joined it.
+type deviceBatch struct {
+	vmIDs   []any
+	done    chan struct{}
+	devices []map[string]any
+	err     error
+}
+
+// withVMDeviceBatch wraps c so device lookups by VM are batched.
+func withVMDeviceBatch(c client.Client) client.Client {
+	return &vmDeviceBatch{Client: c, window: vmDeviceBatchWindow}
+}
+
+func (c *vmDeviceBatch) Call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	if method != "vm.device.query" || isApplying(ctx) {
+		return c.Client.Call(ctx, method, params)
+	}
+	vmID, ok := deviceLookupVM(params)
+	if !ok {
+		return c.Client.Call(ctx, method, params)
+	}
+
+	b := c.join(ctx, vmID)
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	devices := []map[string]any{}
+	for _, device := range b.devices {
+		if reflect.DeepEqual(device["vm"], vmID) {
+			devices = append(devices, device)
+		}
+	}
+	return json.Marshal(devices)
+}
+
+// join adds vmID to the pending batch, starting one if none is pending.
+func (c *vmDeviceBatch) join(ctx context.Context, vmID any) *deviceBatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b := c.pending
+	if b == nil {
+		b = &deviceBatch{done: make(chan struct{})}
+		c.pending = b
+		// The batch outlives the lookup that started it
+		runCtx := context.WithoutCancel(ctx)
+		time.AfterFunc(c.window, func() { c.run(runCtx, b) })
+	}
+	for _, id := range b.vmIDs {
+		if reflect.DeepEqual(id, vmID) {
+			return b
+		}
+	}
+	b.vmIDs = append(b.vmIDs, vmID)
+	return b
+}
+
+// run sends the query of batch b and wakes up its lookups.
+func (c *vmDeviceBatch) run(ctx context.Context, b *deviceBatch) {
+	c.mu.Lock()
+	if c.pending == b {
+		c.pending = nil
+	}
+	c.mu.Unlock()
+	defer close(b.done)
+
+	filter := []any{[]any{[]any{"vm", "in", b.vmIDs}}}
+	result, err := c.Client.Call(ctx, "vm.device.query", filter)
+	if err != nil {
+		b.err = err
+		return
+	}
+	if err := json.Unmarshal(result, &b.devices); err != nil {
+		b.err = fmt.Errorf("parse device query response: %w", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Batched VM device lookups", map[string]any{
+		"vms":     len(b.vmIDs),
+		"devices": len(b.devices),
+	})
+}
+
+// deviceLookupVM returns the VM ID of a lookup of all devices of one VM, the
+// query the VM service's ListDevices makes. It reports false for any other
+// query, which is not batched.
+func deviceLookupVM(params any) (any, bool) {
+	args, ok := callArgs(params)
+	if !ok || len(args) != 1 {
+		return nil, false
+	}
+	filters, ok := args[0].([]any)
+	if !ok || len(filters) != 1 {
+		return nil, false
+	}
+	filter, ok := filters[0].([]any)
+	if !ok || len(filter) != 3 || filter[0] != "vm" || filter[1] != "=" {
+		return nil, false
+	}
+	if _, ok := filter[2].(float64); !ok {
+		return nil, false
+	}
+	return filter[2], true
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestVMDeviceBatch_JoinsLookups(t *testing.T) {
+	var mu sync.Mutex
+	var queries []any
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			mu.Lock()
+			queries = append(queries, params)
+			mu.Unlock()
+			return json.RawMessage(`[
+				{"id": 10, "vm": 1, "attributes": {"dtype": "DISK"}},
+				{"id": 11, "vm": 2, "attributes": {"dtype": "NIC"}},
+				{"id": 12, "vm": 1, "attributes": {"dtype": "NIC"}}
+			]`), nil
+		},
+	}
+	// Long enough for every lookup to join before the query is sent
+	c := &vmDeviceBatch{Client: mock, window: 200 * time.Millisecond}
+
+	results := make([][]map[string]any, 4)
+	var wg sync.WaitGroup
+	for i, vmID := range []int64{1, 2, 3, 1} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := c.Call(context.Background(), "vm.device.query", []any{[]any{[]any{"vm", "=", vmID}}})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if err := json.Unmarshal(result, &results[i]); err != nil {
+				t.Errorf("unexpected result %s: %v", result, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(queries) != 1 {
+		t.Fatalf("expected one batched query, got %v", queries)
+	}
+	filter := queries[0].([]any)[0].([]any)[0].([]any)
+	ids := filter[2].([]any)
+	if filter[0] != "vm" || filter[1] != "in" || len(ids) != 3 {
+		t.Errorf("expected a vm in filter over the three VMs, got %v", filter)
+	}
+
+	for i, want := range [][]float64{{10, 12}, {11}, {}, {10, 12}} {
+		var got []float64
+		for _, device := range results[i] {
+			got = append(got, device["id"].(float64))
+		}
+		if len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Errorf("lookup %d: expected devices %v, got %v", i, want, got)
+		}
+	}
+}
+
+func TestVMDeviceBatch_Passthrough(t *testing.T) {
+	var got []any
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			got = append(got, params)
+			return json.RawMessage(`[]`), nil
+		},
+	}
+	c := withVMDeviceBatch(mock)
+
+	// A lookup by device ID, and a lookup by VM made while applying
+	byID := []any{[]any{[]any{"id", "=", int64(10)}}}
+	byVM := []any{[]any{[]any{"vm", "=", int64(1)}}}
+	if _, err := c.Call(context.Background(), "vm.device.query", byID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Call(withApplying(context.Background()), "vm.device.query", byVM); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || !reflect.DeepEqual(got[0], byID) || !reflect.DeepEqual(got[1], byVM) {
+		t.Errorf("expected both queries to reach the server unchanged, got %v", got)
+	}
+}
+
+func TestVMDeviceBatch_Error(t *testing.T) {
+	mock := &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return nil, errors.New("connection reset")
+		},
+	}
+	c := withVMDeviceBatch(mock)
+
+	_, err := c.Call(context.Background(), "vm.device.query", []any{[]any{[]any{"vm", "=", int64(1)}}})
+	if err == nil || err.Error() != "connection reset" {
+		t.Errorf("expected the query error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// apiFieldError is a single field error from a middleware validation (EINVAL) error.
+type apiFieldError struct {
+	// Field is the offending request field with the method's schema prefix
+	// removed, e.g. "memory" for "vm_create.memory".
+	Field   string
+	Message string
+}
+
+// apiFieldErrors extracts the field errors of a middleware validation error.
+// Direct calls report them in the JSON-RPC error's extra data as
+// [field, message, errno] entries; for failed jobs the client only keeps the
+// first field, parsed from the message. Returns nil for other errors.
+func apiFieldErrors(err error) []apiFieldError {
+	var rpcErr *client.JSONRPCError
+	if errors.As(err, &rpcErr) {
+		if rpcErr.Data == nil {
+			return nil
+		}
+		var fields []apiFieldError
+		for _, entry := range rpcErr.Data.Extra {
+			e, ok := entry.([]any)
+			if !ok || len(e) < 2 {
+				continue
+			}
+			field, _ := e[0].(string)
+			message, _ := e[1].(string)
+			if field == "" || message == "" {
+				continue
+			}
+			fields = append(fields, apiFieldError{Field: trimSchemaPrefix(field), Message: message})
+		}
+		return fields
+	}
+
+	var tnErr *client.TrueNASError
+	if errors.As(err, &tnErr) && tnErr.Code == "EINVAL" && tnErr.Field != "" {
+		message := strings.TrimSpace(strings.TrimPrefix(tnErr.Message, tnErr.Field+":"))
+		return []apiFieldError{{Field: trimSchemaPrefix(tnErr.Field), Message: message}}
+	}
+
+	return nil
+}
+
+// trimSchemaPrefix removes the method schema name (e.g. "vm_create.") from a field.
+func trimSchemaPrefix(field string) string {
+	if _, rest, ok := strings.Cut(field, "."); ok {
+		return rest
+	}
+	return field
+}
+
+// apiFieldPaths maps middleware fields to root attributes of the same name.
+func apiFieldPaths(names ...string) map[string]path.Path {
+	paths := make(map[string]path.Path, len(names))
+	for _, name := range names {
+		paths[name] = path.Root(name)
+	}
+	return paths
+}
+
+// addAPIError reports err from an API call. Field errors for fields in paths
+// are attached to the matching attribute so Terraform points at the offending
+// configuration. If any error cannot be placed, summary and detail are added
+// as a general error as well, so nothing the server reported is lost.
+func addAPIError(diags *diag.Diagnostics, err error, paths map[string]path.Path, summary, detail string) {
+	placed := 0
+	fields := apiFieldErrors(err)
+	for _, f := range fields {
+		p, ok := paths[f.Field]
+		if !ok {
+			continue
+		}
+		diags.AddAttributeError(p, summary, f.Message)
+		placed++
+	}
+
+	if len(fields) == 0 || placed < len(fields) {
+		diags.AddError(summary, detail)
+	}
+}
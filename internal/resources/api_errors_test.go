@@ -0,0 +1,138 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func newValidationRPCError(extra ...[]any) error {
+	entries := make([]any, len(extra))
+	for i, e := range extra {
+		entries[i] = e
+	}
+	return &client.JSONRPCError{
+		Code:    client.ErrCodeTrueNASCall,
+		Message: "Method call error",
+		Data: &client.JSONRPCData{
+			Reason: "[EINVAL] validation failed",
+			Error:  22,
+			Extra:  entries,
+		},
+	}
+}
+
+func TestAPIFieldErrors_JSONRPCError(t *testing.T) {
+	err := fmt.Errorf("create vm: %w", newValidationRPCError(
+		[]any{"vm_create.memory", "Should be greater than or equal to 20", float64(22)},
+		[]any{"vm_create.cpu_model", "Invalid CPU model", float64(22)},
+		[]any{"malformed"},
+	))
+
+	fields := apiFieldErrors(err)
+
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 field errors, got %v", fields)
+	}
+	if fields[0].Field != "memory" || fields[0].Message != "Should be greater than or equal to 20" {
+		t.Errorf("unexpected first field error: %+v", fields[0])
+	}
+	if fields[1].Field != "cpu_model" {
+		t.Errorf("expected field 'cpu_model', got %q", fields[1].Field)
+	}
+}
+
+func TestAPIFieldErrors_NestedField(t *testing.T) {
+	err := newValidationRPCError([]any{"cronjob_create.schedule.minute", "Invalid value", float64(22)})
+
+	fields := apiFieldErrors(err)
+
+	if len(fields) != 1 || fields[0].Field != "schedule.minute" {
+		t.Errorf("expected field 'schedule.minute', got %v", fields)
+	}
+}
+
+func TestAPIFieldErrors_JobError(t *testing.T) {
+	err := client.ParseTrueNASError("[EINVAL] pool_dataset_create.quota: Quota must be at least 1 MiB\n[EINVAL] pool_dataset_create.atime: Invalid choice")
+
+	fields := apiFieldErrors(err)
+
+	// The client keeps only the first line of a job error.
+	if len(fields) != 1 {
+		t.Fatalf("expected 1 field error, got %v", fields)
+	}
+	if fields[0].Field != "quota" || fields[0].Message != "Quota must be at least 1 MiB" {
+		t.Errorf("unexpected field error: %+v", fields[0])
+	}
+}
+
+func TestAPIFieldErrors_OtherErrors(t *testing.T) {
+	tests := map[string]error{
+		"plain error":      errors.New("connection refused"),
+		"non-EINVAL job":   client.ParseTrueNASError("[EFAULT] vm.start: failed"),
+		"rpc without data": &client.JSONRPCError{Code: client.ErrCodeTrueNASCall, Message: "error"},
+	}
+
+	for name, err := range tests {
+		t.Run(name, func(t *testing.T) {
+			if fields := apiFieldErrors(err); len(fields) != 0 {
+				t.Errorf("expected no field errors, got %v", fields)
+			}
+		})
+	}
+}
+
+func TestAddAPIError_AllFieldsPlaced(t *testing.T) {
+	var diags diag.Diagnostics
+	err := newValidationRPCError([]any{"vm_update.memory", "Too small", float64(22)})
+
+	addAPIError(&diags, err, apiFieldPaths("memory"), "Unable to Update VM", err.Error())
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatal("expected an attribute-scoped diagnostic")
+	}
+	if !withPath.Path().Equal(path.Root("memory")) {
+		t.Errorf("expected path 'memory', got %s", withPath.Path())
+	}
+	if withPath.Detail() != "Too small" {
+		t.Errorf("expected detail 'Too small', got %q", withPath.Detail())
+	}
+}
+
+func TestAddAPIError_UnplacedField(t *testing.T) {
+	var diags diag.Diagnostics
+	err := newValidationRPCError(
+		[]any{"vm_update.memory", "Too small", float64(22)},
+		[]any{"vm_update.devices.0", "Invalid device", float64(22)},
+	)
+
+	addAPIError(&diags, err, apiFieldPaths("memory"), "Unable to Update VM", err.Error())
+
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected an attribute error and a general error, got %v", diags)
+	}
+	if _, ok := diags.Errors()[1].(diag.DiagnosticWithPath); ok {
+		t.Error("expected the second error to be general")
+	}
+}
+
+func TestAddAPIError_PlainError(t *testing.T) {
+	var diags diag.Diagnostics
+
+	addAPIError(&diags, errors.New("connection refused"), apiFieldPaths("memory"), "Unable to Update VM", "connection refused")
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	if _, ok := diags.Errors()[0].(diag.DiagnosticWithPath); ok {
+		t.Error("expected a general error")
+	}
+}
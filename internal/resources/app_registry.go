@@ -144,6 +144,7 @@ func (r *AppRegistryResource) Read(ctx context.Context, req resource.ReadRequest
 	mapAppRegistryToModel(reg, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *AppRegistryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -0,0 +1,264 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &AuditConfigResource{}
+	_ resource.ResourceWithConfigure   = &AuditConfigResource{}
+	_ resource.ResourceWithImportState = &AuditConfigResource{}
+)
+
+// AuditConfigResourceModel describes the resource data model.
+type AuditConfigResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Retention         types.Int64  `tfsdk:"retention"`
+	Reservation       types.Int64  `tfsdk:"reservation"`
+	Quota             types.Int64  `tfsdk:"quota"`
+	QuotaFillWarning  types.Int64  `tfsdk:"quota_fill_warning"`
+	QuotaFillCritical types.Int64  `tfsdk:"quota_fill_critical"`
+}
+
+// auditConfigResponse is the JSON shape returned by audit.config and audit.update.
+type auditConfigResponse struct {
+	ID                int64 `json:"id"`
+	Retention         int64 `json:"retention"`
+	Reservation       int64 `json:"reservation"`
+	Quota             int64 `json:"quota"`
+	QuotaFillWarning  int64 `json:"quota_fill_warning"`
+	QuotaFillCritical int64 `json:"quota_fill_critical"`
+}
+
+// AuditConfigResource defines the resource implementation.
+type AuditConfigResource struct {
+	BaseResource
+}
+
+// NewAuditConfigResource creates a new AuditConfigResource.
+func NewAuditConfigResource() resource.Resource {
+	return &AuditConfigResource{}
+}
+
+func (r *AuditConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_config"
+}
+
+func (r *AuditConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the retention and storage limits of the TrueNAS audit log.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'audit_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"retention": schema.Int64Attribute{
+				Description: "Number of days audit entries are kept. Defaults to 7. Range: 1-30.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(7),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 30),
+				},
+			},
+			"reservation": schema.Int64Attribute{
+				Description: "Space in GiB reserved for the audit dataset. 0 for no reservation. Defaults to 0. Range: 0-100.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"quota": schema.Int64Attribute{
+				Description: "Maximum space in GiB the audit dataset may use. 0 for no quota. Defaults to 0. Range: 0-100.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"quota_fill_warning": schema.Int64Attribute{
+				Description: "Percentage of the quota at which a warning alert is raised. Defaults to 75. Range: 5-80.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(75),
+				Validators: []validator.Int64{
+					int64validator.Between(5, 80),
+				},
+			},
+			"quota_fill_critical": schema.Int64Attribute{
+				Description: "Percentage of the quota at which a critical alert is raised. Defaults to 95. Range: 50-95.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(95),
+				Validators: []validator.Int64{
+					int64validator.Between(50, 95),
+				},
+			},
+		},
+	}
+}
+
+func (r *AuditConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AuditConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildAuditConfigParams(&data))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, auditConfigAPIFieldPaths,
+			"Unable to Update Audit Config",
+			fmt.Sprintf("Unable to update audit configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapAuditConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AuditConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "audit.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Audit Config",
+			fmt.Sprintf("Unable to read audit configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config auditConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse audit configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapAuditConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *AuditConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AuditConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildAuditConfigParams(&plan))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, auditConfigAPIFieldPaths,
+			"Unable to Update Audit Config",
+			fmt.Sprintf("Unable to update audit configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapAuditConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AuditConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := map[string]any{
+		"retention":           7,
+		"reservation":         0,
+		"quota":               0,
+		"quota_fill_warning":  75,
+		"quota_fill_critical": 95,
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Audit Config",
+			fmt.Sprintf("Unable to reset audit configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *AuditConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "audit_config"
+	if req.ID != "audit_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'audit_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// auditConfigAPIFieldPaths maps audit.update validation errors to attributes.
+var auditConfigAPIFieldPaths = apiFieldPaths("retention", "reservation", "quota", "quota_fill_warning", "quota_fill_critical")
+
+// updateConfig calls audit.update and parses the response.
+func (r *AuditConfigResource) updateConfig(ctx context.Context, params map[string]any) (*auditConfigResponse, error) {
+	result, err := r.client.Call(ctx, "audit.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config auditConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse audit update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildAuditConfigParams builds the audit.update params from the resource model.
+func buildAuditConfigParams(data *AuditConfigResourceModel) map[string]any {
+	return map[string]any{
+		"retention":           data.Retention.ValueInt64(),
+		"reservation":         data.Reservation.ValueInt64(),
+		"quota":               data.Quota.ValueInt64(),
+		"quota_fill_warning":  data.QuotaFillWarning.ValueInt64(),
+		"quota_fill_critical": data.QuotaFillCritical.ValueInt64(),
+	}
+}
+
+// mapAuditConfigToModel maps the API response to the resource model.
+func mapAuditConfigToModel(config *auditConfigResponse, data *AuditConfigResourceModel) {
+	data.ID = types.StringValue("audit_config")
+	data.Retention = types.Int64Value(config.Retention)
+	data.Reservation = types.Int64Value(config.Reservation)
+	data.Quota = types.Int64Value(config.Quota)
+	data.QuotaFillWarning = types.Int64Value(config.QuotaFillWarning)
+	data.QuotaFillCritical = types.Int64Value(config.QuotaFillCritical)
+}
@@ -0,0 +1,405 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewAuditConfigResource(t *testing.T) {
+	r := NewAuditConfigResource()
+	if r == nil {
+		t.Fatal("NewAuditConfigResource returned nil")
+	}
+
+	_, ok := r.(*AuditConfigResource)
+	if !ok {
+		t.Fatalf("expected *AuditConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*AuditConfigResource))
+	_ = resource.ResourceWithImportState(r.(*AuditConfigResource))
+}
+
+func TestAuditConfigResource_Metadata(t *testing.T) {
+	r := NewAuditConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_audit_config" {
+		t.Errorf("expected TypeName 'truenas_audit_config', got %q", resp.TypeName)
+	}
+}
+
+func TestAuditConfigResource_Schema(t *testing.T) {
+	schemaResp := getAuditConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"retention", "reservation", "quota", "quota_fill_warning", "quota_fill_critical"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() || !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be optional and computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getAuditConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewAuditConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// auditConfigModelParams holds parameters for creating test model values.
+type auditConfigModelParams struct {
+	ID                interface{}
+	Retention         interface{}
+	Reservation       interface{}
+	Quota             interface{}
+	QuotaFillWarning  interface{}
+	QuotaFillCritical interface{}
+}
+
+func createAuditConfigModelValue(p auditConfigModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                  tftypes.String,
+			"retention":           tftypes.Number,
+			"reservation":         tftypes.Number,
+			"quota":               tftypes.Number,
+			"quota_fill_warning":  tftypes.Number,
+			"quota_fill_critical": tftypes.Number,
+		},
+	}, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, p.ID),
+		"retention":           tftypes.NewValue(tftypes.Number, p.Retention),
+		"reservation":         tftypes.NewValue(tftypes.Number, p.Reservation),
+		"quota":               tftypes.NewValue(tftypes.Number, p.Quota),
+		"quota_fill_warning":  tftypes.NewValue(tftypes.Number, p.QuotaFillWarning),
+		"quota_fill_critical": tftypes.NewValue(tftypes.Number, p.QuotaFillCritical),
+	})
+}
+
+func defaultAuditConfigParams() auditConfigModelParams {
+	return auditConfigModelParams{
+		Retention:         float64(30),
+		Reservation:       float64(10),
+		Quota:             float64(20),
+		QuotaFillWarning:  float64(70),
+		QuotaFillCritical: float64(90),
+	}
+}
+
+const testAuditConfigJSON = `{
+	"id": 1,
+	"retention": 30,
+	"reservation": 10,
+	"quota": 20,
+	"quota_fill_warning": 70,
+	"quota_fill_critical": 90,
+	"remote_logging_enabled": false,
+	"space": {"used": 1048576, "used_by_snapshots": 0, "available": 21473787904}
+}`
+
+func TestAuditConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &AuditConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testAuditConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getAuditConfigResourceSchema(t)
+	planValue := createAuditConfigModelValue(defaultAuditConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "audit.update" {
+		t.Errorf("expected method 'audit.update', got %q", capturedMethod)
+	}
+	if capturedParams["retention"] != int64(30) {
+		t.Errorf("expected retention 30, got %v", capturedParams["retention"])
+	}
+	if capturedParams["reservation"] != int64(10) {
+		t.Errorf("expected reservation 10, got %v", capturedParams["reservation"])
+	}
+	if capturedParams["quota_fill_critical"] != int64(90) {
+		t.Errorf("expected quota_fill_critical 90, got %v", capturedParams["quota_fill_critical"])
+	}
+
+	var model AuditConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "audit_config" {
+		t.Errorf("expected ID 'audit_config', got %q", model.ID.ValueString())
+	}
+	if model.Quota.ValueInt64() != 20 {
+		t.Errorf("expected quota 20, got %d", model.Quota.ValueInt64())
+	}
+}
+
+func TestAuditConfigResource_Create_ValidationError(t *testing.T) {
+	r := &AuditConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, newValidationRPCError([]any{"audit_update.reservation", "Reservation exceeds available space", float64(22)})
+			},
+		}},
+	}
+
+	schemaResp := getAuditConfigResourceSchema(t)
+	planValue := createAuditConfigModelValue(defaultAuditConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); detail != "Reservation exceeds available space" {
+		t.Errorf("expected reservation field error, got %q", detail)
+	}
+}
+
+func TestAuditConfigResource_Read_Success(t *testing.T) {
+	r := &AuditConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "audit.config" {
+					t.Errorf("expected method 'audit.config', got %q", method)
+				}
+				return json.RawMessage(testAuditConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getAuditConfigResourceSchema(t)
+	p := defaultAuditConfigParams()
+	p.ID = "audit_config"
+	p.Retention = float64(7)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createAuditConfigModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model AuditConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Retention.ValueInt64() != 30 {
+		t.Errorf("expected retention 30 from API, got %d", model.Retention.ValueInt64())
+	}
+	if model.QuotaFillWarning.ValueInt64() != 70 {
+		t.Errorf("expected quota_fill_warning 70, got %d", model.QuotaFillWarning.ValueInt64())
+	}
+}
+
+func TestAuditConfigResource_Read_APIError(t *testing.T) {
+	r := &AuditConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getAuditConfigResourceSchema(t)
+	p := defaultAuditConfigParams()
+	p.ID = "audit_config"
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createAuditConfigModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestAuditConfigResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &AuditConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "retention": 14, "reservation": 10, "quota": 20, "quota_fill_warning": 70, "quota_fill_critical": 90}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getAuditConfigResourceSchema(t)
+	state := defaultAuditConfigParams()
+	state.ID = "audit_config"
+	plan := state
+	plan.Retention = float64(14)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createAuditConfigModelValue(plan)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createAuditConfigModelValue(state)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["retention"] != int64(14) {
+		t.Errorf("expected retention 14, got %v", capturedParams["retention"])
+	}
+
+	var model AuditConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Retention.ValueInt64() != 14 {
+		t.Errorf("expected retention 14, got %d", model.Retention.ValueInt64())
+	}
+}
+
+func TestAuditConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &AuditConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getAuditConfigResourceSchema(t)
+	p := defaultAuditConfigParams()
+	p.ID = "audit_config"
+	stateValue := createAuditConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "audit.update" {
+		t.Errorf("expected method 'audit.update', got %q", capturedMethod)
+	}
+	if capturedParams["retention"] != 7 {
+		t.Errorf("expected retention reset to 7, got %v", capturedParams["retention"])
+	}
+	if capturedParams["quota"] != 0 {
+		t.Errorf("expected quota reset to 0, got %v", capturedParams["quota"])
+	}
+}
+
+func TestAuditConfigResource_ImportState(t *testing.T) {
+	r := NewAuditConfigResource().(*AuditConfigResource)
+	schemaResp := getAuditConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "audit_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createAuditConfigModelValue(auditConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestAuditConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewAuditConfigResource().(*AuditConfigResource)
+	schemaResp := getAuditConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createAuditConfigModelValue(auditConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -2,7 +2,10 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/deevus/truenas-go/client"
 	"github.com/deevus/terraform-provider-truenas/internal/services"
@@ -43,3 +46,62 @@ func (b *BaseResource) Configure(ctx context.Context, req resource.ConfigureRequ
 func (b *BaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// importNamePrefix marks an import ID that identifies the object by name
+// rather than by ID, e.g. `terraform import truenas_vm.example name=my-vm`.
+const importNamePrefix = "name="
+
+// importName returns the name from an import ID of the form "name=<name>".
+// It reports false if the ID does not use the name= prefix.
+func importName(id string) (string, bool) {
+	return strings.CutPrefix(id, importNamePrefix)
+}
+
+// importPathPrefix marks an import ID that identifies a share by the path it
+// exports, e.g. `terraform import truenas_webdav_share.docs path=/mnt/tank/docs`.
+const importPathPrefix = "path="
+
+// importPath returns the path from an import ID of the form "path=<path>".
+// It reports false if the ID does not use the path= prefix.
+func importPath(id string) (string, bool) {
+	p, ok := strings.CutPrefix(id, importPathPrefix)
+	if !ok {
+		return "", false
+	}
+	if len(p) > 1 {
+		p = strings.TrimRight(p, "/")
+	}
+	return p, true
+}
+
+// findShareIDByPath resolves a share path to its numeric ID via method, a
+// sharing.*.query method. A path can be shared more than once, so it must
+// match exactly one share.
+func findShareIDByPath(ctx context.Context, c client.Client, method, sharePath string) (int64, error) {
+	filter := []any{[]any{[]any{"path", "=", sharePath}}}
+
+	result, err := c.Call(ctx, method, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var shares []struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(result, &shares); err != nil {
+		return 0, fmt.Errorf("parse share query response: %w", err)
+	}
+
+	switch len(shares) {
+	case 0:
+		return 0, fmt.Errorf("no share of %q", sharePath)
+	case 1:
+		return shares[0].ID, nil
+	}
+
+	ids := make([]string, len(shares))
+	for i, s := range shares {
+		ids[i] = strconv.FormatInt(s.ID, 10)
+	}
+	return 0, fmt.Errorf("%d shares of %q (IDs %s); import by ID instead", len(shares), sharePath, strings.Join(ids, ", "))
+}
@@ -0,0 +1,278 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &BootstrapAPIKeyResource{}
+var _ resource.ResourceWithConfigure = &BootstrapAPIKeyResource{}
+var _ resource.ResourceWithValidateConfig = &BootstrapAPIKeyResource{}
+
+// BootstrapAPIKeyResource defines the resource implementation.
+type BootstrapAPIKeyResource struct {
+	BaseResource
+}
+
+// BootstrapAPIKeyResourceModel describes the resource data model.
+type BootstrapAPIKeyResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Username  types.String `tfsdk:"username"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+	Key       types.String `tfsdk:"key"`
+}
+
+// apiKeyResponse is the subset of api_key.create and api_key.query fields the resource reads.
+type apiKeyResponse struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Revoked  bool   `json:"revoked"`
+	Key      string `json:"key"`
+}
+
+// NewBootstrapAPIKeyResource creates a new BootstrapAPIKeyResource.
+func NewBootstrapAPIKeyResource() resource.Resource {
+	return &BootstrapAPIKeyResource{}
+}
+
+func (r *BootstrapAPIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bootstrap_api_key"
+}
+
+func (r *BootstrapAPIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a TrueNAS API key by running `midclt call api_key.create` over SSH, so a fresh " +
+			"install can be managed with only SSH credentials until the key exists. Pass the key to a provider " +
+			"configured with auth_method = \"websocket\". The key is only returned when it is created; changing " +
+			"any argument creates a new key, and destroying the resource deletes the key. The SSH user needs the " +
+			"same sudo access to midclt as the SSH transport.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "API key ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "API key name.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 200),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "User the API key authenticates as, e.g. 'truenas_admin'. Use the same name as " +
+					"websocket.username in the provider that consumes the key.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp after which the key stops working, e.g. '2027-01-01T00:00:00Z'. " +
+					"The key does not expire when unset.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Description: "The API key. Only available in state, since TrueNAS does not return it again.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BootstrapAPIKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data BootstrapAPIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ExpiresAt.IsNull() || data.ExpiresAt.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"Invalid Expiry",
+			fmt.Sprintf("expires_at must be an RFC 3339 timestamp such as '2027-01-01T00:00:00Z': %s", err.Error()),
+		)
+	}
+}
+
+func (r *BootstrapAPIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BootstrapAPIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := map[string]any{
+		"name":     data.Name.ValueString(),
+		"username": data.Username.ValueString(),
+	}
+	if !data.ExpiresAt.IsNull() {
+		params["expires_at"] = data.ExpiresAt.ValueString()
+	}
+
+	stdout, err := r.midclt(ctx, "api_key.create", params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create API Key",
+			fmt.Sprintf("Unable to create API key over SSH: %s", err.Error()),
+		)
+		return
+	}
+
+	var key apiKeyResponse
+	if err := json.Unmarshal([]byte(stdout), &key); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse api_key.create response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(key.ID, 10))
+	data.Key = types.StringValue(key.Key)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BootstrapAPIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BootstrapAPIKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Unable to parse ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	result, err := r.client.Call(ctx, "api_key.query", []any{[]any{[]any{"id", "=", id}}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read API Key",
+			fmt.Sprintf("Unable to query API key: %s", err.Error()),
+		)
+		return
+	}
+
+	var keys []apiKeyResponse
+	if err := json.Unmarshal(result, &keys); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse api_key.query response: %s", err.Error()),
+		)
+		return
+	}
+
+	if len(keys) == 0 || keys[0].Revoked {
+		// Deleted or revoked outside Terraform; the stored key no longer works
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Name = types.StringValue(keys[0].Name)
+	data.Username = types.StringValue(keys[0].Username)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *BootstrapAPIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement, so there is nothing to update.
+	var data BootstrapAPIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BootstrapAPIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BootstrapAPIKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Unable to parse ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if _, err := r.midclt(ctx, "api_key.delete", id); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete API Key",
+			fmt.Sprintf("Unable to delete API key over SSH: %s", err.Error()),
+		)
+		return
+	}
+}
+
+// midclt runs `sudo midclt call <method> <param>` over SSH, as the SSH
+// transport does, and returns its output. The parameter is passed as a
+// single JSON argument.
+func (r *BootstrapAPIKeyResource) midclt(ctx context.Context, method string, param any) (string, error) {
+	arg, err := json.Marshal(param)
+	if err != nil {
+		return "", fmt.Errorf("encode parameters: %w", err)
+	}
+
+	result, err := r.services.Exec.Run(ctx, fmt.Sprintf("sudo midclt call %s %s", method, shellQuote(string(arg))))
+	if err != nil {
+		return "", err
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("midclt exited with code %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+	return result.Stdout, nil
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
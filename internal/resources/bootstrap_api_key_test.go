@@ -0,0 +1,327 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewBootstrapAPIKeyResource(t *testing.T) {
+	r := NewBootstrapAPIKeyResource()
+	if r == nil {
+		t.Fatal("NewBootstrapAPIKeyResource returned nil")
+	}
+
+	_, ok := r.(*BootstrapAPIKeyResource)
+	if !ok {
+		t.Fatalf("expected *BootstrapAPIKeyResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*BootstrapAPIKeyResource))
+}
+
+func TestBootstrapAPIKeyResource_Metadata(t *testing.T) {
+	r := NewBootstrapAPIKeyResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_bootstrap_api_key" {
+		t.Errorf("expected TypeName 'truenas_bootstrap_api_key', got %q", resp.TypeName)
+	}
+}
+
+func TestBootstrapAPIKeyResource_Schema(t *testing.T) {
+	schemaResp := getBootstrapAPIKeyResourceSchema(t)
+
+	attrs := schemaResp.Schema.Attributes
+	for _, name := range []string{"name", "username"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	if !attrs["expires_at"].IsOptional() {
+		t.Error("expected 'expires_at' attribute to be optional")
+	}
+	if !attrs["key"].IsComputed() || !attrs["key"].IsSensitive() {
+		t.Error("expected 'key' attribute to be computed and sensitive")
+	}
+}
+
+// Test helpers
+
+func getBootstrapAPIKeyResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewBootstrapAPIKeyResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// bootstrapAPIKeyModelParams holds parameters for creating test model values.
+type bootstrapAPIKeyModelParams struct {
+	ID        interface{}
+	Name      interface{}
+	Username  interface{}
+	ExpiresAt interface{}
+	Key       interface{}
+}
+
+func createBootstrapAPIKeyModelValue(p bootstrapAPIKeyModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"name":       tftypes.String,
+			"username":   tftypes.String,
+			"expires_at": tftypes.String,
+			"key":        tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, p.ID),
+		"name":       tftypes.NewValue(tftypes.String, p.Name),
+		"username":   tftypes.NewValue(tftypes.String, p.Username),
+		"expires_at": tftypes.NewValue(tftypes.String, p.ExpiresAt),
+		"key":        tftypes.NewValue(tftypes.String, p.Key),
+	})
+}
+
+func defaultBootstrapAPIKeyStateParams() bootstrapAPIKeyModelParams {
+	return bootstrapAPIKeyModelParams{
+		ID:       "7",
+		Name:     "terraform",
+		Username: "truenas_admin",
+		Key:      "7-secret",
+	}
+}
+
+func TestBootstrapAPIKeyResource_Create_Success(t *testing.T) {
+	var capturedCommand string
+
+	r := &BootstrapAPIKeyResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					capturedCommand = command
+					return &services.CommandResult{
+						Stdout: `{"id": 7, "name": "terraform", "username": "truenas_admin", "revoked": false, "key": "7-secret"}` + "\n",
+					}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getBootstrapAPIKeyResourceSchema(t)
+	planValue := createBootstrapAPIKeyModelValue(bootstrapAPIKeyModelParams{
+		ID:        tftypes.UnknownValue,
+		Name:      "terraform",
+		Username:  "truenas_admin",
+		ExpiresAt: "2027-01-01T00:00:00Z",
+		Key:       tftypes.UnknownValue,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	want := `sudo midclt call api_key.create '{"expires_at":"2027-01-01T00:00:00Z","name":"terraform","username":"truenas_admin"}'`
+	if capturedCommand != want {
+		t.Errorf("expected command %q, got %q", want, capturedCommand)
+	}
+
+	var model BootstrapAPIKeyResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	if model.ID.ValueString() != "7" {
+		t.Errorf("expected ID '7', got %q", model.ID.ValueString())
+	}
+	if model.Key.ValueString() != "7-secret" {
+		t.Errorf("expected key '7-secret', got %q", model.Key.ValueString())
+	}
+}
+
+func TestBootstrapAPIKeyResource_Create_CommandFails(t *testing.T) {
+	r := &BootstrapAPIKeyResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					return &services.CommandResult{ExitCode: 1, Stderr: "[ENOENT] User not found\n"}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getBootstrapAPIKeyResourceSchema(t)
+	planValue := createBootstrapAPIKeyModelValue(bootstrapAPIKeyModelParams{
+		ID:       tftypes.UnknownValue,
+		Name:     "terraform",
+		Username: "nobody",
+		Key:      tftypes.UnknownValue,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when midclt fails")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "User not found") {
+		t.Errorf("expected detail to include stderr, got %q", detail)
+	}
+}
+
+func readBootstrapAPIKey(t *testing.T, response string) *resource.ReadResponse {
+	t.Helper()
+
+	r := &BootstrapAPIKeyResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "api_key.query" {
+					t.Errorf("expected method 'api_key.query', got %q", method)
+				}
+				return json.RawMessage(response), nil
+			},
+		}},
+	}
+
+	schemaResp := getBootstrapAPIKeyResourceSchema(t)
+	stateValue := createBootstrapAPIKeyModelValue(defaultBootstrapAPIKeyStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+	return resp
+}
+
+func TestBootstrapAPIKeyResource_Read_KeepsKey(t *testing.T) {
+	resp := readBootstrapAPIKey(t, `[{"id": 7, "name": "terraform", "username": "truenas_admin", "revoked": false}]`)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model BootstrapAPIKeyResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	if model.Key.ValueString() != "7-secret" {
+		t.Errorf("expected key to be kept from state, got %q", model.Key.ValueString())
+	}
+}
+
+func TestBootstrapAPIKeyResource_Read_RemovedWhenGoneOrRevoked(t *testing.T) {
+	for name, response := range map[string]string{
+		"deleted": `[]`,
+		"revoked": `[{"id": 7, "name": "terraform", "username": "truenas_admin", "revoked": true}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := readBootstrapAPIKey(t, response)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Error("expected resource to be removed from state")
+			}
+		})
+	}
+}
+
+func TestBootstrapAPIKeyResource_Delete(t *testing.T) {
+	var capturedCommand string
+
+	r := &BootstrapAPIKeyResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					capturedCommand = command
+					return &services.CommandResult{Stdout: "true\n"}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getBootstrapAPIKeyResourceSchema(t)
+	stateValue := createBootstrapAPIKeyModelValue(defaultBootstrapAPIKeyStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedCommand != "sudo midclt call api_key.delete '7'" {
+		t.Errorf("expected delete command, got %q", capturedCommand)
+	}
+}
+
+func TestBootstrapAPIKeyResource_Delete_ConnectionError(t *testing.T) {
+	r := &BootstrapAPIKeyResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+		}},
+	}
+
+	schemaResp := getBootstrapAPIKeyResourceSchema(t)
+	stateValue := createBootstrapAPIKeyModelValue(defaultBootstrapAPIKeyStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for connection failure")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote(`{"name":"it's"}`); got != `'{"name":"it'\''s"}'` {
+		t.Errorf("unexpected quoting: %s", got)
+	}
+}
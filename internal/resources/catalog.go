@@ -0,0 +1,240 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &CatalogResource{}
+	_ resource.ResourceWithConfigure   = &CatalogResource{}
+	_ resource.ResourceWithImportState = &CatalogResource{}
+)
+
+// defaultCatalogTrains is the train set TrueNAS enables out of the box.
+var defaultCatalogTrains = []string{"stable"}
+
+// CatalogResourceModel describes the resource data model.
+type CatalogResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	PreferredTrains types.Set    `tfsdk:"preferred_trains"`
+	Sync            types.Bool   `tfsdk:"sync"`
+	Label           types.String `tfsdk:"label"`
+	Location        types.String `tfsdk:"location"`
+}
+
+// CatalogResource defines the resource implementation.
+type CatalogResource struct {
+	BaseResource
+}
+
+// NewCatalogResource creates a new CatalogResource.
+func NewCatalogResource() resource.Resource {
+	return &CatalogResource{}
+}
+
+func (r *CatalogResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalog"
+}
+
+func (r *CatalogResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages which trains of the TrueNAS apps catalog are enabled. Apps can only be installed " +
+			"from enabled trains, so declare this before apps that use the community or enterprise trains.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'catalog').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"preferred_trains": schema.SetAttribute{
+				Description: "Catalog trains to enable (e.g. stable, community, enterprise).",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"sync": schema.BoolAttribute{
+				Description: "Run catalog.sync after the trains change so apps from newly enabled trains are " +
+					"available immediately. Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"label": schema.StringAttribute{
+				Description: "Catalog label.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "Path of the catalog checkout on the TrueNAS host.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CatalogResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CatalogResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CatalogResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CatalogResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := methods.CatalogConfig(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Catalog",
+			fmt.Sprintf("Unable to read catalog configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapCatalogToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *CatalogResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CatalogResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CatalogResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := methods.CatalogUpdateParams{
+		PreferredTrains: methods.Set(defaultCatalogTrains),
+	}
+
+	if _, err := methods.CatalogUpdate(ctx, r.client, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Catalog",
+			fmt.Sprintf("Unable to reset catalog trains: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *CatalogResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "catalog"
+	if req.ID != "catalog" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'catalog', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sync"), true)...)
+}
+
+// catalogAPIFieldPaths maps catalog.update validation errors to attributes.
+var catalogAPIFieldPaths = apiFieldPaths("preferred_trains")
+
+// apply updates the enabled trains, syncs the catalog when requested, and
+// maps the result into data.
+func (r *CatalogResource) apply(ctx context.Context, data *CatalogResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	trains := []string{}
+	diags.Append(data.PreferredTrains.ElementsAs(ctx, &trains, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Strings(trains)
+
+	config, err := methods.CatalogUpdate(ctx, r.client, methods.CatalogUpdateParams{
+		PreferredTrains: methods.Set(trains),
+	})
+	if err != nil {
+		addAPIError(&diags, err, catalogAPIFieldPaths,
+			"Unable to Update Catalog",
+			fmt.Sprintf("Unable to update catalog trains: %s", err.Error()),
+		)
+		return diags
+	}
+
+	if data.Sync.ValueBool() {
+		if err := methods.CatalogSync(ctx, r.client); err != nil {
+			diags.AddError(
+				"Unable to Sync Catalog",
+				fmt.Sprintf("Catalog trains were updated but catalog.sync failed: %s", err.Error()),
+			)
+			return diags
+		}
+	}
+
+	mapCatalogToModel(config, data)
+	return diags
+}
+
+// mapCatalogToModel maps the API response to the resource model.
+func mapCatalogToModel(config *methods.CatalogConfigResult, data *CatalogResourceModel) {
+	data.ID = types.StringValue("catalog")
+	data.PreferredTrains = catalogTrainsValue(config.PreferredTrains)
+	data.Label = types.StringValue(config.Label)
+	data.Location = types.StringValue(config.Location)
+}
+
+// catalogTrainsValue converts train names to a set value.
+func catalogTrainsValue(trains []string) types.Set {
+	elements := make([]attr.Value, 0, len(trains))
+	for _, t := range trains {
+		elements = append(elements, types.StringValue(t))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
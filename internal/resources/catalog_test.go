@@ -0,0 +1,464 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewCatalogResource(t *testing.T) {
+	r := NewCatalogResource()
+	if r == nil {
+		t.Fatal("NewCatalogResource returned nil")
+	}
+
+	_, ok := r.(*CatalogResource)
+	if !ok {
+		t.Fatalf("expected *CatalogResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*CatalogResource))
+	_ = resource.ResourceWithImportState(r.(*CatalogResource))
+}
+
+func TestCatalogResource_Metadata(t *testing.T) {
+	r := NewCatalogResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_catalog" {
+		t.Errorf("expected TypeName 'truenas_catalog', got %q", resp.TypeName)
+	}
+}
+
+func TestCatalogResource_Schema(t *testing.T) {
+	schemaResp := getCatalogResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["preferred_trains"].IsRequired() {
+		t.Error("expected 'preferred_trains' attribute to be required")
+	}
+	if !attrs["sync"].IsOptional() || !attrs["sync"].IsComputed() {
+		t.Error("expected 'sync' attribute to be optional and computed")
+	}
+	for _, name := range []string{"id", "label", "location"} {
+		if !attrs[name].IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getCatalogResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewCatalogResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// catalogModelParams holds parameters for creating test model values.
+type catalogModelParams struct {
+	ID              interface{}
+	PreferredTrains []string
+	Sync            interface{}
+	Label           interface{}
+	Location        interface{}
+}
+
+func createCatalogModelValue(p catalogModelParams) tftypes.Value {
+	trainsType := tftypes.Set{ElementType: tftypes.String}
+
+	trains := tftypes.NewValue(trainsType, nil)
+	if p.PreferredTrains != nil {
+		values := make([]tftypes.Value, 0, len(p.PreferredTrains))
+		for _, train := range p.PreferredTrains {
+			values = append(values, tftypes.NewValue(tftypes.String, train))
+		}
+		trains = tftypes.NewValue(trainsType, values)
+	}
+
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":               tftypes.String,
+			"preferred_trains": trainsType,
+			"sync":             tftypes.Bool,
+			"label":            tftypes.String,
+			"location":         tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, p.ID),
+		"preferred_trains": trains,
+		"sync":             tftypes.NewValue(tftypes.Bool, p.Sync),
+		"label":            tftypes.NewValue(tftypes.String, p.Label),
+		"location":         tftypes.NewValue(tftypes.String, p.Location),
+	})
+}
+
+func defaultCatalogParams() catalogModelParams {
+	return catalogModelParams{
+		PreferredTrains: []string{"stable", "community"},
+		Sync:            true,
+	}
+}
+
+const testCatalogJSON = `{
+	"id": "TRUENAS",
+	"label": "TRUENAS",
+	"preferred_trains": ["community", "stable"],
+	"location": "/mnt/.ix-apps/truenas_catalog"
+}`
+
+// newCatalogTestResource returns a resource whose client answers catalog
+// calls and records each method and its wire params.
+func newCatalogTestResource(t *testing.T, calls *[]string, updateParams *map[string]any) *CatalogResource {
+	t.Helper()
+
+	return &CatalogResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				*calls = append(*calls, method)
+				switch method {
+				case "catalog.update":
+					if updateParams != nil {
+						data, _ := json.Marshal(params)
+						_ = json.Unmarshal(data, updateParams)
+					}
+					return json.RawMessage(testCatalogJSON), nil
+				case "catalog.config":
+					return json.RawMessage(testCatalogJSON), nil
+				}
+				t.Errorf("unexpected method %q", method)
+				return nil, nil
+			},
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				*calls = append(*calls, method)
+				return json.RawMessage(`null`), nil
+			},
+		}},
+	}
+}
+
+func TestCatalogResource_Create_Success(t *testing.T) {
+	var calls []string
+	var capturedParams map[string]any
+	r := newCatalogTestResource(t, &calls, &capturedParams)
+
+	schemaResp := getCatalogResourceSchema(t)
+	planValue := createCatalogModelValue(defaultCatalogParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if !slices.Equal(calls, []string{"catalog.update", "catalog.sync"}) {
+		t.Errorf("expected catalog.update then catalog.sync, got %v", calls)
+	}
+	trains, _ := capturedParams["preferred_trains"].([]any)
+	if len(trains) != 2 || trains[0] != "community" || trains[1] != "stable" {
+		t.Errorf("expected sorted trains [community stable], got %v", capturedParams["preferred_trains"])
+	}
+
+	var model CatalogResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "catalog" {
+		t.Errorf("expected ID 'catalog', got %q", model.ID.ValueString())
+	}
+	if model.Label.ValueString() != "TRUENAS" {
+		t.Errorf("expected label 'TRUENAS', got %q", model.Label.ValueString())
+	}
+	if model.Location.ValueString() != "/mnt/.ix-apps/truenas_catalog" {
+		t.Errorf("unexpected location %q", model.Location.ValueString())
+	}
+}
+
+func TestCatalogResource_Create_WithoutSync(t *testing.T) {
+	var calls []string
+	r := newCatalogTestResource(t, &calls, nil)
+
+	schemaResp := getCatalogResourceSchema(t)
+	p := defaultCatalogParams()
+	p.Sync = false
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createCatalogModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !slices.Equal(calls, []string{"catalog.update"}) {
+		t.Errorf("expected only catalog.update, got %v", calls)
+	}
+}
+
+func TestCatalogResource_Create_ValidationError(t *testing.T) {
+	r := &CatalogResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, newValidationRPCError([]any{"catalog_update.preferred_trains", "Invalid train: nightly", float64(22)})
+			},
+		}},
+	}
+
+	schemaResp := getCatalogResourceSchema(t)
+	p := defaultCatalogParams()
+	p.PreferredTrains = []string{"nightly"}
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createCatalogModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("preferred_trains")) {
+		t.Errorf("expected error on preferred_trains, got %v", resp.Diagnostics)
+	}
+}
+
+func TestCatalogResource_Create_SyncError(t *testing.T) {
+	r := &CatalogResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(testCatalogJSON), nil
+			},
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("git clone failed")
+			},
+		}},
+	}
+
+	schemaResp := getCatalogResourceSchema(t)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createCatalogModelValue(defaultCatalogParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when catalog.sync fails")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Unable to Sync Catalog" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
+
+func TestCatalogResource_Read_Success(t *testing.T) {
+	var calls []string
+	r := newCatalogTestResource(t, &calls, nil)
+
+	schemaResp := getCatalogResourceSchema(t)
+	p := defaultCatalogParams()
+	p.ID = "catalog"
+	p.PreferredTrains = []string{"stable"}
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createCatalogModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !slices.Equal(calls, []string{"catalog.config"}) {
+		t.Errorf("expected only catalog.config, got %v", calls)
+	}
+
+	var model CatalogResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if len(model.PreferredTrains.Elements()) != 2 {
+		t.Errorf("expected 2 trains from API, got %v", model.PreferredTrains)
+	}
+	if !model.Sync.ValueBool() {
+		t.Error("expected sync to be preserved from state")
+	}
+}
+
+func TestCatalogResource_Read_APIError(t *testing.T) {
+	r := &CatalogResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getCatalogResourceSchema(t)
+	p := defaultCatalogParams()
+	p.ID = "catalog"
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createCatalogModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestCatalogResource_Update_Success(t *testing.T) {
+	var calls []string
+	var capturedParams map[string]any
+	r := newCatalogTestResource(t, &calls, &capturedParams)
+
+	schemaResp := getCatalogResourceSchema(t)
+	state := defaultCatalogParams()
+	state.ID = "catalog"
+	state.PreferredTrains = []string{"stable"}
+	plan := state
+	plan.PreferredTrains = []string{"stable", "community"}
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createCatalogModelValue(plan)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createCatalogModelValue(state)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !slices.Equal(calls, []string{"catalog.update", "catalog.sync"}) {
+		t.Errorf("expected catalog.update then catalog.sync, got %v", calls)
+	}
+	if trains, _ := capturedParams["preferred_trains"].([]any); len(trains) != 2 {
+		t.Errorf("expected 2 trains sent, got %v", capturedParams["preferred_trains"])
+	}
+}
+
+func TestCatalogResource_Delete_ResetsDefaults(t *testing.T) {
+	var calls []string
+	var capturedParams map[string]any
+	r := newCatalogTestResource(t, &calls, &capturedParams)
+
+	schemaResp := getCatalogResourceSchema(t)
+	p := defaultCatalogParams()
+	p.ID = "catalog"
+	stateValue := createCatalogModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !slices.Equal(calls, []string{"catalog.update"}) {
+		t.Errorf("expected only catalog.update, got %v", calls)
+	}
+	trains, _ := capturedParams["preferred_trains"].([]any)
+	if len(trains) != 1 || trains[0] != "stable" {
+		t.Errorf("expected trains reset to [stable], got %v", capturedParams["preferred_trains"])
+	}
+}
+
+func TestCatalogResource_ImportState(t *testing.T) {
+	r := NewCatalogResource().(*CatalogResource)
+	schemaResp := getCatalogResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "catalog"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createCatalogModelValue(catalogModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model CatalogResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.Sync.ValueBool() {
+		t.Error("expected sync to default to true on import")
+	}
+}
+
+func TestCatalogResource_ImportState_InvalidID(t *testing.T) {
+	r := NewCatalogResource().(*CatalogResource)
+	schemaResp := getCatalogResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createCatalogModelValue(catalogModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
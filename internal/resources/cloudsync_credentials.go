@@ -309,6 +309,7 @@ func (r *CloudSyncCredentialsResource) Read(ctx context.Context, req resource.Re
 	// sensitive data that we don't want to overwrite from state
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *CloudSyncCredentialsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
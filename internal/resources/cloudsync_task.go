@@ -570,6 +570,7 @@ func (r *CloudSyncTaskResource) Read(ctx context.Context, req resource.ReadReque
 	mapTaskToModel(task, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *CloudSyncTaskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -33,6 +34,17 @@ type CronJobResourceModel struct {
 	Schedule      *ScheduleBlock `tfsdk:"schedule"`
 }
 
+// cronJobAPIFieldPaths maps cronjob.create and cronjob.update validation errors to attributes.
+var cronJobAPIFieldPaths = func() map[string]path.Path {
+	paths := apiFieldPaths("user", "command", "description", "enabled")
+	paths["stdout"] = path.Root("capture_stdout")
+	paths["stderr"] = path.Root("capture_stderr")
+	for _, field := range []string{"minute", "hour", "dom", "month", "dow"} {
+		paths["schedule."+field] = path.Root("schedule").AtName(field)
+	}
+	return paths
+}()
+
 // CronJobResource defines the resource implementation.
 type CronJobResource struct {
 	BaseResource
@@ -163,7 +175,7 @@ func (r *CronJobResource) Create(ctx context.Context, req resource.CreateRequest
 
 	job, err := r.services.Cron.Create(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(&resp.Diagnostics, err, cronJobAPIFieldPaths,
 			"Unable to Create Cron Job",
 			fmt.Sprintf("Unable to create cron job: %s", err.Error()),
 		)
@@ -218,6 +230,7 @@ func (r *CronJobResource) Read(ctx context.Context, req resource.ReadRequest, re
 	mapCronJobToModel(job, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *CronJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -244,7 +257,7 @@ func (r *CronJobResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	job, err := r.services.Cron.Update(ctx, id, opts)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(&resp.Diagnostics, err, cronJobAPIFieldPaths,
 			"Unable to Update Cron Job",
 			fmt.Sprintf("Unable to update cron job: %s", err.Error()),
 		)
@@ -18,6 +18,14 @@ var _ resource.Resource = &DatasetResource{}
 var _ resource.ResourceWithConfigure = &DatasetResource{}
 var _ resource.ResourceWithImportState = &DatasetResource{}
 var _ resource.ResourceWithValidateConfig = &DatasetResource{}
+var _ resource.ResourceWithModifyPlan = &DatasetResource{}
+
+// datasetAPIFieldPaths maps pool.dataset.create and pool.dataset.update validation errors to attributes.
+var datasetAPIFieldPaths = apiFieldPaths("compression", "quota", "refquota", "atime", "comments")
+
+// datasetModeledProperties are the dataset properties set through their own
+// attribute, which properties must not repeat.
+var datasetModeledProperties = []string{"compression", "quota", "refquota", "atime", "comments"}
 
 // DatasetResource defines the resource implementation.
 type DatasetResource struct {
@@ -26,22 +34,27 @@ type DatasetResource struct {
 
 // DatasetResourceModel describes the resource data model.
 type DatasetResourceModel struct {
-	ID           types.String                   `tfsdk:"id"`
-	Pool         types.String                   `tfsdk:"pool"`
-	Path         types.String                   `tfsdk:"path"`
-	Parent       types.String                   `tfsdk:"parent"`
-	Name         types.String                   `tfsdk:"name"`
-	MountPath    types.String                   `tfsdk:"mount_path"`
-	FullPath     types.String                   `tfsdk:"full_path"`
-	Compression  types.String                   `tfsdk:"compression"`
-	Quota        customtypes.SizeStringValue    `tfsdk:"quota"`
-	RefQuota     customtypes.SizeStringValue    `tfsdk:"refquota"`
-	Atime        types.String                   `tfsdk:"atime"`
-	Mode         types.String                   `tfsdk:"mode"`
-	UID          types.Int64                    `tfsdk:"uid"`
-	GID          types.Int64                    `tfsdk:"gid"`
-	ForceDestroy types.Bool                     `tfsdk:"force_destroy"`
-	SnapshotID   types.String                   `tfsdk:"snapshot_id"`
+	ID              types.String                 `tfsdk:"id"`
+	Pool            customtypes.DatasetPathValue `tfsdk:"pool"`
+	Path            customtypes.DatasetPathValue `tfsdk:"path"`
+	Parent          customtypes.DatasetPathValue `tfsdk:"parent"`
+	Name            types.String                 `tfsdk:"name"`
+	MountPath       types.String                 `tfsdk:"mount_path"`
+	FullPath        types.String                 `tfsdk:"full_path"`
+	Compression     types.String                 `tfsdk:"compression"`
+	Quota           customtypes.SizeStringValue  `tfsdk:"quota"`
+	RefQuota        customtypes.SizeStringValue  `tfsdk:"refquota"`
+	Atime           types.String                 `tfsdk:"atime"`
+	Comments        types.String                 `tfsdk:"comments"`
+	Mode            types.String                 `tfsdk:"mode"`
+	UID             types.Int64                  `tfsdk:"uid"`
+	GID             types.Int64                  `tfsdk:"gid"`
+	ForceDestroy    types.Bool                   `tfsdk:"force_destroy"`
+	Recursive       types.Bool                   `tfsdk:"recursive"`
+	Force           types.Bool                   `tfsdk:"force"`
+	ProtectNonempty types.Bool                   `tfsdk:"protect_nonempty"`
+	SnapshotID      types.String                 `tfsdk:"snapshot_id"`
+	Properties      types.Map                    `tfsdk:"properties"`
 }
 
 // mapDatasetToModel maps API response fields to the Terraform model.
@@ -54,6 +67,11 @@ func mapDatasetToModel(ds *truenas.Dataset, data *DatasetResourceModel) {
 	data.Quota = customtypes.NewSizeStringValue(fmt.Sprintf("%d", ds.Quota))
 	data.RefQuota = customtypes.NewSizeStringValue(fmt.Sprintf("%d", ds.RefQuota))
 	data.Atime = types.StringValue(ds.Atime)
+	if ds.Comments != "" {
+		data.Comments = types.StringValue(ds.Comments)
+	} else {
+		data.Comments = types.StringNull()
+	}
 }
 
 // NewDatasetResource creates a new DatasetResource.
@@ -77,6 +95,7 @@ func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"pool": schema.StringAttribute{
+				CustomType:  customtypes.DatasetPathType{},
 				Description: "Pool name. Use with 'path' attribute for pool-relative paths.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
@@ -84,6 +103,7 @@ func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"path": schema.StringAttribute{
+				CustomType:  customtypes.DatasetPathType{},
 				Description: "Dataset path. With 'pool': relative path in pool. With 'parent': child dataset name.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
@@ -91,7 +111,8 @@ func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"parent": schema.StringAttribute{
-				Description: "Parent dataset ID (e.g., 'tank/data'). Use with 'path' attribute.",
+				CustomType:  customtypes.DatasetPathType{},
+				Description: "Parent dataset ID (e.g., 'tank/data'; a '/mnt/tank/data' mount path is also accepted). Use with 'path' attribute.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -160,6 +181,14 @@ func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"comments": schema.StringAttribute{
+				Description: "Comments / description for this dataset (e.g. an owner or ticket reference).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"mode": schema.StringAttribute{
 				Description: "Unix mode for the dataset mountpoint (e.g., '755'). Sets permissions via filesystem.setperm after creation.",
 				Optional:    true,
@@ -173,8 +202,9 @@ func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:    true,
 			},
 			"force_destroy": schema.BoolAttribute{
-				Description: "When destroying this resource, also delete all child datasets. Defaults to false.",
-				Optional:    true,
+				Description:        "When destroying this resource, also delete all child datasets. Defaults to false.",
+				Optional:           true,
+				DeprecationMessage: "Use recursive instead.",
 			},
 			"snapshot_id": schema.StringAttribute{
 				Description: "Create dataset as clone from this snapshot. Mutually exclusive with other creation options.",
@@ -185,6 +215,11 @@ func (r *DatasetResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 		},
 	}
+
+	for name, attr := range poolDatasetDestroySchema() {
+		resp.Schema.Attributes[name] = attr
+	}
+	resp.Schema.Attributes["properties"] = poolDatasetPropertiesSchema()
 }
 
 func (r *DatasetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -206,6 +241,42 @@ func (r *DatasetResource) ValidateConfig(ctx context.Context, req resource.Valid
 				"TrueNAS requires explicit permissions when setting ownership.",
 		)
 	}
+
+	validatePoolDatasetProperties(ctx, data.Properties, datasetModeledProperties, &resp.Diagnostics)
+}
+
+// ModifyPlan warns with the snapshot counts a recursive destroy would delete,
+// both when the dataset is being destroyed and when recursion is turned on.
+func (r *DatasetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to count before the dataset exists, or before the provider is configured
+	if req.State.Raw.IsNull() || r.services == nil {
+		return
+	}
+
+	var state DatasetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRecursive := poolDatasetDestroyRecursive(state.Recursive, state.ForceDestroy)
+
+	if req.Plan.Raw.IsNull() {
+		if stateRecursive {
+			addPoolDatasetDestroyWarning(ctx, r.services.Snapshot, state.ID.ValueString(), true, &resp.Diagnostics)
+		}
+		return
+	}
+
+	var plan DatasetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !stateRecursive && poolDatasetDestroyRecursive(plan.Recursive, plan.ForceDestroy) {
+		addPoolDatasetDestroyWarning(ctx, r.services.Snapshot, state.ID.ValueString(), false, &resp.Diagnostics)
+	}
 }
 
 func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -256,9 +327,30 @@ func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 
+		// A clone starts with the snapshot's comments; set the configured ones
+		if !data.Comments.IsNull() && !data.Comments.IsUnknown() && data.Comments.ValueString() != ds.Comments {
+			ds, err = r.services.Dataset.UpdateDataset(ctx, fullName, truenas.UpdateDatasetOpts{
+				Comments: truenas.StringPtr(data.Comments.ValueString()),
+			})
+			if err != nil {
+				addAPIError(&resp.Diagnostics, err, datasetAPIFieldPaths,
+					"Unable to Update Dataset",
+					fmt.Sprintf("Dataset was cloned but unable to set its comments: %s", err.Error()),
+				)
+				return
+			}
+		}
+
 		// Map all attributes from query response
 		mapDatasetToModel(ds, &data)
 
+		properties, diags := applyPoolDatasetProperties(ctx, r.client, ds.ID, data.Properties, types.MapNull(types.StringType))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Properties = properties
+
 		// Set permissions on the mountpoint if mode/uid/gid are specified
 		if r.hasPermissions(&data) {
 			permOpts := r.buildPermOpts(&data, ds.Mountpoint)
@@ -313,10 +405,14 @@ func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest
 		opts.Atime = data.Atime.ValueString()
 	}
 
+	if !data.Comments.IsNull() && !data.Comments.IsUnknown() {
+		opts.Comments = data.Comments.ValueString()
+	}
+
 	// Call the TrueNAS API
 	ds, err := r.services.Dataset.CreateDataset(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(&resp.Diagnostics, err, datasetAPIFieldPaths,
 			"Unable to Create Dataset",
 			fmt.Sprintf("Unable to create dataset %q: %s", fullName, err.Error()),
 		)
@@ -334,6 +430,13 @@ func (r *DatasetResource) Create(ctx context.Context, req resource.CreateRequest
 	// Map all attributes from response
 	mapDatasetToModel(ds, &data)
 
+	properties, diags := applyPoolDatasetProperties(ctx, r.client, ds.ID, data.Properties, types.MapNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Properties = properties
+
 	// Set permissions on the mountpoint if mode/uid/gid are specified
 	// This allows SFTP operations (like host_path creation) to work with NFSv4 ACLs
 	if r.hasPermissions(&data) {
@@ -384,11 +487,19 @@ func (r *DatasetResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if data.Pool.IsNull() && data.Path.IsNull() && data.Parent.IsNull() && data.Name.IsNull() {
 		pool, path := poolDatasetIDToParts(ds.ID)
 		if path != "" {
-			data.Pool = types.StringValue(pool)
-			data.Path = types.StringValue(path)
+			data.Pool = customtypes.NewDatasetPathValue(pool)
+			data.Path = customtypes.NewDatasetPathValue(path)
 		}
 	}
 
+	// Read back only the properties under management
+	properties, diags := readPoolDatasetProperties(ctx, r.client, ds.ID, data.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Properties = properties
+
 	// Read mountpoint permissions if configured (for drift detection)
 	if err := r.readMountpointPermissions(ctx, ds.Mountpoint, &data); err != nil {
 		resp.Diagnostics.AddWarning(
@@ -399,6 +510,7 @@ func (r *DatasetResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -457,6 +569,15 @@ func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest
 		hasChanges = true
 	}
 
+	if !data.Comments.Equal(state.Comments) {
+		if data.Comments.IsNull() {
+			updateOpts.Comments = truenas.StringPtr("")
+		} else {
+			updateOpts.Comments = truenas.StringPtr(data.Comments.ValueString())
+		}
+		hasChanges = true
+	}
+
 	// Check if permissions changed
 	permChanged := !data.Mode.Equal(state.Mode) ||
 		!data.UID.Equal(state.UID) ||
@@ -469,7 +590,7 @@ func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest
 	if hasChanges {
 		ds, err := r.services.Dataset.UpdateDataset(ctx, datasetID, updateOpts)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			addAPIError(&resp.Diagnostics, err, datasetAPIFieldPaths,
 				"Unable to Update Dataset",
 				fmt.Sprintf("Unable to update dataset %q: %s", datasetID, err.Error()),
 			)
@@ -484,6 +605,13 @@ func (r *DatasetResource) Update(ctx context.Context, req resource.UpdateRequest
 		data.MountPath = state.MountPath
 	}
 
+	properties, diags := applyPoolDatasetProperties(ctx, r.client, datasetID, data.Properties, state.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Properties = properties
+
 	// Update permissions if changed
 	if permChanged && r.hasPermissions(&data) {
 		permOpts := r.buildPermOpts(&data, mountPath)
@@ -510,9 +638,26 @@ func (r *DatasetResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	datasetID := data.ID.ValueString()
-	recursive := !data.ForceDestroy.IsNull() && data.ForceDestroy.ValueBool()
+	recursive := poolDatasetDestroyRecursive(data.Recursive, data.ForceDestroy)
+
+	resp.Diagnostics.Append(r.checkMaintenance(ctx, fmt.Sprintf("destroy of dataset %q", datasetID))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkPoolDatasetEmpty(ctx, r.services.Client, datasetID, data.ProtectNonempty)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if data.Force.ValueBool() {
+		err = deletePoolDatasetForced(ctx, r.services.Client, datasetID, recursive)
+	} else {
+		err = r.services.Dataset.DeleteDataset(ctx, datasetID, recursive)
+	}
 
-	if err := r.services.Dataset.DeleteDataset(ctx, datasetID, recursive); err != nil {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Delete Dataset",
 			fmt.Sprintf("Unable to delete dataset %q: %s", datasetID, err.Error()),
@@ -520,6 +665,17 @@ func (r *DatasetResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+func (r *DatasetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Dataset IDs are already the dataset name; accept name=<pool/path> for
+	// consistency with resources that are imported by numeric ID.
+	id := req.ID
+	if name, ok := importName(id); ok {
+		id = name
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
 // getFullName returns the full dataset name from the model.
 func getFullName(data *DatasetResourceModel) string {
 	return poolDatasetFullName(data.Pool, data.Path, data.Parent, data.Name)
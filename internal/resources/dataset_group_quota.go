@@ -0,0 +1,236 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &DatasetGroupQuotaResource{}
+	_ resource.ResourceWithConfigure   = &DatasetGroupQuotaResource{}
+	_ resource.ResourceWithImportState = &DatasetGroupQuotaResource{}
+)
+
+// DatasetGroupQuotaResourceModel describes the resource data model.
+type DatasetGroupQuotaResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Dataset     types.String `tfsdk:"dataset"`
+	GID         types.Int64  `tfsdk:"gid"`
+	Quota       types.Int64  `tfsdk:"quota"`
+	ObjectQuota types.Int64  `tfsdk:"object_quota"`
+	Name        types.String `tfsdk:"name"`
+	UsedBytes   types.Int64  `tfsdk:"used_bytes"`
+}
+
+// DatasetGroupQuotaResource defines the resource implementation.
+type DatasetGroupQuotaResource struct {
+	BaseResource
+}
+
+// NewDatasetGroupQuotaResource creates a new DatasetGroupQuotaResource.
+func NewDatasetGroupQuotaResource() resource.Resource {
+	return &DatasetGroupQuotaResource{}
+}
+
+func (r *DatasetGroupQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_group_quota"
+}
+
+func (r *DatasetGroupQuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the quota of a single group on a dataset, shared by all of its members. Destroying the resource removes the quota.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (<dataset>:<gid>).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dataset": schema.StringAttribute{
+				Description: "Dataset the quota applies to (e.g. 'tank/shares/home').",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gid": schema.Int64Attribute{
+				Description: "GID of the group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"quota": schema.Int64Attribute{
+				Description: "Maximum space in bytes members of the group may consume together on the dataset.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"object_quota": schema.Int64Attribute{
+				Description: "Maximum number of objects (files and directories) members of the group may own together on the dataset. Null for no limit.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Group name resolved from the GID.",
+				Computed:    true,
+			},
+			"used_bytes": schema.Int64Attribute{
+				Description: "Space in bytes currently used by the group on the dataset.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *DatasetGroupQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatasetGroupQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyQuota(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Group Quota",
+			fmt.Sprintf("Unable to set quota for GID %d on %q: %s", data.GID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetGroupQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatasetGroupQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, err := getDatasetQuota(ctx, r.client, data.Dataset.ValueString(), datasetQuotaTypeGroup, data.GID.ValueInt64())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read Group Quota",
+			fmt.Sprintf("Unable to read quota for GID %d on %q: %s", data.GID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if quota == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapDatasetGroupQuotaToModel(quota, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *DatasetGroupQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DatasetGroupQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyQuota(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Group Quota",
+			fmt.Sprintf("Unable to set quota for GID %d on %q: %s", data.GID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetGroupQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatasetGroupQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setDatasetQuota(ctx, r.client, data.Dataset.ValueString(), datasetQuotaTypeGroup, datasetQuotaTypeGroupObj, data.GID.ValueInt64(), 0, 0)
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete Group Quota",
+			fmt.Sprintf("Unable to remove quota for GID %d on %q: %s", data.GID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *DatasetGroupQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dataset, gid, err := parseDatasetQuotaID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected <dataset>:<gid>: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dataset"), dataset)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("gid"), gid)...)
+}
+
+// applyQuota sets the planned space and object quotas and refreshes data from the API.
+func (r *DatasetGroupQuotaResource) applyQuota(ctx context.Context, data *DatasetGroupQuotaResourceModel) error {
+	dataset, gid := data.Dataset.ValueString(), data.GID.ValueInt64()
+
+	err := setDatasetQuota(ctx, r.client, dataset, datasetQuotaTypeGroup, datasetQuotaTypeGroupObj,
+		gid, data.Quota.ValueInt64(), objectQuotaValue(data.ObjectQuota))
+	if err != nil {
+		return err
+	}
+
+	quota, err := getDatasetQuota(ctx, r.client, dataset, datasetQuotaTypeGroup, gid)
+	if err != nil {
+		return fmt.Errorf("read back quota: %w", err)
+	}
+	if quota == nil {
+		return fmt.Errorf("quota not found after it was set")
+	}
+
+	mapDatasetGroupQuotaToModel(quota, data)
+	return nil
+}
+
+// mapDatasetGroupQuotaToModel maps a pool.dataset.get_quota entry to the resource model.
+func mapDatasetGroupQuotaToModel(quota *datasetQuotaResponse, data *DatasetGroupQuotaResourceModel) {
+	data.ID = types.StringValue(datasetQuotaID(data.Dataset.ValueString(), quota.ID))
+	data.GID = types.Int64Value(quota.ID)
+	data.Quota = types.Int64Value(quota.Quota)
+	data.ObjectQuota = objectQuotaAttr(quota.ObjQuota)
+	data.Name = types.StringValue(quota.Name)
+	data.UsedBytes = types.Int64Value(quota.UsedBytes)
+}
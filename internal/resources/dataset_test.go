@@ -2,11 +2,18 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
 	"github.com/deevus/terraform-provider-truenas/internal/services"
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -405,69 +412,48 @@ func createDatasetResourceModelWithPerms(id, pool, path, parent, name, mountPath
 
 // createDatasetResourceModelFull creates a tftypes.Value for the dataset resource model with all fields
 func createDatasetResourceModelFull(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, mode, uid, gid interface{}) tftypes.Value {
-	return createDatasetResourceModelWithSnapshot(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, mode, uid, gid, nil)
+	return createDatasetResourceModelWithSnapshot(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, nil, nil, mode, uid, gid, nil)
 }
 
 // createDatasetResourceModelWithSnapshot creates a tftypes.Value for the dataset resource model with all fields including snapshot_id
-func createDatasetResourceModelWithSnapshot(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, mode, uid, gid, snapshotID interface{}) tftypes.Value {
-	return tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.String,
-			"pool":          tftypes.String,
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"name":          tftypes.String,
-			"mount_path":    tftypes.String,
-			"full_path":     tftypes.String,
-			"compression":   tftypes.String,
-			"quota":         tftypes.String,
-			"refquota":      tftypes.String,
-			"atime":         tftypes.String,
-			"mode":          tftypes.String,
-			"uid":           tftypes.Number,
-			"gid":           tftypes.Number,
-			"force_destroy": tftypes.Bool,
-			"snapshot_id":   tftypes.String,
-		},
-	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.String, id),
-		"pool":          tftypes.NewValue(tftypes.String, pool),
-		"path":          tftypes.NewValue(tftypes.String, path),
-		"parent":        tftypes.NewValue(tftypes.String, parent),
-		"name":          tftypes.NewValue(tftypes.String, name),
-		"mount_path":    tftypes.NewValue(tftypes.String, mountPath),
-		"full_path":     tftypes.NewValue(tftypes.String, fullPath),
-		"compression":   tftypes.NewValue(tftypes.String, compression),
-		"quota":         tftypes.NewValue(tftypes.String, quota),
-		"refquota":      tftypes.NewValue(tftypes.String, refquota),
-		"atime":         tftypes.NewValue(tftypes.String, atime),
-		"mode":          tftypes.NewValue(tftypes.String, mode),
-		"uid":           tftypes.NewValue(tftypes.Number, uid),
-		"gid":           tftypes.NewValue(tftypes.Number, gid),
-		"force_destroy": tftypes.NewValue(tftypes.Bool, forceDestroy),
-		"snapshot_id":   tftypes.NewValue(tftypes.String, snapshotID),
+func createDatasetResourceModelWithSnapshot(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, recursive, force, mode, uid, gid, snapshotID interface{}) tftypes.Value {
+	return createDatasetResourceModelWithProperties(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, recursive, force, mode, uid, gid, snapshotID, nil)
+}
+
+func createDatasetResourceModelWithProperties(id, pool, path, parent, name, mountPath, fullPath, compression, quota, refquota, atime, forceDestroy, recursive, force, mode, uid, gid, snapshotID interface{}, properties map[string]string) tftypes.Value {
+	return createDatasetResourceModelValue(datasetModelParams{
+		ID: id, Pool: pool, Path: path, Parent: parent, Name: name,
+		MountPath: mountPath, FullPath: fullPath, Compression: compression,
+		Quota: quota, RefQuota: refquota, Atime: atime, ForceDestroy: forceDestroy,
+		Recursive: recursive, Force: force, Mode: mode, UID: uid, GID: gid,
+		SnapshotID: snapshotID, Properties: properties,
 	})
 }
 
 // datasetModelParams holds parameters for creating test model values.
 // Using a struct instead of many individual parameters per the 3-param rule.
 type datasetModelParams struct {
-	ID           interface{}
-	Pool         interface{}
-	Path         interface{}
-	Parent       interface{}
-	Name         interface{}
-	MountPath    interface{}
-	FullPath     interface{}
-	Compression  interface{}
-	Quota        interface{}
-	RefQuota     interface{}
-	Atime        interface{}
-	ForceDestroy interface{}
-	Mode         interface{}
-	UID          interface{}
-	GID          interface{}
-	SnapshotID   interface{}
+	ID              interface{}
+	Pool            interface{}
+	Path            interface{}
+	Parent          interface{}
+	Name            interface{}
+	MountPath       interface{}
+	FullPath        interface{}
+	Compression     interface{}
+	Quota           interface{}
+	RefQuota        interface{}
+	Atime           interface{}
+	Comments        interface{}
+	ForceDestroy    interface{}
+	Recursive       interface{}
+	Force           interface{}
+	ProtectNonempty interface{}
+	Mode            interface{}
+	UID             interface{}
+	GID             interface{}
+	SnapshotID      interface{}
+	Properties      map[string]string
 }
 
 // createDatasetResourceModelValue creates a tftypes.Value from datasetModelParams
@@ -477,11 +463,64 @@ func createDatasetResourceModelValue(p datasetModelParams) tftypes.Value {
 	if fullPath == nil {
 		fullPath = p.MountPath
 	}
-	return createDatasetResourceModelWithSnapshot(
-		p.ID, p.Pool, p.Path, p.Parent, p.Name, p.MountPath, fullPath,
-		p.Compression, p.Quota, p.RefQuota, p.Atime, p.ForceDestroy,
-		p.Mode, p.UID, p.GID, p.SnapshotID,
-	)
+
+	propertiesType := tftypes.Map{ElementType: tftypes.String}
+	propertiesValue := tftypes.NewValue(propertiesType, nil)
+	if p.Properties != nil {
+		values := make(map[string]tftypes.Value, len(p.Properties))
+		for k, v := range p.Properties {
+			values[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		propertiesValue = tftypes.NewValue(propertiesType, values)
+	}
+
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":               tftypes.String,
+			"pool":             tftypes.String,
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"name":             tftypes.String,
+			"mount_path":       tftypes.String,
+			"full_path":        tftypes.String,
+			"compression":      tftypes.String,
+			"quota":            tftypes.String,
+			"refquota":         tftypes.String,
+			"atime":            tftypes.String,
+			"comments":         tftypes.String,
+			"mode":             tftypes.String,
+			"uid":              tftypes.Number,
+			"gid":              tftypes.Number,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
+			"snapshot_id":      tftypes.String,
+			"properties":       propertiesType,
+		},
+	}, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, p.ID),
+		"pool":             tftypes.NewValue(tftypes.String, p.Pool),
+		"path":             tftypes.NewValue(tftypes.String, p.Path),
+		"parent":           tftypes.NewValue(tftypes.String, p.Parent),
+		"name":             tftypes.NewValue(tftypes.String, p.Name),
+		"mount_path":       tftypes.NewValue(tftypes.String, p.MountPath),
+		"full_path":        tftypes.NewValue(tftypes.String, fullPath),
+		"compression":      tftypes.NewValue(tftypes.String, p.Compression),
+		"quota":            tftypes.NewValue(tftypes.String, p.Quota),
+		"refquota":         tftypes.NewValue(tftypes.String, p.RefQuota),
+		"atime":            tftypes.NewValue(tftypes.String, p.Atime),
+		"comments":         tftypes.NewValue(tftypes.String, p.Comments),
+		"mode":             tftypes.NewValue(tftypes.String, p.Mode),
+		"uid":              tftypes.NewValue(tftypes.Number, p.UID),
+		"gid":              tftypes.NewValue(tftypes.Number, p.GID),
+		"force_destroy":    tftypes.NewValue(tftypes.Bool, p.ForceDestroy),
+		"recursive":        tftypes.NewValue(tftypes.Bool, p.Recursive),
+		"force":            tftypes.NewValue(tftypes.Bool, p.Force),
+		"protect_nonempty": tftypes.NewValue(tftypes.Bool, p.ProtectNonempty),
+		"snapshot_id":      tftypes.NewValue(tftypes.String, p.SnapshotID),
+		"properties":       propertiesValue,
+	})
 }
 
 // defaultDataset returns a standard test Dataset for use in mocks.
@@ -1173,6 +1212,273 @@ func TestDatasetResource_Delete_WithForceDestroy(t *testing.T) {
 	}
 }
 
+func TestDatasetResource_Delete_WithForce(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					capturedParams = params
+					return json.RawMessage(`true`), nil
+				},
+			},
+			Dataset: &truenas.MockDatasetService{
+				DeleteDatasetFunc: func(ctx context.Context, id string, recursive bool) error {
+					t.Error("expected DeleteDataset not to be called with force")
+					return nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:        "storage/apps",
+		Pool:      "storage",
+		Path:      "apps",
+		MountPath: "/mnt/storage/apps",
+		Recursive: true,
+		Force:     true,
+	})
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "pool.dataset.delete" {
+		t.Errorf("expected method 'pool.dataset.delete', got %q", capturedMethod)
+	}
+	args, ok := capturedParams.([]any)
+	if !ok || len(args) != 2 {
+		t.Fatalf("expected [id, options], got %#v", capturedParams)
+	}
+	if args[0] != "storage/apps" {
+		t.Errorf("expected ID 'storage/apps', got %v", args[0])
+	}
+	opts := args[1].(map[string]any)
+	if opts["recursive"] != true || opts["force"] != true {
+		t.Errorf("expected recursive and force options, got %v", opts)
+	}
+}
+
+func TestDatasetResource_Delete_WithRecursive(t *testing.T) {
+	var capturedRecursive bool
+
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Dataset: &truenas.MockDatasetService{
+				DeleteDatasetFunc: func(ctx context.Context, id string, recursive bool) error {
+					capturedRecursive = recursive
+					return nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:        "storage/apps",
+		Pool:      "storage",
+		Path:      "apps",
+		Recursive: true,
+	})
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !capturedRecursive {
+		t.Error("expected recursive to be true")
+	}
+}
+
+// datasetSnapshotQueryMock returns a snapshot service reporting two snapshots
+// on storage/apps and one on a child dataset.
+func datasetSnapshotQueryMock(capturedFilters *[][]any) *truenas.MockSnapshotService {
+	return &truenas.MockSnapshotService{
+		QueryFunc: func(ctx context.Context, filters [][]any) ([]truenas.Snapshot, error) {
+			*capturedFilters = filters
+			return []truenas.Snapshot{
+				{ID: "storage/apps@a", Dataset: "storage/apps"},
+				{ID: "storage/apps@b", Dataset: "storage/apps"},
+				{ID: "storage/apps/db@a", Dataset: "storage/apps/db"},
+			}, nil
+		},
+	}
+}
+
+func TestDatasetResource_ModifyPlan_RecursiveDestroyWarnsSnapshotCounts(t *testing.T) {
+	var capturedFilters [][]any
+
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Snapshot: datasetSnapshotQueryMock(&capturedFilters),
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:        "storage/apps",
+		Pool:      "storage",
+		Path:      "apps",
+		Recursive: true,
+	})
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateValue.Type(), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{
+		Plan: req.Plan,
+	}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected 1 warning, got %v", resp.Diagnostics)
+	}
+
+	detail := resp.Diagnostics.Warnings()[0].Detail()
+	for _, want := range []string{"3 snapshot(s)", "storage/apps: 2", "storage/apps/db: 1"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected warning to contain %q, got %q", want, detail)
+		}
+	}
+
+	if len(capturedFilters) != 1 || capturedFilters[0][0] != "OR" {
+		t.Errorf("expected an OR filter over the dataset and its children, got %v", capturedFilters)
+	}
+}
+
+func TestDatasetResource_ModifyPlan_NonRecursiveDestroyNoWarning(t *testing.T) {
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Snapshot: &truenas.MockSnapshotService{
+				QueryFunc: func(ctx context.Context, filters [][]any) ([]truenas.Snapshot, error) {
+					t.Error("expected snapshots not to be queried for a non-recursive destroy")
+					return nil, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:   "storage/apps",
+		Pool: "storage",
+		Path: "apps",
+	})
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateValue.Type(), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{
+		Plan: req.Plan,
+	}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %v", resp.Diagnostics)
+	}
+}
+
+func TestDatasetResource_ModifyPlan_EnablingRecursiveWarns(t *testing.T) {
+	var capturedFilters [][]any
+
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Snapshot: datasetSnapshotQueryMock(&capturedFilters),
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	state := datasetModelParams{
+		ID:   "storage/apps",
+		Pool: "storage",
+		Path: "apps",
+	}
+	plan := state
+	plan.Recursive = true
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetResourceModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createDatasetResourceModelValue(plan)},
+	}
+	resp := &resource.ModifyPlanResponse{
+		Plan: req.Plan,
+	}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected 1 warning, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Warnings()[0].Detail(); !strings.HasPrefix(detail, "With recursive enabled") {
+		t.Errorf("expected enable-recursion wording, got %q", detail)
+	}
+}
+
+func TestDatasetResource_ModifyPlan_SnapshotQueryErrorIsWarning(t *testing.T) {
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Snapshot: &truenas.MockSnapshotService{
+				QueryFunc: func(ctx context.Context, filters [][]any) ([]truenas.Snapshot, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:           "storage/apps",
+		Pool:         "storage",
+		Path:         "apps",
+		ForceDestroy: true,
+	})
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateValue.Type(), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{
+		Plan: req.Plan,
+	}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected a warning, not an error: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected 1 warning, got %v", resp.Diagnostics)
+	}
+}
+
 func TestDatasetResource_ImportState(t *testing.T) {
 	r := NewDatasetResource().(*DatasetResource)
 
@@ -1219,15 +1525,15 @@ func TestGetFullName(t *testing.T) {
 		{
 			name: "pool and path mode",
 			model: DatasetResourceModel{
-				Pool: stringValue("tank"),
-				Path: stringValue("data/apps"),
+				Pool: datasetPathValue("tank"),
+				Path: datasetPathValue("data/apps"),
 			},
 			expectedResult: "tank/data/apps",
 		},
 		{
 			name: "parent and name mode",
 			model: DatasetResourceModel{
-				Parent: stringValue("tank/data"),
+				Parent: datasetPathValue("tank/data"),
 				Name:   stringValue("apps"),
 			},
 			expectedResult: "tank/data/apps",
@@ -1235,21 +1541,21 @@ func TestGetFullName(t *testing.T) {
 		{
 			name: "pool only (invalid)",
 			model: DatasetResourceModel{
-				Pool: stringValue("tank"),
+				Pool: datasetPathValue("tank"),
 			},
 			expectedResult: "",
 		},
 		{
 			name: "path only (invalid)",
 			model: DatasetResourceModel{
-				Path: stringValue("data"),
+				Path: datasetPathValue("data"),
 			},
 			expectedResult: "",
 		},
 		{
 			name: "parent only (invalid)",
 			model: DatasetResourceModel{
-				Parent: stringValue("tank"),
+				Parent: datasetPathValue("tank"),
 			},
 			expectedResult: "",
 		},
@@ -1268,9 +1574,9 @@ func TestGetFullName(t *testing.T) {
 		{
 			name: "both modes provided (pool/path takes precedence)",
 			model: DatasetResourceModel{
-				Pool:   stringValue("tank"),
-				Path:   stringValue("data"),
-				Parent: stringValue("other"),
+				Pool:   datasetPathValue("tank"),
+				Path:   datasetPathValue("data"),
+				Parent: datasetPathValue("other"),
 				Name:   stringValue("name"),
 			},
 			expectedResult: "tank/data",
@@ -1290,8 +1596,19 @@ func TestGetFullName(t *testing.T) {
 func TestGetFullName_PathWithParent(t *testing.T) {
 	// path with parent should work (new preferred way)
 	model := DatasetResourceModel{
-		Parent: stringValue("tank/data"),
-		Path:   stringValue("apps"),
+		Parent: datasetPathValue("tank/data"),
+		Path:   datasetPathValue("apps"),
+	}
+	result := getFullName(&model)
+	if result != "tank/data/apps" {
+		t.Errorf("expected 'tank/data/apps', got %q", result)
+	}
+}
+
+func TestGetFullName_NormalizesMountPaths(t *testing.T) {
+	model := DatasetResourceModel{
+		Parent: datasetPathValue("/mnt/tank/data/"),
+		Path:   datasetPathValue("apps/"),
 	}
 	result := getFullName(&model)
 	if result != "tank/data/apps" {
@@ -1302,8 +1619,8 @@ func TestGetFullName_PathWithParent(t *testing.T) {
 func TestGetFullName_PathOverName(t *testing.T) {
 	// when both path and name are provided with parent, path takes precedence
 	model := DatasetResourceModel{
-		Parent: stringValue("tank/data"),
-		Path:   stringValue("newpath"),
+		Parent: datasetPathValue("tank/data"),
+		Path:   datasetPathValue("newpath"),
 		Name:   stringValue("oldname"),
 	}
 	result := getFullName(&model)
@@ -1317,6 +1634,11 @@ func stringValue(s string) types.String {
 	return types.StringValue(s)
 }
 
+// datasetPathValue is a helper to create a customtypes.DatasetPathValue with a value
+func datasetPathValue(s string) customtypes.DatasetPathValue {
+	return customtypes.NewDatasetPathValue(s)
+}
+
 // Test interface compliance
 func TestDatasetResource_ImplementsInterfaces(t *testing.T) {
 	r := NewDatasetResource()
@@ -1621,6 +1943,171 @@ func TestDatasetResource_Update_AtimeChange(t *testing.T) {
 		t.Errorf("expected atime 'off', got %q", capturedOpts.Atime)
 	}
 }
+func TestDatasetResource_Create_Comments(t *testing.T) {
+	var capturedOpts truenas.CreateDatasetOpts
+
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Dataset: &truenas.MockDatasetService{
+				CreateDatasetFunc: func(ctx context.Context, opts truenas.CreateDatasetOpts) (*truenas.Dataset, error) {
+					capturedOpts = opts
+					ds := defaultDataset()
+					ds.Comments = opts.Comments
+					return ds, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	planValue := createDatasetResourceModelValue(datasetModelParams{
+		Pool:     "storage",
+		Path:     "apps",
+		Comments: "OPS-1234 owned by platform",
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedOpts.Comments != "OPS-1234 owned by platform" {
+		t.Errorf("expected comments to be sent, got %q", capturedOpts.Comments)
+	}
+
+	var model DatasetResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Comments.ValueString() != "OPS-1234 owned by platform" {
+		t.Errorf("expected comments in state, got %q", model.Comments.ValueString())
+	}
+}
+
+func TestDatasetResource_Update_CommentsCleared(t *testing.T) {
+	var capturedOpts truenas.UpdateDatasetOpts
+
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Dataset: &truenas.MockDatasetService{
+				UpdateDatasetFunc: func(ctx context.Context, id string, opts truenas.UpdateDatasetOpts) (*truenas.Dataset, error) {
+					capturedOpts = opts
+					return defaultDataset(), nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	state := datasetModelParams{
+		ID:          "storage/apps",
+		Pool:        "storage",
+		Path:        "apps",
+		MountPath:   "/mnt/storage/apps",
+		Compression: "lz4",
+		Atime:       "on",
+		Comments:    "OPS-1234",
+	}
+	plan := state
+	plan.Comments = nil
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetResourceModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createDatasetResourceModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedOpts.Comments == nil || *capturedOpts.Comments != "" {
+		t.Errorf("expected comments to be cleared, got %v", capturedOpts.Comments)
+	}
+
+	var model DatasetResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.Comments.IsNull() {
+		t.Errorf("expected null comments, got %q", model.Comments.ValueString())
+	}
+}
+
+func TestDatasetResource_Create_WithSnapshotId_Comments(t *testing.T) {
+	var updatedID string
+	var capturedOpts truenas.UpdateDatasetOpts
+
+	clone := &truenas.Dataset{
+		ID:          "tank/restored",
+		Name:        "tank/restored",
+		Mountpoint:  "/mnt/tank/restored",
+		Compression: "lz4",
+		Atime:       "on",
+		Comments:    "original data",
+	}
+	r := &DatasetResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Dataset: &truenas.MockDatasetService{
+				GetDatasetFunc: func(ctx context.Context, id string) (*truenas.Dataset, error) {
+					return clone, nil
+				},
+				UpdateDatasetFunc: func(ctx context.Context, id string, opts truenas.UpdateDatasetOpts) (*truenas.Dataset, error) {
+					updatedID = id
+					capturedOpts = opts
+					updated := *clone
+					updated.Comments = *opts.Comments
+					return &updated, nil
+				},
+			},
+			Snapshot: &truenas.MockSnapshotService{
+				CloneFunc: func(ctx context.Context, snapshot, datasetDst string) error {
+					return nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	planValue := createDatasetResourceModelValue(datasetModelParams{
+		Pool:       "tank",
+		Path:       "restored",
+		SnapshotID: "tank/data@snap1",
+		Comments:   "restore for INC-42",
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if updatedID != "tank/restored" {
+		t.Errorf("expected the clone to be updated, got %q", updatedID)
+	}
+	if capturedOpts.Comments == nil || *capturedOpts.Comments != "restore for INC-42" {
+		t.Errorf("expected configured comments to be set, got %v", capturedOpts.Comments)
+	}
+
+	var model DatasetResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Comments.ValueString() != "restore for INC-42" {
+		t.Errorf("expected comments in state, got %q", model.Comments.ValueString())
+	}
+}
+
 
 // Test Create with plan parsing error
 func TestDatasetResource_Create_PlanParseError(t *testing.T) {
@@ -1633,38 +2120,46 @@ func TestDatasetResource_Create_PlanParseError(t *testing.T) {
 	// Create an invalid plan value with wrong type
 	planValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.String,
-			"pool":          tftypes.Number, // Wrong type!
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"name":          tftypes.String,
-			"mount_path":    tftypes.String,
-			"full_path":     tftypes.String,
-			"compression":   tftypes.String,
-			"quota":         tftypes.String,
-			"refquota":      tftypes.String,
-			"atime":         tftypes.String,
-			"mode":          tftypes.String,
-			"uid":           tftypes.Number,
-			"gid":           tftypes.Number,
-			"force_destroy": tftypes.Bool,
+			"id":               tftypes.String,
+			"pool":             tftypes.Number, // Wrong type!
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"name":             tftypes.String,
+			"mount_path":       tftypes.String,
+			"full_path":        tftypes.String,
+			"compression":      tftypes.String,
+			"quota":            tftypes.String,
+			"refquota":         tftypes.String,
+			"atime":            tftypes.String,
+			"comments":         tftypes.String,
+			"mode":             tftypes.String,
+			"uid":              tftypes.Number,
+			"gid":              tftypes.Number,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
 		},
 	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.String, nil),
-		"pool":          tftypes.NewValue(tftypes.Number, 123), // Wrong type!
-		"path":          tftypes.NewValue(tftypes.String, "apps"),
-		"parent":        tftypes.NewValue(tftypes.String, nil),
-		"name":          tftypes.NewValue(tftypes.String, nil),
-		"mount_path":    tftypes.NewValue(tftypes.String, nil),
-		"full_path":     tftypes.NewValue(tftypes.String, nil),
-		"compression":   tftypes.NewValue(tftypes.String, nil),
-		"quota":         tftypes.NewValue(tftypes.String, nil),
-		"refquota":      tftypes.NewValue(tftypes.String, nil),
-		"atime":         tftypes.NewValue(tftypes.String, nil),
-		"mode":          tftypes.NewValue(tftypes.String, nil),
-		"uid":           tftypes.NewValue(tftypes.Number, nil),
-		"gid":           tftypes.NewValue(tftypes.Number, nil),
-		"force_destroy": tftypes.NewValue(tftypes.Bool, nil),
+		"id":               tftypes.NewValue(tftypes.String, nil),
+		"pool":             tftypes.NewValue(tftypes.Number, 123), // Wrong type!
+		"path":             tftypes.NewValue(tftypes.String, "apps"),
+		"parent":           tftypes.NewValue(tftypes.String, nil),
+		"name":             tftypes.NewValue(tftypes.String, nil),
+		"mount_path":       tftypes.NewValue(tftypes.String, nil),
+		"full_path":        tftypes.NewValue(tftypes.String, nil),
+		"compression":      tftypes.NewValue(tftypes.String, nil),
+		"quota":            tftypes.NewValue(tftypes.String, nil),
+		"refquota":         tftypes.NewValue(tftypes.String, nil),
+		"atime":            tftypes.NewValue(tftypes.String, nil),
+		"comments":         tftypes.NewValue(tftypes.String, nil),
+		"mode":             tftypes.NewValue(tftypes.String, nil),
+		"uid":              tftypes.NewValue(tftypes.Number, nil),
+		"gid":              tftypes.NewValue(tftypes.Number, nil),
+		"force_destroy":    tftypes.NewValue(tftypes.Bool, nil),
+		"recursive":        tftypes.NewValue(tftypes.Bool, nil),
+		"force":            tftypes.NewValue(tftypes.Bool, nil),
+		"protect_nonempty": tftypes.NewValue(tftypes.Bool, nil),
 	})
 
 	req := resource.CreateRequest{
@@ -1698,38 +2193,46 @@ func TestDatasetResource_Read_StateParseError(t *testing.T) {
 	// Create an invalid state value with wrong type
 	stateValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.Number, // Wrong type!
-			"pool":          tftypes.String,
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"name":          tftypes.String,
-			"mount_path":    tftypes.String,
-			"full_path":     tftypes.String,
-			"compression":   tftypes.String,
-			"quota":         tftypes.String,
-			"refquota":      tftypes.String,
-			"atime":         tftypes.String,
-			"mode":          tftypes.String,
-			"uid":           tftypes.Number,
-			"gid":           tftypes.Number,
-			"force_destroy": tftypes.Bool,
+			"id":               tftypes.Number, // Wrong type!
+			"pool":             tftypes.String,
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"name":             tftypes.String,
+			"mount_path":       tftypes.String,
+			"full_path":        tftypes.String,
+			"compression":      tftypes.String,
+			"quota":            tftypes.String,
+			"refquota":         tftypes.String,
+			"atime":            tftypes.String,
+			"comments":         tftypes.String,
+			"mode":             tftypes.String,
+			"uid":              tftypes.Number,
+			"gid":              tftypes.Number,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
 		},
 	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.Number, 123), // Wrong type!
-		"pool":          tftypes.NewValue(tftypes.String, "storage"),
-		"path":          tftypes.NewValue(tftypes.String, "apps"),
-		"parent":        tftypes.NewValue(tftypes.String, nil),
-		"name":          tftypes.NewValue(tftypes.String, nil),
-		"mount_path":    tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
-		"full_path":     tftypes.NewValue(tftypes.String, nil),
-		"compression":   tftypes.NewValue(tftypes.String, "lz4"),
-		"quota":         tftypes.NewValue(tftypes.String, nil),
-		"refquota":      tftypes.NewValue(tftypes.String, nil),
-		"atime":         tftypes.NewValue(tftypes.String, nil),
-		"mode":          tftypes.NewValue(tftypes.String, nil),
-		"uid":           tftypes.NewValue(tftypes.Number, nil),
-		"gid":           tftypes.NewValue(tftypes.Number, nil),
-		"force_destroy": tftypes.NewValue(tftypes.Bool, nil),
+		"id":               tftypes.NewValue(tftypes.Number, 123), // Wrong type!
+		"pool":             tftypes.NewValue(tftypes.String, "storage"),
+		"path":             tftypes.NewValue(tftypes.String, "apps"),
+		"parent":           tftypes.NewValue(tftypes.String, nil),
+		"name":             tftypes.NewValue(tftypes.String, nil),
+		"mount_path":       tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
+		"full_path":        tftypes.NewValue(tftypes.String, nil),
+		"compression":      tftypes.NewValue(tftypes.String, "lz4"),
+		"quota":            tftypes.NewValue(tftypes.String, nil),
+		"refquota":         tftypes.NewValue(tftypes.String, nil),
+		"atime":            tftypes.NewValue(tftypes.String, nil),
+		"comments":         tftypes.NewValue(tftypes.String, nil),
+		"mode":             tftypes.NewValue(tftypes.String, nil),
+		"uid":              tftypes.NewValue(tftypes.Number, nil),
+		"gid":              tftypes.NewValue(tftypes.Number, nil),
+		"force_destroy":    tftypes.NewValue(tftypes.Bool, nil),
+		"recursive":        tftypes.NewValue(tftypes.Bool, nil),
+		"force":            tftypes.NewValue(tftypes.Bool, nil),
+		"protect_nonempty": tftypes.NewValue(tftypes.Bool, nil),
 	})
 
 	req := resource.ReadRequest{
@@ -1766,38 +2269,46 @@ func TestDatasetResource_Update_PlanParseError(t *testing.T) {
 	// Invalid plan with wrong type
 	planValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.String,
-			"pool":          tftypes.Number, // Wrong type!
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"name":          tftypes.String,
-			"mount_path":    tftypes.String,
-			"full_path":     tftypes.String,
-			"compression":   tftypes.String,
-			"quota":         tftypes.String,
-			"refquota":      tftypes.String,
-			"atime":         tftypes.String,
-			"mode":          tftypes.String,
-			"uid":           tftypes.Number,
-			"gid":           tftypes.Number,
-			"force_destroy": tftypes.Bool,
+			"id":               tftypes.String,
+			"pool":             tftypes.Number, // Wrong type!
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"name":             tftypes.String,
+			"mount_path":       tftypes.String,
+			"full_path":        tftypes.String,
+			"compression":      tftypes.String,
+			"quota":            tftypes.String,
+			"refquota":         tftypes.String,
+			"atime":            tftypes.String,
+			"comments":         tftypes.String,
+			"mode":             tftypes.String,
+			"uid":              tftypes.Number,
+			"gid":              tftypes.Number,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
 		},
 	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.String, "storage/apps"),
-		"pool":          tftypes.NewValue(tftypes.Number, 123), // Wrong type!
-		"path":          tftypes.NewValue(tftypes.String, "apps"),
-		"parent":        tftypes.NewValue(tftypes.String, nil),
-		"name":          tftypes.NewValue(tftypes.String, nil),
-		"mount_path":    tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
-		"full_path":     tftypes.NewValue(tftypes.String, nil),
-		"compression":   tftypes.NewValue(tftypes.String, "zstd"),
-		"quota":         tftypes.NewValue(tftypes.String, nil),
-		"refquota":      tftypes.NewValue(tftypes.String, nil),
-		"atime":         tftypes.NewValue(tftypes.String, nil),
-		"mode":          tftypes.NewValue(tftypes.String, nil),
-		"uid":           tftypes.NewValue(tftypes.Number, nil),
-		"gid":           tftypes.NewValue(tftypes.Number, nil),
-		"force_destroy": tftypes.NewValue(tftypes.Bool, nil),
+		"id":               tftypes.NewValue(tftypes.String, "storage/apps"),
+		"pool":             tftypes.NewValue(tftypes.Number, 123), // Wrong type!
+		"path":             tftypes.NewValue(tftypes.String, "apps"),
+		"parent":           tftypes.NewValue(tftypes.String, nil),
+		"name":             tftypes.NewValue(tftypes.String, nil),
+		"mount_path":       tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
+		"full_path":        tftypes.NewValue(tftypes.String, nil),
+		"compression":      tftypes.NewValue(tftypes.String, "zstd"),
+		"quota":            tftypes.NewValue(tftypes.String, nil),
+		"refquota":         tftypes.NewValue(tftypes.String, nil),
+		"atime":            tftypes.NewValue(tftypes.String, nil),
+		"comments":         tftypes.NewValue(tftypes.String, nil),
+		"mode":             tftypes.NewValue(tftypes.String, nil),
+		"uid":              tftypes.NewValue(tftypes.Number, nil),
+		"gid":              tftypes.NewValue(tftypes.Number, nil),
+		"force_destroy":    tftypes.NewValue(tftypes.Bool, nil),
+		"recursive":        tftypes.NewValue(tftypes.Bool, nil),
+		"force":            tftypes.NewValue(tftypes.Bool, nil),
+		"protect_nonempty": tftypes.NewValue(tftypes.Bool, nil),
 	})
 
 	req := resource.UpdateRequest{
@@ -1835,38 +2346,46 @@ func TestDatasetResource_Update_StateParseError(t *testing.T) {
 	// Invalid state with wrong type
 	stateValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.Number, // Wrong type!
-			"pool":          tftypes.String,
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"name":          tftypes.String,
-			"mount_path":    tftypes.String,
-			"full_path":     tftypes.String,
-			"compression":   tftypes.String,
-			"quota":         tftypes.String,
-			"refquota":      tftypes.String,
-			"atime":         tftypes.String,
-			"mode":          tftypes.String,
-			"uid":           tftypes.Number,
-			"gid":           tftypes.Number,
-			"force_destroy": tftypes.Bool,
+			"id":               tftypes.Number, // Wrong type!
+			"pool":             tftypes.String,
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"name":             tftypes.String,
+			"mount_path":       tftypes.String,
+			"full_path":        tftypes.String,
+			"compression":      tftypes.String,
+			"quota":            tftypes.String,
+			"refquota":         tftypes.String,
+			"atime":            tftypes.String,
+			"comments":         tftypes.String,
+			"mode":             tftypes.String,
+			"uid":              tftypes.Number,
+			"gid":              tftypes.Number,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
 		},
 	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.Number, 123), // Wrong type!
-		"pool":          tftypes.NewValue(tftypes.String, "storage"),
-		"path":          tftypes.NewValue(tftypes.String, "apps"),
-		"parent":        tftypes.NewValue(tftypes.String, nil),
-		"name":          tftypes.NewValue(tftypes.String, nil),
-		"mount_path":    tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
-		"full_path":     tftypes.NewValue(tftypes.String, nil),
-		"compression":   tftypes.NewValue(tftypes.String, "lz4"),
-		"quota":         tftypes.NewValue(tftypes.String, nil),
-		"refquota":      tftypes.NewValue(tftypes.String, nil),
-		"atime":         tftypes.NewValue(tftypes.String, nil),
-		"mode":          tftypes.NewValue(tftypes.String, nil),
-		"uid":           tftypes.NewValue(tftypes.Number, nil),
-		"gid":           tftypes.NewValue(tftypes.Number, nil),
-		"force_destroy": tftypes.NewValue(tftypes.Bool, nil),
+		"id":               tftypes.NewValue(tftypes.Number, 123), // Wrong type!
+		"pool":             tftypes.NewValue(tftypes.String, "storage"),
+		"path":             tftypes.NewValue(tftypes.String, "apps"),
+		"parent":           tftypes.NewValue(tftypes.String, nil),
+		"name":             tftypes.NewValue(tftypes.String, nil),
+		"mount_path":       tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
+		"full_path":        tftypes.NewValue(tftypes.String, nil),
+		"compression":      tftypes.NewValue(tftypes.String, "lz4"),
+		"quota":            tftypes.NewValue(tftypes.String, nil),
+		"refquota":         tftypes.NewValue(tftypes.String, nil),
+		"atime":            tftypes.NewValue(tftypes.String, nil),
+		"comments":         tftypes.NewValue(tftypes.String, nil),
+		"mode":             tftypes.NewValue(tftypes.String, nil),
+		"uid":              tftypes.NewValue(tftypes.Number, nil),
+		"gid":              tftypes.NewValue(tftypes.Number, nil),
+		"force_destroy":    tftypes.NewValue(tftypes.Bool, nil),
+		"recursive":        tftypes.NewValue(tftypes.Bool, nil),
+		"force":            tftypes.NewValue(tftypes.Bool, nil),
+		"protect_nonempty": tftypes.NewValue(tftypes.Bool, nil),
 	})
 
 	// Valid plan
@@ -2008,38 +2527,46 @@ func TestDatasetResource_Delete_StateParseError(t *testing.T) {
 	// Invalid state with wrong type
 	stateValue := tftypes.NewValue(tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.Number, // Wrong type!
-			"pool":          tftypes.String,
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"name":          tftypes.String,
-			"mount_path":    tftypes.String,
-			"full_path":     tftypes.String,
-			"compression":   tftypes.String,
-			"quota":         tftypes.String,
-			"refquota":      tftypes.String,
-			"atime":         tftypes.String,
-			"mode":          tftypes.String,
-			"uid":           tftypes.Number,
-			"gid":           tftypes.Number,
-			"force_destroy": tftypes.Bool,
+			"id":               tftypes.Number, // Wrong type!
+			"pool":             tftypes.String,
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"name":             tftypes.String,
+			"mount_path":       tftypes.String,
+			"full_path":        tftypes.String,
+			"compression":      tftypes.String,
+			"quota":            tftypes.String,
+			"refquota":         tftypes.String,
+			"atime":            tftypes.String,
+			"comments":         tftypes.String,
+			"mode":             tftypes.String,
+			"uid":              tftypes.Number,
+			"gid":              tftypes.Number,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
 		},
 	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.Number, 123), // Wrong type!
-		"pool":          tftypes.NewValue(tftypes.String, "storage"),
-		"path":          tftypes.NewValue(tftypes.String, "apps"),
-		"parent":        tftypes.NewValue(tftypes.String, nil),
-		"name":          tftypes.NewValue(tftypes.String, nil),
-		"mount_path":    tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
-		"full_path":     tftypes.NewValue(tftypes.String, nil),
-		"compression":   tftypes.NewValue(tftypes.String, "lz4"),
-		"quota":         tftypes.NewValue(tftypes.String, nil),
-		"refquota":      tftypes.NewValue(tftypes.String, nil),
-		"atime":         tftypes.NewValue(tftypes.String, nil),
-		"mode":          tftypes.NewValue(tftypes.String, nil),
-		"uid":           tftypes.NewValue(tftypes.Number, nil),
-		"gid":           tftypes.NewValue(tftypes.Number, nil),
-		"force_destroy": tftypes.NewValue(tftypes.Bool, nil),
+		"id":               tftypes.NewValue(tftypes.Number, 123), // Wrong type!
+		"pool":             tftypes.NewValue(tftypes.String, "storage"),
+		"path":             tftypes.NewValue(tftypes.String, "apps"),
+		"parent":           tftypes.NewValue(tftypes.String, nil),
+		"name":             tftypes.NewValue(tftypes.String, nil),
+		"mount_path":       tftypes.NewValue(tftypes.String, "/mnt/storage/apps"),
+		"full_path":        tftypes.NewValue(tftypes.String, nil),
+		"compression":      tftypes.NewValue(tftypes.String, "lz4"),
+		"quota":            tftypes.NewValue(tftypes.String, nil),
+		"refquota":         tftypes.NewValue(tftypes.String, nil),
+		"atime":            tftypes.NewValue(tftypes.String, nil),
+		"comments":         tftypes.NewValue(tftypes.String, nil),
+		"mode":             tftypes.NewValue(tftypes.String, nil),
+		"uid":              tftypes.NewValue(tftypes.Number, nil),
+		"gid":              tftypes.NewValue(tftypes.Number, nil),
+		"force_destroy":    tftypes.NewValue(tftypes.Bool, nil),
+		"recursive":        tftypes.NewValue(tftypes.Bool, nil),
+		"force":            tftypes.NewValue(tftypes.Bool, nil),
+		"protect_nonempty": tftypes.NewValue(tftypes.Bool, nil),
 	})
 
 	req := resource.DeleteRequest{
@@ -2780,3 +3307,420 @@ func TestDatasetResource_Create_WithSnapshotId_APIError(t *testing.T) {
 		t.Fatal("expected error for clone API error")
 	}
 }
+
+func TestDatasetResource_ImportState_ByName(t *testing.T) {
+	r := NewDatasetResource().(*DatasetResource)
+
+	schemaResp := getDatasetResourceSchema(t)
+
+	req := resource.ImportStateRequest{
+		ID: "name=storage/apps",
+	}
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createDatasetResourceModel(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	// The name= prefix is stripped; dataset IDs are the dataset name
+	var model DatasetResourceModel
+	diags := resp.State.Get(context.Background(), &model)
+	if diags.HasError() {
+		t.Fatalf("failed to get state: %v", diags)
+	}
+
+	if model.ID.ValueString() != "storage/apps" {
+		t.Errorf("expected ID 'storage/apps', got %q", model.ID.ValueString())
+	}
+}
+
+// newDatasetPropertiesClient returns a client that records pool.dataset.update
+// parameters and answers pool.dataset.query with queryJSON.
+func newDatasetPropertiesClient(t *testing.T, queryJSON string, updates *[]any) *client.MockClient {
+	t.Helper()
+	return &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			switch method {
+			case "pool.dataset.update":
+				*updates = append(*updates, params)
+				return json.RawMessage(`{}`), nil
+			case "pool.dataset.query":
+				return json.RawMessage(queryJSON), nil
+			}
+			t.Errorf("unexpected method %q", method)
+			return nil, nil
+		},
+	}
+}
+
+func TestDatasetResource_Create_WithProperties(t *testing.T) {
+	var updates []any
+	r := &DatasetResource{
+		BaseResource: BaseResource{
+			client: newDatasetPropertiesClient(t,
+				`[{"id": "storage/apps", "logbias": {"value": "THROUGHPUT"}, "copies": {"value": "2"}}]`, &updates),
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{
+					CreateDatasetFunc: func(ctx context.Context, opts truenas.CreateDatasetOpts) (*truenas.Dataset, error) {
+						return defaultDataset(), nil
+					},
+				},
+			},
+		},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	planValue := createDatasetResourceModelValue(datasetModelParams{
+		Pool:       "storage",
+		Path:       "apps",
+		Properties: map[string]string{"logbias": "throughput", "copies": "2"},
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 pool.dataset.update call, got %d", len(updates))
+	}
+	params := updates[0].([]any)
+	if params[0] != "storage/apps" {
+		t.Errorf("expected id 'storage/apps', got %v", params[0])
+	}
+	props := params[1].(map[string]any)
+	if props["logbias"] != "throughput" {
+		t.Errorf("expected logbias 'throughput', got %v", props["logbias"])
+	}
+	if props["copies"] != int64(2) {
+		t.Errorf("expected copies sent as integer 2, got %#v", props["copies"])
+	}
+
+	var model DatasetResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	properties := map[string]string{}
+	resp.Diagnostics.Append(model.Properties.ElementsAs(context.Background(), &properties, false)...)
+	if properties["logbias"] != "throughput" {
+		t.Errorf("expected configured spelling 'throughput' kept, got %q", properties["logbias"])
+	}
+	if properties["copies"] != "2" {
+		t.Errorf("expected copies '2', got %q", properties["copies"])
+	}
+}
+
+func TestDatasetResource_Create_WithoutProperties_NoExtraCalls(t *testing.T) {
+	r := &DatasetResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					t.Errorf("unexpected call to %q", method)
+					return nil, nil
+				},
+			},
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{
+					CreateDatasetFunc: func(ctx context.Context, opts truenas.CreateDatasetOpts) (*truenas.Dataset, error) {
+						return defaultDataset(), nil
+					},
+				},
+			},
+		},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	planValue := createDatasetResourceModelValue(datasetModelParams{Pool: "storage", Path: "apps"})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestDatasetResource_Read_PropertiesDrift(t *testing.T) {
+	var updates []any
+	r := &DatasetResource{
+		BaseResource: BaseResource{
+			client: newDatasetPropertiesClient(t,
+				`[{"id": "storage/apps", "logbias": {"value": "LATENCY"}, "sync": {"value": "ALWAYS"}}]`, &updates),
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{
+					GetDatasetFunc: func(ctx context.Context, id string) (*truenas.Dataset, error) {
+						return defaultDataset(), nil
+					},
+				},
+				Filesystem: &truenas.MockFilesystemService{},
+			},
+		},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:         "storage/apps",
+		Pool:       "storage",
+		Path:       "apps",
+		MountPath:  "/mnt/storage/apps",
+		Properties: map[string]string{"logbias": "throughput"},
+	})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model DatasetResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	properties := map[string]string{}
+	resp.Diagnostics.Append(model.Properties.ElementsAs(context.Background(), &properties, false)...)
+	if properties["logbias"] != "LATENCY" {
+		t.Errorf("expected logbias drift to 'LATENCY', got %q", properties["logbias"])
+	}
+	if _, ok := properties["sync"]; ok {
+		t.Error("expected unmanaged property 'sync' not to be read back")
+	}
+}
+
+func TestDatasetResource_Update_PropertyRemovedInherits(t *testing.T) {
+	var updates []any
+	r := &DatasetResource{
+		BaseResource: BaseResource{
+			client: newDatasetPropertiesClient(t,
+				`[{"id": "storage/apps", "logbias": {"value": "THROUGHPUT"}}]`, &updates),
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{},
+			},
+		},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:          "storage/apps",
+		Pool:        "storage",
+		Path:        "apps",
+		MountPath:   "/mnt/storage/apps",
+		Compression: "lz4",
+		Properties:  map[string]string{"logbias": "THROUGHPUT", "sync": "ALWAYS"},
+	})
+	planValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:          "storage/apps",
+		Pool:        "storage",
+		Path:        "apps",
+		MountPath:   "/mnt/storage/apps",
+		Compression: "lz4",
+		Properties:  map[string]string{"logbias": "throughput"},
+	})
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 pool.dataset.update call, got %d", len(updates))
+	}
+	props := updates[0].([]any)[1].(map[string]any)
+	if len(props) != 1 || props["sync"] != "INHERIT" {
+		t.Errorf("expected only sync reset to INHERIT, got %v", props)
+	}
+}
+
+func TestDatasetResource_Update_PropertyAPIError(t *testing.T) {
+	r := &DatasetResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, newValidationRPCError([]any{"pool_dataset_update.logbias", "Invalid choice: FAST", 22})
+				},
+			},
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{},
+			},
+		},
+	}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID: "storage/apps", Pool: "storage", Path: "apps", MountPath: "/mnt/storage/apps", Compression: "lz4",
+	})
+	planValue := createDatasetResourceModelValue(datasetModelParams{
+		ID: "storage/apps", Pool: "storage", Path: "apps", MountPath: "/mnt/storage/apps", Compression: "lz4",
+		Properties: map[string]string{"logbias": "FAST"},
+	})
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid property")
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("properties").AtMapKey("logbias")) {
+		t.Errorf("expected error scoped to properties[\"logbias\"], got %v", resp.Diagnostics)
+	}
+}
+
+func TestDatasetResource_ValidateConfig_Properties(t *testing.T) {
+	r := NewDatasetResource().(*DatasetResource)
+	schemaResp := getDatasetResourceSchema(t)
+
+	configValue := createDatasetResourceModelValue(datasetModelParams{
+		Pool:       "storage",
+		Path:       "apps",
+		Properties: map[string]string{"compression": "zstd", "org:owner": "ops", "logbias": "THROUGHPUT"},
+	})
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configValue},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 errors, got %v", resp.Diagnostics)
+	}
+	summaries := map[string]bool{}
+	for _, d := range resp.Diagnostics.Errors() {
+		summaries[d.Summary()] = true
+	}
+	if !summaries["Property Has Its Own Attribute"] || !summaries["User Properties Not Supported"] {
+		t.Errorf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestCheckPoolDatasetEmpty(t *testing.T) {
+	tests := []struct {
+		name      string
+		protect   types.Bool
+		query     string
+		queryErr  error
+		wantQuery bool
+		wantError string
+	}{
+		{
+			name:    "not protected",
+			protect: types.BoolNull(),
+		},
+		{
+			name:      "empty",
+			protect:   types.BoolValue(true),
+			query:     `[{"id": "tank/apps", "usedbydataset": {"parsed": 98304}}]`,
+			wantQuery: true,
+		},
+		{
+			name:      "already gone",
+			protect:   types.BoolValue(true),
+			query:     `[]`,
+			wantQuery: true,
+		},
+		{
+			name:      "holds data",
+			protect:   types.BoolValue(true),
+			query:     `[{"id": "tank/apps", "usedbydataset": {"parsed": 5368709120}}]`,
+			wantQuery: true,
+			wantError: "it holds 5368709120 bytes of data",
+		},
+		{
+			name:    "has children",
+			protect: types.BoolValue(true),
+			query: `[
+				{"id": "tank/apps", "usedbydataset": {"parsed": 98304}},
+				{"id": "tank/apps/nginx", "usedbydataset": {"parsed": 98304}},
+				{"id": "tank/apps/db", "usedbydataset": {"parsed": 98304}}
+			]`,
+			wantQuery: true,
+			wantError: "it has 2 child dataset(s): tank/apps/db, tank/apps/nginx",
+		},
+		{
+			name:      "query error",
+			protect:   types.BoolValue(true),
+			queryErr:  errors.New("connection refused"),
+			wantQuery: true,
+			wantError: "could not be checked: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queried bool
+			c := &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					queried = true
+					filter := params.([]any)[0]
+					if got := fmt.Sprint(filter); got != "[[OR [[id = tank/apps] [id ^ tank/apps/]]]]" {
+						t.Errorf("unexpected filter %s", got)
+					}
+					if tt.queryErr != nil {
+						return nil, tt.queryErr
+					}
+					return json.RawMessage(tt.query), nil
+				},
+			}
+
+			diags := checkPoolDatasetEmpty(context.Background(), c, "tank/apps", tt.protect)
+
+			if queried != tt.wantQuery {
+				t.Errorf("expected queried = %v, got %v", tt.wantQuery, queried)
+			}
+			if tt.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tt.wantError) {
+				t.Errorf("expected detail to contain %q, got %q", tt.wantError, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &DatasetUserQuotaResource{}
+	_ resource.ResourceWithConfigure   = &DatasetUserQuotaResource{}
+	_ resource.ResourceWithImportState = &DatasetUserQuotaResource{}
+)
+
+// DatasetUserQuotaResourceModel describes the resource data model.
+type DatasetUserQuotaResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Dataset     types.String `tfsdk:"dataset"`
+	UID         types.Int64  `tfsdk:"uid"`
+	Quota       types.Int64  `tfsdk:"quota"`
+	ObjectQuota types.Int64  `tfsdk:"object_quota"`
+	Name        types.String `tfsdk:"name"`
+	UsedBytes   types.Int64  `tfsdk:"used_bytes"`
+}
+
+// DatasetUserQuotaResource defines the resource implementation.
+type DatasetUserQuotaResource struct {
+	BaseResource
+}
+
+// NewDatasetUserQuotaResource creates a new DatasetUserQuotaResource.
+func NewDatasetUserQuotaResource() resource.Resource {
+	return &DatasetUserQuotaResource{}
+}
+
+func (r *DatasetUserQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_user_quota"
+}
+
+func (r *DatasetUserQuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the quota of a single user on a dataset. Destroying the resource removes the quota.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (<dataset>:<uid>).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dataset": schema.StringAttribute{
+				Description: "Dataset the quota applies to (e.g. 'tank/shares/home').",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"uid": schema.Int64Attribute{
+				Description: "UID of the user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"quota": schema.Int64Attribute{
+				Description: "Maximum space in bytes the user may consume on the dataset.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"object_quota": schema.Int64Attribute{
+				Description: "Maximum number of objects (files and directories) the user may own on the dataset. Null for no limit.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Username resolved from the UID.",
+				Computed:    true,
+			},
+			"used_bytes": schema.Int64Attribute{
+				Description: "Space in bytes currently used by the user on the dataset.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *DatasetUserQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatasetUserQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyQuota(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create User Quota",
+			fmt.Sprintf("Unable to set quota for UID %d on %q: %s", data.UID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetUserQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatasetUserQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, err := getDatasetQuota(ctx, r.client, data.Dataset.ValueString(), datasetQuotaTypeUser, data.UID.ValueInt64())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read User Quota",
+			fmt.Sprintf("Unable to read quota for UID %d on %q: %s", data.UID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if quota == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapDatasetUserQuotaToModel(quota, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *DatasetUserQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DatasetUserQuotaResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyQuota(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update User Quota",
+			fmt.Sprintf("Unable to set quota for UID %d on %q: %s", data.UID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatasetUserQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatasetUserQuotaResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setDatasetQuota(ctx, r.client, data.Dataset.ValueString(), datasetQuotaTypeUser, datasetQuotaTypeUserObj, data.UID.ValueInt64(), 0, 0)
+	if err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete User Quota",
+			fmt.Sprintf("Unable to remove quota for UID %d on %q: %s", data.UID.ValueInt64(), data.Dataset.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *DatasetUserQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	dataset, uid, err := parseDatasetQuotaID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected <dataset>:<uid>: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dataset"), dataset)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uid"), uid)...)
+}
+
+// applyQuota sets the planned space and object quotas and refreshes data from the API.
+func (r *DatasetUserQuotaResource) applyQuota(ctx context.Context, data *DatasetUserQuotaResourceModel) error {
+	dataset, uid := data.Dataset.ValueString(), data.UID.ValueInt64()
+
+	err := setDatasetQuota(ctx, r.client, dataset, datasetQuotaTypeUser, datasetQuotaTypeUserObj,
+		uid, data.Quota.ValueInt64(), objectQuotaValue(data.ObjectQuota))
+	if err != nil {
+		return err
+	}
+
+	quota, err := getDatasetQuota(ctx, r.client, dataset, datasetQuotaTypeUser, uid)
+	if err != nil {
+		return fmt.Errorf("read back quota: %w", err)
+	}
+	if quota == nil {
+		return fmt.Errorf("quota not found after it was set")
+	}
+
+	mapDatasetUserQuotaToModel(quota, data)
+	return nil
+}
+
+// mapDatasetUserQuotaToModel maps a pool.dataset.get_quota entry to the resource model.
+func mapDatasetUserQuotaToModel(quota *datasetQuotaResponse, data *DatasetUserQuotaResourceModel) {
+	data.ID = types.StringValue(datasetQuotaID(data.Dataset.ValueString(), quota.ID))
+	data.UID = types.Int64Value(quota.ID)
+	data.Quota = types.Int64Value(quota.Quota)
+	data.ObjectQuota = objectQuotaAttr(quota.ObjQuota)
+	data.Name = types.StringValue(quota.Name)
+	data.UsedBytes = types.Int64Value(quota.UsedBytes)
+}
@@ -0,0 +1,528 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewDatasetUserQuotaResource(t *testing.T) {
+	r := NewDatasetUserQuotaResource()
+	if r == nil {
+		t.Fatal("NewDatasetUserQuotaResource returned nil")
+	}
+
+	_, ok := r.(*DatasetUserQuotaResource)
+	if !ok {
+		t.Fatalf("expected *DatasetUserQuotaResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*DatasetUserQuotaResource))
+	_ = resource.ResourceWithImportState(r.(*DatasetUserQuotaResource))
+}
+
+func TestDatasetUserQuotaResource_Metadata(t *testing.T) {
+	r := NewDatasetUserQuotaResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_dataset_user_quota" {
+		t.Errorf("expected TypeName 'truenas_dataset_user_quota', got %q", resp.TypeName)
+	}
+}
+
+func TestDatasetUserQuotaResource_Schema(t *testing.T) {
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	for _, name := range []string{"dataset", "uid", "quota"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	if !attrs["object_quota"].IsOptional() {
+		t.Error("expected 'object_quota' attribute to be optional")
+	}
+	for _, name := range []string{"id", "name", "used_bytes"} {
+		if !attrs[name].IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getDatasetUserQuotaResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewDatasetUserQuotaResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// datasetUserQuotaModelParams holds parameters for creating test model values.
+type datasetUserQuotaModelParams struct {
+	ID          interface{}
+	Dataset     interface{}
+	UID         interface{}
+	Quota       interface{}
+	ObjectQuota interface{}
+	Name        interface{}
+	UsedBytes   interface{}
+}
+
+func createDatasetUserQuotaModelValue(p datasetUserQuotaModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":           tftypes.String,
+			"dataset":      tftypes.String,
+			"uid":          tftypes.Number,
+			"quota":        tftypes.Number,
+			"object_quota": tftypes.Number,
+			"name":         tftypes.String,
+			"used_bytes":   tftypes.Number,
+		},
+	}, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, p.ID),
+		"dataset":      tftypes.NewValue(tftypes.String, p.Dataset),
+		"uid":          tftypes.NewValue(tftypes.Number, p.UID),
+		"quota":        tftypes.NewValue(tftypes.Number, p.Quota),
+		"object_quota": tftypes.NewValue(tftypes.Number, p.ObjectQuota),
+		"name":         tftypes.NewValue(tftypes.String, p.Name),
+		"used_bytes":   tftypes.NewValue(tftypes.Number, p.UsedBytes),
+	})
+}
+
+func defaultDatasetUserQuotaPlanParams() datasetUserQuotaModelParams {
+	return datasetUserQuotaModelParams{
+		ID:          tftypes.UnknownValue,
+		Dataset:     "tank/home",
+		UID:         float64(1000),
+		Quota:       float64(10737418240),
+		ObjectQuota: nil,
+		Name:        tftypes.UnknownValue,
+		UsedBytes:   tftypes.UnknownValue,
+	}
+}
+
+func defaultDatasetUserQuotaStateParams() datasetUserQuotaModelParams {
+	return datasetUserQuotaModelParams{
+		ID:          "tank/home:1000",
+		Dataset:     "tank/home",
+		UID:         float64(1000),
+		Quota:       float64(10737418240),
+		ObjectQuota: nil,
+		Name:        "alice",
+		UsedBytes:   float64(1024),
+	}
+}
+
+const testUserQuotaJSON = `[{"quota_type": "USER", "id": 1000, "name": "alice", "quota": 10737418240, "used_bytes": 1024, "obj_quota": 0, "obj_used": 12}]`
+
+func TestDatasetUserQuotaResource_Create_Success(t *testing.T) {
+	var setParams []any
+
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				switch method {
+				case "pool.dataset.set_quota":
+					setParams = params.([]any)
+					return nil, nil
+				case "pool.dataset.get_quota":
+					p := params.([]any)
+					if p[0] != "tank/home" || p[1] != "USER" {
+						t.Errorf("unexpected get_quota params: %v", p)
+					}
+					return json.RawMessage(testUserQuotaJSON), nil
+				}
+				t.Fatalf("unexpected method %q", method)
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaPlanParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if setParams[0] != "tank/home" {
+		t.Errorf("expected dataset 'tank/home', got %v", setParams[0])
+	}
+	entries := setParams[1].([]any)
+	space := entries[0].(map[string]any)
+	if space["quota_type"] != "USER" || space["id"] != "1000" || space["quota_value"] != int64(10737418240) {
+		t.Errorf("unexpected space quota entry: %v", space)
+	}
+	obj := entries[1].(map[string]any)
+	if obj["quota_type"] != "USEROBJ" || obj["quota_value"] != int64(0) {
+		t.Errorf("unexpected object quota entry: %v", obj)
+	}
+
+	var model DatasetUserQuotaResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.ID.ValueString() != "tank/home:1000" {
+		t.Errorf("expected ID 'tank/home:1000', got %q", model.ID.ValueString())
+	}
+	if model.Name.ValueString() != "alice" {
+		t.Errorf("expected name 'alice', got %q", model.Name.ValueString())
+	}
+	if model.UsedBytes.ValueInt64() != 1024 {
+		t.Errorf("expected used_bytes 1024, got %d", model.UsedBytes.ValueInt64())
+	}
+	if !model.ObjectQuota.IsNull() {
+		t.Errorf("expected null object_quota, got %d", model.ObjectQuota.ValueInt64())
+	}
+}
+
+func TestDatasetUserQuotaResource_Create_WithObjectQuota(t *testing.T) {
+	var objectQuota any
+
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method == "pool.dataset.set_quota" {
+					objectQuota = params.([]any)[1].([]any)[1].(map[string]any)["quota_value"]
+					return nil, nil
+				}
+				return json.RawMessage(`[{"id": 1000, "name": "alice", "quota": 10737418240, "used_bytes": 0, "obj_quota": 5000}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	p := defaultDatasetUserQuotaPlanParams()
+	p.ObjectQuota = float64(5000)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if objectQuota != int64(5000) {
+		t.Errorf("expected object quota 5000, got %v", objectQuota)
+	}
+
+	var model DatasetUserQuotaResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.ObjectQuota.ValueInt64() != 5000 {
+		t.Errorf("expected object_quota 5000, got %d", model.ObjectQuota.ValueInt64())
+	}
+}
+
+func TestDatasetUserQuotaResource_Create_APIError(t *testing.T) {
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("dataset does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaPlanParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestDatasetUserQuotaResource_Read_Success(t *testing.T) {
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[{"id": 1000, "name": "alice", "quota": 21474836480, "used_bytes": 4096, "obj_quota": 0}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model DatasetUserQuotaResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Quota.ValueInt64() != 21474836480 {
+		t.Errorf("expected quota drift to be detected, got %d", model.Quota.ValueInt64())
+	}
+	if model.UsedBytes.ValueInt64() != 4096 {
+		t.Errorf("expected used_bytes 4096, got %d", model.UsedBytes.ValueInt64())
+	}
+}
+
+func TestDatasetUserQuotaResource_Read_QuotaRemoved(t *testing.T) {
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				// The user still has usage on the dataset but no quota.
+				return json.RawMessage(`[{"id": 1000, "name": "alice", "quota": 0, "used_bytes": 4096, "obj_quota": 0}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected resource to be removed from state")
+	}
+}
+
+func TestDatasetUserQuotaResource_Read_DatasetNotFound(t *testing.T) {
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("[ENOENT] Dataset tank/home does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected resource to be removed from state")
+	}
+}
+
+func TestDatasetUserQuotaResource_Read_APIError(t *testing.T) {
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestDatasetUserQuotaResource_Update_Success(t *testing.T) {
+	var quotaValue any
+
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method == "pool.dataset.set_quota" {
+					quotaValue = params.([]any)[1].([]any)[0].(map[string]any)["quota_value"]
+					return nil, nil
+				}
+				return json.RawMessage(`[{"id": 1000, "name": "alice", "quota": 21474836480, "used_bytes": 1024, "obj_quota": 0}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	plan := defaultDatasetUserQuotaStateParams()
+	plan.Quota = float64(21474836480)
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(plan)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if quotaValue != int64(21474836480) {
+		t.Errorf("expected quota 21474836480 to be set, got %v", quotaValue)
+	}
+}
+
+func TestDatasetUserQuotaResource_Delete_Success(t *testing.T) {
+	var entries []any
+
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "pool.dataset.set_quota" {
+					t.Errorf("expected method 'pool.dataset.set_quota', got %q", method)
+				}
+				entries = params.([]any)[1].([]any)
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	for _, e := range entries {
+		if e.(map[string]any)["quota_value"] != int64(0) {
+			t.Errorf("expected quota to be cleared, got %v", e)
+		}
+	}
+}
+
+func TestDatasetUserQuotaResource_Delete_APIError(t *testing.T) {
+	r := &DatasetUserQuotaResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createDatasetUserQuotaModelValue(defaultDatasetUserQuotaStateParams())},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestDatasetUserQuotaResource_ImportState(t *testing.T) {
+	r := NewDatasetUserQuotaResource().(*DatasetUserQuotaResource)
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "tank/home:1000"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createDatasetUserQuotaModelValue(datasetUserQuotaModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model DatasetUserQuotaResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Dataset.ValueString() != "tank/home" {
+		t.Errorf("expected dataset 'tank/home', got %q", model.Dataset.ValueString())
+	}
+	if model.UID.ValueInt64() != 1000 {
+		t.Errorf("expected uid 1000, got %d", model.UID.ValueInt64())
+	}
+}
+
+func TestDatasetUserQuotaResource_ImportState_InvalidID(t *testing.T) {
+	r := NewDatasetUserQuotaResource().(*DatasetUserQuotaResource)
+	schemaResp := getDatasetUserQuotaResourceSchema(t)
+
+	for _, id := range []string{"tank/home", "tank/home:alice", ":1000"} {
+		req := resource.ImportStateRequest{ID: id}
+		resp := &resource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    createDatasetUserQuotaModelValue(datasetUserQuotaModelParams{}),
+			},
+		}
+
+		r.ImportState(context.Background(), req, resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("expected error for import ID %q", id)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &DiskWipeResource{}
+var _ resource.ResourceWithConfigure = &DiskWipeResource{}
+var _ resource.ResourceWithValidateConfig = &DiskWipeResource{}
+
+// DiskWipeResource defines the resource implementation.
+type DiskWipeResource struct {
+	BaseResource
+}
+
+// DiskWipeResourceModel describes the resource data model.
+type DiskWipeResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Disk     types.String `tfsdk:"disk"`
+	Mode     types.String `tfsdk:"mode"`
+	Confirm  types.String `tfsdk:"confirm"`
+	Triggers types.Map    `tfsdk:"triggers"`
+}
+
+// diskWipeDiskResponse is the subset of disk.query fields checked before wiping.
+type diskWipeDiskResponse struct {
+	Name string  `json:"name"`
+	Pool *string `json:"pool"`
+}
+
+// NewDiskWipeResource creates a new DiskWipeResource.
+func NewDiskWipeResource() resource.Resource {
+	return &DiskWipeResource{}
+}
+
+func (r *DiskWipeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_disk_wipe"
+}
+
+func (r *DiskWipeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Wipes a disk with disk.wipe when created, so it can be absorbed into a new pool. " +
+			"ALL DATA ON THE DISK IS DESTROYED. Disks that belong to a pool are refused. Changing any " +
+			"argument wipes the disk again. Destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (the disk name).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disk": schema.StringAttribute{
+				Description: "Disk device name as reported by disk.query (e.g. 'sdb').",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "Wipe mode: QUICK clears partition tables and labels, FULL writes zeros to the whole " +
+					"disk, FULL_RANDOM writes random data to the whole disk. Defaults to QUICK.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("QUICK"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("QUICK", "FULL", "FULL_RANDOM"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirm": schema.StringAttribute{
+				Description: "Must be set to the same value as `disk` to confirm that the data on it will be destroyed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Map of values that, when changed, wipe the disk again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DiskWipeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DiskWipeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip validation if either value is unknown (e.g., referencing another
+	// resource's output). Create checks again when values are known.
+	if data.Disk.IsUnknown() || data.Confirm.IsUnknown() {
+		return
+	}
+
+	if data.Confirm.ValueString() != data.Disk.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("confirm"),
+			"Wipe Not Confirmed",
+			fmt.Sprintf("confirm must be set to %q to wipe the disk. All data on it will be destroyed.", data.Disk.ValueString()),
+		)
+	}
+}
+
+func (r *DiskWipeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DiskWipeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disk := data.Disk.ValueString()
+	if data.Confirm.ValueString() != disk {
+		resp.Diagnostics.AddError(
+			"Wipe Not Confirmed",
+			fmt.Sprintf("confirm must be set to %q to wipe the disk. All data on it will be destroyed.", disk),
+		)
+		return
+	}
+
+	existing, err := r.getDisk(ctx, disk)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Disk",
+			fmt.Sprintf("Unable to read disk %q: %s", disk, err.Error()),
+		)
+		return
+	}
+	if existing == nil {
+		resp.Diagnostics.AddError(
+			"Disk Not Found",
+			fmt.Sprintf("Disk %q does not exist.", disk),
+		)
+		return
+	}
+	if existing.Pool != nil && *existing.Pool != "" {
+		resp.Diagnostics.AddError(
+			"Disk In Use",
+			fmt.Sprintf("Disk %q is a member of pool %q and will not be wiped. Remove it from the pool first.", disk, *existing.Pool),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkMaintenance(ctx, fmt.Sprintf("wipe of disk %q", disk))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.CallAndWait(ctx, "disk.wipe", []any{disk, data.Mode.ValueString()}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Wipe Disk",
+			fmt.Sprintf("Unable to wipe disk %q: %s", disk, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(disk)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiskWipeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A wipe leaves no remote object to refresh; state is kept as recorded.
+	var data DiskWipeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *DiskWipeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement, so there is nothing to update.
+	var data DiskWipeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiskWipeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A wipe cannot be undone; removing the resource from state is enough.
+}
+
+// getDisk queries a disk by name, including the pool it belongs to.
+// Returns nil if the disk does not exist.
+func (r *DiskWipeResource) getDisk(ctx context.Context, name string) (*diskWipeDiskResponse, error) {
+	params := []any{
+		[]any{[]any{"name", "=", name}},
+		map[string]any{"extra": map[string]any{"pools": true}},
+	}
+
+	result, err := r.client.Call(ctx, "disk.query", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []diskWipeDiskResponse
+	if err := json.Unmarshal(result, &disks); err != nil {
+		return nil, fmt.Errorf("parse disk query response: %w", err)
+	}
+
+	if len(disks) == 0 {
+		return nil, nil
+	}
+
+	return &disks[0], nil
+}
@@ -0,0 +1,392 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewDiskWipeResource(t *testing.T) {
+	r := NewDiskWipeResource()
+	if r == nil {
+		t.Fatal("NewDiskWipeResource returned nil")
+	}
+
+	_, ok := r.(*DiskWipeResource)
+	if !ok {
+		t.Fatalf("expected *DiskWipeResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*DiskWipeResource))
+	_ = resource.ResourceWithValidateConfig(r.(*DiskWipeResource))
+}
+
+func TestDiskWipeResource_Metadata(t *testing.T) {
+	r := NewDiskWipeResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_disk_wipe" {
+		t.Errorf("expected TypeName 'truenas_disk_wipe', got %q", resp.TypeName)
+	}
+}
+
+func TestDiskWipeResource_Schema(t *testing.T) {
+	schemaResp := getDiskWipeResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"disk", "confirm"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	for _, name := range []string{"mode", "triggers"} {
+		if !attrs[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getDiskWipeResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewDiskWipeResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// diskWipeModelParams holds parameters for creating test model values.
+type diskWipeModelParams struct {
+	ID       interface{}
+	Disk     interface{}
+	Mode     interface{}
+	Confirm  interface{}
+	Triggers map[string]string
+}
+
+func createDiskWipeModelValue(p diskWipeModelParams) tftypes.Value {
+	triggersType := tftypes.Map{ElementType: tftypes.String}
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.String,
+			"disk":     tftypes.String,
+			"mode":     tftypes.String,
+			"confirm":  tftypes.String,
+			"triggers": triggersType,
+		},
+	}
+
+	var triggers tftypes.Value
+	if p.Triggers == nil {
+		triggers = tftypes.NewValue(triggersType, nil)
+	} else {
+		elements := make(map[string]tftypes.Value, len(p.Triggers))
+		for k, v := range p.Triggers {
+			elements[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		triggers = tftypes.NewValue(triggersType, elements)
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, p.ID),
+		"disk":     tftypes.NewValue(tftypes.String, p.Disk),
+		"mode":     tftypes.NewValue(tftypes.String, p.Mode),
+		"confirm":  tftypes.NewValue(tftypes.String, p.Confirm),
+		"triggers": triggers,
+	})
+}
+
+func defaultDiskWipePlanParams() diskWipeModelParams {
+	return diskWipeModelParams{
+		ID:      tftypes.UnknownValue,
+		Disk:    "sdb",
+		Mode:    "QUICK",
+		Confirm: "sdb",
+	}
+}
+
+func TestDiskWipeResource_ValidateConfig_Confirmed(t *testing.T) {
+	r := NewDiskWipeResource().(*DiskWipeResource)
+	schemaResp := getDiskWipeResourceSchema(t)
+
+	p := defaultDiskWipePlanParams()
+	p.ID = nil
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createDiskWipeModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestDiskWipeResource_ValidateConfig_NotConfirmed(t *testing.T) {
+	r := NewDiskWipeResource().(*DiskWipeResource)
+	schemaResp := getDiskWipeResourceSchema(t)
+
+	p := defaultDiskWipePlanParams()
+	p.ID = nil
+	p.Confirm = "yes"
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createDiskWipeModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when confirm does not match disk")
+	}
+}
+
+func TestDiskWipeResource_ValidateConfig_UnknownDisk(t *testing.T) {
+	r := NewDiskWipeResource().(*DiskWipeResource)
+	schemaResp := getDiskWipeResourceSchema(t)
+
+	p := defaultDiskWipePlanParams()
+	p.ID = nil
+	p.Disk = tftypes.UnknownValue
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createDiskWipeModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected validation to be deferred for unknown disk, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestDiskWipeResource_Create_Success(t *testing.T) {
+	var wipeMethod string
+	var wipeParams any
+
+	r := &DiskWipeResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "disk.query" {
+					t.Errorf("expected method 'disk.query', got %q", method)
+				}
+				return json.RawMessage(`[{"name": "sdb", "pool": null}]`), nil
+			},
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				wipeMethod = method
+				wipeParams = params
+				return json.RawMessage(`null`), nil
+			},
+		}},
+	}
+
+	schemaResp := getDiskWipeResourceSchema(t)
+	p := defaultDiskWipePlanParams()
+	p.Mode = "FULL"
+	planValue := createDiskWipeModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if wipeMethod != "disk.wipe" {
+		t.Errorf("expected method 'disk.wipe', got %q", wipeMethod)
+	}
+	args, ok := wipeParams.([]any)
+	if !ok || len(args) != 2 || args[0] != "sdb" || args[1] != "FULL" {
+		t.Errorf("expected params [sdb FULL], got %v", wipeParams)
+	}
+
+	var model DiskWipeResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "sdb" {
+		t.Errorf("expected ID 'sdb', got %q", model.ID.ValueString())
+	}
+}
+
+func TestDiskWipeResource_Create_NotConfirmed(t *testing.T) {
+	r := &DiskWipeResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Error("no API call expected without confirmation")
+				return nil, nil
+			},
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Error("disk.wipe should not be called without confirmation")
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getDiskWipeResourceSchema(t)
+	p := defaultDiskWipePlanParams()
+	p.Confirm = "sdc"
+	planValue := createDiskWipeModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when confirm does not match disk")
+	}
+}
+
+func TestDiskWipeResource_Create_PoolMember(t *testing.T) {
+	r := &DiskWipeResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[{"name": "sdb", "pool": "tank"}]`), nil
+			},
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Error("disk.wipe should not be called for a pool member")
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getDiskWipeResourceSchema(t)
+	planValue := createDiskWipeModelValue(defaultDiskWipePlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for disk in use by a pool")
+	}
+	if got := resp.Diagnostics.Errors()[0].Summary(); got != "Disk In Use" {
+		t.Errorf("expected 'Disk In Use' error, got %q", got)
+	}
+}
+
+func TestDiskWipeResource_Create_DiskNotFound(t *testing.T) {
+	r := &DiskWipeResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getDiskWipeResourceSchema(t)
+	planValue := createDiskWipeModelValue(defaultDiskWipePlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for missing disk")
+	}
+}
+
+func TestDiskWipeResource_Create_WipeError(t *testing.T) {
+	r := &DiskWipeResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[{"name": "sdb", "pool": null}]`), nil
+			},
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("job failed: device busy")
+			},
+		}},
+	}
+
+	schemaResp := getDiskWipeResourceSchema(t)
+	planValue := createDiskWipeModelValue(defaultDiskWipePlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when wipe job fails")
+	}
+}
+
+func TestDiskWipeResource_Delete_NoCall(t *testing.T) {
+	r := &DiskWipeResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Error("Delete should not call the API")
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getDiskWipeResourceSchema(t)
+	p := defaultDiskWipePlanParams()
+	p.ID = "sdb"
+	stateValue := createDiskWipeModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// reportDrift returns a warning for each configurable top-level attribute or
+// block whose refreshed value differs from the prior state, when the provider
+// is configured with report_drift. Differences the attribute's type considers
+// semantically equal are ignored, as are reads that follow an import.
+func (b *BaseResource) reportDrift(ctx context.Context, prior, current tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if b.services == nil || !b.services.ReportDrift {
+		return diags
+	}
+
+	for _, name := range driftedAttributes(ctx, prior, current) {
+		diags.AddAttributeWarning(
+			path.Root(name),
+			"Resource Changed Outside Terraform",
+			fmt.Sprintf("%s was changed on the TrueNAS host outside of Terraform. The next apply will revert it "+
+				"unless the configuration is updated to match.", name),
+		)
+	}
+
+	return diags
+}
+
+// driftedAttributes returns the sorted names of configurable top-level
+// attributes and blocks that differ between prior and current.
+func driftedAttributes(ctx context.Context, prior, current tfsdk.State) []string {
+	if prior.Raw.IsNull() || !prior.Raw.IsKnown() || current.Raw.IsNull() || isImportStub(prior.Raw) {
+		return nil
+	}
+
+	diffs, err := prior.Raw.Diff(current.Raw)
+	if err != nil {
+		return nil
+	}
+
+	attributes := current.Schema.GetAttributes()
+	blocks := current.Schema.GetBlocks()
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, d := range diffs {
+		steps := d.Path.Steps()
+		if len(steps) == 0 {
+			continue
+		}
+		step, ok := steps[0].(tftypes.AttributeName)
+		if !ok || seen[string(step)] {
+			continue
+		}
+		name := string(step)
+		seen[name] = true
+
+		if a, ok := attributes[name]; ok {
+			if !a.IsRequired() && !a.IsOptional() {
+				continue
+			}
+			if semanticallyEqual(ctx, a.GetType(), prior.Raw, current.Raw, name) {
+				continue
+			}
+		} else if _, ok := blocks[name]; !ok {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// isImportStub reports whether state only holds an ID, as it does when Read
+// runs right after ImportStatePassthroughID.
+func isImportStub(raw tftypes.Value) bool {
+	var values map[string]tftypes.Value
+	if err := raw.As(&values); err != nil {
+		return false
+	}
+	for name, v := range values {
+		if name != "id" && !v.IsNull() {
+			return false
+		}
+	}
+	return true
+}
+
+// semanticallyEqual reports whether the named top-level attribute has values
+// in prior and current that its custom type considers equal.
+func semanticallyEqual(ctx context.Context, typ attr.Type, prior, current tftypes.Value, name string) bool {
+	p := tftypes.NewAttributePath().WithAttributeName(name)
+
+	oldValue, ok := attributeValue(ctx, typ, prior, p)
+	if !ok {
+		return false
+	}
+	newValue, ok := attributeValue(ctx, typ, current, p)
+	if !ok {
+		return false
+	}
+
+	switch v := oldValue.(type) {
+	case basetypes.StringValuableWithSemanticEquals:
+		nv, ok := newValue.(basetypes.StringValuable)
+		if !ok {
+			return false
+		}
+		equal, diags := v.StringSemanticEquals(ctx, nv)
+		return equal && !diags.HasError()
+	case basetypes.Int64ValuableWithSemanticEquals:
+		nv, ok := newValue.(basetypes.Int64Valuable)
+		if !ok {
+			return false
+		}
+		equal, diags := v.Int64SemanticEquals(ctx, nv)
+		return equal && !diags.HasError()
+	}
+
+	return false
+}
+
+// attributeValue converts the value at p in raw to the framework value of typ.
+func attributeValue(ctx context.Context, typ attr.Type, raw tftypes.Value, p *tftypes.AttributePath) (attr.Value, bool) {
+	v, _, err := tftypes.WalkAttributePath(raw, p)
+	if err != nil {
+		return nil, false
+	}
+	tfValue, ok := v.(tftypes.Value)
+	if !ok {
+		return nil, false
+	}
+	value, err := typ.ValueFromTerraform(ctx, tfValue)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
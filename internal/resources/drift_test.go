@@ -0,0 +1,140 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+func webdavShareDrift(t *testing.T, reportDrift bool, prior, current webdavShareModelParams) diag.Diagnostics {
+	t.Helper()
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	b := &BaseResource{services: &services.TrueNASServices{ReportDrift: reportDrift}}
+
+	return b.reportDrift(context.Background(),
+		tfsdk.State{Schema: schemaResp.Schema, Raw: createWebDAVShareModelValue(prior)},
+		tfsdk.State{Schema: schemaResp.Schema, Raw: createWebDAVShareModelValue(current)},
+	)
+}
+
+func TestReportDrift_Disabled(t *testing.T) {
+	current := defaultWebDAVShareParams()
+	current.RO = false
+
+	diags := webdavShareDrift(t, false, defaultWebDAVShareParams(), current)
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestReportDrift_NoServices(t *testing.T) {
+	schemaResp := getWebDAVShareResourceSchema(t)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: createWebDAVShareModelValue(defaultWebDAVShareParams())}
+
+	b := &BaseResource{}
+	if diags := b.reportDrift(context.Background(), state, state); len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestReportDrift_ChangedAttributes(t *testing.T) {
+	current := defaultWebDAVShareParams()
+	current.RO = false
+	current.Comment = "edited in the UI"
+
+	diags := webdavShareDrift(t, true, defaultWebDAVShareParams(), current)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", len(diags), diags)
+	}
+
+	want := []path.Path{path.Root("comment"), path.Root("ro")}
+	for i, d := range diags {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok {
+			t.Fatalf("expected diagnostic with path, got %T", d)
+		}
+		if !withPath.Path().Equal(want[i]) {
+			t.Errorf("warning %d: expected path %s, got %s", i, want[i], withPath.Path())
+		}
+		if d.Summary() != "Resource Changed Outside Terraform" {
+			t.Errorf("unexpected summary %q", d.Summary())
+		}
+	}
+}
+
+func TestReportDrift_IgnoresComputedAttributes(t *testing.T) {
+	current := defaultWebDAVShareParams()
+	current.ID = "4"
+
+	diags := webdavShareDrift(t, true, defaultWebDAVShareParams(), current)
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestReportDrift_IgnoresSemanticallyEqualValues(t *testing.T) {
+	current := defaultWebDAVShareParams()
+	current.Path = "/mnt/tank/docs/"
+
+	diags := webdavShareDrift(t, true, defaultWebDAVShareParams(), current)
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestReportDrift_IgnoresImport(t *testing.T) {
+	diags := webdavShareDrift(t, true, webdavShareModelParams{ID: "3"}, defaultWebDAVShareParams())
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestReportDrift_Read(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{ReportDrift: true},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`[` + testWebDAVShareJSON + `]`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	p := defaultWebDAVShareParams()
+	p.Enabled = false
+	stateValue := createWebDAVShareModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected 1 warning, got %v", resp.Diagnostics)
+	}
+	withPath := resp.Diagnostics.Warnings()[0].(diag.DiagnosticWithPath)
+	if !withPath.Path().Equal(path.Root("enabled")) {
+		t.Errorf("expected warning on enabled, got %s", withPath.Path())
+	}
+}
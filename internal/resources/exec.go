@@ -0,0 +1,184 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &ExecResource{}
+var _ resource.ResourceWithConfigure = &ExecResource{}
+
+// ExecResource defines the resource implementation.
+type ExecResource struct {
+	BaseResource
+}
+
+// ExecResourceModel describes the resource data model.
+type ExecResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Command          types.String `tfsdk:"command"`
+	ExpectedExitCode types.Int64  `tfsdk:"expected_exit_code"`
+	Triggers         types.Map    `tfsdk:"triggers"`
+	ExitCode         types.Int64  `tfsdk:"exit_code"`
+	Stdout           types.String `tfsdk:"stdout"`
+	Stderr           types.String `tfsdk:"stderr"`
+}
+
+// NewExecResource creates a new ExecResource.
+func NewExecResource() resource.Resource {
+	return &ExecResource{}
+}
+
+func (r *ExecResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exec"
+}
+
+func (r *ExecResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a one-shot shell command on the TrueNAS host over SSH when created. " +
+			"Use for the small gaps the middleware API does not cover. The command runs as the " +
+			"provider's SSH user; prefix it with sudo where root is required. Changing any argument " +
+			"re-runs the command. Destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Random identifier for this run.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"command": schema.StringAttribute{
+				Description: "Shell command to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expected_exit_code": schema.Int64Attribute{
+				Description: "Exit code that counts as success. Any other exit code fails the apply. Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Map of values that, when changed, re-run the command, e.g., " +
+					"`triggers = { config_checksum = truenas_file.config.checksum }`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"exit_code": schema.Int64Attribute{
+				Description: "Exit code of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"stdout": schema.StringAttribute{
+				Description: "Standard output of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stderr": schema.StringAttribute{
+				Description: "Standard error of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ExecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ExecResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	command := data.Command.ValueString()
+
+	result, err := r.services.Exec.Run(ctx, command)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Run Command",
+			fmt.Sprintf("Unable to run command on the TrueNAS host: %s", err.Error()),
+		)
+		return
+	}
+
+	expected := data.ExpectedExitCode.ValueInt64()
+	if int64(result.ExitCode) != expected {
+		resp.Diagnostics.AddError(
+			"Command Failed",
+			fmt.Sprintf("Command exited with code %d, expected %d.\n\nstderr:\n%s",
+				result.ExitCode, expected, strings.TrimSpace(result.Stderr)),
+		)
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Generate ID",
+			err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.ExitCode = types.Int64Value(int64(result.ExitCode))
+	data.Stdout = types.StringValue(result.Stdout)
+	data.Stderr = types.StringValue(result.Stderr)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExecResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The command leaves no remote object to refresh; state is kept as recorded.
+	var data ExecResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *ExecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement, so there is nothing to update.
+	var data ExecResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to undo on the host; removing the resource from state is enough.
+}
@@ -0,0 +1,358 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewExecResource(t *testing.T) {
+	r := NewExecResource()
+	if r == nil {
+		t.Fatal("NewExecResource returned nil")
+	}
+
+	_, ok := r.(*ExecResource)
+	if !ok {
+		t.Fatalf("expected *ExecResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*ExecResource))
+}
+
+func TestExecResource_Metadata(t *testing.T) {
+	r := NewExecResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_exec" {
+		t.Errorf("expected TypeName 'truenas_exec', got %q", resp.TypeName)
+	}
+}
+
+func TestExecResource_Schema(t *testing.T) {
+	schemaResp := getExecResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["command"].IsRequired() {
+		t.Error("expected 'command' attribute to be required")
+	}
+	if !attrs["expected_exit_code"].IsOptional() {
+		t.Error("expected 'expected_exit_code' attribute to be optional")
+	}
+	if !attrs["triggers"].IsOptional() {
+		t.Error("expected 'triggers' attribute to be optional")
+	}
+	for _, name := range []string{"id", "exit_code", "stdout", "stderr"} {
+		if !attrs[name].IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getExecResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewExecResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// execModelParams holds parameters for creating test model values.
+type execModelParams struct {
+	ID               interface{}
+	Command          interface{}
+	ExpectedExitCode interface{}
+	Triggers         map[string]string
+	ExitCode         interface{}
+	Stdout           interface{}
+	Stderr           interface{}
+}
+
+func createExecModelValue(p execModelParams) tftypes.Value {
+	triggersType := tftypes.Map{ElementType: tftypes.String}
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                 tftypes.String,
+			"command":            tftypes.String,
+			"expected_exit_code": tftypes.Number,
+			"triggers":           triggersType,
+			"exit_code":          tftypes.Number,
+			"stdout":             tftypes.String,
+			"stderr":             tftypes.String,
+		},
+	}
+
+	var triggers tftypes.Value
+	if p.Triggers == nil {
+		triggers = tftypes.NewValue(triggersType, nil)
+	} else {
+		elements := make(map[string]tftypes.Value, len(p.Triggers))
+		for k, v := range p.Triggers {
+			elements[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		triggers = tftypes.NewValue(triggersType, elements)
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, p.ID),
+		"command":            tftypes.NewValue(tftypes.String, p.Command),
+		"expected_exit_code": tftypes.NewValue(tftypes.Number, p.ExpectedExitCode),
+		"triggers":           triggers,
+		"exit_code":          tftypes.NewValue(tftypes.Number, p.ExitCode),
+		"stdout":             tftypes.NewValue(tftypes.String, p.Stdout),
+		"stderr":             tftypes.NewValue(tftypes.String, p.Stderr),
+	})
+}
+
+func defaultExecPlanParams() execModelParams {
+	return execModelParams{
+		ID:               tftypes.UnknownValue,
+		Command:          "zfs set com.example:tag=1 tank/data",
+		ExpectedExitCode: float64(0),
+		Triggers:         map[string]string{"version": "1"},
+		ExitCode:         tftypes.UnknownValue,
+		Stdout:           tftypes.UnknownValue,
+		Stderr:           tftypes.UnknownValue,
+	}
+}
+
+func defaultExecStateParams() execModelParams {
+	return execModelParams{
+		ID:               "3f1c0e5a-0000-0000-0000-000000000000",
+		Command:          "zfs set com.example:tag=1 tank/data",
+		ExpectedExitCode: float64(0),
+		Triggers:         map[string]string{"version": "1"},
+		ExitCode:         float64(0),
+		Stdout:           "",
+		Stderr:           "",
+	}
+}
+
+func TestExecResource_Create_Success(t *testing.T) {
+	var capturedCommand string
+
+	r := &ExecResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					capturedCommand = command
+					return &services.CommandResult{ExitCode: 0, Stdout: "done\n"}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getExecResourceSchema(t)
+	planValue := createExecModelValue(defaultExecPlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedCommand != "zfs set com.example:tag=1 tank/data" {
+		t.Errorf("expected command to be run, got %q", capturedCommand)
+	}
+
+	var model ExecResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	if model.ID.ValueString() == "" {
+		t.Error("expected non-empty ID")
+	}
+	if model.ExitCode.ValueInt64() != 0 {
+		t.Errorf("expected exit_code 0, got %d", model.ExitCode.ValueInt64())
+	}
+	if model.Stdout.ValueString() != "done\n" {
+		t.Errorf("expected stdout 'done\\n', got %q", model.Stdout.ValueString())
+	}
+}
+
+func TestExecResource_Create_ExpectedNonZeroExit(t *testing.T) {
+	r := &ExecResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					return &services.CommandResult{ExitCode: 1}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getExecResourceSchema(t)
+	p := defaultExecPlanParams()
+	p.ExpectedExitCode = float64(1)
+	planValue := createExecModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestExecResource_Create_UnexpectedExitCode(t *testing.T) {
+	r := &ExecResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					return &services.CommandResult{ExitCode: 2, Stderr: "cannot open 'tank/data': dataset does not exist\n"}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getExecResourceSchema(t)
+	planValue := createExecModelValue(defaultExecPlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for unexpected exit code")
+	}
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	if !strings.Contains(detail, "exited with code 2, expected 0") {
+		t.Errorf("expected exit codes in error detail, got %q", detail)
+	}
+	if !strings.Contains(detail, "dataset does not exist") {
+		t.Errorf("expected stderr in error detail, got %q", detail)
+	}
+}
+
+func TestExecResource_Create_RunError(t *testing.T) {
+	r := &ExecResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+		}},
+	}
+
+	schemaResp := getExecResourceSchema(t)
+	planValue := createExecModelValue(defaultExecPlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when command cannot be run")
+	}
+}
+
+func TestExecResource_Read_KeepsState(t *testing.T) {
+	r := &ExecResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					t.Error("Read should not run the command")
+					return nil, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getExecResourceSchema(t)
+	p := defaultExecStateParams()
+	p.Stdout = "done\n"
+	stateValue := createExecModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model ExecResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Stdout.ValueString() != "done\n" {
+		t.Errorf("expected stdout to be preserved, got %q", model.Stdout.ValueString())
+	}
+}
+
+func TestExecResource_Delete_NoCommand(t *testing.T) {
+	r := &ExecResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Exec: &services.MockCommandRunner{
+				RunFunc: func(ctx context.Context, command string) (*services.CommandResult, error) {
+					t.Error("Delete should not run a command")
+					return nil, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getExecResourceSchema(t)
+	stateValue := createExecModelValue(defaultExecStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,331 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &FCPortResource{}
+	_ resource.ResourceWithConfigure   = &FCPortResource{}
+	_ resource.ResourceWithImportState = &FCPortResource{}
+)
+
+// FCPortResourceModel describes the resource data model.
+type FCPortResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Port   types.String `tfsdk:"port"`
+	Target types.Int64  `tfsdk:"target"`
+	WWPN   types.String `tfsdk:"wwpn"`
+	WWPNB  types.String `tfsdk:"wwpn_b"`
+}
+
+// fcPortResponse is the JSON shape returned by fcport.* methods.
+type fcPortResponse struct {
+	ID     int64           `json:"id"`
+	Port   string          `json:"port"`
+	Target json.RawMessage `json:"target"`
+	WWPN   string          `json:"wwpn"`
+	WWPNB  string          `json:"wwpn_b"`
+}
+
+// targetID returns the mapped target ID. Depending on the release, fcport
+// reports the target either as a bare ID or as an object with an id field.
+func (p *fcPortResponse) targetID() (int64, error) {
+	var id int64
+	if err := json.Unmarshal(p.Target, &id); err == nil {
+		return id, nil
+	}
+
+	var target struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(p.Target, &target); err != nil {
+		return 0, fmt.Errorf("parse fcport target: %w", err)
+	}
+	return target.ID, nil
+}
+
+// fcPortAPIFieldPaths maps fcport validation errors to attributes.
+var fcPortAPIFieldPaths = apiFieldPaths("port", "target")
+
+// FCPortResource defines the resource implementation.
+type FCPortResource struct {
+	BaseResource
+}
+
+// NewFCPortResource creates a new FCPortResource.
+func NewFCPortResource() resource.Resource {
+	return &FCPortResource{}
+}
+
+func (r *FCPortResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fc_port"
+}
+
+func (r *FCPortResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Maps a Fibre Channel host port to a target on TrueNAS Enterprise (fcport). The target must " +
+			"use the FC or BOTH mode. Requires TrueNAS 25.04 or later on a system with licensed Fibre Channel hardware.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "FC port mapping ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"port": schema.StringAttribute{
+				Description: "FC host port to map (e.g., `fc0`, or `fc0/1` for an NPIV virtual port). " +
+					"See fcport.port_choices for the available ports.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target": schema.Int64Attribute{
+				Description: "ID of the target (iscsi.target) exposed on the port.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"wwpn": schema.StringAttribute{
+				Description: "World Wide Port Name of the port on controller A, for switch zoning.",
+				Computed:    true,
+			},
+			"wwpn_b": schema.StringAttribute{
+				Description: "World Wide Port Name of the port on controller B (HA systems only).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *FCPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FCPortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkFCSupported(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := map[string]any{
+		"port":   data.Port.ValueString(),
+		"target": data.Target.ValueInt64(),
+	}
+
+	result, err := r.client.Call(ctx, "fcport.create", params)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, fcPortAPIFieldPaths,
+			"Unable to Create FC Port",
+			fmt.Sprintf("Unable to map FC port %q to target %d: %s", data.Port.ValueString(), data.Target.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	var port fcPortResponse
+	if err := json.Unmarshal(result, &port); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse FC port create response: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(mapFCPortToModel(&port, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FCPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FCPortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid FC Port ID",
+			fmt.Sprintf("Cannot parse FC port ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	port, err := r.getPort(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read FC Port",
+			fmt.Sprintf("Unable to read FC port %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	if port == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(mapFCPortToModel(port, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+// Update is never called with changes: every configurable attribute requires replacement.
+func (r *FCPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FCPortResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FCPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FCPortResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid FC Port ID",
+			fmt.Sprintf("Cannot parse FC port ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if _, err := r.client.Call(ctx, "fcport.delete", id); err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete FC Port",
+			fmt.Sprintf("Unable to delete FC port %d: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+// getPort queries an FC port mapping by ID. Returns nil if it does not exist.
+func (r *FCPortResource) getPort(ctx context.Context, id int64) (*fcPortResponse, error) {
+	filter := []any{[]any{[]any{"id", "=", id}}}
+
+	result, err := r.client.Call(ctx, "fcport.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []fcPortResponse
+	if err := json.Unmarshal(result, &ports); err != nil {
+		return nil, fmt.Errorf("parse fcport query response: %w", err)
+	}
+
+	if len(ports) == 0 {
+		return nil, nil
+	}
+
+	return &ports[0], nil
+}
+
+// checkFCSupported rejects TrueNAS versions without the fcport API and systems
+// that are not Fibre Channel capable. An undetected version is allowed through
+// and left to fc.capable and the API to reject.
+func checkFCSupported(ctx context.Context, c client.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	version := c.Version()
+	if !version.IsZero() && !version.AtLeast(25, 4) {
+		diags.AddError(
+			"Unsupported TrueNAS Version",
+			fmt.Sprintf("Fibre Channel resources require TrueNAS 25.04 or later. Detected version: %s", version.String()),
+		)
+		return diags
+	}
+
+	result, err := c.Call(ctx, "fc.capable", nil)
+	if err != nil {
+		diags.AddError(
+			"Unable to Check Fibre Channel Support",
+			fmt.Sprintf("Unable to query fc.capable: %s", err.Error()),
+		)
+		return diags
+	}
+
+	var capable bool
+	if err := json.Unmarshal(result, &capable); err != nil {
+		diags.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse fc.capable response: %s", err.Error()),
+		)
+		return diags
+	}
+
+	if !capable {
+		diags.AddError(
+			"Fibre Channel Not Available",
+			"This system is not Fibre Channel capable. Fibre Channel targets require TrueNAS Enterprise "+
+				"with licensed Fibre Channel hardware.",
+		)
+	}
+
+	return diags
+}
+
+// mapFCPortToModel maps the API response to the resource model.
+func mapFCPortToModel(port *fcPortResponse, data *FCPortResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	target, err := port.targetID()
+	if err != nil {
+		diags.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse FC port %d target: %s", port.ID, err.Error()),
+		)
+		return diags
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(port.ID, 10))
+	data.Port = types.StringValue(port.Port)
+	data.Target = types.Int64Value(target)
+	data.WWPN = nonEmptyStringValue(port.WWPN)
+	data.WWPNB = nonEmptyStringValue(port.WWPNB)
+
+	return diags
+}
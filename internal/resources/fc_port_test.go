@@ -0,0 +1,373 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewFCPortResource(t *testing.T) {
+	r := NewFCPortResource()
+	if r == nil {
+		t.Fatal("NewFCPortResource returned nil")
+	}
+
+	_, ok := r.(*FCPortResource)
+	if !ok {
+		t.Fatalf("expected *FCPortResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*FCPortResource))
+	_ = resource.ResourceWithImportState(r.(*FCPortResource))
+}
+
+func TestFCPortResource_Metadata(t *testing.T) {
+	r := NewFCPortResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_fc_port" {
+		t.Errorf("expected TypeName 'truenas_fc_port', got %q", resp.TypeName)
+	}
+}
+
+func TestFCPortResource_Schema(t *testing.T) {
+	schemaResp := getFCPortResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	for _, name := range []string{"port", "target"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	for _, name := range []string{"id", "wwpn", "wwpn_b"} {
+		if !attrs[name].IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getFCPortResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewFCPortResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// fcPortModelParams holds parameters for creating test model values.
+type fcPortModelParams struct {
+	ID     interface{}
+	Port   interface{}
+	Target interface{}
+	WWPN   interface{}
+	WWPNB  interface{}
+}
+
+func createFCPortModelValue(p fcPortModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":     tftypes.String,
+			"port":   tftypes.String,
+			"target": tftypes.Number,
+			"wwpn":   tftypes.String,
+			"wwpn_b": tftypes.String,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, p.ID),
+		"port":   tftypes.NewValue(tftypes.String, p.Port),
+		"target": tftypes.NewValue(tftypes.Number, p.Target),
+		"wwpn":   tftypes.NewValue(tftypes.String, p.WWPN),
+		"wwpn_b": tftypes.NewValue(tftypes.String, p.WWPNB),
+	})
+}
+
+func defaultFCPortParams() fcPortModelParams {
+	return fcPortModelParams{
+		ID:     "2",
+		Port:   "fc0",
+		Target: float64(5),
+		WWPN:   "naa.210000109b123456",
+		WWPNB:  nil,
+	}
+}
+
+const testFCPortJSON = `{
+	"id": 2,
+	"port": "fc0",
+	"wwpn": "naa.210000109b123456",
+	"wwpn_b": null,
+	"target": {"id": 5, "iscsi_target_name": "fc-target"}
+}`
+
+func newFCPortTestClient(t *testing.T, version truenas.Version, capable bool, calls *[]string) *client.MockClient {
+	t.Helper()
+
+	return &client.MockClient{
+		VersionVal: version,
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			*calls = append(*calls, method)
+			switch method {
+			case "fc.capable":
+				if capable {
+					return json.RawMessage(`true`), nil
+				}
+				return json.RawMessage(`false`), nil
+			case "fcport.create":
+				p := params.(map[string]any)
+				if p["port"] != "fc0" || p["target"] != int64(5) {
+					t.Errorf("unexpected fcport.create params: %v", p)
+				}
+				return json.RawMessage(testFCPortJSON), nil
+			}
+			t.Errorf("unexpected method %q", method)
+			return nil, nil
+		},
+	}
+}
+
+func createFCPort(t *testing.T, r *FCPortResource) *resource.CreateResponse {
+	t.Helper()
+
+	schemaResp := getFCPortResourceSchema(t)
+	p := defaultFCPortParams()
+	p.ID = tftypes.UnknownValue
+	p.WWPN = tftypes.UnknownValue
+	p.WWPNB = tftypes.UnknownValue
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createFCPortModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+	return resp
+}
+
+func TestFCPortResource_Create_Success(t *testing.T) {
+	var calls []string
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: newFCPortTestClient(t, truenas.Version{Major: 25, Minor: 4}, true, &calls)},
+	}
+
+	resp := createFCPort(t, r)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(calls) != 2 || calls[0] != "fc.capable" || calls[1] != "fcport.create" {
+		t.Errorf("expected capability check then create, got %v", calls)
+	}
+
+	var model FCPortResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "2" {
+		t.Errorf("expected ID '2', got %q", model.ID.ValueString())
+	}
+	if model.Target.ValueInt64() != 5 {
+		t.Errorf("expected target 5, got %d", model.Target.ValueInt64())
+	}
+	if model.WWPN.ValueString() != "naa.210000109b123456" {
+		t.Errorf("expected wwpn 'naa.210000109b123456', got %q", model.WWPN.ValueString())
+	}
+	if !model.WWPNB.IsNull() {
+		t.Errorf("expected null wwpn_b, got %v", model.WWPNB)
+	}
+}
+
+func TestFCPortResource_Create_UnsupportedVersion(t *testing.T) {
+	var calls []string
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: newFCPortTestClient(t, truenas.Version{Major: 24, Minor: 10}, true, &calls)},
+	}
+
+	resp := createFCPort(t, r)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for TrueNAS 24.10")
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Summary(), "Unsupported TrueNAS Version") {
+		t.Errorf("expected version error, got %v", resp.Diagnostics)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected no API calls, got %v", calls)
+	}
+}
+
+func TestFCPortResource_Create_NotCapable(t *testing.T) {
+	var calls []string
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: newFCPortTestClient(t, truenas.Version{Major: 25, Minor: 4}, false, &calls)},
+	}
+
+	resp := createFCPort(t, r)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a system without Fibre Channel")
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Fibre Channel Not Available" {
+		t.Errorf("expected capability error, got %v", resp.Diagnostics)
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected only the capability check, got %v", calls)
+	}
+}
+
+func TestFCPortResource_Read_BareTargetID(t *testing.T) {
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "fcport.query" {
+					t.Errorf("expected method 'fcport.query', got %q", method)
+				}
+				return json.RawMessage(`[{"id": 2, "port": "fc0/1", "wwpn": "naa.1", "wwpn_b": "naa.2", "target": 7}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getFCPortResourceSchema(t)
+	stateValue := createFCPortModelValue(defaultFCPortParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model FCPortResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Port.ValueString() != "fc0/1" || model.Target.ValueInt64() != 7 {
+		t.Errorf("expected port fc0/1 on target 7, got %q on %d", model.Port.ValueString(), model.Target.ValueInt64())
+	}
+	if model.WWPNB.ValueString() != "naa.2" {
+		t.Errorf("expected wwpn_b 'naa.2', got %q", model.WWPNB.ValueString())
+	}
+}
+
+func TestFCPortResource_Read_NotFound(t *testing.T) {
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getFCPortResourceSchema(t)
+	stateValue := createFCPortModelValue(defaultFCPortParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed")
+	}
+}
+
+func TestFCPortResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params
+				return json.RawMessage(`true`), nil
+			},
+		}},
+	}
+
+	schemaResp := getFCPortResourceSchema(t)
+	stateValue := createFCPortModelValue(defaultFCPortParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "fcport.delete" {
+		t.Errorf("expected method 'fcport.delete', got %q", capturedMethod)
+	}
+	if capturedParams != int64(2) {
+		t.Errorf("expected port ID 2, got %v", capturedParams)
+	}
+}
+
+func TestFCPortResource_Delete_APIError(t *testing.T) {
+	r := &FCPortResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getFCPortResourceSchema(t)
+	stateValue := createFCPortModelValue(defaultFCPortParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
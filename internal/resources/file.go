@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
 	truenas "github.com/deevus/truenas-go"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -30,16 +31,16 @@ type FileResource struct {
 
 // FileResourceModel describes the resource data model.
 type FileResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	HostPath     types.String `tfsdk:"host_path"`
-	RelativePath types.String `tfsdk:"relative_path"`
-	Path         types.String `tfsdk:"path"`
-	Content      types.String `tfsdk:"content"`
-	Mode         types.String `tfsdk:"mode"`
-	UID          types.Int64  `tfsdk:"uid"`
-	GID          types.Int64  `tfsdk:"gid"`
-	Checksum     types.String `tfsdk:"checksum"`
-	ForceDestroy types.Bool   `tfsdk:"force_destroy"`
+	ID           types.String                    `tfsdk:"id"`
+	HostPath     types.String                    `tfsdk:"host_path"`
+	RelativePath types.String                    `tfsdk:"relative_path"`
+	Path         customtypes.FilesystemPathValue `tfsdk:"path"`
+	Content      types.String                    `tfsdk:"content"`
+	Mode         types.String                    `tfsdk:"mode"`
+	UID          types.Int64                     `tfsdk:"uid"`
+	GID          types.Int64                     `tfsdk:"gid"`
+	Checksum     types.String                    `tfsdk:"checksum"`
+	ForceDestroy types.Bool                      `tfsdk:"force_destroy"`
 }
 
 // NewFileResource creates a new FileResource.
@@ -71,6 +72,7 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 			},
 			"path": schema.StringAttribute{
+				CustomType:  customtypes.FilesystemPathType{},
 				Description: "Absolute path to the file. Mutually exclusive with 'host_path'/'relative_path'.",
 				Optional:    true,
 				Computed:    true,
@@ -276,7 +278,7 @@ func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Set computed values
 	data.ID = types.StringValue(fullPath)
-	data.Path = types.StringValue(fullPath)
+	data.Path = customtypes.NewFilesystemPathValue(fullPath)
 	data.Checksum = types.StringValue(computeChecksum(content))
 
 	// Set defaults for mode/uid/gid if not specified
@@ -336,10 +338,11 @@ func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Update computed values to reflect actual remote state
 	// This also ensures path is set after import (where only ID is populated)
-	data.Path = types.StringValue(fullPath)
+	data.Path = customtypes.NewFilesystemPathValue(fullPath)
 	data.Checksum = types.StringValue(computeChecksum(string(content)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -375,7 +378,7 @@ func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Update computed values - explicitly set for consistency with Create
 	data.ID = types.StringValue(fullPath)
-	data.Path = types.StringValue(fullPath)
+	data.Path = customtypes.NewFilesystemPathValue(fullPath)
 	data.Checksum = types.StringValue(computeChecksum(content))
 
 	// Set defaults for mode/uid/gid if not specified (same as Create)
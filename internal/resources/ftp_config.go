@@ -0,0 +1,558 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &FTPConfigResource{}
+	_ resource.ResourceWithConfigure   = &FTPConfigResource{}
+	_ resource.ResourceWithImportState = &FTPConfigResource{}
+)
+
+// FTPConfigResourceModel describes the resource data model.
+type FTPConfigResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	Port                   types.Int64  `tfsdk:"port"`
+	Clients                types.Int64  `tfsdk:"clients"`
+	IPConnections          types.Int64  `tfsdk:"ipconnections"`
+	LoginAttempt           types.Int64  `tfsdk:"loginattempt"`
+	Timeout                types.Int64  `tfsdk:"timeout"`
+	TimeoutNoTransfer      types.Int64  `tfsdk:"timeout_notransfer"`
+	OnlyAnonymous          types.Bool   `tfsdk:"onlyanonymous"`
+	AnonPath               types.String `tfsdk:"anonpath"`
+	OnlyLocal              types.Bool   `tfsdk:"onlylocal"`
+	Banner                 types.String `tfsdk:"banner"`
+	FileMask               types.String `tfsdk:"filemask"`
+	DirMask                types.String `tfsdk:"dirmask"`
+	FXP                    types.Bool   `tfsdk:"fxp"`
+	Resume                 types.Bool   `tfsdk:"resume"`
+	DefaultRoot            types.Bool   `tfsdk:"defaultroot"`
+	Ident                  types.Bool   `tfsdk:"ident"`
+	ReverseDNS             types.Bool   `tfsdk:"reversedns"`
+	MasqAddress            types.String `tfsdk:"masqaddress"`
+	PassivePortsMin        types.Int64  `tfsdk:"passiveportsmin"`
+	PassivePortsMax        types.Int64  `tfsdk:"passiveportsmax"`
+	LocalUserBW            types.Int64  `tfsdk:"localuserbw"`
+	LocalUserDownBandwidth types.Int64  `tfsdk:"localuserdownbandwidth"`
+	AnonUserBW             types.Int64  `tfsdk:"anonuserbw"`
+	AnonUserDownBandwidth  types.Int64  `tfsdk:"anonuserdownbandwidth"`
+	TLS                    types.Bool   `tfsdk:"tls"`
+	TLSPolicy              types.String `tfsdk:"tls_policy"`
+	SSLTLSCertificate      types.Int64  `tfsdk:"ssltls_certificate"`
+	Options                types.String `tfsdk:"options"`
+}
+
+// ftpConfigResponse is the JSON shape returned by ftp.config and ftp.update.
+type ftpConfigResponse struct {
+	ID                     int64   `json:"id"`
+	Port                   int64   `json:"port"`
+	Clients                int64   `json:"clients"`
+	IPConnections          int64   `json:"ipconnections"`
+	LoginAttempt           int64   `json:"loginattempt"`
+	Timeout                int64   `json:"timeout"`
+	TimeoutNoTransfer      int64   `json:"timeout_notransfer"`
+	OnlyAnonymous          bool    `json:"onlyanonymous"`
+	AnonPath               *string `json:"anonpath"`
+	OnlyLocal              bool    `json:"onlylocal"`
+	Banner                 string  `json:"banner"`
+	FileMask               string  `json:"filemask"`
+	DirMask                string  `json:"dirmask"`
+	FXP                    bool    `json:"fxp"`
+	Resume                 bool    `json:"resume"`
+	DefaultRoot            bool    `json:"defaultroot"`
+	Ident                  bool    `json:"ident"`
+	ReverseDNS             bool    `json:"reversedns"`
+	MasqAddress            string  `json:"masqaddress"`
+	PassivePortsMin        int64   `json:"passiveportsmin"`
+	PassivePortsMax        int64   `json:"passiveportsmax"`
+	LocalUserBW            int64   `json:"localuserbw"`
+	LocalUserDownBandwidth int64   `json:"localuserdownbandwidth"`
+	AnonUserBW             int64   `json:"anonuserbw"`
+	AnonUserDownBandwidth  int64   `json:"anonuserdownbandwidth"`
+	TLS                    bool    `json:"tls"`
+	TLSPolicy              string  `json:"tls_policy"`
+	SSLTLSCertificate      *int64  `json:"ssltls_certificate"`
+	Options                string  `json:"options"`
+}
+
+// FTPConfigResource defines the resource implementation.
+type FTPConfigResource struct {
+	BaseResource
+}
+
+// NewFTPConfigResource creates a new FTPConfigResource.
+func NewFTPConfigResource() resource.Resource {
+	return &FTPConfigResource{}
+}
+
+func (r *FTPConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ftp_config"
+}
+
+func (r *FTPConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the global FTP service configuration on TrueNAS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'ftp_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description: "Port the FTP service listens on. Defaults to 21.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(21),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"clients": schema.Int64Attribute{
+				Description: "Maximum number of simultaneous clients. Defaults to 5.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(5),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 10000),
+				},
+			},
+			"ipconnections": schema.Int64Attribute{
+				Description: "Maximum number of connections per IP address (0 for unlimited). Defaults to 2.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(2),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+			},
+			"loginattempt": schema.Int64Attribute{
+				Description: "Maximum number of login attempts before disconnecting. Defaults to 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "Idle timeout in seconds. Defaults to 600.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(600),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10000),
+				},
+			},
+			"timeout_notransfer": schema.Int64Attribute{
+				Description: "Seconds a client may stay connected without transferring data. Defaults to 300.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10000),
+				},
+			},
+			"onlyanonymous": schema.BoolAttribute{
+				Description: "Allow anonymous logins only. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"anonpath": schema.StringAttribute{
+				Description: "Root directory for anonymous users. Required when onlyanonymous is true.",
+				Optional:    true,
+			},
+			"onlylocal": schema.BoolAttribute{
+				Description: "Allow local user logins only. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"banner": schema.StringAttribute{
+				Description: "Message shown to clients when they connect.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"filemask": schema.StringAttribute{
+				Description: "umask applied to newly created files. Defaults to '077'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("077"),
+			},
+			"dirmask": schema.StringAttribute{
+				Description: "umask applied to newly created directories. Defaults to '022'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("022"),
+			},
+			"fxp": schema.BoolAttribute{
+				Description: "Enable File eXchange Protocol (server-to-server transfers). Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"resume": schema.BoolAttribute{
+				Description: "Allow clients to resume interrupted transfers. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"defaultroot": schema.BoolAttribute{
+				Description: "Chroot local users to their home directory. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"ident": schema.BoolAttribute{
+				Description: "Perform ident (RFC 1413) lookups on connecting clients. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"reversedns": schema.BoolAttribute{
+				Description: "Perform reverse DNS lookups on connecting clients. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"masqaddress": schema.StringAttribute{
+				Description: "Public IP address or hostname advertised for passive connections behind NAT.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"passiveportsmin": schema.Int64Attribute{
+				Description: "Lowest port used for passive connections (0 or 1024-65535, 0 lets the server choose). Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(0),
+						int64validator.Between(1024, 65535),
+					),
+				},
+			},
+			"passiveportsmax": schema.Int64Attribute{
+				Description: "Highest port used for passive connections (0 or 1024-65535, 0 lets the server choose). Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(0),
+						int64validator.Between(1024, 65535),
+					),
+				},
+			},
+			"localuserbw": schema.Int64Attribute{
+				Description: "Upload bandwidth limit for local users in KiB/s (0 for unlimited). Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"localuserdownbandwidth": schema.Int64Attribute{
+				Description: "Download bandwidth limit for local users in KiB/s (0 for unlimited). Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"anonuserbw": schema.Int64Attribute{
+				Description: "Upload bandwidth limit for anonymous users in KiB/s (0 for unlimited). Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"anonuserdownbandwidth": schema.Int64Attribute{
+				Description: "Download bandwidth limit for anonymous users in KiB/s (0 for unlimited). Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"tls": schema.BoolAttribute{
+				Description: "Enable TLS (FTPS). Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"tls_policy": schema.StringAttribute{
+				Description: "TLS policy: on, off, data, !data, auth, ctrl, ctrl+data, ctrl+!data, auth+data or auth+!data. Defaults to 'on'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("on"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("on", "off", "data", "!data", "auth", "ctrl", "ctrl+data", "ctrl+!data", "auth+data", "auth+!data"),
+				},
+			},
+			"ssltls_certificate": schema.Int64Attribute{
+				Description: "ID of the certificate used for TLS connections.",
+				Optional:    true,
+			},
+			"options": schema.StringAttribute{
+				Description: "Auxiliary parameters appended to proftpd.conf.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+func (r *FTPConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FTPConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildFTPConfigParams(&data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update FTP Config",
+			fmt.Sprintf("Unable to update FTP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapFTPConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FTPConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FTPConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "ftp.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read FTP Config",
+			fmt.Sprintf("Unable to read FTP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config ftpConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse FTP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapFTPConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *FTPConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan FTPConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildFTPConfigParams(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update FTP Config",
+			fmt.Sprintf("Unable to update FTP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapFTPConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FTPConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := map[string]any{
+		"port":                   21,
+		"clients":                5,
+		"ipconnections":          2,
+		"loginattempt":           1,
+		"timeout":                600,
+		"timeout_notransfer":     300,
+		"onlyanonymous":          false,
+		"anonpath":               nil,
+		"onlylocal":              false,
+		"banner":                 "",
+		"filemask":               "077",
+		"dirmask":                "022",
+		"fxp":                    false,
+		"resume":                 false,
+		"defaultroot":            false,
+		"ident":                  false,
+		"reversedns":             false,
+		"masqaddress":            "",
+		"passiveportsmin":        0,
+		"passiveportsmax":        0,
+		"localuserbw":            0,
+		"localuserdownbandwidth": 0,
+		"anonuserbw":             0,
+		"anonuserdownbandwidth":  0,
+		"tls":                    false,
+		"tls_policy":             "on",
+		"ssltls_certificate":     nil,
+		"options":                "",
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset FTP Config",
+			fmt.Sprintf("Unable to reset FTP configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *FTPConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "ftp_config"
+	if req.ID != "ftp_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'ftp_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateConfig calls ftp.update and parses the response.
+func (r *FTPConfigResource) updateConfig(ctx context.Context, params map[string]any) (*ftpConfigResponse, error) {
+	result, err := r.client.Call(ctx, "ftp.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ftpConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse ftp update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildFTPConfigParams builds the ftp.update params from the resource model.
+func buildFTPConfigParams(data *FTPConfigResourceModel) map[string]any {
+	params := map[string]any{
+		"port":                   data.Port.ValueInt64(),
+		"clients":                data.Clients.ValueInt64(),
+		"ipconnections":          data.IPConnections.ValueInt64(),
+		"loginattempt":           data.LoginAttempt.ValueInt64(),
+		"timeout":                data.Timeout.ValueInt64(),
+		"timeout_notransfer":     data.TimeoutNoTransfer.ValueInt64(),
+		"onlyanonymous":          data.OnlyAnonymous.ValueBool(),
+		"onlylocal":              data.OnlyLocal.ValueBool(),
+		"banner":                 data.Banner.ValueString(),
+		"filemask":               data.FileMask.ValueString(),
+		"dirmask":                data.DirMask.ValueString(),
+		"fxp":                    data.FXP.ValueBool(),
+		"resume":                 data.Resume.ValueBool(),
+		"defaultroot":            data.DefaultRoot.ValueBool(),
+		"ident":                  data.Ident.ValueBool(),
+		"reversedns":             data.ReverseDNS.ValueBool(),
+		"masqaddress":            data.MasqAddress.ValueString(),
+		"passiveportsmin":        data.PassivePortsMin.ValueInt64(),
+		"passiveportsmax":        data.PassivePortsMax.ValueInt64(),
+		"localuserbw":            data.LocalUserBW.ValueInt64(),
+		"localuserdownbandwidth": data.LocalUserDownBandwidth.ValueInt64(),
+		"anonuserbw":             data.AnonUserBW.ValueInt64(),
+		"anonuserdownbandwidth":  data.AnonUserDownBandwidth.ValueInt64(),
+		"tls":                    data.TLS.ValueBool(),
+		"tls_policy":             data.TLSPolicy.ValueString(),
+		"options":                data.Options.ValueString(),
+	}
+
+	if !data.AnonPath.IsNull() && !data.AnonPath.IsUnknown() {
+		params["anonpath"] = data.AnonPath.ValueString()
+	} else {
+		params["anonpath"] = nil
+	}
+	if !data.SSLTLSCertificate.IsNull() && !data.SSLTLSCertificate.IsUnknown() {
+		params["ssltls_certificate"] = data.SSLTLSCertificate.ValueInt64()
+	} else {
+		params["ssltls_certificate"] = nil
+	}
+
+	return params
+}
+
+// mapFTPConfigToModel maps the API response to the resource model.
+func mapFTPConfigToModel(config *ftpConfigResponse, data *FTPConfigResourceModel) {
+	data.ID = types.StringValue("ftp_config")
+	data.Port = types.Int64Value(config.Port)
+	data.Clients = types.Int64Value(config.Clients)
+	data.IPConnections = types.Int64Value(config.IPConnections)
+	data.LoginAttempt = types.Int64Value(config.LoginAttempt)
+	data.Timeout = types.Int64Value(config.Timeout)
+	data.TimeoutNoTransfer = types.Int64Value(config.TimeoutNoTransfer)
+	data.OnlyAnonymous = types.BoolValue(config.OnlyAnonymous)
+	if config.AnonPath != nil {
+		data.AnonPath = nonEmptyStringValue(*config.AnonPath)
+	} else {
+		data.AnonPath = types.StringNull()
+	}
+	data.OnlyLocal = types.BoolValue(config.OnlyLocal)
+	data.Banner = types.StringValue(config.Banner)
+	data.FileMask = types.StringValue(config.FileMask)
+	data.DirMask = types.StringValue(config.DirMask)
+	data.FXP = types.BoolValue(config.FXP)
+	data.Resume = types.BoolValue(config.Resume)
+	data.DefaultRoot = types.BoolValue(config.DefaultRoot)
+	data.Ident = types.BoolValue(config.Ident)
+	data.ReverseDNS = types.BoolValue(config.ReverseDNS)
+	data.MasqAddress = types.StringValue(config.MasqAddress)
+	data.PassivePortsMin = types.Int64Value(config.PassivePortsMin)
+	data.PassivePortsMax = types.Int64Value(config.PassivePortsMax)
+	data.LocalUserBW = types.Int64Value(config.LocalUserBW)
+	data.LocalUserDownBandwidth = types.Int64Value(config.LocalUserDownBandwidth)
+	data.AnonUserBW = types.Int64Value(config.AnonUserBW)
+	data.AnonUserDownBandwidth = types.Int64Value(config.AnonUserDownBandwidth)
+	data.TLS = types.BoolValue(config.TLS)
+	data.TLSPolicy = types.StringValue(config.TLSPolicy)
+	data.SSLTLSCertificate = nilableInt64Value(config.SSLTLSCertificate)
+	data.Options = types.StringValue(config.Options)
+}
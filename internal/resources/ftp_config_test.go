@@ -0,0 +1,639 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewFTPConfigResource(t *testing.T) {
+	r := NewFTPConfigResource()
+	if r == nil {
+		t.Fatal("NewFTPConfigResource returned nil")
+	}
+
+	_, ok := r.(*FTPConfigResource)
+	if !ok {
+		t.Fatalf("expected *FTPConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*FTPConfigResource))
+	_ = resource.ResourceWithImportState(r.(*FTPConfigResource))
+}
+
+func TestFTPConfigResource_Metadata(t *testing.T) {
+	r := NewFTPConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_ftp_config" {
+		t.Errorf("expected TypeName 'truenas_ftp_config', got %q", resp.TypeName)
+	}
+}
+
+func TestFTPConfigResource_Schema(t *testing.T) {
+	schemaResp := getFTPConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"port", "passiveportsmin", "passiveportsmax", "tls", "tls_policy", "ssltls_certificate", "onlyanonymous", "anonpath", "anonuserbw", "anonuserdownbandwidth"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getFTPConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewFTPConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// ftpConfigModelParams holds parameters for creating test model values.
+type ftpConfigModelParams struct {
+	ID                     interface{}
+	Port                   interface{}
+	Clients                interface{}
+	IPConnections          interface{}
+	LoginAttempt           interface{}
+	Timeout                interface{}
+	TimeoutNoTransfer      interface{}
+	OnlyAnonymous          interface{}
+	AnonPath               interface{}
+	OnlyLocal              interface{}
+	Banner                 interface{}
+	FileMask               interface{}
+	DirMask                interface{}
+	FXP                    interface{}
+	Resume                 interface{}
+	DefaultRoot            interface{}
+	Ident                  interface{}
+	ReverseDNS             interface{}
+	MasqAddress            interface{}
+	PassivePortsMin        interface{}
+	PassivePortsMax        interface{}
+	LocalUserBW            interface{}
+	LocalUserDownBandwidth interface{}
+	AnonUserBW             interface{}
+	AnonUserDownBandwidth  interface{}
+	TLS                    interface{}
+	TLSPolicy              interface{}
+	SSLTLSCertificate      interface{}
+	Options                interface{}
+}
+
+func createFTPConfigModelValue(p ftpConfigModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                     tftypes.String,
+			"port":                   tftypes.Number,
+			"clients":                tftypes.Number,
+			"ipconnections":          tftypes.Number,
+			"loginattempt":           tftypes.Number,
+			"timeout":                tftypes.Number,
+			"timeout_notransfer":     tftypes.Number,
+			"onlyanonymous":          tftypes.Bool,
+			"anonpath":               tftypes.String,
+			"onlylocal":              tftypes.Bool,
+			"banner":                 tftypes.String,
+			"filemask":               tftypes.String,
+			"dirmask":                tftypes.String,
+			"fxp":                    tftypes.Bool,
+			"resume":                 tftypes.Bool,
+			"defaultroot":            tftypes.Bool,
+			"ident":                  tftypes.Bool,
+			"reversedns":             tftypes.Bool,
+			"masqaddress":            tftypes.String,
+			"passiveportsmin":        tftypes.Number,
+			"passiveportsmax":        tftypes.Number,
+			"localuserbw":            tftypes.Number,
+			"localuserdownbandwidth": tftypes.Number,
+			"anonuserbw":             tftypes.Number,
+			"anonuserdownbandwidth":  tftypes.Number,
+			"tls":                    tftypes.Bool,
+			"tls_policy":             tftypes.String,
+			"ssltls_certificate":     tftypes.Number,
+			"options":                tftypes.String,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, p.ID),
+		"port":                   tftypes.NewValue(tftypes.Number, p.Port),
+		"clients":                tftypes.NewValue(tftypes.Number, p.Clients),
+		"ipconnections":          tftypes.NewValue(tftypes.Number, p.IPConnections),
+		"loginattempt":           tftypes.NewValue(tftypes.Number, p.LoginAttempt),
+		"timeout":                tftypes.NewValue(tftypes.Number, p.Timeout),
+		"timeout_notransfer":     tftypes.NewValue(tftypes.Number, p.TimeoutNoTransfer),
+		"onlyanonymous":          tftypes.NewValue(tftypes.Bool, p.OnlyAnonymous),
+		"anonpath":               tftypes.NewValue(tftypes.String, p.AnonPath),
+		"onlylocal":              tftypes.NewValue(tftypes.Bool, p.OnlyLocal),
+		"banner":                 tftypes.NewValue(tftypes.String, p.Banner),
+		"filemask":               tftypes.NewValue(tftypes.String, p.FileMask),
+		"dirmask":                tftypes.NewValue(tftypes.String, p.DirMask),
+		"fxp":                    tftypes.NewValue(tftypes.Bool, p.FXP),
+		"resume":                 tftypes.NewValue(tftypes.Bool, p.Resume),
+		"defaultroot":            tftypes.NewValue(tftypes.Bool, p.DefaultRoot),
+		"ident":                  tftypes.NewValue(tftypes.Bool, p.Ident),
+		"reversedns":             tftypes.NewValue(tftypes.Bool, p.ReverseDNS),
+		"masqaddress":            tftypes.NewValue(tftypes.String, p.MasqAddress),
+		"passiveportsmin":        tftypes.NewValue(tftypes.Number, p.PassivePortsMin),
+		"passiveportsmax":        tftypes.NewValue(tftypes.Number, p.PassivePortsMax),
+		"localuserbw":            tftypes.NewValue(tftypes.Number, p.LocalUserBW),
+		"localuserdownbandwidth": tftypes.NewValue(tftypes.Number, p.LocalUserDownBandwidth),
+		"anonuserbw":             tftypes.NewValue(tftypes.Number, p.AnonUserBW),
+		"anonuserdownbandwidth":  tftypes.NewValue(tftypes.Number, p.AnonUserDownBandwidth),
+		"tls":                    tftypes.NewValue(tftypes.Bool, p.TLS),
+		"tls_policy":             tftypes.NewValue(tftypes.String, p.TLSPolicy),
+		"ssltls_certificate":     tftypes.NewValue(tftypes.Number, p.SSLTLSCertificate),
+		"options":                tftypes.NewValue(tftypes.String, p.Options),
+	})
+}
+
+func defaultFTPConfigParams() ftpConfigModelParams {
+	return ftpConfigModelParams{
+		Port:                   float64(2121),
+		Clients:                float64(32),
+		IPConnections:          float64(4),
+		LoginAttempt:           float64(3),
+		Timeout:                float64(600),
+		TimeoutNoTransfer:      float64(300),
+		OnlyAnonymous:          false,
+		AnonPath:               nil,
+		OnlyLocal:              true,
+		Banner:                 "Welcome",
+		FileMask:               "077",
+		DirMask:                "022",
+		FXP:                    false,
+		Resume:                 true,
+		DefaultRoot:            true,
+		Ident:                  false,
+		ReverseDNS:             false,
+		MasqAddress:            "ftp.example.com",
+		PassivePortsMin:        float64(49152),
+		PassivePortsMax:        float64(49200),
+		LocalUserBW:            float64(0),
+		LocalUserDownBandwidth: float64(0),
+		AnonUserBW:             float64(0),
+		AnonUserDownBandwidth:  float64(0),
+		TLS:                    true,
+		TLSPolicy:              "ctrl+data",
+		SSLTLSCertificate:      float64(1),
+		Options:                "",
+	}
+}
+
+const testFTPConfigJSON = `{
+	"id": 1,
+	"port": 2121,
+	"clients": 32,
+	"ipconnections": 4,
+	"loginattempt": 3,
+	"timeout": 600,
+	"timeout_notransfer": 300,
+	"onlyanonymous": false,
+	"anonpath": null,
+	"onlylocal": true,
+	"banner": "Welcome",
+	"filemask": "077",
+	"dirmask": "022",
+	"fxp": false,
+	"resume": true,
+	"defaultroot": true,
+	"ident": false,
+	"reversedns": false,
+	"masqaddress": "ftp.example.com",
+	"passiveportsmin": 49152,
+	"passiveportsmax": 49200,
+	"localuserbw": 0,
+	"localuserdownbandwidth": 0,
+	"anonuserbw": 0,
+	"anonuserdownbandwidth": 0,
+	"tls": true,
+	"tls_policy": "ctrl+data",
+	"ssltls_certificate": 1,
+	"options": ""
+}`
+
+func TestFTPConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testFTPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	planValue := createFTPConfigModelValue(defaultFTPConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "ftp.update" {
+		t.Errorf("expected method 'ftp.update', got %q", capturedMethod)
+	}
+	if capturedParams["port"] != int64(2121) {
+		t.Errorf("expected port 2121, got %v", capturedParams["port"])
+	}
+	if capturedParams["passiveportsmin"] != int64(49152) || capturedParams["passiveportsmax"] != int64(49200) {
+		t.Errorf("expected passive range 49152-49200, got %v-%v", capturedParams["passiveportsmin"], capturedParams["passiveportsmax"])
+	}
+	if capturedParams["tls_policy"] != "ctrl+data" {
+		t.Errorf("expected tls_policy 'ctrl+data', got %v", capturedParams["tls_policy"])
+	}
+	if capturedParams["ssltls_certificate"] != int64(1) {
+		t.Errorf("expected ssltls_certificate 1, got %v", capturedParams["ssltls_certificate"])
+	}
+	if v, ok := capturedParams["anonpath"]; !ok || v != nil {
+		t.Errorf("expected anonpath to be sent as null, got %v", v)
+	}
+
+	var model FTPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "ftp_config" {
+		t.Errorf("expected ID 'ftp_config', got %q", model.ID.ValueString())
+	}
+	if !model.AnonPath.IsNull() {
+		t.Errorf("expected anonpath to be null, got %q", model.AnonPath.ValueString())
+	}
+	if model.SSLTLSCertificate.ValueInt64() != 1 {
+		t.Errorf("expected ssltls_certificate 1, got %d", model.SSLTLSCertificate.ValueInt64())
+	}
+}
+
+func TestFTPConfigResource_Create_Anonymous(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{
+					"id": 1, "port": 21, "onlyanonymous": true, "anonpath": "/mnt/tank/public",
+					"anonuserbw": 1024, "tls_policy": "on", "ssltls_certificate": null
+				}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	p := defaultFTPConfigParams()
+	p.OnlyAnonymous = true
+	p.OnlyLocal = false
+	p.AnonPath = "/mnt/tank/public"
+	p.AnonUserBW = float64(1024)
+	p.TLS = false
+	p.SSLTLSCertificate = nil
+	planValue := createFTPConfigModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["anonpath"] != "/mnt/tank/public" {
+		t.Errorf("expected anonpath '/mnt/tank/public', got %v", capturedParams["anonpath"])
+	}
+	if capturedParams["anonuserbw"] != int64(1024) {
+		t.Errorf("expected anonuserbw 1024, got %v", capturedParams["anonuserbw"])
+	}
+	if v, ok := capturedParams["ssltls_certificate"]; !ok || v != nil {
+		t.Errorf("expected ssltls_certificate to be sent as null, got %v", v)
+	}
+
+	var model FTPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.AnonPath.ValueString() != "/mnt/tank/public" {
+		t.Errorf("expected anonpath '/mnt/tank/public', got %q", model.AnonPath.ValueString())
+	}
+	if !model.SSLTLSCertificate.IsNull() {
+		t.Errorf("expected ssltls_certificate to be null, got %d", model.SSLTLSCertificate.ValueInt64())
+	}
+}
+
+func TestFTPConfigResource_Create_APIError(t *testing.T) {
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	planValue := createFTPConfigModelValue(defaultFTPConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestFTPConfigResource_Read_Success(t *testing.T) {
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "ftp.config" {
+					t.Errorf("expected method 'ftp.config', got %q", method)
+				}
+				return json.RawMessage(testFTPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	p := defaultFTPConfigParams()
+	p.ID = "ftp_config"
+	p.Port = float64(21)
+	stateValue := createFTPConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model FTPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Port.ValueInt64() != 2121 {
+		t.Errorf("expected port 2121, got %d", model.Port.ValueInt64())
+	}
+	if model.MasqAddress.ValueString() != "ftp.example.com" {
+		t.Errorf("expected masqaddress 'ftp.example.com', got %q", model.MasqAddress.ValueString())
+	}
+}
+
+func TestFTPConfigResource_Read_APIError(t *testing.T) {
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	p := defaultFTPConfigParams()
+	p.ID = "ftp_config"
+	stateValue := createFTPConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestFTPConfigResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testFTPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	state := defaultFTPConfigParams()
+	state.ID = "ftp_config"
+	state.TLSPolicy = "on"
+	plan := defaultFTPConfigParams()
+	plan.ID = "ftp_config"
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createFTPConfigModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createFTPConfigModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["tls_policy"] != "ctrl+data" {
+		t.Errorf("expected tls_policy 'ctrl+data', got %v", capturedParams["tls_policy"])
+	}
+}
+
+func TestFTPConfigResource_Update_APIError(t *testing.T) {
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	p := defaultFTPConfigParams()
+	p.ID = "ftp_config"
+	value := createFTPConfigModelValue(p)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestFTPConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	p := defaultFTPConfigParams()
+	p.ID = "ftp_config"
+	stateValue := createFTPConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "ftp.update" {
+		t.Errorf("expected method 'ftp.update', got %q", capturedMethod)
+	}
+	if capturedParams["port"] != 21 {
+		t.Errorf("expected port reset to 21, got %v", capturedParams["port"])
+	}
+	if capturedParams["tls"] != false {
+		t.Errorf("expected tls reset to false, got %v", capturedParams["tls"])
+	}
+}
+
+func TestFTPConfigResource_Delete_APIError(t *testing.T) {
+	r := &FTPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getFTPConfigResourceSchema(t)
+	p := defaultFTPConfigParams()
+	p.ID = "ftp_config"
+	stateValue := createFTPConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestFTPConfigResource_ImportState(t *testing.T) {
+	r := NewFTPConfigResource().(*FTPConfigResource)
+	schemaResp := getFTPConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "ftp_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createFTPConfigModelValue(ftpConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestFTPConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewFTPConfigResource().(*FTPConfigResource)
+	schemaResp := getFTPConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createFTPConfigModelValue(ftpConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
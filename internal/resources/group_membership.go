@@ -0,0 +1,256 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &GroupMembershipResource{}
+	_ resource.ResourceWithConfigure   = &GroupMembershipResource{}
+	_ resource.ResourceWithImportState = &GroupMembershipResource{}
+)
+
+// GroupMembershipResourceModel describes the resource data model.
+type GroupMembershipResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	GroupID types.Int64  `tfsdk:"group_id"`
+	Users   types.Set    `tfsdk:"users"`
+}
+
+// groupMembershipResponse is the subset of group.query fields needed for membership.
+type groupMembershipResponse struct {
+	ID    int64   `json:"id"`
+	Group string  `json:"group"`
+	Users []int64 `json:"users"`
+}
+
+// GroupMembershipResource defines the resource implementation.
+type GroupMembershipResource struct {
+	BaseResource
+}
+
+// NewGroupMembershipResource creates a new GroupMembershipResource.
+func NewGroupMembershipResource() resource.Resource {
+	return &GroupMembershipResource{}
+}
+
+func (r *GroupMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_membership"
+}
+
+func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the members of a local TrueNAS group independently of the group definition. " +
+			"Membership is authoritative: users not listed are removed from the group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (the group ID).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.Int64Attribute{
+				Description: "ID of the local group (the `id` field from group.query, not the gid).",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"users": schema.SetAttribute{
+				Description: "IDs of the users that are members of the group (the `id` field from user.query, not the uid).",
+				Required:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+func (r *GroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupID := data.GroupID.ValueInt64()
+	users := usersFromGroupMembershipModel(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setMembers(ctx, groupID, users); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Group Membership",
+			fmt.Sprintf("Unable to set members of group %d: %s", groupID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(groupID, 10))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupID := data.GroupID.ValueInt64()
+	group, err := r.getGroup(ctx, groupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Group",
+			fmt.Sprintf("Unable to read group %d: %s", groupID, err.Error()),
+		)
+		return
+	}
+
+	if group == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(mapGroupMembershipToModel(group, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupID := data.GroupID.ValueInt64()
+	users := usersFromGroupMembershipModel(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setMembers(ctx, groupID, users); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Group Membership",
+			fmt.Sprintf("Unable to set members of group %d: %s", groupID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(groupID, 10))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupMembershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupID := data.GroupID.ValueInt64()
+	if err := r.setMembers(ctx, groupID, []int64{}); err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete Group Membership",
+			fmt.Sprintf("Unable to remove members of group %d: %s", groupID, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *GroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	groupID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a numeric group ID, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+}
+
+// usersFromGroupMembershipModel extracts the planned user IDs, appending any conversion errors to diags.
+func usersFromGroupMembershipModel(ctx context.Context, data *GroupMembershipResourceModel, diags *diag.Diagnostics) []int64 {
+	users := []int64{}
+	diags.Append(data.Users.ElementsAs(ctx, &users, false)...)
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+	return users
+}
+
+// setMembers replaces the member list of the group.
+func (r *GroupMembershipResource) setMembers(ctx context.Context, groupID int64, users []int64) error {
+	params := []any{groupID, map[string]any{"users": users}}
+	_, err := r.client.Call(ctx, "group.update", params)
+	return err
+}
+
+// getGroup queries a group by ID. Returns nil if the group does not exist.
+func (r *GroupMembershipResource) getGroup(ctx context.Context, groupID int64) (*groupMembershipResponse, error) {
+	filter := []any{[]any{[]any{"id", "=", groupID}}}
+
+	result, err := r.client.Call(ctx, "group.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []groupMembershipResponse
+	if err := json.Unmarshal(result, &groups); err != nil {
+		return nil, fmt.Errorf("parse group query response: %w", err)
+	}
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	return &groups[0], nil
+}
+
+// mapGroupMembershipToModel maps a group.query response to the resource model.
+func mapGroupMembershipToModel(group *groupMembershipResponse, data *GroupMembershipResourceModel) diag.Diagnostics {
+	elements := make([]attr.Value, 0, len(group.Users))
+	for _, id := range group.Users {
+		elements = append(elements, types.Int64Value(id))
+	}
+
+	users, diags := types.SetValue(types.Int64Type, elements)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(group.ID, 10))
+	data.GroupID = types.Int64Value(group.ID)
+	data.Users = users
+	return diags
+}
@@ -0,0 +1,47 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/acctest"
+)
+
+func TestAccGroupMembershipResource_basic(t *testing.T) {
+	acctest.PreCheck(t)
+	h := acctest.NewHarness(t, acctest.WithFixtures(acctest.Fixtures{
+		Collections: map[string][]map[string]any{
+			"user": {
+				{"id": 70, "uid": 3000, "username": "alice", "builtin": false},
+				{"id": 71, "uid": 3001, "username": "bob", "builtin": false},
+			},
+			"group": {
+				{"id": 80, "gid": 3000, "group": "media", "builtin": false, "users": []any{}},
+			},
+		},
+	}))
+	r := h.Resource("truenas_group_membership")
+
+	config := map[string]any{
+		"group_id": 80,
+		"users":    []any{70},
+	}
+	state := r.Apply(config)
+	if got := state.Get("id"); got != "80" {
+		t.Errorf("expected id '80', got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	config["users"] = []any{70, 71}
+	state = r.Apply(config)
+	if got := state.Get("users").([]any); len(got) != 2 {
+		t.Errorf("expected 2 members, got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	state = r.Import("80")
+	if got := state.Get("group_id"); got != int64(80) {
+		t.Errorf("expected imported group_id 80, got %v", got)
+	}
+
+	r.Destroy()
+}
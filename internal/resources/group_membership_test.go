@@ -0,0 +1,482 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewGroupMembershipResource(t *testing.T) {
+	r := NewGroupMembershipResource()
+	if r == nil {
+		t.Fatal("NewGroupMembershipResource returned nil")
+	}
+
+	_, ok := r.(*GroupMembershipResource)
+	if !ok {
+		t.Fatalf("expected *GroupMembershipResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*GroupMembershipResource))
+	_ = resource.ResourceWithImportState(r.(*GroupMembershipResource))
+}
+
+func TestGroupMembershipResource_Metadata(t *testing.T) {
+	r := NewGroupMembershipResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_group_membership" {
+		t.Errorf("expected TypeName 'truenas_group_membership', got %q", resp.TypeName)
+	}
+}
+
+func TestGroupMembershipResource_Schema(t *testing.T) {
+	schemaResp := getGroupMembershipResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	if !attrs["group_id"].IsRequired() {
+		t.Error("expected 'group_id' attribute to be required")
+	}
+	if !attrs["users"].IsRequired() {
+		t.Error("expected 'users' attribute to be required")
+	}
+}
+
+// Test helpers
+
+func getGroupMembershipResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewGroupMembershipResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// groupMembershipModelParams holds parameters for creating test model values.
+type groupMembershipModelParams struct {
+	ID      interface{}
+	GroupID interface{}
+	Users   []int64
+}
+
+func createGroupMembershipModelValue(p groupMembershipModelParams) tftypes.Value {
+	usersType := tftypes.Set{ElementType: tftypes.Number}
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.String,
+			"group_id": tftypes.Number,
+			"users":    usersType,
+		},
+	}
+
+	var users tftypes.Value
+	if p.Users == nil {
+		users = tftypes.NewValue(usersType, nil)
+	} else {
+		elements := make([]tftypes.Value, len(p.Users))
+		for i, id := range p.Users {
+			elements[i] = tftypes.NewValue(tftypes.Number, id)
+		}
+		users = tftypes.NewValue(usersType, elements)
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, p.ID),
+		"group_id": tftypes.NewValue(tftypes.Number, p.GroupID),
+		"users":    users,
+	})
+}
+
+func defaultGroupMembershipStateParams() groupMembershipModelParams {
+	return groupMembershipModelParams{
+		ID:      "42",
+		GroupID: float64(42),
+		Users:   []int64{1000, 1001},
+	}
+}
+
+func TestGroupMembershipResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams []any
+
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.([]any)
+				return json.RawMessage(`42`), nil
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	planValue := createGroupMembershipModelValue(groupMembershipModelParams{
+		ID:      tftypes.UnknownValue,
+		GroupID: float64(42),
+		Users:   []int64{1001, 1000},
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "group.update" {
+		t.Errorf("expected method 'group.update', got %q", capturedMethod)
+	}
+	if capturedParams[0] != int64(42) {
+		t.Errorf("expected group ID 42, got %v", capturedParams[0])
+	}
+	users := capturedParams[1].(map[string]any)["users"].([]int64)
+	if len(users) != 2 || users[0] != 1000 || users[1] != 1001 {
+		t.Errorf("expected users [1000 1001], got %v", users)
+	}
+
+	var model GroupMembershipResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "42" {
+		t.Errorf("expected ID '42', got %q", model.ID.ValueString())
+	}
+}
+
+func TestGroupMembershipResource_Create_APIError(t *testing.T) {
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("group does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	planValue := createGroupMembershipModelValue(groupMembershipModelParams{
+		ID:      tftypes.UnknownValue,
+		GroupID: float64(42),
+		Users:   []int64{1000},
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestGroupMembershipResource_Read_Success(t *testing.T) {
+	var capturedParams any
+
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "group.query" {
+					t.Errorf("expected method 'group.query', got %q", method)
+				}
+				capturedParams = params
+				return json.RawMessage(`[{"id": 42, "group": "media", "users": [1000, 1002]}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	filter := capturedParams.([]any)[0].([]any)[0].([]any)
+	if filter[0] != "id" || filter[2] != int64(42) {
+		t.Errorf("expected filter on id 42, got %v", filter)
+	}
+
+	var model GroupMembershipResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	var users []int64
+	resp.Diagnostics.Append(model.Users.ElementsAs(context.Background(), &users, false)...)
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %v", users)
+	}
+	seen := map[int64]bool{users[0]: true, users[1]: true}
+	if !seen[1000] || !seen[1002] {
+		t.Errorf("expected users 1000 and 1002, got %v", users)
+	}
+}
+
+func TestGroupMembershipResource_Read_GroupNotFound(t *testing.T) {
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed when group does not exist")
+	}
+}
+
+func TestGroupMembershipResource_Read_APIError(t *testing.T) {
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestGroupMembershipResource_Update_Success(t *testing.T) {
+	var capturedParams []any
+
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.([]any)
+				return json.RawMessage(`42`), nil
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+	planValue := createGroupMembershipModelValue(groupMembershipModelParams{
+		ID:      "42",
+		GroupID: float64(42),
+		Users:   []int64{1002},
+	})
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	users := capturedParams[1].(map[string]any)["users"].([]int64)
+	if len(users) != 1 || users[0] != 1002 {
+		t.Errorf("expected users [1002], got %v", users)
+	}
+}
+
+func TestGroupMembershipResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams []any
+
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.([]any)
+				return json.RawMessage(`42`), nil
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "group.update" {
+		t.Errorf("expected method 'group.update', got %q", capturedMethod)
+	}
+	users := capturedParams[1].(map[string]any)["users"].([]int64)
+	if len(users) != 0 {
+		t.Errorf("expected empty member list, got %v", users)
+	}
+}
+
+func TestGroupMembershipResource_Delete_NotFound(t *testing.T) {
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("group 42 does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected not-found to be ignored, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestGroupMembershipResource_Delete_APIError(t *testing.T) {
+	r := &GroupMembershipResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getGroupMembershipResourceSchema(t)
+	stateValue := createGroupMembershipModelValue(defaultGroupMembershipStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestGroupMembershipResource_ImportState(t *testing.T) {
+	r := NewGroupMembershipResource().(*GroupMembershipResource)
+	schemaResp := getGroupMembershipResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "42"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createGroupMembershipModelValue(groupMembershipModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model GroupMembershipResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.GroupID.ValueInt64() != 42 {
+		t.Errorf("expected group_id 42, got %v", model.GroupID)
+	}
+}
+
+func TestGroupMembershipResource_ImportState_InvalidID(t *testing.T) {
+	r := NewGroupMembershipResource().(*GroupMembershipResource)
+	schemaResp := getGroupMembershipResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "media"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createGroupMembershipModelValue(groupMembershipModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for non-numeric import ID")
+	}
+}
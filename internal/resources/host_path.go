@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"path/filepath"
 
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
 	truenas "github.com/deevus/truenas-go"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -27,12 +28,12 @@ type HostPathResource struct {
 
 // HostPathResourceModel describes the resource data model.
 type HostPathResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Path         types.String `tfsdk:"path"`
-	Mode         types.String `tfsdk:"mode"`
-	UID          types.Int64  `tfsdk:"uid"`
-	GID          types.Int64  `tfsdk:"gid"`
-	ForceDestroy types.Bool   `tfsdk:"force_destroy"`
+	ID           types.String                    `tfsdk:"id"`
+	Path         customtypes.FilesystemPathValue `tfsdk:"path"`
+	Mode         types.String                    `tfsdk:"mode"`
+	UID          types.Int64                     `tfsdk:"uid"`
+	GID          types.Int64                     `tfsdk:"gid"`
+	ForceDestroy types.Bool                      `tfsdk:"force_destroy"`
 }
 
 // NewHostPathResource creates a new HostPathResource.
@@ -57,6 +58,7 @@ func (r *HostPathResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 			},
 			"path": schema.StringAttribute{
+				CustomType:  customtypes.FilesystemPathType{},
 				Description: "Full path to the directory (e.g., '/mnt/tank/apps/myapp').",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
@@ -177,6 +179,7 @@ func (r *HostPathResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *HostPathResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -9,6 +9,7 @@ import (
 	truenas "github.com/deevus/truenas-go"
 	"github.com/deevus/truenas-go/client"
 	"github.com/deevus/terraform-provider-truenas/internal/services"
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -1674,7 +1675,7 @@ func TestHostPathResource_buildPermOpts_OnlyMode(t *testing.T) {
 	r := &HostPathResource{}
 
 	data := &HostPathResourceModel{
-		Path: types.StringValue("/mnt/tank/apps/myapp"),
+		Path: customtypes.NewFilesystemPathValue("/mnt/tank/apps/myapp"),
 		Mode: types.StringValue("755"),
 		UID:  types.Int64Null(),
 		GID:  types.Int64Null(),
@@ -1704,7 +1705,7 @@ func TestHostPathResource_buildPermOpts_OnlyUID(t *testing.T) {
 	r := &HostPathResource{}
 
 	data := &HostPathResourceModel{
-		Path: types.StringValue("/mnt/tank/apps/myapp"),
+		Path: customtypes.NewFilesystemPathValue("/mnt/tank/apps/myapp"),
 		Mode: types.StringNull(),
 		UID:  types.Int64Value(1000),
 		GID:  types.Int64Null(),
@@ -1740,7 +1741,7 @@ func TestHostPathResource_buildPermOpts_OnlyGID(t *testing.T) {
 	r := &HostPathResource{}
 
 	data := &HostPathResourceModel{
-		Path: types.StringValue("/mnt/tank/apps/myapp"),
+		Path: customtypes.NewFilesystemPathValue("/mnt/tank/apps/myapp"),
 		Mode: types.StringNull(),
 		UID:  types.Int64Null(),
 		GID:  types.Int64Value(2000),
@@ -1776,7 +1777,7 @@ func TestHostPathResource_buildPermOpts_UIDAndGID_NoMode(t *testing.T) {
 	r := &HostPathResource{}
 
 	data := &HostPathResourceModel{
-		Path: types.StringValue("/mnt/tank/apps/myapp"),
+		Path: customtypes.NewFilesystemPathValue("/mnt/tank/apps/myapp"),
 		Mode: types.StringNull(),
 		UID:  types.Int64Value(1000),
 		GID:  types.Int64Value(2000),
@@ -0,0 +1,357 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &IPMIResource{}
+	_ resource.ResourceWithConfigure      = &IPMIResource{}
+	_ resource.ResourceWithImportState    = &IPMIResource{}
+	_ resource.ResourceWithValidateConfig = &IPMIResource{}
+)
+
+// ipmiDHCPAddressSource is the ip_address_source reported for channels using DHCP.
+const ipmiDHCPAddressSource = "DHCP Address"
+
+// IPMIResourceModel describes the resource data model.
+type IPMIResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Channel   types.Int64  `tfsdk:"channel"`
+	DHCP      types.Bool   `tfsdk:"dhcp"`
+	IPAddress types.String `tfsdk:"ip_address"`
+	Netmask   types.String `tfsdk:"netmask"`
+	Gateway   types.String `tfsdk:"gateway"`
+	VLAN      types.Int64  `tfsdk:"vlan"`
+	Password  types.String `tfsdk:"password"`
+}
+
+// ipmiLANResponse is the subset of ipmi.lan.query fields used by the resource.
+type ipmiLANResponse struct {
+	Channel                 int64  `json:"channel"`
+	IPAddressSource         string `json:"ip_address_source"`
+	IPAddress               string `json:"ip_address"`
+	SubnetMask              string `json:"subnet_mask"`
+	DefaultGatewayIPAddress string `json:"default_gateway_ip_address"`
+	VLANIDEnable            bool   `json:"vlan_id_enable"`
+	VLANID                  int64  `json:"vlan_id"`
+}
+
+// IPMIResource defines the resource implementation.
+type IPMIResource struct {
+	BaseResource
+}
+
+// NewIPMIResource creates a new IPMIResource.
+func NewIPMIResource() resource.Resource {
+	return &IPMIResource{}
+}
+
+func (r *IPMIResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ipmi"
+}
+
+func (r *IPMIResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the network settings of an IPMI (BMC) LAN channel. Destroying the resource " +
+			"leaves the BMC configured as it is, so remote access to the server is not lost.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (the channel number).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel": schema.Int64Attribute{
+				Description: "IPMI LAN channel to configure (see ipmi.lan.channels), usually 1.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"dhcp": schema.BoolAttribute{
+				Description: "Obtain the BMC address via DHCP. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "Static IPv4 address of the BMC. Required unless dhcp is enabled.",
+				Optional:    true,
+			},
+			"netmask": schema.StringAttribute{
+				Description: "Subnet mask of the static address (e.g. '255.255.255.0'). Required unless dhcp is enabled.",
+				Optional:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "Default gateway of the static address.",
+				Optional:    true,
+			},
+			"vlan": schema.Int64Attribute{
+				Description: "VLAN ID for BMC traffic. Leave unset to disable VLAN tagging.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the BMC administrator user. Write-only: it is never read back, " +
+					"so changes made outside Terraform are not detected.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *IPMIResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data IPMIResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip validation if dhcp is unknown (e.g., referencing another
+	// resource's output). The middleware validates it again on apply.
+	if data.DHCP.IsUnknown() {
+		return
+	}
+
+	static := map[string]types.String{
+		"ip_address": data.IPAddress,
+		"netmask":    data.Netmask,
+		"gateway":    data.Gateway,
+	}
+
+	if data.DHCP.ValueBool() {
+		for _, name := range []string{"ip_address", "netmask", "gateway"} {
+			if !static[name].IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid IPMI Configuration",
+					fmt.Sprintf("%s cannot be set when dhcp is enabled.", name),
+				)
+			}
+		}
+		return
+	}
+
+	for _, name := range []string{"ip_address", "netmask"} {
+		if static[name].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid IPMI Configuration",
+				fmt.Sprintf("%s is required unless dhcp is enabled.", name),
+			)
+		}
+	}
+}
+
+func (r *IPMIResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IPMIResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IPMIResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IPMIResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	channel, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Unable to parse ID %q as an IPMI channel: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	lan, err := r.getChannel(ctx, channel)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IPMI Channel",
+			fmt.Sprintf("Unable to query IPMI channel %d: %s", channel, err.Error()),
+		)
+		return
+	}
+
+	if lan == nil {
+		// The channel is gone, e.g. the BMC was replaced
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapIPMILANToModel(lan, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *IPMIResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IPMIResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *IPMIResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to do: resetting the BMC network settings could make the
+	// server unreachable out of band, so they are left as they are.
+}
+
+// ipmiAPIFieldPaths maps ipmi.lan.update validation errors to attributes.
+var ipmiAPIFieldPaths = map[string]path.Path{
+	"dhcp":      path.Root("dhcp"),
+	"ipaddress": path.Root("ip_address"),
+	"netmask":   path.Root("netmask"),
+	"gateway":   path.Root("gateway"),
+	"vlan":      path.Root("vlan"),
+	"password":  path.Root("password"),
+}
+
+// apply writes the channel settings via ipmi.lan.update and maps the
+// resulting channel configuration into data.
+func (r *IPMIResource) apply(ctx context.Context, data *IPMIResourceModel, diags *diag.Diagnostics) {
+	channel := data.Channel.ValueInt64()
+
+	params := []any{channel, buildIPMIParams(data)}
+	if _, err := r.client.Call(ctx, "ipmi.lan.update", params); err != nil {
+		addAPIError(diags, err, ipmiAPIFieldPaths,
+			"Unable to Update IPMI Channel",
+			fmt.Sprintf("Unable to update IPMI channel %d: %s", channel, err.Error()),
+		)
+		return
+	}
+
+	lan, err := r.getChannel(ctx, channel)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read IPMI Channel",
+			fmt.Sprintf("Unable to query IPMI channel %d after update: %s", channel, err.Error()),
+		)
+		return
+	}
+	if lan == nil {
+		diags.AddError(
+			"IPMI Channel Not Found",
+			fmt.Sprintf("IPMI channel %d does not exist.", channel),
+		)
+		return
+	}
+
+	mapIPMILANToModel(lan, data)
+}
+
+// getChannel queries an IPMI LAN channel. Returns nil if it does not exist.
+func (r *IPMIResource) getChannel(ctx context.Context, channel int64) (*ipmiLANResponse, error) {
+	filter := []any{[]any{[]any{"channel", "=", channel}}}
+	result, err := r.client.Call(ctx, "ipmi.lan.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []ipmiLANResponse
+	if err := json.Unmarshal(result, &channels); err != nil {
+		return nil, fmt.Errorf("parse ipmi lan query response: %w", err)
+	}
+
+	if len(channels) == 0 {
+		return nil, nil
+	}
+	return &channels[0], nil
+}
+
+// buildIPMIParams builds the ipmi.lan.update params from the resource model.
+// The password is only sent when set, so an unmanaged password is kept.
+func buildIPMIParams(data *IPMIResourceModel) map[string]any {
+	params := map[string]any{
+		"dhcp": data.DHCP.ValueBool(),
+		"vlan": nil,
+	}
+
+	if !data.DHCP.ValueBool() {
+		params["ipaddress"] = data.IPAddress.ValueString()
+		params["netmask"] = data.Netmask.ValueString()
+		params["gateway"] = data.Gateway.ValueString()
+	}
+	if !data.VLAN.IsNull() {
+		params["vlan"] = data.VLAN.ValueInt64()
+	}
+	if !data.Password.IsNull() {
+		params["password"] = data.Password.ValueString()
+	}
+
+	return params
+}
+
+// mapIPMILANToModel maps an ipmi.lan.query entry to the resource model.
+// The password is never returned and is kept as is.
+func mapIPMILANToModel(lan *ipmiLANResponse, data *IPMIResourceModel) {
+	data.ID = types.StringValue(strconv.FormatInt(lan.Channel, 10))
+	data.Channel = types.Int64Value(lan.Channel)
+	data.DHCP = types.BoolValue(lan.IPAddressSource == ipmiDHCPAddressSource)
+
+	// Addresses handed out by DHCP are not part of the configuration.
+	if data.DHCP.ValueBool() {
+		data.IPAddress = types.StringNull()
+		data.Netmask = types.StringNull()
+		data.Gateway = types.StringNull()
+	} else {
+		data.IPAddress = nonEmptyStringValue(lan.IPAddress)
+		data.Netmask = nonEmptyStringValue(lan.SubnetMask)
+		data.Gateway = nonEmptyStringValue(lan.DefaultGatewayIPAddress)
+		// BMCs report an unset gateway as 0.0.0.0
+		if lan.DefaultGatewayIPAddress == "0.0.0.0" {
+			data.Gateway = types.StringNull()
+		}
+	}
+
+	if lan.VLANIDEnable {
+		data.VLAN = types.Int64Value(lan.VLANID)
+	} else {
+		data.VLAN = types.Int64Null()
+	}
+}
@@ -0,0 +1,423 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewIPMIResource(t *testing.T) {
+	r := NewIPMIResource()
+	if r == nil {
+		t.Fatal("NewIPMIResource returned nil")
+	}
+
+	_, ok := r.(*IPMIResource)
+	if !ok {
+		t.Fatalf("expected *IPMIResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*IPMIResource))
+	_ = resource.ResourceWithImportState(r.(*IPMIResource))
+	_ = resource.ResourceWithValidateConfig(r.(*IPMIResource))
+}
+
+func TestIPMIResource_Metadata(t *testing.T) {
+	r := NewIPMIResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_ipmi" {
+		t.Errorf("expected TypeName 'truenas_ipmi', got %q", resp.TypeName)
+	}
+}
+
+func TestIPMIResource_Schema(t *testing.T) {
+	schemaResp := getIPMIResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["channel"].IsRequired() {
+		t.Error("expected 'channel' attribute to be required")
+	}
+	for _, name := range []string{"dhcp", "ip_address", "netmask", "gateway", "vlan", "password"} {
+		if !attrs[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	if !attrs["password"].IsSensitive() {
+		t.Error("expected 'password' attribute to be sensitive")
+	}
+}
+
+// Test helpers
+
+func getIPMIResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewIPMIResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// ipmiModelParams holds parameters for creating test model values.
+type ipmiModelParams struct {
+	ID        interface{}
+	Channel   interface{}
+	DHCP      interface{}
+	IPAddress interface{}
+	Netmask   interface{}
+	Gateway   interface{}
+	VLAN      interface{}
+	Password  interface{}
+}
+
+func createIPMIModelValue(p ipmiModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"channel":    tftypes.Number,
+			"dhcp":       tftypes.Bool,
+			"ip_address": tftypes.String,
+			"netmask":    tftypes.String,
+			"gateway":    tftypes.String,
+			"vlan":       tftypes.Number,
+			"password":   tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, p.ID),
+		"channel":    tftypes.NewValue(tftypes.Number, p.Channel),
+		"dhcp":       tftypes.NewValue(tftypes.Bool, p.DHCP),
+		"ip_address": tftypes.NewValue(tftypes.String, p.IPAddress),
+		"netmask":    tftypes.NewValue(tftypes.String, p.Netmask),
+		"gateway":    tftypes.NewValue(tftypes.String, p.Gateway),
+		"vlan":       tftypes.NewValue(tftypes.Number, p.VLAN),
+		"password":   tftypes.NewValue(tftypes.String, p.Password),
+	})
+}
+
+func defaultIPMIParams() ipmiModelParams {
+	return ipmiModelParams{
+		ID:        tftypes.UnknownValue,
+		Channel:   float64(1),
+		DHCP:      false,
+		IPAddress: "10.0.10.21",
+		Netmask:   "255.255.255.0",
+		Gateway:   "10.0.10.1",
+		VLAN:      float64(10),
+		Password:  "bmc-secret",
+	}
+}
+
+const testIPMILANJSON = `[{
+	"channel": 1,
+	"id": 1,
+	"ip_address_source": "Static Address",
+	"ip_address": "10.0.10.21",
+	"mac_address": "3c:ec:ef:00:11:22",
+	"subnet_mask": "255.255.255.0",
+	"default_gateway_ip_address": "10.0.10.1",
+	"vlan_id_enable": true,
+	"vlan_id": 10
+}]`
+
+// newIPMITestResource returns a resource whose ipmi.lan.query calls return lan
+// and whose ipmi.lan.update params are captured.
+func newIPMITestResource(t *testing.T, lan string, params *[]any) *IPMIResource {
+	t.Helper()
+
+	return &IPMIResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+				switch method {
+				case "ipmi.lan.query":
+					return json.RawMessage(lan), nil
+				case "ipmi.lan.update":
+					if params != nil {
+						*params = p.([]any)
+					}
+					return json.RawMessage(`1`), nil
+				}
+				t.Errorf("unexpected method %q", method)
+				return nil, nil
+			},
+		}},
+	}
+}
+
+func createIPMI(t *testing.T, r *IPMIResource, p ipmiModelParams) *resource.CreateResponse {
+	t.Helper()
+
+	schemaResp := getIPMIResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createIPMIModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+	return resp
+}
+
+func validateIPMI(t *testing.T, p ipmiModelParams) diag.Diagnostics {
+	t.Helper()
+
+	r := NewIPMIResource().(*IPMIResource)
+	schemaResp := getIPMIResourceSchema(t)
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createIPMIModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+	return resp.Diagnostics
+}
+
+func TestIPMIResource_ValidateConfig_Static(t *testing.T) {
+	if diags := validateIPMI(t, defaultIPMIParams()); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+}
+
+func TestIPMIResource_ValidateConfig_StaticWithoutAddress(t *testing.T) {
+	p := defaultIPMIParams()
+	p.IPAddress = nil
+	p.Netmask = nil
+
+	if diags := validateIPMI(t, p); diags.ErrorsCount() != 2 {
+		t.Fatalf("expected errors for ip_address and netmask, got %v", diags)
+	}
+}
+
+func TestIPMIResource_ValidateConfig_DHCPWithAddress(t *testing.T) {
+	p := defaultIPMIParams()
+	p.DHCP = true
+
+	if diags := validateIPMI(t, p); diags.ErrorsCount() != 3 {
+		t.Fatalf("expected errors for ip_address, netmask and gateway, got %v", diags)
+	}
+}
+
+func TestIPMIResource_Create_Static(t *testing.T) {
+	var params []any
+	r := newIPMITestResource(t, testIPMILANJSON, &params)
+
+	resp := createIPMI(t, r, defaultIPMIParams())
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if params[0] != int64(1) {
+		t.Errorf("expected channel 1, got %v", params[0])
+	}
+	data := params[1].(map[string]any)
+	if data["dhcp"] != false || data["ipaddress"] != "10.0.10.21" || data["netmask"] != "255.255.255.0" {
+		t.Errorf("unexpected static params: %v", data)
+	}
+	if data["vlan"] != int64(10) {
+		t.Errorf("expected vlan 10, got %v", data["vlan"])
+	}
+	if data["password"] != "bmc-secret" {
+		t.Errorf("expected password to be sent, got %v", data["password"])
+	}
+
+	var model IPMIResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "1" {
+		t.Errorf("expected ID '1', got %q", model.ID.ValueString())
+	}
+	if model.Gateway.ValueString() != "10.0.10.1" {
+		t.Errorf("expected gateway '10.0.10.1', got %q", model.Gateway.ValueString())
+	}
+	if model.Password.ValueString() != "bmc-secret" {
+		t.Error("expected password to be preserved from plan")
+	}
+}
+
+func TestIPMIResource_Create_DHCP(t *testing.T) {
+	var params []any
+	r := newIPMITestResource(t, `[{
+		"channel": 1,
+		"ip_address_source": "DHCP Address",
+		"ip_address": "192.168.1.77",
+		"subnet_mask": "255.255.255.0",
+		"default_gateway_ip_address": "192.168.1.1",
+		"vlan_id_enable": false,
+		"vlan_id": 0
+	}]`, &params)
+
+	p := defaultIPMIParams()
+	p.DHCP = true
+	p.IPAddress = nil
+	p.Netmask = nil
+	p.Gateway = nil
+	p.VLAN = nil
+	p.Password = nil
+	resp := createIPMI(t, r, p)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	data := params[1].(map[string]any)
+	if _, ok := data["ipaddress"]; ok {
+		t.Error("expected no static address with dhcp")
+	}
+	if _, ok := data["password"]; ok {
+		t.Error("expected password to be omitted when unset")
+	}
+	if v, ok := data["vlan"]; !ok || v != nil {
+		t.Errorf("expected vlan to be sent as null, got %v", v)
+	}
+
+	var model IPMIResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.DHCP.ValueBool() {
+		t.Error("expected dhcp to be true")
+	}
+	if !model.IPAddress.IsNull() || !model.VLAN.IsNull() {
+		t.Error("expected leased address and vlan to be null")
+	}
+}
+
+func TestIPMIResource_Create_ChannelNotFound(t *testing.T) {
+	r := newIPMITestResource(t, `[]`, nil)
+
+	resp := createIPMI(t, r, defaultIPMIParams())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for missing channel")
+	}
+}
+
+func TestIPMIResource_Create_APIError(t *testing.T) {
+	r := &IPMIResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	resp := createIPMI(t, r, defaultIPMIParams())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestIPMIResource_Read_UnsetGateway(t *testing.T) {
+	r := newIPMITestResource(t, `[{
+		"channel": 1,
+		"ip_address_source": "Static Address",
+		"ip_address": "10.0.10.21",
+		"subnet_mask": "255.255.255.0",
+		"default_gateway_ip_address": "0.0.0.0",
+		"vlan_id_enable": false,
+		"vlan_id": 0
+	}]`, nil)
+
+	schemaResp := getIPMIResourceSchema(t)
+	p := defaultIPMIParams()
+	p.ID = "1"
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createIPMIModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model IPMIResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.Gateway.IsNull() {
+		t.Errorf("expected null gateway, got %q", model.Gateway.ValueString())
+	}
+	if !model.VLAN.IsNull() {
+		t.Errorf("expected null vlan, got %d", model.VLAN.ValueInt64())
+	}
+	if model.Password.ValueString() != "bmc-secret" {
+		t.Error("expected password to be kept from state")
+	}
+}
+
+func TestIPMIResource_Read_ChannelGone(t *testing.T) {
+	r := newIPMITestResource(t, `[]`, nil)
+
+	schemaResp := getIPMIResourceSchema(t)
+	p := defaultIPMIParams()
+	p.ID = "1"
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createIPMIModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createIPMIModelValue(p)},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected resource to be removed from state")
+	}
+}
+
+func TestIPMIResource_Delete_LeavesBMCConfigured(t *testing.T) {
+	r := &IPMIResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Fatalf("expected no API calls, got %q", method)
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getIPMIResourceSchema(t)
+	p := defaultIPMIParams()
+	p.ID = "1"
+	stateValue := createIPMIModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,332 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &ISCSIAuthResource{}
+	_ resource.ResourceWithConfigure      = &ISCSIAuthResource{}
+	_ resource.ResourceWithImportState    = &ISCSIAuthResource{}
+	_ resource.ResourceWithValidateConfig = &ISCSIAuthResource{}
+)
+
+// ISCSIAuthResourceModel describes the resource data model.
+type ISCSIAuthResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Tag        types.Int64  `tfsdk:"tag"`
+	User       types.String `tfsdk:"user"`
+	Secret     types.String `tfsdk:"secret"`
+	PeerUser   types.String `tfsdk:"peer_user"`
+	PeerSecret types.String `tfsdk:"peer_secret"`
+}
+
+// iscsiAuthResponse is the JSON shape returned by iscsi.auth.* methods.
+// Secrets are not read back; they are write-only in this resource.
+type iscsiAuthResponse struct {
+	ID       int64  `json:"id"`
+	Tag      int64  `json:"tag"`
+	User     string `json:"user"`
+	PeerUser string `json:"peeruser"`
+}
+
+// iscsiAuthAPIFieldPaths maps iscsi.auth validation errors to attributes.
+var iscsiAuthAPIFieldPaths = map[string]path.Path{
+	"tag":        path.Root("tag"),
+	"user":       path.Root("user"),
+	"secret":     path.Root("secret"),
+	"peeruser":   path.Root("peer_user"),
+	"peersecret": path.Root("peer_secret"),
+}
+
+// ISCSIAuthResource defines the resource implementation.
+type ISCSIAuthResource struct {
+	BaseResource
+}
+
+// NewISCSIAuthResource creates a new ISCSIAuthResource.
+func NewISCSIAuthResource() resource.Resource {
+	return &ISCSIAuthResource{}
+}
+
+func (r *ISCSIAuthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iscsi_auth"
+}
+
+func (r *ISCSIAuthResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages iSCSI CHAP credentials on TrueNAS. Credentials sharing a tag form an authorized " +
+			"access group; a target enables CHAP by referencing the tag in its group's auth setting. Secrets " +
+			"are write-only and are not read back from TrueNAS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Credential ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag": schema.Int64Attribute{
+				Description: "Authorized access group tag. Targets reference this number to require these credentials.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"user": schema.StringAttribute{
+				Description: "CHAP user name initiators authenticate with.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Description: "CHAP secret (12 to 16 characters).",
+				Required:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(12, 16),
+				},
+			},
+			"peer_user": schema.StringAttribute{
+				Description: "Mutual CHAP user name the target authenticates with. Null disables mutual CHAP.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"peer_secret": schema.StringAttribute{
+				Description: "Mutual CHAP secret (12 to 16 characters). Required with peer_user and must differ from secret.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(12, 16),
+				},
+			},
+		},
+	}
+}
+
+func (r *ISCSIAuthResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ISCSIAuthResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip validation for unknown values (e.g., secrets generated by another
+	// resource). The middleware validates them again on apply.
+	if data.PeerUser.IsUnknown() || data.PeerSecret.IsUnknown() || data.Secret.IsUnknown() {
+		return
+	}
+
+	if data.PeerUser.IsNull() != data.PeerSecret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("peer_secret"),
+			"Invalid iSCSI Auth Configuration",
+			"peer_user and peer_secret must be set together to enable mutual CHAP.",
+		)
+	}
+	if !data.PeerSecret.IsNull() && data.PeerSecret.ValueString() == data.Secret.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("peer_secret"),
+			"Invalid iSCSI Auth Configuration",
+			"peer_secret must differ from secret.",
+		)
+	}
+}
+
+func (r *ISCSIAuthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ISCSIAuthResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "iscsi.auth.create", buildISCSIAuthParams(&data))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, iscsiAuthAPIFieldPaths,
+			"Unable to Create iSCSI Auth",
+			fmt.Sprintf("Unable to create iSCSI auth for user %q: %s", data.User.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var auth iscsiAuthResponse
+	if err := json.Unmarshal(result, &auth); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse iSCSI auth create response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapISCSIAuthToModel(&auth, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ISCSIAuthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ISCSIAuthResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid iSCSI Auth ID",
+			fmt.Sprintf("Cannot parse iSCSI auth ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	auth, err := r.getAuth(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read iSCSI Auth",
+			fmt.Sprintf("Unable to read iSCSI auth %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	if auth == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapISCSIAuthToModel(auth, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *ISCSIAuthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ISCSIAuthResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(plan.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid iSCSI Auth ID",
+			fmt.Sprintf("Cannot parse iSCSI auth ID %q: %s", plan.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	result, err := r.client.Call(ctx, "iscsi.auth.update", []any{id, buildISCSIAuthParams(&plan)})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, iscsiAuthAPIFieldPaths,
+			"Unable to Update iSCSI Auth",
+			fmt.Sprintf("Unable to update iSCSI auth %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	var auth iscsiAuthResponse
+	if err := json.Unmarshal(result, &auth); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse iSCSI auth update response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapISCSIAuthToModel(&auth, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ISCSIAuthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ISCSIAuthResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid iSCSI Auth ID",
+			fmt.Sprintf("Cannot parse iSCSI auth ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if _, err := r.client.Call(ctx, "iscsi.auth.delete", id); err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete iSCSI Auth",
+			fmt.Sprintf("Unable to delete iSCSI auth %d: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+// getAuth queries iSCSI credentials by ID. Returns nil if they do not exist.
+func (r *ISCSIAuthResource) getAuth(ctx context.Context, id int64) (*iscsiAuthResponse, error) {
+	filter := []any{[]any{[]any{"id", "=", id}}}
+
+	result, err := r.client.Call(ctx, "iscsi.auth.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var auths []iscsiAuthResponse
+	if err := json.Unmarshal(result, &auths); err != nil {
+		return nil, fmt.Errorf("parse iscsi auth query response: %w", err)
+	}
+
+	if len(auths) == 0 {
+		return nil, nil
+	}
+
+	return &auths[0], nil
+}
+
+// buildISCSIAuthParams builds the iscsi.auth create/update params from the resource model.
+// Mutual CHAP is cleared by sending empty peer credentials.
+func buildISCSIAuthParams(data *ISCSIAuthResourceModel) map[string]any {
+	return map[string]any{
+		"tag":        data.Tag.ValueInt64(),
+		"user":       data.User.ValueString(),
+		"secret":     data.Secret.ValueString(),
+		"peeruser":   data.PeerUser.ValueString(),
+		"peersecret": data.PeerSecret.ValueString(),
+	}
+}
+
+// mapISCSIAuthToModel maps the API response to the resource model.
+// Secrets are write-only and are preserved from the plan/state.
+func mapISCSIAuthToModel(auth *iscsiAuthResponse, data *ISCSIAuthResourceModel) {
+	data.ID = types.StringValue(strconv.FormatInt(auth.ID, 10))
+	data.Tag = types.Int64Value(auth.Tag)
+	data.User = types.StringValue(auth.User)
+	data.PeerUser = nonEmptyStringValue(auth.PeerUser)
+	if data.PeerUser.IsNull() {
+		data.PeerSecret = types.StringNull()
+	}
+}
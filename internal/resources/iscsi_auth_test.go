@@ -0,0 +1,445 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewISCSIAuthResource(t *testing.T) {
+	r := NewISCSIAuthResource()
+	if r == nil {
+		t.Fatal("NewISCSIAuthResource returned nil")
+	}
+
+	_, ok := r.(*ISCSIAuthResource)
+	if !ok {
+		t.Fatalf("expected *ISCSIAuthResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*ISCSIAuthResource))
+	_ = resource.ResourceWithImportState(r.(*ISCSIAuthResource))
+	_ = resource.ResourceWithValidateConfig(r.(*ISCSIAuthResource))
+}
+
+func TestISCSIAuthResource_Metadata(t *testing.T) {
+	r := NewISCSIAuthResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_iscsi_auth" {
+		t.Errorf("expected TypeName 'truenas_iscsi_auth', got %q", resp.TypeName)
+	}
+}
+
+func TestISCSIAuthResource_Schema(t *testing.T) {
+	schemaResp := getISCSIAuthResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"tag", "user", "secret"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	for _, name := range []string{"peer_user", "peer_secret"} {
+		if !attrs[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	for _, name := range []string{"secret", "peer_secret"} {
+		if !attrs[name].IsSensitive() {
+			t.Errorf("expected '%s' attribute to be sensitive", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getISCSIAuthResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewISCSIAuthResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// iscsiAuthModelParams holds parameters for creating test model values.
+type iscsiAuthModelParams struct {
+	ID         interface{}
+	Tag        interface{}
+	User       interface{}
+	Secret     interface{}
+	PeerUser   interface{}
+	PeerSecret interface{}
+}
+
+func createISCSIAuthModelValue(p iscsiAuthModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":          tftypes.String,
+			"tag":         tftypes.Number,
+			"user":        tftypes.String,
+			"secret":      tftypes.String,
+			"peer_user":   tftypes.String,
+			"peer_secret": tftypes.String,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, p.ID),
+		"tag":         tftypes.NewValue(tftypes.Number, p.Tag),
+		"user":        tftypes.NewValue(tftypes.String, p.User),
+		"secret":      tftypes.NewValue(tftypes.String, p.Secret),
+		"peer_user":   tftypes.NewValue(tftypes.String, p.PeerUser),
+		"peer_secret": tftypes.NewValue(tftypes.String, p.PeerSecret),
+	})
+}
+
+func defaultISCSIAuthParams() iscsiAuthModelParams {
+	return iscsiAuthModelParams{
+		ID:         "4",
+		Tag:        float64(1),
+		User:       "initiator1",
+		Secret:     "secretsecret1",
+		PeerUser:   "target1",
+		PeerSecret: "peersecret12",
+	}
+}
+
+const testISCSIAuthJSON = `{
+	"id": 4,
+	"tag": 1,
+	"user": "initiator1",
+	"secret": "secretsecret1",
+	"peeruser": "target1",
+	"peersecret": "peersecret12"
+}`
+
+func validateISCSIAuth(t *testing.T, p iscsiAuthModelParams) diag.Diagnostics {
+	t.Helper()
+
+	r := NewISCSIAuthResource().(*ISCSIAuthResource)
+	schemaResp := getISCSIAuthResourceSchema(t)
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createISCSIAuthModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+	return resp.Diagnostics
+}
+
+func TestISCSIAuthResource_ValidateConfig_MutualCHAP(t *testing.T) {
+	if diags := validateISCSIAuth(t, defaultISCSIAuthParams()); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+}
+
+func TestISCSIAuthResource_ValidateConfig_PeerUserWithoutSecret(t *testing.T) {
+	p := defaultISCSIAuthParams()
+	p.PeerSecret = nil
+
+	diags := validateISCSIAuth(t, p)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("peer_secret")) {
+		t.Errorf("expected error on peer_secret, got %v", diags)
+	}
+}
+
+func TestISCSIAuthResource_ValidateConfig_PeerSecretMatchesSecret(t *testing.T) {
+	p := defaultISCSIAuthParams()
+	p.PeerSecret = p.Secret
+
+	if diags := validateISCSIAuth(t, p); diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+}
+
+func TestISCSIAuthResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testISCSIAuthJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	p := defaultISCSIAuthParams()
+	p.ID = tftypes.UnknownValue
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createISCSIAuthModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "iscsi.auth.create" {
+		t.Errorf("expected method 'iscsi.auth.create', got %q", capturedMethod)
+	}
+	if capturedParams["tag"] != int64(1) {
+		t.Errorf("expected tag 1, got %v", capturedParams["tag"])
+	}
+	if capturedParams["peeruser"] != "target1" || capturedParams["peersecret"] != "peersecret12" {
+		t.Errorf("expected mutual CHAP params, got %v", capturedParams)
+	}
+
+	var model ISCSIAuthResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "4" {
+		t.Errorf("expected ID '4', got %q", model.ID.ValueString())
+	}
+	if model.Secret.ValueString() != "secretsecret1" {
+		t.Errorf("expected secret preserved from plan, got %q", model.Secret.ValueString())
+	}
+}
+
+func TestISCSIAuthResource_Create_ValidationError(t *testing.T) {
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, newValidationRPCError([]any{"iscsi_auth_create.peersecret", "Secret and peer secret must be different", float64(22)})
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	p := defaultISCSIAuthParams()
+	p.ID = tftypes.UnknownValue
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createISCSIAuthModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("peer_secret")) {
+		t.Errorf("expected error on the 'peer_secret' attribute, got %v", resp.Diagnostics)
+	}
+}
+
+func TestISCSIAuthResource_Read_Success(t *testing.T) {
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "iscsi.auth.query" {
+					t.Errorf("expected method 'iscsi.auth.query', got %q", method)
+				}
+				return json.RawMessage(`[{"id": 4, "tag": 2, "user": "initiator1", "peeruser": ""}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	stateValue := createISCSIAuthModelValue(defaultISCSIAuthParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model ISCSIAuthResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Tag.ValueInt64() != 2 {
+		t.Errorf("expected tag 2, got %d", model.Tag.ValueInt64())
+	}
+	if model.Secret.ValueString() != "secretsecret1" {
+		t.Errorf("expected secret preserved from state, got %q", model.Secret.ValueString())
+	}
+	if !model.PeerUser.IsNull() || !model.PeerSecret.IsNull() {
+		t.Errorf("expected mutual CHAP removed, got peer_user=%v peer_secret=%v", model.PeerUser, model.PeerSecret)
+	}
+}
+
+func TestISCSIAuthResource_Read_NotFound(t *testing.T) {
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	stateValue := createISCSIAuthModelValue(defaultISCSIAuthParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed")
+	}
+}
+
+func TestISCSIAuthResource_Update_DisableMutualCHAP(t *testing.T) {
+	var capturedParams []any
+
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "iscsi.auth.update" {
+					t.Errorf("expected method 'iscsi.auth.update', got %q", method)
+				}
+				capturedParams = params.([]any)
+				return json.RawMessage(`{"id": 4, "tag": 1, "user": "initiator1", "peeruser": ""}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	stateValue := createISCSIAuthModelValue(defaultISCSIAuthParams())
+	planParams := defaultISCSIAuthParams()
+	planParams.PeerUser = nil
+	planParams.PeerSecret = nil
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createISCSIAuthModelValue(planParams)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams[0] != int64(4) {
+		t.Errorf("expected auth ID 4, got %v", capturedParams[0])
+	}
+	params := capturedParams[1].(map[string]any)
+	if params["peeruser"] != "" || params["peersecret"] != "" {
+		t.Errorf("expected empty peer credentials, got %v", params)
+	}
+}
+
+func TestISCSIAuthResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params
+				return json.RawMessage(`true`), nil
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	stateValue := createISCSIAuthModelValue(defaultISCSIAuthParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "iscsi.auth.delete" {
+		t.Errorf("expected method 'iscsi.auth.delete', got %q", capturedMethod)
+	}
+	if capturedParams != int64(4) {
+		t.Errorf("expected auth ID 4, got %v", capturedParams)
+	}
+}
+
+func TestISCSIAuthResource_Delete_APIError(t *testing.T) {
+	r := &ISCSIAuthResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getISCSIAuthResourceSchema(t)
+	stateValue := createISCSIAuthModelValue(defaultISCSIAuthParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
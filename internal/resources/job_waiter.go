@@ -0,0 +1,243 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &JobWaiterResource{}
+var _ resource.ResourceWithConfigure = &JobWaiterResource{}
+
+// Job states reported by core.get_jobs.
+const (
+	jobStateSuccess = "SUCCESS"
+	jobStateFailed  = "FAILED"
+	jobStateAborted = "ABORTED"
+)
+
+// JobWaiterResource defines the resource implementation.
+type JobWaiterResource struct {
+	BaseResource
+}
+
+// JobWaiterResourceModel describes the resource data model.
+type JobWaiterResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	JobID       types.Int64  `tfsdk:"job_id"`
+	Timeout     types.Int64  `tfsdk:"timeout"`
+	FailOnError types.Bool   `tfsdk:"fail_on_error"`
+	Method      types.String `tfsdk:"method"`
+	State       types.String `tfsdk:"state"`
+	Error       types.String `tfsdk:"error"`
+	Result      types.String `tfsdk:"result"`
+}
+
+// jobWaiterJobResponse is the subset of core.get_jobs fields the waiter reports.
+type jobWaiterJobResponse struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method"`
+	State  string          `json:"state"`
+	Error  *string         `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+// NewJobWaiterResource creates a new JobWaiterResource.
+func NewJobWaiterResource() resource.Resource {
+	return &JobWaiterResource{}
+}
+
+func (r *JobWaiterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_waiter"
+}
+
+func (r *JobWaiterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Blocks until a middleware job reaches a terminal state (SUCCESS, FAILED or ABORTED), so " +
+			"resources can depend on operations started outside Terraform. Changing job_id waits again. " +
+			"Destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (the job ID).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"job_id": schema.Int64Attribute{
+				Description: "ID of the job to wait for, e.g. from the truenas_job data source.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "Timeout in seconds to wait for the job to finish. Defaults to 600. Range: 1-86400.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(600),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 86400),
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description: "Fail the apply when the job ends in FAILED or ABORTED. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"method": schema.StringAttribute{
+				Description: "Method that started the job.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "Terminal state of the job.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"error": schema.StringAttribute{
+				Description: "Error message of a failed job, or null.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON-encoded job result, or null.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *JobWaiterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data JobWaiterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobID := data.JobID.ValueInt64()
+	timeout := time.Duration(data.Timeout.ValueInt64()) * time.Second
+
+	var job *jobWaiterJobResponse
+	err := waitForCollection(ctx, r.client, "core.get_jobs", timeout, func(ctx context.Context) (bool, error) {
+		var err error
+		job, err = r.getJob(ctx, jobID)
+		if err != nil {
+			return false, err
+		}
+		if job == nil {
+			return false, fmt.Errorf("job %d does not exist", jobID)
+		}
+		return isTerminalJobState(job.State), nil
+	})
+	if err != nil {
+		detail := fmt.Sprintf("Unable to wait for job %d: %s", jobID, err.Error())
+		if job != nil {
+			detail = fmt.Sprintf("Unable to wait for job %d (last state %q): %s", jobID, job.State, err.Error())
+		}
+		resp.Diagnostics.AddError("Unable to Wait for Job", detail)
+		return
+	}
+
+	if job.State != jobStateSuccess && data.FailOnError.ValueBool() {
+		message := "no error reported"
+		if job.Error != nil {
+			message = *job.Error
+		}
+		resp.Diagnostics.AddError(
+			"Job Failed",
+			fmt.Sprintf("Job %d (%s) ended in state %s: %s", jobID, job.Method, job.State, message),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(jobID, 10))
+	data.Method = types.StringValue(job.Method)
+	data.State = types.StringValue(job.State)
+	data.Error = types.StringPointerValue(job.Error)
+	data.Result = types.StringNull()
+	if len(job.Result) > 0 && string(job.Result) != "null" {
+		data.Result = types.StringValue(string(job.Result))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JobWaiterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Finished jobs are eventually pruned from core.get_jobs; state is kept as recorded.
+	var data JobWaiterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *JobWaiterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only timeout and fail_on_error can change in place; they apply to the next wait.
+	var data JobWaiterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JobWaiterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// There is nothing to undo; removing the resource from state is enough.
+}
+
+// getJob queries a job by ID. Returns nil if the job does not exist.
+func (r *JobWaiterResource) getJob(ctx context.Context, id int64) (*jobWaiterJobResponse, error) {
+	params := []any{[]any{[]any{"id", "=", id}}}
+
+	result, err := r.client.Call(ctx, "core.get_jobs", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []jobWaiterJobResponse
+	if err := json.Unmarshal(result, &jobs); err != nil {
+		return nil, fmt.Errorf("parse job query response: %w", err)
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0], nil
+}
+
+// isTerminalJobState reports whether a job in the given state has finished.
+func isTerminalJobState(state string) bool {
+	return state == jobStateSuccess || state == jobStateFailed || state == jobStateAborted
+}
@@ -0,0 +1,327 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewJobWaiterResource(t *testing.T) {
+	r := NewJobWaiterResource()
+	if r == nil {
+		t.Fatal("NewJobWaiterResource returned nil")
+	}
+
+	_, ok := r.(*JobWaiterResource)
+	if !ok {
+		t.Fatalf("expected *JobWaiterResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*JobWaiterResource))
+}
+
+func TestJobWaiterResource_Metadata(t *testing.T) {
+	r := NewJobWaiterResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_job_waiter" {
+		t.Errorf("expected TypeName 'truenas_job_waiter', got %q", resp.TypeName)
+	}
+}
+
+func TestJobWaiterResource_Schema(t *testing.T) {
+	schemaResp := getJobWaiterResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["job_id"].IsRequired() {
+		t.Error("expected 'job_id' attribute to be required")
+	}
+	for _, name := range []string{"timeout", "fail_on_error"} {
+		if !attrs[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	for _, name := range []string{"id", "method", "state", "error", "result"} {
+		if !attrs[name].IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getJobWaiterResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewJobWaiterResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// jobWaiterModelParams holds parameters for creating test model values.
+type jobWaiterModelParams struct {
+	ID          interface{}
+	JobID       interface{}
+	Timeout     interface{}
+	FailOnError interface{}
+	Method      interface{}
+	State       interface{}
+	Error       interface{}
+	Result      interface{}
+}
+
+func createJobWaiterModelValue(p jobWaiterModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":            tftypes.String,
+			"job_id":        tftypes.Number,
+			"timeout":       tftypes.Number,
+			"fail_on_error": tftypes.Bool,
+			"method":        tftypes.String,
+			"state":         tftypes.String,
+			"error":         tftypes.String,
+			"result":        tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, p.ID),
+		"job_id":        tftypes.NewValue(tftypes.Number, p.JobID),
+		"timeout":       tftypes.NewValue(tftypes.Number, p.Timeout),
+		"fail_on_error": tftypes.NewValue(tftypes.Bool, p.FailOnError),
+		"method":        tftypes.NewValue(tftypes.String, p.Method),
+		"state":         tftypes.NewValue(tftypes.String, p.State),
+		"error":         tftypes.NewValue(tftypes.String, p.Error),
+		"result":        tftypes.NewValue(tftypes.String, p.Result),
+	})
+}
+
+func defaultJobWaiterPlanParams() jobWaiterModelParams {
+	return jobWaiterModelParams{
+		ID:          tftypes.UnknownValue,
+		JobID:       float64(42),
+		Timeout:     float64(60),
+		FailOnError: true,
+		Method:      tftypes.UnknownValue,
+		State:       tftypes.UnknownValue,
+		Error:       tftypes.UnknownValue,
+		Result:      tftypes.UnknownValue,
+	}
+}
+
+// newJobWaiterTestResource returns a resource whose core.get_jobs calls return
+// the given responses in order, repeating the last one. A core.get_jobs change
+// event is sent after each non-final response so the wait re-checks immediately.
+func newJobWaiterTestResource(t *testing.T, responses ...string) (*JobWaiterResource, *int) {
+	t.Helper()
+
+	events := make(chan json.RawMessage, len(responses))
+	calls := 0
+	return &JobWaiterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+				if collection != "core.get_jobs" {
+					t.Errorf("expected collection 'core.get_jobs', got %q", collection)
+				}
+				return truenas.NewSubscription[json.RawMessage](events, func() {}), nil
+			},
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "core.get_jobs" {
+					t.Errorf("expected method 'core.get_jobs', got %q", method)
+				}
+				i := calls
+				calls++
+				if i >= len(responses)-1 {
+					return json.RawMessage(responses[len(responses)-1]), nil
+				}
+				events <- json.RawMessage(`{"id": 42}`)
+				return json.RawMessage(responses[i]), nil
+			},
+		}},
+	}, &calls
+}
+
+func createJobWaiter(t *testing.T, r *JobWaiterResource, p jobWaiterModelParams) *resource.CreateResponse {
+	t.Helper()
+
+	schemaResp := getJobWaiterResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createJobWaiterModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+	return resp
+}
+
+func TestJobWaiterResource_Create_WaitsForSuccess(t *testing.T) {
+	r, calls := newJobWaiterTestResource(t,
+		`[{"id": 42, "method": "pool.scrub.scrub", "state": "RUNNING", "error": null, "result": null}]`,
+		`[{"id": 42, "method": "pool.scrub.scrub", "state": "RUNNING", "error": null, "result": null}]`,
+		`[{"id": 42, "method": "pool.scrub.scrub", "state": "SUCCESS", "error": null, "result": {"errors": 0}}]`,
+	)
+
+	resp := createJobWaiter(t, r, defaultJobWaiterPlanParams())
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 job queries, got %d", *calls)
+	}
+
+	var model JobWaiterResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.ID.ValueString() != "42" {
+		t.Errorf("expected ID '42', got %q", model.ID.ValueString())
+	}
+	if model.State.ValueString() != "SUCCESS" {
+		t.Errorf("expected state SUCCESS, got %q", model.State.ValueString())
+	}
+	if model.Method.ValueString() != "pool.scrub.scrub" {
+		t.Errorf("expected method 'pool.scrub.scrub', got %q", model.Method.ValueString())
+	}
+	if model.Result.ValueString() != `{"errors": 0}` {
+		t.Errorf("expected JSON result, got %q", model.Result.ValueString())
+	}
+	if !model.Error.IsNull() {
+		t.Errorf("expected null error, got %q", model.Error.ValueString())
+	}
+}
+
+func TestJobWaiterResource_Create_JobFailed(t *testing.T) {
+	r, _ := newJobWaiterTestResource(t,
+		`[{"id": 42, "method": "replication.run", "state": "FAILED", "error": "[EFAULT] No snapshots", "result": null}]`,
+	)
+
+	resp := createJobWaiter(t, r, defaultJobWaiterPlanParams())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for failed job")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Job Failed" {
+		t.Errorf("expected 'Job Failed' error, got %q", summary)
+	}
+}
+
+func TestJobWaiterResource_Create_JobFailedIgnored(t *testing.T) {
+	r, _ := newJobWaiterTestResource(t,
+		`[{"id": 42, "method": "replication.run", "state": "ABORTED", "error": "Aborted by user", "result": null}]`,
+	)
+
+	p := defaultJobWaiterPlanParams()
+	p.FailOnError = false
+	resp := createJobWaiter(t, r, p)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model JobWaiterResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.State.ValueString() != "ABORTED" {
+		t.Errorf("expected state ABORTED, got %q", model.State.ValueString())
+	}
+	if model.Error.ValueString() != "Aborted by user" {
+		t.Errorf("expected error 'Aborted by user', got %q", model.Error.ValueString())
+	}
+}
+
+func TestJobWaiterResource_Create_JobNotFound(t *testing.T) {
+	r, _ := newJobWaiterTestResource(t, `[]`)
+
+	resp := createJobWaiter(t, r, defaultJobWaiterPlanParams())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for missing job")
+	}
+}
+
+func TestJobWaiterResource_Create_Timeout(t *testing.T) {
+	r, _ := newJobWaiterTestResource(t,
+		`[{"id": 42, "method": "pool.scrub.scrub", "state": "RUNNING", "error": null, "result": null}]`,
+	)
+
+	p := defaultJobWaiterPlanParams()
+	p.Timeout = float64(1)
+	resp := createJobWaiter(t, r, p)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the job does not finish in time")
+	}
+}
+
+func TestJobWaiterResource_Create_APIError(t *testing.T) {
+	r := &JobWaiterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	resp := createJobWaiter(t, r, defaultJobWaiterPlanParams())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestJobWaiterResource_Read_KeepsState(t *testing.T) {
+	r := &JobWaiterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Fatalf("expected no API calls, got %q", method)
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getJobWaiterResourceSchema(t)
+	state := createJobWaiterModelValue(jobWaiterModelParams{
+		ID:          "42",
+		JobID:       float64(42),
+		Timeout:     float64(600),
+		FailOnError: true,
+		Method:      "pool.scrub.scrub",
+		State:       "SUCCESS",
+	})
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.Equal(state) {
+		t.Error("expected state to be kept as recorded")
+	}
+}
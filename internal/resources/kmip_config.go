@@ -0,0 +1,290 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &KMIPConfigResource{}
+	_ resource.ResourceWithConfigure   = &KMIPConfigResource{}
+	_ resource.ResourceWithImportState = &KMIPConfigResource{}
+)
+
+// KMIPConfigResourceModel describes the resource data model.
+type KMIPConfigResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	Server               types.String `tfsdk:"server"`
+	Port                 types.Int64  `tfsdk:"port"`
+	Certificate          types.Int64  `tfsdk:"certificate"`
+	CertificateAuthority types.Int64  `tfsdk:"certificate_authority"`
+	ManageSEDDisks       types.Bool   `tfsdk:"manage_sed_disks"`
+	ManageZFSKeys        types.Bool   `tfsdk:"manage_zfs_keys"`
+	Validate             types.Bool   `tfsdk:"validate"`
+}
+
+// kmipConfigResponse is the JSON shape returned by kmip.config and kmip.update.
+type kmipConfigResponse struct {
+	ID                   int64   `json:"id"`
+	Enabled              bool    `json:"enabled"`
+	Server               *string `json:"server"`
+	Port                 int64   `json:"port"`
+	Certificate          *int64  `json:"certificate"`
+	CertificateAuthority *int64  `json:"certificate_authority"`
+	ManageSEDDisks       bool    `json:"manage_sed_disks"`
+	ManageZFSKeys        bool    `json:"manage_zfs_keys"`
+}
+
+// KMIPConfigResource defines the resource implementation.
+type KMIPConfigResource struct {
+	BaseResource
+}
+
+// NewKMIPConfigResource creates a new KMIPConfigResource.
+func NewKMIPConfigResource() resource.Resource {
+	return &KMIPConfigResource{}
+}
+
+func (r *KMIPConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kmip_config"
+}
+
+func (r *KMIPConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the KMIP (Key Management Interoperability Protocol) configuration on TrueNAS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'kmip_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Enable the KMIP integration. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"server": schema.StringAttribute{
+				Description: "Hostname or IP address of the KMIP server.",
+				Required:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "Port of the KMIP server. Defaults to 5696.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(5696),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"certificate": schema.Int64Attribute{
+				Description: "ID of the client certificate TrueNAS uses to authenticate to the KMIP server.",
+				Required:    true,
+			},
+			"certificate_authority": schema.Int64Attribute{
+				Description: "ID of the certificate authority used to verify the KMIP server.",
+				Required:    true,
+			},
+			"manage_sed_disks": schema.BoolAttribute{
+				Description: "Store self-encrypting drive passwords on the KMIP server. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"manage_zfs_keys": schema.BoolAttribute{
+				Description: "Store ZFS dataset encryption keys on the KMIP server. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"validate": schema.BoolAttribute{
+				Description: "Verify connectivity to the KMIP server before saving. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *KMIPConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KMIPConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildKMIPConfigParams(&data, nil))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update KMIP Config",
+			fmt.Sprintf("Unable to update KMIP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapKMIPConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KMIPConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KMIPConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "kmip.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read KMIP Config",
+			fmt.Sprintf("Unable to read KMIP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config kmipConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse KMIP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapKMIPConfigToModel(&config, &data)
+
+	// validate is not returned by the API
+	if data.Validate.IsNull() {
+		data.Validate = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *KMIPConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state KMIPConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildKMIPConfigParams(&plan, &state))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update KMIP Config",
+			fmt.Sprintf("Unable to update KMIP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapKMIPConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *KMIPConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Disable KMIP and stop managing keys; keys already pushed to the
+	// server are pulled back to TrueNAS by the middleware.
+	params := map[string]any{
+		"enabled":          false,
+		"manage_sed_disks": false,
+		"manage_zfs_keys":  false,
+		"validate":         false,
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset KMIP Config",
+			fmt.Sprintf("Unable to reset KMIP configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *KMIPConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "kmip_config"
+	if req.ID != "kmip_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'kmip_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateConfig calls kmip.update, waits for the job, and parses the response.
+func (r *KMIPConfigResource) updateConfig(ctx context.Context, params map[string]any) (*kmipConfigResponse, error) {
+	result, err := r.client.CallAndWait(ctx, "kmip.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config kmipConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse kmip update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildKMIPConfigParams builds the kmip.update params from the resource model.
+// When state is given and the server changed, change_server is set so the
+// middleware migrates managed keys to the new server.
+func buildKMIPConfigParams(data *KMIPConfigResourceModel, state *KMIPConfigResourceModel) map[string]any {
+	params := map[string]any{
+		"enabled":               data.Enabled.ValueBool(),
+		"server":                data.Server.ValueString(),
+		"port":                  data.Port.ValueInt64(),
+		"certificate":           data.Certificate.ValueInt64(),
+		"certificate_authority": data.CertificateAuthority.ValueInt64(),
+		"manage_sed_disks":      data.ManageSEDDisks.ValueBool(),
+		"manage_zfs_keys":       data.ManageZFSKeys.ValueBool(),
+		"validate":              data.Validate.ValueBool(),
+	}
+
+	if state != nil && !state.Server.Equal(data.Server) {
+		params["change_server"] = true
+	}
+
+	return params
+}
+
+// mapKMIPConfigToModel maps the API response to the resource model.
+func mapKMIPConfigToModel(config *kmipConfigResponse, data *KMIPConfigResourceModel) {
+	data.ID = types.StringValue("kmip_config")
+	data.Enabled = types.BoolValue(config.Enabled)
+	if config.Server != nil {
+		data.Server = nonEmptyStringValue(*config.Server)
+	} else {
+		data.Server = types.StringNull()
+	}
+	data.Port = types.Int64Value(config.Port)
+	data.Certificate = nilableInt64Value(config.Certificate)
+	data.CertificateAuthority = nilableInt64Value(config.CertificateAuthority)
+	data.ManageSEDDisks = types.BoolValue(config.ManageSEDDisks)
+	data.ManageZFSKeys = types.BoolValue(config.ManageZFSKeys)
+}
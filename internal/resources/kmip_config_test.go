@@ -0,0 +1,506 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewKMIPConfigResource(t *testing.T) {
+	r := NewKMIPConfigResource()
+	if r == nil {
+		t.Fatal("NewKMIPConfigResource returned nil")
+	}
+
+	_, ok := r.(*KMIPConfigResource)
+	if !ok {
+		t.Fatalf("expected *KMIPConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*KMIPConfigResource))
+	_ = resource.ResourceWithImportState(r.(*KMIPConfigResource))
+}
+
+func TestKMIPConfigResource_Metadata(t *testing.T) {
+	r := NewKMIPConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_kmip_config" {
+		t.Errorf("expected TypeName 'truenas_kmip_config', got %q", resp.TypeName)
+	}
+}
+
+func TestKMIPConfigResource_Schema(t *testing.T) {
+	schemaResp := getKMIPConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	for _, name := range []string{"server", "certificate", "certificate_authority"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	for _, name := range []string{"enabled", "port", "manage_sed_disks", "manage_zfs_keys", "validate"} {
+		if !attrs[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getKMIPConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewKMIPConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// kmipConfigModelParams holds parameters for creating test model values.
+type kmipConfigModelParams struct {
+	ID                   interface{}
+	Enabled              interface{}
+	Server               interface{}
+	Port                 interface{}
+	Certificate          interface{}
+	CertificateAuthority interface{}
+	ManageSEDDisks       interface{}
+	ManageZFSKeys        interface{}
+	Validate             interface{}
+}
+
+func createKMIPConfigModelValue(p kmipConfigModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                    tftypes.String,
+			"enabled":               tftypes.Bool,
+			"server":                tftypes.String,
+			"port":                  tftypes.Number,
+			"certificate":           tftypes.Number,
+			"certificate_authority": tftypes.Number,
+			"manage_sed_disks":      tftypes.Bool,
+			"manage_zfs_keys":       tftypes.Bool,
+			"validate":              tftypes.Bool,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":                    tftypes.NewValue(tftypes.String, p.ID),
+		"enabled":               tftypes.NewValue(tftypes.Bool, p.Enabled),
+		"server":                tftypes.NewValue(tftypes.String, p.Server),
+		"port":                  tftypes.NewValue(tftypes.Number, p.Port),
+		"certificate":           tftypes.NewValue(tftypes.Number, p.Certificate),
+		"certificate_authority": tftypes.NewValue(tftypes.Number, p.CertificateAuthority),
+		"manage_sed_disks":      tftypes.NewValue(tftypes.Bool, p.ManageSEDDisks),
+		"manage_zfs_keys":       tftypes.NewValue(tftypes.Bool, p.ManageZFSKeys),
+		"validate":              tftypes.NewValue(tftypes.Bool, p.Validate),
+	})
+}
+
+func defaultKMIPConfigParams() kmipConfigModelParams {
+	return kmipConfigModelParams{
+		Enabled:              true,
+		Server:               "kmip.example.com",
+		Port:                 float64(5696),
+		Certificate:          float64(3),
+		CertificateAuthority: float64(2),
+		ManageSEDDisks:       true,
+		ManageZFSKeys:        true,
+		Validate:             true,
+	}
+}
+
+const testKMIPConfigJSON = `{
+	"id": 1,
+	"enabled": true,
+	"server": "kmip.example.com",
+	"port": 5696,
+	"certificate": 3,
+	"certificate_authority": 2,
+	"manage_sed_disks": true,
+	"manage_zfs_keys": true
+}`
+
+func TestKMIPConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testKMIPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	planValue := createKMIPConfigModelValue(defaultKMIPConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "kmip.update" {
+		t.Errorf("expected method 'kmip.update', got %q", capturedMethod)
+	}
+	if capturedParams["server"] != "kmip.example.com" {
+		t.Errorf("expected server 'kmip.example.com', got %v", capturedParams["server"])
+	}
+	if capturedParams["certificate"] != int64(3) || capturedParams["certificate_authority"] != int64(2) {
+		t.Errorf("expected certificate 3 and CA 2, got %v and %v", capturedParams["certificate"], capturedParams["certificate_authority"])
+	}
+	if _, ok := capturedParams["change_server"]; ok {
+		t.Error("expected change_server to be omitted on create")
+	}
+
+	var model KMIPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "kmip_config" {
+		t.Errorf("expected ID 'kmip_config', got %q", model.ID.ValueString())
+	}
+	if !model.ManageSEDDisks.ValueBool() {
+		t.Error("expected manage_sed_disks to be true")
+	}
+	if !model.Validate.ValueBool() {
+		t.Error("expected validate to be preserved from plan")
+	}
+}
+
+func TestKMIPConfigResource_Create_APIError(t *testing.T) {
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("unable to connect to kmip server")
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	planValue := createKMIPConfigModelValue(defaultKMIPConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestKMIPConfigResource_Read_Success(t *testing.T) {
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "kmip.config" {
+					t.Errorf("expected method 'kmip.config', got %q", method)
+				}
+				return json.RawMessage(testKMIPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	stateValue := createKMIPConfigModelValue(kmipConfigModelParams{ID: "kmip_config"})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model KMIPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Server.ValueString() != "kmip.example.com" {
+		t.Errorf("expected server 'kmip.example.com', got %q", model.Server.ValueString())
+	}
+	if model.CertificateAuthority.ValueInt64() != 2 {
+		t.Errorf("expected certificate_authority 2, got %d", model.CertificateAuthority.ValueInt64())
+	}
+	if !model.Validate.ValueBool() {
+		t.Error("expected validate to default to true after import")
+	}
+}
+
+func TestKMIPConfigResource_Read_APIError(t *testing.T) {
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	p := defaultKMIPConfigParams()
+	p.ID = "kmip_config"
+	stateValue := createKMIPConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestKMIPConfigResource_Update_ChangeServer(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "enabled": true, "server": "kmip2.example.com", "port": 5696, "certificate": 3, "certificate_authority": 2, "manage_sed_disks": true, "manage_zfs_keys": true}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	state := defaultKMIPConfigParams()
+	state.ID = "kmip_config"
+	plan := defaultKMIPConfigParams()
+	plan.ID = "kmip_config"
+	plan.Server = "kmip2.example.com"
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createKMIPConfigModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createKMIPConfigModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["change_server"] != true {
+		t.Errorf("expected change_server true when server changes, got %v", capturedParams["change_server"])
+	}
+}
+
+func TestKMIPConfigResource_Update_SameServer(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testKMIPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	state := defaultKMIPConfigParams()
+	state.ID = "kmip_config"
+	state.ManageSEDDisks = false
+	plan := defaultKMIPConfigParams()
+	plan.ID = "kmip_config"
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createKMIPConfigModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createKMIPConfigModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if _, ok := capturedParams["change_server"]; ok {
+		t.Error("expected change_server to be omitted when server is unchanged")
+	}
+	if capturedParams["manage_sed_disks"] != true {
+		t.Errorf("expected manage_sed_disks true, got %v", capturedParams["manage_sed_disks"])
+	}
+}
+
+func TestKMIPConfigResource_Update_APIError(t *testing.T) {
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	p := defaultKMIPConfigParams()
+	p.ID = "kmip_config"
+	value := createKMIPConfigModelValue(p)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestKMIPConfigResource_Delete_Disables(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "enabled": false}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	p := defaultKMIPConfigParams()
+	p.ID = "kmip_config"
+	stateValue := createKMIPConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	for _, key := range []string{"enabled", "manage_sed_disks", "manage_zfs_keys"} {
+		if capturedParams[key] != false {
+			t.Errorf("expected %s false, got %v", key, capturedParams[key])
+		}
+	}
+}
+
+func TestKMIPConfigResource_Delete_APIError(t *testing.T) {
+	r := &KMIPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallAndWaitFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getKMIPConfigResourceSchema(t)
+	p := defaultKMIPConfigParams()
+	p.ID = "kmip_config"
+	stateValue := createKMIPConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestKMIPConfigResource_ImportState(t *testing.T) {
+	r := NewKMIPConfigResource().(*KMIPConfigResource)
+	schemaResp := getKMIPConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "kmip_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createKMIPConfigModelValue(kmipConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestKMIPConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewKMIPConfigResource().(*KMIPConfigResource)
+	schemaResp := getKMIPConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "kmip"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createKMIPConfigModelValue(kmipConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// maintenanceJobPrefixes are the method prefixes of running jobs that block
+// destructive pool and dataset operations.
+var maintenanceJobPrefixes = []string{"update."}
+
+// maintenancePoolResponse is the subset of pool.query fields the guard reads.
+type maintenancePoolResponse struct {
+	Name string `json:"name"`
+	Scan *struct {
+		Function   string   `json:"function"`
+		State      string   `json:"state"`
+		Percentage *float64 `json:"percentage"`
+	} `json:"scan"`
+}
+
+// maintenanceJobResponse is the subset of core.get_jobs fields the guard reads.
+type maintenanceJobResponse struct {
+	ID       int64  `json:"id"`
+	Method   string `json:"method"`
+	Progress struct {
+		Percent *float64 `json:"percent"`
+	} `json:"progress"`
+}
+
+// maintenanceBlockers describes the running pool scrubs and resilvers and
+// system update jobs, one entry per operation.
+func maintenanceBlockers(ctx context.Context, c client.Client) ([]string, error) {
+	var blockers []string
+
+	result, err := c.Call(ctx, "pool.query", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query pools: %w", err)
+	}
+	var pools []maintenancePoolResponse
+	if err := json.Unmarshal(result, &pools); err != nil {
+		return nil, fmt.Errorf("parse pool query response: %w", err)
+	}
+	for _, pool := range pools {
+		if pool.Scan == nil || pool.Scan.State != "SCANNING" {
+			continue
+		}
+		blockers = append(blockers, fmt.Sprintf("%s of pool %q%s",
+			strings.ToLower(pool.Scan.Function), pool.Name, percentDone(pool.Scan.Percentage)))
+	}
+
+	result, err = c.Call(ctx, "core.get_jobs", []any{[]any{[]any{"state", "=", "RUNNING"}}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query jobs: %w", err)
+	}
+	var jobs []maintenanceJobResponse
+	if err := json.Unmarshal(result, &jobs); err != nil {
+		return nil, fmt.Errorf("parse job query response: %w", err)
+	}
+	for _, job := range jobs {
+		for _, prefix := range maintenanceJobPrefixes {
+			if strings.HasPrefix(job.Method, prefix) {
+				blockers = append(blockers, fmt.Sprintf("job %d %s%s", job.ID, job.Method, percentDone(job.Progress.Percent)))
+				break
+			}
+		}
+	}
+
+	return blockers, nil
+}
+
+// percentDone formats an optional progress percentage for a blocker entry.
+func percentDone(percent *float64) string {
+	if percent == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (%.0f%% done)", *percent)
+}
+
+// checkMaintenance refuses a destructive operation, described like
+// `destroy of dataset "tank/apps"`, while a pool scrub or
+// resilver or a system update is running, when the provider is configured
+// with maintenance_guard. With maintenance_guard_timeout it first waits for
+// the maintenance to finish.
+func (b *BaseResource) checkMaintenance(ctx context.Context, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if b.services == nil || !b.services.MaintenanceGuard {
+		return diags
+	}
+
+	blockers, err := maintenanceBlockers(ctx, b.client)
+	if err == nil && len(blockers) > 0 && b.services.MaintenanceTimeout > 0 {
+		err = waitForCollection(ctx, b.client, "core.get_jobs", b.services.MaintenanceTimeout, func(ctx context.Context) (bool, error) {
+			var checkErr error
+			blockers, checkErr = maintenanceBlockers(ctx, b.client)
+			return len(blockers) == 0, checkErr
+		})
+		if err != nil && len(blockers) > 0 {
+			// Timed out (or was cancelled) with maintenance still running
+			err = nil
+		}
+	}
+	if err != nil {
+		diags.AddError(
+			"Unable to Check Maintenance",
+			fmt.Sprintf("maintenance_guard could not check for running maintenance before the %s: %s", operation, err.Error()),
+		)
+		return diags
+	}
+
+	if len(blockers) > 0 {
+		diags.AddError(
+			"Blocked by Maintenance",
+			fmt.Sprintf("maintenance_guard refused the %s because maintenance is running on the TrueNAS host:\n"+
+				"  - %s\n"+
+				"Retry once it finishes, or raise maintenance_guard_timeout to wait for it.",
+				operation, strings.Join(blockers, "\n  - ")),
+		)
+	}
+
+	return diags
+}
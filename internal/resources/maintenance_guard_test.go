@@ -0,0 +1,193 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+const (
+	testIdlePoolsJSON       = `[{"name": "tank", "scan": {"function": "SCRUB", "state": "FINISHED", "percentage": 100}}]`
+	testResilveringPoolJSON = `[{"name": "tank", "scan": {"function": "RESILVER", "state": "SCANNING", "percentage": 42.4}}, {"name": "boot-pool", "scan": null}]`
+)
+
+// maintenanceClient answers pool.query and core.get_jobs from the given
+// functions, so tests can change the answers between checks.
+func maintenanceClient(pools, jobs func() string) *client.MockClient {
+	return &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			switch method {
+			case "pool.query":
+				return json.RawMessage(pools()), nil
+			case "core.get_jobs":
+				return json.RawMessage(jobs()), nil
+			}
+			return nil, errors.New("unexpected method " + method)
+		},
+	}
+}
+
+func staticResponse(s string) func() string {
+	return func() string { return s }
+}
+
+func TestMaintenanceBlockers(t *testing.T) {
+	c := maintenanceClient(staticResponse(testResilveringPoolJSON), staticResponse(`[
+		{"id": 812, "method": "update.update", "progress": {"percent": 10}},
+		{"id": 813, "method": "pool.dataset.snapshot_task.run", "progress": {"percent": null}}
+	]`))
+
+	blockers, err := maintenanceBlockers(context.Background(), c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{`resilver of pool "tank" (42% done)`, "job 812 update.update (10% done)"}
+	if len(blockers) != len(want) {
+		t.Fatalf("expected %v, got %v", want, blockers)
+	}
+	for i := range want {
+		if blockers[i] != want[i] {
+			t.Errorf("expected blocker %q, got %q", want[i], blockers[i])
+		}
+	}
+}
+
+func TestCheckMaintenance_Disabled(t *testing.T) {
+	b := &BaseResource{
+		services: &services.TrueNASServices{},
+		client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Errorf("expected no API calls with maintenance_guard disabled, got %q", method)
+				return nil, nil
+			},
+		},
+	}
+
+	if diags := b.checkMaintenance(context.Background(), `destroy of dataset "tank/apps"`); diags.HasError() {
+		t.Errorf("unexpected errors: %v", diags)
+	}
+}
+
+func TestCheckMaintenance_NoMaintenance(t *testing.T) {
+	b := &BaseResource{
+		services: &services.TrueNASServices{MaintenanceGuard: true},
+		client:   maintenanceClient(staticResponse(testIdlePoolsJSON), staticResponse(`[]`)),
+	}
+
+	if diags := b.checkMaintenance(context.Background(), `destroy of dataset "tank/apps"`); diags.HasError() {
+		t.Errorf("unexpected errors: %v", diags)
+	}
+}
+
+func TestCheckMaintenance_Blocked(t *testing.T) {
+	b := &BaseResource{
+		services: &services.TrueNASServices{MaintenanceGuard: true},
+		client:   maintenanceClient(staticResponse(testResilveringPoolJSON), staticResponse(`[]`)),
+	}
+
+	diags := b.checkMaintenance(context.Background(), `destroy of dataset "tank/apps"`)
+	if !diags.HasError() {
+		t.Fatal("expected error while a resilver is running")
+	}
+	if diags.Errors()[0].Summary() != "Blocked by Maintenance" {
+		t.Errorf("expected 'Blocked by Maintenance', got %q", diags.Errors()[0].Summary())
+	}
+	detail := diags.Errors()[0].Detail()
+	for _, want := range []string{`destroy of dataset "tank/apps"`, `resilver of pool "tank"`} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected detail to contain %q, got %q", want, detail)
+		}
+	}
+}
+
+func TestCheckMaintenance_WaitsForMaintenance(t *testing.T) {
+	checks := 0
+	pools := func() string {
+		checks++
+		if checks < 3 {
+			return testResilveringPoolJSON
+		}
+		return testIdlePoolsJSON
+	}
+
+	b := &BaseResource{
+		services: &services.TrueNASServices{MaintenanceGuard: true, MaintenanceTimeout: time.Second},
+		client:   maintenanceClient(pools, staticResponse(`[]`)),
+	}
+
+	if diags := b.checkMaintenance(context.Background(), `wipe of disk "sdb"`); diags.HasError() {
+		t.Errorf("unexpected errors: %v", diags)
+	}
+	if checks != 3 {
+		t.Errorf("expected 3 checks, got %d", checks)
+	}
+}
+
+func TestCheckMaintenance_WaitTimesOut(t *testing.T) {
+	b := &BaseResource{
+		services: &services.TrueNASServices{MaintenanceGuard: true, MaintenanceTimeout: 100 * time.Millisecond},
+		client:   maintenanceClient(staticResponse(testResilveringPoolJSON), staticResponse(`[]`)),
+	}
+
+	diags := b.checkMaintenance(context.Background(), `wipe of disk "sdb"`)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Blocked by Maintenance" {
+		t.Errorf("expected 'Blocked by Maintenance' after the wait, got %v", diags)
+	}
+}
+
+func TestCheckMaintenance_QueryError(t *testing.T) {
+	b := &BaseResource{
+		services: &services.TrueNASServices{MaintenanceGuard: true},
+		client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		},
+	}
+
+	diags := b.checkMaintenance(context.Background(), `destroy of zvol "tank/vm0"`)
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Unable to Check Maintenance" {
+		t.Errorf("expected 'Unable to Check Maintenance', got %v", diags)
+	}
+}
+
+func TestDatasetResource_Delete_BlockedByMaintenance(t *testing.T) {
+	s := &services.TrueNASServices{
+		MaintenanceGuard: true,
+		Client:           maintenanceClient(staticResponse(testResilveringPoolJSON), staticResponse(`[]`)),
+		Dataset: &truenas.MockDatasetService{
+			DeleteDatasetFunc: func(ctx context.Context, id string, recursive bool) error {
+				t.Error("expected dataset not to be deleted during maintenance")
+				return nil
+			},
+		},
+	}
+	r := &DatasetResource{BaseResource: BaseResource{services: s, client: s.Client}}
+
+	schemaResp := getDatasetResourceSchema(t)
+	stateValue := createDatasetResourceModelValue(datasetModelParams{
+		ID:   "tank/apps",
+		Pool: "tank",
+		Path: "apps",
+	})
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected delete to be refused during maintenance")
+	}
+}
@@ -0,0 +1,538 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &NFSConfigResource{}
+	_ resource.ResourceWithConfigure      = &NFSConfigResource{}
+	_ resource.ResourceWithImportState    = &NFSConfigResource{}
+	_ resource.ResourceWithValidateConfig = &NFSConfigResource{}
+	_ resource.ResourceWithModifyPlan     = &NFSConfigResource{}
+)
+
+// NFSConfigResourceModel describes the resource data model.
+type NFSConfigResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Servers         types.Int64  `tfsdk:"servers"`
+	AllowNonroot    types.Bool   `tfsdk:"allow_nonroot"`
+	Protocols       types.Set    `tfsdk:"protocols"`
+	V4Krb           types.Bool   `tfsdk:"v4_krb"`
+	V4Domain        types.String `tfsdk:"v4_domain"`
+	BindIP          types.Set    `tfsdk:"bindip"`
+	MountdPort      types.Int64  `tfsdk:"mountd_port"`
+	RPCStatdPort    types.Int64  `tfsdk:"rpcstatd_port"`
+	RPCLockdPort    types.Int64  `tfsdk:"rpclockd_port"`
+	MountdLog       types.Bool   `tfsdk:"mountd_log"`
+	StatdLockdLog   types.Bool   `tfsdk:"statd_lockd_log"`
+	UserdManageGids types.Bool   `tfsdk:"userd_manage_gids"`
+	RDMA            types.Bool   `tfsdk:"rdma"`
+}
+
+// nfsConfigResponse is the JSON shape returned by nfs.config and nfs.update.
+type nfsConfigResponse struct {
+	Servers         *int64   `json:"servers"`
+	ManagedNFSD     *bool    `json:"managed_nfsd"`
+	AllowNonroot    bool     `json:"allow_nonroot"`
+	Protocols       []string `json:"protocols"`
+	V4Krb           bool     `json:"v4_krb"`
+	V4Domain        string   `json:"v4_domain"`
+	BindIP          []string `json:"bindip"`
+	MountdPort      *int64   `json:"mountd_port"`
+	RPCStatdPort    *int64   `json:"rpcstatd_port"`
+	RPCLockdPort    *int64   `json:"rpclockd_port"`
+	MountdLog       bool     `json:"mountd_log"`
+	StatdLockdLog   bool     `json:"statd_lockd_log"`
+	UserdManageGids bool     `json:"userd_manage_gids"`
+	RDMA            *bool    `json:"rdma"`
+}
+
+// nfsServerPorts are the attributes binding NFS helper daemons to fixed
+// ports, so firewalls can allow them.
+var nfsServerPorts = []string{"mountd_port", "rpcstatd_port", "rpclockd_port"}
+
+// NFSConfigResource defines the resource implementation.
+type NFSConfigResource struct {
+	BaseResource
+}
+
+// NewNFSConfigResource creates a new NFSConfigResource.
+func NewNFSConfigResource() resource.Resource {
+	return &NFSConfigResource{}
+}
+
+func (r *NFSConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nfs_config"
+}
+
+func (r *NFSConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	portValidators := []validator.Int64{int64validator.Between(1, 65535)}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the TrueNAS NFS service configuration. This is a singleton resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (always 'nfs_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"servers": schema.Int64Attribute{
+				Description: "Number of nfsd threads. Leave unset to let TrueNAS size it from the CPU count.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 256),
+				},
+			},
+			"allow_nonroot": schema.BoolAttribute{
+				Description: "Allow mount requests from non-root ports (the 'insecure' export option). Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"protocols": schema.SetAttribute{
+				Description: "NFS protocols to serve: NFSV3, NFSV4 or both. Defaults to both.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default: setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue("NFSV3"),
+					types.StringValue("NFSV4"),
+				})),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("NFSV3", "NFSV4")),
+				},
+			},
+			"v4_krb": schema.BoolAttribute{
+				Description: "Require Kerberos authentication on NFSv4 shares. Requires NFSV4 in protocols. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"v4_domain": schema.StringAttribute{
+				Description: "NFSv4 ID mapping domain. Requires NFSV4 in protocols. Defaults to empty, which uses the DNS domain.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"bindip": schema.SetAttribute{
+				Description: "IP addresses NFS listens on. Defaults to empty, which listens on all addresses.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"mountd_port": schema.Int64Attribute{
+				Description: "Fixed port for mountd. Leave unset for a dynamic port.",
+				Optional:    true,
+				Validators:  portValidators,
+			},
+			"rpcstatd_port": schema.Int64Attribute{
+				Description: "Fixed port for rpc.statd. Leave unset for a dynamic port.",
+				Optional:    true,
+				Validators:  portValidators,
+			},
+			"rpclockd_port": schema.Int64Attribute{
+				Description: "Fixed port for rpc.lockd. Leave unset for a dynamic port.",
+				Optional:    true,
+				Validators:  portValidators,
+			},
+			"mountd_log": schema.BoolAttribute{
+				Description: "Log mountd requests. Left as configured on the NAS when unset.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"statd_lockd_log": schema.BoolAttribute{
+				Description: "Log rpc.statd and rpc.lockd. Left as configured on the NAS when unset.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"userd_manage_gids": schema.BoolAttribute{
+				Description: "Let the server look up group membership instead of trusting the client's list, " +
+					"for users in more than 16 groups. Left as configured on the NAS when unset.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rdma": schema.BoolAttribute{
+				Description: "Serve NFS over RDMA. Requires an RDMA capable NIC and TrueNAS 25.04 or later. " +
+					"Left as configured on the NAS when unset.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NFSConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NFSConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NFSv4 settings are only checked against a known protocol list; the
+	// default serves NFSv4.
+	if !data.Protocols.IsNull() && !data.Protocols.IsUnknown() {
+		var protocols []string
+		resp.Diagnostics.Append(data.Protocols.ElementsAs(ctx, &protocols, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !slices.Contains(protocols, "NFSV4") {
+			if data.V4Krb.ValueBool() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("v4_krb"),
+					"Invalid NFS Configuration",
+					"v4_krb can only be enabled when protocols includes \"NFSV4\".",
+				)
+			}
+			if data.V4Domain.ValueString() != "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("v4_domain"),
+					"Invalid NFS Configuration",
+					"v4_domain can only be set when protocols includes \"NFSV4\".",
+				)
+			}
+		}
+	}
+
+	ports := []types.Int64{data.MountdPort, data.RPCStatdPort, data.RPCLockdPort}
+	seen := make(map[int64]string)
+	for i, port := range ports {
+		if port.IsNull() || port.IsUnknown() {
+			continue
+		}
+		if other, ok := seen[port.ValueInt64()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(nfsServerPorts[i]),
+				"Invalid NFS Configuration",
+				fmt.Sprintf("%s and %s cannot both use port %d.", other, nfsServerPorts[i], port.ValueInt64()),
+			)
+			continue
+		}
+		seen[port.ValueInt64()] = nfsServerPorts[i]
+	}
+}
+
+// ModifyPlan rejects settings the connected TrueNAS version does not have,
+// so the plan fails instead of nfs.update.
+func (r *NFSConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan NFSConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkNFSConfigSupported(r.client.Version(), &plan)...)
+}
+
+func (r *NFSConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NFSConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NFSConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NFSConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "nfs.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read NFS Config",
+			fmt.Sprintf("Unable to read NFS configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config nfsConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse NFS configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapNFSConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *NFSConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NFSConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NFSConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults, leaving logging and gid handling as they are
+	params := map[string]any{
+		"allow_nonroot": false,
+		"protocols":     []string{"NFSV3", "NFSV4"},
+		"v4_krb":        false,
+		"v4_domain":     "",
+		"bindip":        []string{},
+		"mountd_port":   nil,
+		"rpcstatd_port": nil,
+		"rpclockd_port": nil,
+	}
+	setNFSServersParam(params, r.client.Version(), types.Int64Null())
+	if nfsRDMASupported(r.client.Version()) {
+		params["rdma"] = false
+	}
+
+	if _, err := r.client.Call(ctx, "nfs.update", params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset NFS Config",
+			fmt.Sprintf("Unable to reset NFS configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *NFSConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "nfs_config"
+	if req.ID != "nfs_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'nfs_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// nfsConfigAPIFieldPaths maps nfs.update validation errors to attributes.
+var nfsConfigAPIFieldPaths = apiFieldPaths(
+	"servers", "allow_nonroot", "protocols", "v4_krb", "v4_domain", "bindip",
+	"mountd_port", "rpcstatd_port", "rpclockd_port",
+	"mountd_log", "statd_lockd_log", "userd_manage_gids", "rdma",
+)
+
+// apply writes the NFS settings via nfs.update and maps the result into data.
+func (r *NFSConfigResource) apply(ctx context.Context, data *NFSConfigResourceModel, diags *diag.Diagnostics) {
+	version := r.client.Version()
+	diags.Append(checkNFSConfigSupported(version, data)...)
+	if diags.HasError() {
+		return
+	}
+
+	params, d := buildNFSConfigParams(ctx, data, version)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "nfs.update", params)
+	if err != nil {
+		addAPIError(diags, err, nfsConfigAPIFieldPaths,
+			"Unable to Update NFS Config",
+			fmt.Sprintf("Unable to update NFS configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config nfsConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		diags.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse NFS configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapNFSConfigToModel(&config, data)
+}
+
+// nfsRDMASupported reports whether version has NFS over RDMA, which TrueNAS
+// added in 25.04. An undetected version is assumed to have it.
+func nfsRDMASupported(version truenas.Version) bool {
+	return version.IsZero() || version.AtLeast(25, 4)
+}
+
+// checkNFSConfigSupported rejects settings that version does not have.
+// Unknown values are left for apply to check.
+func checkNFSConfigSupported(version truenas.Version, data *NFSConfigResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.RDMA.ValueBool() && !nfsRDMASupported(version) {
+		diags.AddAttributeError(
+			path.Root("rdma"),
+			"Unsupported TrueNAS Version",
+			fmt.Sprintf("NFS over RDMA requires TrueNAS 25.04 or later. Detected version: %s", version.String()),
+		)
+	}
+	return diags
+}
+
+// setNFSServersParam sets the nfsd thread count in params. TrueNAS 25.04
+// sizes it automatically when servers is null; earlier versions do so when
+// managed_nfsd is set.
+func setNFSServersParam(params map[string]any, version truenas.Version, servers types.Int64) {
+	managed := servers.IsNull() || servers.IsUnknown()
+	if nfsRDMASupported(version) {
+		params["servers"] = nil
+		if !managed {
+			params["servers"] = servers.ValueInt64()
+		}
+		return
+	}
+
+	params["managed_nfsd"] = managed
+	if !managed {
+		params["servers"] = servers.ValueInt64()
+	}
+}
+
+// buildNFSConfigParams builds the nfs.update params from the resource model.
+// Settings left unset that the NAS keeps are not sent.
+func buildNFSConfigParams(ctx context.Context, data *NFSConfigResourceModel, version truenas.Version) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	protocols := []string{}
+	diags.Append(data.Protocols.ElementsAs(ctx, &protocols, false)...)
+	bindIP := []string{}
+	diags.Append(data.BindIP.ElementsAs(ctx, &bindIP, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	sort.Strings(protocols)
+	sort.Strings(bindIP)
+
+	params := map[string]any{
+		"allow_nonroot": data.AllowNonroot.ValueBool(),
+		"protocols":     protocols,
+		"v4_krb":        data.V4Krb.ValueBool(),
+		"v4_domain":     data.V4Domain.ValueString(),
+		"bindip":        bindIP,
+		"mountd_port":   nil,
+		"rpcstatd_port": nil,
+		"rpclockd_port": nil,
+	}
+	setNFSServersParam(params, version, data.Servers)
+
+	for i, port := range []types.Int64{data.MountdPort, data.RPCStatdPort, data.RPCLockdPort} {
+		if !port.IsNull() && !port.IsUnknown() {
+			params[nfsServerPorts[i]] = port.ValueInt64()
+		}
+	}
+
+	optional := map[string]types.Bool{
+		"mountd_log":        data.MountdLog,
+		"statd_lockd_log":   data.StatdLockdLog,
+		"userd_manage_gids": data.UserdManageGids,
+	}
+	if nfsRDMASupported(version) {
+		optional["rdma"] = data.RDMA
+	}
+	for name, value := range optional {
+		if !value.IsNull() && !value.IsUnknown() {
+			params[name] = value.ValueBool()
+		}
+	}
+
+	return params, diags
+}
+
+// mapNFSConfigToModel maps the API response to the resource model.
+func mapNFSConfigToModel(config *nfsConfigResponse, data *NFSConfigResourceModel) {
+	data.ID = types.StringValue("nfs_config")
+	data.Servers = nilableInt64Value(config.Servers)
+	// Before 25.04 servers holds the automatic count while managed
+	if config.ManagedNFSD != nil && *config.ManagedNFSD {
+		data.Servers = types.Int64Null()
+	}
+	data.AllowNonroot = types.BoolValue(config.AllowNonroot)
+	data.Protocols = stringSetValue(config.Protocols)
+	data.V4Krb = types.BoolValue(config.V4Krb)
+	data.V4Domain = types.StringValue(config.V4Domain)
+	data.BindIP = stringSetValue(config.BindIP)
+	data.MountdPort = nilableInt64Value(config.MountdPort)
+	data.RPCStatdPort = nilableInt64Value(config.RPCStatdPort)
+	data.RPCLockdPort = nilableInt64Value(config.RPCLockdPort)
+	data.MountdLog = types.BoolValue(config.MountdLog)
+	data.StatdLockdLog = types.BoolValue(config.StatdLockdLog)
+	data.UserdManageGids = types.BoolValue(config.UserdManageGids)
+	data.RDMA = types.BoolValue(config.RDMA != nil && *config.RDMA)
+}
+
+// stringSetValue converts strings to a set value.
+func stringSetValue(values []string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
@@ -0,0 +1,481 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewNFSConfigResource(t *testing.T) {
+	r := NewNFSConfigResource()
+	if r == nil {
+		t.Fatal("NewNFSConfigResource returned nil")
+	}
+
+	_, ok := r.(*NFSConfigResource)
+	if !ok {
+		t.Fatalf("expected *NFSConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*NFSConfigResource))
+	_ = resource.ResourceWithImportState(r.(*NFSConfigResource))
+	_ = resource.ResourceWithValidateConfig(r.(*NFSConfigResource))
+	_ = resource.ResourceWithModifyPlan(r.(*NFSConfigResource))
+}
+
+func TestNFSConfigResource_Metadata(t *testing.T) {
+	r := NewNFSConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_nfs_config" {
+		t.Errorf("expected TypeName 'truenas_nfs_config', got %q", resp.TypeName)
+	}
+}
+
+func TestNFSConfigResource_Schema(t *testing.T) {
+	schemaResp := getNFSConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"servers", "allow_nonroot", "protocols", "v4_krb", "v4_domain", "bindip",
+		"mountd_port", "rpcstatd_port", "rpclockd_port", "mountd_log", "statd_lockd_log", "userd_manage_gids", "rdma"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getNFSConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewNFSConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// nfsConfigModelParams holds parameters for creating test model values.
+type nfsConfigModelParams struct {
+	ID              interface{}
+	Servers         interface{}
+	AllowNonroot    interface{}
+	Protocols       []string
+	V4Krb           interface{}
+	V4Domain        interface{}
+	BindIP          []string
+	MountdPort      interface{}
+	RPCStatdPort    interface{}
+	RPCLockdPort    interface{}
+	MountdLog       interface{}
+	StatdLockdLog   interface{}
+	UserdManageGids interface{}
+	RDMA            interface{}
+}
+
+func nfsStringSet(values []string) tftypes.Value {
+	elements := make([]tftypes.Value, len(values))
+	for i, v := range values {
+		elements[i] = tftypes.NewValue(tftypes.String, v)
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+}
+
+func createNFSConfigModelValue(p nfsConfigModelParams) tftypes.Value {
+	setType := tftypes.Set{ElementType: tftypes.String}
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                tftypes.String,
+			"servers":           tftypes.Number,
+			"allow_nonroot":     tftypes.Bool,
+			"protocols":         setType,
+			"v4_krb":            tftypes.Bool,
+			"v4_domain":         tftypes.String,
+			"bindip":            setType,
+			"mountd_port":       tftypes.Number,
+			"rpcstatd_port":     tftypes.Number,
+			"rpclockd_port":     tftypes.Number,
+			"mountd_log":        tftypes.Bool,
+			"statd_lockd_log":   tftypes.Bool,
+			"userd_manage_gids": tftypes.Bool,
+			"rdma":              tftypes.Bool,
+		},
+	}, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, p.ID),
+		"servers":           tftypes.NewValue(tftypes.Number, p.Servers),
+		"allow_nonroot":     tftypes.NewValue(tftypes.Bool, p.AllowNonroot),
+		"protocols":         nfsStringSet(p.Protocols),
+		"v4_krb":            tftypes.NewValue(tftypes.Bool, p.V4Krb),
+		"v4_domain":         tftypes.NewValue(tftypes.String, p.V4Domain),
+		"bindip":            nfsStringSet(p.BindIP),
+		"mountd_port":       tftypes.NewValue(tftypes.Number, p.MountdPort),
+		"rpcstatd_port":     tftypes.NewValue(tftypes.Number, p.RPCStatdPort),
+		"rpclockd_port":     tftypes.NewValue(tftypes.Number, p.RPCLockdPort),
+		"mountd_log":        tftypes.NewValue(tftypes.Bool, p.MountdLog),
+		"statd_lockd_log":   tftypes.NewValue(tftypes.Bool, p.StatdLockdLog),
+		"userd_manage_gids": tftypes.NewValue(tftypes.Bool, p.UserdManageGids),
+		"rdma":              tftypes.NewValue(tftypes.Bool, p.RDMA),
+	})
+}
+
+func defaultNFSConfigParams() nfsConfigModelParams {
+	return nfsConfigModelParams{
+		AllowNonroot: false,
+		Protocols:    []string{"NFSV3", "NFSV4"},
+		V4Krb:        false,
+		V4Domain:     "example.com",
+		BindIP:       []string{},
+		MountdPort:   float64(618),
+		RPCStatdPort: float64(871),
+		RPCLockdPort: float64(32803),
+		RDMA:         true,
+	}
+}
+
+const testNFSConfigJSON = `{
+	"id": 1,
+	"servers": null,
+	"allow_nonroot": false,
+	"protocols": ["NFSV3", "NFSV4"],
+	"v4_krb": false,
+	"v4_domain": "example.com",
+	"bindip": [],
+	"mountd_port": 618,
+	"rpcstatd_port": 871,
+	"rpclockd_port": 32803,
+	"mountd_log": true,
+	"statd_lockd_log": false,
+	"v4_krb_enabled": false,
+	"userd_manage_gids": false,
+	"keytab_has_nfs_spn": false,
+	"managed_nfsd": true,
+	"rdma": true
+}`
+
+// newNFSConfigTestResource returns a resource on version whose nfs.update
+// params are captured in params.
+func newNFSConfigTestResource(version truenas.Version, params *map[string]any) *NFSConfigResource {
+	return &NFSConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: version,
+			CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+				if params != nil {
+					*params = p.(map[string]any)
+				}
+				return json.RawMessage(testNFSConfigJSON), nil
+			},
+		}},
+	}
+}
+
+func validateNFSConfig(t *testing.T, p nfsConfigModelParams) diag.Diagnostics {
+	t.Helper()
+
+	r := NewNFSConfigResource().(*NFSConfigResource)
+	schemaResp := getNFSConfigResourceSchema(t)
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createNFSConfigModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+	return resp.Diagnostics
+}
+
+func TestNFSConfigResource_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(p *nfsConfigModelParams)
+		wantPath string
+	}{
+		{"valid", func(p *nfsConfigModelParams) {}, ""},
+		{"v4 domain without NFSv4", func(p *nfsConfigModelParams) { p.Protocols = []string{"NFSV3"} }, "v4_domain"},
+		{"kerberos without NFSv4", func(p *nfsConfigModelParams) {
+			p.Protocols = []string{"NFSV3"}
+			p.V4Domain = ""
+			p.V4Krb = true
+		}, "v4_krb"},
+		{"NFSv3 only", func(p *nfsConfigModelParams) {
+			p.Protocols = []string{"NFSV3"}
+			p.V4Domain = ""
+		}, ""},
+		{"duplicate port", func(p *nfsConfigModelParams) { p.RPCLockdPort = float64(618) }, "rpclockd_port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := defaultNFSConfigParams()
+			tt.modify(&p)
+
+			diags := validateNFSConfig(t, p)
+
+			if tt.wantPath == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(path.Root(tt.wantPath)) {
+				t.Errorf("expected error on %s, got %v", tt.wantPath, diags)
+			}
+		})
+	}
+}
+
+func TestNFSConfigResource_ModifyPlan_RDMAVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version truenas.Version
+		rdma    interface{}
+		wantErr bool
+	}{
+		{"25.04", truenas.Version{Major: 25, Minor: 4}, true, false},
+		{"24.10", truenas.Version{Major: 24, Minor: 10, Patch: 2}, true, true},
+		{"24.10 without rdma", truenas.Version{Major: 24, Minor: 10, Patch: 2}, false, false},
+		{"24.10 rdma unknown", truenas.Version{Major: 24, Minor: 10, Patch: 2}, tftypes.UnknownValue, false},
+		{"undetected", truenas.Version{}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNFSConfigTestResource(tt.version, nil)
+			schemaResp := getNFSConfigResourceSchema(t)
+
+			p := defaultNFSConfigParams()
+			p.RDMA = tt.rdma
+			plan := createNFSConfigModelValue(p)
+			req := resource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(plan.Type(), nil)},
+			}
+			resp := &resource.ModifyPlanResponse{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+			}
+
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(path.Root("rdma")) {
+					t.Errorf("expected error on rdma, got %v", resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+func TestNFSConfigResource_Create_Success(t *testing.T) {
+	var capturedParams map[string]any
+	r := newNFSConfigTestResource(truenas.Version{Major: 25, Minor: 4}, &capturedParams)
+
+	schemaResp := getNFSConfigResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createNFSConfigModelValue(defaultNFSConfigParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if v, ok := capturedParams["servers"]; !ok || v != nil {
+		t.Errorf("expected servers to be sent as null, got %v", v)
+	}
+	if _, ok := capturedParams["managed_nfsd"]; ok {
+		t.Error("expected no managed_nfsd on 25.04")
+	}
+	if capturedParams["rdma"] != true {
+		t.Errorf("expected rdma true, got %v", capturedParams["rdma"])
+	}
+	if capturedParams["mountd_port"] != int64(618) {
+		t.Errorf("expected mountd_port 618, got %v", capturedParams["mountd_port"])
+	}
+	if _, ok := capturedParams["mountd_log"]; ok {
+		t.Error("expected unset mountd_log not to be sent")
+	}
+
+	var model NFSConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "nfs_config" {
+		t.Errorf("expected ID 'nfs_config', got %q", model.ID.ValueString())
+	}
+	if !model.Servers.IsNull() {
+		t.Errorf("expected null servers while managed, got %v", model.Servers)
+	}
+	if !model.MountdLog.ValueBool() || !model.RDMA.ValueBool() {
+		t.Errorf("expected mountd_log and rdma from the response, got %v and %v", model.MountdLog, model.RDMA)
+	}
+	if model.V4Domain.ValueString() != "example.com" {
+		t.Errorf("expected v4_domain 'example.com', got %q", model.V4Domain.ValueString())
+	}
+}
+
+func TestNFSConfigResource_Create_Before2504(t *testing.T) {
+	var capturedParams map[string]any
+	r := newNFSConfigTestResource(truenas.Version{Major: 24, Minor: 10}, &capturedParams)
+
+	schemaResp := getNFSConfigResourceSchema(t)
+	p := defaultNFSConfigParams()
+	p.RDMA = nil
+	p.Servers = float64(8)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createNFSConfigModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["managed_nfsd"] != false || capturedParams["servers"] != int64(8) {
+		t.Errorf("expected managed_nfsd false and servers 8, got %v and %v", capturedParams["managed_nfsd"], capturedParams["servers"])
+	}
+	if _, ok := capturedParams["rdma"]; ok {
+		t.Error("expected rdma not to be sent before 25.04")
+	}
+}
+
+func TestNFSConfigResource_Create_RDMABefore2504(t *testing.T) {
+	called := false
+	r := &NFSConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: truenas.Version{Major: 24, Minor: 10},
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				called = true
+				return json.RawMessage(testNFSConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getNFSConfigResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createNFSConfigModelValue(defaultNFSConfigParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for rdma before 25.04")
+	}
+	if called {
+		t.Error("expected no update when rdma is unsupported")
+	}
+}
+
+func TestNFSConfigResource_Read_ManagedBefore2504(t *testing.T) {
+	r := &NFSConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "nfs.config" {
+					t.Errorf("expected method 'nfs.config', got %q", method)
+				}
+				return json.RawMessage(`{"servers": 4, "managed_nfsd": true, "protocols": ["NFSV4"], "bindip": ["10.0.0.5"], "v4_domain": ""}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getNFSConfigResourceSchema(t)
+	p := defaultNFSConfigParams()
+	p.ID = "nfs_config"
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createNFSConfigModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model NFSConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.Servers.IsNull() {
+		t.Errorf("expected null servers while managed, got %v", model.Servers)
+	}
+	if model.RDMA.ValueBool() {
+		t.Error("expected rdma false when the version has none")
+	}
+	if len(model.BindIP.Elements()) != 1 || len(model.Protocols.Elements()) != 1 {
+		t.Errorf("expected bindip and protocols from the response, got %v and %v", model.BindIP, model.Protocols)
+	}
+}
+
+func TestNFSConfigResource_Delete(t *testing.T) {
+	var capturedParams map[string]any
+	r := newNFSConfigTestResource(truenas.Version{Major: 25, Minor: 4}, &capturedParams)
+
+	r.Delete(context.Background(), resource.DeleteRequest{}, &resource.DeleteResponse{})
+
+	if capturedParams["rdma"] != false || capturedParams["v4_domain"] != "" {
+		t.Errorf("expected rdma and v4_domain reset, got %v", capturedParams)
+	}
+	if v, ok := capturedParams["mountd_port"]; !ok || v != nil {
+		t.Errorf("expected mountd_port reset to null, got %v", v)
+	}
+}
+
+func TestNFSConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewNFSConfigResource().(*NFSConfigResource)
+	schemaResp := getNFSConfigResourceSchema(t)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: "nfs"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
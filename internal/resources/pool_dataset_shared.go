@@ -1,9 +1,20 @@
 package resources
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -13,28 +24,29 @@ import (
 // -- Shared identity helpers --
 
 // poolDatasetFullName builds the full dataset/zvol name from pool+path or parent+path.
+// Each part is normalized first, so a parent of "/mnt/tank/vms" is accepted.
 // Returns "" if the configuration is invalid.
 //
 // Modes:
 //   - pool + path: "tank" + "vms/disk0" -> "tank/vms/disk0"
 //   - parent + path: "tank/vms" + "disk0" -> "tank/vms/disk0"
-func poolDatasetFullName(pool, path, parent, name types.String) string {
-	hasPool := !pool.IsNull() && !pool.IsUnknown() && pool.ValueString() != ""
-	hasPath := !path.IsNull() && !path.IsUnknown() && path.ValueString() != ""
+func poolDatasetFullName(pool, path, parent customtypes.DatasetPathValue, name types.String) string {
+	hasPool := !pool.IsNull() && !pool.IsUnknown() && pool.NormalizedValue() != ""
+	hasPath := !path.IsNull() && !path.IsUnknown() && path.NormalizedValue() != ""
 
 	if hasPool && hasPath {
-		return fmt.Sprintf("%s/%s", pool.ValueString(), path.ValueString())
+		return fmt.Sprintf("%s/%s", pool.NormalizedValue(), path.NormalizedValue())
 	}
 
-	hasParent := !parent.IsNull() && !parent.IsUnknown() && parent.ValueString() != ""
+	hasParent := !parent.IsNull() && !parent.IsUnknown() && parent.NormalizedValue() != ""
 	hasName := !name.IsNull() && !name.IsUnknown() && name.ValueString() != ""
 
 	if hasParent {
 		if hasPath {
-			return fmt.Sprintf("%s/%s", parent.ValueString(), path.ValueString())
+			return fmt.Sprintf("%s/%s", parent.NormalizedValue(), path.NormalizedValue())
 		}
 		if hasName {
-			return fmt.Sprintf("%s/%s", parent.ValueString(), name.ValueString())
+			return fmt.Sprintf("%s/%s", parent.NormalizedValue(), name.ValueString())
 		}
 	}
 
@@ -64,6 +76,7 @@ func poolDatasetIdentitySchema() map[string]schema.Attribute {
 			},
 		},
 		"pool": schema.StringAttribute{
+			CustomType:  customtypes.DatasetPathType{},
 			Description: "Pool name. Use with 'path' attribute.",
 			Optional:    true,
 			PlanModifiers: []planmodifier.String{
@@ -71,6 +84,7 @@ func poolDatasetIdentitySchema() map[string]schema.Attribute {
 			},
 		},
 		"path": schema.StringAttribute{
+			CustomType:  customtypes.DatasetPathType{},
 			Description: "Path within the pool (e.g., 'vms/disk0').",
 			Optional:    true,
 			PlanModifiers: []planmodifier.String{
@@ -78,7 +92,8 @@ func poolDatasetIdentitySchema() map[string]schema.Attribute {
 			},
 		},
 		"parent": schema.StringAttribute{
-			Description: "Parent dataset ID (e.g., 'tank/vms'). Use with 'path' attribute.",
+			CustomType:  customtypes.DatasetPathType{},
+			Description: "Parent dataset ID (e.g., 'tank/vms'; a '/mnt/tank/vms' mount path is also accepted). Use with 'path' attribute.",
 			Optional:    true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.RequiresReplace(),
@@ -86,3 +101,343 @@ func poolDatasetIdentitySchema() map[string]schema.Attribute {
 		},
 	}
 }
+
+// poolDatasetDestroySchema returns the recursive/force destroy options shared
+// by both dataset and zvol resources. They map to the pool.dataset.delete options.
+func poolDatasetDestroySchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"recursive": schema.BoolAttribute{
+			Description: "When destroying this resource, also delete its snapshots and child datasets. " +
+				"Plans that destroy the resource, or enable this option, warn with the snapshot counts that " +
+				"would be lost. Defaults to false.",
+			Optional: true,
+		},
+		"force": schema.BoolAttribute{
+			Description: "When destroying this resource, delete it even if it is busy (e.g. mounted or shared). Defaults to false.",
+			Optional:    true,
+		},
+		"protect_nonempty": schema.BoolAttribute{
+			Description: "Refuse to destroy this resource while it holds data or has child datasets. " +
+				"Checked against TrueNAS at destroy time, in addition to lifecycle.prevent_destroy. Defaults to false.",
+			Optional: true,
+		},
+	}
+}
+
+// -- Shared destroy helpers --
+
+// poolDatasetDestroyRecursive reports whether destroy should be recursive.
+// force_destroy is the deprecated spelling of recursive.
+func poolDatasetDestroyRecursive(recursive, forceDestroy types.Bool) bool {
+	return recursive.ValueBool() || forceDestroy.ValueBool()
+}
+
+// deletePoolDatasetForced calls pool.dataset.delete with force set, which
+// DatasetService does not expose.
+func deletePoolDatasetForced(ctx context.Context, c client.Client, id string, recursive bool) error {
+	params := []any{id, map[string]any{"recursive": recursive, "force": true}}
+	_, err := c.Call(ctx, "pool.dataset.delete", params)
+	return err
+}
+
+// poolDatasetEmptyUsage is the space a dataset or zvol may use and still count
+// as empty for protect_nonempty. A freshly created one uses some ZFS metadata.
+const poolDatasetEmptyUsage = 1 << 20
+
+// poolDatasetUsageResponse is the part of a pool.dataset.query entry that
+// protect_nonempty looks at.
+type poolDatasetUsageResponse struct {
+	ID            string `json:"id"`
+	UsedByDataset struct {
+		Parsed int64 `json:"parsed"`
+	} `json:"usedbydataset"`
+}
+
+// checkPoolDatasetEmpty fails the destroy of id when protect_nonempty is set
+// and the dataset holds data or has child datasets. A dataset that no longer
+// exists is empty.
+func checkPoolDatasetEmpty(ctx context.Context, c client.Client, id string, protect types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !protect.ValueBool() {
+		return diags
+	}
+
+	params := []any{
+		[]any{[]any{"OR", []any{
+			[]any{"id", "=", id},
+			[]any{"id", "^", id + "/"},
+		}}},
+		map[string]any{"extra": map[string]any{"flat": true, "retrieve_children": false}},
+	}
+	result, err := c.Call(ctx, "pool.dataset.query", params)
+	if err != nil {
+		diags.AddError(
+			"Unable to Check Dataset Contents",
+			fmt.Sprintf("protect_nonempty is set, but the contents of %q could not be checked: %s", id, err.Error()),
+		)
+		return diags
+	}
+
+	var datasets []poolDatasetUsageResponse
+	if err := json.Unmarshal(result, &datasets); err != nil {
+		diags.AddError("Unable to Parse Response", fmt.Sprintf("Unable to parse dataset query response: %s", err.Error()))
+		return diags
+	}
+
+	var used int64
+	var children []string
+	for _, ds := range datasets {
+		if ds.ID == id {
+			used = ds.UsedByDataset.Parsed
+		} else {
+			children = append(children, ds.ID)
+		}
+	}
+	sort.Strings(children)
+
+	var reasons []string
+	if used > poolDatasetEmptyUsage {
+		reasons = append(reasons, fmt.Sprintf("it holds %d bytes of data", used))
+	}
+	if len(children) > 0 {
+		reasons = append(reasons, fmt.Sprintf("it has %d child dataset(s): %s", len(children), strings.Join(children, ", ")))
+	}
+	if len(reasons) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Refusing to Destroy Non-Empty Dataset",
+		fmt.Sprintf("%q was not destroyed because protect_nonempty is set and %s. "+
+			"Empty it first, or set protect_nonempty = false and apply before destroying it.",
+			id, strings.Join(reasons, " and ")),
+	)
+	return diags
+}
+
+// addPoolDatasetDestroyWarning warns with the per-dataset snapshot counts a
+// recursive destroy of id would delete. destroying selects the wording for a
+// destroy plan versus a plan that only enables recursion. Nothing is added if
+// there are no snapshots; failing to count them is a warning, not an error.
+func addPoolDatasetDestroyWarning(ctx context.Context, snapshots truenas.SnapshotServiceAPI, id string, destroying bool, diags *diag.Diagnostics) {
+	filters := [][]any{{"OR", []any{
+		[]any{"dataset", "=", id},
+		[]any{"dataset", "^", id + "/"},
+	}}}
+	snaps, err := snapshots.Query(ctx, filters)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Count Snapshots",
+			fmt.Sprintf("Unable to list the snapshots a recursive destroy of %q would delete: %s", id, err.Error()),
+		)
+		return
+	}
+	if len(snaps) == 0 {
+		return
+	}
+
+	counts := map[string]int{}
+	for _, snap := range snaps {
+		counts[snap.Dataset]++
+	}
+	datasets := make([]string, 0, len(counts))
+	for dataset := range counts {
+		datasets = append(datasets, dataset)
+	}
+	sort.Strings(datasets)
+
+	var b strings.Builder
+	if destroying {
+		fmt.Fprintf(&b, "Destroying %q recursively will also delete %d snapshot(s):", id, len(snaps))
+	} else {
+		fmt.Fprintf(&b, "With recursive enabled, destroying %q will also delete %d snapshot(s):", id, len(snaps))
+	}
+	for _, dataset := range datasets {
+		fmt.Fprintf(&b, "\n  %s: %d", dataset, counts[dataset])
+	}
+
+	diags.AddWarning("Recursive Destroy Deletes Snapshots", b.String())
+}
+
+// -- Shared property passthrough --
+
+// poolDatasetPropertyInherit is the pool.dataset.update value that resets a
+// property to the value inherited from the parent dataset.
+const poolDatasetPropertyInherit = "INHERIT"
+
+// poolDatasetPropertiesSchema returns the properties map shared by both
+// dataset and zvol resources, for ZFS properties without their own attribute.
+func poolDatasetPropertiesSchema() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Additional ZFS properties without their own attribute, keyed by pool.dataset.update field " +
+			"name (e.g. { logbias = \"THROUGHPUT\", sync = \"ALWAYS\" }). They are set right after creation and on " +
+			"every change; integer values are sent as numbers. Only the listed keys are read back, and removing a " +
+			"key resets it to INHERIT. Properties with their own attribute and ZFS user properties are not allowed.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// validatePoolDatasetProperties rejects properties keys that have their own
+// attribute on the resource, or that are ZFS user properties.
+func validatePoolDatasetProperties(ctx context.Context, properties types.Map, modeled []string, diags *diag.Diagnostics) {
+	if properties.IsNull() || properties.IsUnknown() {
+		return
+	}
+
+	for key := range properties.Elements() {
+		switch {
+		case slices.Contains(modeled, key):
+			diags.AddAttributeError(
+				path.Root("properties").AtMapKey(key),
+				"Property Has Its Own Attribute",
+				fmt.Sprintf("Set %q with the %s attribute instead of properties.", key, key),
+			)
+		case strings.Contains(key, ":"):
+			diags.AddAttributeError(
+				path.Root("properties").AtMapKey(key),
+				"User Properties Not Supported",
+				fmt.Sprintf("%q is a ZFS user property. properties only accepts native ZFS properties.", key),
+			)
+		}
+	}
+}
+
+// poolDatasetPropertiesParams returns the pool.dataset.update parameters that
+// move the properties in state to those in plan. Changed and added keys are
+// set, and keys removed from plan are reset to INHERIT. Values that only
+// differ in letter case are left alone, as the API upper-cases enum values.
+func poolDatasetPropertiesParams(ctx context.Context, plan, state types.Map) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	planned := map[string]string{}
+	current := map[string]string{}
+	if !plan.IsNull() && !plan.IsUnknown() {
+		diags.Append(plan.ElementsAs(ctx, &planned, false)...)
+	}
+	if !state.IsNull() && !state.IsUnknown() {
+		diags.Append(state.ElementsAs(ctx, &current, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	params := map[string]any{}
+	for key, value := range planned {
+		if old, ok := current[key]; ok && strings.EqualFold(old, value) {
+			continue
+		}
+		params[key] = poolDatasetPropertyValue(value)
+	}
+	for key := range current {
+		if _, ok := planned[key]; !ok {
+			params[key] = poolDatasetPropertyInherit
+		}
+	}
+	return params, diags
+}
+
+// poolDatasetPropertyValue converts a properties value to its API form:
+// integers are sent as numbers, everything else as strings.
+func poolDatasetPropertyValue(value string) any {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n
+	}
+	return value
+}
+
+// updatePoolDatasetProperties calls pool.dataset.update with params, which
+// DatasetService has no field for.
+func updatePoolDatasetProperties(ctx context.Context, c client.Client, id string, params map[string]any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	_, err := c.Call(ctx, "pool.dataset.update", []any{id, params})
+	return err
+}
+
+// poolDatasetPropertyResponse is a property as returned by pool.dataset.query.
+type poolDatasetPropertyResponse struct {
+	Value any `json:"value"`
+}
+
+// readPoolDatasetProperties reads back the keys of configured from the
+// dataset. A value that only differs in letter case from the configured one
+// keeps the configured spelling. Keys the API does not return are dropped so
+// the next plan shows them as changes. A null map is returned as-is without a
+// query, so properties stay unmanaged until configured.
+func readPoolDatasetProperties(ctx context.Context, c client.Client, id string, configured types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if configured.IsNull() || configured.IsUnknown() {
+		return configured, diags
+	}
+
+	wanted := map[string]string{}
+	diags.Append(configured.ElementsAs(ctx, &wanted, false)...)
+	if diags.HasError() {
+		return configured, diags
+	}
+
+	filter := []any{[]any{[]any{"id", "=", id}}}
+	result, err := c.Call(ctx, "pool.dataset.query", filter)
+	if err != nil {
+		diags.AddError("Unable to Read Dataset Properties", fmt.Sprintf("Unable to query properties of %q: %s", id, err.Error()))
+		return configured, diags
+	}
+
+	var datasets []map[string]json.RawMessage
+	if err := json.Unmarshal(result, &datasets); err != nil {
+		diags.AddError("Unable to Parse Response", fmt.Sprintf("Unable to parse dataset query response: %s", err.Error()))
+		return configured, diags
+	}
+	if len(datasets) == 0 {
+		diags.AddError("Unable to Read Dataset Properties", fmt.Sprintf("Dataset %q was not found.", id))
+		return configured, diags
+	}
+
+	values := map[string]attr.Value{}
+	for key, want := range wanted {
+		raw, ok := datasets[0][key]
+		if !ok {
+			continue
+		}
+		var prop poolDatasetPropertyResponse
+		if err := json.Unmarshal(raw, &prop); err != nil || prop.Value == nil {
+			continue
+		}
+		value := fmt.Sprint(prop.Value)
+		if strings.EqualFold(value, want) {
+			value = want
+		}
+		values[key] = types.StringValue(value)
+	}
+
+	properties, d := types.MapValue(types.StringType, values)
+	diags.Append(d...)
+	return properties, diags
+}
+
+// applyPoolDatasetProperties moves the properties of dataset id from state to
+// plan and returns the properties as read back. Validation errors for a
+// property are reported against its key in the properties map.
+func applyPoolDatasetProperties(ctx context.Context, c client.Client, id string, plan, state types.Map) (types.Map, diag.Diagnostics) {
+	params, diags := poolDatasetPropertiesParams(ctx, plan, state)
+	if diags.HasError() {
+		return plan, diags
+	}
+
+	if err := updatePoolDatasetProperties(ctx, c, id, params); err != nil {
+		paths := make(map[string]path.Path, len(params))
+		for key := range params {
+			paths[key] = path.Root("properties").AtMapKey(key)
+		}
+		addAPIError(&diags, err, paths,
+			"Unable to Set Dataset Properties",
+			fmt.Sprintf("Unable to set properties of %q: %s", id, err.Error()),
+		)
+		return plan, diags
+	}
+
+	properties, d := readPoolDatasetProperties(ctx, c, id, plan)
+	diags.Append(d...)
+	return properties, diags
+}
@@ -0,0 +1,285 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &PoolResilverPriorityResource{}
+	_ resource.ResourceWithConfigure   = &PoolResilverPriorityResource{}
+	_ resource.ResourceWithImportState = &PoolResilverPriorityResource{}
+)
+
+// resilverTimeRegexp matches the HH:MM times accepted by pool.resilver.update.
+var resilverTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// defaultResilverWeekdays is every day of the week (1 = Monday, 7 = Sunday).
+var defaultResilverWeekdays = []int64{1, 2, 3, 4, 5, 6, 7}
+
+// PoolResilverPriorityResourceModel describes the resource data model.
+type PoolResilverPriorityResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Begin    types.String `tfsdk:"begin"`
+	End      types.String `tfsdk:"end"`
+	Weekdays types.Set    `tfsdk:"weekdays"`
+}
+
+// poolResilverResponse is the JSON shape returned by pool.resilver.config and pool.resilver.update.
+type poolResilverResponse struct {
+	ID      int64   `json:"id"`
+	Enabled bool    `json:"enabled"`
+	Begin   string  `json:"begin"`
+	End     string  `json:"end"`
+	Weekday []int64 `json:"weekday"`
+}
+
+// PoolResilverPriorityResource defines the resource implementation.
+type PoolResilverPriorityResource struct {
+	BaseResource
+}
+
+// NewPoolResilverPriorityResource creates a new PoolResilverPriorityResource.
+func NewPoolResilverPriorityResource() resource.Resource {
+	return &PoolResilverPriorityResource{}
+}
+
+func (r *PoolResilverPriorityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pool_resilver_priority"
+}
+
+func (r *PoolResilverPriorityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the resilver priority window on TrueNAS. Inside the window, resilvers run at " +
+			"higher priority than other I/O; outside it they are throttled.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'pool_resilver_priority').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Prioritize resilvers during the configured window. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"begin": schema.StringAttribute{
+				Description: "Start of the priority window in HH:MM (24-hour). Defaults to '18:00'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("18:00"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(resilverTimeRegexp, "must be a time in HH:MM format"),
+				},
+			},
+			"end": schema.StringAttribute{
+				Description: "End of the priority window in HH:MM (24-hour). Defaults to '09:00'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("09:00"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(resilverTimeRegexp, "must be a time in HH:MM format"),
+				},
+			},
+			"weekdays": schema.SetAttribute{
+				Description: "Days of the week the window applies to, 1 (Monday) through 7 (Sunday). Defaults to every day.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Default:     setdefault.StaticValue(resilverWeekdaysValue(defaultResilverWeekdays)),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueInt64sAre(int64validator.Between(1, 7)),
+				},
+			},
+		},
+	}
+}
+
+func (r *PoolResilverPriorityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PoolResilverPriorityResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := buildPoolResilverParams(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resilver Priority",
+			fmt.Sprintf("Unable to update resilver priority configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapPoolResilverToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PoolResilverPriorityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PoolResilverPriorityResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "pool.resilver.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resilver Priority",
+			fmt.Sprintf("Unable to read resilver priority configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config poolResilverResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse resilver priority configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapPoolResilverToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *PoolResilverPriorityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PoolResilverPriorityResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := buildPoolResilverParams(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Resilver Priority",
+			fmt.Sprintf("Unable to update resilver priority configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapPoolResilverToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PoolResilverPriorityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := map[string]any{
+		"enabled": true,
+		"begin":   "18:00",
+		"end":     "09:00",
+		"weekday": defaultResilverWeekdays,
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Resilver Priority",
+			fmt.Sprintf("Unable to reset resilver priority configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *PoolResilverPriorityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "pool_resilver_priority"
+	if req.ID != "pool_resilver_priority" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'pool_resilver_priority', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateConfig calls pool.resilver.update and parses the response.
+func (r *PoolResilverPriorityResource) updateConfig(ctx context.Context, params map[string]any) (*poolResilverResponse, error) {
+	result, err := r.client.Call(ctx, "pool.resilver.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config poolResilverResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse resilver update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildPoolResilverParams builds the pool.resilver.update params from the resource model,
+// appending any conversion errors to diags.
+func buildPoolResilverParams(ctx context.Context, data *PoolResilverPriorityResourceModel, diags *diag.Diagnostics) map[string]any {
+	weekdays := []int64{}
+	diags.Append(data.Weekdays.ElementsAs(ctx, &weekdays, false)...)
+	sort.Slice(weekdays, func(i, j int) bool { return weekdays[i] < weekdays[j] })
+
+	return map[string]any{
+		"enabled": data.Enabled.ValueBool(),
+		"begin":   data.Begin.ValueString(),
+		"end":     data.End.ValueString(),
+		"weekday": weekdays,
+	}
+}
+
+// mapPoolResilverToModel maps the API response to the resource model.
+func mapPoolResilverToModel(config *poolResilverResponse, data *PoolResilverPriorityResourceModel) {
+	data.ID = types.StringValue("pool_resilver_priority")
+	data.Enabled = types.BoolValue(config.Enabled)
+	data.Begin = types.StringValue(config.Begin)
+	data.End = types.StringValue(config.End)
+	data.Weekdays = resilverWeekdaysValue(config.Weekday)
+}
+
+// resilverWeekdaysValue converts weekday numbers to a set value.
+func resilverWeekdaysValue(weekdays []int64) types.Set {
+	elements := make([]attr.Value, 0, len(weekdays))
+	for _, d := range weekdays {
+		elements = append(elements, types.Int64Value(d))
+	}
+	return types.SetValueMust(types.Int64Type, elements)
+}
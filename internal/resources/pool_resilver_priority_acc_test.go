@@ -0,0 +1,29 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/acctest"
+)
+
+func TestAccPoolResilverPriorityResource_basic(t *testing.T) {
+	acctest.PreCheck(t)
+	h := acctest.NewHarness(t)
+	r := h.Resource("truenas_pool_resilver_priority")
+
+	config := map[string]any{
+		"begin":    "22:00",
+		"end":      "06:00",
+		"weekdays": []any{6, 7},
+	}
+	state := r.Apply(config)
+	if got := state.Get("enabled"); got != true {
+		t.Errorf("expected default enabled true, got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	r.Import("pool_resilver_priority")
+	r.ExpectNoChanges(config)
+
+	r.Destroy()
+}
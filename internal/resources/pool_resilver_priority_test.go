@@ -0,0 +1,469 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewPoolResilverPriorityResource(t *testing.T) {
+	r := NewPoolResilverPriorityResource()
+	if r == nil {
+		t.Fatal("NewPoolResilverPriorityResource returned nil")
+	}
+
+	_, ok := r.(*PoolResilverPriorityResource)
+	if !ok {
+		t.Fatalf("expected *PoolResilverPriorityResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*PoolResilverPriorityResource))
+	_ = resource.ResourceWithImportState(r.(*PoolResilverPriorityResource))
+}
+
+func TestPoolResilverPriorityResource_Metadata(t *testing.T) {
+	r := NewPoolResilverPriorityResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_pool_resilver_priority" {
+		t.Errorf("expected TypeName 'truenas_pool_resilver_priority', got %q", resp.TypeName)
+	}
+}
+
+func TestPoolResilverPriorityResource_Schema(t *testing.T) {
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"enabled", "begin", "end", "weekdays"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getPoolResilverPriorityResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewPoolResilverPriorityResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// poolResilverPriorityModelParams holds parameters for creating test model values.
+type poolResilverPriorityModelParams struct {
+	ID       interface{}
+	Enabled  interface{}
+	Begin    interface{}
+	End      interface{}
+	Weekdays []int64
+}
+
+func createPoolResilverPriorityModelValue(p poolResilverPriorityModelParams) tftypes.Value {
+	weekdaysType := tftypes.Set{ElementType: tftypes.Number}
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.String,
+			"enabled":  tftypes.Bool,
+			"begin":    tftypes.String,
+			"end":      tftypes.String,
+			"weekdays": weekdaysType,
+		},
+	}
+
+	var weekdays tftypes.Value
+	if p.Weekdays == nil {
+		weekdays = tftypes.NewValue(weekdaysType, nil)
+	} else {
+		elements := make([]tftypes.Value, 0, len(p.Weekdays))
+		for _, d := range p.Weekdays {
+			elements = append(elements, tftypes.NewValue(tftypes.Number, float64(d)))
+		}
+		weekdays = tftypes.NewValue(weekdaysType, elements)
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, p.ID),
+		"enabled":  tftypes.NewValue(tftypes.Bool, p.Enabled),
+		"begin":    tftypes.NewValue(tftypes.String, p.Begin),
+		"end":      tftypes.NewValue(tftypes.String, p.End),
+		"weekdays": weekdays,
+	})
+}
+
+func defaultPoolResilverPriorityParams() poolResilverPriorityModelParams {
+	return poolResilverPriorityModelParams{
+		Enabled:  true,
+		Begin:    "22:00",
+		End:      "06:00",
+		Weekdays: []int64{6, 7},
+	}
+}
+
+const testPoolResilverJSON = `{
+	"id": 1,
+	"enabled": true,
+	"begin": "22:00",
+	"end": "06:00",
+	"weekday": [6, 7]
+}`
+
+func TestPoolResilverPriorityResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testPoolResilverJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	p := defaultPoolResilverPriorityParams()
+	p.Weekdays = []int64{7, 6}
+	planValue := createPoolResilverPriorityModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "pool.resilver.update" {
+		t.Errorf("expected method 'pool.resilver.update', got %q", capturedMethod)
+	}
+	if capturedParams["begin"] != "22:00" {
+		t.Errorf("expected begin '22:00', got %v", capturedParams["begin"])
+	}
+	if capturedParams["end"] != "06:00" {
+		t.Errorf("expected end '06:00', got %v", capturedParams["end"])
+	}
+	if !reflect.DeepEqual(capturedParams["weekday"], []int64{6, 7}) {
+		t.Errorf("expected sorted weekday [6 7], got %v", capturedParams["weekday"])
+	}
+
+	var model PoolResilverPriorityResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	if model.ID.ValueString() != "pool_resilver_priority" {
+		t.Errorf("expected ID 'pool_resilver_priority', got %q", model.ID.ValueString())
+	}
+	if len(model.Weekdays.Elements()) != 2 {
+		t.Errorf("expected 2 weekdays, got %d", len(model.Weekdays.Elements()))
+	}
+}
+
+func TestPoolResilverPriorityResource_Create_APIError(t *testing.T) {
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	planValue := createPoolResilverPriorityModelValue(defaultPoolResilverPriorityParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestPoolResilverPriorityResource_Read_Success(t *testing.T) {
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "pool.resilver.config" {
+					t.Errorf("expected method 'pool.resilver.config', got %q", method)
+				}
+				return json.RawMessage(`{"id": 1, "enabled": false, "begin": "01:00", "end": "05:00", "weekday": [1, 2, 3]}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	p := defaultPoolResilverPriorityParams()
+	p.ID = "pool_resilver_priority"
+	stateValue := createPoolResilverPriorityModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model PoolResilverPriorityResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	if model.Enabled.ValueBool() {
+		t.Error("expected enabled false")
+	}
+	if model.Begin.ValueString() != "01:00" {
+		t.Errorf("expected begin '01:00', got %q", model.Begin.ValueString())
+	}
+	if model.End.ValueString() != "05:00" {
+		t.Errorf("expected end '05:00', got %q", model.End.ValueString())
+	}
+	if len(model.Weekdays.Elements()) != 3 {
+		t.Errorf("expected 3 weekdays, got %d", len(model.Weekdays.Elements()))
+	}
+}
+
+func TestPoolResilverPriorityResource_Read_APIError(t *testing.T) {
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	p := defaultPoolResilverPriorityParams()
+	p.ID = "pool_resilver_priority"
+	stateValue := createPoolResilverPriorityModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestPoolResilverPriorityResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "enabled": true, "begin": "23:30", "end": "06:00", "weekday": [6, 7]}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	state := defaultPoolResilverPriorityParams()
+	state.ID = "pool_resilver_priority"
+	plan := defaultPoolResilverPriorityParams()
+	plan.ID = "pool_resilver_priority"
+	plan.Begin = "23:30"
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createPoolResilverPriorityModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createPoolResilverPriorityModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["begin"] != "23:30" {
+		t.Errorf("expected begin '23:30', got %v", capturedParams["begin"])
+	}
+}
+
+func TestPoolResilverPriorityResource_Update_APIError(t *testing.T) {
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	p := defaultPoolResilverPriorityParams()
+	p.ID = "pool_resilver_priority"
+	value := createPoolResilverPriorityModelValue(p)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestPoolResilverPriorityResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	p := defaultPoolResilverPriorityParams()
+	p.ID = "pool_resilver_priority"
+	stateValue := createPoolResilverPriorityModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["begin"] != "18:00" || capturedParams["end"] != "09:00" {
+		t.Errorf("expected window reset to 18:00-09:00, got %v-%v", capturedParams["begin"], capturedParams["end"])
+	}
+	if !reflect.DeepEqual(capturedParams["weekday"], []int64{1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("expected weekday reset to every day, got %v", capturedParams["weekday"])
+	}
+}
+
+func TestPoolResilverPriorityResource_Delete_APIError(t *testing.T) {
+	r := &PoolResilverPriorityResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+	p := defaultPoolResilverPriorityParams()
+	p.ID = "pool_resilver_priority"
+	stateValue := createPoolResilverPriorityModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestPoolResilverPriorityResource_ImportState(t *testing.T) {
+	r := NewPoolResilverPriorityResource().(*PoolResilverPriorityResource)
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "pool_resilver_priority"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createPoolResilverPriorityModelValue(poolResilverPriorityModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestPoolResilverPriorityResource_ImportState_InvalidID(t *testing.T) {
+	r := NewPoolResilverPriorityResource().(*PoolResilverPriorityResource)
+	schemaResp := getPoolResilverPriorityResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "resilver"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createPoolResilverPriorityModelValue(poolResilverPriorityModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -0,0 +1,423 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &PrivilegeResource{}
+	_ resource.ResourceWithConfigure   = &PrivilegeResource{}
+	_ resource.ResourceWithImportState = &PrivilegeResource{}
+)
+
+// PrivilegeResourceModel describes the resource data model.
+type PrivilegeResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	LocalGroups types.Set    `tfsdk:"local_groups"`
+	DSGroups    types.Set    `tfsdk:"ds_groups"`
+	Roles       types.Set    `tfsdk:"roles"`
+	WebShell    types.Bool   `tfsdk:"web_shell"`
+	BuiltinName types.String `tfsdk:"builtin_name"`
+}
+
+// privilegeGroupResponse is the subset of a privilege's group entries used by
+// the resource. Directory service groups that no longer resolve carry only
+// gid and sid.
+type privilegeGroupResponse struct {
+	GID int64  `json:"gid"`
+	SID string `json:"sid"`
+}
+
+// privilegeResponse is the JSON shape returned by privilege.* methods.
+type privilegeResponse struct {
+	ID          int64                    `json:"id"`
+	BuiltinName *string                  `json:"builtin_name"`
+	Name        string                   `json:"name"`
+	LocalGroups []privilegeGroupResponse `json:"local_groups"`
+	DSGroups    []privilegeGroupResponse `json:"ds_groups"`
+	Roles       []string                 `json:"roles"`
+	WebShell    bool                     `json:"web_shell"`
+}
+
+// privilegeAPIFieldPaths maps privilege validation errors to attributes.
+var privilegeAPIFieldPaths = apiFieldPaths("name", "local_groups", "ds_groups", "roles", "web_shell")
+
+// PrivilegeResource defines the resource implementation.
+type PrivilegeResource struct {
+	BaseResource
+}
+
+// NewPrivilegeResource creates a new PrivilegeResource.
+func NewPrivilegeResource() resource.Resource {
+	return &PrivilegeResource{}
+}
+
+func (r *PrivilegeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_privilege"
+}
+
+func (r *PrivilegeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a TrueNAS privilege, which grants roles to the members of local and directory " +
+			"service groups. Users (and their API keys) receive the roles of every privilege their groups hold.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Privilege ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Privilege name. Must be unique.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"local_groups": schema.SetAttribute{
+				Description: "GIDs of the local groups that hold the privilege. Defaults to [].",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.Int64Type, []attr.Value{})),
+			},
+			"ds_groups": schema.SetAttribute{
+				Description: "Directory service groups that hold the privilege, as GIDs (e.g., \"1000513\") or " +
+					"SIDs. Defaults to [].",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+			},
+			"roles": schema.SetAttribute{
+				Description: "Roles granted by the privilege (e.g., `READONLY_ADMIN`, `SHARING_ADMIN`). " +
+					"`privilege.roles` lists the roles the server knows. Defaults to [].",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+			},
+			"web_shell": schema.BoolAttribute{
+				Description: "Allow holders to use the shell in the web UI. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"builtin_name": schema.StringAttribute{
+				Description: "Name of the built-in privilege this is (e.g., `LOCAL_ADMINISTRATOR`), or null for " +
+					"privileges created by users. Built-in privileges cannot be deleted.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PrivilegeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PrivilegeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := buildPrivilegeParams(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "privilege.create", params)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, privilegeAPIFieldPaths,
+			"Unable to Create Privilege",
+			fmt.Sprintf("Unable to create privilege %q: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var privilege privilegeResponse
+	if err := json.Unmarshal(result, &privilege); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse privilege create response: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(mapPrivilegeToModel(ctx, &privilege, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PrivilegeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PrivilegeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Privilege ID",
+			fmt.Sprintf("Cannot parse privilege ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	privilege, err := r.getPrivilege(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Privilege",
+			fmt.Sprintf("Unable to read privilege %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	if privilege == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(mapPrivilegeToModel(ctx, privilege, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *PrivilegeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PrivilegeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(plan.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Privilege ID",
+			fmt.Sprintf("Cannot parse privilege ID %q: %s", plan.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	params := buildPrivilegeParams(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "privilege.update", []any{id, params})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, privilegeAPIFieldPaths,
+			"Unable to Update Privilege",
+			fmt.Sprintf("Unable to update privilege %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	var privilege privilegeResponse
+	if err := json.Unmarshal(result, &privilege); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse privilege update response: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(mapPrivilegeToModel(ctx, &privilege, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PrivilegeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PrivilegeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Privilege ID",
+			fmt.Sprintf("Cannot parse privilege ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if _, err := r.client.Call(ctx, "privilege.delete", id); err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete Privilege",
+			fmt.Sprintf("Unable to delete privilege %d: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *PrivilegeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := importName(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	privilege, err := r.queryPrivilege(ctx, []any{"name", "=", name})
+	if err == nil && privilege == nil {
+		err = fmt.Errorf("no privilege named %q", name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Privilege",
+			fmt.Sprintf("Unable to look up privilege %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(privilege.ID, 10))...)
+}
+
+// getPrivilege queries a privilege by ID. Returns nil if the privilege does not exist.
+func (r *PrivilegeResource) getPrivilege(ctx context.Context, id int64) (*privilegeResponse, error) {
+	return r.queryPrivilege(ctx, []any{"id", "=", id})
+}
+
+// queryPrivilege returns the first privilege matching filter, or nil if none does.
+func (r *PrivilegeResource) queryPrivilege(ctx context.Context, filter []any) (*privilegeResponse, error) {
+	result, err := r.client.Call(ctx, "privilege.query", []any{[]any{filter}})
+	if err != nil {
+		return nil, err
+	}
+
+	var privileges []privilegeResponse
+	if err := json.Unmarshal(result, &privileges); err != nil {
+		return nil, fmt.Errorf("parse privilege query response: %w", err)
+	}
+
+	if len(privileges) == 0 {
+		return nil, nil
+	}
+
+	return &privileges[0], nil
+}
+
+// buildPrivilegeParams builds the privilege create/update params from the resource model.
+// Directory service groups given as numbers are sent as GIDs, anything else as SIDs.
+func buildPrivilegeParams(ctx context.Context, data *PrivilegeResourceModel, diags *diag.Diagnostics) map[string]any {
+	localGroups := []int64{}
+	diags.Append(data.LocalGroups.ElementsAs(ctx, &localGroups, false)...)
+	sort.Slice(localGroups, func(i, j int) bool { return localGroups[i] < localGroups[j] })
+
+	var dsGroupNames []string
+	diags.Append(data.DSGroups.ElementsAs(ctx, &dsGroupNames, false)...)
+	sort.Strings(dsGroupNames)
+	dsGroups := make([]any, 0, len(dsGroupNames))
+	for _, g := range dsGroupNames {
+		if gid, err := strconv.ParseInt(g, 10, 64); err == nil {
+			dsGroups = append(dsGroups, gid)
+		} else {
+			dsGroups = append(dsGroups, g)
+		}
+	}
+
+	roles := []string{}
+	diags.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+	sort.Strings(roles)
+
+	return map[string]any{
+		"name":         data.Name.ValueString(),
+		"local_groups": localGroups,
+		"ds_groups":    dsGroups,
+		"roles":        roles,
+		"web_shell":    data.WebShell.ValueBool(),
+	}
+}
+
+// mapPrivilegeToModel maps a privilege response to the resource model.
+// Directory service groups keep the form (GID or SID) the model already uses
+// for them, falling back to the GID.
+func mapPrivilegeToModel(ctx context.Context, privilege *privilegeResponse, data *PrivilegeResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	configured := map[string]bool{}
+	if !data.DSGroups.IsNull() && !data.DSGroups.IsUnknown() {
+		var names []string
+		diags.Append(data.DSGroups.ElementsAs(ctx, &names, false)...)
+		for _, n := range names {
+			configured[n] = true
+		}
+	}
+
+	localGroups := make([]attr.Value, 0, len(privilege.LocalGroups))
+	for _, g := range privilege.LocalGroups {
+		localGroups = append(localGroups, types.Int64Value(g.GID))
+	}
+
+	dsGroups := make([]attr.Value, 0, len(privilege.DSGroups))
+	for _, g := range privilege.DSGroups {
+		if g.SID != "" && configured[g.SID] {
+			dsGroups = append(dsGroups, types.StringValue(g.SID))
+		} else {
+			dsGroups = append(dsGroups, types.StringValue(strconv.FormatInt(g.GID, 10)))
+		}
+	}
+
+	roles := make([]attr.Value, 0, len(privilege.Roles))
+	for _, role := range privilege.Roles {
+		roles = append(roles, types.StringValue(role))
+	}
+
+	localGroupSet, d := types.SetValue(types.Int64Type, localGroups)
+	diags.Append(d...)
+	dsGroupSet, d := types.SetValue(types.StringType, dsGroups)
+	diags.Append(d...)
+	roleSet, d := types.SetValue(types.StringType, roles)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.ID = types.StringValue(strconv.FormatInt(privilege.ID, 10))
+	data.Name = types.StringValue(privilege.Name)
+	data.LocalGroups = localGroupSet
+	data.DSGroups = dsGroupSet
+	data.Roles = roleSet
+	data.WebShell = types.BoolValue(privilege.WebShell)
+	data.BuiltinName = types.StringPointerValue(privilege.BuiltinName)
+	return diags
+}
@@ -0,0 +1,432 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewPrivilegeResource(t *testing.T) {
+	r := NewPrivilegeResource()
+	if r == nil {
+		t.Fatal("NewPrivilegeResource returned nil")
+	}
+
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*PrivilegeResource))
+	_ = resource.ResourceWithImportState(r.(*PrivilegeResource))
+}
+
+func TestPrivilegeResource_Metadata(t *testing.T) {
+	r := NewPrivilegeResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "truenas"}, resp)
+
+	if resp.TypeName != "truenas_privilege" {
+		t.Errorf("expected TypeName 'truenas_privilege', got %q", resp.TypeName)
+	}
+}
+
+func TestPrivilegeResource_Schema(t *testing.T) {
+	schemaResp := getPrivilegeResourceSchema(t)
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["name"].IsRequired() {
+		t.Error("expected 'name' attribute to be required")
+	}
+	for _, name := range []string{"local_groups", "ds_groups", "roles", "web_shell"} {
+		if !attrs[name].IsOptional() || !attrs[name].IsComputed() {
+			t.Errorf("expected %q attribute to be optional and computed", name)
+		}
+	}
+	if !attrs["builtin_name"].IsComputed() {
+		t.Error("expected 'builtin_name' attribute to be computed")
+	}
+}
+
+// Test helpers
+
+func getPrivilegeResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewPrivilegeResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// privilegeModelParams holds parameters for creating test model values.
+type privilegeModelParams struct {
+	ID          interface{}
+	Name        interface{}
+	LocalGroups []int64
+	DSGroups    []string
+	Roles       []string
+	WebShell    interface{}
+	BuiltinName interface{}
+}
+
+func createPrivilegeModelValue(p privilegeModelParams) tftypes.Value {
+	numberSet := tftypes.Set{ElementType: tftypes.Number}
+	stringSet := tftypes.Set{ElementType: tftypes.String}
+
+	localGroups := make([]tftypes.Value, len(p.LocalGroups))
+	for i, gid := range p.LocalGroups {
+		localGroups[i] = tftypes.NewValue(tftypes.Number, gid)
+	}
+	stringValues := func(values []string) []tftypes.Value {
+		out := make([]tftypes.Value, len(values))
+		for i, v := range values {
+			out[i] = tftypes.NewValue(tftypes.String, v)
+		}
+		return out
+	}
+
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":           tftypes.String,
+			"name":         tftypes.String,
+			"local_groups": numberSet,
+			"ds_groups":    stringSet,
+			"roles":        stringSet,
+			"web_shell":    tftypes.Bool,
+			"builtin_name": tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, p.ID),
+		"name":         tftypes.NewValue(tftypes.String, p.Name),
+		"local_groups": tftypes.NewValue(numberSet, localGroups),
+		"ds_groups":    tftypes.NewValue(stringSet, stringValues(p.DSGroups)),
+		"roles":        tftypes.NewValue(stringSet, stringValues(p.Roles)),
+		"web_shell":    tftypes.NewValue(tftypes.Bool, p.WebShell),
+		"builtin_name": tftypes.NewValue(tftypes.String, p.BuiltinName),
+	})
+}
+
+func defaultPrivilegeParams() privilegeModelParams {
+	return privilegeModelParams{
+		ID:          "5",
+		Name:        "sharing-admins",
+		LocalGroups: []int64{3000},
+		DSGroups:    []string{"S-1-5-21-1004336348-1177238915-682003330-512"},
+		Roles:       []string{"SHARING_ADMIN", "READONLY_ADMIN"},
+		WebShell:    false,
+		BuiltinName: nil,
+	}
+}
+
+const testPrivilegeJSON = `{
+	"id": 5,
+	"builtin_name": null,
+	"name": "sharing-admins",
+	"local_groups": [{"id": 40, "gid": 3000, "name": "sharing", "builtin": false}],
+	"ds_groups": [{"gid": 1000512, "sid": "S-1-5-21-1004336348-1177238915-682003330-512", "group": "CORP\\domain admins"}],
+	"roles": ["READONLY_ADMIN", "SHARING_ADMIN"],
+	"web_shell": false
+}`
+
+func TestPrivilegeResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedMethod = method
+			capturedParams = params.(map[string]any)
+			return json.RawMessage(testPrivilegeJSON), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	p := defaultPrivilegeParams()
+	p.ID = tftypes.UnknownValue
+	p.BuiltinName = tftypes.UnknownValue
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(p)},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "privilege.create" {
+		t.Errorf("expected method 'privilege.create', got %q", capturedMethod)
+	}
+	if got := fmt.Sprint(capturedParams["roles"]); got != "[READONLY_ADMIN SHARING_ADMIN]" {
+		t.Errorf("unexpected roles: %s", got)
+	}
+	if got := fmt.Sprint(capturedParams["local_groups"]); got != "[3000]" {
+		t.Errorf("unexpected local_groups: %s", got)
+	}
+	if capturedParams["web_shell"] != false {
+		t.Errorf("expected web_shell false, got %v", capturedParams["web_shell"])
+	}
+
+	var model PrivilegeResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "5" {
+		t.Errorf("expected ID '5', got %q", model.ID.ValueString())
+	}
+	if !model.BuiltinName.IsNull() {
+		t.Errorf("expected null builtin_name, got %v", model.BuiltinName)
+	}
+
+	var dsGroups []string
+	resp.Diagnostics.Append(model.DSGroups.ElementsAs(context.Background(), &dsGroups, false)...)
+	if len(dsGroups) != 1 || dsGroups[0] != "S-1-5-21-1004336348-1177238915-682003330-512" {
+		t.Errorf("expected ds_groups to keep the configured SID, got %v", dsGroups)
+	}
+}
+
+func TestPrivilegeResource_Create_APIError(t *testing.T) {
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return nil, errors.New("[EINVAL] privilege_create.roles.0: Invalid role")
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	p := defaultPrivilegeParams()
+	p.ID = tftypes.UnknownValue
+	p.BuiltinName = tftypes.UnknownValue
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(p)},
+	}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestBuildPrivilegeParams_DSGroups(t *testing.T) {
+	schemaResp := getPrivilegeResourceSchema(t)
+	p := defaultPrivilegeParams()
+	p.DSGroups = []string{"1000513", "S-1-5-32-544"}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(p)}
+	var data PrivilegeResourceModel
+	if diags := plan.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("failed to get plan: %v", diags)
+	}
+
+	resp := &resource.CreateResponse{}
+	params := buildPrivilegeParams(context.Background(), &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	dsGroups := params["ds_groups"].([]any)
+	if len(dsGroups) != 2 || dsGroups[0] != int64(1000513) || dsGroups[1] != "S-1-5-32-544" {
+		t.Errorf("expected GIDs as numbers and SIDs as strings, got %#v", dsGroups)
+	}
+}
+
+func TestPrivilegeResource_Read_Success(t *testing.T) {
+	var capturedParams any
+
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedParams = params
+			return json.RawMessage(`[` + testPrivilegeJSON + `]`), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	p := defaultPrivilegeParams()
+	p.DSGroups = []string{"1000512"}
+	state := createPrivilegeModelValue(p)
+
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+	r.Read(context.Background(), resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if got := fmt.Sprint(capturedParams); got != "[[[id = 5]]]" {
+		t.Errorf("unexpected query filter: %s", got)
+	}
+
+	var model PrivilegeResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+
+	var dsGroups []string
+	resp.Diagnostics.Append(model.DSGroups.ElementsAs(context.Background(), &dsGroups, false)...)
+	if len(dsGroups) != 1 || dsGroups[0] != "1000512" {
+		t.Errorf("expected ds_groups to keep the configured GID, got %v", dsGroups)
+	}
+}
+
+func TestPrivilegeResource_Read_NotFound(t *testing.T) {
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return json.RawMessage(`[]`), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	state := createPrivilegeModelValue(defaultPrivilegeParams())
+
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+	r.Read(context.Background(), resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed")
+	}
+}
+
+func TestPrivilegeResource_Update_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams []any
+
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedMethod = method
+			capturedParams = params.([]any)
+			return json.RawMessage(`{"id": 5, "builtin_name": null, "name": "sharing-admins",
+				"local_groups": [], "ds_groups": [], "roles": ["SHARING_ADMIN"], "web_shell": true}`), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	state := createPrivilegeModelValue(defaultPrivilegeParams())
+	p := defaultPrivilegeParams()
+	p.LocalGroups = nil
+	p.DSGroups = nil
+	p.Roles = []string{"SHARING_ADMIN"}
+	p.WebShell = true
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(p)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "privilege.update" {
+		t.Errorf("expected method 'privilege.update', got %q", capturedMethod)
+	}
+	if capturedParams[0] != int64(5) {
+		t.Errorf("expected privilege ID 5, got %v", capturedParams[0])
+	}
+	params := capturedParams[1].(map[string]any)
+	if len(params["local_groups"].([]int64)) != 0 {
+		t.Errorf("expected local_groups to be cleared, got %v", params["local_groups"])
+	}
+	if params["web_shell"] != true {
+		t.Errorf("expected web_shell true, got %v", params["web_shell"])
+	}
+}
+
+func TestPrivilegeResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedMethod = method
+			capturedParams = params
+			return json.RawMessage(`true`), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	resp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(defaultPrivilegeParams())},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "privilege.delete" || capturedParams != int64(5) {
+		t.Errorf("expected privilege.delete of 5, got %s(%v)", capturedMethod, capturedParams)
+	}
+}
+
+func TestPrivilegeResource_Delete_NotFound(t *testing.T) {
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return nil, errors.New("[ENOENT] Privilege 5 does not exist")
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	resp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(defaultPrivilegeParams())},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected no error for an already deleted privilege, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestPrivilegeResource_ImportState_ByName(t *testing.T) {
+	var capturedParams any
+
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedParams = params
+			return json.RawMessage(`[` + testPrivilegeJSON + `]`), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(privilegeModelParams{})},
+	}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: "name=sharing-admins"}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if got := fmt.Sprint(capturedParams); got != "[[[name = sharing-admins]]]" {
+		t.Errorf("unexpected query filter: %s", got)
+	}
+
+	var model PrivilegeResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "5" {
+		t.Errorf("expected ID '5', got %q", model.ID.ValueString())
+	}
+}
+
+func TestPrivilegeResource_ImportState_NameNotFound(t *testing.T) {
+	r := &PrivilegeResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return json.RawMessage(`[]`), nil
+		},
+	}}}
+
+	schemaResp := getPrivilegeResourceSchema(t)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createPrivilegeModelValue(privilegeModelParams{})},
+	}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: "name=missing"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for unknown privilege name")
+	}
+}
@@ -0,0 +1,236 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &ReportingConfigResource{}
+	_ resource.ResourceWithConfigure   = &ReportingConfigResource{}
+	_ resource.ResourceWithImportState = &ReportingConfigResource{}
+)
+
+// ReportingConfigResourceModel describes the resource data model.
+type ReportingConfigResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Tier0Days           types.Int64  `tfsdk:"tier0_days"`
+	Tier1Days           types.Int64  `tfsdk:"tier1_days"`
+	Tier1UpdateInterval types.Int64  `tfsdk:"tier1_update_interval"`
+}
+
+// reportingConfigResponse is the JSON shape returned by reporting.config and reporting.update.
+type reportingConfigResponse struct {
+	ID                  int64 `json:"id"`
+	Tier0Days           int64 `json:"tier0_days"`
+	Tier1Days           int64 `json:"tier1_days"`
+	Tier1UpdateInterval int64 `json:"tier1_update_interval"`
+}
+
+// ReportingConfigResource defines the resource implementation.
+type ReportingConfigResource struct {
+	BaseResource
+}
+
+// NewReportingConfigResource creates a new ReportingConfigResource.
+func NewReportingConfigResource() resource.Resource {
+	return &ReportingConfigResource{}
+}
+
+func (r *ReportingConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reporting_config"
+}
+
+func (r *ReportingConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages how long TrueNAS keeps reporting (netdata) history.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'reporting_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tier0_days": schema.Int64Attribute{
+				Description: "Number of days per-second metrics are kept. Defaults to 7. Minimum 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(7),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"tier1_days": schema.Int64Attribute{
+				Description: "Number of days downsampled metrics are kept. This is the history the reporting graphs can go back. Defaults to 365. Minimum 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(365),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"tier1_update_interval": schema.Int64Attribute{
+				Description: "Interval in seconds between downsampled data points. Defaults to 300. Minimum 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *ReportingConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ReportingConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildReportingConfigParams(&data))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, reportingConfigAPIFieldPaths,
+			"Unable to Update Reporting Config",
+			fmt.Sprintf("Unable to update reporting configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapReportingConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReportingConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ReportingConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "reporting.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Reporting Config",
+			fmt.Sprintf("Unable to read reporting configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config reportingConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse reporting configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapReportingConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *ReportingConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ReportingConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildReportingConfigParams(&plan))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, reportingConfigAPIFieldPaths,
+			"Unable to Update Reporting Config",
+			fmt.Sprintf("Unable to update reporting configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapReportingConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ReportingConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := map[string]any{
+		"tier0_days":            7,
+		"tier1_days":            365,
+		"tier1_update_interval": 300,
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Reporting Config",
+			fmt.Sprintf("Unable to reset reporting configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ReportingConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "reporting_config"
+	if req.ID != "reporting_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'reporting_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// reportingConfigAPIFieldPaths maps reporting.update validation errors to attributes.
+var reportingConfigAPIFieldPaths = apiFieldPaths("tier0_days", "tier1_days", "tier1_update_interval")
+
+// updateConfig calls reporting.update and parses the response.
+func (r *ReportingConfigResource) updateConfig(ctx context.Context, params map[string]any) (*reportingConfigResponse, error) {
+	result, err := r.client.Call(ctx, "reporting.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config reportingConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse reporting update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildReportingConfigParams builds the reporting.update params from the resource model.
+func buildReportingConfigParams(data *ReportingConfigResourceModel) map[string]any {
+	return map[string]any{
+		"tier0_days":            data.Tier0Days.ValueInt64(),
+		"tier1_days":            data.Tier1Days.ValueInt64(),
+		"tier1_update_interval": data.Tier1UpdateInterval.ValueInt64(),
+	}
+}
+
+// mapReportingConfigToModel maps the API response to the resource model.
+func mapReportingConfigToModel(config *reportingConfigResponse, data *ReportingConfigResourceModel) {
+	data.ID = types.StringValue("reporting_config")
+	data.Tier0Days = types.Int64Value(config.Tier0Days)
+	data.Tier1Days = types.Int64Value(config.Tier1Days)
+	data.Tier1UpdateInterval = types.Int64Value(config.Tier1UpdateInterval)
+}
@@ -0,0 +1,393 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewReportingConfigResource(t *testing.T) {
+	r := NewReportingConfigResource()
+	if r == nil {
+		t.Fatal("NewReportingConfigResource returned nil")
+	}
+
+	_, ok := r.(*ReportingConfigResource)
+	if !ok {
+		t.Fatalf("expected *ReportingConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*ReportingConfigResource))
+	_ = resource.ResourceWithImportState(r.(*ReportingConfigResource))
+}
+
+func TestReportingConfigResource_Metadata(t *testing.T) {
+	r := NewReportingConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_reporting_config" {
+		t.Errorf("expected TypeName 'truenas_reporting_config', got %q", resp.TypeName)
+	}
+}
+
+func TestReportingConfigResource_Schema(t *testing.T) {
+	schemaResp := getReportingConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"tier0_days", "tier1_days", "tier1_update_interval"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() || !attr.IsComputed() {
+			t.Errorf("expected '%s' attribute to be optional and computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getReportingConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewReportingConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// reportingConfigModelParams holds parameters for creating test model values.
+type reportingConfigModelParams struct {
+	ID                  interface{}
+	Tier0Days           interface{}
+	Tier1Days           interface{}
+	Tier1UpdateInterval interface{}
+}
+
+func createReportingConfigModelValue(p reportingConfigModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                    tftypes.String,
+			"tier0_days":            tftypes.Number,
+			"tier1_days":            tftypes.Number,
+			"tier1_update_interval": tftypes.Number,
+		},
+	}, map[string]tftypes.Value{
+		"id":                    tftypes.NewValue(tftypes.String, p.ID),
+		"tier0_days":            tftypes.NewValue(tftypes.Number, p.Tier0Days),
+		"tier1_days":            tftypes.NewValue(tftypes.Number, p.Tier1Days),
+		"tier1_update_interval": tftypes.NewValue(tftypes.Number, p.Tier1UpdateInterval),
+	})
+}
+
+func defaultReportingConfigParams() reportingConfigModelParams {
+	return reportingConfigModelParams{
+		Tier0Days:           float64(14),
+		Tier1Days:           float64(730),
+		Tier1UpdateInterval: float64(600),
+	}
+}
+
+const testReportingConfigJSON = `{
+	"id": 1,
+	"tier0_days": 14,
+	"tier1_days": 730,
+	"tier1_update_interval": 600
+}`
+
+func TestReportingConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &ReportingConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testReportingConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingConfigResourceSchema(t)
+	planValue := createReportingConfigModelValue(defaultReportingConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "reporting.update" {
+		t.Errorf("expected method 'reporting.update', got %q", capturedMethod)
+	}
+	if capturedParams["tier0_days"] != int64(14) {
+		t.Errorf("expected tier0_days 14, got %v", capturedParams["tier0_days"])
+	}
+	if capturedParams["tier1_days"] != int64(730) {
+		t.Errorf("expected tier1_days 730, got %v", capturedParams["tier1_days"])
+	}
+	if capturedParams["tier1_update_interval"] != int64(600) {
+		t.Errorf("expected tier1_update_interval 600, got %v", capturedParams["tier1_update_interval"])
+	}
+
+	var model ReportingConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "reporting_config" {
+		t.Errorf("expected ID 'reporting_config', got %q", model.ID.ValueString())
+	}
+	if model.Tier1Days.ValueInt64() != 730 {
+		t.Errorf("expected tier1_days 730, got %d", model.Tier1Days.ValueInt64())
+	}
+}
+
+func TestReportingConfigResource_Create_ValidationError(t *testing.T) {
+	r := &ReportingConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, newValidationRPCError([]any{"reporting_update.tier1_days", "Insufficient disk space for requested retention", float64(22)})
+			},
+		}},
+	}
+
+	schemaResp := getReportingConfigResourceSchema(t)
+	planValue := createReportingConfigModelValue(defaultReportingConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); detail != "Insufficient disk space for requested retention" {
+		t.Errorf("expected tier1_days field error, got %q", detail)
+	}
+}
+
+func TestReportingConfigResource_Read_Success(t *testing.T) {
+	r := &ReportingConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "reporting.config" {
+					t.Errorf("expected method 'reporting.config', got %q", method)
+				}
+				return json.RawMessage(testReportingConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingConfigResourceSchema(t)
+	p := defaultReportingConfigParams()
+	p.ID = "reporting_config"
+	p.Tier1Days = float64(365)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createReportingConfigModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model ReportingConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Tier1Days.ValueInt64() != 730 {
+		t.Errorf("expected tier1_days 730 from API, got %d", model.Tier1Days.ValueInt64())
+	}
+	if model.Tier1UpdateInterval.ValueInt64() != 600 {
+		t.Errorf("expected tier1_update_interval 600, got %d", model.Tier1UpdateInterval.ValueInt64())
+	}
+}
+
+func TestReportingConfigResource_Read_APIError(t *testing.T) {
+	r := &ReportingConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getReportingConfigResourceSchema(t)
+	p := defaultReportingConfigParams()
+	p.ID = "reporting_config"
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createReportingConfigModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestReportingConfigResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &ReportingConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "tier0_days": 30, "tier1_days": 730, "tier1_update_interval": 600}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingConfigResourceSchema(t)
+	state := defaultReportingConfigParams()
+	state.ID = "reporting_config"
+	plan := state
+	plan.Tier0Days = float64(30)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createReportingConfigModelValue(plan)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createReportingConfigModelValue(state)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["tier0_days"] != int64(30) {
+		t.Errorf("expected tier0_days 30, got %v", capturedParams["tier0_days"])
+	}
+
+	var model ReportingConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Tier0Days.ValueInt64() != 30 {
+		t.Errorf("expected tier0_days 30, got %d", model.Tier0Days.ValueInt64())
+	}
+}
+
+func TestReportingConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &ReportingConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingConfigResourceSchema(t)
+	p := defaultReportingConfigParams()
+	p.ID = "reporting_config"
+	stateValue := createReportingConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "reporting.update" {
+		t.Errorf("expected method 'reporting.update', got %q", capturedMethod)
+	}
+	if capturedParams["tier1_days"] != 365 {
+		t.Errorf("expected tier1_days reset to 365, got %v", capturedParams["tier1_days"])
+	}
+	if capturedParams["tier1_update_interval"] != 300 {
+		t.Errorf("expected tier1_update_interval reset to 300, got %v", capturedParams["tier1_update_interval"])
+	}
+}
+
+func TestReportingConfigResource_ImportState(t *testing.T) {
+	r := NewReportingConfigResource().(*ReportingConfigResource)
+	schemaResp := getReportingConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "reporting_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createReportingConfigModelValue(reportingConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestReportingConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewReportingConfigResource().(*ReportingConfigResource)
+	schemaResp := getReportingConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createReportingConfigModelValue(reportingConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
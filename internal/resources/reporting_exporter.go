@@ -0,0 +1,375 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Exporter type constants matching TrueNAS API values.
+const (
+	ReportingExporterTypeGraphite = "GRAPHITE"
+)
+
+var (
+	_ resource.Resource                = &ReportingExporterResource{}
+	_ resource.ResourceWithConfigure   = &ReportingExporterResource{}
+	_ resource.ResourceWithImportState = &ReportingExporterResource{}
+)
+
+// ReportingExporterResourceModel describes the resource data model.
+type ReportingExporterResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Enabled  types.Bool     `tfsdk:"enabled"`
+	Graphite *GraphiteBlock `tfsdk:"graphite"`
+}
+
+// GraphiteBlock represents the attributes of a GRAPHITE exporter.
+type GraphiteBlock struct {
+	DestinationIP         types.String `tfsdk:"destination_ip"`
+	DestinationPort       types.Int64  `tfsdk:"destination_port"`
+	Prefix                types.String `tfsdk:"prefix"`
+	Namespace             types.String `tfsdk:"namespace"`
+	UpdateEvery           types.Int64  `tfsdk:"update_every"`
+	SendNamesInsteadOfIDs types.Bool   `tfsdk:"send_names_instead_of_ids"`
+	MatchingCharts        types.String `tfsdk:"matching_charts"`
+}
+
+// reportingExporterResponse is the JSON shape returned by reporting.exporters.query.
+type reportingExporterResponse struct {
+	ID         int64                       `json:"id"`
+	Name       string                      `json:"name"`
+	Enabled    bool                        `json:"enabled"`
+	Attributes reportingExporterAttributes `json:"attributes"`
+}
+
+// reportingExporterAttributes holds the type-specific exporter attributes.
+type reportingExporterAttributes struct {
+	ExporterType          string `json:"exporter_type"`
+	DestinationIP         string `json:"destination_ip"`
+	DestinationPort       int64  `json:"destination_port"`
+	Prefix                string `json:"prefix"`
+	Namespace             string `json:"namespace"`
+	UpdateEvery           int64  `json:"update_every"`
+	SendNamesInsteadOfIDs bool   `json:"send_names_instead_of_ids"`
+	MatchingCharts        string `json:"matching_charts"`
+}
+
+// ReportingExporterResource defines the resource implementation.
+type ReportingExporterResource struct {
+	BaseResource
+}
+
+// NewReportingExporterResource creates a new ReportingExporterResource.
+func NewReportingExporterResource() resource.Resource {
+	return &ReportingExporterResource{}
+}
+
+func (r *ReportingExporterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reporting_exporter"
+}
+
+func (r *ReportingExporterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a reporting exporter that ships TrueNAS metrics to an external time-series database.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Exporter ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Exporter name.",
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Enable the exporter. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"graphite": schema.SingleNestedBlock{
+				Description: "Graphite exporter settings.",
+				Attributes: map[string]schema.Attribute{
+					"destination_ip": schema.StringAttribute{
+						Description: "Hostname or IP address of the Graphite server.",
+						Optional:    true,
+					},
+					"destination_port": schema.Int64Attribute{
+						Description: "Port of the Graphite server. Defaults to 2003.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(2003),
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					},
+					"prefix": schema.StringAttribute{
+						Description: "Prefix prepended to all metric names. Defaults to 'dragonfish'.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("dragonfish"),
+					},
+					"namespace": schema.StringAttribute{
+						Description: "Namespace appended after the prefix (usually the host name).",
+						Optional:    true,
+					},
+					"update_every": schema.Int64Attribute{
+						Description: "Interval in seconds between metric pushes. Defaults to 1.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(1),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"send_names_instead_of_ids": schema.BoolAttribute{
+						Description: "Send chart names instead of IDs. Defaults to true.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"matching_charts": schema.StringAttribute{
+						Description: "Space-separated list of chart patterns to export. Defaults to '*'.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("*"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildReportingExporterParams builds the API params from the resource model.
+func buildReportingExporterParams(data *ReportingExporterResourceModel) map[string]any {
+	params := map[string]any{
+		"name":    data.Name.ValueString(),
+		"enabled": data.Enabled.ValueBool(),
+	}
+
+	if data.Graphite != nil {
+		attrs := map[string]any{
+			"exporter_type":             ReportingExporterTypeGraphite,
+			"destination_ip":            data.Graphite.DestinationIP.ValueString(),
+			"destination_port":          data.Graphite.DestinationPort.ValueInt64(),
+			"prefix":                    data.Graphite.Prefix.ValueString(),
+			"namespace":                 data.Graphite.Namespace.ValueString(),
+			"update_every":              data.Graphite.UpdateEvery.ValueInt64(),
+			"send_names_instead_of_ids": data.Graphite.SendNamesInsteadOfIDs.ValueBool(),
+			"matching_charts":           data.Graphite.MatchingCharts.ValueString(),
+		}
+		params["attributes"] = attrs
+	}
+
+	return params
+}
+
+func (r *ReportingExporterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ReportingExporterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Graphite == nil {
+		resp.Diagnostics.AddError(
+			"Missing Exporter Configuration",
+			"An exporter type block (graphite) must be specified.",
+		)
+		return
+	}
+
+	params := buildReportingExporterParams(&data)
+
+	result, err := r.client.Call(ctx, "reporting.exporters.create", params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Reporting Exporter",
+			fmt.Sprintf("Unable to create reporting exporter %q: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var exporter reportingExporterResponse
+	if err := json.Unmarshal(result, &exporter); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse reporting exporter create response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapReportingExporterToModel(&exporter, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReportingExporterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ReportingExporterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Unable to parse ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	exporter, err := r.getExporter(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Reporting Exporter",
+			fmt.Sprintf("Unable to query reporting exporter: %s", err.Error()),
+		)
+		return
+	}
+
+	if exporter == nil {
+		// Exporter was deleted outside Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapReportingExporterToModel(exporter, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *ReportingExporterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state ReportingExporterResourceModel
+	var plan ReportingExporterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Unable to parse ID %q: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	params := buildReportingExporterParams(&plan)
+
+	result, err := r.client.Call(ctx, "reporting.exporters.update", []any{id, params})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Reporting Exporter",
+			fmt.Sprintf("Unable to update reporting exporter: %s", err.Error()),
+		)
+		return
+	}
+
+	var exporter reportingExporterResponse
+	if err := json.Unmarshal(result, &exporter); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse reporting exporter update response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapReportingExporterToModel(&exporter, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ReportingExporterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ReportingExporterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("Unable to parse ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if _, err := r.client.Call(ctx, "reporting.exporters.delete", id); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Reporting Exporter",
+			fmt.Sprintf("Unable to delete reporting exporter: %s", err.Error()),
+		)
+		return
+	}
+}
+
+// getExporter queries a single exporter by ID. Returns nil if not found.
+func (r *ReportingExporterResource) getExporter(ctx context.Context, id int64) (*reportingExporterResponse, error) {
+	filter := []any{[]any{[]any{"id", "=", id}}}
+	result, err := r.client.Call(ctx, "reporting.exporters.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporters []reportingExporterResponse
+	if err := json.Unmarshal(result, &exporters); err != nil {
+		return nil, fmt.Errorf("parse exporter query response: %w", err)
+	}
+
+	if len(exporters) == 0 {
+		return nil, nil
+	}
+
+	return &exporters[0], nil
+}
+
+// mapReportingExporterToModel maps an API response to the resource model.
+func mapReportingExporterToModel(exporter *reportingExporterResponse, data *ReportingExporterResourceModel) {
+	data.ID = types.StringValue(strconv.FormatInt(exporter.ID, 10))
+	data.Name = types.StringValue(exporter.Name)
+	data.Enabled = types.BoolValue(exporter.Enabled)
+
+	if exporter.Attributes.ExporterType != ReportingExporterTypeGraphite {
+		data.Graphite = nil
+		return
+	}
+
+	attrs := exporter.Attributes
+	data.Graphite = &GraphiteBlock{
+		DestinationIP:         nonEmptyStringValue(attrs.DestinationIP),
+		DestinationPort:       types.Int64Value(attrs.DestinationPort),
+		Prefix:                types.StringValue(attrs.Prefix),
+		Namespace:             nonEmptyStringValue(attrs.Namespace),
+		UpdateEvery:           types.Int64Value(attrs.UpdateEvery),
+		SendNamesInsteadOfIDs: types.BoolValue(attrs.SendNamesInsteadOfIDs),
+		MatchingCharts:        types.StringValue(attrs.MatchingCharts),
+	}
+}
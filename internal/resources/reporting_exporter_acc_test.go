@@ -0,0 +1,48 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/acctest"
+)
+
+func TestAccReportingExporterResource_basic(t *testing.T) {
+	acctest.PreCheck(t)
+	h := acctest.NewHarness(t)
+	r := h.Resource("truenas_reporting_exporter")
+
+	config := map[string]any{
+		"name": "graphite",
+		"graphite": map[string]any{
+			"destination_ip": "10.0.0.20",
+			"namespace":      "nas01",
+		},
+	}
+	state := r.Apply(config)
+
+	id, _ := state.Get("id").(string)
+	if id == "" {
+		t.Fatal("expected an exporter id")
+	}
+	if got := state.Get("graphite.destination_port"); got != int64(2003) {
+		t.Errorf("expected default destination_port 2003, got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	config["enabled"] = false
+	state = r.Apply(config)
+	if got := state.Get("enabled"); got != false {
+		t.Errorf("expected enabled false, got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	state = r.Import(id)
+	if got := state.Get("graphite.namespace"); got != "nas01" {
+		t.Errorf("expected imported namespace 'nas01', got %v", got)
+	}
+
+	r.Destroy()
+	if calls := h.Server.Calls("reporting.exporters.delete"); len(calls) != 1 {
+		t.Errorf("expected 1 delete call, got %d", len(calls))
+	}
+}
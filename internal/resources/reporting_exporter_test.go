@@ -0,0 +1,613 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewReportingExporterResource(t *testing.T) {
+	r := NewReportingExporterResource()
+	if r == nil {
+		t.Fatal("NewReportingExporterResource returned nil")
+	}
+
+	_, ok := r.(*ReportingExporterResource)
+	if !ok {
+		t.Fatalf("expected *ReportingExporterResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*ReportingExporterResource))
+	_ = resource.ResourceWithImportState(r.(*ReportingExporterResource))
+}
+
+func TestReportingExporterResource_Metadata(t *testing.T) {
+	r := NewReportingExporterResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_reporting_exporter" {
+		t.Errorf("expected TypeName 'truenas_reporting_exporter', got %q", resp.TypeName)
+	}
+}
+
+func TestReportingExporterResource_Schema(t *testing.T) {
+	schemaResp := getReportingExporterResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	if !attrs["name"].IsRequired() {
+		t.Error("expected 'name' attribute to be required")
+	}
+	if !attrs["enabled"].IsOptional() {
+		t.Error("expected 'enabled' attribute to be optional")
+	}
+
+	if schemaResp.Schema.Blocks["graphite"] == nil {
+		t.Error("expected 'graphite' block")
+	}
+}
+
+// Test helpers
+
+func getReportingExporterResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewReportingExporterResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// reportingExporterModelParams holds parameters for creating test model values.
+type reportingExporterModelParams struct {
+	ID       interface{}
+	Name     interface{}
+	Enabled  interface{}
+	Graphite *graphiteBlockParams
+}
+
+type graphiteBlockParams struct {
+	DestinationIP         interface{}
+	DestinationPort       interface{}
+	Prefix                interface{}
+	Namespace             interface{}
+	UpdateEvery           interface{}
+	SendNamesInsteadOfIDs interface{}
+	MatchingCharts        interface{}
+}
+
+func graphiteBlockType() tftypes.Object {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"destination_ip":            tftypes.String,
+		"destination_port":          tftypes.Number,
+		"prefix":                    tftypes.String,
+		"namespace":                 tftypes.String,
+		"update_every":              tftypes.Number,
+		"send_names_instead_of_ids": tftypes.Bool,
+		"matching_charts":           tftypes.String,
+	}}
+}
+
+func createReportingExporterModelValue(p reportingExporterModelParams) tftypes.Value {
+	graphite := tftypes.NewValue(graphiteBlockType(), nil)
+	if p.Graphite != nil {
+		graphite = tftypes.NewValue(graphiteBlockType(), map[string]tftypes.Value{
+			"destination_ip":            tftypes.NewValue(tftypes.String, p.Graphite.DestinationIP),
+			"destination_port":          tftypes.NewValue(tftypes.Number, p.Graphite.DestinationPort),
+			"prefix":                    tftypes.NewValue(tftypes.String, p.Graphite.Prefix),
+			"namespace":                 tftypes.NewValue(tftypes.String, p.Graphite.Namespace),
+			"update_every":              tftypes.NewValue(tftypes.Number, p.Graphite.UpdateEvery),
+			"send_names_instead_of_ids": tftypes.NewValue(tftypes.Bool, p.Graphite.SendNamesInsteadOfIDs),
+			"matching_charts":           tftypes.NewValue(tftypes.String, p.Graphite.MatchingCharts),
+		})
+	}
+
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.String,
+			"name":     tftypes.String,
+			"enabled":  tftypes.Bool,
+			"graphite": graphiteBlockType(),
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, p.ID),
+		"name":     tftypes.NewValue(tftypes.String, p.Name),
+		"enabled":  tftypes.NewValue(tftypes.Bool, p.Enabled),
+		"graphite": graphite,
+	})
+}
+
+func defaultGraphiteParams() *graphiteBlockParams {
+	return &graphiteBlockParams{
+		DestinationIP:         "graphite.example.com",
+		DestinationPort:       float64(2003),
+		Prefix:                "truenas",
+		Namespace:             "nas01",
+		UpdateEvery:           float64(10),
+		SendNamesInsteadOfIDs: true,
+		MatchingCharts:        "*",
+	}
+}
+
+const testReportingExporterJSON = `{
+	"id": 3,
+	"name": "graphite",
+	"enabled": true,
+	"attributes": {
+		"exporter_type": "GRAPHITE",
+		"destination_ip": "graphite.example.com",
+		"destination_port": 2003,
+		"prefix": "truenas",
+		"namespace": "nas01",
+		"update_every": 10,
+		"send_names_instead_of_ids": true,
+		"matching_charts": "*"
+	}
+}`
+
+func TestReportingExporterResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testReportingExporterJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	planValue := createReportingExporterModelValue(reportingExporterModelParams{
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "reporting.exporters.create" {
+		t.Errorf("expected method 'reporting.exporters.create', got %q", capturedMethod)
+	}
+	if capturedParams["name"] != "graphite" {
+		t.Errorf("expected name 'graphite', got %v", capturedParams["name"])
+	}
+	attrs, ok := capturedParams["attributes"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected attributes map, got %T", capturedParams["attributes"])
+	}
+	if attrs["exporter_type"] != "GRAPHITE" {
+		t.Errorf("expected exporter_type 'GRAPHITE', got %v", attrs["exporter_type"])
+	}
+	if attrs["destination_port"] != int64(2003) {
+		t.Errorf("expected destination_port 2003, got %v", attrs["destination_port"])
+	}
+
+	var model ReportingExporterResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "3" {
+		t.Errorf("expected ID '3', got %q", model.ID.ValueString())
+	}
+	if model.Graphite == nil || model.Graphite.Namespace.ValueString() != "nas01" {
+		t.Error("expected graphite namespace 'nas01'")
+	}
+}
+
+func TestReportingExporterResource_Create_MissingBlock(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				t.Fatal("API should not be called without an exporter block")
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	planValue := createReportingExporterModelValue(reportingExporterModelParams{
+		Name:    "graphite",
+		Enabled: true,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when no exporter block is set")
+	}
+}
+
+func TestReportingExporterResource_Create_APIError(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	planValue := createReportingExporterModelValue(reportingExporterModelParams{
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestReportingExporterResource_Read_Success(t *testing.T) {
+	var capturedFilter any
+
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "reporting.exporters.query" {
+					t.Errorf("expected method 'reporting.exporters.query', got %q", method)
+				}
+				capturedFilter = params
+				return json.RawMessage("[" + testReportingExporterJSON + "]"), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "old-name",
+		Enabled:  false,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedFilter == nil {
+		t.Fatal("expected query filter to be passed")
+	}
+
+	var model ReportingExporterResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Name.ValueString() != "graphite" {
+		t.Errorf("expected name 'graphite', got %q", model.Name.ValueString())
+	}
+	if !model.Enabled.ValueBool() {
+		t.Error("expected enabled to be true")
+	}
+}
+
+func TestReportingExporterResource_Read_NotFound(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage("[]"), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed when exporter not found")
+	}
+}
+
+func TestReportingExporterResource_Read_APIError(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestReportingExporterResource_Read_InvalidID(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "not-a-number",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid ID")
+	}
+}
+
+func TestReportingExporterResource_Update_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedID any
+
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedID = params.([]any)[0]
+				return json.RawMessage(testReportingExporterJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  false,
+		Graphite: defaultGraphiteParams(),
+	})
+	planValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "reporting.exporters.update" {
+		t.Errorf("expected method 'reporting.exporters.update', got %q", capturedMethod)
+	}
+	if capturedID != int64(3) {
+		t.Errorf("expected ID 3, got %v", capturedID)
+	}
+}
+
+func TestReportingExporterResource_Update_APIError(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	value := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestReportingExporterResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedID any
+
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedID = params
+				return json.RawMessage("true"), nil
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "reporting.exporters.delete" {
+		t.Errorf("expected method 'reporting.exporters.delete', got %q", capturedMethod)
+	}
+	if capturedID != int64(3) {
+		t.Errorf("expected ID 3, got %v", capturedID)
+	}
+}
+
+func TestReportingExporterResource_Delete_APIError(t *testing.T) {
+	r := &ReportingExporterResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("exporter in use")
+			},
+		}},
+	}
+
+	schemaResp := getReportingExporterResourceSchema(t)
+	stateValue := createReportingExporterModelValue(reportingExporterModelParams{
+		ID:       "3",
+		Name:     "graphite",
+		Enabled:  true,
+		Graphite: defaultGraphiteParams(),
+	})
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestMapReportingExporterToModel_UnknownType(t *testing.T) {
+	exporter := &reportingExporterResponse{
+		ID:      7,
+		Name:    "other",
+		Enabled: true,
+		Attributes: reportingExporterAttributes{
+			ExporterType: "SOMETHING_ELSE",
+		},
+	}
+
+	var data ReportingExporterResourceModel
+	data.Graphite = &GraphiteBlock{}
+	mapReportingExporterToModel(exporter, &data)
+
+	if data.Graphite != nil {
+		t.Error("expected graphite block to be nil for non-graphite exporter")
+	}
+	if data.ID.ValueString() != "7" {
+		t.Errorf("expected ID '7', got %q", data.ID.ValueString())
+	}
+}
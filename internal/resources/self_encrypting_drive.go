@@ -0,0 +1,362 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &SelfEncryptingDriveResource{}
+	_ resource.ResourceWithConfigure   = &SelfEncryptingDriveResource{}
+	_ resource.ResourceWithImportState = &SelfEncryptingDriveResource{}
+)
+
+// SelfEncryptingDriveResourceModel describes the resource data model.
+type SelfEncryptingDriveResourceModel struct {
+	ID       types.String           `tfsdk:"id"`
+	SEDUser  types.String           `tfsdk:"sed_user"`
+	Password types.String           `tfsdk:"password"`
+	Disk     []SEDDiskPasswordModel `tfsdk:"disk"`
+}
+
+// SEDDiskPasswordModel represents a per-disk SED password override.
+type SEDDiskPasswordModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	Password   types.String `tfsdk:"password"`
+}
+
+// sedAdvancedConfigResponse is the subset of system.advanced.config used for SED.
+type sedAdvancedConfigResponse struct {
+	SEDUser string `json:"sed_user"`
+}
+
+// sedDiskResponse is the subset of disk.query fields used for SED.
+type sedDiskResponse struct {
+	Identifier string `json:"identifier"`
+	Passwd     string `json:"passwd"`
+}
+
+// SelfEncryptingDriveResource defines the resource implementation.
+type SelfEncryptingDriveResource struct {
+	BaseResource
+}
+
+// NewSelfEncryptingDriveResource creates a new SelfEncryptingDriveResource.
+func NewSelfEncryptingDriveResource() resource.Resource {
+	return &SelfEncryptingDriveResource{}
+}
+
+func (r *SelfEncryptingDriveResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_self_encrypting_drive"
+}
+
+func (r *SelfEncryptingDriveResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages self-encrypting drive (SED) passwords on TrueNAS: the global SED password " +
+			"and optional per-disk overrides. Passwords are write-only and never read back.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'self_encrypting_drive').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sed_user": schema.StringAttribute{
+				Description: "SED user used to unlock drives: USER or MASTER. Defaults to USER.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("USER"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("USER", "MASTER"),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Global SED password used for all drives without a per-disk password.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"disk": schema.ListNestedBlock{
+				Description: "Per-disk SED passwords, set via disk.update.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"identifier": schema.StringAttribute{
+							Description: "Disk identifier as reported by disk.query (e.g. {serial_lunid}...).",
+							Required:    true,
+						},
+						"password": schema.StringAttribute{
+							Description: "SED password for this disk.",
+							Required:    true,
+							Sensitive:   true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *SelfEncryptingDriveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SelfEncryptingDriveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setGlobalPassword(ctx, data.SEDUser.ValueString(), data.Password.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Set SED Password",
+			fmt.Sprintf("Unable to set global SED password: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, disk := range data.Disk {
+		if err := r.setDiskPassword(ctx, disk.Identifier.ValueString(), disk.Password.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Set Disk SED Password",
+				fmt.Sprintf("Unable to set SED password for disk %q: %s", disk.Identifier.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	data.ID = types.StringValue("self_encrypting_drive")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SelfEncryptingDriveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SelfEncryptingDriveResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "system.advanced.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SED Config",
+			fmt.Sprintf("Unable to read advanced system configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config sedAdvancedConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse advanced system configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("self_encrypting_drive")
+	data.SEDUser = types.StringValue(config.SEDUser)
+
+	// The password itself is never returned; only detect removal.
+	result, err = r.client.Call(ctx, "system.advanced.sed_global_password_is_set", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SED Config",
+			fmt.Sprintf("Unable to check global SED password: %s", err.Error()),
+		)
+		return
+	}
+
+	var isSet bool
+	if err := json.Unmarshal(result, &isSet); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse global SED password status: %s", err.Error()),
+		)
+		return
+	}
+	if !isSet {
+		data.Password = types.StringNull()
+	}
+
+	// Drop disks that no longer exist or no longer have a password so that
+	// Terraform plans to set them again.
+	disks := make([]SEDDiskPasswordModel, 0, len(data.Disk))
+	for _, disk := range data.Disk {
+		remote, err := r.getDisk(ctx, disk.Identifier.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Disk",
+				fmt.Sprintf("Unable to read disk %q: %s", disk.Identifier.ValueString(), err.Error()),
+			)
+			return
+		}
+		if remote == nil || remote.Passwd == "" {
+			continue
+		}
+		disks = append(disks, disk)
+	}
+	if len(disks) > 0 {
+		data.Disk = disks
+	} else {
+		data.Disk = nil
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *SelfEncryptingDriveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SelfEncryptingDriveResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setGlobalPassword(ctx, plan.SEDUser.ValueString(), plan.Password.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Set SED Password",
+			fmt.Sprintf("Unable to set global SED password: %s", err.Error()),
+		)
+		return
+	}
+
+	// Clear passwords for disks removed from the configuration
+	planned := make(map[string]bool, len(plan.Disk))
+	for _, disk := range plan.Disk {
+		planned[disk.Identifier.ValueString()] = true
+	}
+	for _, disk := range state.Disk {
+		if planned[disk.Identifier.ValueString()] {
+			continue
+		}
+		if err := r.setDiskPassword(ctx, disk.Identifier.ValueString(), ""); err != nil && !isNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Unable to Clear Disk SED Password",
+				fmt.Sprintf("Unable to clear SED password for disk %q: %s", disk.Identifier.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	for _, disk := range plan.Disk {
+		if err := r.setDiskPassword(ctx, disk.Identifier.ValueString(), disk.Password.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Set Disk SED Password",
+				fmt.Sprintf("Unable to set SED password for disk %q: %s", disk.Identifier.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue("self_encrypting_drive")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SelfEncryptingDriveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SelfEncryptingDriveResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, disk := range data.Disk {
+		if err := r.setDiskPassword(ctx, disk.Identifier.ValueString(), ""); err != nil && !isNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Unable to Clear Disk SED Password",
+				fmt.Sprintf("Unable to clear SED password for disk %q: %s", disk.Identifier.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	// Reset to TrueNAS defaults
+	if err := r.setGlobalPassword(ctx, "USER", ""); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset SED Password",
+			fmt.Sprintf("Unable to clear global SED password: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *SelfEncryptingDriveResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "self_encrypting_drive"
+	if req.ID != "self_encrypting_drive" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'self_encrypting_drive', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setGlobalPassword updates the SED user and global password via system.advanced.update.
+// An empty password clears the global password.
+func (r *SelfEncryptingDriveResource) setGlobalPassword(ctx context.Context, sedUser, password string) error {
+	params := map[string]any{
+		"sed_user":   sedUser,
+		"sed_passwd": password,
+	}
+
+	_, err := r.client.Call(ctx, "system.advanced.update", params)
+	return err
+}
+
+// setDiskPassword updates a single disk's SED password via disk.update.
+// An empty password clears the per-disk override.
+func (r *SelfEncryptingDriveResource) setDiskPassword(ctx context.Context, identifier, password string) error {
+	params := []any{identifier, map[string]any{"passwd": password}}
+
+	_, err := r.client.Call(ctx, "disk.update", params)
+	return err
+}
+
+// getDisk queries a disk by identifier, including its SED password.
+// Returns nil if the disk does not exist.
+func (r *SelfEncryptingDriveResource) getDisk(ctx context.Context, identifier string) (*sedDiskResponse, error) {
+	params := []any{
+		[]any{[]any{"identifier", "=", identifier}},
+		map[string]any{"extra": map[string]any{"passwords": true}},
+	}
+
+	result, err := r.client.Call(ctx, "disk.query", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []sedDiskResponse
+	if err := json.Unmarshal(result, &disks); err != nil {
+		return nil, fmt.Errorf("parse disk query response: %w", err)
+	}
+
+	if len(disks) == 0 {
+		return nil, nil
+	}
+
+	return &disks[0], nil
+}
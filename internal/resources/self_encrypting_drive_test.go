@@ -0,0 +1,526 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewSelfEncryptingDriveResource(t *testing.T) {
+	r := NewSelfEncryptingDriveResource()
+	if r == nil {
+		t.Fatal("NewSelfEncryptingDriveResource returned nil")
+	}
+
+	_, ok := r.(*SelfEncryptingDriveResource)
+	if !ok {
+		t.Fatalf("expected *SelfEncryptingDriveResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*SelfEncryptingDriveResource))
+	_ = resource.ResourceWithImportState(r.(*SelfEncryptingDriveResource))
+}
+
+func TestSelfEncryptingDriveResource_Metadata(t *testing.T) {
+	r := NewSelfEncryptingDriveResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_self_encrypting_drive" {
+		t.Errorf("expected TypeName 'truenas_self_encrypting_drive', got %q", resp.TypeName)
+	}
+}
+
+func TestSelfEncryptingDriveResource_Schema(t *testing.T) {
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["password"].IsSensitive() {
+		t.Error("expected 'password' attribute to be sensitive")
+	}
+	if !attrs["sed_user"].IsOptional() {
+		t.Error("expected 'sed_user' attribute to be optional")
+	}
+	if _, ok := schemaResp.Schema.Blocks["disk"]; !ok {
+		t.Error("expected 'disk' block")
+	}
+}
+
+// Test helpers
+
+func getSelfEncryptingDriveResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewSelfEncryptingDriveResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+var sedDiskObjectType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"identifier": tftypes.String,
+		"password":   tftypes.String,
+	},
+}
+
+// sedDiskParams holds parameters for a disk block in test model values.
+type sedDiskParams struct {
+	Identifier string
+	Password   string
+}
+
+// selfEncryptingDriveModelParams holds parameters for creating test model values.
+type selfEncryptingDriveModelParams struct {
+	ID       interface{}
+	SEDUser  interface{}
+	Password interface{}
+	Disks    []sedDiskParams
+}
+
+func createSelfEncryptingDriveModelValue(p selfEncryptingDriveModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":       tftypes.String,
+			"sed_user": tftypes.String,
+			"password": tftypes.String,
+			"disk":     tftypes.List{ElementType: sedDiskObjectType},
+		},
+	}
+
+	var disks []tftypes.Value
+	for _, d := range p.Disks {
+		disks = append(disks, tftypes.NewValue(sedDiskObjectType, map[string]tftypes.Value{
+			"identifier": tftypes.NewValue(tftypes.String, d.Identifier),
+			"password":   tftypes.NewValue(tftypes.String, d.Password),
+		}))
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, p.ID),
+		"sed_user": tftypes.NewValue(tftypes.String, p.SEDUser),
+		"password": tftypes.NewValue(tftypes.String, p.Password),
+		"disk":     tftypes.NewValue(tftypes.List{ElementType: sedDiskObjectType}, disks),
+	})
+}
+
+func defaultSelfEncryptingDriveParams() selfEncryptingDriveModelParams {
+	return selfEncryptingDriveModelParams{
+		SEDUser:  "USER",
+		Password: "global-secret",
+		Disks: []sedDiskParams{
+			{Identifier: "{serial_lunid}AAA_111", Password: "disk-secret"},
+		},
+	}
+}
+
+func TestSelfEncryptingDriveResource_Create_Success(t *testing.T) {
+	var advancedParams map[string]any
+	var diskParams []any
+
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				switch method {
+				case "system.advanced.update":
+					advancedParams = params.(map[string]any)
+				case "disk.update":
+					diskParams = params.([]any)
+				default:
+					t.Errorf("unexpected method %q", method)
+				}
+				return json.RawMessage(`{}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	planValue := createSelfEncryptingDriveModelValue(defaultSelfEncryptingDriveParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if advancedParams["sed_passwd"] != "global-secret" || advancedParams["sed_user"] != "USER" {
+		t.Errorf("unexpected system.advanced.update params: %v", advancedParams)
+	}
+	if diskParams[0] != "{serial_lunid}AAA_111" {
+		t.Errorf("expected disk identifier, got %v", diskParams[0])
+	}
+	if diskParams[1].(map[string]any)["passwd"] != "disk-secret" {
+		t.Errorf("expected disk passwd 'disk-secret', got %v", diskParams[1])
+	}
+
+	var model SelfEncryptingDriveResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "self_encrypting_drive" {
+		t.Errorf("expected ID 'self_encrypting_drive', got %q", model.ID.ValueString())
+	}
+}
+
+func TestSelfEncryptingDriveResource_Create_DiskError(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method == "disk.update" {
+					return nil, errors.New("disk does not support SED")
+				}
+				return json.RawMessage(`{}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	planValue := createSelfEncryptingDriveModelValue(defaultSelfEncryptingDriveParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for disk update failure")
+	}
+}
+
+func TestSelfEncryptingDriveResource_Create_APIError(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	planValue := createSelfEncryptingDriveModelValue(defaultSelfEncryptingDriveParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSelfEncryptingDriveResource_Read_Success(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				switch method {
+				case "system.advanced.config":
+					return json.RawMessage(`{"id": 1, "sed_user": "MASTER"}`), nil
+				case "system.advanced.sed_global_password_is_set":
+					return json.RawMessage(`true`), nil
+				case "disk.query":
+					return json.RawMessage(`[{"identifier": "{serial_lunid}AAA_111", "passwd": "disk-secret"}]`), nil
+				}
+				return nil, errors.New("unexpected method: " + method)
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	p := defaultSelfEncryptingDriveParams()
+	p.ID = "self_encrypting_drive"
+	stateValue := createSelfEncryptingDriveModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model SelfEncryptingDriveResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.SEDUser.ValueString() != "MASTER" {
+		t.Errorf("expected sed_user 'MASTER', got %q", model.SEDUser.ValueString())
+	}
+	if model.Password.ValueString() != "global-secret" {
+		t.Error("expected password to be preserved from state")
+	}
+	if len(model.Disk) != 1 {
+		t.Errorf("expected 1 disk, got %d", len(model.Disk))
+	}
+}
+
+func TestSelfEncryptingDriveResource_Read_DetectsRemovedPasswords(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				switch method {
+				case "system.advanced.config":
+					return json.RawMessage(`{"id": 1, "sed_user": "USER"}`), nil
+				case "system.advanced.sed_global_password_is_set":
+					return json.RawMessage(`false`), nil
+				case "disk.query":
+					return json.RawMessage(`[{"identifier": "{serial_lunid}AAA_111", "passwd": ""}]`), nil
+				}
+				return nil, errors.New("unexpected method: " + method)
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	p := defaultSelfEncryptingDriveParams()
+	p.ID = "self_encrypting_drive"
+	stateValue := createSelfEncryptingDriveModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model SelfEncryptingDriveResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.Password.IsNull() {
+		t.Error("expected password to be null when not set on the server")
+	}
+	if len(model.Disk) != 0 {
+		t.Errorf("expected disk without password to be dropped, got %d", len(model.Disk))
+	}
+}
+
+func TestSelfEncryptingDriveResource_Read_APIError(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	p := defaultSelfEncryptingDriveParams()
+	p.ID = "self_encrypting_drive"
+	stateValue := createSelfEncryptingDriveModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSelfEncryptingDriveResource_Update_ClearsRemovedDisks(t *testing.T) {
+	diskPasswords := map[string]string{}
+
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method == "disk.update" {
+					args := params.([]any)
+					diskPasswords[args[0].(string)] = args[1].(map[string]any)["passwd"].(string)
+				}
+				return json.RawMessage(`{}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	state := defaultSelfEncryptingDriveParams()
+	state.ID = "self_encrypting_drive"
+	state.Disks = append(state.Disks, sedDiskParams{Identifier: "{serial_lunid}BBB_222", Password: "old"})
+	plan := defaultSelfEncryptingDriveParams()
+	plan.ID = "self_encrypting_drive"
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createSelfEncryptingDriveModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSelfEncryptingDriveModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if pw, ok := diskPasswords["{serial_lunid}BBB_222"]; !ok || pw != "" {
+		t.Errorf("expected removed disk password to be cleared, got %q (called=%v)", pw, ok)
+	}
+	if diskPasswords["{serial_lunid}AAA_111"] != "disk-secret" {
+		t.Errorf("expected kept disk password to be set, got %q", diskPasswords["{serial_lunid}AAA_111"])
+	}
+}
+
+func TestSelfEncryptingDriveResource_Update_APIError(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	p := defaultSelfEncryptingDriveParams()
+	p.ID = "self_encrypting_drive"
+	value := createSelfEncryptingDriveModelValue(p)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSelfEncryptingDriveResource_Delete_ClearsPasswords(t *testing.T) {
+	var methods []string
+	var advancedParams map[string]any
+
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				methods = append(methods, method)
+				if method == "system.advanced.update" {
+					advancedParams = params.(map[string]any)
+				}
+				return json.RawMessage(`{}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	p := defaultSelfEncryptingDriveParams()
+	p.ID = "self_encrypting_drive"
+	stateValue := createSelfEncryptingDriveModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(methods) != 2 || methods[0] != "disk.update" || methods[1] != "system.advanced.update" {
+		t.Errorf("unexpected call sequence: %v", methods)
+	}
+	if advancedParams["sed_passwd"] != "" {
+		t.Errorf("expected global password to be cleared, got %v", advancedParams["sed_passwd"])
+	}
+}
+
+func TestSelfEncryptingDriveResource_Delete_APIError(t *testing.T) {
+	r := &SelfEncryptingDriveResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+	p := defaultSelfEncryptingDriveParams()
+	p.ID = "self_encrypting_drive"
+	stateValue := createSelfEncryptingDriveModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSelfEncryptingDriveResource_ImportState_InvalidID(t *testing.T) {
+	r := NewSelfEncryptingDriveResource().(*SelfEncryptingDriveResource)
+	schemaResp := getSelfEncryptingDriveResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "sed"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createSelfEncryptingDriveModelValue(selfEncryptingDriveModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -204,6 +204,7 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 	mapSnapshotToModel(snap, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
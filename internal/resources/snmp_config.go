@@ -0,0 +1,318 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &SNMPConfigResource{}
+	_ resource.ResourceWithConfigure   = &SNMPConfigResource{}
+	_ resource.ResourceWithImportState = &SNMPConfigResource{}
+)
+
+// SNMPConfigResourceModel describes the resource data model.
+type SNMPConfigResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Location         types.String `tfsdk:"location"`
+	Contact          types.String `tfsdk:"contact"`
+	Community        types.String `tfsdk:"community"`
+	Traps            types.Bool   `tfsdk:"traps"`
+	V3               types.Bool   `tfsdk:"v3"`
+	V3Username       types.String `tfsdk:"v3_username"`
+	V3AuthType       types.String `tfsdk:"v3_authtype"`
+	V3Password       types.String `tfsdk:"v3_password"`
+	V3PrivProto      types.String `tfsdk:"v3_privproto"`
+	V3PrivPassphrase types.String `tfsdk:"v3_privpassphrase"`
+	LogLevel         types.Int64  `tfsdk:"loglevel"`
+	Options          types.String `tfsdk:"options"`
+	ZILStat          types.Bool   `tfsdk:"zilstat"`
+}
+
+// SNMPConfigResource defines the resource implementation.
+type SNMPConfigResource struct {
+	BaseResource
+}
+
+// NewSNMPConfigResource creates a new SNMPConfigResource.
+func NewSNMPConfigResource() resource.Resource {
+	return &SNMPConfigResource{}
+}
+
+func (r *SNMPConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snmp_config"
+}
+
+func (r *SNMPConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the global SNMP service configuration on TrueNAS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'snmp_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "System location reported over SNMP.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"contact": schema.StringAttribute{
+				Description: "Contact information reported over SNMP (e-mail or name).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"community": schema.StringAttribute{
+				Description: "SNMPv1/v2c community string. Defaults to 'public'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("public"),
+			},
+			"traps": schema.BoolAttribute{
+				Description: "Enable SNMP traps. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"v3": schema.BoolAttribute{
+				Description: "Enable SNMPv3. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"v3_username": schema.StringAttribute{
+				Description: "SNMPv3 username.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"v3_authtype": schema.StringAttribute{
+				Description: "SNMPv3 authentication type: MD5 or SHA. Defaults to SHA.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("SHA"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("", "MD5", "SHA"),
+				},
+			},
+			"v3_password": schema.StringAttribute{
+				Description: "SNMPv3 authentication password (at least 8 characters).",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(8),
+				},
+			},
+			"v3_privproto": schema.StringAttribute{
+				Description: "SNMPv3 privacy protocol: AES or DES. Null disables privacy.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("AES", "DES"),
+				},
+			},
+			"v3_privpassphrase": schema.StringAttribute{
+				Description: "SNMPv3 privacy passphrase (at least 8 characters).",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(8),
+				},
+			},
+			"loglevel": schema.Int64Attribute{
+				Description: "SNMP daemon log level (0=emergency through 7=debug). Defaults to 3.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 7),
+				},
+			},
+			"options": schema.StringAttribute{
+				Description: "Auxiliary parameters appended to snmpd.conf.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"zilstat": schema.BoolAttribute{
+				Description: "Expose ZIL statistics over SNMP. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *SNMPConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SNMPConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := methods.SNMPUpdate(ctx, r.client, buildSNMPConfigParams(&data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SNMP Config",
+			fmt.Sprintf("Unable to update SNMP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapSNMPConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SNMPConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SNMPConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := methods.SNMPConfig(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SNMP Config",
+			fmt.Sprintf("Unable to read SNMP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapSNMPConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *SNMPConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SNMPConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := methods.SNMPUpdate(ctx, r.client, buildSNMPConfigParams(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SNMP Config",
+			fmt.Sprintf("Unable to update SNMP configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapSNMPConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SNMPConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := methods.SNMPUpdateParams{
+		Location:    methods.Set(""),
+		Contact:     methods.Set(""),
+		Community:   methods.Set("public"),
+		Traps:       methods.Set(false),
+		V3:          methods.Set(false),
+		V3Username:  methods.Set(""),
+		V3Authtype:  methods.Set("SHA"),
+		V3Privproto: methods.Null[string](),
+		Loglevel:    methods.Set(int64(3)),
+		Options:     methods.Set(""),
+		Zilstat:     methods.Set(false),
+	}
+
+	if _, err := methods.SNMPUpdate(ctx, r.client, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset SNMP Config",
+			fmt.Sprintf("Unable to reset SNMP configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *SNMPConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "snmp_config"
+	if req.ID != "snmp_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'snmp_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildSNMPConfigParams builds the snmp.update params from the resource model.
+// Secrets are only sent when set so that existing values are preserved.
+func buildSNMPConfigParams(data *SNMPConfigResourceModel) methods.SNMPUpdateParams {
+	params := methods.SNMPUpdateParams{
+		Location:    methods.Set(data.Location.ValueString()),
+		Contact:     methods.Set(data.Contact.ValueString()),
+		Community:   methods.Set(data.Community.ValueString()),
+		Traps:       methods.Set(data.Traps.ValueBool()),
+		V3:          methods.Set(data.V3.ValueBool()),
+		V3Username:  methods.Set(data.V3Username.ValueString()),
+		V3Authtype:  methods.Set(data.V3AuthType.ValueString()),
+		V3Privproto: methods.Null[string](),
+		Loglevel:    methods.Set(data.LogLevel.ValueInt64()),
+		Options:     methods.Set(data.Options.ValueString()),
+		Zilstat:     methods.Set(data.ZILStat.ValueBool()),
+	}
+
+	if !data.V3PrivProto.IsNull() && !data.V3PrivProto.IsUnknown() {
+		params.V3Privproto = methods.Set(data.V3PrivProto.ValueString())
+	}
+	if !data.V3Password.IsNull() && !data.V3Password.IsUnknown() {
+		params.V3Password = methods.Set(data.V3Password.ValueString())
+	}
+	if !data.V3PrivPassphrase.IsNull() && !data.V3PrivPassphrase.IsUnknown() {
+		params.V3Privpassphrase = methods.Set(data.V3PrivPassphrase.ValueString())
+	}
+
+	return params
+}
+
+// mapSNMPConfigToModel maps the API response to the resource model.
+// Secrets are write-only and are preserved from the plan/state.
+func mapSNMPConfigToModel(config *methods.SNMPConfigResult, data *SNMPConfigResourceModel) {
+	data.ID = types.StringValue("snmp_config")
+	data.Location = types.StringValue(config.Location)
+	data.Contact = types.StringValue(config.Contact)
+	data.Community = types.StringValue(config.Community)
+	data.Traps = types.BoolValue(config.Traps)
+	data.V3 = types.BoolValue(config.V3)
+	data.V3Username = types.StringValue(config.V3Username)
+	data.V3AuthType = types.StringValue(config.V3Authtype)
+	if config.V3Privproto != nil && *config.V3Privproto != "" {
+		data.V3PrivProto = types.StringValue(*config.V3Privproto)
+	} else {
+		data.V3PrivProto = types.StringNull()
+	}
+	data.LogLevel = types.Int64Value(config.Loglevel)
+	data.Options = types.StringValue(config.Options)
+	data.ZILStat = types.BoolValue(config.Zilstat)
+}
@@ -0,0 +1,42 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/acctest"
+)
+
+func TestAccSNMPConfigResource_basic(t *testing.T) {
+	acctest.PreCheck(t)
+	h := acctest.NewHarness(t)
+	r := h.Resource("truenas_snmp_config")
+
+	config := map[string]any{
+		"location":  "rack 4",
+		"contact":   "ops@example.com",
+		"community": "monitoring",
+	}
+	state := r.Apply(config)
+
+	if got := state.Get("id"); got != "snmp_config" {
+		t.Errorf("expected id 'snmp_config', got %v", got)
+	}
+	if got := state.Get("loglevel"); got != int64(3) {
+		t.Errorf("expected default loglevel 3, got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	config["traps"] = true
+	state = r.Apply(config)
+	if got := state.Get("traps"); got != true {
+		t.Errorf("expected traps true, got %v", got)
+	}
+	r.ExpectNoChanges(config)
+
+	state = r.Import("snmp_config")
+	if got := state.Get("location"); got != "rack 4" {
+		t.Errorf("expected imported location 'rack 4', got %v", got)
+	}
+
+	r.Destroy()
+}
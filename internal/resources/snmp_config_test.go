@@ -0,0 +1,569 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewSNMPConfigResource(t *testing.T) {
+	r := NewSNMPConfigResource()
+	if r == nil {
+		t.Fatal("NewSNMPConfigResource returned nil")
+	}
+
+	_, ok := r.(*SNMPConfigResource)
+	if !ok {
+		t.Fatalf("expected *SNMPConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*SNMPConfigResource))
+	_ = resource.ResourceWithImportState(r.(*SNMPConfigResource))
+}
+
+func TestSNMPConfigResource_Metadata(t *testing.T) {
+	r := NewSNMPConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_snmp_config" {
+		t.Errorf("expected TypeName 'truenas_snmp_config', got %q", resp.TypeName)
+	}
+}
+
+func TestSNMPConfigResource_Schema(t *testing.T) {
+	schemaResp := getSNMPConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"location", "contact", "community", "traps", "v3", "v3_username", "v3_authtype", "v3_privproto", "loglevel", "options", "zilstat"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	for _, name := range []string{"v3_password", "v3_privpassphrase"} {
+		if !attrs[name].IsSensitive() {
+			t.Errorf("expected '%s' attribute to be sensitive", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getSNMPConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewSNMPConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// snmpConfigModelParams holds parameters for creating test model values.
+type snmpConfigModelParams struct {
+	ID               interface{}
+	Location         interface{}
+	Contact          interface{}
+	Community        interface{}
+	Traps            interface{}
+	V3               interface{}
+	V3Username       interface{}
+	V3AuthType       interface{}
+	V3Password       interface{}
+	V3PrivProto      interface{}
+	V3PrivPassphrase interface{}
+	LogLevel         interface{}
+	Options          interface{}
+	ZILStat          interface{}
+}
+
+func createSNMPConfigModelValue(p snmpConfigModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                tftypes.String,
+			"location":          tftypes.String,
+			"contact":           tftypes.String,
+			"community":         tftypes.String,
+			"traps":             tftypes.Bool,
+			"v3":                tftypes.Bool,
+			"v3_username":       tftypes.String,
+			"v3_authtype":       tftypes.String,
+			"v3_password":       tftypes.String,
+			"v3_privproto":      tftypes.String,
+			"v3_privpassphrase": tftypes.String,
+			"loglevel":          tftypes.Number,
+			"options":           tftypes.String,
+			"zilstat":           tftypes.Bool,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, p.ID),
+		"location":          tftypes.NewValue(tftypes.String, p.Location),
+		"contact":           tftypes.NewValue(tftypes.String, p.Contact),
+		"community":         tftypes.NewValue(tftypes.String, p.Community),
+		"traps":             tftypes.NewValue(tftypes.Bool, p.Traps),
+		"v3":                tftypes.NewValue(tftypes.Bool, p.V3),
+		"v3_username":       tftypes.NewValue(tftypes.String, p.V3Username),
+		"v3_authtype":       tftypes.NewValue(tftypes.String, p.V3AuthType),
+		"v3_password":       tftypes.NewValue(tftypes.String, p.V3Password),
+		"v3_privproto":      tftypes.NewValue(tftypes.String, p.V3PrivProto),
+		"v3_privpassphrase": tftypes.NewValue(tftypes.String, p.V3PrivPassphrase),
+		"loglevel":          tftypes.NewValue(tftypes.Number, p.LogLevel),
+		"options":           tftypes.NewValue(tftypes.String, p.Options),
+		"zilstat":           tftypes.NewValue(tftypes.Bool, p.ZILStat),
+	})
+}
+
+func defaultSNMPConfigParams() snmpConfigModelParams {
+	return snmpConfigModelParams{
+		Location:         "Server Room A",
+		Contact:          "admin@example.com",
+		Community:        "monitoring",
+		Traps:            false,
+		V3:               true,
+		V3Username:       "snmpuser",
+		V3AuthType:       "SHA",
+		V3Password:       "authpassword",
+		V3PrivProto:      "AES",
+		V3PrivPassphrase: "privpassword",
+		LogLevel:         float64(3),
+		Options:          "",
+		ZILStat:          true,
+	}
+}
+
+const testSNMPConfigJSON = `{
+	"id": 1,
+	"location": "Server Room A",
+	"contact": "admin@example.com",
+	"community": "monitoring",
+	"traps": false,
+	"v3": true,
+	"v3_username": "snmpuser",
+	"v3_authtype": "SHA",
+	"v3_privproto": "AES",
+	"loglevel": 3,
+	"options": "",
+	"zilstat": true
+}`
+
+// snmpWireParams decodes snmp.update params the way they are sent to the API.
+func snmpWireParams(t *testing.T, params any) map[string]any {
+	t.Helper()
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("failed to marshal params: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode params: %v", err)
+	}
+	return decoded
+}
+
+func TestSNMPConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = snmpWireParams(t, params)
+				return json.RawMessage(testSNMPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	planValue := createSNMPConfigModelValue(defaultSNMPConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "snmp.update" {
+		t.Errorf("expected method 'snmp.update', got %q", capturedMethod)
+	}
+	if capturedParams["community"] != "monitoring" {
+		t.Errorf("expected community 'monitoring', got %v", capturedParams["community"])
+	}
+	if capturedParams["v3_password"] != "authpassword" {
+		t.Errorf("expected v3_password to be sent, got %v", capturedParams["v3_password"])
+	}
+	if capturedParams["v3_privproto"] != "AES" {
+		t.Errorf("expected v3_privproto 'AES', got %v", capturedParams["v3_privproto"])
+	}
+
+	var model SNMPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "snmp_config" {
+		t.Errorf("expected ID 'snmp_config', got %q", model.ID.ValueString())
+	}
+	if model.V3Password.ValueString() != "authpassword" {
+		t.Error("expected v3_password to be preserved from plan")
+	}
+	if !model.ZILStat.ValueBool() {
+		t.Error("expected zilstat to be true")
+	}
+}
+
+func TestSNMPConfigResource_Create_OmitsUnsetSecrets(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = snmpWireParams(t, params)
+				return json.RawMessage(`{"id": 1, "community": "public", "v3_authtype": "SHA", "v3_privproto": null, "loglevel": 3}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	p := defaultSNMPConfigParams()
+	p.V3 = false
+	p.V3Password = nil
+	p.V3PrivProto = nil
+	p.V3PrivPassphrase = nil
+	planValue := createSNMPConfigModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if _, ok := capturedParams["v3_password"]; ok {
+		t.Error("expected v3_password to be omitted when unset")
+	}
+	if _, ok := capturedParams["v3_privpassphrase"]; ok {
+		t.Error("expected v3_privpassphrase to be omitted when unset")
+	}
+	if v, ok := capturedParams["v3_privproto"]; !ok || v != nil {
+		t.Errorf("expected v3_privproto to be sent as null, got %v", v)
+	}
+
+	var model SNMPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.V3PrivProto.IsNull() {
+		t.Errorf("expected v3_privproto to be null, got %q", model.V3PrivProto.ValueString())
+	}
+}
+
+func TestSNMPConfigResource_Create_APIError(t *testing.T) {
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	planValue := createSNMPConfigModelValue(defaultSNMPConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSNMPConfigResource_Read_Success(t *testing.T) {
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "snmp.config" {
+					t.Errorf("expected method 'snmp.config', got %q", method)
+				}
+				return json.RawMessage(testSNMPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	p := defaultSNMPConfigParams()
+	p.ID = "snmp_config"
+	p.Location = "somewhere else"
+	stateValue := createSNMPConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model SNMPConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Location.ValueString() != "Server Room A" {
+		t.Errorf("expected location 'Server Room A', got %q", model.Location.ValueString())
+	}
+	if model.V3PrivPassphrase.ValueString() != "privpassword" {
+		t.Error("expected v3_privpassphrase to be preserved from state")
+	}
+}
+
+func TestSNMPConfigResource_Read_APIError(t *testing.T) {
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	p := defaultSNMPConfigParams()
+	p.ID = "snmp_config"
+	stateValue := createSNMPConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSNMPConfigResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = snmpWireParams(t, params)
+				return json.RawMessage(testSNMPConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	state := defaultSNMPConfigParams()
+	state.ID = "snmp_config"
+	state.ZILStat = false
+	plan := defaultSNMPConfigParams()
+	plan.ID = "snmp_config"
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createSNMPConfigModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSNMPConfigModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["zilstat"] != true {
+		t.Errorf("expected zilstat true, got %v", capturedParams["zilstat"])
+	}
+}
+
+func TestSNMPConfigResource_Update_APIError(t *testing.T) {
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	p := defaultSNMPConfigParams()
+	p.ID = "snmp_config"
+	value := createSNMPConfigModelValue(p)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSNMPConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = snmpWireParams(t, params)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	p := defaultSNMPConfigParams()
+	p.ID = "snmp_config"
+	stateValue := createSNMPConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "snmp.update" {
+		t.Errorf("expected method 'snmp.update', got %q", capturedMethod)
+	}
+	if capturedParams["community"] != "public" {
+		t.Errorf("expected community reset to 'public', got %v", capturedParams["community"])
+	}
+	if capturedParams["v3"] != false {
+		t.Errorf("expected v3 reset to false, got %v", capturedParams["v3"])
+	}
+}
+
+func TestSNMPConfigResource_Delete_APIError(t *testing.T) {
+	r := &SNMPConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSNMPConfigResourceSchema(t)
+	p := defaultSNMPConfigParams()
+	p.ID = "snmp_config"
+	stateValue := createSNMPConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSNMPConfigResource_ImportState(t *testing.T) {
+	r := NewSNMPConfigResource().(*SNMPConfigResource)
+	schemaResp := getSNMPConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "snmp_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createSNMPConfigModelValue(snmpConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestSNMPConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewSNMPConfigResource().(*SNMPConfigResource)
+	schemaResp := getSNMPConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createSNMPConfigModelValue(snmpConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
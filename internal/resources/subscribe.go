@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+// collectionPollInterval is how often waitForCollection re-checks the condition
+// when no change event arrives. It covers transports without subscription
+// support (SSH) and events dropped by a full subscriber channel.
+const collectionPollInterval = 5 * time.Second
+
+// subscribeCollection subscribes to change events for an API collection such as
+// "vm.query". Each event carries the changed object's fields. The returned cancel
+// function must be called to release the subscription.
+//
+// Transports that cannot subscribe return a nil channel and no error, so callers
+// can fall back to polling: receiving from a nil channel blocks forever.
+func subscribeCollection(ctx context.Context, c client.Client, collection string) (<-chan json.RawMessage, func(), error) {
+	sub, err := c.Subscribe(ctx, collection, nil)
+	if errors.Is(err, client.ErrUnsupportedOperation) {
+		return nil, func() {}, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("subscribe to %s: %w", collection, err)
+	}
+	if sub == nil {
+		return nil, func() {}, nil
+	}
+
+	return sub.C, sub.Close, nil
+}
+
+// collectionCheckFunc reports whether the condition being waited for holds.
+type collectionCheckFunc func(ctx context.Context) (bool, error)
+
+// waitForCollection blocks until check reports true, re-checking whenever the
+// collection emits a change event and at least every collectionPollInterval.
+// The subscription is opened before the first check so no change is missed.
+func waitForCollection(ctx context.Context, c client.Client, collection string, timeout time.Duration, check collectionCheckFunc) error {
+	events, cancel, err := subscribeCollection(ctx, c, collection)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	pollInterval := collectionPollInterval
+	if timeout < pollInterval {
+		pollInterval = timeout / 10
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("timeout after %v", timeout)
+		case _, ok := <-events:
+			if !ok {
+				// Subscription ended (client closed or reconnecting); keep polling.
+				events = nil
+			}
+		case <-time.After(pollInterval):
+		}
+	}
+}
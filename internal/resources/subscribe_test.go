@@ -0,0 +1,198 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestSubscribeCollection_Unsupported(t *testing.T) {
+	c := &client.MockClient{
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return nil, client.ErrUnsupportedOperation
+		},
+	}
+
+	events, cancel, err := subscribeCollection(context.Background(), c, "vm.query")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cancel()
+
+	if events != nil {
+		t.Error("expected nil channel for unsupported transport")
+	}
+}
+
+func TestSubscribeCollection_Error(t *testing.T) {
+	c := &client.MockClient{
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return nil, errors.New("connection failed")
+		},
+	}
+
+	_, _, err := subscribeCollection(context.Background(), c, "vm.query")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "subscribe to vm.query") {
+		t.Errorf("expected collection in error, got %q", err.Error())
+	}
+}
+
+func TestSubscribeCollection_Success(t *testing.T) {
+	ch := make(chan json.RawMessage, 1)
+	closed := false
+	var gotCollection string
+	c := &client.MockClient{
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			gotCollection = collection
+			return truenas.NewSubscription[json.RawMessage](ch, func() { closed = true }), nil
+		},
+	}
+
+	events, cancel, err := subscribeCollection(context.Background(), c, "vm.query")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotCollection != "vm.query" {
+		t.Errorf("expected collection 'vm.query', got %q", gotCollection)
+	}
+
+	ch <- json.RawMessage(`{"id":1}`)
+	if got := string(<-events); got != `{"id":1}` {
+		t.Errorf("expected event payload, got %q", got)
+	}
+
+	cancel()
+	if !closed {
+		t.Error("expected cancel to close the subscription")
+	}
+}
+
+func TestWaitForCollection_WakesOnEvent(t *testing.T) {
+	ch := make(chan json.RawMessage, 1)
+	c := &client.MockClient{
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return truenas.NewSubscription[json.RawMessage](ch, func() {}), nil
+		},
+	}
+
+	checks := 0
+	start := time.Now()
+	err := waitForCollection(context.Background(), c, "vm.query", time.Minute, func(ctx context.Context) (bool, error) {
+		checks++
+		if checks == 1 {
+			ch <- json.RawMessage(`{"id":1,"status":{"state":"RUNNING"}}`)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if checks != 2 {
+		t.Errorf("expected 2 checks, got %d", checks)
+	}
+	if elapsed := time.Since(start); elapsed >= collectionPollInterval {
+		t.Errorf("expected event to wake the waiter before the poll interval, took %v", elapsed)
+	}
+}
+
+func TestWaitForCollection_PollsWithoutSubscription(t *testing.T) {
+	c := &client.MockClient{}
+
+	checks := 0
+	err := waitForCollection(context.Background(), c, "vm.query", 100*time.Millisecond, func(ctx context.Context) (bool, error) {
+		checks++
+		return checks == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if checks != 3 {
+		t.Errorf("expected 3 checks, got %d", checks)
+	}
+}
+
+func TestWaitForCollection_ClosedSubscription(t *testing.T) {
+	ch := make(chan json.RawMessage)
+	close(ch)
+	c := &client.MockClient{
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return truenas.NewSubscription[json.RawMessage](ch, func() {}), nil
+		},
+	}
+
+	checks := 0
+	err := waitForCollection(context.Background(), c, "vm.query", 100*time.Millisecond, func(ctx context.Context) (bool, error) {
+		checks++
+		return checks == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForCollection_Timeout(t *testing.T) {
+	c := &client.MockClient{}
+
+	err := waitForCollection(context.Background(), c, "vm.query", 50*time.Millisecond, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected timeout error, got %q", err.Error())
+	}
+}
+
+func TestWaitForCollection_CheckError(t *testing.T) {
+	c := &client.MockClient{}
+
+	err := waitForCollection(context.Background(), c, "vm.query", time.Minute, func(ctx context.Context) (bool, error) {
+		return false, errors.New("query failed")
+	})
+	if err == nil || err.Error() != "query failed" {
+		t.Fatalf("expected check error, got %v", err)
+	}
+}
+
+func TestWaitForCollection_SubscribeError(t *testing.T) {
+	c := &client.MockClient{
+		SubscribeFunc: func(ctx context.Context, collection string, params any) (*truenas.Subscription[json.RawMessage], error) {
+			return nil, errors.New("connection failed")
+		},
+	}
+
+	err := waitForCollection(context.Background(), c, "vm.query", time.Minute, func(ctx context.Context) (bool, error) {
+		t.Error("check should not run when subscribe fails")
+		return true, nil
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWaitForCollection_ContextCancelled(t *testing.T) {
+	c := &client.MockClient{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := waitForCollection(ctx, c, "vm.query", time.Minute, func(ctx context.Context) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &SyslogConfigResource{}
+	_ resource.ResourceWithConfigure      = &SyslogConfigResource{}
+	_ resource.ResourceWithImportState    = &SyslogConfigResource{}
+	_ resource.ResourceWithValidateConfig = &SyslogConfigResource{}
+)
+
+// SyslogConfigResourceModel describes the resource data model.
+type SyslogConfigResourceModel struct {
+	ID             types.String                           `tfsdk:"id"`
+	Server         types.String                           `tfsdk:"server"`
+	Transport      customtypes.CaseInsensitiveStringValue `tfsdk:"transport"`
+	TLSCertificate types.Int64                            `tfsdk:"tls_certificate"`
+	Level          customtypes.CaseInsensitiveStringValue `tfsdk:"level"`
+}
+
+// syslogAdvancedConfigResponse is the subset of system.advanced.config used for syslog.
+type syslogAdvancedConfigResponse struct {
+	SyslogServer         string `json:"syslogserver"`
+	SyslogTransport      string `json:"syslog_transport"`
+	SyslogTLSCertificate *int64 `json:"syslog_tls_certificate"`
+	SyslogLevel          string `json:"sysloglevel"`
+}
+
+// SyslogConfigResource defines the resource implementation.
+type SyslogConfigResource struct {
+	BaseResource
+}
+
+// NewSyslogConfigResource creates a new SyslogConfigResource.
+func NewSyslogConfigResource() resource.Resource {
+	return &SyslogConfigResource{}
+}
+
+func (r *SyslogConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_syslog_config"
+}
+
+func (r *SyslogConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages remote syslog forwarding on TrueNAS (the syslog settings of the advanced " +
+			"system configuration), including TLS transport for centralized logging.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'syslog_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Description: "Remote syslog server as 'host' or 'host:port'. Leave unset to disable forwarding.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"transport": schema.StringAttribute{
+				Description: "Transport used to reach the server: UDP, TCP or TLS. Defaults to UDP.",
+				Optional:    true,
+				Computed:    true,
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				Default:     customtypes.CaseInsensitiveStringDefault("UDP"),
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("UDP", "TCP", "TLS"),
+				},
+			},
+			"tls_certificate": schema.Int64Attribute{
+				Description: "ID of the client certificate presented to the server. Only valid with the TLS transport.",
+				Optional:    true,
+			},
+			"level": schema.StringAttribute{
+				Description: "Minimum severity forwarded to the server: F_EMERG, F_ALERT, F_CRIT, F_ERR, " +
+					"F_WARNING, F_NOTICE, F_INFO or F_DEBUG. Defaults to F_INFO.",
+				Optional:   true,
+				Computed:   true,
+				CustomType: customtypes.CaseInsensitiveStringType{},
+				Default:    customtypes.CaseInsensitiveStringDefault("F_INFO"),
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("F_EMERG", "F_ALERT", "F_CRIT", "F_ERR", "F_WARNING", "F_NOTICE", "F_INFO", "F_DEBUG"),
+				},
+			},
+		},
+	}
+}
+
+func (r *SyslogConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SyslogConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Skip validation if the transport is unknown (e.g., referencing another
+	// resource's output). The middleware validates it again on apply.
+	if data.Transport.IsUnknown() {
+		return
+	}
+
+	tls := strings.EqualFold(data.Transport.ValueString(), "TLS")
+	if !tls && !data.TLSCertificate.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_certificate"),
+			"Invalid Syslog Configuration",
+			"tls_certificate can only be set when transport is \"TLS\".",
+		)
+	}
+	if tls && data.Server.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server"),
+			"Invalid Syslog Configuration",
+			"server is required when transport is \"TLS\".",
+		)
+	}
+}
+
+func (r *SyslogConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SyslogConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SyslogConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SyslogConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "system.advanced.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Syslog Config",
+			fmt.Sprintf("Unable to read advanced system configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config syslogAdvancedConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse advanced system configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapSyslogConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *SyslogConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SyslogConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SyslogConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Stop forwarding and reset to TrueNAS defaults
+	params := map[string]any{
+		"syslogserver":           "",
+		"syslog_transport":       "UDP",
+		"syslog_tls_certificate": nil,
+		"sysloglevel":            "F_INFO",
+	}
+
+	if _, err := r.client.Call(ctx, "system.advanced.update", params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Syslog Config",
+			fmt.Sprintf("Unable to reset syslog configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *SyslogConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "syslog_config"
+	if req.ID != "syslog_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'syslog_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// syslogConfigAPIFieldPaths maps system.advanced.update validation errors to attributes.
+var syslogConfigAPIFieldPaths = map[string]path.Path{
+	"syslogserver":           path.Root("server"),
+	"syslog_transport":       path.Root("transport"),
+	"syslog_tls_certificate": path.Root("tls_certificate"),
+	"sysloglevel":            path.Root("level"),
+}
+
+// apply checks that the referenced certificate exists, then writes the
+// syslog settings via system.advanced.update and maps the result into data.
+func (r *SyslogConfigResource) apply(ctx context.Context, data *SyslogConfigResourceModel, diags *diag.Diagnostics) {
+	if !data.TLSCertificate.IsNull() {
+		id := data.TLSCertificate.ValueInt64()
+		exists, err := r.certificateExists(ctx, id)
+		if err != nil {
+			diags.AddError(
+				"Unable to Read Certificate",
+				fmt.Sprintf("Unable to look up certificate %d: %s", id, err.Error()),
+			)
+			return
+		}
+		if !exists {
+			diags.AddAttributeError(
+				path.Root("tls_certificate"),
+				"Certificate Not Found",
+				fmt.Sprintf("Certificate %d does not exist on the TrueNAS host.", id),
+			)
+			return
+		}
+	}
+
+	result, err := r.client.Call(ctx, "system.advanced.update", buildSyslogConfigParams(data))
+	if err != nil {
+		addAPIError(diags, err, syslogConfigAPIFieldPaths,
+			"Unable to Update Syslog Config",
+			fmt.Sprintf("Unable to update syslog configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config syslogAdvancedConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		diags.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse advanced system configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapSyslogConfigToModel(&config, data)
+}
+
+// certificateExists reports whether a certificate with the given ID exists.
+func (r *SyslogConfigResource) certificateExists(ctx context.Context, id int64) (bool, error) {
+	filter := []any{[]any{[]any{"id", "=", id}}}
+	result, err := r.client.Call(ctx, "certificate.query", filter)
+	if err != nil {
+		return false, err
+	}
+
+	var certs []json.RawMessage
+	if err := json.Unmarshal(result, &certs); err != nil {
+		return false, fmt.Errorf("parse certificate query response: %w", err)
+	}
+
+	return len(certs) > 0, nil
+}
+
+// buildSyslogConfigParams builds the system.advanced.update params from the resource model.
+func buildSyslogConfigParams(data *SyslogConfigResourceModel) map[string]any {
+	params := map[string]any{
+		"syslogserver":           data.Server.ValueString(),
+		"syslog_transport":       strings.ToUpper(data.Transport.ValueString()),
+		"syslog_tls_certificate": nil,
+		"sysloglevel":            strings.ToUpper(data.Level.ValueString()),
+	}
+
+	if !data.TLSCertificate.IsNull() {
+		params["syslog_tls_certificate"] = data.TLSCertificate.ValueInt64()
+	}
+
+	return params
+}
+
+// mapSyslogConfigToModel maps the API response to the resource model.
+func mapSyslogConfigToModel(config *syslogAdvancedConfigResponse, data *SyslogConfigResourceModel) {
+	data.ID = types.StringValue("syslog_config")
+	data.Server = nonEmptyStringValue(config.SyslogServer)
+	data.Transport = customtypes.NewCaseInsensitiveStringValue(config.SyslogTransport)
+	data.TLSCertificate = nilableInt64Value(config.SyslogTLSCertificate)
+	data.Level = customtypes.NewCaseInsensitiveStringValue(config.SyslogLevel)
+}
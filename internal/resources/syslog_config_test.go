@@ -0,0 +1,510 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewSyslogConfigResource(t *testing.T) {
+	r := NewSyslogConfigResource()
+	if r == nil {
+		t.Fatal("NewSyslogConfigResource returned nil")
+	}
+
+	_, ok := r.(*SyslogConfigResource)
+	if !ok {
+		t.Fatalf("expected *SyslogConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*SyslogConfigResource))
+	_ = resource.ResourceWithImportState(r.(*SyslogConfigResource))
+	_ = resource.ResourceWithValidateConfig(r.(*SyslogConfigResource))
+}
+
+func TestSyslogConfigResource_Metadata(t *testing.T) {
+	r := NewSyslogConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_syslog_config" {
+		t.Errorf("expected TypeName 'truenas_syslog_config', got %q", resp.TypeName)
+	}
+}
+
+func TestSyslogConfigResource_Schema(t *testing.T) {
+	schemaResp := getSyslogConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"server", "transport", "tls_certificate", "level"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getSyslogConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewSyslogConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// syslogConfigModelParams holds parameters for creating test model values.
+type syslogConfigModelParams struct {
+	ID             interface{}
+	Server         interface{}
+	Transport      interface{}
+	TLSCertificate interface{}
+	Level          interface{}
+}
+
+func createSyslogConfigModelValue(p syslogConfigModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":              tftypes.String,
+			"server":          tftypes.String,
+			"transport":       tftypes.String,
+			"tls_certificate": tftypes.Number,
+			"level":           tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, p.ID),
+		"server":          tftypes.NewValue(tftypes.String, p.Server),
+		"transport":       tftypes.NewValue(tftypes.String, p.Transport),
+		"tls_certificate": tftypes.NewValue(tftypes.Number, p.TLSCertificate),
+		"level":           tftypes.NewValue(tftypes.String, p.Level),
+	})
+}
+
+func defaultSyslogConfigParams() syslogConfigModelParams {
+	return syslogConfigModelParams{
+		Server:         "logs.example.com:6514",
+		Transport:      "TLS",
+		TLSCertificate: float64(3),
+		Level:          "F_NOTICE",
+	}
+}
+
+const testSyslogConfigJSON = `{
+	"id": 1,
+	"syslogserver": "logs.example.com:6514",
+	"syslog_transport": "TLS",
+	"syslog_tls_certificate": 3,
+	"sysloglevel": "F_NOTICE",
+	"sed_user": "USER"
+}`
+
+// newSyslogConfigTestResource returns a resource whose certificate.query calls
+// return certs and whose system.advanced calls are recorded in calls.
+func newSyslogConfigTestResource(t *testing.T, certs string, calls *[]string, params *map[string]any) *SyslogConfigResource {
+	t.Helper()
+
+	return &SyslogConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+				*calls = append(*calls, method)
+				switch method {
+				case "certificate.query":
+					return json.RawMessage(certs), nil
+				case "system.advanced.update":
+					if params != nil {
+						*params = p.(map[string]any)
+					}
+					return json.RawMessage(testSyslogConfigJSON), nil
+				}
+				t.Errorf("unexpected method %q", method)
+				return nil, nil
+			},
+		}},
+	}
+}
+
+func validateSyslogConfig(t *testing.T, p syslogConfigModelParams) diag.Diagnostics {
+	t.Helper()
+
+	r := NewSyslogConfigResource().(*SyslogConfigResource)
+	schemaResp := getSyslogConfigResourceSchema(t)
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+	return resp.Diagnostics
+}
+
+func TestSyslogConfigResource_ValidateConfig_TLS(t *testing.T) {
+	if diags := validateSyslogConfig(t, defaultSyslogConfigParams()); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+}
+
+func TestSyslogConfigResource_ValidateConfig_CertificateWithoutTLS(t *testing.T) {
+	p := defaultSyslogConfigParams()
+	p.Transport = "TCP"
+
+	diags := validateSyslogConfig(t, p)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("tls_certificate")) {
+		t.Errorf("expected error on tls_certificate, got %v", diags)
+	}
+}
+
+func TestSyslogConfigResource_ValidateConfig_TLSWithoutServer(t *testing.T) {
+	p := defaultSyslogConfigParams()
+	p.Server = nil
+
+	diags := validateSyslogConfig(t, p)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("server")) {
+		t.Errorf("expected error on server, got %v", diags)
+	}
+}
+
+func TestSyslogConfigResource_ValidateConfig_UnknownTransport(t *testing.T) {
+	p := defaultSyslogConfigParams()
+	p.Transport = tftypes.UnknownValue
+	p.Server = nil
+
+	if diags := validateSyslogConfig(t, p); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+}
+
+func TestSyslogConfigResource_Create_Success(t *testing.T) {
+	var calls []string
+	var capturedParams map[string]any
+	r := newSyslogConfigTestResource(t, `[{"id": 3, "name": "syslog-client"}]`, &calls, &capturedParams)
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(defaultSyslogConfigParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(calls) != 2 || calls[0] != "certificate.query" || calls[1] != "system.advanced.update" {
+		t.Errorf("expected certificate lookup then update, got %v", calls)
+	}
+	if capturedParams["syslogserver"] != "logs.example.com:6514" {
+		t.Errorf("expected syslogserver 'logs.example.com:6514', got %v", capturedParams["syslogserver"])
+	}
+	if capturedParams["syslog_transport"] != "TLS" {
+		t.Errorf("expected syslog_transport 'TLS', got %v", capturedParams["syslog_transport"])
+	}
+	if capturedParams["syslog_tls_certificate"] != int64(3) {
+		t.Errorf("expected syslog_tls_certificate 3, got %v", capturedParams["syslog_tls_certificate"])
+	}
+
+	var model SyslogConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "syslog_config" {
+		t.Errorf("expected ID 'syslog_config', got %q", model.ID.ValueString())
+	}
+	if model.Level.ValueString() != "F_NOTICE" {
+		t.Errorf("expected level 'F_NOTICE', got %q", model.Level.ValueString())
+	}
+}
+
+func TestSyslogConfigResource_Create_LowercaseEnums(t *testing.T) {
+	var calls []string
+	var capturedParams map[string]any
+	r := newSyslogConfigTestResource(t, `[{"id": 3, "name": "syslog-client"}]`, &calls, &capturedParams)
+
+	p := defaultSyslogConfigParams()
+	p.Transport = "tls"
+	p.Level = "f_notice"
+
+	if diags := validateSyslogConfig(t, p); diags.HasError() {
+		t.Fatalf("unexpected validation errors: %v", diags)
+	}
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["syslog_transport"] != "TLS" {
+		t.Errorf("expected syslog_transport 'TLS', got %v", capturedParams["syslog_transport"])
+	}
+	if capturedParams["sysloglevel"] != "F_NOTICE" {
+		t.Errorf("expected sysloglevel 'F_NOTICE', got %v", capturedParams["sysloglevel"])
+	}
+}
+
+func TestSyslogConfigResource_Create_CertificateNotFound(t *testing.T) {
+	var calls []string
+	r := newSyslogConfigTestResource(t, `[]`, &calls, nil)
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(defaultSyslogConfigParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for missing certificate")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Certificate Not Found" {
+		t.Errorf("expected 'Certificate Not Found', got %q", summary)
+	}
+	for _, method := range calls {
+		if method == "system.advanced.update" {
+			t.Error("expected no update when the certificate is missing")
+		}
+	}
+}
+
+func TestSyslogConfigResource_Create_NoCertificate(t *testing.T) {
+	var calls []string
+	var capturedParams map[string]any
+	r := newSyslogConfigTestResource(t, `[]`, &calls, &capturedParams)
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	p := defaultSyslogConfigParams()
+	p.Transport = "UDP"
+	p.TLSCertificate = nil
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected only the update call, got %v", calls)
+	}
+	if v, ok := capturedParams["syslog_tls_certificate"]; !ok || v != nil {
+		t.Errorf("expected syslog_tls_certificate to be sent as null, got %v", v)
+	}
+}
+
+func TestSyslogConfigResource_Update_APIError(t *testing.T) {
+	r := &SyslogConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method == "certificate.query" {
+					return json.RawMessage(`[{"id": 3}]`), nil
+				}
+				return nil, newValidationRPCError([]any{"system_advanced_update.syslogserver", "Invalid host", float64(22)})
+			},
+		}},
+	}
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	state := defaultSyslogConfigParams()
+	state.ID = "syslog_config"
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(state)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(state)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("server")) {
+		t.Errorf("expected error on server, got %v", resp.Diagnostics)
+	}
+}
+
+func TestSyslogConfigResource_Read_Success(t *testing.T) {
+	r := &SyslogConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "system.advanced.config" {
+					t.Errorf("expected method 'system.advanced.config', got %q", method)
+				}
+				return json.RawMessage(`{"syslogserver": "", "syslog_transport": "UDP", "syslog_tls_certificate": null, "sysloglevel": "F_INFO"}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	p := defaultSyslogConfigParams()
+	p.ID = "syslog_config"
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createSyslogConfigModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model SyslogConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.Server.IsNull() {
+		t.Errorf("expected null server, got %q", model.Server.ValueString())
+	}
+	if !model.TLSCertificate.IsNull() {
+		t.Errorf("expected null tls_certificate, got %d", model.TLSCertificate.ValueInt64())
+	}
+	if model.Transport.ValueString() != "UDP" {
+		t.Errorf("expected transport 'UDP', got %q", model.Transport.ValueString())
+	}
+}
+
+func TestSyslogConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &SyslogConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "system.advanced.update" {
+					t.Errorf("expected method 'system.advanced.update', got %q", method)
+				}
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	p := defaultSyslogConfigParams()
+	p.ID = "syslog_config"
+	stateValue := createSyslogConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedParams["syslogserver"] != "" {
+		t.Errorf("expected syslogserver reset to '', got %v", capturedParams["syslogserver"])
+	}
+	if capturedParams["syslog_transport"] != "UDP" {
+		t.Errorf("expected syslog_transport reset to 'UDP', got %v", capturedParams["syslog_transport"])
+	}
+}
+
+func TestSyslogConfigResource_Delete_APIError(t *testing.T) {
+	r := &SyslogConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getSyslogConfigResourceSchema(t)
+	p := defaultSyslogConfigParams()
+	p.ID = "syslog_config"
+	stateValue := createSyslogConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestSyslogConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewSyslogConfigResource().(*SyslogConfigResource)
+	schemaResp := getSyslogConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createSyslogConfigModelValue(syslogConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -0,0 +1,250 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &TrueCommandResource{}
+	_ resource.ResourceWithConfigure   = &TrueCommandResource{}
+	_ resource.ResourceWithImportState = &TrueCommandResource{}
+)
+
+// TrueCommandResourceModel describes the resource data model.
+type TrueCommandResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	APIKey          types.String `tfsdk:"api_key"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	Status          types.String `tfsdk:"status"`
+	StatusReason    types.String `tfsdk:"status_reason"`
+	RemoteURL       types.String `tfsdk:"remote_url"`
+	RemoteIPAddress types.String `tfsdk:"remote_ip_address"`
+}
+
+// trueCommandResponse is the JSON shape returned by truecommand.config and truecommand.update.
+type trueCommandResponse struct {
+	ID              int64   `json:"id"`
+	Enabled         bool    `json:"enabled"`
+	Status          string  `json:"status"`
+	StatusReason    string  `json:"status_reason"`
+	RemoteURL       *string `json:"remote_url"`
+	RemoteIPAddress *string `json:"remote_ip_address"`
+}
+
+// TrueCommandResource defines the resource implementation.
+type TrueCommandResource struct {
+	BaseResource
+}
+
+// NewTrueCommandResource creates a new TrueCommandResource.
+func NewTrueCommandResource() resource.Resource {
+	return &TrueCommandResource{}
+}
+
+func (r *TrueCommandResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_truecommand"
+}
+
+func (r *TrueCommandResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the TrueCommand connection of a TrueNAS system.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'truecommand').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_key": schema.StringAttribute{
+				Description: "TrueCommand API key used to enroll this system.",
+				Required:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(16),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Enable the TrueCommand connection. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"status": schema.StringAttribute{
+				Description: "Connection status (e.g. CONNECTED, CONNECTING, DISABLED, FAILED).",
+				Computed:    true,
+			},
+			"status_reason": schema.StringAttribute{
+				Description: "Human-readable explanation of the connection status.",
+				Computed:    true,
+			},
+			"remote_url": schema.StringAttribute{
+				Description: "URL of the TrueCommand instance once connected.",
+				Computed:    true,
+			},
+			"remote_ip_address": schema.StringAttribute{
+				Description: "IP address of the TrueCommand instance once connected.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *TrueCommandResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TrueCommandResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildTrueCommandParams(&data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update TrueCommand Config",
+			fmt.Sprintf("Unable to update TrueCommand configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapTrueCommandToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TrueCommandResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TrueCommandResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "truecommand.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read TrueCommand Config",
+			fmt.Sprintf("Unable to read TrueCommand configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config trueCommandResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse TrueCommand configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapTrueCommandToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *TrueCommandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TrueCommandResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildTrueCommandParams(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update TrueCommand Config",
+			fmt.Sprintf("Unable to update TrueCommand configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapTrueCommandToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TrueCommandResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Disconnect from TrueCommand and clear the API key
+	params := map[string]any{
+		"enabled": false,
+		"api_key": nil,
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset TrueCommand Config",
+			fmt.Sprintf("Unable to reset TrueCommand configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *TrueCommandResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "truecommand"
+	if req.ID != "truecommand" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'truecommand', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateConfig calls truecommand.update and parses the response.
+func (r *TrueCommandResource) updateConfig(ctx context.Context, params map[string]any) (*trueCommandResponse, error) {
+	result, err := r.client.Call(ctx, "truecommand.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config trueCommandResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse truecommand update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildTrueCommandParams builds the truecommand.update params from the resource model.
+func buildTrueCommandParams(data *TrueCommandResourceModel) map[string]any {
+	return map[string]any{
+		"api_key": data.APIKey.ValueString(),
+		"enabled": data.Enabled.ValueBool(),
+	}
+}
+
+// mapTrueCommandToModel maps the API response to the resource model.
+// The API key is write-only and is preserved from the plan/state.
+func mapTrueCommandToModel(config *trueCommandResponse, data *TrueCommandResourceModel) {
+	data.ID = types.StringValue("truecommand")
+	data.Enabled = types.BoolValue(config.Enabled)
+	data.Status = types.StringValue(config.Status)
+	data.StatusReason = types.StringValue(config.StatusReason)
+	if config.RemoteURL != nil {
+		data.RemoteURL = types.StringValue(*config.RemoteURL)
+	} else {
+		data.RemoteURL = types.StringNull()
+	}
+	if config.RemoteIPAddress != nil {
+		data.RemoteIPAddress = types.StringValue(*config.RemoteIPAddress)
+	} else {
+		data.RemoteIPAddress = types.StringNull()
+	}
+}
@@ -0,0 +1,474 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewTrueCommandResource(t *testing.T) {
+	r := NewTrueCommandResource()
+	if r == nil {
+		t.Fatal("NewTrueCommandResource returned nil")
+	}
+
+	_, ok := r.(*TrueCommandResource)
+	if !ok {
+		t.Fatalf("expected *TrueCommandResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*TrueCommandResource))
+	_ = resource.ResourceWithImportState(r.(*TrueCommandResource))
+}
+
+func TestTrueCommandResource_Metadata(t *testing.T) {
+	r := NewTrueCommandResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_truecommand" {
+		t.Errorf("expected TypeName 'truenas_truecommand', got %q", resp.TypeName)
+	}
+}
+
+func TestTrueCommandResource_Schema(t *testing.T) {
+	schemaResp := getTrueCommandResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["api_key"].IsRequired() {
+		t.Error("expected 'api_key' attribute to be required")
+	}
+	if !attrs["api_key"].IsSensitive() {
+		t.Error("expected 'api_key' attribute to be sensitive")
+	}
+	if !attrs["enabled"].IsOptional() {
+		t.Error("expected 'enabled' attribute to be optional")
+	}
+	for _, name := range []string{"id", "status", "status_reason", "remote_url", "remote_ip_address"} {
+		if !attrs[name].IsComputed() {
+			t.Errorf("expected '%s' attribute to be computed", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getTrueCommandResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewTrueCommandResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// trueCommandModelParams holds parameters for creating test model values.
+type trueCommandModelParams struct {
+	ID              interface{}
+	APIKey          interface{}
+	Enabled         interface{}
+	Status          interface{}
+	StatusReason    interface{}
+	RemoteURL       interface{}
+	RemoteIPAddress interface{}
+}
+
+func createTrueCommandModelValue(p trueCommandModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":                tftypes.String,
+			"api_key":           tftypes.String,
+			"enabled":           tftypes.Bool,
+			"status":            tftypes.String,
+			"status_reason":     tftypes.String,
+			"remote_url":        tftypes.String,
+			"remote_ip_address": tftypes.String,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, p.ID),
+		"api_key":           tftypes.NewValue(tftypes.String, p.APIKey),
+		"enabled":           tftypes.NewValue(tftypes.Bool, p.Enabled),
+		"status":            tftypes.NewValue(tftypes.String, p.Status),
+		"status_reason":     tftypes.NewValue(tftypes.String, p.StatusReason),
+		"remote_url":        tftypes.NewValue(tftypes.String, p.RemoteURL),
+		"remote_ip_address": tftypes.NewValue(tftypes.String, p.RemoteIPAddress),
+	})
+}
+
+func defaultTrueCommandPlanParams() trueCommandModelParams {
+	return trueCommandModelParams{
+		ID:              tftypes.UnknownValue,
+		APIKey:          "0123456789abcdef",
+		Enabled:         true,
+		Status:          tftypes.UnknownValue,
+		StatusReason:    tftypes.UnknownValue,
+		RemoteURL:       tftypes.UnknownValue,
+		RemoteIPAddress: tftypes.UnknownValue,
+	}
+}
+
+func defaultTrueCommandStateParams() trueCommandModelParams {
+	return trueCommandModelParams{
+		ID:              "truecommand",
+		APIKey:          "0123456789abcdef",
+		Enabled:         true,
+		Status:          "CONNECTED",
+		StatusReason:    "TrueCommand service is connected.",
+		RemoteURL:       "http://10.0.0.5/",
+		RemoteIPAddress: "10.0.0.5",
+	}
+}
+
+const testTrueCommandJSON = `{
+	"id": 1,
+	"enabled": true,
+	"status": "CONNECTED",
+	"status_reason": "TrueCommand service is connected.",
+	"remote_url": "http://10.0.0.5/",
+	"remote_ip_address": "10.0.0.5"
+}`
+
+func TestTrueCommandResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testTrueCommandJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	planValue := createTrueCommandModelValue(defaultTrueCommandPlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "truecommand.update" {
+		t.Errorf("expected method 'truecommand.update', got %q", capturedMethod)
+	}
+	if capturedParams["api_key"] != "0123456789abcdef" {
+		t.Errorf("expected api_key to be sent, got %v", capturedParams["api_key"])
+	}
+	if capturedParams["enabled"] != true {
+		t.Errorf("expected enabled true, got %v", capturedParams["enabled"])
+	}
+
+	var model TrueCommandResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "truecommand" {
+		t.Errorf("expected ID 'truecommand', got %q", model.ID.ValueString())
+	}
+	if model.APIKey.ValueString() != "0123456789abcdef" {
+		t.Error("expected api_key to be preserved from plan")
+	}
+	if model.Status.ValueString() != "CONNECTED" {
+		t.Errorf("expected status 'CONNECTED', got %q", model.Status.ValueString())
+	}
+	if model.RemoteIPAddress.ValueString() != "10.0.0.5" {
+		t.Errorf("expected remote_ip_address '10.0.0.5', got %q", model.RemoteIPAddress.ValueString())
+	}
+}
+
+func TestTrueCommandResource_Create_APIError(t *testing.T) {
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("invalid api key")
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	planValue := createTrueCommandModelValue(defaultTrueCommandPlanParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTrueCommandResource_Read_Success(t *testing.T) {
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "truecommand.config" {
+					t.Errorf("expected method 'truecommand.config', got %q", method)
+				}
+				return json.RawMessage(`{"id": 1, "enabled": true, "status": "CONNECTING", "status_reason": "Waiting for TrueCommand", "remote_url": null, "remote_ip_address": null}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	stateValue := createTrueCommandModelValue(defaultTrueCommandStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model TrueCommandResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Status.ValueString() != "CONNECTING" {
+		t.Errorf("expected status 'CONNECTING', got %q", model.Status.ValueString())
+	}
+	if !model.RemoteURL.IsNull() {
+		t.Errorf("expected remote_url to be null, got %q", model.RemoteURL.ValueString())
+	}
+	if model.APIKey.ValueString() != "0123456789abcdef" {
+		t.Error("expected api_key to be preserved from state")
+	}
+}
+
+func TestTrueCommandResource_Read_APIError(t *testing.T) {
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	stateValue := createTrueCommandModelValue(defaultTrueCommandStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTrueCommandResource_Update_Disable(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "enabled": false, "status": "DISABLED", "status_reason": "TrueCommand service is disabled.", "remote_url": null, "remote_ip_address": null}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	plan := defaultTrueCommandPlanParams()
+	plan.ID = "truecommand"
+	plan.Enabled = false
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createTrueCommandModelValue(defaultTrueCommandStateParams())},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createTrueCommandModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["enabled"] != false {
+		t.Errorf("expected enabled false, got %v", capturedParams["enabled"])
+	}
+
+	var model TrueCommandResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Status.ValueString() != "DISABLED" {
+		t.Errorf("expected status 'DISABLED', got %q", model.Status.ValueString())
+	}
+}
+
+func TestTrueCommandResource_Update_APIError(t *testing.T) {
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	value := createTrueCommandModelValue(defaultTrueCommandStateParams())
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTrueCommandResource_Delete_Disconnects(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "enabled": false}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	stateValue := createTrueCommandModelValue(defaultTrueCommandStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "truecommand.update" {
+		t.Errorf("expected method 'truecommand.update', got %q", capturedMethod)
+	}
+	if capturedParams["enabled"] != false {
+		t.Errorf("expected enabled false, got %v", capturedParams["enabled"])
+	}
+	if v, ok := capturedParams["api_key"]; !ok || v != nil {
+		t.Errorf("expected api_key to be cleared, got %v", v)
+	}
+}
+
+func TestTrueCommandResource_Delete_APIError(t *testing.T) {
+	r := &TrueCommandResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getTrueCommandResourceSchema(t)
+	stateValue := createTrueCommandModelValue(defaultTrueCommandStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTrueCommandResource_ImportState(t *testing.T) {
+	r := NewTrueCommandResource().(*TrueCommandResource)
+	schemaResp := getTrueCommandResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "truecommand"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createTrueCommandModelValue(trueCommandModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestTrueCommandResource_ImportState_InvalidID(t *testing.T) {
+	r := NewTrueCommandResource().(*TrueCommandResource)
+	schemaResp := getTrueCommandResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "tc"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createTrueCommandModelValue(trueCommandModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -0,0 +1,234 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &TwoFactorConfigResource{}
+	_ resource.ResourceWithConfigure   = &TwoFactorConfigResource{}
+	_ resource.ResourceWithImportState = &TwoFactorConfigResource{}
+)
+
+// TwoFactorConfigResourceModel describes the resource data model.
+type TwoFactorConfigResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Window  types.Int64  `tfsdk:"window"`
+	SSH     types.Bool   `tfsdk:"ssh"`
+}
+
+// twoFactorConfigResponse is the JSON shape returned by auth.twofactor.config and auth.twofactor.update.
+type twoFactorConfigResponse struct {
+	ID       int64 `json:"id"`
+	Enabled  bool  `json:"enabled"`
+	Window   int64 `json:"window"`
+	Services struct {
+		SSH bool `json:"ssh"`
+	} `json:"services"`
+}
+
+// TwoFactorConfigResource defines the resource implementation.
+type TwoFactorConfigResource struct {
+	BaseResource
+}
+
+// NewTwoFactorConfigResource creates a new TwoFactorConfigResource.
+func NewTwoFactorConfigResource() resource.Resource {
+	return &TwoFactorConfigResource{}
+}
+
+func (r *TwoFactorConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_twofactor_config"
+}
+
+func (r *TwoFactorConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the global two-factor authentication configuration on TrueNAS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'twofactor_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Require two-factor authentication for users that have it configured. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"window": schema.Int64Attribute{
+				Description: "Number of OTP intervals before and after the current one that are also accepted. Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ssh": schema.BoolAttribute{
+				Description: "Also require two-factor authentication for SSH logins. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *TwoFactorConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TwoFactorConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildTwoFactorConfigParams(&data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Two-Factor Config",
+			fmt.Sprintf("Unable to update two-factor authentication configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapTwoFactorConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TwoFactorConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TwoFactorConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "auth.twofactor.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Two-Factor Config",
+			fmt.Sprintf("Unable to read two-factor authentication configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config twoFactorConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse two-factor authentication configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapTwoFactorConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *TwoFactorConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TwoFactorConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildTwoFactorConfigParams(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Two-Factor Config",
+			fmt.Sprintf("Unable to update two-factor authentication configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapTwoFactorConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TwoFactorConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := map[string]any{
+		"enabled": false,
+		"window":  0,
+		"services": map[string]any{
+			"ssh": false,
+		},
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Two-Factor Config",
+			fmt.Sprintf("Unable to reset two-factor authentication configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *TwoFactorConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "twofactor_config"
+	if req.ID != "twofactor_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'twofactor_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateConfig calls auth.twofactor.update and parses the response.
+func (r *TwoFactorConfigResource) updateConfig(ctx context.Context, params map[string]any) (*twoFactorConfigResponse, error) {
+	result, err := r.client.Call(ctx, "auth.twofactor.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config twoFactorConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse twofactor update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildTwoFactorConfigParams builds the auth.twofactor.update params from the resource model.
+func buildTwoFactorConfigParams(data *TwoFactorConfigResourceModel) map[string]any {
+	return map[string]any{
+		"enabled": data.Enabled.ValueBool(),
+		"window":  data.Window.ValueInt64(),
+		"services": map[string]any{
+			"ssh": data.SSH.ValueBool(),
+		},
+	}
+}
+
+// mapTwoFactorConfigToModel maps the API response to the resource model.
+func mapTwoFactorConfigToModel(config *twoFactorConfigResponse, data *TwoFactorConfigResourceModel) {
+	data.ID = types.StringValue("twofactor_config")
+	data.Enabled = types.BoolValue(config.Enabled)
+	data.Window = types.Int64Value(config.Window)
+	data.SSH = types.BoolValue(config.Services.SSH)
+}
@@ -0,0 +1,445 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewTwoFactorConfigResource(t *testing.T) {
+	r := NewTwoFactorConfigResource()
+	if r == nil {
+		t.Fatal("NewTwoFactorConfigResource returned nil")
+	}
+
+	_, ok := r.(*TwoFactorConfigResource)
+	if !ok {
+		t.Fatalf("expected *TwoFactorConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*TwoFactorConfigResource))
+	_ = resource.ResourceWithImportState(r.(*TwoFactorConfigResource))
+}
+
+func TestTwoFactorConfigResource_Metadata(t *testing.T) {
+	r := NewTwoFactorConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_twofactor_config" {
+		t.Errorf("expected TypeName 'truenas_twofactor_config', got %q", resp.TypeName)
+	}
+}
+
+func TestTwoFactorConfigResource_Schema(t *testing.T) {
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"enabled", "window", "ssh"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getTwoFactorConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewTwoFactorConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// twoFactorConfigModelParams holds parameters for creating test model values.
+type twoFactorConfigModelParams struct {
+	ID      interface{}
+	Enabled interface{}
+	Window  interface{}
+	SSH     interface{}
+}
+
+func createTwoFactorConfigModelValue(p twoFactorConfigModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":      tftypes.String,
+			"enabled": tftypes.Bool,
+			"window":  tftypes.Number,
+			"ssh":     tftypes.Bool,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, p.ID),
+		"enabled": tftypes.NewValue(tftypes.Bool, p.Enabled),
+		"window":  tftypes.NewValue(tftypes.Number, p.Window),
+		"ssh":     tftypes.NewValue(tftypes.Bool, p.SSH),
+	})
+}
+
+func defaultTwoFactorConfigParams() twoFactorConfigModelParams {
+	return twoFactorConfigModelParams{
+		Enabled: true,
+		Window:  float64(1),
+		SSH:     true,
+	}
+}
+
+const testTwoFactorConfigJSON = `{
+	"id": 1,
+	"enabled": true,
+	"window": 1,
+	"services": {"ssh": true}
+}`
+
+func TestTwoFactorConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testTwoFactorConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	planValue := createTwoFactorConfigModelValue(defaultTwoFactorConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "auth.twofactor.update" {
+		t.Errorf("expected method 'auth.twofactor.update', got %q", capturedMethod)
+	}
+	if capturedParams["enabled"] != true {
+		t.Errorf("expected enabled true, got %v", capturedParams["enabled"])
+	}
+	if capturedParams["window"] != int64(1) {
+		t.Errorf("expected window 1, got %v", capturedParams["window"])
+	}
+	services, ok := capturedParams["services"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected services to be a map, got %T", capturedParams["services"])
+	}
+	if services["ssh"] != true {
+		t.Errorf("expected services.ssh true, got %v", services["ssh"])
+	}
+
+	var model TwoFactorConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "twofactor_config" {
+		t.Errorf("expected ID 'twofactor_config', got %q", model.ID.ValueString())
+	}
+	if !model.SSH.ValueBool() {
+		t.Error("expected ssh to be true")
+	}
+}
+
+func TestTwoFactorConfigResource_Create_APIError(t *testing.T) {
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	planValue := createTwoFactorConfigModelValue(defaultTwoFactorConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTwoFactorConfigResource_Read_Success(t *testing.T) {
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				if method != "auth.twofactor.config" {
+					t.Errorf("expected method 'auth.twofactor.config', got %q", method)
+				}
+				return json.RawMessage(`{"id": 1, "enabled": false, "window": 0, "services": {"ssh": false}}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	p := defaultTwoFactorConfigParams()
+	p.ID = "twofactor_config"
+	stateValue := createTwoFactorConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model TwoFactorConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Enabled.ValueBool() {
+		t.Error("expected enabled to be false")
+	}
+	if model.SSH.ValueBool() {
+		t.Error("expected ssh to be false")
+	}
+}
+
+func TestTwoFactorConfigResource_Read_APIError(t *testing.T) {
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	p := defaultTwoFactorConfigParams()
+	p.ID = "twofactor_config"
+	stateValue := createTwoFactorConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTwoFactorConfigResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "enabled": true, "window": 2, "services": {"ssh": true}}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	state := defaultTwoFactorConfigParams()
+	state.ID = "twofactor_config"
+	plan := defaultTwoFactorConfigParams()
+	plan.ID = "twofactor_config"
+	plan.Window = float64(2)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createTwoFactorConfigModelValue(state)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createTwoFactorConfigModelValue(plan)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["window"] != int64(2) {
+		t.Errorf("expected window 2, got %v", capturedParams["window"])
+	}
+}
+
+func TestTwoFactorConfigResource_Update_APIError(t *testing.T) {
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("validation error")
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	p := defaultTwoFactorConfigParams()
+	p.ID = "twofactor_config"
+	value := createTwoFactorConfigModelValue(p)
+
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTwoFactorConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	p := defaultTwoFactorConfigParams()
+	p.ID = "twofactor_config"
+	stateValue := createTwoFactorConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["enabled"] != false {
+		t.Errorf("expected enabled reset to false, got %v", capturedParams["enabled"])
+	}
+	if services := capturedParams["services"].(map[string]any); services["ssh"] != false {
+		t.Errorf("expected services.ssh reset to false, got %v", services["ssh"])
+	}
+}
+
+func TestTwoFactorConfigResource_Delete_APIError(t *testing.T) {
+	r := &TwoFactorConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+	p := defaultTwoFactorConfigParams()
+	p.ID = "twofactor_config"
+	stateValue := createTwoFactorConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestTwoFactorConfigResource_ImportState(t *testing.T) {
+	r := NewTwoFactorConfigResource().(*TwoFactorConfigResource)
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "twofactor_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createTwoFactorConfigModelValue(twoFactorConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestTwoFactorConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewTwoFactorConfigResource().(*TwoFactorConfigResource)
+	schemaResp := getTwoFactorConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "2fa"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createTwoFactorConfigModelValue(twoFactorConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
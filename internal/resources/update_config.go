@@ -0,0 +1,235 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &UpdateConfigResource{}
+	_ resource.ResourceWithConfigure   = &UpdateConfigResource{}
+	_ resource.ResourceWithImportState = &UpdateConfigResource{}
+)
+
+// UpdateConfigResourceModel describes the resource data model.
+type UpdateConfigResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Train        types.String `tfsdk:"train"`
+	AutoDownload types.Bool   `tfsdk:"auto_download"`
+	CurrentTrain types.String `tfsdk:"current_train"`
+}
+
+// updateTrainsResponse is the JSON shape returned by update.get_trains.
+type updateTrainsResponse struct {
+	Trains   map[string]json.RawMessage `json:"trains"`
+	Current  string                     `json:"current"`
+	Selected string                     `json:"selected"`
+}
+
+// UpdateConfigResource defines the resource implementation.
+type UpdateConfigResource struct {
+	BaseResource
+}
+
+// NewUpdateConfigResource creates a new UpdateConfigResource.
+func NewUpdateConfigResource() resource.Resource {
+	return &UpdateConfigResource{}
+}
+
+func (r *UpdateConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_update_config"
+}
+
+func (r *UpdateConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the TrueNAS OS update train and automatic update downloads. Updates are applied " +
+			"with the truenas_apply_pending_update action.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource ID (always 'update_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"train": schema.StringAttribute{
+				Description: "Update train to follow (e.g. 'TrueNAS-SCALE-Fangtooth'). Must be one of the trains " +
+					"offered by update.get_trains.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"auto_download": schema.BoolAttribute{
+				Description: "Download available updates in the background. They are not applied until requested. " +
+					"Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"current_train": schema.StringAttribute{
+				Description: "Train of the running OS version.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *UpdateConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UpdateConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &data, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UpdateConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UpdateConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.read(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *UpdateConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state UpdateConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UpdateConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Re-enable automatic downloads. The train cannot be unset and is left
+	// as selected.
+	if _, err := r.client.Call(ctx, "update.set_auto_download", []any{true}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset Update Config",
+			fmt.Sprintf("Unable to re-enable automatic update downloads: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *UpdateConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "update_config"
+	if req.ID != "update_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'update_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply sets the train and auto-download setting, skipping calls for values
+// that match state, then reads the configuration back into data.
+func (r *UpdateConfigResource) apply(ctx context.Context, data, state *UpdateConfigResourceModel, diags *diag.Diagnostics) {
+	if state == nil || !state.Train.Equal(data.Train) {
+		train := data.Train.ValueString()
+		if _, err := r.client.Call(ctx, "update.set_train", []any{train}); err != nil {
+			diags.AddAttributeError(
+				path.Root("train"),
+				"Unable to Set Update Train",
+				fmt.Sprintf("Unable to set update train to %q: %s", train, err.Error()),
+			)
+			return
+		}
+	}
+
+	if state == nil || !state.AutoDownload.Equal(data.AutoDownload) {
+		if _, err := r.client.Call(ctx, "update.set_auto_download", []any{data.AutoDownload.ValueBool()}); err != nil {
+			diags.AddError(
+				"Unable to Update Auto Download",
+				fmt.Sprintf("Unable to set automatic update downloads: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	r.read(ctx, data, diags)
+}
+
+// read maps update.get_trains and update.get_auto_download into data.
+func (r *UpdateConfigResource) read(ctx context.Context, data *UpdateConfigResourceModel, diags *diag.Diagnostics) {
+	result, err := r.client.Call(ctx, "update.get_trains", nil)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Update Config",
+			fmt.Sprintf("Unable to read update trains: %s", err.Error()),
+		)
+		return
+	}
+
+	var trains updateTrainsResponse
+	if err := json.Unmarshal(result, &trains); err != nil {
+		diags.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse update trains: %s", err.Error()),
+		)
+		return
+	}
+
+	result, err = r.client.Call(ctx, "update.get_auto_download", nil)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Update Config",
+			fmt.Sprintf("Unable to read automatic update download setting: %s", err.Error()),
+		)
+		return
+	}
+
+	var autoDownload bool
+	if err := json.Unmarshal(result, &autoDownload); err != nil {
+		diags.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse automatic update download setting: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("update_config")
+	data.Train = types.StringValue(trains.Selected)
+	data.AutoDownload = types.BoolValue(autoDownload)
+	data.CurrentTrain = types.StringValue(trains.Current)
+}
@@ -0,0 +1,390 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewUpdateConfigResource(t *testing.T) {
+	r := NewUpdateConfigResource()
+	if r == nil {
+		t.Fatal("NewUpdateConfigResource returned nil")
+	}
+
+	_, ok := r.(*UpdateConfigResource)
+	if !ok {
+		t.Fatalf("expected *UpdateConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*UpdateConfigResource))
+	_ = resource.ResourceWithImportState(r.(*UpdateConfigResource))
+}
+
+func TestUpdateConfigResource_Metadata(t *testing.T) {
+	r := NewUpdateConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_update_config" {
+		t.Errorf("expected TypeName 'truenas_update_config', got %q", resp.TypeName)
+	}
+}
+
+func TestUpdateConfigResource_Schema(t *testing.T) {
+	schemaResp := getUpdateConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	if !attrs["train"].IsRequired() {
+		t.Error("expected 'train' attribute to be required")
+	}
+	if !attrs["auto_download"].IsOptional() || !attrs["auto_download"].IsComputed() {
+		t.Error("expected 'auto_download' attribute to be optional and computed")
+	}
+	if !attrs["current_train"].IsComputed() {
+		t.Error("expected 'current_train' attribute to be computed")
+	}
+}
+
+// Test helpers
+
+func getUpdateConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewUpdateConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// updateConfigModelParams holds parameters for creating test model values.
+type updateConfigModelParams struct {
+	ID           interface{}
+	Train        interface{}
+	AutoDownload interface{}
+	CurrentTrain interface{}
+}
+
+func createUpdateConfigModelValue(p updateConfigModelParams) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":            tftypes.String,
+			"train":         tftypes.String,
+			"auto_download": tftypes.Bool,
+			"current_train": tftypes.String,
+		},
+	}, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, p.ID),
+		"train":         tftypes.NewValue(tftypes.String, p.Train),
+		"auto_download": tftypes.NewValue(tftypes.Bool, p.AutoDownload),
+		"current_train": tftypes.NewValue(tftypes.String, p.CurrentTrain),
+	})
+}
+
+const testUpdateTrainsJSON = `{
+	"trains": {
+		"TrueNAS-SCALE-Electric-Eel": {"description": "TrueNAS SCALE 24.10"},
+		"TrueNAS-SCALE-Fangtooth": {"description": "TrueNAS SCALE 25.04"}
+	},
+	"current": "TrueNAS-SCALE-Electric-Eel",
+	"selected": "TrueNAS-SCALE-Fangtooth"
+}`
+
+// newUpdateConfigMockClient returns a client that answers the update.* reads
+// and records every call.
+func newUpdateConfigMockClient(calls *[]string, params map[string]any, autoDownload string) *client.MockClient {
+	return &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+			*calls = append(*calls, method)
+			if p != nil {
+				params[method] = p.([]any)[0]
+			}
+			switch method {
+			case "update.get_trains":
+				return json.RawMessage(testUpdateTrainsJSON), nil
+			case "update.get_auto_download":
+				return json.RawMessage(autoDownload), nil
+			}
+			return json.RawMessage(`null`), nil
+		},
+	}
+}
+
+func TestUpdateConfigResource_Create_Success(t *testing.T) {
+	var calls []string
+	params := map[string]any{}
+
+	r := &UpdateConfigResource{
+		BaseResource: BaseResource{client: newUpdateConfigMockClient(&calls, params, "false")},
+	}
+
+	schemaResp := getUpdateConfigResourceSchema(t)
+	planValue := createUpdateConfigModelValue(updateConfigModelParams{
+		Train:        "TrueNAS-SCALE-Fangtooth",
+		AutoDownload: false,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if params["update.set_train"] != "TrueNAS-SCALE-Fangtooth" {
+		t.Errorf("expected set_train 'TrueNAS-SCALE-Fangtooth', got %v", params["update.set_train"])
+	}
+	if params["update.set_auto_download"] != false {
+		t.Errorf("expected set_auto_download false, got %v", params["update.set_auto_download"])
+	}
+
+	var model UpdateConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "update_config" {
+		t.Errorf("expected ID 'update_config', got %q", model.ID.ValueString())
+	}
+	if model.CurrentTrain.ValueString() != "TrueNAS-SCALE-Electric-Eel" {
+		t.Errorf("expected current_train 'TrueNAS-SCALE-Electric-Eel', got %q", model.CurrentTrain.ValueString())
+	}
+	if model.AutoDownload.ValueBool() {
+		t.Error("expected auto_download false")
+	}
+}
+
+func TestUpdateConfigResource_Create_InvalidTrain(t *testing.T) {
+	r := &UpdateConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("Invalid train name")
+			},
+		}},
+	}
+
+	schemaResp := getUpdateConfigResourceSchema(t)
+	planValue := createUpdateConfigModelValue(updateConfigModelParams{
+		Train:        "TrueNAS-SCALE-Nope",
+		AutoDownload: true,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Unable to Set Update Train" {
+		t.Errorf("expected train error, got %q", summary)
+	}
+}
+
+func TestUpdateConfigResource_Read_Success(t *testing.T) {
+	var calls []string
+
+	r := &UpdateConfigResource{
+		BaseResource: BaseResource{client: newUpdateConfigMockClient(&calls, map[string]any{}, "true")},
+	}
+
+	schemaResp := getUpdateConfigResourceSchema(t)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createUpdateConfigModelValue(updateConfigModelParams{
+			ID:           "update_config",
+			Train:        "TrueNAS-SCALE-Electric-Eel",
+			AutoDownload: false,
+			CurrentTrain: "TrueNAS-SCALE-Electric-Eel",
+		})},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model UpdateConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Train.ValueString() != "TrueNAS-SCALE-Fangtooth" {
+		t.Errorf("expected train 'TrueNAS-SCALE-Fangtooth' from API, got %q", model.Train.ValueString())
+	}
+	if !model.AutoDownload.ValueBool() {
+		t.Error("expected auto_download true from API")
+	}
+}
+
+func TestUpdateConfigResource_Read_APIError(t *testing.T) {
+	r := &UpdateConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getUpdateConfigResourceSchema(t)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createUpdateConfigModelValue(updateConfigModelParams{
+			ID:           "update_config",
+			Train:        "TrueNAS-SCALE-Fangtooth",
+			AutoDownload: true,
+		})},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestUpdateConfigResource_Update_OnlyChangedSettings(t *testing.T) {
+	var calls []string
+	params := map[string]any{}
+
+	r := &UpdateConfigResource{
+		BaseResource: BaseResource{client: newUpdateConfigMockClient(&calls, params, "false")},
+	}
+
+	schemaResp := getUpdateConfigResourceSchema(t)
+	state := updateConfigModelParams{
+		ID:           "update_config",
+		Train:        "TrueNAS-SCALE-Fangtooth",
+		AutoDownload: true,
+		CurrentTrain: "TrueNAS-SCALE-Electric-Eel",
+	}
+	plan := state
+	plan.AutoDownload = false
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createUpdateConfigModelValue(plan)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createUpdateConfigModelValue(state)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if _, ok := params["update.set_train"]; ok {
+		t.Error("expected update.set_train not to be called for an unchanged train")
+	}
+	if params["update.set_auto_download"] != false {
+		t.Errorf("expected set_auto_download false, got %v", params["update.set_auto_download"])
+	}
+}
+
+func TestUpdateConfigResource_Delete_ReenablesAutoDownload(t *testing.T) {
+	var calls []string
+	params := map[string]any{}
+
+	r := &UpdateConfigResource{
+		BaseResource: BaseResource{client: newUpdateConfigMockClient(&calls, params, "true")},
+	}
+
+	schemaResp := getUpdateConfigResourceSchema(t)
+	stateValue := createUpdateConfigModelValue(updateConfigModelParams{
+		ID:           "update_config",
+		Train:        "TrueNAS-SCALE-Fangtooth",
+		AutoDownload: false,
+		CurrentTrain: "TrueNAS-SCALE-Electric-Eel",
+	})
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(calls) != 1 || calls[0] != "update.set_auto_download" {
+		t.Fatalf("expected only update.set_auto_download, got %v", calls)
+	}
+	if params["update.set_auto_download"] != true {
+		t.Errorf("expected auto_download reset to true, got %v", params["update.set_auto_download"])
+	}
+}
+
+func TestUpdateConfigResource_ImportState(t *testing.T) {
+	r := NewUpdateConfigResource().(*UpdateConfigResource)
+	schemaResp := getUpdateConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "update_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createUpdateConfigModelValue(updateConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestUpdateConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewUpdateConfigResource().(*UpdateConfigResource)
+	schemaResp := getUpdateConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createUpdateConfigModelValue(updateConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
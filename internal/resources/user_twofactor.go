@@ -0,0 +1,281 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &UserTwoFactorResource{}
+	_ resource.ResourceWithConfigure   = &UserTwoFactorResource{}
+	_ resource.ResourceWithImportState = &UserTwoFactorResource{}
+)
+
+// UserTwoFactorResourceModel describes the resource data model.
+type UserTwoFactorResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Username        types.String `tfsdk:"username"`
+	Interval        types.Int64  `tfsdk:"interval"`
+	OTPDigits       types.Int64  `tfsdk:"otp_digits"`
+	ProvisioningURI types.String `tfsdk:"provisioning_uri"`
+}
+
+// userTwoFactorResponse is the subset of user.query fields needed for 2FA.
+type userTwoFactorResponse struct {
+	ID                      int64  `json:"id"`
+	Username                string `json:"username"`
+	TwoFactorAuthConfigured bool   `json:"twofactor_auth_configured"`
+}
+
+// UserTwoFactorResource defines the resource implementation.
+type UserTwoFactorResource struct {
+	BaseResource
+}
+
+// NewUserTwoFactorResource creates a new UserTwoFactorResource.
+func NewUserTwoFactorResource() resource.Resource {
+	return &UserTwoFactorResource{}
+}
+
+func (r *UserTwoFactorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_twofactor"
+}
+
+func (r *UserTwoFactorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provisions a two-factor authentication (TOTP) secret for a TrueNAS user. " +
+			"Changing any argument generates a new secret.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (the username).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username of the local user to provision.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"interval": schema.Int64Attribute{
+				Description: "TOTP interval in seconds. Defaults to 30.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(30),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(5),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"otp_digits": schema.Int64Attribute{
+				Description: "Number of digits in generated one-time passwords (6-8). Defaults to 6.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(6),
+				Validators: []validator.Int64{
+					int64validator.Between(6, 8),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"provisioning_uri": schema.StringAttribute{
+				Description: "otpauth:// URI for enrolling the secret in an authenticator app.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserTwoFactorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserTwoFactorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := data.Username.ValueString()
+	params := []any{username, map[string]any{
+		"interval":   data.Interval.ValueInt64(),
+		"otp_digits": data.OTPDigits.ValueInt64(),
+	}}
+
+	if _, err := r.client.Call(ctx, "user.renew_2fa_secret", params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create User Two-Factor Secret",
+			fmt.Sprintf("Unable to create two-factor secret for user %q: %s", username, err.Error()),
+		)
+		return
+	}
+
+	uri, err := r.getProvisioningURI(ctx, username)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Provisioning URI",
+			fmt.Sprintf("Unable to read provisioning URI for user %q: %s", username, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(username)
+	data.ProvisioningURI = types.StringValue(uri)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserTwoFactorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserTwoFactorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := data.Username.ValueString()
+	user, err := r.getUser(ctx, username)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read User",
+			fmt.Sprintf("Unable to read user %q: %s", username, err.Error()),
+		)
+		return
+	}
+
+	// The user is gone or the secret was removed outside of Terraform
+	if user == nil || !user.TwoFactorAuthConfigured {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	uri, err := r.getProvisioningURI(ctx, username)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Provisioning URI",
+			fmt.Sprintf("Unable to read provisioning URI for user %q: %s", username, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(username)
+	data.ProvisioningURI = types.StringValue(uri)
+	mapProvisioningURIToModel(uri, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *UserTwoFactorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All arguments require replacement, so there is nothing to update in place.
+	var plan UserTwoFactorResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UserTwoFactorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserTwoFactorResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := data.Username.ValueString()
+	if _, err := r.client.Call(ctx, "user.unset_2fa_secret", username); err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete User Two-Factor Secret",
+			fmt.Sprintf("Unable to remove two-factor secret for user %q: %s", username, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *UserTwoFactorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The import ID is the username - set it to both id and username attributes
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), req.ID)...)
+}
+
+// getUser queries a user by username. Returns nil if the user does not exist.
+func (r *UserTwoFactorResource) getUser(ctx context.Context, username string) (*userTwoFactorResponse, error) {
+	filter := []any{[]any{[]any{"username", "=", username}}}
+
+	result, err := r.client.Call(ctx, "user.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []userTwoFactorResponse
+	if err := json.Unmarshal(result, &users); err != nil {
+		return nil, fmt.Errorf("parse user query response: %w", err)
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+// getProvisioningURI fetches the otpauth:// URI for the user's 2FA secret.
+func (r *UserTwoFactorResource) getProvisioningURI(ctx context.Context, username string) (string, error) {
+	result, err := r.client.Call(ctx, "user.provisioning_uri", username)
+	if err != nil {
+		return "", err
+	}
+
+	var uri string
+	if err := json.Unmarshal(result, &uri); err != nil {
+		return "", fmt.Errorf("parse provisioning uri response: %w", err)
+	}
+
+	return uri, nil
+}
+
+// mapProvisioningURIToModel recovers interval and digits from the otpauth:// URI
+// so that imported resources do not plan a replacement.
+func mapProvisioningURIToModel(uri string, data *UserTwoFactorResourceModel) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return
+	}
+
+	query := parsed.Query()
+	if period, err := strconv.ParseInt(query.Get("period"), 10, 64); err == nil {
+		data.Interval = types.Int64Value(period)
+	}
+	if digits, err := strconv.ParseInt(query.Get("digits"), 10, 64); err == nil {
+		data.OTPDigits = types.Int64Value(digits)
+	}
+}
@@ -0,0 +1,479 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testProvisioningURI = "otpauth://totp/alice@truenas?secret=JBSWY3DPEHPK3PXP&issuer=iXsystems&period=30&digits=6"
+
+func TestNewUserTwoFactorResource(t *testing.T) {
+	r := NewUserTwoFactorResource()
+	if r == nil {
+		t.Fatal("NewUserTwoFactorResource returned nil")
+	}
+
+	_, ok := r.(*UserTwoFactorResource)
+	if !ok {
+		t.Fatalf("expected *UserTwoFactorResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*UserTwoFactorResource))
+	_ = resource.ResourceWithImportState(r.(*UserTwoFactorResource))
+}
+
+func TestUserTwoFactorResource_Metadata(t *testing.T) {
+	r := NewUserTwoFactorResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_user_twofactor" {
+		t.Errorf("expected TypeName 'truenas_user_twofactor', got %q", resp.TypeName)
+	}
+}
+
+func TestUserTwoFactorResource_Schema(t *testing.T) {
+	schemaResp := getUserTwoFactorResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["username"].IsRequired() {
+		t.Error("expected 'username' attribute to be required")
+	}
+	if !attrs["interval"].IsOptional() {
+		t.Error("expected 'interval' attribute to be optional")
+	}
+	if !attrs["otp_digits"].IsOptional() {
+		t.Error("expected 'otp_digits' attribute to be optional")
+	}
+	if !attrs["provisioning_uri"].IsComputed() || !attrs["provisioning_uri"].IsSensitive() {
+		t.Error("expected 'provisioning_uri' attribute to be computed and sensitive")
+	}
+}
+
+// Test helpers
+
+func getUserTwoFactorResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewUserTwoFactorResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// userTwoFactorModelParams holds parameters for creating test model values.
+type userTwoFactorModelParams struct {
+	ID              interface{}
+	Username        interface{}
+	Interval        interface{}
+	OTPDigits       interface{}
+	ProvisioningURI interface{}
+}
+
+func createUserTwoFactorModelValue(p userTwoFactorModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":               tftypes.String,
+			"username":         tftypes.String,
+			"interval":         tftypes.Number,
+			"otp_digits":       tftypes.Number,
+			"provisioning_uri": tftypes.String,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":               tftypes.NewValue(tftypes.String, p.ID),
+		"username":         tftypes.NewValue(tftypes.String, p.Username),
+		"interval":         tftypes.NewValue(tftypes.Number, p.Interval),
+		"otp_digits":       tftypes.NewValue(tftypes.Number, p.OTPDigits),
+		"provisioning_uri": tftypes.NewValue(tftypes.String, p.ProvisioningURI),
+	})
+}
+
+func defaultUserTwoFactorStateParams() userTwoFactorModelParams {
+	return userTwoFactorModelParams{
+		ID:              "alice",
+		Username:        "alice",
+		Interval:        float64(30),
+		OTPDigits:       float64(6),
+		ProvisioningURI: testProvisioningURI,
+	}
+}
+
+func TestUserTwoFactorResource_Create_Success(t *testing.T) {
+	var methods []string
+	var renewParams []any
+
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				methods = append(methods, method)
+				switch method {
+				case "user.renew_2fa_secret":
+					renewParams = params.([]any)
+					return json.RawMessage(`{"id": 1000, "username": "alice", "twofactor_auth_configured": true}`), nil
+				case "user.provisioning_uri":
+					if params != "alice" {
+						t.Errorf("expected username 'alice', got %v", params)
+					}
+					return json.RawMessage(`"otpauth://totp/alice@truenas?secret=JBSWY3DPEHPK3PXP&issuer=iXsystems&period=60&digits=8"`), nil
+				}
+				return nil, errors.New("unexpected method: " + method)
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	planValue := createUserTwoFactorModelValue(userTwoFactorModelParams{
+		ID:              tftypes.UnknownValue,
+		Username:        "alice",
+		Interval:        float64(60),
+		OTPDigits:       float64(8),
+		ProvisioningURI: tftypes.UnknownValue,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(methods) != 2 || methods[0] != "user.renew_2fa_secret" || methods[1] != "user.provisioning_uri" {
+		t.Fatalf("unexpected call sequence: %v", methods)
+	}
+	if renewParams[0] != "alice" {
+		t.Errorf("expected username 'alice', got %v", renewParams[0])
+	}
+	opts := renewParams[1].(map[string]any)
+	if opts["interval"] != int64(60) || opts["otp_digits"] != int64(8) {
+		t.Errorf("expected interval 60 and otp_digits 8, got %v", opts)
+	}
+
+	var model UserTwoFactorResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "alice" {
+		t.Errorf("expected ID 'alice', got %q", model.ID.ValueString())
+	}
+	if model.ProvisioningURI.IsNull() || model.ProvisioningURI.IsUnknown() {
+		t.Error("expected provisioning_uri to be set")
+	}
+}
+
+func TestUserTwoFactorResource_Create_APIError(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("user does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	planValue := createUserTwoFactorModelValue(userTwoFactorModelParams{
+		ID:              tftypes.UnknownValue,
+		Username:        "alice",
+		Interval:        float64(30),
+		OTPDigits:       float64(6),
+		ProvisioningURI: tftypes.UnknownValue,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestUserTwoFactorResource_Read_Success(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				switch method {
+				case "user.query":
+					return json.RawMessage(`[{"id": 1000, "username": "alice", "twofactor_auth_configured": true}]`), nil
+				case "user.provisioning_uri":
+					return json.RawMessage(`"` + testProvisioningURI + `"`), nil
+				}
+				return nil, errors.New("unexpected method: " + method)
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(userTwoFactorModelParams{
+		ID:       "alice",
+		Username: "alice",
+	})
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model UserTwoFactorResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Interval.ValueInt64() != 30 {
+		t.Errorf("expected interval 30 from URI, got %d", model.Interval.ValueInt64())
+	}
+	if model.OTPDigits.ValueInt64() != 6 {
+		t.Errorf("expected otp_digits 6 from URI, got %d", model.OTPDigits.ValueInt64())
+	}
+	if model.ProvisioningURI.ValueString() != testProvisioningURI {
+		t.Errorf("expected provisioning_uri %q, got %q", testProvisioningURI, model.ProvisioningURI.ValueString())
+	}
+}
+
+func TestUserTwoFactorResource_Read_NotConfigured(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[{"id": 1000, "username": "alice", "twofactor_auth_configured": false}]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(defaultUserTwoFactorStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed when 2FA is not configured")
+	}
+}
+
+func TestUserTwoFactorResource_Read_UserNotFound(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(defaultUserTwoFactorStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed when user is not found")
+	}
+}
+
+func TestUserTwoFactorResource_Read_APIError(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(defaultUserTwoFactorStateParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestUserTwoFactorResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params
+				return json.RawMessage(`null`), nil
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(defaultUserTwoFactorStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "user.unset_2fa_secret" {
+		t.Errorf("expected method 'user.unset_2fa_secret', got %q", capturedMethod)
+	}
+	if capturedParams != "alice" {
+		t.Errorf("expected username 'alice', got %v", capturedParams)
+	}
+}
+
+func TestUserTwoFactorResource_Delete_NotFound(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("user alice does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(defaultUserTwoFactorStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected not-found to be ignored, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestUserTwoFactorResource_Delete_APIError(t *testing.T) {
+	r := &UserTwoFactorResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getUserTwoFactorResourceSchema(t)
+	stateValue := createUserTwoFactorModelValue(defaultUserTwoFactorStateParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestUserTwoFactorResource_ImportState(t *testing.T) {
+	r := NewUserTwoFactorResource().(*UserTwoFactorResource)
+	schemaResp := getUserTwoFactorResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "alice"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createUserTwoFactorModelValue(userTwoFactorModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model UserTwoFactorResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Username.ValueString() != "alice" {
+		t.Errorf("expected username 'alice', got %q", model.Username.ValueString())
+	}
+}
+
+func TestMapProvisioningURIToModel_MissingParams(t *testing.T) {
+	data := UserTwoFactorResourceModel{
+		Interval:  types.Int64Value(30),
+		OTPDigits: types.Int64Value(6),
+	}
+
+	mapProvisioningURIToModel("otpauth://totp/alice@truenas?secret=JBSWY3DPEHPK3PXP", &data)
+
+	if data.Interval.ValueInt64() != 30 || data.OTPDigits.ValueInt64() != 6 {
+		t.Errorf("expected existing values to be kept, got interval=%d digits=%d", data.Interval.ValueInt64(), data.OTPDigits.ValueInt64())
+	}
+}
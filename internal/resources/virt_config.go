@@ -125,6 +125,7 @@ func (r *VirtConfigResource) Read(ctx context.Context, req resource.ReadRequest,
 	data.ID = types.StringValue("virt_config")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *VirtConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -2,12 +2,24 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
-	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/terraform-provider-truenas/internal/api/methods"
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	"github.com/deevus/truenas-go/client"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -28,31 +40,55 @@ const (
 )
 
 var (
-	_ resource.Resource                = &VMResource{}
-	_ resource.ResourceWithConfigure   = &VMResource{}
-	_ resource.ResourceWithImportState = &VMResource{}
+	_ resource.Resource                   = &VMResource{}
+	_ resource.ResourceWithConfigure      = &VMResource{}
+	_ resource.ResourceWithImportState    = &VMResource{}
+	_ resource.ResourceWithModifyPlan     = &VMResource{}
+	_ resource.ResourceWithValidateConfig = &VMResource{}
 )
 
+// vmAPIFieldPaths maps vm.create and vm.update validation errors to attributes.
+var vmAPIFieldPaths = apiFieldPaths(
+	"name", "description", "vcpus", "cores", "threads", "memory", "min_memory", "autostart", "time",
+	"bootloader", "bootloader_ovmf", "cpu_mode", "cpu_model", "shutdown_timeout", "command_line_args",
+)
+
+// vmUUIDRegex matches the lowercase form libvirt reports domain UUIDs in.
+var vmUUIDRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// vmCPUListRegex matches the libvirt list syntax of cpuset and nodeset.
+var vmCPUListRegex = regexp.MustCompile(`^[0-9]+(-[0-9]+)?(,[0-9]+(-[0-9]+)?)*$`)
+
 // VMResourceModel describes the resource data model.
 type VMResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Description      types.String `tfsdk:"description"`
-	VCPUs            types.Int64  `tfsdk:"vcpus"`
-	Cores            types.Int64  `tfsdk:"cores"`
-	Threads          types.Int64  `tfsdk:"threads"`
-	Memory           types.Int64  `tfsdk:"memory"`
-	MinMemory        types.Int64  `tfsdk:"min_memory"`
-	Autostart        types.Bool   `tfsdk:"autostart"`
-	Time             types.String `tfsdk:"time"`
-	Bootloader       types.String `tfsdk:"bootloader"`
-	BootloaderOVMF   types.String `tfsdk:"bootloader_ovmf"`
-	CPUMode          types.String `tfsdk:"cpu_mode"`
-	CPUModel         types.String `tfsdk:"cpu_model"`
-	ShutdownTimeout  types.Int64  `tfsdk:"shutdown_timeout"`
-	CommandLineArgs  types.String `tfsdk:"command_line_args"`
-	State            types.String `tfsdk:"state"`
-	DisplayAvailable types.Bool   `tfsdk:"display_available"`
+	ID               types.String                           `tfsdk:"id"`
+	Name             types.String                           `tfsdk:"name"`
+	Description      types.String                           `tfsdk:"description"`
+	VCPUs            types.Int64                            `tfsdk:"vcpus"`
+	Cores            types.Int64                            `tfsdk:"cores"`
+	Threads          types.Int64                            `tfsdk:"threads"`
+	Memory           customtypes.MemoryMiBValue             `tfsdk:"memory"`
+	MinMemory        customtypes.MemoryMiBValue             `tfsdk:"min_memory"`
+	Autostart        types.Bool                             `tfsdk:"autostart"`
+	Time             customtypes.CaseInsensitiveStringValue `tfsdk:"time"`
+	Bootloader       customtypes.CaseInsensitiveStringValue `tfsdk:"bootloader"`
+	BootloaderOVMF   types.String                           `tfsdk:"bootloader_ovmf"`
+	CPUMode          customtypes.CaseInsensitiveStringValue `tfsdk:"cpu_mode"`
+	CPUModel         types.String                           `tfsdk:"cpu_model"`
+	ShutdownTimeout  types.Int64                            `tfsdk:"shutdown_timeout"`
+	CommandLineArgs  types.String                           `tfsdk:"command_line_args"`
+	State            types.String                           `tfsdk:"state"`
+	DisplayAvailable types.Bool                             `tfsdk:"display_available"`
+	ForceStopAfter   types.Int64                            `tfsdk:"force_stop_after"`
+	StartRetries     types.Int64                            `tfsdk:"start_retries"`
+	StartRetryDelay  types.Int64                            `tfsdk:"start_retry_delay"`
+	StartDelay       types.Int64                            `tfsdk:"start_delay"`
+	DeleteZvols      types.Bool                             `tfsdk:"delete_zvols"`
+	TPM              types.Bool                             `tfsdk:"tpm"`
+	UUID             types.String                           `tfsdk:"uuid"`
+	CPUSet           types.String                           `tfsdk:"cpuset"`
+	NodeSet          types.String                           `tfsdk:"nodeset"`
+	DeviceIDsByLabel types.Map                              `tfsdk:"device_ids_by_label"`
 	// Device blocks
 	Disks    []VMDiskModel    `tfsdk:"disk"`
 	Raws     []VMRawModel     `tfsdk:"raw"`
@@ -65,36 +101,42 @@ type VMResourceModel struct {
 
 // VMDiskModel represents a DISK device.
 type VMDiskModel struct {
-	DeviceID           types.Int64  `tfsdk:"device_id"`
-	Path               types.String `tfsdk:"path"`
-	Type               types.String `tfsdk:"type"`
-	LogicalSectorSize  types.Int64  `tfsdk:"logical_sectorsize"`
-	PhysicalSectorSize types.Int64  `tfsdk:"physical_sectorsize"`
-	IOType             types.String `tfsdk:"iotype"`
-	Serial             types.String `tfsdk:"serial"`
-	Order              types.Int64  `tfsdk:"order"`
+	DeviceID             types.Int64                     `tfsdk:"device_id"`
+	Path                 customtypes.FilesystemPathValue `tfsdk:"path"`
+	Type                 types.String                    `tfsdk:"type"`
+	LogicalSectorSize    types.Int64                     `tfsdk:"logical_sectorsize"`
+	PhysicalSectorSize   types.Int64                     `tfsdk:"physical_sectorsize"`
+	IOType               types.String                    `tfsdk:"iotype"`
+	Serial               types.String                    `tfsdk:"serial"`
+	Order                types.Int64                     `tfsdk:"order"`
+	Size                 customtypes.SizeStringValue     `tfsdk:"size"`
+	ZvolName             types.String                    `tfsdk:"zvol_name"`
+	Pool                 types.String                    `tfsdk:"pool"`
+	ManageZvol           types.Bool                      `tfsdk:"manage_zvol"`
+	DeleteBackingStorage types.Bool                      `tfsdk:"delete_backing_storage"`
 }
 
 // VMRawModel represents a RAW device.
 type VMRawModel struct {
-	DeviceID           types.Int64  `tfsdk:"device_id"`
-	Path               types.String `tfsdk:"path"`
-	Type               types.String `tfsdk:"type"`
-	Boot               types.Bool   `tfsdk:"boot"`
-	Exists             types.Bool   `tfsdk:"exists"`
-	Size               types.Int64  `tfsdk:"size"`
-	LogicalSectorSize  types.Int64  `tfsdk:"logical_sectorsize"`
-	PhysicalSectorSize types.Int64  `tfsdk:"physical_sectorsize"`
-	IOType             types.String `tfsdk:"iotype"`
-	Serial             types.String `tfsdk:"serial"`
-	Order              types.Int64  `tfsdk:"order"`
+	DeviceID             types.Int64                     `tfsdk:"device_id"`
+	Path                 customtypes.FilesystemPathValue `tfsdk:"path"`
+	Type                 types.String                    `tfsdk:"type"`
+	Boot                 types.Bool                      `tfsdk:"boot"`
+	Exists               types.Bool                      `tfsdk:"exists"`
+	Size                 types.Int64                     `tfsdk:"size"`
+	LogicalSectorSize    types.Int64                     `tfsdk:"logical_sectorsize"`
+	PhysicalSectorSize   types.Int64                     `tfsdk:"physical_sectorsize"`
+	IOType               types.String                    `tfsdk:"iotype"`
+	Serial               types.String                    `tfsdk:"serial"`
+	Order                types.Int64                     `tfsdk:"order"`
+	DeleteBackingStorage types.Bool                      `tfsdk:"delete_backing_storage"`
 }
 
 // VMCDROMModel represents a CDROM device.
 type VMCDROMModel struct {
-	DeviceID types.Int64  `tfsdk:"device_id"`
-	Path     types.String `tfsdk:"path"`
-	Order    types.Int64  `tfsdk:"order"`
+	DeviceID types.Int64                     `tfsdk:"device_id"`
+	Path     customtypes.FilesystemPathValue `tfsdk:"path"`
+	Order    types.Int64                     `tfsdk:"order"`
 }
 
 // VMNICModel represents a NIC device.
@@ -105,6 +147,15 @@ type VMNICModel struct {
 	MAC                 types.String `tfsdk:"mac"`
 	TrustGuestRXFilters types.Bool   `tfsdk:"trust_guest_rx_filters"`
 	Order               types.Int64  `tfsdk:"order"`
+
+	CreateBridge *VMNICCreateBridgeModel `tfsdk:"create_bridge"`
+}
+
+// VMNICCreateBridgeModel describes the bridge to create for a NIC whose
+// nic_attach names a bridge that does not exist yet.
+type VMNICCreateBridgeModel struct {
+	Members types.List `tfsdk:"members"`
+	STP     types.Bool `tfsdk:"stp"`
 }
 
 // VMDisplayModel represents a DISPLAY device.
@@ -118,6 +169,7 @@ type VMDisplayModel struct {
 	Wait       types.Bool   `tfsdk:"wait"`
 	Password   types.String `tfsdk:"password"`
 	Web        types.Bool   `tfsdk:"web"`
+	WebURL     types.String `tfsdk:"web_url"`
 	Order      types.Int64  `tfsdk:"order"`
 }
 
@@ -152,7 +204,7 @@ func (r *VMResource) Metadata(ctx context.Context, req resource.MetadataRequest,
 
 func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a QEMU/KVM virtual machine on TrueNAS.",
+		Description: "Manages a QEMU/KVM virtual machine on TrueNAS. If a create fails after the VM was created, the VM is kept in state, tainted, so the next apply replaces it instead of failing on its name.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "VM ID (numeric, stored as string for Terraform compatibility).",
@@ -199,14 +251,16 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				},
 			},
 			"memory": schema.Int64Attribute{
-				Description: "Memory in MB (minimum 20).",
+				Description: "Memory in MiB (minimum 20). Values reported in bytes are normalized to MiB.",
+				CustomType:  customtypes.MemoryMiBType{},
 				Required:    true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(20),
 				},
 			},
 			"min_memory": schema.Int64Attribute{
-				Description: "Minimum memory for ballooning in MB. Null to disable.",
+				Description: "Minimum memory for ballooning in MiB. Null to disable.",
+				CustomType:  customtypes.MemoryMiBType{},
 				Optional:    true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(20),
@@ -222,38 +276,43 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				Description: "Clock type: LOCAL or UTC. Defaults to LOCAL.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("LOCAL"),
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				Default:     customtypes.CaseInsensitiveStringDefault("LOCAL"),
 				Validators: []validator.String{
-					stringvalidator.OneOf("LOCAL", "UTC"),
+					stringvalidator.OneOfCaseInsensitive("LOCAL", "UTC"),
 				},
 			},
 			"bootloader": schema.StringAttribute{
 				Description: "Bootloader type: UEFI or UEFI_CSM. Defaults to UEFI.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("UEFI"),
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				Default:     customtypes.CaseInsensitiveStringDefault("UEFI"),
 				Validators: []validator.String{
-					stringvalidator.OneOf("UEFI", "UEFI_CSM"),
+					stringvalidator.OneOfCaseInsensitive("UEFI", "UEFI_CSM"),
 				},
 			},
 			"bootloader_ovmf": schema.StringAttribute{
-				Description: "OVMF firmware file. Defaults to OVMF_CODE.fd.",
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString("OVMF_CODE.fd"),
+				Description: "OVMF firmware file. Defaults to OVMF_CODE.fd. Checked at plan time against the host's " +
+					"firmware files; see the truenas_vm_ovmf_firmwares data source.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("OVMF_CODE.fd"),
 			},
 			"cpu_mode": schema.StringAttribute{
 				Description: "CPU mode: CUSTOM, HOST-MODEL, or HOST-PASSTHROUGH. Defaults to CUSTOM.",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("CUSTOM"),
+				CustomType:  customtypes.CaseInsensitiveStringType{},
+				Default:     customtypes.CaseInsensitiveStringDefault("CUSTOM"),
 				Validators: []validator.String{
-					stringvalidator.OneOf("CUSTOM", "HOST-MODEL", "HOST-PASSTHROUGH"),
+					stringvalidator.OneOfCaseInsensitive("CUSTOM", "HOST-MODEL", "HOST-PASSTHROUGH"),
 				},
 			},
 			"cpu_model": schema.StringAttribute{
-				Description: "CPU model name (when cpu_mode is CUSTOM).",
-				Optional:    true,
+				Description: "CPU model name (when cpu_mode is CUSTOM). Checked at plan time against the host's " +
+					"supported models; see the truenas_vm_cpu_models data source.",
+				Optional: true,
 			},
 			"shutdown_timeout": schema.Int64Attribute{
 				Description: "Shutdown timeout in seconds (5-300). Defaults to 90.",
@@ -284,6 +343,77 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				Computed:      true,
 				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
+			"force_stop_after": schema.Int64Attribute{
+				Description: "When destroying a RUNNING VM, seconds to wait for the guest to shut down before powering " +
+					"it off. The guest also gets no longer than shutdown_timeout. Unset powers the VM off immediately.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"start_retries": schema.Int64Attribute{
+				Description: "Times to retry starting the VM when the host does not have enough free memory for it, " +
+					"for example while another guest is shutting down. Unset or 0 fails on the first attempt.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"start_retry_delay": schema.Int64Attribute{
+				Description: "Seconds to wait between start_retries attempts. Defaults to 30.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"start_delay": schema.Int64Attribute{
+				Description: "Seconds to wait before the provider starts the VM. Give VMs created in the same apply " +
+					"different delays to stagger their starts. Does not apply to autostart when TrueNAS boots.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"delete_zvols": schema.BoolAttribute{
+				Description: "When destroying this VM, also delete the zvols backing its DISK devices. Defaults to false.",
+				Optional:    true,
+			},
+			"tpm": schema.BoolAttribute{
+				Description: "Attach an emulated TPM 2.0 (swtpm) to the VM, as required by Windows 11 guests. Requires " +
+					"the UEFI bootloader. Unset leaves the VM's TPM setting unmanaged.",
+				Optional: true,
+			},
+			"uuid": schema.StringAttribute{
+				Description: "Domain UUID presented to the guest (SMBIOS system UUID). Set it to keep licensing-sensitive " +
+					"guests stable when the VM is recreated. Unset leaves the UUID generated by TrueNAS unmanaged.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vmUUIDRegex, "must be a lowercase UUID (e.g. 3f2b6a9e-4c1d-4f0a-9b8e-2d7c5a1e6f30)"),
+				},
+			},
+			"cpuset": schema.StringAttribute{
+				Description: "Host CPUs the VM's vCPUs may run on, as a list of CPUs and ranges (e.g. `0-3,8`). Unset " +
+					"leaves the VM's CPU affinity unmanaged.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vmCPUListRegex, "must be a list of CPUs and ranges (e.g. 0-3,8)"),
+				},
+			},
+			"nodeset": schema.StringAttribute{
+				Description: "Host NUMA nodes the VM's memory is allocated from, as a list of nodes and ranges (e.g. " +
+					"`0` or `0-1`). Keep it to the nodes of the cpuset so latency-sensitive guests do not reach " +
+					"across nodes for memory. Unset leaves the VM's memory placement unmanaged.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vmCPUListRegex, "must be a list of NUMA nodes and ranges (e.g. 0-1)"),
+				},
+			},
+			"device_ids_by_label": schema.MapAttribute{
+				Description: "Device IDs keyed by device block and index within the block, e.g. `disk.0` or `nic.1`. " +
+					"Known at plan time unless devices are being added.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"disk": schema.ListNestedBlock{
@@ -296,8 +426,44 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 							PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
 						},
 						"path": schema.StringAttribute{
-							Description: "Path to zvol device (e.g., /dev/zvol/tank/vms/disk0).",
-							Required:    true,
+							CustomType: customtypes.FilesystemPathType{},
+							Description: "Path to zvol device (e.g., /dev/zvol/tank/vms/disk0). Required unless size is set, " +
+								"in which case it is the path of the created zvol.",
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+						},
+						"size": schema.StringAttribute{
+							CustomType: customtypes.SizeStringType{},
+							Description: "Create the backing zvol with this size (e.g., '20G') instead of attaching an existing " +
+								"one at path. Requires zvol_name or pool. Increasing it grows the zvol.",
+							Optional: true,
+						},
+						"zvol_name": schema.StringAttribute{
+							Description: "Name of the zvol to create when size is set (e.g., tank/vms/web-disk0). Computed " +
+								"when pool is used instead.",
+							Optional:      true,
+							Computed:      true,
+							PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("pool")),
+							},
+						},
+						"pool": schema.StringAttribute{
+							Description: "Pool or dataset to create the zvol in when size is set. The zvol is named " +
+								"<vm name>-disk<n> with the first free n.",
+							Optional: true,
+						},
+						"manage_zvol": schema.BoolAttribute{
+							Description: "Delete the zvol created from size when this disk is removed or the VM is " +
+								"destroyed. Defaults to false, which keeps it.",
+							Optional: true,
+						},
+						"delete_backing_storage": schema.BoolAttribute{
+							Description: "Delete the zvol at path along with the device when this disk is removed " +
+								"from the configuration. Defaults to false, which keeps it. Destroying the VM is " +
+								"governed by delete_zvols instead.",
+							Optional: true,
 						},
 						"type": schema.StringAttribute{
 							Description: "Disk bus type: AHCI or VIRTIO. Defaults to AHCI.",
@@ -351,7 +517,7 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"device_id": schema.Int64Attribute{Computed: true, Description: "Device ID assigned by TrueNAS.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
-						"path":      schema.StringAttribute{Required: true, Description: "Path to raw file."},
+						"path":      schema.StringAttribute{CustomType: customtypes.FilesystemPathType{}, Required: true, Description: "Path to raw file."},
 						"type": schema.StringAttribute{
 							Optional: true, Computed: true, Default: stringdefault.StaticString("AHCI"),
 							Description: "Disk bus type: AHCI or VIRTIO. Defaults to AHCI.",
@@ -365,6 +531,11 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 							Optional:    true,
 							Description: "Set to true when the file at path already exists. When false (default), the API creates the raw file.",
 						},
+						"delete_backing_storage": schema.BoolAttribute{
+							Optional: true,
+							Description: "Delete the raw file at path along with the device when this block is removed " +
+								"from the configuration. Defaults to false, which keeps it.",
+						},
 						"size":                schema.Int64Attribute{Optional: true, Description: "File size in bytes (for creation)."},
 						"logical_sectorsize":  schema.Int64Attribute{Optional: true, Description: "Logical sector size: 512 or 4096.", Validators: []validator.Int64{int64validator.OneOf(512, 4096)}},
 						"physical_sectorsize": schema.Int64Attribute{Optional: true, Description: "Physical sector size: 512 or 4096.", Validators: []validator.Int64{int64validator.OneOf(512, 4096)}},
@@ -383,7 +554,7 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"device_id": schema.Int64Attribute{Computed: true, Description: "Device ID assigned by TrueNAS.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
-						"path":      schema.StringAttribute{Required: true, Description: "Path to ISO file (must start with /mnt/)."},
+						"path":      schema.StringAttribute{CustomType: customtypes.FilesystemPathType{}, Required: true, Description: "Path to ISO file (must start with /mnt/)."},
 						"order":     schema.Int64Attribute{Optional: true, Computed: true, Description: "Device boot/load order.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
 					},
 				},
@@ -398,11 +569,33 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 							Description: "NIC emulation type: E1000 or VIRTIO. Defaults to E1000.",
 							Validators:  []validator.String{stringvalidator.OneOf("E1000", "VIRTIO")},
 						},
-						"nic_attach":             schema.StringAttribute{Optional: true, Description: "Host interface to attach to."},
+						"nic_attach":             schema.StringAttribute{Optional: true, Description: "Host interface to attach to. Checked at plan time against the interfaces the host offers unless create_bridge is set; see the truenas_vm_nic_attach_choices data source."},
 						"mac":                    schema.StringAttribute{Optional: true, Computed: true, Description: "MAC address (auto-generated if not set).", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 						"trust_guest_rx_filters": schema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(false), Description: "Trust guest RX filters. Defaults to false."},
 						"order":                  schema.Int64Attribute{Optional: true, Computed: true, Description: "Device boot/load order.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
 					},
+					Blocks: map[string]schema.Block{
+						"create_bridge": schema.SingleNestedBlock{
+							Description: "Create the bridge named by nic_attach when it does not exist. The bridge is created and " +
+								"committed before the NIC is attached. It is left in place when the VM is destroyed, and never " +
+								"changed once it exists.",
+							Validators: []validator.Object{
+								objectvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("nic_attach")),
+							},
+							Attributes: map[string]schema.Attribute{
+								"members": schema.ListAttribute{
+									Description: "Host interfaces to add to the bridge, such as [\"enp1s0\"].",
+									ElementType: types.StringType,
+									Required:    true,
+									Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+								},
+								"stp": schema.BoolAttribute{
+									Description: "Enable the Spanning Tree Protocol on the bridge. Defaults to true.",
+									Optional:    true,
+								},
+							},
+						},
+					},
 				},
 			},
 			"display": schema.ListNestedBlock{
@@ -433,7 +626,12 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 						"wait":     schema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(false), Description: "Wait for client before booting. Defaults to false."},
 						"password": schema.StringAttribute{Required: true, Sensitive: true, Description: "Connection password. Required by TrueNAS for display devices."},
 						"web":      schema.BoolAttribute{Optional: true, Computed: true, Default: booldefault.StaticBool(true), Description: "Enable web client. Defaults to true."},
-						"order":    schema.Int64Attribute{Optional: true, Computed: true, Description: "Device boot/load order.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
+						"web_url": schema.StringAttribute{
+							Computed:      true,
+							Description:   "Web client URL, using HTTPS when the TrueNAS UI redirects to HTTPS. Null when the web client is disabled.",
+							PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+						},
+						"order": schema.Int64Attribute{Optional: true, Computed: true, Description: "Device boot/load order.", PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}},
 					},
 				},
 			},
@@ -469,88 +667,274 @@ func (r *VMResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 	}
 }
 
-// -- CRUD --
+// ValidateConfig checks that each disk either attaches an existing zvol at
+// path or creates one from size.
+func (r *VMResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var displayList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("display"), &displayList)...)
+	if !resp.Diagnostics.HasError() && !displayList.IsNull() && !displayList.IsUnknown() {
+		var displays []VMDisplayModel
+		resp.Diagnostics.Append(displayList.ElementsAs(ctx, &displays, false)...)
+		validateDisplayPorts(displays, &resp.Diagnostics)
+	}
 
-func (r *VMResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data VMResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	var diskList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("disk"), &diskList)...)
+	if resp.Diagnostics.HasError() || diskList.IsNull() || diskList.IsUnknown() {
+		return
+	}
+
+	var disks []VMDiskModel
+	resp.Diagnostics.Append(diskList.ElementsAs(ctx, &disks, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	opts := r.buildCreateOpts(&data)
-	vm, err := r.services.VM.CreateVM(ctx, opts)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create VM", fmt.Sprintf("Unable to create VM %q: %s", data.Name.ValueString(), err.Error()))
+	for i, disk := range disks {
+		diskPath := path.Root("disk").AtListIndex(i)
+		hasPath := !disk.Path.IsNull()
+		hasSize := !disk.Size.IsNull()
+		hasTarget := !disk.ZvolName.IsNull() || !disk.Pool.IsNull()
+
+		switch {
+		case hasPath && (hasSize || hasTarget):
+			resp.Diagnostics.AddAttributeError(diskPath.AtName("path"), "Conflicting Disk Source",
+				"A disk attaches the existing zvol at path, or creates one from size with zvol_name or pool, not both.")
+		case !hasSize && hasTarget:
+			resp.Diagnostics.AddAttributeError(diskPath.AtName("size"), "Missing Disk Size",
+				"zvol_name and pool only apply when size is set.")
+		case !hasPath && !hasSize:
+			resp.Diagnostics.AddAttributeError(diskPath, "Missing Disk Source",
+				"A disk requires path, or size with zvol_name or pool to create its zvol.")
+		case hasSize && !hasTarget:
+			resp.Diagnostics.AddAttributeError(diskPath.AtName("size"), "Missing Zvol Name",
+				"Set zvol_name or pool so the provider knows where to create the zvol.")
+		}
+		if disk.ManageZvol.ValueBool() && !hasSize {
+			resp.Diagnostics.AddAttributeError(diskPath.AtName("manage_zvol"), "Invalid Managed Zvol",
+				"manage_zvol only applies to zvols created from size; use delete_zvols to remove zvols attached by path when the VM is destroyed.")
+		}
+	}
+}
+
+// vmChoiceCheck describes an attribute validated at plan time against a
+// host-specific vm.*_choices method.
+type vmChoiceCheck struct {
+	title      string
+	noun       string
+	dataSource string
+	choices    func(ctx context.Context, c client.Client) (map[string]any, error)
+}
+
+var (
+	vmCPUModelCheck = vmChoiceCheck{
+		title:      "CPU Model",
+		noun:       "CPU model",
+		dataSource: "truenas_vm_cpu_models",
+		choices:    methods.VMCPUModelChoices,
+	}
+	vmOVMFCheck = vmChoiceCheck{
+		title:      "OVMF Firmware",
+		noun:       "OVMF firmware",
+		dataSource: "truenas_vm_ovmf_firmwares",
+		choices:    methods.VMBootloaderOVMFChoices,
+	}
+	vmNICAttachCheck = vmChoiceCheck{
+		title:      "NIC Attachment",
+		noun:       "NIC attachment",
+		dataSource: "truenas_vm_nic_attach_choices",
+		choices:    methods.VMDeviceNICAttachChoices,
+	}
+)
+
+// ModifyPlan matches reordered device blocks to the devices they describe,
+// and checks cpu_model (when cpu_mode is CUSTOM), bootloader_ovmf and each
+// NIC's nic_attach against what the host offers, so an unknown value fails
+// the plan instead of vm.create or the VM start failing with an opaque
+// libvirt error.
+func (r *VMResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
 		return
 	}
-	vmID := vm.ID
 
-	// Create devices
-	for i := range data.Disks {
-		dev, err := r.services.VM.CreateDevice(ctx, buildDiskDeviceOpts(&data.Disks[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create Disk Device", err.Error())
+	var plan VMResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *VMResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &VMResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Disks[i].DeviceID = types.Int64Value(dev.ID)
-	}
-	for i := range data.Raws {
-		dev, err := r.services.VM.CreateDevice(ctx, buildRawDeviceOpts(&data.Raws[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create Raw Device", err.Error())
+
+		var config VMResourceModel
+		resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Raws[i].DeviceID = types.Int64Value(dev.ID)
+		alignPlannedDevices(ctx, &plan, &config, state, resp)
 	}
-	for i := range data.CDROMs {
-		dev, err := r.services.VM.CreateDevice(ctx, buildCDROMDeviceOpts(&data.CDROMs[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create CDROM Device", err.Error())
-			return
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("device_ids_by_label"), deviceIDsByLabel(&plan))...)
+
+	if strings.EqualFold(plan.CPUMode.ValueString(), "CUSTOM") &&
+		(state == nil || !state.CPUModel.Equal(plan.CPUModel)) {
+		r.checkVMChoice(ctx, vmCPUModelCheck, path.Root("cpu_model"), plan.CPUModel, &resp.Diagnostics)
+	}
+	if state == nil || !state.BootloaderOVMF.Equal(plan.BootloaderOVMF) {
+		r.checkVMChoice(ctx, vmOVMFCheck, path.Root("bootloader_ovmf"), plan.BootloaderOVMF, &resp.Diagnostics)
+	}
+
+	attached := make(map[string]bool)
+	if state != nil {
+		for _, nic := range state.NICs {
+			attached[nic.NICAttach.ValueString()] = true
 		}
-		data.CDROMs[i].DeviceID = types.Int64Value(dev.ID)
 	}
-	for i := range data.NICs {
-		dev, err := r.services.VM.CreateDevice(ctx, buildNICDeviceOpts(&data.NICs[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create NIC Device", err.Error())
-			return
+	for i, nic := range plan.NICs {
+		// A bridge still to be created is not offered yet
+		if nic.CreateBridge != nil || attached[nic.NICAttach.ValueString()] {
+			continue
 		}
-		data.NICs[i].DeviceID = types.Int64Value(dev.ID)
+		r.checkVMChoice(ctx, vmNICAttachCheck, path.Root("nic").AtListIndex(i).AtName("nic_attach"), nic.NICAttach, &resp.Diagnostics)
 	}
-	for i := range data.Displays {
-		dev, err := r.services.VM.CreateDevice(ctx, buildDisplayDeviceOpts(&data.Displays[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create Display Device", err.Error())
-			return
+
+	r.checkDisplayPorts(ctx, &plan, state, &resp.Diagnostics)
+	r.planDiskZvols(ctx, plan.Disks, state, resp)
+}
+
+// planDiskZvols fills in what is already known about zvols created from
+// size: the path of a named zvol, and a null zvol_name for disks attached by
+// path. The zvol of an existing disk cannot be renamed or moved.
+func (r *VMResource) planDiskZvols(ctx context.Context, disks []VMDiskModel, state *VMResourceModel, resp *resource.ModifyPlanResponse) {
+	stateByID := make(map[int64]VMDiskModel)
+	if state != nil {
+		for _, s := range state.Disks {
+			if !s.DeviceID.IsNull() {
+				stateByID[s.DeviceID.ValueInt64()] = s
+			}
 		}
-		data.Displays[i].DeviceID = types.Int64Value(dev.ID)
 	}
-	for i := range data.PCIs {
-		dev, err := r.services.VM.CreateDevice(ctx, buildPCIDeviceOpts(&data.PCIs[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create PCI Device", err.Error())
-			return
+
+	for i, disk := range disks {
+		diskPath := path.Root("disk").AtListIndex(i)
+
+		if !disk.DeviceID.IsNull() && !disk.DeviceID.IsUnknown() {
+			if s, ok := stateByID[disk.DeviceID.ValueInt64()]; ok && !s.ZvolName.IsNull() &&
+				(!disk.ZvolName.Equal(s.ZvolName) || !disk.Pool.Equal(s.Pool)) {
+				resp.Diagnostics.AddAttributeError(diskPath.AtName("zvol_name"), "Cannot Move Disk Zvol",
+					fmt.Sprintf("The zvol of an existing disk cannot be renamed or moved (currently %s). "+
+						"Remove the disk block and add a new one to create a new zvol.", s.ZvolName.ValueString()))
+			}
+			continue
+		}
+
+		switch {
+		case disk.Size.IsNull() && disk.ZvolName.IsUnknown():
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, diskPath.AtName("zvol_name"), types.StringNull())...)
+		case !disk.ZvolName.IsNull() && !disk.ZvolName.IsUnknown() && disk.Path.IsUnknown():
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, diskPath.AtName("path"),
+				customtypes.NewFilesystemPathValue(zvolDevicePath(disk.ZvolName.ValueString())))...)
 		}
-		data.PCIs[i].DeviceID = types.Int64Value(dev.ID)
 	}
-	for i := range data.USBs {
-		dev, err := r.services.VM.CreateDevice(ctx, buildUSBDeviceOpts(&data.USBs[i], vmID))
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to Create USB Device", err.Error())
-			return
+}
+
+// checkVMChoice reports an attribute error when value is not one of the
+// host's choices. Unset and unknown values are skipped, and a failure to
+// read the choices is only a warning.
+func (r *VMResource) checkVMChoice(ctx context.Context, check vmChoiceCheck, attrPath path.Path, value types.String, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return
+	}
+
+	choices, err := check.choices(ctx, r.client)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Validate "+check.title,
+			fmt.Sprintf("Unable to read VM %s choices: %s", check.noun, err.Error()),
+		)
+		return
+	}
+
+	if _, ok := choices[value.ValueString()]; ok {
+		return
+	}
+
+	names := make([]string, 0, len(choices))
+	for name := range choices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	diags.AddAttributeError(
+		attrPath,
+		"Invalid "+check.title,
+		fmt.Sprintf("%s %q is not available on this host. Available: %s. The %s data source lists them.",
+			check.noun, value.ValueString(), strings.Join(names, ", "), check.dataSource),
+	)
+}
+
+// -- CRUD --
+
+func (r *VMResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VMResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.ensureBridges(ctx, data.NICs); err != nil {
+		addBridgeError(&resp.Diagnostics, err)
+		return
+	}
+
+	vm, err := r.services.VM.CreateVM(ctx, r.buildCreateOpts(&data))
+	if err != nil {
+		addVMError(&resp.Diagnostics, err, vmAPIFieldPaths, "Unable to Create VM", fmt.Sprintf("Unable to create VM %q: %s", data.Name.ValueString(), err.Error()))
+		return
+	}
+	vmID := vm.ID
+	currentState := VMStateStopped
+	data.ID = types.StringValue(strconv.FormatInt(vmID, 10))
+
+	// From here on a failure leaves the VM behind. Mark it in private state
+	// and return it with the error, so Terraform keeps track of it (tainted)
+	// and replaces it on the next apply instead of failing on the duplicate
+	// name.
+	if resp.Private != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, vmCreatePrivateKey, []byte(`{"vm_id":`+data.ID.ValueString()+`}`))...)
+	}
+	defer func() {
+		if resp.Diagnostics.HasError() {
+			r.setPartialCreateState(ctx, &data, resp)
 		}
-		data.USBs[i].DeviceID = types.Int64Value(dev.ID)
+	}()
+
+	if err := r.provisionDiskZvols(ctx, data.Name.ValueString(), data.Disks); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Disk Zvol", err.Error())
+		return
+	}
+
+	// Create devices
+	if err := r.createDevices(ctx, vmID, &data); err != nil {
+		addVMError(&resp.Diagnostics, err, nil, "Unable to Create VM Devices", err.Error())
+		return
+	}
+
+	if err := r.applyRawOptions(ctx, vmID, &data, nil); err != nil {
+		addVMError(&resp.Diagnostics, err, nil, "Unable to Configure VM", err.Error())
+		return
 	}
 
 	// Handle desired state
 	desiredState := data.State.ValueString()
-	if desiredState == VMStateRunning {
-		if err := r.reconcileState(ctx, vmID, VMStateStopped, VMStateRunning); err != nil {
-			resp.Diagnostics.AddError("Unable to Start VM", err.Error())
-			return
-		}
+	if err := r.reconcileState(ctx, vmID, currentState, desiredState, vmStartRetryFromModel(&data)); err != nil {
+		addVMError(&resp.Diagnostics, err, nil, "Unable to Reconcile VM State", err.Error())
+		return
 	}
 
 	// Read back to get final state
@@ -567,14 +951,27 @@ func (r *VMResource) Create(ctx context.Context, req resource.CreateRequest, res
 		resp.Diagnostics.AddError("Unable to Query VM Devices", err.Error())
 		return
 	}
-	priorRaws := data.Raws
+	priorDisks, priorRaws, priorNICs := data.Disks, data.Raws, data.NICs
 	r.mapDevicesToModel(devices, &data)
+	preserveDiskZvol(data.Disks, priorDisks)
 	preserveRawExists(data.Raws, priorRaws)
+	preserveNICCreateBridge(data.NICs, priorNICs)
+	if err := r.setDisplayWebURLs(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to Read UI Settings", err.Error())
+		return
+	}
+	if err := r.readRawOptions(ctx, vmID, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to Read VM Options", err.Error())
+		return
+	}
 
 	// Restore desired state (mapVMToModel sets state from API status)
 	data.State = types.StringValue(desiredState)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Private != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, vmCreatePrivateKey, nil)...)
+	}
 }
 
 func (r *VMResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -611,9 +1008,19 @@ func (r *VMResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		resp.Diagnostics.AddError("Unable to Query VM Devices", err.Error())
 		return
 	}
-	priorRaws := data.Raws
+	priorDisks, priorRaws, priorNICs := data.Disks, data.Raws, data.NICs
 	r.mapDevicesToModel(devices, &data)
+	preserveDiskZvol(data.Disks, priorDisks)
 	preserveRawExists(data.Raws, priorRaws)
+	preserveNICCreateBridge(data.NICs, priorNICs)
+	if err := r.setDisplayWebURLs(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to Read UI Settings", err.Error())
+		return
+	}
+	if err := r.readRawOptions(ctx, vmID, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to Read VM Options", err.Error())
+		return
+	}
 
 	// Restore desired state from prior state (user-specified)
 	if !priorState.IsNull() && !priorState.IsUnknown() {
@@ -621,6 +1028,7 @@ func (r *VMResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *VMResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -647,14 +1055,41 @@ func (r *VMResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if changed {
 		_, err := r.services.VM.UpdateVM(ctx, vmID, *updateOpts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to Update VM", err.Error())
+			addVMError(&resp.Diagnostics, err, vmAPIFieldPaths, "Unable to Update VM", err.Error())
 			return
 		}
 	}
 
+	if err := r.ensureBridges(ctx, data.NICs); err != nil {
+		addBridgeError(&resp.Diagnostics, err)
+		return
+	}
+
+	if err := r.provisionDiskZvols(ctx, data.Name.ValueString(), data.Disks); err != nil {
+		resp.Diagnostics.AddError("Unable to Create Disk Zvol", err.Error())
+		return
+	}
+	if err := r.resizeDiskZvols(ctx, data.Disks, stateData.Disks); err != nil {
+		resp.Diagnostics.AddError("Unable to Resize Disk Zvol", err.Error())
+		return
+	}
+
 	// Reconcile devices
 	if err := r.reconcileDevices(ctx, vmID, &data, &stateData); err != nil {
-		resp.Diagnostics.AddError("Unable to Update VM Devices", err.Error())
+		addVMError(&resp.Diagnostics, err, nil, "Unable to Update VM Devices", err.Error())
+		return
+	}
+
+	// Zvols of removed disks go once their devices are gone
+	keep := make(map[int64]bool)
+	collectDeviceIDs(keep, &data)
+	if err := r.deleteManagedZvols(ctx, stateData.Disks, keep); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete Disk Zvol", err.Error())
+		return
+	}
+
+	if err := r.applyRawOptions(ctx, vmID, &data, &stateData); err != nil {
+		addVMError(&resp.Diagnostics, err, nil, "Unable to Configure VM", err.Error())
 		return
 	}
 
@@ -668,8 +1103,8 @@ func (r *VMResource) Update(ctx context.Context, req resource.UpdateRequest, res
 			resp.Diagnostics.AddError("Unable to Query VM State", err.Error())
 			return
 		}
-		if err := r.reconcileState(ctx, vmID, vm.State, desiredState); err != nil {
-			resp.Diagnostics.AddError("Unable to Reconcile VM State", err.Error())
+		if err := r.reconcileState(ctx, vmID, vm.State, desiredState, vmStartRetryFromModel(&data)); err != nil {
+			addVMError(&resp.Diagnostics, err, nil, "Unable to Reconcile VM State", err.Error())
 			return
 		}
 	}
@@ -687,9 +1122,19 @@ func (r *VMResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		resp.Diagnostics.AddError("Unable to Query VM Devices", err.Error())
 		return
 	}
-	priorRaws := data.Raws
+	priorDisks, priorRaws, priorNICs := data.Disks, data.Raws, data.NICs
 	r.mapDevicesToModel(devices, &data)
+	preserveDiskZvol(data.Disks, priorDisks)
 	preserveRawExists(data.Raws, priorRaws)
+	preserveNICCreateBridge(data.NICs, priorNICs)
+	if err := r.setDisplayWebURLs(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to Read UI Settings", err.Error())
+		return
+	}
+	if err := r.readRawOptions(ctx, vmID, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to Read VM Options", err.Error())
+		return
+	}
 
 	// Restore desired state
 	data.State = types.StringValue(desiredState)
@@ -721,18 +1166,204 @@ func (r *VMResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 	}
 
 	if vm.State == VMStateRunning {
-		err := r.services.VM.StopVM(ctx, vmID, truenas.StopVMOpts{Force: true, ForceAfterTimeout: true})
-		if err != nil {
+		if err := r.stopVMForDelete(ctx, vmID, data.ForceStopAfter); err != nil {
 			resp.Diagnostics.AddError("Unable to Stop VM", fmt.Sprintf("Unable to stop VM before delete: %s", err.Error()))
 			return
 		}
 	}
 
-	// Delete the VM
-	err = r.services.VM.DeleteVM(ctx, vmID)
+	// Delete the VM, and its zvols if requested (not exposed by VMService)
+	if data.DeleteZvols.ValueBool() {
+		_, err = r.client.Call(ctx, "vm.delete", []any{vmID, map[string]any{"zvols": true, "force": false}})
+	} else {
+		err = r.services.VM.DeleteVM(ctx, vmID)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Delete VM", err.Error())
 		return
 	}
+
+	if !data.DeleteZvols.ValueBool() {
+		if err := r.deleteManagedZvols(ctx, data.Disks, nil); err != nil {
+			resp.Diagnostics.AddError("Unable to Delete Disk Zvol", err.Error())
+			return
+		}
+	}
+}
+
+func (r *VMResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := importName(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	vm, err := r.findVMByName(ctx, name)
+	if err == nil && vm == nil {
+		err = fmt.Errorf("no VM named %q", name)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import VM",
+			fmt.Sprintf("Unable to look up VM %q: %s", name, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(vm.ID, 10))...)
+}
+
+// systemGeneralUIResponse is the subset of system.general.config fields used
+// to build display web client URLs.
+type systemGeneralUIResponse struct {
+	UIHTTPSRedirect bool `json:"ui_httpsredirect"`
+}
+
+// setDisplayWebURLs sets web_url on display devices with the web client
+// enabled. The scheme follows the UI's HTTPS redirect setting, and the host is
+// the display's bind address unless it binds to all interfaces, in which case
+// the provider host is used.
+func (r *VMResource) setDisplayWebURLs(ctx context.Context, data *VMResourceModel) error {
+	scheme := ""
+	for i := range data.Displays {
+		d := &data.Displays[i]
+		d.WebURL = types.StringNull()
+		if !d.Web.ValueBool() || d.WebPort.ValueInt64() == 0 {
+			continue
+		}
+
+		host := d.Bind.ValueString()
+		if host == "" || host == "0.0.0.0" || host == "::" {
+			host = r.services.Host
+		}
+		if host == "" {
+			continue
+		}
+
+		if scheme == "" {
+			result, err := r.client.Call(ctx, "system.general.config", nil)
+			if err != nil {
+				return fmt.Errorf("unable to read system.general config: %w", err)
+			}
+			var ui systemGeneralUIResponse
+			if err := json.Unmarshal(result, &ui); err != nil {
+				return fmt.Errorf("parse system.general config response: %w", err)
+			}
+			scheme = "http"
+			if ui.UIHTTPSRedirect {
+				scheme = "https"
+			}
+		}
+
+		d.WebURL = types.StringValue(displayWebURL(scheme, host, d.Type.ValueString(), d.WebPort.ValueInt64()))
+	}
+	return nil
 }
 
+// displayWebURL builds the web client URL of a display device.
+func displayWebURL(scheme, host, displayType string, webPort int64) string {
+	port := strconv.FormatInt(webPort, 10)
+	base := scheme + "://" + net.JoinHostPort(host, port)
+	if displayType == "VNC" {
+		return base + "/vnc.html?autoconnect=1"
+	}
+	query := url.Values{"host": {host}, "port": {port}}
+	return base + "/spice_auto.html?" + query.Encode()
+}
+
+// applyRawOptions sets the VM's trusted_platform_module flag, domain UUID,
+// cpuset and nodeset when they are configured and differ from state. None of
+// them is part of the client's VM options, so they are sent with a raw
+// vm.update.
+func (r *VMResource) applyRawOptions(ctx context.Context, vmID int64, plan, state *VMResourceModel) error {
+	opts := map[string]any{}
+	if !plan.TPM.IsNull() && !plan.TPM.IsUnknown() && (state == nil || !state.TPM.Equal(plan.TPM)) {
+		opts["trusted_platform_module"] = plan.TPM.ValueBool()
+	}
+	if !plan.UUID.IsNull() && !plan.UUID.IsUnknown() && (state == nil || !state.UUID.Equal(plan.UUID)) {
+		if err := r.checkUUIDAvailable(ctx, vmID, plan.UUID.ValueString()); err != nil {
+			return err
+		}
+		opts["uuid"] = plan.UUID.ValueString()
+	}
+	if !plan.CPUSet.IsNull() && !plan.CPUSet.IsUnknown() && (state == nil || !state.CPUSet.Equal(plan.CPUSet)) {
+		opts["cpuset"] = plan.CPUSet.ValueString()
+	}
+	if !plan.NodeSet.IsNull() && !plan.NodeSet.IsUnknown() && (state == nil || !state.NodeSet.Equal(plan.NodeSet)) {
+		opts["nodeset"] = plan.NodeSet.ValueString()
+	}
+	if len(opts) == 0 {
+		return nil
+	}
+
+	if _, err := r.client.Call(ctx, "vm.update", []any{vmID, opts}); err != nil {
+		return fmt.Errorf("unable to update VM %d: %w", vmID, err)
+	}
+	return nil
+}
+
+// checkUUIDAvailable fails when another VM already uses uuid. libvirt refuses
+// to define two domains with one UUID, which is what a create_before_destroy
+// replacement that keeps the UUID would otherwise run into at start.
+func (r *VMResource) checkUUIDAvailable(ctx context.Context, vmID int64, uuid string) error {
+	filter := []any{[]any{[]any{"uuid", "=", uuid}, []any{"id", "!=", vmID}}}
+	result, err := r.client.Call(ctx, "vm.query", filter)
+	if err != nil {
+		return fmt.Errorf("unable to query VMs by UUID: %w", err)
+	}
+
+	var vms []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(result, &vms); err != nil {
+		return fmt.Errorf("parse VM query response: %w", err)
+	}
+	if len(vms) > 0 {
+		return fmt.Errorf("uuid %s is already used by VM %q (ID %d); two VMs cannot share a UUID, so a "+
+			"replacement that keeps the UUID cannot use create_before_destroy", uuid, vms[0].Name, vms[0].ID)
+	}
+	return nil
+}
+
+// readRawOptions refreshes tpm, uuid, cpuset and nodeset from vm.query. Each
+// is only read when configured, so an unmanaged setting does not show up as
+// drift.
+func (r *VMResource) readRawOptions(ctx context.Context, vmID int64, data *VMResourceModel) error {
+	if data.TPM.IsNull() && data.UUID.IsNull() && data.CPUSet.IsNull() && data.NodeSet.IsNull() {
+		return nil
+	}
+
+	filter := []any{[]any{[]any{"id", "=", vmID}}}
+	result, err := r.client.Call(ctx, "vm.query", filter)
+	if err != nil {
+		return fmt.Errorf("unable to query VM %d: %w", vmID, err)
+	}
+
+	var vms []struct {
+		TrustedPlatformModule bool   `json:"trusted_platform_module"`
+		UUID                  string `json:"uuid"`
+		CPUSet                string `json:"cpuset"`
+		NodeSet               string `json:"nodeset"`
+	}
+	if err := json.Unmarshal(result, &vms); err != nil {
+		return fmt.Errorf("parse VM query response: %w", err)
+	}
+	if len(vms) == 0 {
+		return fmt.Errorf("VM %d not found", vmID)
+	}
+
+	if !data.TPM.IsNull() {
+		data.TPM = types.BoolValue(vms[0].TrustedPlatformModule)
+	}
+	if !data.UUID.IsNull() {
+		data.UUID = types.StringValue(vms[0].UUID)
+	}
+	if !data.CPUSet.IsNull() {
+		data.CPUSet = types.StringValue(vms[0].CPUSet)
+	}
+	if !data.NodeSet.IsNull() {
+		data.NodeSet = types.StringValue(vms[0].NodeSet)
+	}
+	return nil
+}
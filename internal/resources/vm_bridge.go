@@ -0,0 +1,185 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// bridgeCheckinTimeout is the number of seconds TrueNAS waits for a
+// committed network change to be checked in before rolling it back.
+const bridgeCheckinTimeout = 60
+
+// pendingNetworkChangesError reports network changes that were staged on
+// TrueNAS before a bridge could be created, typically by an apply that failed
+// part way through or by an unfinished edit in the web UI.
+type pendingNetworkChangesError struct {
+	bridge string
+	// checkinSeconds is the time left before TrueNAS reverts changes that
+	// were committed but not checked in, or nil if none are waiting.
+	checkinSeconds *int64
+}
+
+func (e *pendingNetworkChangesError) Error() string {
+	if e.checkinSeconds != nil {
+		return fmt.Sprintf("cannot create bridge %q: TrueNAS has uncommitted network changes that are "+
+			"waiting to be checked in and will be reverted in %d seconds. Confirm them under Network with "+
+			"\"Save Changes\" or wait for the revert, then apply again.", e.bridge, *e.checkinSeconds)
+	}
+	return fmt.Sprintf("cannot create bridge %q: TrueNAS has uncommitted network changes, possibly left "+
+		"by an earlier apply that failed. Review them under Network and use \"Test Changes\" and "+
+		"\"Save Changes\" to keep them or \"Revert Changes\" to discard them "+
+		"(or run `midclt call interface.rollback`), then apply again.", e.bridge)
+}
+
+// addBridgeError adds the diagnostic for an ensureBridges failure.
+func addBridgeError(diags *diag.Diagnostics, err error) {
+	var pending *pendingNetworkChangesError
+	if errors.As(err, &pending) {
+		diags.AddError("Uncommitted Network Changes", err.Error())
+		return
+	}
+	diags.AddError("Unable to Create Bridge", err.Error())
+}
+
+// ensureBridges creates the bridges that NICs with create_bridge attach to
+// and that do not exist yet. TrueNAS stages network changes: new bridges are
+// committed with a checkin timeout and then checked in, and staged changes
+// are rolled back if any step fails so nothing is left pending.
+func (r *VMResource) ensureBridges(ctx context.Context, nics []VMNICModel) error {
+	var created []string
+
+	for _, nic := range nics {
+		if nic.CreateBridge == nil || nic.NICAttach.IsNull() || nic.NICAttach.IsUnknown() {
+			continue
+		}
+		name := nic.NICAttach.ValueString()
+		if slices.Contains(created, name) {
+			continue
+		}
+
+		exists, err := r.interfaceExists(ctx, name)
+		if err != nil {
+			return fmt.Errorf("unable to look up interface %q: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+
+		// Committing would also apply changes someone else staged
+		if len(created) == 0 {
+			pending, err := r.hasPendingNetworkChanges(ctx)
+			if err != nil {
+				return fmt.Errorf("unable to check for pending network changes: %w", err)
+			}
+			if pending {
+				return &pendingNetworkChangesError{
+					bridge:         name,
+					checkinSeconds: r.networkCheckinWaiting(ctx),
+				}
+			}
+		}
+
+		var members []string
+		if diags := nic.CreateBridge.Members.ElementsAs(ctx, &members, false); diags.HasError() {
+			return fmt.Errorf("invalid members for bridge %q", name)
+		}
+
+		params := map[string]any{
+			"type":           "BRIDGE",
+			"name":           name,
+			"bridge_members": members,
+		}
+		if !nic.CreateBridge.STP.IsNull() && !nic.CreateBridge.STP.IsUnknown() {
+			params["stp"] = nic.CreateBridge.STP.ValueBool()
+		}
+
+		if _, err := r.client.Call(ctx, "interface.create", params); err != nil {
+			r.rollbackNetworkChanges(ctx)
+			return fmt.Errorf("unable to create bridge %q: %w", name, err)
+		}
+		created = append(created, name)
+	}
+
+	if len(created) == 0 {
+		return nil
+	}
+
+	if _, err := r.client.Call(ctx, "interface.commit", map[string]any{"checkin_timeout": bridgeCheckinTimeout}); err != nil {
+		r.rollbackNetworkChanges(ctx)
+		return fmt.Errorf("unable to commit bridges %v: %w", created, err)
+	}
+	if _, err := r.client.Call(ctx, "interface.checkin", nil); err != nil {
+		return fmt.Errorf("unable to check in bridges %v; TrueNAS rolls them back within %d seconds: %w",
+			created, bridgeCheckinTimeout, err)
+	}
+
+	tflog.Info(ctx, "Created bridges for VM NICs", map[string]any{
+		"bridges": created,
+	})
+	return nil
+}
+
+// interfaceExists reports whether a network interface with name exists.
+func (r *VMResource) interfaceExists(ctx context.Context, name string) (bool, error) {
+	result, err := r.client.Call(ctx, "interface.query", []any{[]any{[]any{"name", "=", name}}})
+	if err != nil {
+		return false, err
+	}
+
+	var interfaces []json.RawMessage
+	if err := json.Unmarshal(result, &interfaces); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return len(interfaces) > 0, nil
+}
+
+// hasPendingNetworkChanges reports whether network changes are staged but
+// not yet committed.
+func (r *VMResource) hasPendingNetworkChanges(ctx context.Context) (bool, error) {
+	result, err := r.client.Call(ctx, "interface.has_pending_changes", nil)
+	if err != nil {
+		return false, err
+	}
+
+	var pending bool
+	if err := json.Unmarshal(result, &pending); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return pending, nil
+}
+
+// networkCheckinWaiting returns the seconds left before TrueNAS reverts
+// committed network changes that were not checked in, or nil if none are
+// waiting. It is only used to word an error, so lookup failures are ignored.
+func (r *VMResource) networkCheckinWaiting(ctx context.Context) *int64 {
+	result, err := r.client.Call(ctx, "interface.checkin_waiting", nil)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to check for network changes awaiting checkin", map[string]any{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	var remaining *int64
+	if err := json.Unmarshal(result, &remaining); err != nil {
+		return nil
+	}
+	return remaining
+}
+
+// rollbackNetworkChanges discards staged network changes after a failure.
+// Errors are only logged since the original failure is what gets reported.
+func (r *VMResource) rollbackNetworkChanges(ctx context.Context) {
+	if _, err := r.client.Call(ctx, "interface.rollback", nil); err != nil {
+		tflog.Warn(ctx, "Unable to roll back network changes", map[string]any{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// displayPort is a port or web_port set on a display block.
+type displayPort struct {
+	index int
+	attr  string
+	port  int64
+}
+
+func (p displayPort) path() path.Path {
+	return path.Root("display").AtListIndex(p.index).AtName(p.attr)
+}
+
+// configuredDisplayPorts returns the known ports and web ports of displays,
+// in block order.
+func configuredDisplayPorts(displays []VMDisplayModel) []displayPort {
+	var ports []displayPort
+	for i, d := range displays {
+		if !d.Port.IsNull() && !d.Port.IsUnknown() {
+			ports = append(ports, displayPort{index: i, attr: "port", port: d.Port.ValueInt64()})
+		}
+		if !d.WebPort.IsNull() && !d.WebPort.IsUnknown() {
+			ports = append(ports, displayPort{index: i, attr: "web_port", port: d.WebPort.ValueInt64()})
+		}
+	}
+	return ports
+}
+
+// validateDisplayPorts reports ports set more than once across the display
+// blocks of one VM.
+func validateDisplayPorts(displays []VMDisplayModel, diags *diag.Diagnostics) {
+	seen := make(map[int64]displayPort)
+	for _, p := range configuredDisplayPorts(displays) {
+		if first, ok := seen[p.port]; ok {
+			diags.AddAttributeError(p.path(), "Conflicting Display Port",
+				fmt.Sprintf("Port %d is also used by display[%d].%s. Each display needs its own port and web_port.",
+					p.port, first.index, first.attr))
+			continue
+		}
+		seen[p.port] = p
+	}
+}
+
+// checkDisplayPorts fails the plan when a display is given a port that a
+// display of another VM or an app already uses on the host. Ports the VM
+// already has in state are its own and are not checked. VMs planned in the
+// same run cannot see each other, so collisions between them are left to
+// vm.device.create.
+func (r *VMResource) checkDisplayPorts(ctx context.Context, plan, state *VMResourceModel, diags *diag.Diagnostics) {
+	own := make(map[int64]bool)
+	if state != nil {
+		for _, p := range configuredDisplayPorts(state.Displays) {
+			own[p.port] = true
+		}
+	}
+
+	var ports []displayPort
+	for _, p := range configuredDisplayPorts(plan.Displays) {
+		if !own[p.port] {
+			ports = append(ports, p)
+		}
+	}
+	if len(ports) == 0 {
+		return
+	}
+
+	used, err := r.usedDisplayPorts(ctx, plan)
+	if err != nil {
+		diags.AddWarning("Unable to Validate Display Ports",
+			fmt.Sprintf("Unable to read the ports in use on the host: %s", err.Error()))
+		return
+	}
+
+	for _, p := range ports {
+		if owner, ok := used[p.port]; ok {
+			diags.AddAttributeError(p.path(), "Display Port In Use",
+				fmt.Sprintf("Port %d is already used by %s. Choose another port, or leave it unset to have TrueNAS pick a free one.",
+					p.port, owner))
+		}
+	}
+}
+
+// usedDisplayPorts maps the ports used on the host by the displays of VMs
+// other than plan, and by apps, to a description of their user.
+func (r *VMResource) usedDisplayPorts(ctx context.Context, plan *VMResourceModel) (map[int64]string, error) {
+	filter := []any{[]any{[]any{"attributes.dtype", "=", string(truenas.DeviceTypeDisplay)}}}
+	result, err := r.client.Call(ctx, "vm.device.query", filter)
+	if err != nil {
+		return nil, fmt.Errorf("vm.device.query: %w", err)
+	}
+	var devices []truenas.VMDeviceResponse
+	if err := json.Unmarshal(result, &devices); err != nil {
+		return nil, fmt.Errorf("parse vm.device.query response: %w", err)
+	}
+
+	used := make(map[int64]string)
+	for _, dev := range devices {
+		if !plan.ID.IsNull() && !plan.ID.IsUnknown() && strconv.FormatInt(dev.VM, 10) == plan.ID.ValueString() {
+			continue
+		}
+		for _, attr := range []string{"port", "web_port"} {
+			if port, ok := dev.Attributes[attr].(float64); ok && port > 0 {
+				used[int64(port)] = fmt.Sprintf("the display of VM %d", dev.VM)
+			}
+		}
+	}
+
+	result, err = r.client.Call(ctx, "app.used_ports", nil)
+	if err != nil {
+		return nil, fmt.Errorf("app.used_ports: %w", err)
+	}
+	var appPorts []int64
+	if err := json.Unmarshal(result, &appPorts); err != nil {
+		return nil, fmt.Errorf("parse app.used_ports response: %w", err)
+	}
+	for _, port := range appPorts {
+		if _, ok := used[port]; !ok {
+			used[port] = "an app"
+		}
+	}
+	return used, nil
+}
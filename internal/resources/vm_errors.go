@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// vmHostCapabilityError describes a VM failure caused by missing host support
+// rather than by the configuration, recognized by the middleware's message.
+type vmHostCapabilityError struct {
+	patterns  []string // lower-case substrings of the middleware error
+	attribute string   // attribute to scope the diagnostic to, or "" for none
+	summary   string
+	hint      string
+}
+
+var vmHostCapabilityErrors = []vmHostCapabilityError{
+	{
+		patterns: []string{"does not support virtualization", "kvm is not available", "requires kvm"},
+		summary:  "Virtualization Not Available",
+		hint: "The TrueNAS host cannot run VMs. Enable hardware virtualization (Intel VT-x or AMD-V) in the " +
+			"system firmware. If TrueNAS itself runs in a VM, enable nested virtualization on the hypervisor. " +
+			"vm.virtualization_details reports why virtualization is unavailable.",
+	},
+	{
+		patterns:  []string{"iommu"},
+		attribute: "pci",
+		summary:   "IOMMU Not Enabled",
+		hint: "PCI passthrough requires an IOMMU. Enable Intel VT-d or AMD-Vi in the system firmware and " +
+			"reboot, or remove the pci blocks from the VM.",
+	},
+	{
+		patterns:  []string{"not available for pci passthru", "not available for passthru"},
+		attribute: "pci",
+		summary:   "PCI Device Not Available for Passthrough",
+		hint: "The device is in use by the host or shares an IOMMU group with devices that are. Isolate it " +
+			"(System > Advanced > Isolated GPU Device(s) for GPUs) or choose another device; vm.device.passthrough_device_choices " +
+			"lists the devices that can be passed through.",
+	},
+}
+
+// matchVMHostCapabilityError returns the host capability error err was caused by, if any.
+func matchVMHostCapabilityError(err error) (*vmHostCapabilityError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	msg := strings.ToLower(err.Error())
+	for i := range vmHostCapabilityErrors {
+		for _, pattern := range vmHostCapabilityErrors[i].patterns {
+			if strings.Contains(msg, pattern) {
+				return &vmHostCapabilityErrors[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// vmOutOfMemoryError is returned when vm.start fails because the host cannot
+// guarantee the VM's memory, together with what the host has left for guests.
+type vmOutOfMemoryError struct {
+	err       error
+	available *int64 // bytes reported by vm.get_available_memory, nil if unknown
+}
+
+func (e *vmOutOfMemoryError) Error() string { return e.err.Error() }
+
+func (e *vmOutOfMemoryError) Unwrap() error { return e.err }
+
+// isVMOutOfMemoryError reports whether err is vm.start refusing to run a guest
+// the host does not have enough free memory for.
+func isVMOutOfMemoryError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "enomem") ||
+		strings.Contains(msg, "cannot guarantee memory") ||
+		strings.Contains(msg, "not enough memory") ||
+		strings.Contains(msg, "insufficient memory")
+}
+
+// addVMError reports err from a VM operation. Failures caused by missing host
+// support get a targeted summary and a remediation hint, and a start refused
+// for lack of memory is reported against memory with the host's free memory;
+// anything else is reported through addAPIError.
+func addVMError(diags *diag.Diagnostics, err error, paths map[string]path.Path, summary, detail string) {
+	var oom *vmOutOfMemoryError
+	if errors.As(err, &oom) {
+		available := "vm.get_available_memory could not be queried."
+		if oom.available != nil {
+			available = fmt.Sprintf("vm.get_available_memory reports %d MiB available to guests.", *oom.available/(1<<20))
+		}
+		diags.AddAttributeError(path.Root("memory"), "Insufficient Memory to Start VM", fmt.Sprintf(
+			"%s\n\n%s Free memory by stopping other VMs or apps, lower memory (or set min_memory to allow "+
+				"ballooning), or set start_retries to wait for memory to become available.", detail, available))
+		return
+	}
+
+	capability, ok := matchVMHostCapabilityError(err)
+	if !ok {
+		addAPIError(diags, err, paths, summary, detail)
+		return
+	}
+
+	detail = fmt.Sprintf("%s\n\n%s", detail, capability.hint)
+	if capability.attribute != "" {
+		diags.AddAttributeError(path.Root(capability.attribute), capability.summary, detail)
+		return
+	}
+	diags.AddError(capability.summary, detail)
+}
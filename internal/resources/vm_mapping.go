@@ -3,7 +3,9 @@ package resources
 import (
 	"strconv"
 
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
 	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -15,17 +17,17 @@ func (r *VMResource) mapVMToModel(vm *truenas.VM, data *VMResourceModel) {
 	data.VCPUs = types.Int64Value(vm.VCPUs)
 	data.Cores = types.Int64Value(vm.Cores)
 	data.Threads = types.Int64Value(vm.Threads)
-	data.Memory = types.Int64Value(vm.Memory)
+	data.Memory = customtypes.NewMemoryMiBValue(customtypes.NormalizeMemoryMiB(vm.Memory))
 	if vm.MinMemory != nil {
-		data.MinMemory = types.Int64Value(*vm.MinMemory)
+		data.MinMemory = customtypes.NewMemoryMiBValue(customtypes.NormalizeMemoryMiB(*vm.MinMemory))
 	} else {
-		data.MinMemory = types.Int64Null()
+		data.MinMemory = customtypes.NewMemoryMiBNull()
 	}
 	data.Autostart = types.BoolValue(vm.Autostart)
-	data.Time = types.StringValue(vm.Time)
-	data.Bootloader = types.StringValue(vm.Bootloader)
+	data.Time = customtypes.NewCaseInsensitiveStringValue(vm.Time)
+	data.Bootloader = customtypes.NewCaseInsensitiveStringValue(vm.Bootloader)
 	data.BootloaderOVMF = types.StringValue(vm.BootloaderOVMF)
-	data.CPUMode = types.StringValue(vm.CPUMode)
+	data.CPUMode = customtypes.NewCaseInsensitiveStringValue(vm.CPUMode)
 	if vm.CPUModel != "" {
 		data.CPUModel = types.StringValue(vm.CPUModel)
 	} else {
@@ -38,7 +40,9 @@ func (r *VMResource) mapVMToModel(vm *truenas.VM, data *VMResourceModel) {
 }
 
 // mapDevicesToModel maps truenas.VMDevice slices to the resource model.
+// Devices already in data keep their position in their block.
 func (r *VMResource) mapDevicesToModel(devices []truenas.VMDevice, data *VMResourceModel) {
+	prior := *data
 	data.Disks = nil
 	data.Raws = nil
 	data.CDROMs = nil
@@ -65,11 +69,106 @@ func (r *VMResource) mapDevicesToModel(devices []truenas.VMDevice, data *VMResou
 			data.USBs = append(data.USBs, mapUSBDevice(dev))
 		}
 	}
+
+	orderDevicesLikePrior(data, &prior)
+	data.DeviceIDsByLabel = deviceIDsByLabel(data)
+}
+
+// deviceIDsByLabel maps a label for each device, its block name and index
+// within the block (e.g. "disk.0", "nic.1"), to its device ID. The map is
+// unknown while any device has yet to be assigned an ID.
+func deviceIDsByLabel(data *VMResourceModel) types.Map {
+	ids := make(map[string]attr.Value)
+	known := addDeviceLabels(ids, "disk", data.Disks, func(d VMDiskModel) types.Int64 { return d.DeviceID }) &&
+		addDeviceLabels(ids, "raw", data.Raws, func(d VMRawModel) types.Int64 { return d.DeviceID }) &&
+		addDeviceLabels(ids, "cdrom", data.CDROMs, func(d VMCDROMModel) types.Int64 { return d.DeviceID }) &&
+		addDeviceLabels(ids, "nic", data.NICs, func(d VMNICModel) types.Int64 { return d.DeviceID }) &&
+		addDeviceLabels(ids, "display", data.Displays, func(d VMDisplayModel) types.Int64 { return d.DeviceID }) &&
+		addDeviceLabels(ids, "pci", data.PCIs, func(d VMPCIModel) types.Int64 { return d.DeviceID }) &&
+		addDeviceLabels(ids, "usb", data.USBs, func(d VMUSBModel) types.Int64 { return d.DeviceID })
+	if !known {
+		return types.MapUnknown(types.Int64Type)
+	}
+	return types.MapValueMust(types.Int64Type, ids)
+}
+
+// addDeviceLabels adds the devices of one block to ids and reports whether
+// every device ID was known.
+func addDeviceLabels[T any](ids map[string]attr.Value, block string, devices []T, id func(T) types.Int64) bool {
+	for i, d := range devices {
+		deviceID := id(d)
+		if deviceID.IsNull() || deviceID.IsUnknown() {
+			return false
+		}
+		ids[block+"."+strconv.Itoa(i)] = deviceID
+	}
+	return true
+}
+
+// preserveDiskZvol copies the zvol settings (size, zvol_name, pool,
+// manage_zvol and delete_backing_storage) from prior disks to mapped ones.
+// They are provider-side configuration with no counterpart on the device,
+// matched the same way as preserveRawExists. A zvol_name left unknown by the plan becomes null.
+func preserveDiskZvol(mapped, prior []VMDiskModel) {
+	priorByID := make(map[int64]VMDiskModel)
+	for _, p := range prior {
+		if !p.DeviceID.IsNull() && !p.DeviceID.IsUnknown() {
+			priorByID[p.DeviceID.ValueInt64()] = p
+		}
+	}
+
+	for i := range mapped {
+		p, ok := VMDiskModel{}, false
+		if !mapped[i].DeviceID.IsNull() && !mapped[i].DeviceID.IsUnknown() {
+			p, ok = priorByID[mapped[i].DeviceID.ValueInt64()]
+		}
+		if !ok && i < len(prior) {
+			p, ok = prior[i], true
+		}
+		if !ok {
+			continue
+		}
+
+		mapped[i].Size = p.Size
+		mapped[i].ZvolName = p.ZvolName
+		mapped[i].Pool = p.Pool
+		mapped[i].ManageZvol = p.ManageZvol
+		mapped[i].DeleteBackingStorage = p.DeleteBackingStorage
+		if p.ZvolName.IsUnknown() {
+			mapped[i].ZvolName = types.StringNull()
+		}
+	}
+}
+
+// preserveNICCreateBridge copies create_bridge from prior NICs to mapped ones.
+// create_bridge is provider-side configuration with no counterpart on the
+// device, matched the same way as preserveRawExists.
+func preserveNICCreateBridge(mapped, prior []VMNICModel) {
+	priorByID := make(map[int64]VMNICModel)
+	for _, p := range prior {
+		if !p.DeviceID.IsNull() && !p.DeviceID.IsUnknown() {
+			priorByID[p.DeviceID.ValueInt64()] = p
+		}
+	}
+
+	for i := range mapped {
+		if !mapped[i].DeviceID.IsNull() && !mapped[i].DeviceID.IsUnknown() {
+			if p, ok := priorByID[mapped[i].DeviceID.ValueInt64()]; ok {
+				mapped[i].CreateBridge = p.CreateBridge
+				continue
+			}
+		}
+		if i < len(prior) {
+			mapped[i].CreateBridge = prior[i].CreateBridge
+		}
+	}
 }
 
-// preserveRawExists copies the exists attribute from prior RAW devices to mapped ones.
-// exists is a create-time API flag not returned in query responses, so it must be
-// preserved from the plan/state to avoid inconsistent results after apply.
+// preserveRawExists copies the exists and delete_backing_storage attributes from
+// prior RAW devices to mapped ones. exists is a create-time API flag not returned in
+// query responses, and delete_backing_storage only applies when the device is
+// deleted, so both must be preserved from the plan/state to avoid inconsistent
+// results after apply.
 func preserveRawExists(mapped, prior []VMRawModel) {
 	// Build lookup by device ID
 	priorByID := make(map[int64]VMRawModel)
@@ -83,12 +182,14 @@ func preserveRawExists(mapped, prior []VMRawModel) {
 		if !mapped[i].DeviceID.IsNull() && !mapped[i].DeviceID.IsUnknown() {
 			if p, ok := priorByID[mapped[i].DeviceID.ValueInt64()]; ok {
 				mapped[i].Exists = p.Exists
+				mapped[i].DeleteBackingStorage = p.DeleteBackingStorage
 				continue
 			}
 		}
 		// Fallback: match by index for newly created devices
 		if i < len(prior) {
 			mapped[i].Exists = prior[i].Exists
+			mapped[i].DeleteBackingStorage = prior[i].DeleteBackingStorage
 		}
 	}
 }
@@ -99,7 +200,7 @@ func mapDiskDevice(dev truenas.VMDevice) VMDiskModel {
 		Order:    types.Int64Value(dev.Order),
 	}
 	if dev.Disk != nil {
-		m.Path = nonEmptyStringValue(dev.Disk.Path)
+		m.Path = customtypes.FilesystemPathValue{StringValue: nonEmptyStringValue(dev.Disk.Path)}
 		m.Type = nonEmptyStringValue(dev.Disk.Type)
 		m.IOType = nonEmptyStringValue(dev.Disk.IOType)
 		m.Serial = nonEmptyStringValue(dev.Disk.Serial)
@@ -115,7 +216,7 @@ func mapRawDevice(dev truenas.VMDevice) VMRawModel {
 		Order:    types.Int64Value(dev.Order),
 	}
 	if dev.Raw != nil {
-		m.Path = nonEmptyStringValue(dev.Raw.Path)
+		m.Path = customtypes.FilesystemPathValue{StringValue: nonEmptyStringValue(dev.Raw.Path)}
 		m.Type = nonEmptyStringValue(dev.Raw.Type)
 		m.IOType = nonEmptyStringValue(dev.Raw.IOType)
 		m.Serial = nonEmptyStringValue(dev.Raw.Serial)
@@ -134,7 +235,7 @@ func mapCDROMDevice(dev truenas.VMDevice) VMCDROMModel {
 		Order:    types.Int64Value(dev.Order),
 	}
 	if dev.CDROM != nil {
-		m.Path = nonEmptyStringValue(dev.CDROM.Path)
+		m.Path = customtypes.FilesystemPathValue{StringValue: nonEmptyStringValue(dev.CDROM.Path)}
 	}
 	return m
 }
@@ -168,6 +269,7 @@ func mapDisplayDevice(dev truenas.VMDevice) VMDisplayModel {
 		m.Password = nonEmptyStringValue(dev.Display.Password)
 		m.Web = types.BoolValue(dev.Display.Web)
 	}
+	m.WebURL = types.StringNull()
 	return m
 }
 
@@ -0,0 +1,327 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &VMMigrationResource{}
+var _ resource.ResourceWithConfigure = &VMMigrationResource{}
+var _ resource.ResourceWithValidateConfig = &VMMigrationResource{}
+
+// VMMigrationResource defines the resource implementation.
+type VMMigrationResource struct {
+	BaseResource
+}
+
+// VMMigrationResourceModel describes the resource data model.
+type VMMigrationResourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	Definition types.String            `tfsdk:"definition"`
+	Name       types.String            `tfsdk:"name"`
+	ZvolMap    map[string]types.String `tfsdk:"zvol_map"`
+}
+
+// NewVMMigrationResource creates a new VMMigrationResource.
+func NewVMMigrationResource() resource.Resource {
+	return &VMMigrationResource{}
+}
+
+func (r *VMMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_migration"
+}
+
+func (r *VMMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Recreates a VM exported by the truenas_vm_definition data source on this provider's host, " +
+			"rewriting disk zvol paths through zvol_map. Use a provider alias for each host. Disk data is not " +
+			"copied: replicate the zvols to this host first. Any change recreates the VM; destroying the resource " +
+			"deletes the VM but not its zvols.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the VM on this host.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"definition": schema.StringAttribute{
+				Description: "VM definition JSON from the truenas_vm_definition data source on the source host.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the VM on this host. Defaults to the name in the definition.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zvol_map": schema.MapAttribute{
+				Description: "Zvols or parent datasets on the source host mapped to their counterparts on this host " +
+					"(e.g., \"tank/vms\" = \"fast/vms\"). The longest matching entry rewrites each disk's zvol; " +
+					"disks that match no entry keep their path.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *VMMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data VMMigrationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The definition is usually unknown until the data source is read
+	if data.Definition.IsUnknown() || data.Definition.IsNull() {
+		return
+	}
+	if _, err := parseVMDefinition(data.Definition.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Invalid VM Definition",
+			err.Error(),
+		)
+	}
+}
+
+func (r *VMMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VMMigrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	def, err := parseVMDefinition(data.Definition.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid VM Definition", err.Error())
+		return
+	}
+
+	zvolMap := make(map[string]string, len(data.ZvolMap))
+	for k, v := range data.ZvolMap {
+		zvolMap[k] = v.ValueString()
+	}
+	zvols := rewriteVMDefinitionZvols(def, zvolMap)
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		def["name"] = data.Name.ValueString()
+	}
+
+	// Fail before creating anything if a disk has nothing to attach
+	for _, name := range zvols {
+		existing, err := r.services.Dataset.GetZvol(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Look Up Zvol", fmt.Sprintf("Unable to look up zvol %q: %s", name, err.Error()))
+			return
+		}
+		if existing == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("zvol_map"),
+				"Zvol Not Found",
+				fmt.Sprintf("Zvol %q does not exist on this host. Replicate it here or map it with zvol_map.", name),
+			)
+			return
+		}
+	}
+
+	result, err := r.client.Call(ctx, "vm.create", def)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create VM", fmt.Sprintf("Unable to recreate VM %q: %s", def["name"], err.Error()))
+		return
+	}
+
+	var created struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(result, &created); err != nil {
+		resp.Diagnostics.AddError("Unable to Parse Response", fmt.Sprintf("Unable to parse VM create response: %s", err.Error()))
+		return
+	}
+	tflog.Info(ctx, "Recreated migrated VM", map[string]any{
+		"vm_id": created.ID,
+		"name":  created.Name,
+		"zvols": zvols,
+	})
+
+	data.ID = types.StringValue(strconv.FormatInt(created.ID, 10))
+	data.Name = types.StringValue(created.Name)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VMMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VMMigrationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmID, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid VM ID", err.Error())
+		return
+	}
+
+	vm, err := r.services.VM.GetVM(ctx, vmID)
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to Read VM", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(vm.Name)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *VMMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every configurable attribute requires replacement
+	var data VMMigrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VMMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VMMigrationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmID, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid VM ID", err.Error())
+		return
+	}
+
+	vm, err := r.services.VM.GetVM(ctx, vmID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return // Already deleted
+		}
+		resp.Diagnostics.AddError("Unable to Query VM State", err.Error())
+		return
+	}
+
+	if vm.State == VMStateRunning {
+		if err := r.services.VM.StopVM(ctx, vmID, truenas.StopVMOpts{Force: true}); err != nil {
+			resp.Diagnostics.AddError("Unable to Stop VM", fmt.Sprintf("Unable to stop VM before delete: %s", err.Error()))
+			return
+		}
+	}
+
+	if err := r.services.VM.DeleteVM(ctx, vmID); err != nil {
+		resp.Diagnostics.AddError("Unable to Delete VM", err.Error())
+	}
+}
+
+// vmDefinitionOmittedFields are the fields of an exported definition that
+// vm.create rejects, in case the definition was built by hand from
+// vm.query output.
+var vmDefinitionOmittedFields = []string{"id", "status", "display_available"}
+
+// parseVMDefinition decodes a definition exported by truenas_vm_definition,
+// keeping numbers exactly as exported.
+func parseVMDefinition(s string) (map[string]any, error) {
+	var def map[string]any
+	decoder := json.NewDecoder(bytes.NewReader([]byte(s)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("definition is not a JSON object: %w", err)
+	}
+	if name, _ := def["name"].(string); name == "" {
+		return nil, fmt.Errorf("definition has no VM name")
+	}
+	if _, ok := def["devices"].([]any); !ok && def["devices"] != nil {
+		return nil, fmt.Errorf("definition devices must be a list")
+	}
+
+	for _, k := range vmDefinitionOmittedFields {
+		delete(def, k)
+	}
+	return def, nil
+}
+
+// rewriteVMDefinitionZvols points the DISK devices of def at the zvols
+// zvolMap maps their current zvols to, and returns the zvol of every disk.
+// The longest key matching a zvol name or one of its parent datasets wins.
+func rewriteVMDefinitionZvols(def map[string]any, zvolMap map[string]string) []string {
+	var zvols []string
+
+	devices, _ := def["devices"].([]any)
+	for _, raw := range devices {
+		device, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		attrs, ok := device["attributes"].(map[string]any)
+		if !ok {
+			continue
+		}
+		// Older versions carry dtype on the device, newer ones in attributes
+		dtype, _ := device["dtype"].(string)
+		if dtype == "" {
+			dtype, _ = attrs["dtype"].(string)
+		}
+		if dtype != "DISK" {
+			continue
+		}
+
+		devPath, _ := attrs["path"].(string)
+		zvol, ok := strings.CutPrefix(devPath, "/dev/zvol/")
+		if !ok {
+			continue
+		}
+		zvol = mapZvolName(zvol, zvolMap)
+		attrs["path"] = zvolDevicePath(zvol)
+		zvols = append(zvols, zvol)
+	}
+	return zvols
+}
+
+// mapZvolName rewrites zvol through the longest matching entry of zvolMap.
+func mapZvolName(zvol string, zvolMap map[string]string) string {
+	best := ""
+	for from := range zvolMap {
+		if (zvol == from || strings.HasPrefix(zvol, from+"/")) && len(from) > len(best) {
+			best = from
+		}
+	}
+	if best == "" {
+		return zvol
+	}
+	return zvolMap[best] + strings.TrimPrefix(zvol, best)
+}
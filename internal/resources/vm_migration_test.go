@@ -0,0 +1,326 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/deevus/terraform-provider-truenas/internal/services"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testVMDefinitionJSON = `{
+	"name": "web",
+	"memory": 4096,
+	"vcpus": 2,
+	"devices": [
+		{"dtype": "DISK", "order": 1001, "attributes": {"path": "/dev/zvol/tank/vms/web-disk0", "type": "VIRTIO"}},
+		{"attributes": {"dtype": "DISK", "path": "/dev/zvol/tank/scratch/web"}},
+		{"dtype": "RAW", "attributes": {"path": "/mnt/tank/images/web.img"}},
+		{"dtype": "NIC", "attributes": {"type": "VIRTIO", "nic_attach": "br0", "mac": "00:a0:98:11:22:33"}}
+	]
+}`
+
+func TestNewVMMigrationResource(t *testing.T) {
+	r := NewVMMigrationResource()
+	if r == nil {
+		t.Fatal("NewVMMigrationResource returned nil")
+	}
+
+	_, ok := r.(*VMMigrationResource)
+	if !ok {
+		t.Fatalf("expected *VMMigrationResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*VMMigrationResource))
+	_ = resource.ResourceWithValidateConfig(r.(*VMMigrationResource))
+}
+
+func TestVMMigrationResource_Metadata(t *testing.T) {
+	r := NewVMMigrationResource()
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "truenas"}, resp)
+
+	if resp.TypeName != "truenas_vm_migration" {
+		t.Errorf("expected TypeName 'truenas_vm_migration', got %q", resp.TypeName)
+	}
+}
+
+// Test helpers
+
+func getVMMigrationResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewVMMigrationResource()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+func createVMMigrationModelValue(id, definition, name any, zvolMap map[string]string) tftypes.Value {
+	mapType := tftypes.Map{ElementType: tftypes.String}
+	var zvols tftypes.Value
+	if zvolMap == nil {
+		zvols = tftypes.NewValue(mapType, nil)
+	} else {
+		elements := make(map[string]tftypes.Value, len(zvolMap))
+		for k, v := range zvolMap {
+			elements[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		zvols = tftypes.NewValue(mapType, elements)
+	}
+
+	return tftypes.NewValue(tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"definition": tftypes.String,
+			"name":       tftypes.String,
+			"zvol_map":   mapType,
+		},
+	}, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, id),
+		"definition": tftypes.NewValue(tftypes.String, definition),
+		"name":       tftypes.NewValue(tftypes.String, name),
+		"zvol_map":   zvols,
+	})
+}
+
+// newVMMigrationTestResource returns a resource on a host with the given
+// zvols whose vm.create params are captured in params.
+func newVMMigrationTestResource(zvols []string, params *map[string]any) *VMMigrationResource {
+	return &VMMigrationResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{
+					GetZvolFunc: func(ctx context.Context, id string) (*truenas.Zvol, error) {
+						for _, z := range zvols {
+							if z == id {
+								return &truenas.Zvol{ID: id}, nil
+							}
+						}
+						return nil, nil
+					},
+				},
+			},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, p any) (json.RawMessage, error) {
+					*params = p.(map[string]any)
+					return json.RawMessage(`{"id": 7, "name": "` + (*params)["name"].(string) + `"}`), nil
+				},
+			},
+		},
+	}
+}
+
+func TestVMMigrationResource_Create_RewritesZvols(t *testing.T) {
+	var params map[string]any
+	r := newVMMigrationTestResource([]string{"fast/vms/web-disk0", "tank/scratch/web"}, &params)
+
+	schemaResp := getVMMigrationResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMMigrationModelValue(
+			tftypes.UnknownValue, testVMDefinitionJSON, tftypes.UnknownValue, map[string]string{"tank/vms": "fast/vms"},
+		)},
+	}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	devices := params["devices"].([]any)
+	wantPaths := []string{"/dev/zvol/fast/vms/web-disk0", "/dev/zvol/tank/scratch/web", "/mnt/tank/images/web.img"}
+	for i, want := range wantPaths {
+		got := devices[i].(map[string]any)["attributes"].(map[string]any)["path"]
+		if got != want {
+			t.Errorf("expected device %d path %q, got %v", i, want, got)
+		}
+	}
+	if params["memory"] != json.Number("4096") {
+		t.Errorf("expected memory passed through as exported, got %#v", params["memory"])
+	}
+
+	var model VMMigrationResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "7" || model.Name.ValueString() != "web" {
+		t.Errorf("expected VM 7 named web, got %v and %v", model.ID, model.Name)
+	}
+}
+
+func TestVMMigrationResource_Create_Rename(t *testing.T) {
+	var params map[string]any
+	r := newVMMigrationTestResource([]string{"tank/vms/web-disk0", "tank/scratch/web"}, &params)
+
+	schemaResp := getVMMigrationResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMMigrationModelValue(
+			tftypes.UnknownValue, testVMDefinitionJSON, "web-copy", nil,
+		)},
+	}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if params["name"] != "web-copy" {
+		t.Errorf("expected name 'web-copy', got %v", params["name"])
+	}
+}
+
+func TestVMMigrationResource_Create_MissingZvol(t *testing.T) {
+	var params map[string]any
+	r := newVMMigrationTestResource([]string{"tank/scratch/web"}, &params)
+
+	schemaResp := getVMMigrationResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMMigrationModelValue(
+			tftypes.UnknownValue, testVMDefinitionJSON, tftypes.UnknownValue, map[string]string{"tank/vms": "fast/vms"},
+		)},
+	}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a missing zvol")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Zvol Not Found" {
+		t.Errorf("expected 'Zvol Not Found', got %q", summary)
+	}
+	if params != nil {
+		t.Error("expected no vm.create when a zvol is missing")
+	}
+}
+
+func TestVMMigrationResource_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition any
+		wantErr    bool
+	}{
+		{"valid", testVMDefinitionJSON, false},
+		{"unknown", tftypes.UnknownValue, false},
+		{"not json", "web", true},
+		{"no name", `{"devices": []}`, true},
+		{"bad devices", `{"name": "web", "devices": {}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewVMMigrationResource().(*VMMigrationResource)
+			schemaResp := getVMMigrationResourceSchema(t)
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createVMMigrationModelValue(nil, tt.definition, nil, nil)},
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			r.ValidateConfig(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestVMMigrationResource_Read_Deleted(t *testing.T) {
+	r := &VMMigrationResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return nil, errors.New("[ENOENT] VM 7 does not exist")
+				},
+			}},
+		},
+	}
+
+	schemaResp := getVMMigrationResourceSchema(t)
+	state := createVMMigrationModelValue("7", testVMDefinitionJSON, "web", nil)
+	req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected the resource to be removed from state")
+	}
+}
+
+func TestVMMigrationResource_Delete_StopsRunningVM(t *testing.T) {
+	var calls []string
+	r := &VMMigrationResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(id, "web", 4096, VMStateRunning), nil
+				},
+				StopVMFunc: func(ctx context.Context, id int64, opts truenas.StopVMOpts) error {
+					calls = append(calls, "stop")
+					if !opts.Force {
+						t.Error("expected a forced stop")
+					}
+					return nil
+				},
+				DeleteVMFunc: func(ctx context.Context, id int64) error {
+					calls = append(calls, "delete")
+					return nil
+				},
+			}},
+		},
+	}
+
+	schemaResp := getVMMigrationResourceSchema(t)
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMMigrationModelValue("7", testVMDefinitionJSON, "web", nil)}}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(calls) != 2 || calls[0] != "stop" || calls[1] != "delete" {
+		t.Errorf("expected stop then delete, got %v", calls)
+	}
+}
+
+func TestMapZvolName(t *testing.T) {
+	zvolMap := map[string]string{
+		"tank/vms":          "fast/vms",
+		"tank/vms/web-disk": "archive/web",
+		"tank/vm":           "other/vm",
+	}
+
+	tests := []struct {
+		zvol string
+		want string
+	}{
+		{"tank/vms/db-disk0", "fast/vms/db-disk0"},
+		{"tank/vms/web-disk", "archive/web"},
+		{"tank/vms/web-disk/child", "archive/web/child"},
+		{"tank/vmstore/disk", "tank/vmstore/disk"},
+		{"tank/vm", "other/vm"},
+	}
+
+	for _, tt := range tests {
+		if got := mapZvolName(tt.zvol, zvolMap); got != tt.want {
+			t.Errorf("mapZvolName(%q) = %q, want %q", tt.zvol, got, tt.want)
+		}
+	}
+}
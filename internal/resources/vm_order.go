@@ -0,0 +1,322 @@
+package resources
+
+import (
+	"context"
+
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Device blocks are lists, but the position of a device in its block means
+// nothing to TrueNAS: boot order is the order attribute, and vm.device.query
+// returns devices in its own order. A device's identity is its device_id, so
+// devices read back from the API are put in the order the same device IDs had
+// before, and blocks reordered in the configuration are matched to the
+// devices they describe rather than to the device at the same index.
+
+// orderByDeviceID returns mapped reordered so that devices whose ID appears
+// in prior keep prior's order. Devices not in prior follow in API order.
+func orderByDeviceID[T any](mapped, prior []T, id func(T) types.Int64) []T {
+	if len(mapped) < 2 || len(prior) == 0 {
+		return mapped
+	}
+
+	byID := make(map[int64]int, len(mapped))
+	for i, m := range mapped {
+		if d := id(m); !d.IsNull() && !d.IsUnknown() {
+			byID[d.ValueInt64()] = i
+		}
+	}
+
+	ordered := make([]T, 0, len(mapped))
+	taken := make([]bool, len(mapped))
+	for _, p := range prior {
+		d := id(p)
+		if d.IsNull() || d.IsUnknown() {
+			continue
+		}
+		if i, ok := byID[d.ValueInt64()]; ok && !taken[i] {
+			ordered = append(ordered, mapped[i])
+			taken[i] = true
+		}
+	}
+	for i, m := range mapped {
+		if !taken[i] {
+			ordered = append(ordered, m)
+		}
+	}
+	return ordered
+}
+
+// orderDevicesLikePrior puts each device block of data in the order of the
+// same block in prior.
+func orderDevicesLikePrior(data, prior *VMResourceModel) {
+	data.Disks = orderByDeviceID(data.Disks, prior.Disks, func(d VMDiskModel) types.Int64 { return d.DeviceID })
+	data.Raws = orderByDeviceID(data.Raws, prior.Raws, func(d VMRawModel) types.Int64 { return d.DeviceID })
+	data.CDROMs = orderByDeviceID(data.CDROMs, prior.CDROMs, func(d VMCDROMModel) types.Int64 { return d.DeviceID })
+	data.NICs = orderByDeviceID(data.NICs, prior.NICs, func(d VMNICModel) types.Int64 { return d.DeviceID })
+	data.Displays = orderByDeviceID(data.Displays, prior.Displays, func(d VMDisplayModel) types.Int64 { return d.DeviceID })
+	data.PCIs = orderByDeviceID(data.PCIs, prior.PCIs, func(d VMPCIModel) types.Int64 { return d.DeviceID })
+	data.USBs = orderByDeviceID(data.USBs, prior.USBs, func(d VMUSBModel) types.Int64 { return d.DeviceID })
+}
+
+// alignDevices matches the planned devices of one block to the devices in
+// state they describe. The framework carries computed values such as
+// device_id over from the state device at the same index, so reordering the
+// blocks in the configuration would otherwise rewrite every device that
+// moved. A planned device matches the first unclaimed state device that
+// matches reports as the same device, given its configuration and planned
+// values, or else the state device at its own index if that is unclaimed;
+// unmatched devices are new. carry sets the
+// computed values of a planned device that were not configured from its
+// state device, or to unknown when st is nil. It reports whether any planned
+// device was changed.
+func alignDevices[T any](plan, config, state []T, matches func(cfg, planned, st T) bool, carry func(dst *T, cfg T, st *T)) bool {
+	if len(plan) != len(config) || len(state) == 0 {
+		return false
+	}
+
+	target := make([]int, len(plan))
+	claimed := make([]bool, len(state))
+	for i := range plan {
+		target[i] = -1
+		for j, st := range state {
+			if !claimed[j] && matches(config[i], plan[i], st) {
+				target[i], claimed[j] = j, true
+				break
+			}
+		}
+	}
+	for i := range plan {
+		if target[i] < 0 && i < len(state) && !claimed[i] {
+			target[i], claimed[i] = i, true
+		}
+	}
+
+	changed := false
+	for i := range plan {
+		switch {
+		case target[i] == i:
+			continue
+		case target[i] < 0 && i >= len(state):
+			// Nothing was carried over from state
+			continue
+		case target[i] < 0:
+			carry(&plan[i], config[i], nil)
+		default:
+			carry(&plan[i], config[i], &state[target[i]])
+		}
+		changed = true
+	}
+	return changed
+}
+
+// knownString returns the value of s and whether it is set and known.
+func knownString(s interface {
+	IsNull() bool
+	IsUnknown() bool
+	ValueString() string
+}) (string, bool) {
+	if s.IsNull() || s.IsUnknown() || s.ValueString() == "" {
+		return "", false
+	}
+	return s.ValueString(), true
+}
+
+// sameString reports whether a configured identity attribute is set and
+// equal to the state value.
+func sameString(cfg, st interface {
+	IsNull() bool
+	IsUnknown() bool
+	ValueString() string
+}) bool {
+	v, ok := knownString(cfg)
+	return ok && v == st.ValueString()
+}
+
+// carryInt64 sets dst from st when the attribute was not configured.
+func carryInt64(dst *types.Int64, cfg types.Int64, st *types.Int64) {
+	if !cfg.IsNull() {
+		return
+	}
+	if st == nil {
+		*dst = types.Int64Unknown()
+		return
+	}
+	*dst = *st
+}
+
+// carryString sets dst from st when the attribute was not configured.
+func carryString(dst *types.String, cfg types.String, st *types.String) {
+	if !cfg.IsNull() {
+		return
+	}
+	if st == nil {
+		*dst = types.StringUnknown()
+		return
+	}
+	*dst = *st
+}
+
+func matchDisk(cfg, _, st VMDiskModel) bool {
+	if _, ok := knownString(cfg.Path); ok {
+		return sameString(cfg.Path, st.Path)
+	}
+	return sameString(cfg.ZvolName, st.ZvolName)
+}
+
+func carryDisk(dst *VMDiskModel, cfg VMDiskModel, st *VMDiskModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryString(&dst.Serial, cfg.Serial, nil)
+		carryInt64(&dst.Order, cfg.Order, nil)
+		if cfg.Path.IsNull() {
+			dst.Path = customtypes.NewFilesystemPathUnknown()
+		}
+		if cfg.ZvolName.IsNull() {
+			dst.ZvolName = types.StringUnknown()
+		}
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryString(&dst.Serial, cfg.Serial, &st.Serial)
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+	if cfg.Path.IsNull() {
+		dst.Path = st.Path
+	}
+	if cfg.ZvolName.IsNull() {
+		dst.ZvolName = st.ZvolName
+	}
+}
+
+func matchRaw(cfg, _, st VMRawModel) bool {
+	return sameString(cfg.Path, st.Path)
+}
+
+func carryRaw(dst *VMRawModel, cfg VMRawModel, st *VMRawModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryString(&dst.Serial, cfg.Serial, nil)
+		carryInt64(&dst.Order, cfg.Order, nil)
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryString(&dst.Serial, cfg.Serial, &st.Serial)
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+}
+
+func matchCDROM(cfg, _, st VMCDROMModel) bool {
+	return sameString(cfg.Path, st.Path)
+}
+
+func carryCDROM(dst *VMCDROMModel, cfg VMCDROMModel, st *VMCDROMModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryInt64(&dst.Order, cfg.Order, nil)
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+}
+
+// matchNIC identifies a NIC by its MAC address, or by the interface it
+// attaches to when no MAC is configured.
+func matchNIC(cfg, planned, st VMNICModel) bool {
+	if _, ok := knownString(cfg.MAC); ok {
+		return sameString(cfg.MAC, st.MAC)
+	}
+	return sameString(cfg.NICAttach, st.NICAttach) && planned.Type.Equal(st.Type)
+}
+
+func carryNIC(dst *VMNICModel, cfg VMNICModel, st *VMNICModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryString(&dst.MAC, cfg.MAC, nil)
+		carryInt64(&dst.Order, cfg.Order, nil)
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryString(&dst.MAC, cfg.MAC, &st.MAC)
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+}
+
+// matchDisplay identifies a display by its type and, when configured, its
+// port.
+func matchDisplay(cfg, planned, st VMDisplayModel) bool {
+	if !planned.Type.Equal(st.Type) {
+		return false
+	}
+	return cfg.Port.IsNull() || cfg.Port.Equal(st.Port)
+}
+
+func carryDisplay(dst *VMDisplayModel, cfg VMDisplayModel, st *VMDisplayModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryInt64(&dst.Port, cfg.Port, nil)
+		carryInt64(&dst.WebPort, cfg.WebPort, nil)
+		carryInt64(&dst.Order, cfg.Order, nil)
+		dst.WebURL = types.StringUnknown()
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryInt64(&dst.Port, cfg.Port, &st.Port)
+	carryInt64(&dst.WebPort, cfg.WebPort, &st.WebPort)
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+	dst.WebURL = st.WebURL
+}
+
+func matchPCI(cfg, _, st VMPCIModel) bool {
+	return sameString(cfg.PPTDev, st.PPTDev)
+}
+
+func carryPCI(dst *VMPCIModel, cfg VMPCIModel, st *VMPCIModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryInt64(&dst.Order, cfg.Order, nil)
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+}
+
+func matchUSB(cfg, _, st VMUSBModel) bool {
+	return sameString(cfg.Device, st.Device)
+}
+
+func carryUSB(dst *VMUSBModel, cfg VMUSBModel, st *VMUSBModel) {
+	if st == nil {
+		dst.DeviceID = types.Int64Unknown()
+		carryInt64(&dst.Order, cfg.Order, nil)
+		return
+	}
+	dst.DeviceID = st.DeviceID
+	carryInt64(&dst.Order, cfg.Order, &st.Order)
+}
+
+// alignPlannedDevices matches every device block of plan to state with
+// alignDevices and writes the blocks it changed back to the plan.
+func alignPlannedDevices(ctx context.Context, plan, config, state *VMResourceModel, resp *resource.ModifyPlanResponse) {
+	if alignDevices(plan.Disks, config.Disks, state.Disks, matchDisk, carryDisk) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("disk"), plan.Disks)...)
+	}
+	if alignDevices(plan.Raws, config.Raws, state.Raws, matchRaw, carryRaw) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("raw"), plan.Raws)...)
+	}
+	if alignDevices(plan.CDROMs, config.CDROMs, state.CDROMs, matchCDROM, carryCDROM) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cdrom"), plan.CDROMs)...)
+	}
+	if alignDevices(plan.NICs, config.NICs, state.NICs, matchNIC, carryNIC) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("nic"), plan.NICs)...)
+	}
+	if alignDevices(plan.Displays, config.Displays, state.Displays, matchDisplay, carryDisplay) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("display"), plan.Displays)...)
+	}
+	if alignDevices(plan.PCIs, config.PCIs, state.PCIs, matchPCI, carryPCI) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pci"), plan.PCIs)...)
+	}
+	if alignDevices(plan.USBs, config.USBs, state.USBs, matchUSB, carryUSB) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("usb"), plan.USBs)...)
+	}
+}
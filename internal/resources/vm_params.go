@@ -1,6 +1,9 @@
 package resources
 
 import (
+	"strings"
+
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
 	truenas "github.com/deevus/truenas-go"
 )
 
@@ -11,17 +14,17 @@ func (r *VMResource) buildCreateOpts(data *VMResourceModel) truenas.CreateVMOpts
 		VCPUs:           data.VCPUs.ValueInt64(),
 		Cores:           data.Cores.ValueInt64(),
 		Threads:         data.Threads.ValueInt64(),
-		Memory:          data.Memory.ValueInt64(),
+		Memory:          data.Memory.ValueMiB(),
 		Autostart:       data.Autostart.ValueBool(),
-		Time:            data.Time.ValueString(),
-		Bootloader:      data.Bootloader.ValueString(),
+		Time:            strings.ToUpper(data.Time.ValueString()),
+		Bootloader:      strings.ToUpper(data.Bootloader.ValueString()),
 		BootloaderOVMF:  data.BootloaderOVMF.ValueString(),
-		CPUMode:         data.CPUMode.ValueString(),
+		CPUMode:         strings.ToUpper(data.CPUMode.ValueString()),
 		ShutdownTimeout: data.ShutdownTimeout.ValueInt64(),
 		CommandLineArgs: data.CommandLineArgs.ValueString(),
 	}
 	if !data.MinMemory.IsNull() && !data.MinMemory.IsUnknown() {
-		v := data.MinMemory.ValueInt64()
+		v := data.MinMemory.ValueMiB()
 		opts.MinMemory = &v
 	}
 	if !data.CPUModel.IsNull() && !data.CPUModel.IsUnknown() {
@@ -37,13 +40,13 @@ func (r *VMResource) buildUpdateOpts(plan, state *VMResourceModel) (*truenas.Upd
 		!plan.VCPUs.Equal(state.VCPUs) ||
 		!plan.Cores.Equal(state.Cores) ||
 		!plan.Threads.Equal(state.Threads) ||
-		!plan.Memory.Equal(state.Memory) ||
-		!plan.MinMemory.Equal(state.MinMemory) ||
+		!memoryMiBEqual(plan.Memory, state.Memory) ||
+		!memoryMiBEqual(plan.MinMemory, state.MinMemory) ||
 		!plan.Autostart.Equal(state.Autostart) ||
-		!plan.Time.Equal(state.Time) ||
-		!plan.Bootloader.Equal(state.Bootloader) ||
+		!strings.EqualFold(plan.Time.ValueString(), state.Time.ValueString()) ||
+		!strings.EqualFold(plan.Bootloader.ValueString(), state.Bootloader.ValueString()) ||
 		!plan.BootloaderOVMF.Equal(state.BootloaderOVMF) ||
-		!plan.CPUMode.Equal(state.CPUMode) ||
+		!strings.EqualFold(plan.CPUMode.ValueString(), state.CPUMode.ValueString()) ||
 		!plan.CPUModel.Equal(state.CPUModel) ||
 		!plan.ShutdownTimeout.Equal(state.ShutdownTimeout) ||
 		!plan.CommandLineArgs.Equal(state.CommandLineArgs)
@@ -53,6 +56,15 @@ func (r *VMResource) buildUpdateOpts(plan, state *VMResourceModel) (*truenas.Upd
 	return &opts, true
 }
 
+// memoryMiBEqual reports whether two memory values are equal once normalized
+// to MiB, so a configuration written in bytes does not trigger an update.
+func memoryMiBEqual(a, b customtypes.MemoryMiBValue) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return a.Equal(b)
+	}
+	return a.ValueMiB() == b.ValueMiB()
+}
+
 // -- Device opts builders --
 
 func buildDiskDeviceOpts(disk *VMDiskModel, vmID int64) truenas.CreateVMDeviceOpts {
@@ -2,12 +2,29 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
 )
 
+// vmStartTimeout bounds how long reconcileState waits for a started VM to report RUNNING.
+const vmStartTimeout = 2 * time.Minute
+
+// vmStartRetryDelay is the wait between vm.start retries when start_retry_delay is unset.
+const vmStartRetryDelay = 30 * time.Second
+
+// vmDeviceCreateConcurrency bounds the device groups createDevices creates at once.
+// The client's own limiter (SSH sessions / WebSocket max_concurrent) still applies.
+const vmDeviceCreateConcurrency = 4
+
 // reconcileDevices compares plan vs state devices and creates/updates/deletes as needed.
 func (r *VMResource) reconcileDevices(ctx context.Context, vmID int64, plan, state *VMResourceModel) error {
 	// Build maps of state device IDs to detect what exists
@@ -18,7 +35,7 @@ func (r *VMResource) reconcileDevices(ctx context.Context, vmID int64, plan, sta
 	collectDeviceIDs(planDeviceIDs, plan)
 
 	// Delete devices in state but not in plan
-	if err := r.deleteRemovedDevices(ctx, stateDeviceIDs, planDeviceIDs); err != nil {
+	if err := r.deleteRemovedDevices(ctx, stateDeviceIDs, planDeviceIDs, backingStorageDeletes(state)); err != nil {
 		return err
 	}
 
@@ -48,6 +65,68 @@ func (r *VMResource) reconcileDevices(ctx context.Context, vmID int64, plan, sta
 	return nil
 }
 
+// createDevices creates the planned devices of a new VM and stores the
+// assigned device IDs on the model. Devices whose boot or enumeration order
+// falls back to their ID when they share an order value (disks, raw files and
+// CD-ROMs together, and each other device type) are created one after
+// another in configuration order; only these groups run concurrently. The
+// first failure cancels the creates still pending.
+func (r *VMResource) createDevices(ctx context.Context, vmID int64, data *VMResourceModel) error {
+	type pendingDevice struct {
+		kind     string
+		index    int
+		opts     truenas.CreateVMDeviceOpts
+		deviceID *types.Int64
+	}
+
+	var storage, nics, displays, pcis, usbs []pendingDevice
+	for i := range data.Disks {
+		storage = append(storage, pendingDevice{"disk", i, buildDiskDeviceOpts(&data.Disks[i], vmID), &data.Disks[i].DeviceID})
+	}
+	for i := range data.Raws {
+		storage = append(storage, pendingDevice{"raw", i, buildRawDeviceOpts(&data.Raws[i], vmID), &data.Raws[i].DeviceID})
+	}
+	for i := range data.CDROMs {
+		storage = append(storage, pendingDevice{"cdrom", i, buildCDROMDeviceOpts(&data.CDROMs[i], vmID), &data.CDROMs[i].DeviceID})
+	}
+	for i := range data.NICs {
+		nics = append(nics, pendingDevice{"nic", i, buildNICDeviceOpts(&data.NICs[i], vmID), &data.NICs[i].DeviceID})
+	}
+	for i := range data.Displays {
+		displays = append(displays, pendingDevice{"display", i, buildDisplayDeviceOpts(&data.Displays[i], vmID), &data.Displays[i].DeviceID})
+	}
+	for i := range data.PCIs {
+		pcis = append(pcis, pendingDevice{"pci", i, buildPCIDeviceOpts(&data.PCIs[i], vmID), &data.PCIs[i].DeviceID})
+	}
+	for i := range data.USBs {
+		usbs = append(usbs, pendingDevice{"usb", i, buildUSBDeviceOpts(&data.USBs[i], vmID), &data.USBs[i].DeviceID})
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(vmDeviceCreateConcurrency)
+
+	for _, group := range [][]pendingDevice{storage, nics, displays, pcis, usbs} {
+		if len(group) == 0 {
+			continue
+		}
+		g.Go(func() error {
+			for _, d := range group {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				dev, err := r.services.VM.CreateDevice(ctx, d.opts)
+				if err != nil {
+					return fmt.Errorf("create %s device %d: %w", d.kind, d.index, err)
+				}
+				*d.deviceID = types.Int64Value(dev.ID)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
 func collectDeviceIDs(ids map[int64]bool, data *VMResourceModel) {
 	for _, d := range data.Disks {
 		if !d.DeviceID.IsNull() && !d.DeviceID.IsUnknown() {
@@ -86,10 +165,18 @@ func collectDeviceIDs(ids map[int64]bool, data *VMResourceModel) {
 	}
 }
 
-func (r *VMResource) deleteRemovedDevices(ctx context.Context, stateIDs, planIDs map[int64]bool) error {
+// deleteRemovedDevices deletes the devices in stateIDs that are not in
+// planIDs. A device with an entry in backing is deleted with that
+// vm.device.delete option set, which VMService does not expose.
+func (r *VMResource) deleteRemovedDevices(ctx context.Context, stateIDs, planIDs map[int64]bool, backing map[int64]string) error {
 	for id := range stateIDs {
 		if !planIDs[id] {
-			err := r.services.VM.DeleteDevice(ctx, id)
+			var err error
+			if option, ok := backing[id]; ok {
+				_, err = r.client.Call(ctx, "vm.device.delete", []any{id, map[string]any{option: true}})
+			} else {
+				err = r.services.VM.DeleteDevice(ctx, id)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to delete device %d: %w", id, err)
 			}
@@ -98,6 +185,24 @@ func (r *VMResource) deleteRemovedDevices(ctx context.Context, stateIDs, planIDs
 	return nil
 }
 
+// backingStorageDeletes returns the vm.device.delete option that removes the
+// backing storage of each device in data with delete_backing_storage = true:
+// zvol for DISK devices and raw_file for RAW devices.
+func backingStorageDeletes(data *VMResourceModel) map[int64]string {
+	backing := make(map[int64]string)
+	for _, d := range data.Disks {
+		if d.DeleteBackingStorage.ValueBool() && !d.DeviceID.IsNull() && !d.DeviceID.IsUnknown() {
+			backing[d.DeviceID.ValueInt64()] = "zvol"
+		}
+	}
+	for _, d := range data.Raws {
+		if d.DeleteBackingStorage.ValueBool() && !d.DeviceID.IsNull() && !d.DeviceID.IsUnknown() {
+			backing[d.DeviceID.ValueInt64()] = "raw_file"
+		}
+	}
+	return backing
+}
+
 func (r *VMResource) reconcileDiskDevices(ctx context.Context, vmID int64, plan, state []VMDiskModel) error {
 	stateByID := make(map[int64]VMDiskModel)
 	for _, s := range state {
@@ -308,15 +413,212 @@ func usbEqual(a, b VMUSBModel) bool {
 }
 
 // reconcileState starts or stops the VM to match the desired state.
-func (r *VMResource) reconcileState(ctx context.Context, vmID int64, currentState, desiredState string) error {
+func (r *VMResource) reconcileState(ctx context.Context, vmID int64, currentState, desiredState string, retry vmStartRetry) error {
 	if currentState == desiredState {
 		return nil
 	}
 
 	if desiredState == VMStateRunning {
-		return r.services.VM.StartVM(ctx, vmID)
+		if err := r.startVM(ctx, vmID, retry); err != nil {
+			return err
+		}
+		return r.waitForVMState(ctx, vmID, VMStateRunning, vmStartTimeout)
 	}
 
 	// vm.stop is a job
 	return r.services.VM.StopVM(ctx, vmID, truenas.StopVMOpts{Force: false, ForceAfterTimeout: true})
 }
+
+// vmStartRetry controls how long vm.start waits before its first attempt and
+// how often it is retried when the host is short of memory.
+type vmStartRetry struct {
+	wait     time.Duration
+	attempts int64
+	delay    time.Duration
+}
+
+// vmStartRetryFromModel reads start_delay, start_retries and start_retry_delay
+// from the model.
+func vmStartRetryFromModel(data *VMResourceModel) vmStartRetry {
+	retry := vmStartRetry{attempts: data.StartRetries.ValueInt64(), delay: vmStartRetryDelay}
+	if !data.StartRetryDelay.IsNull() && !data.StartRetryDelay.IsUnknown() {
+		retry.delay = time.Duration(data.StartRetryDelay.ValueInt64()) * time.Second
+	}
+	if !data.StartDelay.IsNull() && !data.StartDelay.IsUnknown() {
+		retry.wait = time.Duration(data.StartDelay.ValueInt64()) * time.Second
+	}
+	return retry
+}
+
+// startVM calls vm.start after the configured start delay, retrying
+// out-of-memory failures as configured. When the host is still short of memory
+// after the last attempt, the error is returned as a *vmOutOfMemoryError
+// carrying the memory available to guests.
+func (r *VMResource) startVM(ctx context.Context, vmID int64, retry vmStartRetry) error {
+	if retry.wait > 0 {
+		tflog.Info(ctx, "Delaying VM start", map[string]any{
+			"vm_id": vmID,
+			"delay": retry.wait.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retry.wait):
+		}
+	}
+
+	for attempt := int64(0); ; attempt++ {
+		err := r.services.VM.StartVM(ctx, vmID)
+		if err == nil || !isVMOutOfMemoryError(err) {
+			return err
+		}
+
+		if attempt >= retry.attempts {
+			return r.newVMOutOfMemoryError(ctx, err)
+		}
+
+		tflog.Info(ctx, "Not enough memory to start VM, retrying", map[string]any{
+			"vm_id":   vmID,
+			"attempt": attempt + 1,
+			"retries": retry.attempts,
+			"delay":   retry.delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return r.newVMOutOfMemoryError(ctx, err)
+		case <-time.After(retry.delay):
+		}
+	}
+}
+
+// newVMOutOfMemoryError wraps a failed vm.start with the memory currently
+// available to guests. The lookup is best effort, as the start already failed.
+func (r *VMResource) newVMOutOfMemoryError(ctx context.Context, err error) error {
+	oom := &vmOutOfMemoryError{err: err}
+
+	result, callErr := r.client.Call(ctx, "vm.get_available_memory", nil)
+	if callErr != nil {
+		return oom
+	}
+	var available int64
+	if json.Unmarshal(result, &available) == nil {
+		oom.available = &available
+	}
+	return oom
+}
+
+// stopVMForDelete stops a running VM ahead of vm.delete. With forceStopAfter
+// unset the VM is powered off at once; otherwise the guest is asked to shut
+// down and is powered off only if it is still running after that many seconds.
+func (r *VMResource) stopVMForDelete(ctx context.Context, vmID int64, forceStopAfter types.Int64) error {
+	force := truenas.StopVMOpts{Force: true, ForceAfterTimeout: true}
+	if forceStopAfter.IsNull() {
+		return r.services.VM.StopVM(ctx, vmID, force)
+	}
+
+	// The graceful vm.stop job also returns once the VM's own shutdown_timeout
+	// expires, leaving the VM running.
+	stopCtx, cancel := context.WithTimeout(ctx, time.Duration(forceStopAfter.ValueInt64())*time.Second)
+	err := r.services.VM.StopVM(stopCtx, vmID, truenas.StopVMOpts{})
+	timedOut := stopCtx.Err() != nil
+	cancel()
+	if err != nil && !timedOut {
+		return err
+	}
+
+	vm, err := r.services.VM.GetVM(ctx, vmID)
+	if err != nil {
+		return err
+	}
+	if vm == nil || vm.State != VMStateRunning {
+		return nil
+	}
+
+	tflog.Info(ctx, "VM did not shut down gracefully, powering off", map[string]any{
+		"vm_id":            vmID,
+		"force_stop_after": forceStopAfter.ValueInt64(),
+	})
+	return r.services.VM.StopVM(ctx, vmID, force)
+}
+
+// waitForVMState blocks until the VM reports the given state. vm.start returns
+// before the domain is up, so this watches vm.query change events instead of
+// trusting the call's return.
+func (r *VMResource) waitForVMState(ctx context.Context, vmID int64, state string, timeout time.Duration) error {
+	var current string
+	err := waitForCollection(ctx, r.client, "vm.query", timeout, func(ctx context.Context) (bool, error) {
+		vm, err := r.services.VM.GetVM(ctx, vmID)
+		if err != nil {
+			return false, err
+		}
+		if vm == nil {
+			return false, fmt.Errorf("VM %d no longer exists", vmID)
+		}
+		current = vm.State
+		return current == state, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for VM %d to reach %s (last state %q): %w", vmID, state, current, err)
+	}
+	return nil
+}
+
+// vmQueryResponse is the subset of vm.query fields needed to locate a VM by name.
+type vmQueryResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// findVMByName returns the VM with the given name, or nil if none exists.
+// The typed VM service can only fetch VMs by ID, so the name is resolved with
+// a vm.query filter and the VM itself is read through the service.
+func (r *VMResource) findVMByName(ctx context.Context, name string) (*truenas.VM, error) {
+	filter := []any{[]any{[]any{"name", "=", name}}}
+
+	result, err := r.client.Call(ctx, "vm.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var vms []vmQueryResponse
+	if err := json.Unmarshal(result, &vms); err != nil {
+		return nil, fmt.Errorf("parse vm query response: %w", err)
+	}
+
+	if len(vms) == 0 {
+		return nil, nil
+	}
+
+	return r.services.VM.GetVM(ctx, vms[0].ID)
+}
+
+// vmCreatePrivateKey is the private state key Create sets once the VM exists
+// and clears once it is done. A VM still carrying it in state was left
+// behind by a failed create, and will be replaced on the next apply.
+const vmCreatePrivateKey = "create_in_progress"
+
+// setPartialCreateState stores the VM a failed Create left behind as the new
+// state. Values that never became known are stored as null, since state
+// cannot hold unknown values.
+func (r *VMResource) setPartialCreateState(ctx context.Context, data *VMResourceModel, resp *resource.CreateResponse) {
+	var state tfsdk.State
+	state.Schema = resp.State.Schema
+	if diags := state.Set(ctx, data); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Save Partially Created VM", err.Error())
+		return
+	}
+	resp.State.Raw = raw
+}
@@ -2,12 +2,27 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
 	"github.com/deevus/terraform-provider-truenas/internal/services"
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -31,30 +46,36 @@ func getVMResourceSchema(t *testing.T) resource.SchemaResponse {
 
 func vmDiskBlockType() tftypes.Object {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"device_id":           tftypes.Number,
-		"path":                tftypes.String,
-		"type":                tftypes.String,
-		"logical_sectorsize":  tftypes.Number,
-		"physical_sectorsize": tftypes.Number,
-		"iotype":              tftypes.String,
-		"serial":              tftypes.String,
-		"order":               tftypes.Number,
+		"device_id":              tftypes.Number,
+		"path":                   tftypes.String,
+		"type":                   tftypes.String,
+		"logical_sectorsize":     tftypes.Number,
+		"physical_sectorsize":    tftypes.Number,
+		"iotype":                 tftypes.String,
+		"serial":                 tftypes.String,
+		"order":                  tftypes.Number,
+		"size":                   tftypes.String,
+		"zvol_name":              tftypes.String,
+		"pool":                   tftypes.String,
+		"manage_zvol":            tftypes.Bool,
+		"delete_backing_storage": tftypes.Bool,
 	}}
 }
 
 func vmRawBlockType() tftypes.Object {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
-		"device_id":           tftypes.Number,
-		"path":                tftypes.String,
-		"type":                tftypes.String,
-		"boot":                tftypes.Bool,
-		"exists":              tftypes.Bool,
-		"size":                tftypes.Number,
-		"logical_sectorsize":  tftypes.Number,
-		"physical_sectorsize": tftypes.Number,
-		"iotype":              tftypes.String,
-		"serial":              tftypes.String,
-		"order":               tftypes.Number,
+		"device_id":              tftypes.Number,
+		"path":                   tftypes.String,
+		"type":                   tftypes.String,
+		"boot":                   tftypes.Bool,
+		"exists":                 tftypes.Bool,
+		"size":                   tftypes.Number,
+		"logical_sectorsize":     tftypes.Number,
+		"physical_sectorsize":    tftypes.Number,
+		"iotype":                 tftypes.String,
+		"serial":                 tftypes.String,
+		"order":                  tftypes.Number,
+		"delete_backing_storage": tftypes.Bool,
 	}}
 }
 
@@ -74,9 +95,33 @@ func vmNICBlockType() tftypes.Object {
 		"mac":                    tftypes.String,
 		"trust_guest_rx_filters": tftypes.Bool,
 		"order":                  tftypes.Number,
+		"create_bridge":          vmNICCreateBridgeType(),
+	}}
+}
+
+func vmNICCreateBridgeType() tftypes.Object {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"members": tftypes.List{ElementType: tftypes.String},
+		"stp":     tftypes.Bool,
 	}}
 }
 
+// vmNICCreateBridgeValue returns the create_bridge block for members, or a
+// null block when members is nil.
+func vmNICCreateBridgeValue(members []string) tftypes.Value {
+	if members == nil {
+		return tftypes.NewValue(vmNICCreateBridgeType(), nil)
+	}
+	values := make([]tftypes.Value, len(members))
+	for i, m := range members {
+		values[i] = tftypes.NewValue(tftypes.String, m)
+	}
+	return tftypes.NewValue(vmNICCreateBridgeType(), map[string]tftypes.Value{
+		"members": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values),
+		"stp":     tftypes.NewValue(tftypes.Bool, nil),
+	})
+}
+
 func vmDisplayBlockType() tftypes.Object {
 	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
 		"device_id":  tftypes.Number,
@@ -88,6 +133,7 @@ func vmDisplayBlockType() tftypes.Object {
 		"wait":       tftypes.Bool,
 		"password":   tftypes.String,
 		"web":        tftypes.Bool,
+		"web_url":    tftypes.String,
 		"order":      tftypes.Number,
 	}}
 }
@@ -113,31 +159,41 @@ func vmUSBBlockType() tftypes.Object {
 func vmObjectType() tftypes.Object {
 	return tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":                tftypes.String,
-			"name":              tftypes.String,
-			"description":       tftypes.String,
-			"vcpus":             tftypes.Number,
-			"cores":             tftypes.Number,
-			"threads":           tftypes.Number,
-			"memory":            tftypes.Number,
-			"min_memory":        tftypes.Number,
-			"autostart":         tftypes.Bool,
-			"time":              tftypes.String,
-			"bootloader":        tftypes.String,
-			"bootloader_ovmf":   tftypes.String,
-			"cpu_mode":          tftypes.String,
-			"cpu_model":         tftypes.String,
-			"shutdown_timeout":  tftypes.Number,
-			"command_line_args": tftypes.String,
-			"state":             tftypes.String,
-			"display_available": tftypes.Bool,
-			"disk":              tftypes.List{ElementType: vmDiskBlockType()},
-			"raw":               tftypes.List{ElementType: vmRawBlockType()},
-			"cdrom":             tftypes.List{ElementType: vmCDROMBlockType()},
-			"nic":               tftypes.List{ElementType: vmNICBlockType()},
-			"display":           tftypes.List{ElementType: vmDisplayBlockType()},
-			"pci":               tftypes.List{ElementType: vmPCIBlockType()},
-			"usb":               tftypes.List{ElementType: vmUSBBlockType()},
+			"id":                  tftypes.String,
+			"name":                tftypes.String,
+			"description":         tftypes.String,
+			"vcpus":               tftypes.Number,
+			"cores":               tftypes.Number,
+			"threads":             tftypes.Number,
+			"memory":              tftypes.Number,
+			"min_memory":          tftypes.Number,
+			"autostart":           tftypes.Bool,
+			"time":                tftypes.String,
+			"bootloader":          tftypes.String,
+			"bootloader_ovmf":     tftypes.String,
+			"cpu_mode":            tftypes.String,
+			"cpu_model":           tftypes.String,
+			"shutdown_timeout":    tftypes.Number,
+			"command_line_args":   tftypes.String,
+			"state":               tftypes.String,
+			"display_available":   tftypes.Bool,
+			"force_stop_after":    tftypes.Number,
+			"start_retries":       tftypes.Number,
+			"start_retry_delay":   tftypes.Number,
+			"start_delay":         tftypes.Number,
+			"delete_zvols":        tftypes.Bool,
+			"tpm":                 tftypes.Bool,
+			"uuid":                tftypes.String,
+			"cpuset":              tftypes.String,
+			"nodeset":             tftypes.String,
+			"device_ids_by_label": tftypes.Map{ElementType: tftypes.Number},
+			"disk":                tftypes.List{ElementType: vmDiskBlockType()},
+			"raw":                 tftypes.List{ElementType: vmRawBlockType()},
+			"cdrom":               tftypes.List{ElementType: vmCDROMBlockType()},
+			"nic":                 tftypes.List{ElementType: vmNICBlockType()},
+			"display":             tftypes.List{ElementType: vmDisplayBlockType()},
+			"pci":                 tftypes.List{ElementType: vmPCIBlockType()},
+			"usb":                 tftypes.List{ElementType: vmUSBBlockType()},
 		},
 	}
 }
@@ -161,6 +217,16 @@ type vmModelParams struct {
 	CommandLineArgs  interface{}
 	State            interface{}
 	DisplayAvailable interface{}
+	ForceStopAfter   interface{}
+	StartRetries     interface{}
+	StartRetryDelay  interface{}
+	StartDelay       interface{}
+	DeleteZvols      interface{}
+	TPM              interface{}
+	UUID             interface{}
+	CPUSet           interface{}
+	NodeSet          interface{}
+	DeviceIDsByLabel interface{}
 	Disks            []vmDiskParams
 	NICs             []vmNICParams
 	CDROMs           []vmCDROMParams
@@ -168,14 +234,19 @@ type vmModelParams struct {
 }
 
 type vmDiskParams struct {
-	DeviceID           interface{}
-	Path               interface{}
-	Type               interface{}
-	LogicalSectorSize  interface{}
-	PhysicalSectorSize interface{}
-	IOType             interface{}
-	Serial             interface{}
-	Order              interface{}
+	DeviceID             interface{}
+	Path                 interface{}
+	Type                 interface{}
+	LogicalSectorSize    interface{}
+	PhysicalSectorSize   interface{}
+	IOType               interface{}
+	Serial               interface{}
+	Order                interface{}
+	Size                 interface{}
+	ZvolName             interface{}
+	Pool                 interface{}
+	ManageZvol           interface{}
+	DeleteBackingStorage interface{}
 }
 
 type vmNICParams struct {
@@ -185,6 +256,7 @@ type vmNICParams struct {
 	MAC                 interface{}
 	TrustGuestRXFilters interface{}
 	Order               interface{}
+	CreateBridge        []string
 }
 
 type vmCDROMParams struct {
@@ -203,6 +275,7 @@ type vmDisplayParams struct {
 	Wait       interface{}
 	Password   interface{}
 	Web        interface{}
+	WebURL     interface{}
 	Order      interface{}
 }
 
@@ -215,14 +288,19 @@ func createVMModelValue(p vmModelParams) tftypes.Value {
 	var diskValues []tftypes.Value
 	for _, d := range p.Disks {
 		diskValues = append(diskValues, tftypes.NewValue(vmDiskBlockType(), map[string]tftypes.Value{
-			"device_id":           tftypes.NewValue(tftypes.Number, d.DeviceID),
-			"path":                tftypes.NewValue(tftypes.String, d.Path),
-			"type":                tftypes.NewValue(tftypes.String, d.Type),
-			"logical_sectorsize":  tftypes.NewValue(tftypes.Number, d.LogicalSectorSize),
-			"physical_sectorsize": tftypes.NewValue(tftypes.Number, d.PhysicalSectorSize),
-			"iotype":              tftypes.NewValue(tftypes.String, d.IOType),
-			"serial":              tftypes.NewValue(tftypes.String, d.Serial),
-			"order":               tftypes.NewValue(tftypes.Number, d.Order),
+			"device_id":              tftypes.NewValue(tftypes.Number, d.DeviceID),
+			"path":                   tftypes.NewValue(tftypes.String, d.Path),
+			"type":                   tftypes.NewValue(tftypes.String, d.Type),
+			"logical_sectorsize":     tftypes.NewValue(tftypes.Number, d.LogicalSectorSize),
+			"physical_sectorsize":    tftypes.NewValue(tftypes.Number, d.PhysicalSectorSize),
+			"iotype":                 tftypes.NewValue(tftypes.String, d.IOType),
+			"serial":                 tftypes.NewValue(tftypes.String, d.Serial),
+			"order":                  tftypes.NewValue(tftypes.Number, d.Order),
+			"size":                   tftypes.NewValue(tftypes.String, d.Size),
+			"zvol_name":              tftypes.NewValue(tftypes.String, d.ZvolName),
+			"pool":                   tftypes.NewValue(tftypes.String, d.Pool),
+			"manage_zvol":            tftypes.NewValue(tftypes.Bool, d.ManageZvol),
+			"delete_backing_storage": tftypes.NewValue(tftypes.Bool, d.DeleteBackingStorage),
 		}))
 	}
 	diskList := emptyBlockList(vmDiskBlockType())
@@ -240,6 +318,7 @@ func createVMModelValue(p vmModelParams) tftypes.Value {
 			"mac":                    tftypes.NewValue(tftypes.String, n.MAC),
 			"trust_guest_rx_filters": tftypes.NewValue(tftypes.Bool, n.TrustGuestRXFilters),
 			"order":                  tftypes.NewValue(tftypes.Number, n.Order),
+			"create_bridge":          vmNICCreateBridgeValue(n.CreateBridge),
 		}))
 	}
 	nicList := emptyBlockList(vmNICBlockType())
@@ -274,6 +353,7 @@ func createVMModelValue(p vmModelParams) tftypes.Value {
 			"wait":       tftypes.NewValue(tftypes.Bool, d.Wait),
 			"password":   tftypes.NewValue(tftypes.String, d.Password),
 			"web":        tftypes.NewValue(tftypes.Bool, d.Web),
+			"web_url":    tftypes.NewValue(tftypes.String, d.WebURL),
 			"order":      tftypes.NewValue(tftypes.Number, d.Order),
 		}))
 	}
@@ -283,31 +363,41 @@ func createVMModelValue(p vmModelParams) tftypes.Value {
 	}
 
 	values := map[string]tftypes.Value{
-		"id":                tftypes.NewValue(tftypes.String, p.ID),
-		"name":              tftypes.NewValue(tftypes.String, p.Name),
-		"description":       tftypes.NewValue(tftypes.String, p.Description),
-		"vcpus":             tftypes.NewValue(tftypes.Number, p.VCPUs),
-		"cores":             tftypes.NewValue(tftypes.Number, p.Cores),
-		"threads":           tftypes.NewValue(tftypes.Number, p.Threads),
-		"memory":            tftypes.NewValue(tftypes.Number, p.Memory),
-		"min_memory":        tftypes.NewValue(tftypes.Number, p.MinMemory),
-		"autostart":         tftypes.NewValue(tftypes.Bool, p.Autostart),
-		"time":              tftypes.NewValue(tftypes.String, p.Time),
-		"bootloader":        tftypes.NewValue(tftypes.String, p.Bootloader),
-		"bootloader_ovmf":   tftypes.NewValue(tftypes.String, p.BootloaderOVMF),
-		"cpu_mode":          tftypes.NewValue(tftypes.String, p.CPUMode),
-		"cpu_model":         tftypes.NewValue(tftypes.String, p.CPUModel),
-		"shutdown_timeout":  tftypes.NewValue(tftypes.Number, p.ShutdownTimeout),
-		"command_line_args": tftypes.NewValue(tftypes.String, p.CommandLineArgs),
-		"state":             tftypes.NewValue(tftypes.String, p.State),
-		"display_available": tftypes.NewValue(tftypes.Bool, p.DisplayAvailable),
-		"disk":              diskList,
-		"raw":               emptyBlockList(vmRawBlockType()),
-		"cdrom":             cdromList,
-		"nic":               nicList,
-		"display":           displayList,
-		"pci":               emptyBlockList(vmPCIBlockType()),
-		"usb":               emptyBlockList(vmUSBBlockType()),
+		"id":                  tftypes.NewValue(tftypes.String, p.ID),
+		"name":                tftypes.NewValue(tftypes.String, p.Name),
+		"description":         tftypes.NewValue(tftypes.String, p.Description),
+		"vcpus":               tftypes.NewValue(tftypes.Number, p.VCPUs),
+		"cores":               tftypes.NewValue(tftypes.Number, p.Cores),
+		"threads":             tftypes.NewValue(tftypes.Number, p.Threads),
+		"memory":              tftypes.NewValue(tftypes.Number, p.Memory),
+		"min_memory":          tftypes.NewValue(tftypes.Number, p.MinMemory),
+		"autostart":           tftypes.NewValue(tftypes.Bool, p.Autostart),
+		"time":                tftypes.NewValue(tftypes.String, p.Time),
+		"bootloader":          tftypes.NewValue(tftypes.String, p.Bootloader),
+		"bootloader_ovmf":     tftypes.NewValue(tftypes.String, p.BootloaderOVMF),
+		"cpu_mode":            tftypes.NewValue(tftypes.String, p.CPUMode),
+		"cpu_model":           tftypes.NewValue(tftypes.String, p.CPUModel),
+		"shutdown_timeout":    tftypes.NewValue(tftypes.Number, p.ShutdownTimeout),
+		"command_line_args":   tftypes.NewValue(tftypes.String, p.CommandLineArgs),
+		"state":               tftypes.NewValue(tftypes.String, p.State),
+		"display_available":   tftypes.NewValue(tftypes.Bool, p.DisplayAvailable),
+		"force_stop_after":    tftypes.NewValue(tftypes.Number, p.ForceStopAfter),
+		"start_retries":       tftypes.NewValue(tftypes.Number, p.StartRetries),
+		"start_retry_delay":   tftypes.NewValue(tftypes.Number, p.StartRetryDelay),
+		"start_delay":         tftypes.NewValue(tftypes.Number, p.StartDelay),
+		"delete_zvols":        tftypes.NewValue(tftypes.Bool, p.DeleteZvols),
+		"tpm":                 tftypes.NewValue(tftypes.Bool, p.TPM),
+		"uuid":                tftypes.NewValue(tftypes.String, p.UUID),
+		"cpuset":              tftypes.NewValue(tftypes.String, p.CPUSet),
+		"nodeset":             tftypes.NewValue(tftypes.String, p.NodeSet),
+		"device_ids_by_label": tftypes.NewValue(tftypes.Map{ElementType: tftypes.Number}, p.DeviceIDsByLabel),
+		"disk":                diskList,
+		"raw":                 emptyBlockList(vmRawBlockType()),
+		"cdrom":               cdromList,
+		"nic":                 nicList,
+		"display":             displayList,
+		"pci":                 emptyBlockList(vmPCIBlockType()),
+		"usb":                 emptyBlockList(vmUSBBlockType()),
 	}
 
 	return tftypes.NewValue(vmObjectType(), values)
@@ -451,7 +541,7 @@ func TestVMResource_buildCreateOpts(t *testing.T) {
 	t.Run("minimal", func(t *testing.T) {
 		data := &VMResourceModel{
 			Name:   types.StringValue("test-vm"),
-			Memory: types.Int64Value(2048),
+			Memory: customtypes.NewMemoryMiBValue(2048),
 		}
 		opts := r.buildCreateOpts(data)
 		if opts.Name != "test-vm" {
@@ -465,15 +555,15 @@ func TestVMResource_buildCreateOpts(t *testing.T) {
 	t.Run("with optional fields", func(t *testing.T) {
 		data := &VMResourceModel{
 			Name:            types.StringValue("test-vm"),
-			Memory:          types.Int64Value(4096),
+			Memory:          customtypes.NewMemoryMiBValue(4096),
 			Description:     types.StringValue("A test VM"),
 			VCPUs:           types.Int64Value(2),
 			Cores:           types.Int64Value(2),
 			Threads:         types.Int64Value(2),
 			Autostart:       types.BoolValue(false),
-			Time:            types.StringValue("UTC"),
-			Bootloader:      types.StringValue("UEFI"),
-			CPUMode:         types.StringValue("HOST-PASSTHROUGH"),
+			Time:            customtypes.NewCaseInsensitiveStringValue("UTC"),
+			Bootloader:      customtypes.NewCaseInsensitiveStringValue("UEFI"),
+			CPUMode:         customtypes.NewCaseInsensitiveStringValue("HOST-PASSTHROUGH"),
 			CommandLineArgs: types.StringValue("-cpu host"),
 		}
 		opts := r.buildCreateOpts(data)
@@ -500,9 +590,9 @@ func TestVMResource_buildCreateOpts(t *testing.T) {
 	t.Run("null optional fields omitted", func(t *testing.T) {
 		data := &VMResourceModel{
 			Name:      types.StringValue("test-vm"),
-			Memory:    types.Int64Value(2048),
+			Memory:    customtypes.NewMemoryMiBValue(2048),
 			CPUModel:  types.StringNull(),
-			MinMemory: types.Int64Null(),
+			MinMemory: customtypes.NewMemoryMiBNull(),
 		}
 		opts := r.buildCreateOpts(data)
 		if opts.CPUModel != "" {
@@ -570,21 +660,24 @@ func TestVMResource_Create_WithStateRunning(t *testing.T) {
 	startCalled := false
 
 	r := &VMResource{
-		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
-			CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
-				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
-			},
-			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
-				return mockVM(1, "test-vm", 2048, "RUNNING"), nil
-			},
-			StartVMFunc: func(ctx context.Context, id int64) error {
-				startCalled = true
-				return nil
-			},
-			ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
-				return nil, nil
-			},
-		}}},
+		BaseResource: BaseResource{
+			client: &client.MockClient{},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+				},
+				StartVMFunc: func(ctx context.Context, id int64) error {
+					startCalled = true
+					return nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+		},
 	}
 
 	schemaResp := getVMResourceSchema(t)
@@ -612,11 +705,18 @@ func TestVMResource_Create_WithStateRunning(t *testing.T) {
 
 func TestVMResource_Create_APIError(t *testing.T) {
 	r := &VMResource{
-		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
-			CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
-				return nil, errors.New("vm already exists")
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`[]`), nil
+				},
 			},
-		}}},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return nil, errors.New("vm already exists")
+				},
+			}},
+		},
 	}
 
 	schemaResp := getVMResourceSchema(t)
@@ -634,7 +734,52 @@ func TestVMResource_Create_APIError(t *testing.T) {
 	}
 }
 
+func TestVMResource_Create_DeviceErrorKeepsVM(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+				return mockVM(7, "test-vm", 2048, "STOPPED"), nil
+			},
+			CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+				return nil, errors.New("device create failed")
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = nil
+	p.DeviceIDsByLabel = tftypes.UnknownValue
+	p.NICs = []vmNICParams{{NICAttach: "br0", Type: "VIRTIO", DeviceID: tftypes.UnknownValue}}
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when a device cannot be created")
+	}
+	if !resp.State.Raw.IsFullyKnown() {
+		t.Fatal("expected no unknown values in the state of a failed create")
+	}
+
+	// The VM is returned with the error so Terraform keeps track of it
+	var model VMResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "7" {
+		t.Errorf("expected the created VM in state, got ID %v", model.ID)
+	}
+	if len(model.NICs) != 1 || !model.NICs[0].DeviceID.IsNull() {
+		t.Errorf("expected the NIC that was not created to have a null device_id, got %v", model.NICs)
+	}
+}
+
 func TestVMResource_Create_WithDevices(t *testing.T) {
+	var mu sync.Mutex
 	var deviceCreateCalls []truenas.CreateVMDeviceOpts
 
 	r := &VMResource{
@@ -646,6 +791,8 @@ func TestVMResource_Create_WithDevices(t *testing.T) {
 				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
 			},
 			CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+				mu.Lock()
+				defer mu.Unlock()
 				deviceCreateCalls = append(deviceCreateCalls, opts)
 				return &truenas.VMDevice{ID: int64(len(deviceCreateCalls) + 100)}, nil
 			},
@@ -690,6 +837,66 @@ func TestVMResource_Create_WithDevices(t *testing.T) {
 	}
 }
 
+func TestVMResource_Create_DeviceIDs(t *testing.T) {
+	ids := map[truenas.DeviceType]int64{truenas.DeviceTypeDisk: 101, truenas.DeviceTypeNIC: 102}
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+			},
+			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+			},
+			CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+				return &truenas.VMDevice{ID: ids[opts.DeviceType]}, nil
+			},
+			ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+				// The API lists the NIC first
+				return []truenas.VMDevice{
+					{ID: 102, VM: 1, Order: 1001, DeviceType: truenas.DeviceTypeNIC,
+						NIC: &truenas.NICDevice{Type: "VIRTIO", NICAttach: "br0", MAC: "00:11:22:33:44:55"}},
+					{ID: 101, VM: 1, Order: 1000, DeviceType: truenas.DeviceTypeDisk,
+						Disk: &truenas.DiskDevice{Path: "/dev/zvol/tank/vms/disk0", Type: "VIRTIO"}},
+				}, nil
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.Disks = []vmDiskParams{{DeviceID: nil, Path: "/dev/zvol/tank/vms/disk0", Type: "VIRTIO", IOType: "THREADS"}}
+	p.NICs = []vmNICParams{{DeviceID: nil, Type: "VIRTIO", NICAttach: "br0", TrustGuestRXFilters: false}}
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Disks[0].DeviceID.ValueInt64() != 101 {
+		t.Errorf("expected disk device_id 101, got %v", model.Disks[0].DeviceID)
+	}
+	if model.NICs[0].DeviceID.ValueInt64() != 102 {
+		t.Errorf("expected nic device_id 102, got %v", model.NICs[0].DeviceID)
+	}
+
+	want := types.MapValueMust(types.Int64Type, map[string]attr.Value{
+		"disk.0": types.Int64Value(101),
+		"nic.0":  types.Int64Value(102),
+	})
+	if !model.DeviceIDsByLabel.Equal(want) {
+		t.Errorf("expected device_ids_by_label %v, got %v", want, model.DeviceIDsByLabel)
+	}
+}
+
 // -- Read tests --
 
 func TestVMResource_Read_Success(t *testing.T) {
@@ -962,6 +1169,152 @@ func TestVMResource_Delete_Running(t *testing.T) {
 	}
 }
 
+func TestVMResource_Delete_Running_ForceStopAfter_ShutsDownGracefully(t *testing.T) {
+	var stops []truenas.StopVMOpts
+	var getCalls int
+
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+				getCalls++
+				if getCalls == 1 {
+					return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+				}
+				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+			},
+			StopVMFunc: func(ctx context.Context, id int64, opts truenas.StopVMOpts) error {
+				stops = append(stops, opts)
+				return nil
+			},
+			DeleteVMFunc: func(ctx context.Context, id int64) error {
+				return nil
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.State = "RUNNING"
+	p.ForceStopAfter = int64(60)
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(stops) != 1 {
+		t.Fatalf("expected only the graceful vm.stop, got %v", stops)
+	}
+	if stops[0].Force || stops[0].ForceAfterTimeout {
+		t.Errorf("expected graceful stop options, got %+v", stops[0])
+	}
+}
+
+func TestVMResource_Delete_Running_ForceStopAfter_PowersOff(t *testing.T) {
+	var stops []truenas.StopVMOpts
+
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+				return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+			},
+			StopVMFunc: func(ctx context.Context, id int64, opts truenas.StopVMOpts) error {
+				stops = append(stops, opts)
+				if !opts.Force {
+					// Guest ignores the shutdown request
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			},
+			DeleteVMFunc: func(ctx context.Context, id int64) error {
+				return nil
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.State = "RUNNING"
+	p.ForceStopAfter = int64(0)
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(stops) != 2 {
+		t.Fatalf("expected graceful then forced vm.stop, got %v", stops)
+	}
+	if !stops[1].Force {
+		t.Errorf("expected second stop to force power off, got %+v", stops[1])
+	}
+}
+
+func TestVMResource_Delete_DeleteZvols(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				DeleteVMFunc: func(ctx context.Context, id int64) error {
+					t.Error("expected DeleteVM not to be called with delete_zvols")
+					return nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					capturedParams = params
+					return json.RawMessage(`true`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.DeleteZvols = true
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "vm.delete" {
+		t.Errorf("expected method 'vm.delete', got %q", capturedMethod)
+	}
+	args, ok := capturedParams.([]any)
+	if !ok || len(args) != 2 {
+		t.Fatalf("expected [id, options], got %#v", capturedParams)
+	}
+	if args[0] != int64(1) {
+		t.Errorf("expected VM ID 1, got %v", args[0])
+	}
+	if opts := args[1].(map[string]any); opts["zvols"] != true {
+		t.Errorf("expected zvols option, got %v", opts)
+	}
+}
+
 // -- ImportState tests --
 
 func TestVMResource_ImportState(t *testing.T) {
@@ -1071,7 +1424,7 @@ func TestVMResource_mapDevicesToModel(t *testing.T) {
 
 func TestVMResource_buildDiskDeviceOpts(t *testing.T) {
 	disk := &VMDiskModel{
-		Path:   types.StringValue("/dev/zvol/tank/vms/disk0"),
+		Path:   customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 		Type:   types.StringValue("VIRTIO"),
 		IOType: types.StringValue("THREADS"),
 	}
@@ -1103,7 +1456,7 @@ func TestVMResource_buildNICDeviceOpts(t *testing.T) {
 
 func TestVMResource_buildCDROMDeviceOpts(t *testing.T) {
 	cdrom := &VMCDROMModel{
-		Path: types.StringValue("/mnt/tank/iso/ubuntu.iso"),
+		Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/ubuntu.iso"),
 	}
 	opts := buildCDROMDeviceOpts(cdrom, 1)
 	if opts.DeviceType != truenas.DeviceTypeCDROM {
@@ -1173,7 +1526,7 @@ func TestVMResource_reconcileDevices(t *testing.T) {
 
 		plan := &VMResourceModel{
 			Disks: []VMDiskModel{{
-				Path: types.StringValue("/dev/zvol/tank/vms/new-disk"),
+				Path: customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/new-disk"),
 				Type: types.StringValue("VIRTIO"),
 			}},
 		}
@@ -1203,7 +1556,7 @@ func TestVMResource_reconcileDevices(t *testing.T) {
 		state := &VMResourceModel{
 			Disks: []VMDiskModel{{
 				DeviceID: types.Int64Value(50),
-				Path:     types.StringValue("/dev/zvol/tank/vms/old-disk"),
+				Path:     customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/old-disk"),
 				Type:     types.StringValue("VIRTIO"),
 			}},
 		}
@@ -1217,6 +1570,72 @@ func TestVMResource_reconcileDevices(t *testing.T) {
 		}
 	})
 
+	t.Run("delete removed device with backing storage", func(t *testing.T) {
+		var deletedIDs []int64
+		var calls []any
+		r := &VMResource{
+			BaseResource: BaseResource{
+				services: &services.TrueNASServices{VM: &truenas.MockVMService{
+					DeleteDeviceFunc: func(ctx context.Context, id int64) error {
+						deletedIDs = append(deletedIDs, id)
+						return nil
+					},
+				}},
+				client: &client.MockClient{
+					CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+						if method != "vm.device.delete" {
+							t.Errorf("unexpected method %q", method)
+						}
+						calls = append(calls, params)
+						return json.RawMessage(`true`), nil
+					},
+				},
+			},
+		}
+
+		plan := &VMResourceModel{}
+		state := &VMResourceModel{
+			Disks: []VMDiskModel{
+				{
+					DeviceID:             types.Int64Value(50),
+					Path:                 customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/old-disk"),
+					DeleteBackingStorage: types.BoolValue(true),
+				},
+				{
+					DeviceID:             types.Int64Value(51),
+					Path:                 customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/kept-zvol"),
+					DeleteBackingStorage: types.BoolValue(false),
+				},
+			},
+			Raws: []VMRawModel{{
+				DeviceID:             types.Int64Value(52),
+				Path:                 customtypes.NewFilesystemPathValue("/mnt/tank/vms/old.img"),
+				DeleteBackingStorage: types.BoolValue(true),
+			}},
+		}
+
+		err := r.reconcileDevices(context.Background(), 1, plan, state)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(deletedIDs) != 1 || deletedIDs[0] != 51 {
+			t.Errorf("expected only device 51 to be deleted without options, got %v", deletedIDs)
+		}
+
+		want := map[string]bool{
+			fmt.Sprint([]any{int64(50), map[string]any{"zvol": true}}):     true,
+			fmt.Sprint([]any{int64(52), map[string]any{"raw_file": true}}): true,
+		}
+		if len(calls) != len(want) {
+			t.Fatalf("expected %d vm.device.delete calls, got %v", len(want), calls)
+		}
+		for _, c := range calls {
+			if !want[fmt.Sprint(c)] {
+				t.Errorf("unexpected vm.device.delete params %v", c)
+			}
+		}
+	})
+
 	t.Run("update existing device", func(t *testing.T) {
 		var updatedParams []any
 		r := &VMResource{
@@ -1231,14 +1650,14 @@ func TestVMResource_reconcileDevices(t *testing.T) {
 		plan := &VMResourceModel{
 			Disks: []VMDiskModel{{
 				DeviceID: types.Int64Value(50),
-				Path:     types.StringValue("/dev/zvol/tank/vms/disk0"),
+				Path:     customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 				Type:     types.StringValue("VIRTIO"), // changed from AHCI
 			}},
 		}
 		state := &VMResourceModel{
 			Disks: []VMDiskModel{{
 				DeviceID: types.Int64Value(50),
-				Path:     types.StringValue("/dev/zvol/tank/vms/disk0"),
+				Path:     customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 				Type:     types.StringValue("AHCI"),
 			}},
 		}
@@ -1273,7 +1692,7 @@ func TestVMResource_reconcileDevices(t *testing.T) {
 
 		disk := VMDiskModel{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/dev/zvol/tank/vms/disk0"),
+			Path:     customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 			Type:     types.StringValue("VIRTIO"),
 		}
 		plan := &VMResourceModel{Disks: []VMDiskModel{disk}}
@@ -1295,15 +1714,21 @@ func TestVMResource_reconcileState(t *testing.T) {
 	t.Run("stopped to running calls vm.start", func(t *testing.T) {
 		var calledMethod string
 		r := &VMResource{
-			BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
-				StartVMFunc: func(ctx context.Context, id int64) error {
-					calledMethod = "vm.start"
-					return nil
-				},
-			}}},
+			BaseResource: BaseResource{
+				client: &client.MockClient{},
+				services: &services.TrueNASServices{VM: &truenas.MockVMService{
+					StartVMFunc: func(ctx context.Context, id int64) error {
+						calledMethod = "vm.start"
+						return nil
+					},
+					GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+						return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+					},
+				}},
+			},
 		}
 
-		err := r.reconcileState(context.Background(), 1, "STOPPED", "RUNNING")
+		err := r.reconcileState(context.Background(), 1, "STOPPED", "RUNNING", vmStartRetry{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1323,7 +1748,7 @@ func TestVMResource_reconcileState(t *testing.T) {
 			}}},
 		}
 
-		err := r.reconcileState(context.Background(), 1, "RUNNING", "STOPPED")
+		err := r.reconcileState(context.Background(), 1, "RUNNING", "STOPPED", vmStartRetry{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1343,7 +1768,7 @@ func TestVMResource_reconcileState(t *testing.T) {
 			}}},
 		}
 
-		err := r.reconcileState(context.Background(), 1, "RUNNING", "RUNNING")
+		err := r.reconcileState(context.Background(), 1, "RUNNING", "RUNNING", vmStartRetry{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1353,23 +1778,86 @@ func TestVMResource_reconcileState(t *testing.T) {
 	})
 }
 
-// -- Additional helper types for raw/pci/usb model values --
+func TestVMResource_waitForVMState(t *testing.T) {
+	t.Run("returns once state is reached", func(t *testing.T) {
+		calls := 0
+		r := &VMResource{
+			BaseResource: BaseResource{
+				client: &client.MockClient{},
+				services: &services.TrueNASServices{VM: &truenas.MockVMService{
+					GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+						calls++
+						if calls < 2 {
+							return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+						}
+						return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+					},
+				}},
+			},
+		}
 
-type vmRawParams struct {
-	DeviceID           interface{}
-	Path               interface{}
-	Type               interface{}
-	Boot               interface{}
-	Exists             interface{}
-	Size               interface{}
-	LogicalSectorSize  interface{}
-	PhysicalSectorSize interface{}
-	IOType             interface{}
-	Serial             interface{}
-	Order              interface{}
-}
+		if err := r.waitForVMState(context.Background(), 1, VMStateRunning, time.Second); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("expected 2 vm lookups, got %d", calls)
+		}
+	})
 
-type vmPCIParams struct {
+	t.Run("timeout reports last state", func(t *testing.T) {
+		r := &VMResource{
+			BaseResource: BaseResource{
+				client: &client.MockClient{},
+				services: &services.TrueNASServices{VM: &truenas.MockVMService{
+					GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+						return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+					},
+				}},
+			},
+		}
+
+		err := r.waitForVMState(context.Background(), 1, VMStateRunning, 50*time.Millisecond)
+		if err == nil {
+			t.Fatal("expected timeout error")
+		}
+		if !strings.Contains(err.Error(), `last state "STOPPED"`) {
+			t.Errorf("expected last state in error, got %q", err.Error())
+		}
+	})
+
+	t.Run("vm deleted", func(t *testing.T) {
+		r := &VMResource{
+			BaseResource: BaseResource{
+				client:   &client.MockClient{},
+				services: &services.TrueNASServices{VM: &truenas.MockVMService{}},
+			},
+		}
+
+		err := r.waitForVMState(context.Background(), 1, VMStateRunning, time.Second)
+		if err == nil {
+			t.Fatal("expected error when VM disappears")
+		}
+	})
+}
+
+// -- Additional helper types for raw/pci/usb model values --
+
+type vmRawParams struct {
+	DeviceID             interface{}
+	Path                 interface{}
+	Type                 interface{}
+	Boot                 interface{}
+	Exists               interface{}
+	Size                 interface{}
+	LogicalSectorSize    interface{}
+	PhysicalSectorSize   interface{}
+	IOType               interface{}
+	Serial               interface{}
+	Order                interface{}
+	DeleteBackingStorage interface{}
+}
+
+type vmPCIParams struct {
 	DeviceID interface{}
 	PPTDev   interface{}
 	Order    interface{}
@@ -1390,14 +1878,19 @@ func createVMModelValueFull(p vmModelParams, raws []vmRawParams, pcis []vmPCIPar
 	var diskValues []tftypes.Value
 	for _, d := range p.Disks {
 		diskValues = append(diskValues, tftypes.NewValue(vmDiskBlockType(), map[string]tftypes.Value{
-			"device_id":           tftypes.NewValue(tftypes.Number, d.DeviceID),
-			"path":                tftypes.NewValue(tftypes.String, d.Path),
-			"type":                tftypes.NewValue(tftypes.String, d.Type),
-			"logical_sectorsize":  tftypes.NewValue(tftypes.Number, d.LogicalSectorSize),
-			"physical_sectorsize": tftypes.NewValue(tftypes.Number, d.PhysicalSectorSize),
-			"iotype":              tftypes.NewValue(tftypes.String, d.IOType),
-			"serial":              tftypes.NewValue(tftypes.String, d.Serial),
-			"order":               tftypes.NewValue(tftypes.Number, d.Order),
+			"device_id":              tftypes.NewValue(tftypes.Number, d.DeviceID),
+			"path":                   tftypes.NewValue(tftypes.String, d.Path),
+			"type":                   tftypes.NewValue(tftypes.String, d.Type),
+			"logical_sectorsize":     tftypes.NewValue(tftypes.Number, d.LogicalSectorSize),
+			"physical_sectorsize":    tftypes.NewValue(tftypes.Number, d.PhysicalSectorSize),
+			"iotype":                 tftypes.NewValue(tftypes.String, d.IOType),
+			"serial":                 tftypes.NewValue(tftypes.String, d.Serial),
+			"order":                  tftypes.NewValue(tftypes.Number, d.Order),
+			"size":                   tftypes.NewValue(tftypes.String, d.Size),
+			"zvol_name":              tftypes.NewValue(tftypes.String, d.ZvolName),
+			"pool":                   tftypes.NewValue(tftypes.String, d.Pool),
+			"manage_zvol":            tftypes.NewValue(tftypes.Bool, d.ManageZvol),
+			"delete_backing_storage": tftypes.NewValue(tftypes.Bool, d.DeleteBackingStorage),
 		}))
 	}
 	diskList := emptyBlockList(vmDiskBlockType())
@@ -1415,6 +1908,7 @@ func createVMModelValueFull(p vmModelParams, raws []vmRawParams, pcis []vmPCIPar
 			"mac":                    tftypes.NewValue(tftypes.String, n.MAC),
 			"trust_guest_rx_filters": tftypes.NewValue(tftypes.Bool, n.TrustGuestRXFilters),
 			"order":                  tftypes.NewValue(tftypes.Number, n.Order),
+			"create_bridge":          vmNICCreateBridgeValue(n.CreateBridge),
 		}))
 	}
 	nicList := emptyBlockList(vmNICBlockType())
@@ -1449,6 +1943,7 @@ func createVMModelValueFull(p vmModelParams, raws []vmRawParams, pcis []vmPCIPar
 			"wait":       tftypes.NewValue(tftypes.Bool, d.Wait),
 			"password":   tftypes.NewValue(tftypes.String, d.Password),
 			"web":        tftypes.NewValue(tftypes.Bool, d.Web),
+			"web_url":    tftypes.NewValue(tftypes.String, d.WebURL),
 			"order":      tftypes.NewValue(tftypes.Number, d.Order),
 		}))
 	}
@@ -1461,17 +1956,18 @@ func createVMModelValueFull(p vmModelParams, raws []vmRawParams, pcis []vmPCIPar
 	var rawValues []tftypes.Value
 	for _, r := range raws {
 		rawValues = append(rawValues, tftypes.NewValue(vmRawBlockType(), map[string]tftypes.Value{
-			"device_id":           tftypes.NewValue(tftypes.Number, r.DeviceID),
-			"path":                tftypes.NewValue(tftypes.String, r.Path),
-			"type":                tftypes.NewValue(tftypes.String, r.Type),
-			"boot":                tftypes.NewValue(tftypes.Bool, r.Boot),
-			"exists":              tftypes.NewValue(tftypes.Bool, r.Exists),
-			"size":                tftypes.NewValue(tftypes.Number, r.Size),
-			"logical_sectorsize":  tftypes.NewValue(tftypes.Number, r.LogicalSectorSize),
-			"physical_sectorsize": tftypes.NewValue(tftypes.Number, r.PhysicalSectorSize),
-			"iotype":              tftypes.NewValue(tftypes.String, r.IOType),
-			"serial":              tftypes.NewValue(tftypes.String, r.Serial),
-			"order":               tftypes.NewValue(tftypes.Number, r.Order),
+			"device_id":              tftypes.NewValue(tftypes.Number, r.DeviceID),
+			"path":                   tftypes.NewValue(tftypes.String, r.Path),
+			"type":                   tftypes.NewValue(tftypes.String, r.Type),
+			"boot":                   tftypes.NewValue(tftypes.Bool, r.Boot),
+			"exists":                 tftypes.NewValue(tftypes.Bool, r.Exists),
+			"size":                   tftypes.NewValue(tftypes.Number, r.Size),
+			"logical_sectorsize":     tftypes.NewValue(tftypes.Number, r.LogicalSectorSize),
+			"physical_sectorsize":    tftypes.NewValue(tftypes.Number, r.PhysicalSectorSize),
+			"iotype":                 tftypes.NewValue(tftypes.String, r.IOType),
+			"serial":                 tftypes.NewValue(tftypes.String, r.Serial),
+			"order":                  tftypes.NewValue(tftypes.Number, r.Order),
+			"delete_backing_storage": tftypes.NewValue(tftypes.Bool, r.DeleteBackingStorage),
 		}))
 	}
 	rawList := emptyBlockList(vmRawBlockType())
@@ -1509,31 +2005,41 @@ func createVMModelValueFull(p vmModelParams, raws []vmRawParams, pcis []vmPCIPar
 	}
 
 	values := map[string]tftypes.Value{
-		"id":                tftypes.NewValue(tftypes.String, p.ID),
-		"name":              tftypes.NewValue(tftypes.String, p.Name),
-		"description":       tftypes.NewValue(tftypes.String, p.Description),
-		"vcpus":             tftypes.NewValue(tftypes.Number, p.VCPUs),
-		"cores":             tftypes.NewValue(tftypes.Number, p.Cores),
-		"threads":           tftypes.NewValue(tftypes.Number, p.Threads),
-		"memory":            tftypes.NewValue(tftypes.Number, p.Memory),
-		"min_memory":        tftypes.NewValue(tftypes.Number, p.MinMemory),
-		"autostart":         tftypes.NewValue(tftypes.Bool, p.Autostart),
-		"time":              tftypes.NewValue(tftypes.String, p.Time),
-		"bootloader":        tftypes.NewValue(tftypes.String, p.Bootloader),
-		"bootloader_ovmf":   tftypes.NewValue(tftypes.String, p.BootloaderOVMF),
-		"cpu_mode":          tftypes.NewValue(tftypes.String, p.CPUMode),
-		"cpu_model":         tftypes.NewValue(tftypes.String, p.CPUModel),
-		"shutdown_timeout":  tftypes.NewValue(tftypes.Number, p.ShutdownTimeout),
-		"command_line_args": tftypes.NewValue(tftypes.String, p.CommandLineArgs),
-		"state":             tftypes.NewValue(tftypes.String, p.State),
-		"display_available": tftypes.NewValue(tftypes.Bool, p.DisplayAvailable),
-		"disk":              diskList,
-		"raw":               rawList,
-		"cdrom":             cdromList,
-		"nic":               nicList,
-		"display":           displayList,
-		"pci":               pciList,
-		"usb":               usbList,
+		"id":                  tftypes.NewValue(tftypes.String, p.ID),
+		"name":                tftypes.NewValue(tftypes.String, p.Name),
+		"description":         tftypes.NewValue(tftypes.String, p.Description),
+		"vcpus":               tftypes.NewValue(tftypes.Number, p.VCPUs),
+		"cores":               tftypes.NewValue(tftypes.Number, p.Cores),
+		"threads":             tftypes.NewValue(tftypes.Number, p.Threads),
+		"memory":              tftypes.NewValue(tftypes.Number, p.Memory),
+		"min_memory":          tftypes.NewValue(tftypes.Number, p.MinMemory),
+		"autostart":           tftypes.NewValue(tftypes.Bool, p.Autostart),
+		"time":                tftypes.NewValue(tftypes.String, p.Time),
+		"bootloader":          tftypes.NewValue(tftypes.String, p.Bootloader),
+		"bootloader_ovmf":     tftypes.NewValue(tftypes.String, p.BootloaderOVMF),
+		"cpu_mode":            tftypes.NewValue(tftypes.String, p.CPUMode),
+		"cpu_model":           tftypes.NewValue(tftypes.String, p.CPUModel),
+		"shutdown_timeout":    tftypes.NewValue(tftypes.Number, p.ShutdownTimeout),
+		"command_line_args":   tftypes.NewValue(tftypes.String, p.CommandLineArgs),
+		"state":               tftypes.NewValue(tftypes.String, p.State),
+		"display_available":   tftypes.NewValue(tftypes.Bool, p.DisplayAvailable),
+		"force_stop_after":    tftypes.NewValue(tftypes.Number, p.ForceStopAfter),
+		"start_retries":       tftypes.NewValue(tftypes.Number, p.StartRetries),
+		"start_retry_delay":   tftypes.NewValue(tftypes.Number, p.StartRetryDelay),
+		"start_delay":         tftypes.NewValue(tftypes.Number, p.StartDelay),
+		"delete_zvols":        tftypes.NewValue(tftypes.Bool, p.DeleteZvols),
+		"tpm":                 tftypes.NewValue(tftypes.Bool, p.TPM),
+		"uuid":                tftypes.NewValue(tftypes.String, p.UUID),
+		"cpuset":              tftypes.NewValue(tftypes.String, p.CPUSet),
+		"nodeset":             tftypes.NewValue(tftypes.String, p.NodeSet),
+		"device_ids_by_label": tftypes.NewValue(tftypes.Map{ElementType: tftypes.Number}, p.DeviceIDsByLabel),
+		"disk":                diskList,
+		"raw":                 rawList,
+		"cdrom":               cdromList,
+		"nic":                 nicList,
+		"display":             displayList,
+		"pci":                 pciList,
+		"usb":                 usbList,
 	}
 
 	return tftypes.NewValue(vmObjectType(), values)
@@ -1574,6 +2080,131 @@ func TestVMResource_Create_DeviceCreateError(t *testing.T) {
 	}
 }
 
+func TestVMResource_Create_DeviceCreateErrorStopsGroup(t *testing.T) {
+	var mu sync.Mutex
+	var diskPaths []string
+
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+			},
+			CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+				if opts.DeviceType == truenas.DeviceTypeDisk {
+					mu.Lock()
+					diskPaths = append(diskPaths, opts.Disk.Path)
+					mu.Unlock()
+					return nil, errors.New("zvol busy")
+				}
+				return &truenas.VMDevice{ID: 200}, nil
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.Disks = []vmDiskParams{
+		{Path: "/dev/zvol/tank/vms/disk0", Type: "VIRTIO", IOType: "THREADS"},
+		{Path: "/dev/zvol/tank/vms/disk1", Type: "VIRTIO", IOType: "THREADS"},
+	}
+	p.NICs = []vmNICParams{{Type: "VIRTIO", NICAttach: "br0", TrustGuestRXFilters: false}}
+	planValue := createVMModelValue(p)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when device creation fails")
+	}
+	// Disks are created in order, so the second is never attempted
+	if len(diskPaths) != 1 || diskPaths[0] != "/dev/zvol/tank/vms/disk0" {
+		t.Errorf("expected only disk0 to be attempted, got %v", diskPaths)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "disk device 0") {
+		t.Errorf("expected error detail to mention disk device 0, got %q", detail)
+	}
+}
+
+func TestVMResource_createDevices_OrderedWithinGroup(t *testing.T) {
+	var mu sync.Mutex
+	var storageOrder, nicOrder []string
+	var storageInFlight, maxStorageInFlight atomic.Int32
+	var nextID atomic.Int64
+
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+				var label string
+				switch opts.DeviceType {
+				case truenas.DeviceTypeDisk:
+					label = opts.Disk.Path
+				case truenas.DeviceTypeCDROM:
+					label = opts.CDROM.Path
+				case truenas.DeviceTypeNIC:
+					label = opts.NIC.NICAttach
+				}
+
+				if opts.DeviceType != truenas.DeviceTypeNIC {
+					n := storageInFlight.Add(1)
+					defer storageInFlight.Add(-1)
+					if n > maxStorageInFlight.Load() {
+						maxStorageInFlight.Store(n)
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+
+				mu.Lock()
+				if opts.DeviceType == truenas.DeviceTypeNIC {
+					nicOrder = append(nicOrder, label)
+				} else {
+					storageOrder = append(storageOrder, label)
+				}
+				mu.Unlock()
+				return &truenas.VMDevice{ID: nextID.Add(1)}, nil
+			},
+		}}},
+	}
+
+	data := &VMResourceModel{}
+	for i := 0; i < 3; i++ {
+		data.Disks = append(data.Disks, VMDiskModel{
+			Path:   customtypes.NewFilesystemPathValue(fmt.Sprintf("/dev/zvol/tank/vms/disk%d", i)),
+			Type:   types.StringValue("VIRTIO"),
+			IOType: types.StringValue("THREADS"),
+		})
+	}
+	data.CDROMs = []VMCDROMModel{{Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/install.iso")}}
+	data.NICs = []VMNICModel{
+		{Type: types.StringValue("VIRTIO"), NICAttach: types.StringValue("br0")},
+		{Type: types.StringValue("VIRTIO"), NICAttach: types.StringValue("br1")},
+	}
+
+	if err := r.createDevices(context.Background(), 1, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantStorage := []string{"/dev/zvol/tank/vms/disk0", "/dev/zvol/tank/vms/disk1", "/dev/zvol/tank/vms/disk2", "/mnt/tank/iso/install.iso"}
+	if fmt.Sprint(storageOrder) != fmt.Sprint(wantStorage) {
+		t.Errorf("expected storage devices in order %v, got %v", wantStorage, storageOrder)
+	}
+	if fmt.Sprint(nicOrder) != "[br0 br1]" {
+		t.Errorf("expected NICs in order [br0 br1], got %v", nicOrder)
+	}
+	if got := maxStorageInFlight.Load(); got != 1 {
+		t.Errorf("expected storage devices to be created one at a time, got %d at once", got)
+	}
+	for i := 1; i < len(data.Disks); i++ {
+		if data.Disks[i].DeviceID.ValueInt64() <= data.Disks[i-1].DeviceID.ValueInt64() {
+			t.Errorf("expected disk device IDs in configuration order, got %v", data.Disks)
+		}
+	}
+}
+
 func TestVMResource_Create_StartError(t *testing.T) {
 	r := &VMResource{
 		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
@@ -2046,6 +2677,316 @@ func TestVMResource_Create_PCIDeviceCreateError(t *testing.T) {
 	}
 }
 
+func TestVMResource_Create_VirtualizationUnsupported(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return nil, errors.New("[EINVAL] vm_create.name: This system does not support virtualization.")
+				},
+			}},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(defaultVMPlanParams())},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	d := resp.Diagnostics.Errors()[0]
+	if d.Summary() != "Virtualization Not Available" {
+		t.Errorf("expected 'Virtualization Not Available', got %q", d.Summary())
+	}
+	if !strings.Contains(d.Detail(), "VT-x") {
+		t.Errorf("expected a remediation hint, got %q", d.Detail())
+	}
+}
+
+func TestVMResource_Create_IOMMUDisabled(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+			},
+			CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+				return nil, errors.New("[EINVAL] vm_device_create.attributes.pptdev: IOMMU support is required.")
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	planValue := createVMModelValueFull(defaultVMPlanParams(), nil,
+		[]vmPCIParams{{DeviceID: nil, PPTDev: "0000:01:00.0", Order: nil}},
+		nil,
+	)
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	d := resp.Diagnostics.Errors()[0]
+	if d.Summary() != "IOMMU Not Enabled" {
+		t.Errorf("expected 'IOMMU Not Enabled', got %q", d.Summary())
+	}
+	withPath, ok := d.(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("pci")) {
+		t.Errorf("expected the error to be scoped to 'pci', got %v", d)
+	}
+}
+
+func TestMatchVMHostCapabilityError(t *testing.T) {
+	tests := []struct {
+		err     error
+		summary string
+	}{
+		{errors.New("[EFAULT] unsupported configuration: Domain requires KVM, but it is not available"), "Virtualization Not Available"},
+		{errors.New("[EINVAL] vm_device_create.attributes.pptdev: 0000:01:00.0 is not available for PCI passthru"), "PCI Device Not Available for Passthrough"},
+		{errors.New("[EINVAL] vm_create.memory: Too small"), ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		capability, ok := matchVMHostCapabilityError(tt.err)
+		if tt.summary == "" {
+			if ok {
+				t.Errorf("expected no match for %v, got %q", tt.err, capability.summary)
+			}
+			continue
+		}
+		if !ok || capability.summary != tt.summary {
+			t.Errorf("expected %q for %v, got %v", tt.summary, tt.err, capability)
+		}
+	}
+}
+
+func TestIsVMOutOfMemoryError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("[ENOMEM] Cannot guarantee memory for guest web"), true},
+		{errors.New("Not enough memory to start the VM"), true},
+		{errors.New("[EFAULT] unsupported configuration"), false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := isVMOutOfMemoryError(tt.err); got != tt.want {
+			t.Errorf("isVMOutOfMemoryError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestVMResource_startVM_RetriesOutOfMemory(t *testing.T) {
+	starts := 0
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			StartVMFunc: func(ctx context.Context, id int64) error {
+				starts++
+				if starts < 3 {
+					return errors.New("[ENOMEM] Cannot guarantee memory for guest test-vm")
+				}
+				return nil
+			},
+		}}},
+	}
+
+	err := r.startVM(context.Background(), 1, vmStartRetry{attempts: 2, delay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if starts != 3 {
+		t.Errorf("expected 3 vm.start calls, got %d", starts)
+	}
+}
+
+func TestVMResource_startVM_OutOfMemoryExhausted(t *testing.T) {
+	starts := 0
+	var calledMethod string
+	r := &VMResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					calledMethod = method
+					return json.RawMessage(`1073741824`), nil
+				},
+			},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				StartVMFunc: func(ctx context.Context, id int64) error {
+					starts++
+					return errors.New("[ENOMEM] Cannot guarantee memory for guest test-vm")
+				},
+			}},
+		},
+	}
+
+	err := r.startVM(context.Background(), 1, vmStartRetry{attempts: 1, delay: time.Millisecond})
+
+	var oom *vmOutOfMemoryError
+	if !errors.As(err, &oom) {
+		t.Fatalf("expected *vmOutOfMemoryError, got %v", err)
+	}
+	if starts != 2 {
+		t.Errorf("expected 2 vm.start calls, got %d", starts)
+	}
+	if calledMethod != "vm.get_available_memory" {
+		t.Errorf("expected vm.get_available_memory, got %q", calledMethod)
+	}
+	if oom.available == nil || *oom.available != 1073741824 {
+		t.Errorf("expected 1073741824 bytes available, got %v", oom.available)
+	}
+}
+
+func TestVMResource_startVM_OtherErrorNotRetried(t *testing.T) {
+	starts := 0
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			StartVMFunc: func(ctx context.Context, id int64) error {
+				starts++
+				return errors.New("[EFAULT] domain failed to start")
+			},
+		}}},
+	}
+
+	err := r.startVM(context.Background(), 1, vmStartRetry{attempts: 3, delay: time.Millisecond})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	var oom *vmOutOfMemoryError
+	if errors.As(err, &oom) {
+		t.Error("expected a plain error, got *vmOutOfMemoryError")
+	}
+	if starts != 1 {
+		t.Errorf("expected 1 vm.start call, got %d", starts)
+	}
+}
+
+func TestVMResource_Create_OutOfMemory(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`536870912`), nil
+				},
+			},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				StartVMFunc: func(ctx context.Context, id int64) error {
+					return errors.New("[ENOMEM] Cannot guarantee memory for guest test-vm")
+				},
+			}},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.State = "RUNNING"
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	d := resp.Diagnostics.Errors()[0]
+	if d.Summary() != "Insufficient Memory to Start VM" {
+		t.Errorf("expected 'Insufficient Memory to Start VM', got %q", d.Summary())
+	}
+	if !strings.Contains(d.Detail(), "512 MiB available") {
+		t.Errorf("expected available memory in detail, got %q", d.Detail())
+	}
+	withPath, ok := d.(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("memory")) {
+		t.Errorf("expected the error to be scoped to 'memory', got %v", d)
+	}
+}
+
+func TestVMResource_Schema_StartRetries(t *testing.T) {
+	schemaResp := getVMResourceSchema(t)
+	for _, name := range []string{"start_retries", "start_retry_delay", "start_delay"} {
+		attr, ok := schemaResp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("expected '%s' attribute", name)
+		}
+		if !attr.IsOptional() || attr.IsComputed() {
+			t.Errorf("expected '%s' to be optional and not computed", name)
+		}
+	}
+}
+
+func TestVMStartRetryFromModel(t *testing.T) {
+	retry := vmStartRetryFromModel(&VMResourceModel{StartRetries: types.Int64Null(), StartRetryDelay: types.Int64Null()})
+	if retry.attempts != 0 || retry.delay != vmStartRetryDelay {
+		t.Errorf("unexpected defaults: %+v", retry)
+	}
+
+	retry = vmStartRetryFromModel(&VMResourceModel{StartRetries: types.Int64Value(3), StartRetryDelay: types.Int64Value(5)})
+	if retry.attempts != 3 || retry.delay != 5*time.Second {
+		t.Errorf("unexpected retry: %+v", retry)
+	}
+
+	retry = vmStartRetryFromModel(&VMResourceModel{StartDelay: types.Int64Value(20)})
+	if retry.wait != 20*time.Second {
+		t.Errorf("expected a 20s start delay, got %+v", retry)
+	}
+}
+
+func TestVMResource_startVM_StartDelay(t *testing.T) {
+	starts := 0
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			StartVMFunc: func(ctx context.Context, id int64) error {
+				starts++
+				return nil
+			},
+		}}},
+	}
+
+	begin := time.Now()
+	if err := r.startVM(context.Background(), 1, vmStartRetry{wait: 20 * time.Millisecond}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed < 20*time.Millisecond {
+		t.Errorf("expected vm.start to wait for the start delay, started after %s", elapsed)
+	}
+	if starts != 1 {
+		t.Errorf("expected 1 vm.start call, got %d", starts)
+	}
+
+	// A cancelled apply does not start the VM
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := r.startVM(ctx, 1, vmStartRetry{wait: time.Hour}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if starts != 1 {
+		t.Errorf("expected no further vm.start calls, got %d", starts)
+	}
+}
+
 func TestVMResource_Create_USBDeviceCreateError(t *testing.T) {
 	r := &VMResource{
 		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
@@ -2208,21 +3149,27 @@ func TestVMResource_Update_WithDeviceReconciliation(t *testing.T) {
 func TestVMResource_Update_StateTransition(t *testing.T) {
 	startCalled := false
 	r := &VMResource{
-		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
-			UpdateVMFunc: func(ctx context.Context, id int64, opts truenas.UpdateVMOpts) (*truenas.VM, error) {
-				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
-			},
-			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
-				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
-			},
-			ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
-				return nil, nil
-			},
-			StartVMFunc: func(ctx context.Context, id int64) error {
-				startCalled = true
-				return nil
-			},
-		}}},
+		BaseResource: BaseResource{
+			client: &client.MockClient{},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				UpdateVMFunc: func(ctx context.Context, id int64, opts truenas.UpdateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					if startCalled {
+						return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+					}
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+				StartVMFunc: func(ctx context.Context, id int64) error {
+					startCalled = true
+					return nil
+				},
+			}},
+		},
 	}
 
 	schemaResp := getVMResourceSchema(t)
@@ -2413,21 +3360,29 @@ func TestVMResource_Update_StateReconcileError(t *testing.T) {
 func TestVMResource_Update_ReadBackError(t *testing.T) {
 	getInstanceCallCount := 0
 	r := &VMResource{
-		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
-			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
-				getInstanceCallCount++
-				if getInstanceCallCount >= 2 {
-					return nil, errors.New("read-back failed")
-				}
-				return mockVM(1, "test-vm", 2048, "STOPPED"), nil
-			},
-			ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
-				return nil, nil
-			},
-			StartVMFunc: func(ctx context.Context, id int64) error {
-				return nil
-			},
-		}}},
+		BaseResource: BaseResource{
+			client: &client.MockClient{},
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					getInstanceCallCount++
+					switch getInstanceCallCount {
+					case 1:
+						return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+					case 2:
+						// Start wait
+						return mockVM(1, "test-vm", 2048, "RUNNING"), nil
+					default:
+						return nil, errors.New("read-back failed")
+					}
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+				StartVMFunc: func(ctx context.Context, id int64) error {
+					return nil
+				},
+			}},
+		},
 	}
 
 	schemaResp := getVMResourceSchema(t)
@@ -2605,7 +3560,7 @@ func TestVMResource_buildUpdateOpts(t *testing.T) {
 	t.Run("no changes returns nil", func(t *testing.T) {
 		data := &VMResourceModel{
 			Name:   types.StringValue("test-vm"),
-			Memory: types.Int64Value(2048),
+			Memory: customtypes.NewMemoryMiBValue(2048),
 		}
 		opts, changed := r.buildUpdateOpts(data, data)
 		if changed {
@@ -2623,13 +3578,13 @@ func TestVMResource_buildUpdateOpts(t *testing.T) {
 			VCPUs:           types.Int64Value(4),
 			Cores:           types.Int64Value(4),
 			Threads:         types.Int64Value(2),
-			Memory:          types.Int64Value(8192),
-			MinMemory:       types.Int64Value(4096),
+			Memory:          customtypes.NewMemoryMiBValue(8192),
+			MinMemory:       customtypes.NewMemoryMiBValue(4096),
 			Autostart:       types.BoolValue(false),
-			Time:            types.StringValue("UTC"),
-			Bootloader:      types.StringValue("UEFI_CSM"),
+			Time:            customtypes.NewCaseInsensitiveStringValue("UTC"),
+			Bootloader:      customtypes.NewCaseInsensitiveStringValue("UEFI_CSM"),
 			BootloaderOVMF:  types.StringValue("OVMF_CODE_TPM.fd"),
-			CPUMode:         types.StringValue("HOST-PASSTHROUGH"),
+			CPUMode:         customtypes.NewCaseInsensitiveStringValue("HOST-PASSTHROUGH"),
 			CPUModel:        types.StringValue("Haswell"),
 			ShutdownTimeout: types.Int64Value(120),
 			CommandLineArgs: types.StringValue("-cpu host"),
@@ -2640,13 +3595,13 @@ func TestVMResource_buildUpdateOpts(t *testing.T) {
 			VCPUs:           types.Int64Value(1),
 			Cores:           types.Int64Value(1),
 			Threads:         types.Int64Value(1),
-			Memory:          types.Int64Value(2048),
-			MinMemory:       types.Int64Null(),
+			Memory:          customtypes.NewMemoryMiBValue(2048),
+			MinMemory:       customtypes.NewMemoryMiBNull(),
 			Autostart:       types.BoolValue(true),
-			Time:            types.StringValue("LOCAL"),
-			Bootloader:      types.StringValue("UEFI"),
+			Time:            customtypes.NewCaseInsensitiveStringValue("LOCAL"),
+			Bootloader:      customtypes.NewCaseInsensitiveStringValue("UEFI"),
 			BootloaderOVMF:  types.StringValue("OVMF_CODE.fd"),
-			CPUMode:         types.StringValue("CUSTOM"),
+			CPUMode:         customtypes.NewCaseInsensitiveStringValue("CUSTOM"),
 			CPUModel:        types.StringNull(),
 			ShutdownTimeout: types.Int64Value(90),
 			CommandLineArgs: types.StringValue(""),
@@ -2699,11 +3654,11 @@ func TestVMResource_buildUpdateOpts(t *testing.T) {
 	t.Run("min_memory set to null", func(t *testing.T) {
 		plan := &VMResourceModel{
 			Name:      types.StringValue("test-vm"),
-			MinMemory: types.Int64Null(),
+			MinMemory: customtypes.NewMemoryMiBNull(),
 		}
 		state := &VMResourceModel{
 			Name:      types.StringValue("test-vm"),
-			MinMemory: types.Int64Value(1024),
+			MinMemory: customtypes.NewMemoryMiBValue(1024),
 		}
 		opts, changed := r.buildUpdateOpts(plan, state)
 		if !changed {
@@ -2714,6 +3669,37 @@ func TestVMResource_buildUpdateOpts(t *testing.T) {
 		}
 	})
 
+	t.Run("memory in bytes matches MiB state", func(t *testing.T) {
+		plan := &VMResourceModel{
+			Name:   types.StringValue("test-vm"),
+			Memory: customtypes.NewMemoryMiBValue(4294967296),
+		}
+		state := &VMResourceModel{
+			Name:   types.StringValue("test-vm"),
+			Memory: customtypes.NewMemoryMiBValue(4096),
+		}
+		if _, changed := r.buildUpdateOpts(plan, state); changed {
+			t.Error("expected no changes when memory differs only by unit")
+		}
+	})
+
+	t.Run("enum differs only by case", func(t *testing.T) {
+		plan := &VMResourceModel{
+			Name:    types.StringValue("test-vm"),
+			CPUMode: customtypes.NewCaseInsensitiveStringValue("host-passthrough"),
+		}
+		state := &VMResourceModel{
+			Name:    types.StringValue("test-vm"),
+			CPUMode: customtypes.NewCaseInsensitiveStringValue("HOST-PASSTHROUGH"),
+		}
+		if _, changed := r.buildUpdateOpts(plan, state); changed {
+			t.Error("expected no changes when cpu_mode differs only by case")
+		}
+		if opts := r.buildCreateOpts(plan); opts.CPUMode != "HOST-PASSTHROUGH" {
+			t.Errorf("expected cpu_mode sent as HOST-PASSTHROUGH, got %q", opts.CPUMode)
+		}
+	})
+
 	t.Run("cpu_model set to null", func(t *testing.T) {
 		plan := &VMResourceModel{
 			Name:     types.StringValue("test-vm"),
@@ -2738,7 +3724,7 @@ func TestVMResource_buildUpdateOpts(t *testing.T) {
 func TestVMResource_buildRawDeviceOpts(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		raw := &VMRawModel{
-			Path:   types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:   customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:   types.StringValue("AHCI"),
 			Boot:   types.BoolValue(false),
 			IOType: types.StringValue("THREADS"),
@@ -2766,7 +3752,7 @@ func TestVMResource_buildRawDeviceOpts(t *testing.T) {
 
 	t.Run("with exists true", func(t *testing.T) {
 		raw := &VMRawModel{
-			Path:   types.StringValue("/dev/zvol/tank/vms/disk0"),
+			Path:   customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 			Type:   types.StringValue("AHCI"),
 			Boot:   types.BoolValue(false),
 			Exists: types.BoolValue(true),
@@ -2783,7 +3769,7 @@ func TestVMResource_buildRawDeviceOpts(t *testing.T) {
 
 	t.Run("with optional fields", func(t *testing.T) {
 		raw := &VMRawModel{
-			Path:               types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:               customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:               types.StringValue("VIRTIO"),
 			Boot:               types.BoolValue(true),
 			Size:               types.Int64Value(10737418240),
@@ -2819,7 +3805,7 @@ func TestVMResource_buildRawDeviceOpts(t *testing.T) {
 
 func TestVMResource_buildDiskDeviceOpts_WithOptionalFields(t *testing.T) {
 	disk := &VMDiskModel{
-		Path:               types.StringValue("/dev/zvol/tank/vms/disk0"),
+		Path:               customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 		Type:               types.StringValue("VIRTIO"),
 		IOType:             types.StringValue("THREADS"),
 		LogicalSectorSize:  types.Int64Value(512),
@@ -2895,7 +3881,7 @@ func TestVMResource_reconcileRawDevices(t *testing.T) {
 		}
 
 		plan := []VMRawModel{{
-			Path:   types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:   customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:   types.StringValue("AHCI"),
 			Boot:   types.BoolValue(false),
 			IOType: types.StringValue("THREADS"),
@@ -2923,13 +3909,13 @@ func TestVMResource_reconcileRawDevices(t *testing.T) {
 
 		plan := []VMRawModel{{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:     customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:     types.StringValue("VIRTIO"), // changed
 			Boot:     types.BoolValue(false),
 		}}
 		state := []VMRawModel{{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:     customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:     types.StringValue("AHCI"),
 			Boot:     types.BoolValue(false),
 		}}
@@ -2953,7 +3939,7 @@ func TestVMResource_reconcileRawDevices(t *testing.T) {
 		}
 
 		plan := []VMRawModel{{
-			Path: types.StringValue("/mnt/tank/vms/raw.img"),
+			Path: customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type: types.StringValue("AHCI"),
 		}}
 		err := r.reconcileRawDevices(context.Background(), 1, plan, nil)
@@ -2973,13 +3959,13 @@ func TestVMResource_reconcileRawDevices(t *testing.T) {
 
 		plan := []VMRawModel{{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:     customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:     types.StringValue("VIRTIO"),
 			Boot:     types.BoolValue(true),
 		}}
 		state := []VMRawModel{{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/mnt/tank/vms/raw.img"),
+			Path:     customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 			Type:     types.StringValue("AHCI"),
 			Boot:     types.BoolValue(false),
 		}}
@@ -3003,7 +3989,7 @@ func TestVMResource_reconcileCDROMDevices(t *testing.T) {
 		}
 
 		plan := []VMCDROMModel{{
-			Path: types.StringValue("/mnt/tank/iso/test.iso"),
+			Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/test.iso"),
 		}}
 		err := r.reconcileCDROMDevices(context.Background(), 1, plan, nil)
 		if err != nil {
@@ -3027,11 +4013,11 @@ func TestVMResource_reconcileCDROMDevices(t *testing.T) {
 
 		plan := []VMCDROMModel{{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/mnt/tank/iso/new.iso"),
+			Path:     customtypes.NewFilesystemPathValue("/mnt/tank/iso/new.iso"),
 		}}
 		state := []VMCDROMModel{{
 			DeviceID: types.Int64Value(50),
-			Path:     types.StringValue("/mnt/tank/iso/old.iso"),
+			Path:     customtypes.NewFilesystemPathValue("/mnt/tank/iso/old.iso"),
 		}}
 		err := r.reconcileCDROMDevices(context.Background(), 1, plan, state)
 		if err != nil {
@@ -3051,7 +4037,7 @@ func TestVMResource_reconcileCDROMDevices(t *testing.T) {
 			}}},
 		}
 
-		plan := []VMCDROMModel{{Path: types.StringValue("/mnt/tank/iso/test.iso")}}
+		plan := []VMCDROMModel{{Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/test.iso")}}
 		err := r.reconcileCDROMDevices(context.Background(), 1, plan, nil)
 		if err == nil {
 			t.Fatal("expected error")
@@ -3067,8 +4053,8 @@ func TestVMResource_reconcileCDROMDevices(t *testing.T) {
 			}}},
 		}
 
-		plan := []VMCDROMModel{{DeviceID: types.Int64Value(50), Path: types.StringValue("/mnt/tank/iso/new.iso")}}
-		state := []VMCDROMModel{{DeviceID: types.Int64Value(50), Path: types.StringValue("/mnt/tank/iso/old.iso")}}
+		plan := []VMCDROMModel{{DeviceID: types.Int64Value(50), Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/new.iso")}}
+		state := []VMCDROMModel{{DeviceID: types.Int64Value(50), Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/old.iso")}}
 		err := r.reconcileCDROMDevices(context.Background(), 1, plan, state)
 		if err == nil {
 			t.Fatal("expected error")
@@ -3426,7 +4412,7 @@ func TestVMResource_reconcileUSBDevices(t *testing.T) {
 
 func TestVMResource_rawEqual(t *testing.T) {
 	a := VMRawModel{
-		Path: types.StringValue("/mnt/tank/vms/raw.img"),
+		Path: customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 		Type: types.StringValue("AHCI"),
 		Boot: types.BoolValue(false),
 		Size: types.Int64Value(1024),
@@ -3438,7 +4424,7 @@ func TestVMResource_rawEqual(t *testing.T) {
 	}
 
 	c := a
-	c.Path = types.StringValue("/mnt/tank/vms/other.img")
+	c.Path = customtypes.NewFilesystemPathValue("/mnt/tank/vms/other.img")
 	if rawEqual(a, c) {
 		t.Error("expected different path to return false")
 	}
@@ -3662,7 +4648,7 @@ func TestVMResource_reconcileDevices_NonDiskTypes(t *testing.T) {
 
 		plan := &VMResourceModel{
 			Raws: []VMRawModel{{
-				Path:   types.StringValue("/mnt/tank/vms/raw.img"),
+				Path:   customtypes.NewFilesystemPathValue("/mnt/tank/vms/raw.img"),
 				Type:   types.StringValue("AHCI"),
 				Boot:   types.BoolValue(false),
 				IOType: types.StringValue("THREADS"),
@@ -3691,7 +4677,7 @@ func TestVMResource_reconcileDevices_NonDiskTypes(t *testing.T) {
 		}
 
 		plan := &VMResourceModel{
-			CDROMs: []VMCDROMModel{{Path: types.StringValue("/mnt/tank/iso/test.iso")}},
+			CDROMs: []VMCDROMModel{{Path: customtypes.NewFilesystemPathValue("/mnt/tank/iso/test.iso")}},
 		}
 		state := &VMResourceModel{}
 
@@ -3809,7 +4795,7 @@ func TestVMResource_reconcileDevices_NonDiskTypes(t *testing.T) {
 
 func TestVMResource_diskEqual(t *testing.T) {
 	a := VMDiskModel{
-		Path:               types.StringValue("/dev/zvol/tank/vms/disk0"),
+		Path:               customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk0"),
 		Type:               types.StringValue("VIRTIO"),
 		LogicalSectorSize:  types.Int64Null(),
 		PhysicalSectorSize: types.Int64Null(),
@@ -3823,7 +4809,7 @@ func TestVMResource_diskEqual(t *testing.T) {
 	}
 
 	c := a
-	c.Path = types.StringValue("/dev/zvol/tank/vms/disk1")
+	c.Path = customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/disk1")
 	if diskEqual(a, c) {
 		t.Error("expected different path to return false")
 	}
@@ -3840,3 +4826,1801 @@ func TestVMResource_diskEqual(t *testing.T) {
 		t.Error("expected different logical_sectorsize to return false")
 	}
 }
+
+// -- ImportState tests --
+
+func TestVMResource_ImportState_ByID(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			t.Fatalf("unexpected call to %s", method)
+			return nil, nil
+		},
+	}}}
+
+	schemaResp := getVMResourceSchema(t)
+	req := resource.ImportStateRequest{ID: "5"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(vmModelParams{})},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model VMResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "5" {
+		t.Errorf("expected ID '5', got %q", model.ID.ValueString())
+	}
+}
+
+func TestVMResource_ImportState_ByName(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &VMResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			capturedMethod = method
+			capturedParams = params
+			return json.RawMessage(`[{"id": 12, "name": "my-vm"}]`), nil
+		},
+	}, services: &services.TrueNASServices{VM: &truenas.MockVMService{
+		GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+			return mockVM(id, "my-vm", 2048, "STOPPED"), nil
+		},
+	}}}}
+
+	schemaResp := getVMResourceSchema(t)
+	req := resource.ImportStateRequest{ID: "name=my-vm"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(vmModelParams{})},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "vm.query" {
+		t.Errorf("expected method 'vm.query', got %q", capturedMethod)
+	}
+	if got := fmt.Sprint(capturedParams); got != "[[[name = my-vm]]]" {
+		t.Errorf("unexpected query filter: %s", got)
+	}
+
+	var model VMResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "12" {
+		t.Errorf("expected ID '12', got %q", model.ID.ValueString())
+	}
+}
+
+func TestVMResource_ImportState_NameNotFound(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return json.RawMessage(`[]`), nil
+		},
+	}}}
+
+	schemaResp := getVMResourceSchema(t)
+	req := resource.ImportStateRequest{ID: "name=missing"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(vmModelParams{})},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for unknown VM name")
+	}
+}
+
+func TestVMResource_ImportState_QueryError(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return nil, errors.New("connection refused")
+		},
+	}}}
+
+	schemaResp := getVMResourceSchema(t)
+	req := resource.ImportStateRequest{ID: "name=my-vm"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(vmModelParams{})},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when vm.query fails")
+	}
+}
+
+// -- Display web URL tests --
+
+func TestDisplayWebURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		scheme      string
+		host        string
+		displayType string
+		want        string
+	}{
+		{"spice", "https", "nas.example.com", "SPICE", "https://nas.example.com:5901/spice_auto.html?host=nas.example.com&port=5901"},
+		{"vnc", "http", "10.0.0.5", "VNC", "http://10.0.0.5:5901/vnc.html?autoconnect=1"},
+		{"ipv6 host", "http", "fd00::5", "SPICE", "http://[fd00::5]:5901/spice_auto.html?host=fd00%3A%3A5&port=5901"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := displayWebURL(tt.scheme, tt.host, tt.displayType, 5901); got != tt.want {
+				t.Errorf("displayWebURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVMResource_setDisplayWebURLs(t *testing.T) {
+	newResource := func(httpsRedirect bool, calls *int) *VMResource {
+		return &VMResource{BaseResource: BaseResource{
+			services: &services.TrueNASServices{Host: "nas.example.com"},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					*calls++
+					if method != "system.general.config" {
+						t.Errorf("expected method 'system.general.config', got %q", method)
+					}
+					return json.RawMessage(fmt.Sprintf(`{"ui_httpsredirect": %t}`, httpsRedirect)), nil
+				},
+			},
+		}}
+	}
+	display := func(bind string, web bool, webPort int64) VMDisplayModel {
+		return VMDisplayModel{
+			Type:    types.StringValue("SPICE"),
+			Bind:    types.StringValue(bind),
+			Web:     types.BoolValue(web),
+			WebPort: types.Int64Value(webPort),
+		}
+	}
+
+	t.Run("https redirect uses provider host for wildcard bind", func(t *testing.T) {
+		var calls int
+		r := newResource(true, &calls)
+		data := &VMResourceModel{Displays: []VMDisplayModel{display("0.0.0.0", true, 5901), display("::", true, 5902)}}
+
+		if err := r.setDisplayWebURLs(context.Background(), data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := "https://nas.example.com:5901/spice_auto.html?host=nas.example.com&port=5901"
+		if got := data.Displays[0].WebURL.ValueString(); got != want {
+			t.Errorf("expected web_url %q, got %q", want, got)
+		}
+		if calls != 1 {
+			t.Errorf("expected UI settings to be read once, got %d calls", calls)
+		}
+	})
+
+	t.Run("specific bind address over http", func(t *testing.T) {
+		var calls int
+		r := newResource(false, &calls)
+		data := &VMResourceModel{Displays: []VMDisplayModel{display("192.168.1.10", true, 5901)}}
+
+		if err := r.setDisplayWebURLs(context.Background(), data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := "http://192.168.1.10:5901/spice_auto.html?host=192.168.1.10&port=5901"
+		if got := data.Displays[0].WebURL.ValueString(); got != want {
+			t.Errorf("expected web_url %q, got %q", want, got)
+		}
+	})
+
+	t.Run("web client disabled", func(t *testing.T) {
+		var calls int
+		r := newResource(true, &calls)
+		data := &VMResourceModel{Displays: []VMDisplayModel{display("0.0.0.0", false, 5901), display("0.0.0.0", true, 0)}}
+
+		if err := r.setDisplayWebURLs(context.Background(), data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for i, d := range data.Displays {
+			if !d.WebURL.IsNull() {
+				t.Errorf("display %d: expected null web_url, got %q", i, d.WebURL.ValueString())
+			}
+		}
+		if calls != 0 {
+			t.Errorf("expected no UI settings call, got %d", calls)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		r := &VMResource{BaseResource: BaseResource{
+			services: &services.TrueNASServices{Host: "nas.example.com"},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+		}}
+		data := &VMResourceModel{Displays: []VMDisplayModel{display("0.0.0.0", true, 5901)}}
+
+		if err := r.setDisplayWebURLs(context.Background(), data); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestVMResource_Read_DisplayWebURL(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`{"ui_httpsredirect": true}`), nil
+				},
+			},
+			services: &services.TrueNASServices{
+				Host: "nas.example.com",
+				VM: &truenas.MockVMService{
+					GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+						return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+					},
+					ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+						return []truenas.VMDevice{
+							{ID: 101, VM: 1, Order: 1000, DeviceType: truenas.DeviceTypeDisplay,
+								Display: &truenas.DisplayDevice{Type: "SPICE", Resolution: "1024x768", Bind: "0.0.0.0", Web: true, Port: 5900, WebPort: 5901}},
+						}, nil
+					},
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	stateValue := createVMModelValue(p)
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if len(model.Displays) != 1 {
+		t.Fatalf("expected 1 display, got %d", len(model.Displays))
+	}
+	want := "https://nas.example.com:5901/spice_auto.html?host=nas.example.com&port=5901"
+	if got := model.Displays[0].WebURL.ValueString(); got != want {
+		t.Errorf("expected web_url %q, got %q", want, got)
+	}
+}
+
+func TestVMResource_Create_WithTPM(t *testing.T) {
+	var methods []string
+	var updateParams any
+
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					methods = append(methods, method)
+					if method == "vm.update" {
+						updateParams = params
+						return json.RawMessage(`{"id": 1}`), nil
+					}
+					return json.RawMessage(`[{"id": 1, "trusted_platform_module": true}]`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.TPM = true
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(methods) != 2 || methods[0] != "vm.update" || methods[1] != "vm.query" {
+		t.Fatalf("expected [vm.update vm.query], got %v", methods)
+	}
+	args, ok := updateParams.([]any)
+	if !ok || len(args) != 2 {
+		t.Fatalf("expected [id, params], got %#v", updateParams)
+	}
+	if opts := args[1].(map[string]any); opts["trusted_platform_module"] != true {
+		t.Errorf("expected trusted_platform_module true, got %v", opts["trusted_platform_module"])
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if !model.TPM.ValueBool() {
+		t.Error("expected tpm true in state")
+	}
+}
+
+func TestVMResource_Create_TPMError(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return nil, errors.New("[EINVAL] vm_update.trusted_platform_module: TPM requires UEFI")
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.TPM = true
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when TPM cannot be set")
+	}
+}
+
+func TestVMResource_Update_TPMUnchanged(t *testing.T) {
+	var methods []string
+
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					methods = append(methods, method)
+					return json.RawMessage(`[{"id": 1, "trusted_platform_module": true}]`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.TPM = true
+	req := resource.UpdateRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(methods) != 1 || methods[0] != "vm.query" {
+		t.Errorf("expected only vm.query, got %v", methods)
+	}
+}
+
+func TestVMResource_Read_TPMDrift(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					if method != "vm.query" {
+						t.Errorf("expected method 'vm.query', got %q", method)
+					}
+					return json.RawMessage(`[{"id": 1, "trusted_platform_module": false}]`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.TPM = true
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.TPM.ValueBool() {
+		t.Error("expected tpm false after TPM was removed outside Terraform")
+	}
+}
+
+func TestVMResource_Read_MemoryReportedInBytes(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{VM: &truenas.MockVMService{
+			GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+				return mockVM(1, "test-vm", 2147483648, "STOPPED"), nil
+			},
+			ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+				return nil, nil
+			},
+		}}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.Memory.ValueInt64() != 2048 {
+		t.Errorf("expected memory 2048 MiB, got %d", model.Memory.ValueInt64())
+	}
+}
+
+// -- cpu_model and bootloader_ovmf plan validation --
+
+func modifyVMPlan(t *testing.T, r *VMResource, state, plan tftypes.Value) *resource.ModifyPlanResponse {
+	t.Helper()
+	return modifyVMPlanWithConfig(t, r, state, plan, plan)
+}
+
+func modifyVMPlanWithConfig(t *testing.T, r *VMResource, state, plan, config tftypes.Value) *resource.ModifyPlanResponse {
+	t.Helper()
+
+	schemaResp := getVMResourceSchema(t)
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(context.Background(), req, resp)
+	return resp
+}
+
+// vmChoicesClient answers vm.cpu_model_choices and vm.bootloader_ovmf_choices,
+// counting calls per method.
+func vmChoicesClient(calls map[string]int) *client.MockClient {
+	return &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			calls[method]++
+			switch method {
+			case "vm.cpu_model_choices":
+				return json.RawMessage(`{"EPYC": "EPYC", "Haswell": "Haswell"}`), nil
+			case "vm.bootloader_ovmf_choices":
+				return json.RawMessage(`{"OVMF_CODE.fd": "OVMF_CODE.fd", "OVMF_CODE_4M.secboot.fd": "OVMF_CODE_4M.secboot.fd"}`), nil
+			}
+			return nil, fmt.Errorf("unexpected method %q", method)
+		},
+	}
+}
+
+func TestVMResource_ModifyPlan_CPUModel(t *testing.T) {
+	nullState := tftypes.NewValue(vmObjectType(), nil)
+
+	tests := []struct {
+		name      string
+		cpuMode   string
+		cpuModel  interface{}
+		stateFunc func() tftypes.Value
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "supported model", cpuMode: "CUSTOM", cpuModel: "Haswell", wantCalls: 1},
+		{name: "unsupported model", cpuMode: "custom", cpuModel: "Pentium4", wantErr: true, wantCalls: 1},
+		{name: "no model", cpuMode: "CUSTOM", cpuModel: nil},
+		{name: "unknown model", cpuMode: "CUSTOM", cpuModel: tftypes.UnknownValue},
+		{name: "host passthrough ignores model", cpuMode: "HOST-PASSTHROUGH", cpuModel: "Pentium4"},
+		{
+			name: "unchanged model", cpuMode: "CUSTOM", cpuModel: "Pentium4",
+			stateFunc: func() tftypes.Value {
+				p := defaultVMPlanParams()
+				p.ID = "1"
+				p.CPUModel = "Pentium4"
+				return createVMModelValue(p)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := map[string]int{}
+			r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(calls)}}
+
+			p := defaultVMPlanParams()
+			p.CPUMode = tt.cpuMode
+			p.CPUModel = tt.cpuModel
+
+			state := nullState
+			if tt.stateFunc != nil {
+				state = tt.stateFunc()
+			}
+
+			resp := modifyVMPlan(t, r, state, createVMModelValue(p))
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if calls["vm.cpu_model_choices"] != tt.wantCalls {
+				t.Errorf("expected %d vm.cpu_model_choices calls, got %d", tt.wantCalls, calls["vm.cpu_model_choices"])
+			}
+			if tt.wantErr {
+				detail := resp.Diagnostics.Errors()[0].Detail()
+				if !strings.Contains(detail, "EPYC, Haswell") {
+					t.Errorf("expected available models in error, got %q", detail)
+				}
+			}
+		})
+	}
+}
+
+func TestVMResource_ModifyPlan_BootloaderOVMF(t *testing.T) {
+	tests := []struct {
+		name      string
+		ovmf      interface{}
+		stateOVMF interface{}
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "default firmware", ovmf: "OVMF_CODE.fd", wantCalls: 1},
+		{name: "secure boot firmware", ovmf: "OVMF_CODE_4M.secboot.fd", wantCalls: 1},
+		{name: "missing firmware", ovmf: "OVMF_CODE_4M.ms.fd", wantErr: true, wantCalls: 1},
+		{name: "unchanged firmware", ovmf: "OVMF_CODE_4M.ms.fd", stateOVMF: "OVMF_CODE_4M.ms.fd"},
+		{name: "changed firmware", ovmf: "OVMF_CODE_4M.ms.fd", stateOVMF: "OVMF_CODE.fd", wantErr: true, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := map[string]int{}
+			r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(calls)}}
+
+			p := defaultVMPlanParams()
+			p.BootloaderOVMF = tt.ovmf
+
+			state := tftypes.NewValue(vmObjectType(), nil)
+			if tt.stateOVMF != nil {
+				s := defaultVMPlanParams()
+				s.ID = "1"
+				s.BootloaderOVMF = tt.stateOVMF
+				state = createVMModelValue(s)
+			}
+
+			resp := modifyVMPlan(t, r, state, createVMModelValue(p))
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if calls["vm.bootloader_ovmf_choices"] != tt.wantCalls {
+				t.Errorf("expected %d vm.bootloader_ovmf_choices calls, got %d", tt.wantCalls, calls["vm.bootloader_ovmf_choices"])
+			}
+			if tt.wantErr {
+				diagnostic := resp.Diagnostics.Errors()[0]
+				if diagnostic.Summary() != "Invalid OVMF Firmware" {
+					t.Errorf("expected 'Invalid OVMF Firmware', got %q", diagnostic.Summary())
+				}
+				if !strings.Contains(diagnostic.Detail(), "OVMF_CODE.fd, OVMF_CODE_4M.secboot.fd") {
+					t.Errorf("expected available firmware in error, got %q", diagnostic.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestVMResource_ModifyPlan_ChoicesErrorIsWarning(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			return nil, errors.New("connection refused")
+		},
+	}}}
+
+	p := defaultVMPlanParams()
+	p.CPUModel = "Haswell"
+
+	resp := modifyVMPlan(t, r, tftypes.NewValue(vmObjectType(), nil), createVMModelValue(p))
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 2 {
+		t.Errorf("expected a warning per check, got %v", resp.Diagnostics)
+	}
+}
+
+func TestVMResource_ModifyPlan_Destroy(t *testing.T) {
+	calls := map[string]int{}
+	r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(calls)}}
+
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.CPUModel = "Pentium4"
+
+	resp := modifyVMPlan(t, r, createVMModelValue(p), tftypes.NewValue(vmObjectType(), nil))
+
+	if len(resp.Diagnostics) != 0 || len(calls) != 0 {
+		t.Errorf("expected no checks on destroy, got %v and %v", calls, resp.Diagnostics)
+	}
+}
+
+// -- nic_attach plan validation and create_bridge --
+
+func TestVMResource_ModifyPlan_NICAttach(t *testing.T) {
+	tests := []struct {
+		name         string
+		attach       string
+		createBridge []string
+		stateAttach  string
+		wantErr      bool
+		wantCalls    int
+	}{
+		{name: "existing interface", attach: "br0", wantCalls: 1},
+		{name: "missing interface", attach: "br1", wantErr: true, wantCalls: 1},
+		{name: "missing bridge to create", attach: "br1", createBridge: []string{"enp1s0"}},
+		{name: "unchanged attachment", attach: "br1", stateAttach: "br1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := map[string]int{}
+			r := &VMResource{BaseResource: BaseResource{client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					calls[method]++
+					if method == "vm.device.nic_attach_choices" {
+						return json.RawMessage(`{"br0": "br0", "enp1s0": "enp1s0"}`), nil
+					}
+					return vmChoicesClient(map[string]int{}).CallFunc(ctx, method, params)
+				},
+			}}}
+
+			p := defaultVMPlanParams()
+			p.NICs = []vmNICParams{{
+				Type: "VIRTIO", NICAttach: tt.attach, MAC: tftypes.UnknownValue,
+				TrustGuestRXFilters: false, Order: tftypes.UnknownValue, DeviceID: tftypes.UnknownValue,
+				CreateBridge: tt.createBridge,
+			}}
+
+			state := tftypes.NewValue(vmObjectType(), nil)
+			if tt.stateAttach != "" {
+				s := defaultVMPlanParams()
+				s.ID = "1"
+				s.NICs = []vmNICParams{{
+					DeviceID: float64(3), Type: "VIRTIO", NICAttach: tt.stateAttach, MAC: "00:a0:98:00:00:01",
+					TrustGuestRXFilters: false, Order: float64(1002),
+				}}
+				state = createVMModelValue(s)
+			}
+
+			resp := modifyVMPlan(t, r, state, createVMModelValue(p))
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if calls["vm.device.nic_attach_choices"] != tt.wantCalls {
+				t.Errorf("expected %d vm.device.nic_attach_choices calls, got %d", tt.wantCalls, calls["vm.device.nic_attach_choices"])
+			}
+			if tt.wantErr {
+				diagnostic := resp.Diagnostics.Errors()[0]
+				if diagnostic.Summary() != "Invalid NIC Attachment" {
+					t.Errorf("expected 'Invalid NIC Attachment', got %q", diagnostic.Summary())
+				}
+				if !strings.Contains(diagnostic.Detail(), "Available: br0, enp1s0.") {
+					t.Errorf("expected valid attachments in error, got %q", diagnostic.Detail())
+				}
+			}
+		})
+	}
+}
+
+// bridgeClient answers the interface.* calls made by ensureBridges and
+// records every method called.
+func bridgeClient(existing []string, pending bool, failMethod string, calls *[]string) *client.MockClient {
+	return &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			*calls = append(*calls, method)
+			if method == failMethod {
+				return nil, errors.New("[EINVAL] interface_create.bridge_members: enp9s0 not found")
+			}
+			switch method {
+			case "interface.query":
+				filter := params.([]any)[0].([]any)[0].([]any)
+				if slices.Contains(existing, filter[2].(string)) {
+					return json.RawMessage(`[{"name": "br0"}]`), nil
+				}
+				return json.RawMessage(`[]`), nil
+			case "interface.has_pending_changes":
+				return json.RawMessage(strconv.FormatBool(pending)), nil
+			}
+			return json.RawMessage(`null`), nil
+		},
+	}
+}
+
+func bridgeNIC(attach string, members ...string) VMNICModel {
+	nic := VMNICModel{NICAttach: types.StringValue(attach)}
+	if members != nil {
+		elems := make([]attr.Value, len(members))
+		for i, m := range members {
+			elems[i] = types.StringValue(m)
+		}
+		nic.CreateBridge = &VMNICCreateBridgeModel{
+			Members: types.ListValueMust(types.StringType, elems),
+			STP:     types.BoolValue(false),
+		}
+	}
+	return nic
+}
+
+func TestVMResource_EnsureBridges_CreatesMissingBridge(t *testing.T) {
+	var calls []string
+	var createParams map[string]any
+	mock := bridgeClient(nil, false, "", &calls)
+	call := mock.CallFunc
+	mock.CallFunc = func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method == "interface.create" {
+			createParams = params.(map[string]any)
+		}
+		return call(ctx, method, params)
+	}
+	r := &VMResource{BaseResource: BaseResource{client: mock}}
+
+	// Two NICs on the same new bridge create it once
+	err := r.ensureBridges(context.Background(), []VMNICModel{
+		bridgeNIC("br1", "enp1s0", "enp2s0"),
+		bridgeNIC("br1", "enp1s0", "enp2s0"),
+		bridgeNIC("enp3s0"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"interface.query", "interface.has_pending_changes", "interface.create", "interface.commit", "interface.checkin"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("expected calls %v, got %v", want, calls)
+	}
+	if createParams["type"] != "BRIDGE" || createParams["name"] != "br1" || createParams["stp"] != false {
+		t.Errorf("unexpected interface.create params %v", createParams)
+	}
+	if members, _ := createParams["bridge_members"].([]string); !slices.Equal(members, []string{"enp1s0", "enp2s0"}) {
+		t.Errorf("unexpected bridge members %v", createParams["bridge_members"])
+	}
+}
+
+func TestVMResource_EnsureBridges_ExistingBridge(t *testing.T) {
+	var calls []string
+	r := &VMResource{BaseResource: BaseResource{client: bridgeClient([]string{"br0"}, false, "", &calls)}}
+
+	if err := r.ensureBridges(context.Background(), []VMNICModel{bridgeNIC("br0", "enp1s0")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(calls, []string{"interface.query"}) {
+		t.Errorf("expected only the existence check, got %v", calls)
+	}
+}
+
+func TestVMResource_EnsureBridges_PendingChanges(t *testing.T) {
+	var calls []string
+	r := &VMResource{BaseResource: BaseResource{client: bridgeClient(nil, true, "", &calls)}}
+
+	err := r.ensureBridges(context.Background(), []VMNICModel{bridgeNIC("br1", "enp1s0")})
+	if err == nil || !strings.Contains(err.Error(), "uncommitted network changes") {
+		t.Fatalf("expected pending changes error, got %v", err)
+	}
+	if slices.Contains(calls, "interface.create") {
+		t.Error("expected no bridge to be created while changes are pending")
+	}
+}
+
+func TestVMResource_EnsureBridges_PendingCheckin(t *testing.T) {
+	var calls []string
+	mock := bridgeClient(nil, true, "", &calls)
+	call := mock.CallFunc
+	mock.CallFunc = func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		if method == "interface.checkin_waiting" {
+			return json.RawMessage(`42`), nil
+		}
+		return call(ctx, method, params)
+	}
+	r := &VMResource{BaseResource: BaseResource{client: mock}}
+
+	err := r.ensureBridges(context.Background(), []VMNICModel{bridgeNIC("br1", "enp1s0")})
+	if err == nil || !strings.Contains(err.Error(), "reverted in 42 seconds") {
+		t.Fatalf("expected pending checkin error, got %v", err)
+	}
+	if slices.Contains(calls, "interface.rollback") {
+		t.Error("expected changes staged by someone else to be left alone")
+	}
+}
+
+func TestAddBridgeError(t *testing.T) {
+	var diags diag.Diagnostics
+	addBridgeError(&diags, &pendingNetworkChangesError{bridge: "br1"})
+	if got := diags[0].Summary(); got != "Uncommitted Network Changes" {
+		t.Errorf("expected pending changes summary, got %q", got)
+	}
+	if !strings.Contains(diags[0].Detail(), "interface.rollback") {
+		t.Errorf("expected remediation in detail, got %q", diags[0].Detail())
+	}
+
+	diags = nil
+	addBridgeError(&diags, errors.New("unable to create bridge"))
+	if got := diags[0].Summary(); got != "Unable to Create Bridge" {
+		t.Errorf("expected generic summary, got %q", got)
+	}
+}
+
+func TestVMResource_EnsureBridges_CreateFailsRollsBack(t *testing.T) {
+	var calls []string
+	r := &VMResource{BaseResource: BaseResource{client: bridgeClient(nil, false, "interface.create", &calls)}}
+
+	err := r.ensureBridges(context.Background(), []VMNICModel{bridgeNIC("br1", "enp9s0")})
+	if err == nil || !strings.Contains(err.Error(), "enp9s0 not found") {
+		t.Fatalf("expected create error, got %v", err)
+	}
+	if calls[len(calls)-1] != "interface.rollback" || slices.Contains(calls, "interface.commit") {
+		t.Errorf("expected staged changes to be rolled back, got %v", calls)
+	}
+}
+
+func TestPreserveNICCreateBridge(t *testing.T) {
+	prior := []VMNICModel{bridgeNIC("br1", "enp1s0")}
+	prior[0].DeviceID = types.Int64Value(3)
+	mapped := []VMNICModel{{DeviceID: types.Int64Value(3), NICAttach: types.StringValue("br1")}}
+
+	preserveNICCreateBridge(mapped, prior)
+
+	if mapped[0].CreateBridge != prior[0].CreateBridge {
+		t.Error("expected create_bridge to be kept from the prior NIC")
+	}
+}
+
+func TestPreserveDeleteBackingStorage(t *testing.T) {
+	priorDisks := []VMDiskModel{{DeviceID: types.Int64Value(3), DeleteBackingStorage: types.BoolValue(true)}}
+	disks := []VMDiskModel{{DeviceID: types.Int64Value(3)}}
+	preserveDiskZvol(disks, priorDisks)
+	if !disks[0].DeleteBackingStorage.ValueBool() {
+		t.Error("expected delete_backing_storage to be kept from the prior disk")
+	}
+
+	priorRaws := []VMRawModel{{DeviceID: types.Int64Value(4), DeleteBackingStorage: types.BoolValue(true)}}
+	raws := []VMRawModel{{DeviceID: types.Int64Value(4)}}
+	preserveRawExists(raws, priorRaws)
+	if !raws[0].DeleteBackingStorage.ValueBool() {
+		t.Error("expected delete_backing_storage to be kept from the prior RAW device")
+	}
+}
+
+// -- disks created from size --
+
+func TestVMResource_ValidateConfig_DiskSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		disk        vmDiskParams
+		wantSummary string
+	}{
+		{name: "path", disk: vmDiskParams{Path: "/dev/zvol/tank/vms/disk0"}},
+		{name: "size with pool", disk: vmDiskParams{Size: "20G", Pool: "tank/vms"}},
+		{name: "size with zvol_name", disk: vmDiskParams{Size: "20G", ZvolName: "tank/vms/web0", ManageZvol: true}},
+		{name: "path and size", disk: vmDiskParams{Path: "/dev/zvol/tank/vms/disk0", Size: "20G", Pool: "tank"}, wantSummary: "Conflicting Disk Source"},
+		{name: "neither", disk: vmDiskParams{}, wantSummary: "Missing Disk Source"},
+		{name: "size only", disk: vmDiskParams{Size: "20G"}, wantSummary: "Missing Zvol Name"},
+		{name: "pool only", disk: vmDiskParams{Pool: "tank"}, wantSummary: "Missing Disk Size"},
+		{name: "manage_zvol with path", disk: vmDiskParams{Path: "/dev/zvol/tank/vms/disk0", ManageZvol: true}, wantSummary: "Invalid Managed Zvol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewVMResource().(*VMResource)
+			schemaResp := getVMResourceSchema(t)
+
+			p := defaultVMPlanParams()
+			p.Disks = []vmDiskParams{tt.disk}
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+			}, resp)
+
+			if tt.wantSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.wantSummary {
+				t.Errorf("expected %q error, got %v", tt.wantSummary, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestVMResource_ValidateConfig_DisplayPorts(t *testing.T) {
+	display := func(port, webPort interface{}) vmDisplayParams {
+		return vmDisplayParams{Type: "SPICE", Port: port, WebPort: webPort, Web: true}
+	}
+
+	tests := []struct {
+		name     string
+		displays []vmDisplayParams
+		wantPath path.Path
+	}{
+		{name: "distinct", displays: []vmDisplayParams{display(float64(5900), float64(5901)), display(float64(5902), float64(5903))}},
+		{name: "unset", displays: []vmDisplayParams{display(nil, nil), display(nil, nil)}},
+		{name: "port reused", displays: []vmDisplayParams{display(float64(5900), float64(5901)), display(float64(5900), nil)}, wantPath: path.Root("display").AtListIndex(1).AtName("port")},
+		{name: "web_port is port", displays: []vmDisplayParams{display(float64(5900), float64(5900))}, wantPath: path.Root("display").AtListIndex(0).AtName("web_port")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewVMResource().(*VMResource)
+			schemaResp := getVMResourceSchema(t)
+
+			p := defaultVMPlanParams()
+			p.Displays = tt.displays
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+			}, resp)
+
+			if len(tt.wantPath.Steps()) == 0 {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got %v", resp.Diagnostics)
+			}
+			d := resp.Diagnostics.Errors()[0]
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if d.Summary() != "Conflicting Display Port" || !ok || !withPath.Path().Equal(tt.wantPath) {
+				t.Errorf("expected a Conflicting Display Port error on %s, got %v", tt.wantPath, d)
+			}
+		})
+	}
+}
+
+// vmDisplayPortsClient answers the display port checks with a display of VM
+// 3 on ports 5910 and 5911, a display of VM 7 on port 5920, and an app on
+// port 8080. Other calls go to vmChoicesClient.
+func vmDisplayPortsClient(calls map[string]int, queryErr error) *client.MockClient {
+	choices := vmChoicesClient(calls)
+	return &client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			switch method {
+			case "vm.device.query":
+				calls[method]++
+				if queryErr != nil {
+					return nil, queryErr
+				}
+				return json.RawMessage(`[
+					{"id": 30, "vm": 3, "order": 1002, "attributes": {"dtype": "DISPLAY", "port": 5910, "web_port": 5911}},
+					{"id": 70, "vm": 7, "order": 1002, "attributes": {"dtype": "DISPLAY", "port": 5920, "web_port": 5921}}
+				]`), nil
+			case "app.used_ports":
+				calls[method]++
+				return json.RawMessage(`[8080, 30000]`), nil
+			}
+			return choices.CallFunc(ctx, method, params)
+		},
+	}
+}
+
+func TestVMResource_ModifyPlan_DisplayPorts(t *testing.T) {
+	display := func(id, port, webPort interface{}) vmDisplayParams {
+		return vmDisplayParams{DeviceID: id, Type: "SPICE", Port: port, WebPort: webPort, Web: true}
+	}
+	nullState := tftypes.NewValue(vmObjectType(), nil)
+
+	t.Run("used by another VM", func(t *testing.T) {
+		r := &VMResource{BaseResource: BaseResource{client: vmDisplayPortsClient(map[string]int{}, nil)}}
+		p := defaultVMPlanParams()
+		p.Displays = []vmDisplayParams{display(nil, float64(5911), nil)}
+
+		resp := modifyVMPlan(t, r, nullState, createVMModelValue(p))
+		if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Display Port In Use" {
+			t.Fatalf("expected a Display Port In Use error, got %v", resp.Diagnostics)
+		}
+		if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "VM 3") {
+			t.Errorf("expected the error to name VM 3, got %q", resp.Diagnostics.Errors()[0].Detail())
+		}
+	})
+
+	t.Run("used by an app", func(t *testing.T) {
+		r := &VMResource{BaseResource: BaseResource{client: vmDisplayPortsClient(map[string]int{}, nil)}}
+		p := defaultVMPlanParams()
+		p.Displays = []vmDisplayParams{display(nil, float64(5930), float64(8080))}
+
+		resp := modifyVMPlan(t, r, nullState, createVMModelValue(p))
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Fatalf("expected one error, got %v", resp.Diagnostics)
+		}
+		d := resp.Diagnostics.Errors()[0]
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(path.Root("display").AtListIndex(0).AtName("web_port")) || !strings.Contains(d.Detail(), "an app") {
+			t.Errorf("expected the app's port to be reported on web_port, got %v", d)
+		}
+	})
+
+	t.Run("free port", func(t *testing.T) {
+		r := &VMResource{BaseResource: BaseResource{client: vmDisplayPortsClient(map[string]int{}, nil)}}
+		p := defaultVMPlanParams()
+		p.Displays = []vmDisplayParams{display(nil, float64(5930), float64(5931))}
+
+		resp := modifyVMPlan(t, r, nullState, createVMModelValue(p))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("own ports", func(t *testing.T) {
+		calls := map[string]int{}
+		r := &VMResource{BaseResource: BaseResource{client: vmDisplayPortsClient(calls, nil)}}
+		s := defaultVMPlanParams()
+		s.ID = "7"
+		s.Displays = []vmDisplayParams{display(float64(70), float64(5920), float64(5921))}
+		p := s
+		p.Description = "changed"
+
+		resp := modifyVMPlan(t, r, createVMModelValue(s), createVMModelValue(p))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if calls["vm.device.query"] != 0 {
+			t.Errorf("expected unchanged ports not to be checked, got %d vm.device.query calls", calls["vm.device.query"])
+		}
+	})
+
+	t.Run("query error is a warning", func(t *testing.T) {
+		r := &VMResource{BaseResource: BaseResource{client: vmDisplayPortsClient(map[string]int{}, errors.New("boom"))}}
+		p := defaultVMPlanParams()
+		p.Displays = []vmDisplayParams{display(nil, float64(5911), nil)}
+
+		resp := modifyVMPlan(t, r, nullState, createVMModelValue(p))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Unable to Validate Display Ports" {
+			t.Errorf("expected an Unable to Validate Display Ports warning, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestVMResource_ModifyPlan_DiskZvol(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(map[string]int{})}}
+
+	p := defaultVMPlanParams()
+	p.Disks = []vmDiskParams{
+		{DeviceID: tftypes.UnknownValue, Path: tftypes.UnknownValue, Type: "VIRTIO", IOType: "THREADS",
+			Serial: tftypes.UnknownValue, Order: tftypes.UnknownValue, Size: "20G", ZvolName: "tank/vms/web0"},
+		{DeviceID: tftypes.UnknownValue, Path: "/dev/zvol/tank/vms/data", Type: "VIRTIO", IOType: "THREADS",
+			Serial: tftypes.UnknownValue, Order: tftypes.UnknownValue, ZvolName: tftypes.UnknownValue},
+	}
+
+	resp := modifyVMPlan(t, r, tftypes.NewValue(vmObjectType(), nil), createVMModelValue(p))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var plan VMResourceModel
+	resp.Plan.Get(context.Background(), &plan)
+	if plan.Disks[0].Path.ValueString() != "/dev/zvol/tank/vms/web0" {
+		t.Errorf("expected path of the named zvol, got %v", plan.Disks[0].Path)
+	}
+	if !plan.Disks[1].ZvolName.IsNull() {
+		t.Errorf("expected null zvol_name for a disk attached by path, got %v", plan.Disks[1].ZvolName)
+	}
+}
+
+func TestVMResource_ModifyPlan_DiskZvolCannotMove(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(map[string]int{})}}
+
+	disk := vmDiskParams{DeviceID: float64(50), Path: "/dev/zvol/tank/vms/web0", Type: "VIRTIO", IOType: "THREADS",
+		Serial: "abc", Order: float64(1000), Size: "20G", ZvolName: "tank/vms/web0"}
+	s := defaultVMPlanParams()
+	s.ID = "7"
+	s.Disks = []vmDiskParams{disk}
+
+	p := s
+	moved := disk
+	moved.ZvolName = "fast/vms/web0"
+	p.Disks = []vmDiskParams{moved}
+
+	resp := modifyVMPlan(t, r, createVMModelValue(s), createVMModelValue(p))
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Cannot Move Disk Zvol" {
+		t.Errorf("expected 'Cannot Move Disk Zvol' error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestVMResource_Create_DiskFromSize(t *testing.T) {
+	var createdZvol truenas.CreateZvolOpts
+	var devicePath string
+
+	r := &VMResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Dataset: &truenas.MockDatasetService{
+				GetZvolFunc: func(ctx context.Context, id string) (*truenas.Zvol, error) {
+					if id == "tank/vms/test-vm-disk0" {
+						return &truenas.Zvol{ID: id}, nil
+					}
+					return nil, nil
+				},
+				CreateZvolFunc: func(ctx context.Context, opts truenas.CreateZvolOpts) (*truenas.Zvol, error) {
+					createdZvol = opts
+					return &truenas.Zvol{ID: opts.Name}, nil
+				},
+			},
+			VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(7, "test-vm", 2048, "STOPPED"), nil
+				},
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(7, "test-vm", 2048, "STOPPED"), nil
+				},
+				CreateDeviceFunc: func(ctx context.Context, opts truenas.CreateVMDeviceOpts) (*truenas.VMDevice, error) {
+					devicePath = opts.Disk.Path
+					return &truenas.VMDevice{ID: 50}, nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return []truenas.VMDevice{{ID: 50, VM: 7, Order: 1000, DeviceType: truenas.DeviceTypeDisk,
+						Disk: &truenas.DiskDevice{Path: devicePath, Type: "VIRTIO", IOType: "THREADS", Serial: "abc"}}}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.Disks = []vmDiskParams{{
+		DeviceID: tftypes.UnknownValue, Path: tftypes.UnknownValue, Type: "VIRTIO", IOType: "THREADS",
+		Serial: tftypes.UnknownValue, Order: tftypes.UnknownValue,
+		Size: "20G", ZvolName: tftypes.UnknownValue, Pool: "tank/vms", ManageZvol: true,
+	}}
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	// disk0 is taken, so the first free name is used
+	if createdZvol.Name != "tank/vms/test-vm-disk1" || createdZvol.Volsize != 20000000000 {
+		t.Errorf("unexpected zvol %+v", createdZvol)
+	}
+	if devicePath != "/dev/zvol/tank/vms/test-vm-disk1" {
+		t.Errorf("expected device on the new zvol, got %q", devicePath)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	disk := model.Disks[0]
+	if disk.ZvolName.ValueString() != "tank/vms/test-vm-disk1" || disk.Size.ValueString() != "20G" || !disk.ManageZvol.ValueBool() {
+		t.Errorf("expected zvol settings in state, got %+v", disk)
+	}
+}
+
+func TestVMResource_DeleteManagedZvols(t *testing.T) {
+	var deleted []string
+	r := &VMResource{BaseResource: BaseResource{services: &services.TrueNASServices{
+		Dataset: &truenas.MockDatasetService{
+			DeleteZvolFunc: func(ctx context.Context, id string) error {
+				deleted = append(deleted, id)
+				return nil
+			},
+		},
+	}}}
+
+	disks := []VMDiskModel{
+		{DeviceID: types.Int64Value(50), ZvolName: types.StringValue("tank/vms/kept"), ManageZvol: types.BoolValue(true)},
+		{DeviceID: types.Int64Value(51), ZvolName: types.StringValue("tank/vms/removed"), ManageZvol: types.BoolValue(true)},
+		{DeviceID: types.Int64Value(52), ZvolName: types.StringValue("tank/vms/unmanaged"), ManageZvol: types.BoolValue(false)},
+		{DeviceID: types.Int64Value(53), Path: customtypes.NewFilesystemPathValue("/dev/zvol/tank/vms/attached")},
+	}
+
+	if err := r.deleteManagedZvols(context.Background(), disks, map[int64]bool{50: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(deleted, []string{"tank/vms/removed"}) {
+		t.Errorf("expected only the removed managed zvol to be deleted, got %v", deleted)
+	}
+}
+
+func TestVMResource_ResizeDiskZvols(t *testing.T) {
+	var resized map[string]int64
+	r := &VMResource{BaseResource: BaseResource{services: &services.TrueNASServices{
+		Dataset: &truenas.MockDatasetService{
+			UpdateZvolFunc: func(ctx context.Context, id string, opts truenas.UpdateZvolOpts) (*truenas.Zvol, error) {
+				resized = map[string]int64{id: *opts.Volsize}
+				return &truenas.Zvol{ID: id}, nil
+			},
+		},
+	}}}
+
+	disk := func(size string) VMDiskModel {
+		return VMDiskModel{DeviceID: types.Int64Value(50), ZvolName: types.StringValue("tank/vms/web0"),
+			Size: customtypes.NewSizeStringValue(size)}
+	}
+
+	if err := r.resizeDiskZvols(context.Background(), []VMDiskModel{disk("20G")}, []VMDiskModel{disk("20000M")}); err != nil || resized != nil {
+		t.Errorf("expected an equal size not to resize, got %v (%v)", resized, err)
+	}
+	if err := r.resizeDiskZvols(context.Background(), []VMDiskModel{disk("40G")}, []VMDiskModel{disk("20G")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resized["tank/vms/web0"] != 40000000000 {
+		t.Errorf("expected zvol grown to 40G, got %v", resized)
+	}
+}
+
+// -- device order --
+
+func TestOrderByDeviceID(t *testing.T) {
+	id := func(d VMPCIModel) types.Int64 { return d.DeviceID }
+	pci := func(deviceID int64) VMPCIModel { return VMPCIModel{DeviceID: types.Int64Value(deviceID)} }
+
+	mapped := []VMPCIModel{pci(50), pci(51), pci(52)}
+	prior := []VMPCIModel{pci(52), {DeviceID: types.Int64Unknown()}, pci(50), pci(49)}
+
+	got := orderByDeviceID(mapped, prior, id)
+	var ids []int64
+	for _, d := range got {
+		ids = append(ids, d.DeviceID.ValueInt64())
+	}
+	// Known devices keep the prior order, the rest follow in API order
+	if !slices.Equal(ids, []int64{52, 50, 51}) {
+		t.Errorf("expected order [52 50 51], got %v", ids)
+	}
+}
+
+func TestVMResource_MapDevicesToModel_KeepsPriorOrder(t *testing.T) {
+	r := &VMResource{}
+	data := VMResourceModel{
+		Disks: []VMDiskModel{
+			{DeviceID: types.Int64Value(51)},
+			{DeviceID: types.Int64Value(50)},
+		},
+	}
+
+	disk := func(id int64, path string) truenas.VMDevice {
+		return truenas.VMDevice{ID: id, DeviceType: truenas.DeviceTypeDisk, Disk: &truenas.DiskDevice{Path: path}}
+	}
+	r.mapDevicesToModel([]truenas.VMDevice{
+		disk(50, "/dev/zvol/tank/vms/a"),
+		disk(51, "/dev/zvol/tank/vms/b"),
+		disk(52, "/dev/zvol/tank/vms/c"),
+	}, &data)
+
+	var paths []string
+	for _, d := range data.Disks {
+		paths = append(paths, d.Path.ValueString())
+	}
+	want := []string{"/dev/zvol/tank/vms/b", "/dev/zvol/tank/vms/a", "/dev/zvol/tank/vms/c"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("expected disks in prior order %v, got %v", want, paths)
+	}
+}
+
+func TestVMResource_ModifyPlan_ReorderedDevices(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(map[string]int{})}}
+
+	disk := func(id, path, serial, order interface{}) vmDiskParams {
+		return vmDiskParams{DeviceID: id, Path: path, Type: "VIRTIO", IOType: "THREADS", Serial: serial, Order: order}
+	}
+	nic := func(id, attach, mac, order interface{}) vmNICParams {
+		return vmNICParams{DeviceID: id, Type: "VIRTIO", NICAttach: attach, MAC: mac, TrustGuestRXFilters: false, Order: order}
+	}
+
+	s := defaultVMPlanParams()
+	s.ID = "7"
+	s.Disks = []vmDiskParams{
+		disk(float64(50), "/dev/zvol/tank/vms/a", "serial-a", float64(1001)),
+		disk(float64(51), "/dev/zvol/tank/vms/b", "serial-b", float64(1002)),
+	}
+	s.NICs = []vmNICParams{nic(float64(60), "br0", "00:a0:98:00:00:01", float64(1003))}
+
+	// The blocks were swapped, and a NIC was added in front of the existing one
+	c := defaultVMPlanParams()
+	c.ID = "7"
+	c.Disks = []vmDiskParams{
+		disk(nil, "/dev/zvol/tank/vms/b", nil, nil),
+		disk(nil, "/dev/zvol/tank/vms/a", nil, nil),
+	}
+	c.NICs = []vmNICParams{nic(nil, "br1", nil, nil), nic(nil, "br0", nil, nil)}
+
+	// What the framework plans: computed values carried over by index
+	p := c
+	p.Disks = []vmDiskParams{
+		disk(float64(50), "/dev/zvol/tank/vms/b", "serial-a", float64(1001)),
+		disk(float64(51), "/dev/zvol/tank/vms/a", "serial-b", float64(1002)),
+	}
+	p.NICs = []vmNICParams{
+		nic(float64(60), "br1", "00:a0:98:00:00:01", float64(1003)),
+		nic(tftypes.UnknownValue, "br0", tftypes.UnknownValue, tftypes.UnknownValue),
+	}
+
+	resp := modifyVMPlanWithConfig(t, r, createVMModelValue(s), createVMModelValue(p), createVMModelValue(c))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var plan VMResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(context.Background(), &plan)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if plan.Disks[0].DeviceID.ValueInt64() != 51 || plan.Disks[0].Serial.ValueString() != "serial-b" || plan.Disks[0].Order.ValueInt64() != 1002 {
+		t.Errorf("expected first disk to stay device 51, got %+v", plan.Disks[0])
+	}
+	if plan.Disks[1].DeviceID.ValueInt64() != 50 || plan.Disks[1].Serial.ValueString() != "serial-a" || plan.Disks[1].Order.ValueInt64() != 1001 {
+		t.Errorf("expected second disk to stay device 50, got %+v", plan.Disks[1])
+	}
+	if !plan.NICs[0].DeviceID.IsUnknown() || !plan.NICs[0].MAC.IsUnknown() || !plan.NICs[0].Order.IsUnknown() {
+		t.Errorf("expected the added NIC to be new, got %+v", plan.NICs[0])
+	}
+	if plan.NICs[1].DeviceID.ValueInt64() != 60 || plan.NICs[1].MAC.ValueString() != "00:a0:98:00:00:01" {
+		t.Errorf("expected the br0 NIC to stay device 60, got %+v", plan.NICs[1])
+	}
+}
+
+func TestVMResource_ModifyPlan_EditedDeviceKeepsIndex(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(map[string]int{})}}
+
+	s := defaultVMPlanParams()
+	s.ID = "7"
+	s.CDROMs = []vmCDROMParams{{DeviceID: float64(70), Path: "/mnt/tank/iso/old.iso", Order: float64(1000)}}
+
+	c := s
+	c.CDROMs = []vmCDROMParams{{DeviceID: nil, Path: "/mnt/tank/iso/new.iso", Order: nil}}
+
+	p := s
+	p.CDROMs = []vmCDROMParams{{DeviceID: float64(70), Path: "/mnt/tank/iso/new.iso", Order: float64(1000)}}
+
+	resp := modifyVMPlanWithConfig(t, r, createVMModelValue(s), createVMModelValue(p), createVMModelValue(c))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var plan VMResourceModel
+	resp.Plan.Get(context.Background(), &plan)
+	// A changed path is an update of the same device, not a new one
+	if plan.CDROMs[0].DeviceID.ValueInt64() != 70 {
+		t.Errorf("expected the edited CD-ROM to stay device 70, got %v", plan.CDROMs[0].DeviceID)
+	}
+}
+
+func TestVMResource_ModifyPlan_DeviceIDsByLabel(t *testing.T) {
+	r := &VMResource{BaseResource: BaseResource{client: vmChoicesClient(map[string]int{})}}
+
+	s := defaultVMPlanParams()
+	s.ID = "7"
+	s.Disks = []vmDiskParams{{DeviceID: float64(50), Path: "/dev/zvol/tank/vms/a", Type: "VIRTIO", IOType: "THREADS", Order: float64(1001)}}
+	s.NICs = []vmNICParams{{DeviceID: float64(60), Type: "VIRTIO", NICAttach: "br0", MAC: "00:a0:98:00:00:01", TrustGuestRXFilters: false, Order: float64(1002)}}
+
+	t.Run("devices unchanged", func(t *testing.T) {
+		p := s
+		p.Description = "changed"
+		p.DeviceIDsByLabel = tftypes.UnknownValue
+
+		resp := modifyVMPlanWithConfig(t, r, createVMModelValue(s), createVMModelValue(p), createVMModelValue(p))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		var plan VMResourceModel
+		resp.Plan.Get(context.Background(), &plan)
+		want := types.MapValueMust(types.Int64Type, map[string]attr.Value{
+			"disk.0": types.Int64Value(50),
+			"nic.0":  types.Int64Value(60),
+		})
+		if !plan.DeviceIDsByLabel.Equal(want) {
+			t.Errorf("expected device_ids_by_label %v, got %v", want, plan.DeviceIDsByLabel)
+		}
+	})
+
+	t.Run("device added", func(t *testing.T) {
+		p := s
+		p.NICs = append(slices.Clone(s.NICs), vmNICParams{DeviceID: tftypes.UnknownValue, Type: "VIRTIO", NICAttach: "br1", MAC: tftypes.UnknownValue, TrustGuestRXFilters: false, Order: tftypes.UnknownValue})
+		p.DeviceIDsByLabel = tftypes.UnknownValue
+
+		resp := modifyVMPlanWithConfig(t, r, createVMModelValue(s), createVMModelValue(p), createVMModelValue(p))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+
+		var plan VMResourceModel
+		resp.Plan.Get(context.Background(), &plan)
+		if !plan.DeviceIDsByLabel.IsUnknown() {
+			t.Errorf("expected device_ids_by_label to be unknown, got %v", plan.DeviceIDsByLabel)
+		}
+	})
+}
+
+// -- uuid --
+
+const testVMUUID = "3f2b6a9e-4c1d-4f0a-9b8e-2d7c5a1e6f30"
+
+// newVMUUIDTestResource returns a VM resource whose raw client answers the
+// UUID conflict check with conflictJSON and records vm.update params.
+func newVMUUIDTestResource(conflictJSON string, methods *[]string, updateParams *any) *VMResource {
+	return &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					*methods = append(*methods, method)
+					if method == "vm.update" {
+						*updateParams = params
+						return json.RawMessage(`{"id": 1}`), nil
+					}
+					filter := params.([]any)[0].([]any)
+					if len(filter) == 2 {
+						return json.RawMessage(conflictJSON), nil
+					}
+					return json.RawMessage(`[{"id": 1, "uuid": "` + testVMUUID + `"}]`), nil
+				},
+			},
+		},
+	}
+}
+
+func TestVMResource_Create_WithUUID(t *testing.T) {
+	var methods []string
+	var updateParams any
+	r := newVMUUIDTestResource(`[]`, &methods, &updateParams)
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.UUID = testVMUUID
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(methods) != 3 || methods[0] != "vm.query" || methods[1] != "vm.update" || methods[2] != "vm.query" {
+		t.Fatalf("expected [vm.query vm.update vm.query], got %v", methods)
+	}
+	opts := updateParams.([]any)[1].(map[string]any)
+	if opts["uuid"] != testVMUUID {
+		t.Errorf("expected uuid %q, got %v", testVMUUID, opts["uuid"])
+	}
+	if _, ok := opts["trusted_platform_module"]; ok {
+		t.Error("expected unmanaged tpm to be left out of vm.update")
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.UUID.ValueString() != testVMUUID {
+		t.Errorf("expected uuid %q, got %q", testVMUUID, model.UUID.ValueString())
+	}
+}
+
+func TestVMResource_Create_UUIDInUse(t *testing.T) {
+	var methods []string
+	var updateParams any
+	r := newVMUUIDTestResource(`[{"id": 4, "name": "old-vm"}]`, &methods, &updateParams)
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.UUID = testVMUUID
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the UUID is used by another VM")
+	}
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	if !strings.Contains(detail, `"old-vm"`) || !strings.Contains(detail, "create_before_destroy") {
+		t.Errorf("expected conflict detail naming the VM and create_before_destroy, got %q", detail)
+	}
+	if updateParams != nil {
+		t.Error("expected vm.update not to be called")
+	}
+}
+
+func TestVMResource_Update_UUIDUnchanged(t *testing.T) {
+	var methods []string
+	var updateParams any
+	r := newVMUUIDTestResource(`[]`, &methods, &updateParams)
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.UUID = testVMUUID
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if updateParams != nil {
+		t.Errorf("expected no vm.update for an unchanged uuid, got %v", updateParams)
+	}
+}
+
+func TestVMResource_Schema_UUIDValidation(t *testing.T) {
+	schemaResp := getVMResourceSchema(t)
+	attr := schemaResp.Schema.Attributes["uuid"].(schema.StringAttribute)
+
+	for value, wantErr := range map[string]bool{
+		testVMUUID:                             false,
+		"3F2B6A9E-4C1D-4F0A-9B8E-2D7C5A1E6F30": true,
+		"not-a-uuid":                           true,
+	} {
+		resp := &validator.StringResponse{}
+		for _, v := range attr.Validators {
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("uuid"),
+				ConfigValue: types.StringValue(value),
+			}, resp)
+		}
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("uuid %q: expected error %v, got %v", value, wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+// -- cpuset and nodeset --
+
+func TestVMResource_Create_WithCPUSetAndNodeSet(t *testing.T) {
+	var methods []string
+	var updateParams any
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				CreateVMFunc: func(ctx context.Context, opts truenas.CreateVMOpts) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					methods = append(methods, method)
+					if method == "vm.update" {
+						updateParams = params
+						return json.RawMessage(`{"id": 1}`), nil
+					}
+					return json.RawMessage(`[{"id": 1, "cpuset": "0-3,8", "nodeset": "0"}]`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.CPUSet = "0-3,8"
+	p.NodeSet = "0"
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if len(methods) != 2 || methods[0] != "vm.update" || methods[1] != "vm.query" {
+		t.Fatalf("expected [vm.update vm.query], got %v", methods)
+	}
+	opts := updateParams.([]any)[1].(map[string]any)
+	if opts["cpuset"] != "0-3,8" || opts["nodeset"] != "0" {
+		t.Errorf("expected cpuset and nodeset in vm.update, got %v", opts)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if model.CPUSet.ValueString() != "0-3,8" || model.NodeSet.ValueString() != "0" {
+		t.Errorf("unexpected cpuset %q and nodeset %q", model.CPUSet.ValueString(), model.NodeSet.ValueString())
+	}
+}
+
+func TestVMResource_Read_NodeSetDrift(t *testing.T) {
+	r := &VMResource{
+		BaseResource: BaseResource{
+			services: &services.TrueNASServices{VM: &truenas.MockVMService{
+				GetVMFunc: func(ctx context.Context, id int64) (*truenas.VM, error) {
+					return mockVM(1, "test-vm", 2048, "STOPPED"), nil
+				},
+				ListDevicesFunc: func(ctx context.Context, vmID int64) ([]truenas.VMDevice, error) {
+					return nil, nil
+				},
+			}},
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					return json.RawMessage(`[{"id": 1, "cpuset": "0-3", "nodeset": null}]`), nil
+				},
+			},
+		},
+	}
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.NodeSet = "0"
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model VMResourceModel
+	resp.State.Get(context.Background(), &model)
+	if !model.NodeSet.Equal(types.StringValue("")) {
+		t.Errorf("expected empty nodeset after it was cleared outside Terraform, got %v", model.NodeSet)
+	}
+	if !model.CPUSet.IsNull() {
+		t.Errorf("expected unmanaged cpuset to stay null, got %v", model.CPUSet)
+	}
+}
+
+func TestVMResource_Update_CPUSetUnchanged(t *testing.T) {
+	var methods []string
+	var updateParams any
+	r := newVMUUIDTestResource(`[]`, &methods, &updateParams)
+
+	schemaResp := getVMResourceSchema(t)
+	p := defaultVMPlanParams()
+	p.ID = "1"
+	p.CPUSet = "0-3"
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createVMModelValue(p)},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if updateParams != nil {
+		t.Errorf("expected no vm.update for an unchanged cpuset, got %v", updateParams)
+	}
+}
+
+func TestVMResource_Schema_CPUListValidation(t *testing.T) {
+	schemaResp := getVMResourceSchema(t)
+
+	for _, name := range []string{"cpuset", "nodeset"} {
+		attr := schemaResp.Schema.Attributes[name].(schema.StringAttribute)
+		for value, wantErr := range map[string]bool{
+			"0":       false,
+			"0-3,8":   false,
+			"0-1,4-5": false,
+			"":        true,
+			"0-":      true,
+			"0, 1":    true,
+		} {
+			resp := &validator.StringResponse{}
+			for _, v := range attr.Validators {
+				v.ValidateString(context.Background(), validator.StringRequest{
+					Path:        path.Root(name),
+					ConfigValue: types.StringValue(value),
+				}, resp)
+			}
+			if resp.Diagnostics.HasError() != wantErr {
+				t.Errorf("%s %q: expected error %v, got %v", name, value, wantErr, resp.Diagnostics)
+			}
+		}
+	}
+}
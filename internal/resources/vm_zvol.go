@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// vmZvolNameAttempts bounds the search for a free zvol name under pool.
+const vmZvolNameAttempts = 100
+
+// zvolDevicePath returns the device path of the zvol with the given name.
+func zvolDevicePath(name string) string {
+	return "/dev/zvol/" + name
+}
+
+// provisionDiskZvols creates the zvols backing new disks that set size, and
+// points their path at the new zvol. Disks that already have a device are
+// left alone.
+func (r *VMResource) provisionDiskZvols(ctx context.Context, vmName string, disks []VMDiskModel) error {
+	for i := range disks {
+		disk := &disks[i]
+		if disk.Size.IsNull() || !(disk.DeviceID.IsNull() || disk.DeviceID.IsUnknown()) {
+			continue
+		}
+
+		volsize, err := truenas.ParseSize(disk.Size.ValueString())
+		if err != nil {
+			return fmt.Errorf("disk %d: unable to parse size %q: %w", i, disk.Size.ValueString(), err)
+		}
+
+		name := disk.ZvolName.ValueString()
+		if disk.ZvolName.IsNull() || disk.ZvolName.IsUnknown() {
+			name, err = r.freeZvolName(ctx, disk.Pool.ValueString(), vmName)
+			if err != nil {
+				return fmt.Errorf("disk %d: %w", i, err)
+			}
+		}
+
+		if _, err := r.services.Dataset.CreateZvol(ctx, truenas.CreateZvolOpts{Name: name, Volsize: volsize}); err != nil {
+			return fmt.Errorf("disk %d: unable to create zvol %q: %w", i, name, err)
+		}
+		tflog.Debug(ctx, "Created zvol for VM disk", map[string]any{
+			"zvol": name,
+			"size": volsize,
+		})
+
+		disk.ZvolName = types.StringValue(name)
+		disk.Path = customtypes.NewFilesystemPathValue(zvolDevicePath(name))
+	}
+	return nil
+}
+
+// freeZvolName returns the first name of the form <pool>/<vm>-disk<n> that
+// is not taken.
+func (r *VMResource) freeZvolName(ctx context.Context, pool, vmName string) (string, error) {
+	for n := 0; n < vmZvolNameAttempts; n++ {
+		name := fmt.Sprintf("%s/%s-disk%d", pool, vmName, n)
+		existing, err := r.services.Dataset.GetZvol(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("unable to look up zvol %q: %w", name, err)
+		}
+		if existing == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no free zvol name for VM %q under %q", vmName, pool)
+}
+
+// resizeDiskZvols grows the zvols of existing disks whose size changed.
+func (r *VMResource) resizeDiskZvols(ctx context.Context, plan, state []VMDiskModel) error {
+	stateByID := make(map[int64]VMDiskModel)
+	for _, s := range state {
+		if !s.DeviceID.IsNull() && !s.DeviceID.IsUnknown() {
+			stateByID[s.DeviceID.ValueInt64()] = s
+		}
+	}
+
+	for _, p := range plan {
+		if p.Size.IsNull() || p.ZvolName.IsNull() || p.DeviceID.IsNull() || p.DeviceID.IsUnknown() {
+			continue
+		}
+		s, ok := stateByID[p.DeviceID.ValueInt64()]
+		if !ok || s.Size.IsNull() {
+			continue
+		}
+
+		volsize, err := truenas.ParseSize(p.Size.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to parse size %q: %w", p.Size.ValueString(), err)
+		}
+		// "20G" and "20000M" are the same zvol
+		if prior, err := truenas.ParseSize(s.Size.ValueString()); err == nil && prior == volsize {
+			continue
+		}
+		if _, err := r.services.Dataset.UpdateZvol(ctx, p.ZvolName.ValueString(), truenas.UpdateZvolOpts{Volsize: &volsize}); err != nil {
+			return fmt.Errorf("unable to resize zvol %q: %w", p.ZvolName.ValueString(), err)
+		}
+	}
+	return nil
+}
+
+// deleteManagedZvols deletes the zvols of disks with manage_zvol = true
+// whose device is not in keep.
+func (r *VMResource) deleteManagedZvols(ctx context.Context, disks []VMDiskModel, keep map[int64]bool) error {
+	for _, d := range disks {
+		if !d.ManageZvol.ValueBool() || d.ZvolName.IsNull() || d.ZvolName.ValueString() == "" {
+			continue
+		}
+		if !d.DeviceID.IsNull() && keep[d.DeviceID.ValueInt64()] {
+			continue
+		}
+
+		name := d.ZvolName.ValueString()
+		if err := r.services.Dataset.DeleteZvol(ctx, name); err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("unable to delete zvol %q: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &WebDAVConfigResource{}
+	_ resource.ResourceWithConfigure   = &WebDAVConfigResource{}
+	_ resource.ResourceWithImportState = &WebDAVConfigResource{}
+)
+
+// WebDAVConfigResourceModel describes the resource data model.
+type WebDAVConfigResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Protocol   types.String `tfsdk:"protocol"`
+	TCPPort    types.Int64  `tfsdk:"tcpport"`
+	TCPPortSSL types.Int64  `tfsdk:"tcpportssl"`
+	Password   types.String `tfsdk:"password"`
+	HTAuth     types.String `tfsdk:"htauth"`
+	CertSSL    types.Int64  `tfsdk:"certssl"`
+}
+
+// webdavConfigResponse is the JSON shape returned by webdav.config and webdav.update.
+type webdavConfigResponse struct {
+	ID         int64  `json:"id"`
+	Protocol   string `json:"protocol"`
+	TCPPort    int64  `json:"tcpport"`
+	TCPPortSSL int64  `json:"tcpportssl"`
+	Password   string `json:"password"`
+	HTAuth     string `json:"htauth"`
+	CertSSL    *int64 `json:"certssl"`
+}
+
+// WebDAVConfigResource defines the resource implementation.
+type WebDAVConfigResource struct {
+	BaseResource
+}
+
+// NewWebDAVConfigResource creates a new WebDAVConfigResource.
+func NewWebDAVConfigResource() resource.Resource {
+	return &WebDAVConfigResource{}
+}
+
+func (r *WebDAVConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webdav_config"
+}
+
+func (r *WebDAVConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the TrueNAS WebDAV service configuration. This is a singleton resource. " +
+			"The WebDAV service was removed in TrueNAS 24.10, so this resource requires an earlier version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Resource identifier (always 'webdav_config').",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Description: "Protocol to serve: HTTP, HTTPS or HTTPHTTPS. Defaults to HTTP.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("HTTP"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("HTTP", "HTTPS", "HTTPHTTPS"),
+				},
+			},
+			"tcpport": schema.Int64Attribute{
+				Description: "HTTP port. Defaults to 8080.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(8080),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"tcpportssl": schema.Int64Attribute{
+				Description: "HTTPS port. Defaults to 8081.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(8081),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password for the webdav user. Defaults to \"davtest\".",
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Default:     stringdefault.StaticString("davtest"),
+			},
+			"htauth": schema.StringAttribute{
+				Description: "HTTP authentication type: NONE, BASIC or DIGEST. Defaults to DIGEST.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("DIGEST"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("NONE", "BASIC", "DIGEST"),
+				},
+			},
+			"certssl": schema.Int64Attribute{
+				Description: "ID of the certificate used for HTTPS.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *WebDAVConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebDAVConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkWebDAVSupported(r.client.Version())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildWebDAVConfigParams(&data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update WebDAV Config",
+			fmt.Sprintf("Unable to update WebDAV configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapWebDAVConfigToModel(config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebDAVConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebDAVConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "webdav.config", nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read WebDAV Config",
+			fmt.Sprintf("Unable to read WebDAV configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	var config webdavConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse WebDAV configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapWebDAVConfigToModel(&config, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *WebDAVConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan WebDAVConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkWebDAVSupported(r.client.Version())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.updateConfig(ctx, buildWebDAVConfigParams(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update WebDAV Config",
+			fmt.Sprintf("Unable to update WebDAV configuration: %s", err.Error()),
+		)
+		return
+	}
+
+	mapWebDAVConfigToModel(config, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WebDAVConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Reset to TrueNAS defaults
+	params := map[string]any{
+		"protocol":   "HTTP",
+		"tcpport":    8080,
+		"tcpportssl": 8081,
+		"password":   "davtest",
+		"htauth":     "DIGEST",
+		"certssl":    nil,
+	}
+
+	if _, err := r.updateConfig(ctx, params); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Reset WebDAV Config",
+			fmt.Sprintf("Unable to reset WebDAV configuration: %s", err.Error()),
+		)
+		return
+	}
+}
+
+func (r *WebDAVConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Validate the import ID - must be "webdav_config"
+	if req.ID != "webdav_config" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID 'webdav_config', got %q. This resource is a singleton.", req.ID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// updateConfig calls webdav.update and parses the response.
+func (r *WebDAVConfigResource) updateConfig(ctx context.Context, params map[string]any) (*webdavConfigResponse, error) {
+	result, err := r.client.Call(ctx, "webdav.update", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var config webdavConfigResponse
+	if err := json.Unmarshal(result, &config); err != nil {
+		return nil, fmt.Errorf("parse webdav update response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// buildWebDAVConfigParams builds the webdav.update params from the resource model.
+func buildWebDAVConfigParams(data *WebDAVConfigResourceModel) map[string]any {
+	params := map[string]any{
+		"protocol":   data.Protocol.ValueString(),
+		"tcpport":    data.TCPPort.ValueInt64(),
+		"tcpportssl": data.TCPPortSSL.ValueInt64(),
+		"password":   data.Password.ValueString(),
+		"htauth":     data.HTAuth.ValueString(),
+	}
+
+	if !data.CertSSL.IsNull() && !data.CertSSL.IsUnknown() {
+		params["certssl"] = data.CertSSL.ValueInt64()
+	} else {
+		params["certssl"] = nil
+	}
+
+	return params
+}
+
+// mapWebDAVConfigToModel maps the API response to the resource model.
+func mapWebDAVConfigToModel(config *webdavConfigResponse, data *WebDAVConfigResourceModel) {
+	data.ID = types.StringValue("webdav_config")
+	data.Protocol = types.StringValue(config.Protocol)
+	data.TCPPort = types.Int64Value(config.TCPPort)
+	data.TCPPortSSL = types.Int64Value(config.TCPPortSSL)
+	data.Password = types.StringValue(config.Password)
+	data.HTAuth = types.StringValue(config.HTAuth)
+	data.CertSSL = nilableInt64Value(config.CertSSL)
+}
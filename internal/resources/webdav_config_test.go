@@ -0,0 +1,546 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewWebDAVConfigResource(t *testing.T) {
+	r := NewWebDAVConfigResource()
+	if r == nil {
+		t.Fatal("NewWebDAVConfigResource returned nil")
+	}
+
+	_, ok := r.(*WebDAVConfigResource)
+	if !ok {
+		t.Fatalf("expected *WebDAVConfigResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*WebDAVConfigResource))
+	_ = resource.ResourceWithImportState(r.(*WebDAVConfigResource))
+}
+
+func TestWebDAVConfigResource_Metadata(t *testing.T) {
+	r := NewWebDAVConfigResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_webdav_config" {
+		t.Errorf("expected TypeName 'truenas_webdav_config', got %q", resp.TypeName)
+	}
+}
+
+func TestWebDAVConfigResource_Schema(t *testing.T) {
+	schemaResp := getWebDAVConfigResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"protocol", "tcpport", "tcpportssl", "password", "htauth", "certssl"} {
+		attr, ok := attrs[name]
+		if !ok {
+			t.Errorf("expected '%s' attribute", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+	if !attrs["password"].IsSensitive() {
+		t.Error("expected 'password' attribute to be sensitive")
+	}
+}
+
+// Test helpers
+
+func getWebDAVConfigResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewWebDAVConfigResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// webdavConfigModelParams holds parameters for creating test model values.
+type webdavConfigModelParams struct {
+	ID         interface{}
+	Protocol   interface{}
+	TCPPort    interface{}
+	TCPPortSSL interface{}
+	Password   interface{}
+	HTAuth     interface{}
+	CertSSL    interface{}
+}
+
+func createWebDAVConfigModelValue(p webdavConfigModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":         tftypes.String,
+			"protocol":   tftypes.String,
+			"tcpport":    tftypes.Number,
+			"tcpportssl": tftypes.Number,
+			"password":   tftypes.String,
+			"htauth":     tftypes.String,
+			"certssl":    tftypes.Number,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, p.ID),
+		"protocol":   tftypes.NewValue(tftypes.String, p.Protocol),
+		"tcpport":    tftypes.NewValue(tftypes.Number, p.TCPPort),
+		"tcpportssl": tftypes.NewValue(tftypes.Number, p.TCPPortSSL),
+		"password":   tftypes.NewValue(tftypes.String, p.Password),
+		"htauth":     tftypes.NewValue(tftypes.String, p.HTAuth),
+		"certssl":    tftypes.NewValue(tftypes.Number, p.CertSSL),
+	})
+}
+
+func defaultWebDAVConfigParams() webdavConfigModelParams {
+	return webdavConfigModelParams{
+		Protocol:   "HTTPHTTPS",
+		TCPPort:    float64(8080),
+		TCPPortSSL: float64(8443),
+		Password:   "s3cret",
+		HTAuth:     "BASIC",
+		CertSSL:    float64(1),
+	}
+}
+
+const testWebDAVConfigJSON = `{
+	"id": 1,
+	"protocol": "HTTPHTTPS",
+	"tcpport": 8080,
+	"tcpportssl": 8443,
+	"password": "s3cret",
+	"htauth": "BASIC",
+	"certssl": 1
+}`
+
+func TestWebDAVConfigResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: truenas.Version{Major: 24, Minor: 4, Patch: 2},
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testWebDAVConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	planValue := createWebDAVConfigModelValue(defaultWebDAVConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "webdav.update" {
+		t.Errorf("expected method 'webdav.update', got %q", capturedMethod)
+	}
+	if capturedParams["protocol"] != "HTTPHTTPS" {
+		t.Errorf("expected protocol 'HTTPHTTPS', got %v", capturedParams["protocol"])
+	}
+	if capturedParams["certssl"] != int64(1) {
+		t.Errorf("expected certssl 1, got %v", capturedParams["certssl"])
+	}
+
+	var model WebDAVConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "webdav_config" {
+		t.Errorf("expected ID 'webdav_config', got %q", model.ID.ValueString())
+	}
+	if model.TCPPortSSL.ValueInt64() != 8443 {
+		t.Errorf("expected tcpportssl 8443, got %d", model.TCPPortSSL.ValueInt64())
+	}
+}
+
+func TestWebDAVConfigResource_Create_NullCertificate(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1, "protocol": "HTTP", "tcpport": 8080, "tcpportssl": 8081, "password": "davtest", "htauth": "DIGEST", "certssl": null}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	planValue := createWebDAVConfigModelValue(webdavConfigModelParams{
+		Protocol:   "HTTP",
+		TCPPort:    float64(8080),
+		TCPPortSSL: float64(8081),
+		Password:   "davtest",
+		HTAuth:     "DIGEST",
+		CertSSL:    nil,
+	})
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if v, ok := capturedParams["certssl"]; !ok || v != nil {
+		t.Errorf("expected certssl to be sent as nil, got %v", v)
+	}
+
+	var model WebDAVConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if !model.CertSSL.IsNull() {
+		t.Errorf("expected certssl to be null, got %v", model.CertSSL)
+	}
+}
+
+func TestWebDAVConfigResource_Create_UnsupportedVersion(t *testing.T) {
+	called := false
+
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: truenas.Version{Major: 24, Minor: 10, Patch: 2, Build: 4},
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				called = true
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	planValue := createWebDAVConfigModelValue(defaultWebDAVConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for TrueNAS 24.10")
+	}
+	if called {
+		t.Error("expected no API call on unsupported version")
+	}
+}
+
+func TestWebDAVConfigResource_Create_APIError(t *testing.T) {
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("certificate not found")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	planValue := createWebDAVConfigModelValue(defaultWebDAVConfigParams())
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestWebDAVConfigResource_Read_Success(t *testing.T) {
+	var capturedMethod string
+
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				return json.RawMessage(testWebDAVConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	p := defaultWebDAVConfigParams()
+	p.ID = "webdav_config"
+	p.HTAuth = "DIGEST"
+	stateValue := createWebDAVConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "webdav.config" {
+		t.Errorf("expected method 'webdav.config', got %q", capturedMethod)
+	}
+
+	var model WebDAVConfigResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.HTAuth.ValueString() != "BASIC" {
+		t.Errorf("expected htauth 'BASIC', got %q", model.HTAuth.ValueString())
+	}
+}
+
+func TestWebDAVConfigResource_Read_APIError(t *testing.T) {
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	p := defaultWebDAVConfigParams()
+	p.ID = "webdav_config"
+	stateValue := createWebDAVConfigModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestWebDAVConfigResource_Update_Success(t *testing.T) {
+	var capturedParams map[string]any
+
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testWebDAVConfigJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	stateParams := defaultWebDAVConfigParams()
+	stateParams.ID = "webdav_config"
+	stateParams.HTAuth = "DIGEST"
+	stateValue := createWebDAVConfigModelValue(stateParams)
+	planParams := defaultWebDAVConfigParams()
+	planParams.ID = "webdav_config"
+	planValue := createWebDAVConfigModelValue(planParams)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedParams["htauth"] != "BASIC" {
+		t.Errorf("expected htauth 'BASIC', got %v", capturedParams["htauth"])
+	}
+}
+
+func TestWebDAVConfigResource_Update_APIError(t *testing.T) {
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	p := defaultWebDAVConfigParams()
+	p.ID = "webdav_config"
+	value := createWebDAVConfigModelValue(p)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestWebDAVConfigResource_Delete_ResetsDefaults(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(`{"id": 1}`), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	p := defaultWebDAVConfigParams()
+	p.ID = "webdav_config"
+	stateValue := createWebDAVConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "webdav.update" {
+		t.Errorf("expected method 'webdav.update', got %q", capturedMethod)
+	}
+	if capturedParams["protocol"] != "HTTP" {
+		t.Errorf("expected protocol reset to 'HTTP', got %v", capturedParams["protocol"])
+	}
+	if capturedParams["certssl"] != nil {
+		t.Errorf("expected certssl reset to nil, got %v", capturedParams["certssl"])
+	}
+}
+
+func TestWebDAVConfigResource_Delete_APIError(t *testing.T) {
+	r := &WebDAVConfigResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVConfigResourceSchema(t)
+	p := defaultWebDAVConfigParams()
+	p.ID = "webdav_config"
+	stateValue := createWebDAVConfigModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestWebDAVConfigResource_ImportState(t *testing.T) {
+	r := NewWebDAVConfigResource().(*WebDAVConfigResource)
+	schemaResp := getWebDAVConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "webdav_config"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createWebDAVConfigModelValue(webdavConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
+
+func TestWebDAVConfigResource_ImportState_InvalidID(t *testing.T) {
+	r := NewWebDAVConfigResource().(*WebDAVConfigResource)
+	schemaResp := getWebDAVConfigResourceSchema(t)
+
+	req := resource.ImportStateRequest{ID: "something"}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    createWebDAVConfigModelValue(webdavConfigModelParams{}),
+		},
+	}
+
+	r.ImportState(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid import ID")
+	}
+}
@@ -0,0 +1,342 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
+	truenas "github.com/deevus/truenas-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &WebDAVShareResource{}
+	_ resource.ResourceWithConfigure   = &WebDAVShareResource{}
+	_ resource.ResourceWithImportState = &WebDAVShareResource{}
+)
+
+// WebDAVShareResourceModel describes the resource data model.
+type WebDAVShareResourceModel struct {
+	ID      types.String                    `tfsdk:"id"`
+	Name    types.String                    `tfsdk:"name"`
+	Path    customtypes.FilesystemPathValue `tfsdk:"path"`
+	Comment types.String                    `tfsdk:"comment"`
+	RO      types.Bool                      `tfsdk:"ro"`
+	Perm    types.Bool                      `tfsdk:"perm"`
+	Enabled types.Bool                      `tfsdk:"enabled"`
+}
+
+// webdavShareResponse is the JSON shape returned by sharing.webdav.* methods.
+type webdavShareResponse struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Comment string `json:"comment"`
+	RO      bool   `json:"ro"`
+	Perm    bool   `json:"perm"`
+	Enabled bool   `json:"enabled"`
+}
+
+// webdavShareAPIFieldPaths maps sharing.webdav validation errors to attributes.
+var webdavShareAPIFieldPaths = apiFieldPaths("name", "path", "comment", "ro", "perm", "enabled")
+
+// WebDAVShareResource defines the resource implementation.
+type WebDAVShareResource struct {
+	BaseResource
+}
+
+// NewWebDAVShareResource creates a new WebDAVShareResource.
+func NewWebDAVShareResource() resource.Resource {
+	return &WebDAVShareResource{}
+}
+
+func (r *WebDAVShareResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webdav_share"
+}
+
+func (r *WebDAVShareResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a WebDAV share on TrueNAS. The WebDAV service was removed in TrueNAS 24.10, " +
+			"so this resource requires an earlier version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Share ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Share name, used in the WebDAV URL.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"path": schema.StringAttribute{
+				CustomType:  customtypes.FilesystemPathType{},
+				Description: "Path to share (e.g., `/mnt/tank/webdav`).",
+				Required:    true,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Share description. Defaults to \"\".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"ro": schema.BoolAttribute{
+				Description: "Share is read-only. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"perm": schema.BoolAttribute{
+				Description: "Change ownership of the shared path to the webdav user. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Share is enabled. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *WebDAVShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebDAVShareResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkWebDAVSupported(r.client.Version())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.Call(ctx, "sharing.webdav.create", buildWebDAVShareParams(&data))
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, webdavShareAPIFieldPaths,
+			"Unable to Create WebDAV Share",
+			fmt.Sprintf("Unable to create WebDAV share %q: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var share webdavShareResponse
+	if err := json.Unmarshal(result, &share); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse WebDAV share create response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapWebDAVShareToModel(&share, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebDAVShareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebDAVShareResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Share ID",
+			fmt.Sprintf("Cannot parse WebDAV share ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	share, err := r.getShare(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read WebDAV Share",
+			fmt.Sprintf("Unable to read WebDAV share %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	if share == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapWebDAVShareToModel(share, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
+}
+
+func (r *WebDAVShareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan WebDAVShareResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkWebDAVSupported(r.client.Version())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(plan.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Share ID",
+			fmt.Sprintf("Cannot parse WebDAV share ID %q: %s", plan.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	result, err := r.client.Call(ctx, "sharing.webdav.update", []any{id, buildWebDAVShareParams(&plan)})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, webdavShareAPIFieldPaths,
+			"Unable to Update WebDAV Share",
+			fmt.Sprintf("Unable to update WebDAV share %d: %s", id, err.Error()),
+		)
+		return
+	}
+
+	var share webdavShareResponse
+	if err := json.Unmarshal(result, &share); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Parse Response",
+			fmt.Sprintf("Unable to parse WebDAV share update response: %s", err.Error()),
+		)
+		return
+	}
+
+	mapWebDAVShareToModel(&share, &plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WebDAVShareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WebDAVShareResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(data.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Share ID",
+			fmt.Sprintf("Cannot parse WebDAV share ID %q: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if _, err := r.client.Call(ctx, "sharing.webdav.delete", id); err != nil {
+		if isNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Delete WebDAV Share",
+			fmt.Sprintf("Unable to delete WebDAV share %d: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *WebDAVShareResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sharePath, ok := importPath(req.ID)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	id, err := findShareIDByPath(ctx, r.client, "sharing.webdav.query", sharePath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import WebDAV Share",
+			fmt.Sprintf("Unable to look up WebDAV share of %q: %s", sharePath, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(id, 10))...)
+}
+
+// getShare queries a WebDAV share by ID. Returns nil if the share does not exist.
+func (r *WebDAVShareResource) getShare(ctx context.Context, id int64) (*webdavShareResponse, error) {
+	filter := []any{[]any{[]any{"id", "=", id}}}
+
+	result, err := r.client.Call(ctx, "sharing.webdav.query", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var shares []webdavShareResponse
+	if err := json.Unmarshal(result, &shares); err != nil {
+		return nil, fmt.Errorf("parse webdav share query response: %w", err)
+	}
+
+	if len(shares) == 0 {
+		return nil, nil
+	}
+
+	return &shares[0], nil
+}
+
+// checkWebDAVSupported rejects TrueNAS versions that no longer ship the WebDAV service.
+// An undetected version is allowed through and left to the API to reject.
+func checkWebDAVSupported(version truenas.Version) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !version.IsZero() && version.AtLeast(24, 10) {
+		diags.AddError(
+			"Unsupported TrueNAS Version",
+			fmt.Sprintf("WebDAV resources require a TrueNAS version earlier than 24.10, where the WebDAV service was removed. Detected version: %s", version.String()),
+		)
+	}
+	return diags
+}
+
+// buildWebDAVShareParams builds the sharing.webdav create/update params from the resource model.
+func buildWebDAVShareParams(data *WebDAVShareResourceModel) map[string]any {
+	return map[string]any{
+		"name":    data.Name.ValueString(),
+		"path":    data.Path.ValueString(),
+		"comment": data.Comment.ValueString(),
+		"ro":      data.RO.ValueBool(),
+		"perm":    data.Perm.ValueBool(),
+		"enabled": data.Enabled.ValueBool(),
+	}
+}
+
+// mapWebDAVShareToModel maps the API response to the resource model.
+func mapWebDAVShareToModel(share *webdavShareResponse, data *WebDAVShareResourceModel) {
+	data.ID = types.StringValue(strconv.FormatInt(share.ID, 10))
+	data.Name = types.StringValue(share.Name)
+	data.Path = customtypes.NewFilesystemPathValue(share.Path)
+	data.Comment = types.StringValue(share.Comment)
+	data.RO = types.BoolValue(share.RO)
+	data.Perm = types.BoolValue(share.Perm)
+	data.Enabled = types.BoolValue(share.Enabled)
+}
@@ -0,0 +1,655 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewWebDAVShareResource(t *testing.T) {
+	r := NewWebDAVShareResource()
+	if r == nil {
+		t.Fatal("NewWebDAVShareResource returned nil")
+	}
+
+	_, ok := r.(*WebDAVShareResource)
+	if !ok {
+		t.Fatalf("expected *WebDAVShareResource, got %T", r)
+	}
+
+	// Verify interface implementations
+	_ = resource.Resource(r)
+	_ = resource.ResourceWithConfigure(r.(*WebDAVShareResource))
+	_ = resource.ResourceWithImportState(r.(*WebDAVShareResource))
+}
+
+func TestWebDAVShareResource_Metadata(t *testing.T) {
+	r := NewWebDAVShareResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "truenas",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), req, resp)
+
+	if resp.TypeName != "truenas_webdav_share" {
+		t.Errorf("expected TypeName 'truenas_webdav_share', got %q", resp.TypeName)
+	}
+}
+
+func TestWebDAVShareResource_Schema(t *testing.T) {
+	schemaResp := getWebDAVShareResourceSchema(t)
+
+	if schemaResp.Schema.Description == "" {
+		t.Error("expected non-empty schema description")
+	}
+
+	attrs := schemaResp.Schema.Attributes
+	if !attrs["id"].IsComputed() {
+		t.Error("expected 'id' attribute to be computed")
+	}
+	for _, name := range []string{"name", "path"} {
+		if !attrs[name].IsRequired() {
+			t.Errorf("expected '%s' attribute to be required", name)
+		}
+	}
+	for _, name := range []string{"comment", "ro", "perm", "enabled"} {
+		if !attrs[name].IsOptional() {
+			t.Errorf("expected '%s' attribute to be optional", name)
+		}
+	}
+}
+
+// Test helpers
+
+func getWebDAVShareResourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	r := NewWebDAVShareResource()
+	schemaReq := resource.SchemaRequest{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), schemaReq, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("failed to get schema: %v", schemaResp.Diagnostics)
+	}
+	return *schemaResp
+}
+
+// webdavShareModelParams holds parameters for creating test model values.
+type webdavShareModelParams struct {
+	ID      interface{}
+	Name    interface{}
+	Path    interface{}
+	Comment interface{}
+	RO      interface{}
+	Perm    interface{}
+	Enabled interface{}
+}
+
+func createWebDAVShareModelValue(p webdavShareModelParams) tftypes.Value {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":      tftypes.String,
+			"name":    tftypes.String,
+			"path":    tftypes.String,
+			"comment": tftypes.String,
+			"ro":      tftypes.Bool,
+			"perm":    tftypes.Bool,
+			"enabled": tftypes.Bool,
+		},
+	}
+
+	return tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, p.ID),
+		"name":    tftypes.NewValue(tftypes.String, p.Name),
+		"path":    tftypes.NewValue(tftypes.String, p.Path),
+		"comment": tftypes.NewValue(tftypes.String, p.Comment),
+		"ro":      tftypes.NewValue(tftypes.Bool, p.RO),
+		"perm":    tftypes.NewValue(tftypes.Bool, p.Perm),
+		"enabled": tftypes.NewValue(tftypes.Bool, p.Enabled),
+	})
+}
+
+func defaultWebDAVShareParams() webdavShareModelParams {
+	return webdavShareModelParams{
+		ID:      "3",
+		Name:    "docs",
+		Path:    "/mnt/tank/docs",
+		Comment: "Shared documents",
+		RO:      true,
+		Perm:    true,
+		Enabled: true,
+	}
+}
+
+const testWebDAVShareJSON = `{
+	"id": 3,
+	"name": "docs",
+	"path": "/mnt/tank/docs",
+	"comment": "Shared documents",
+	"ro": true,
+	"perm": true,
+	"enabled": true
+}`
+
+func TestWebDAVShareResource_Create_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams map[string]any
+
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: truenas.Version{Major: 24, Minor: 4, Patch: 2},
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.(map[string]any)
+				return json.RawMessage(testWebDAVShareJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	p := defaultWebDAVShareParams()
+	p.ID = tftypes.UnknownValue
+	planValue := createWebDAVShareModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "sharing.webdav.create" {
+		t.Errorf("expected method 'sharing.webdav.create', got %q", capturedMethod)
+	}
+	if capturedParams["path"] != "/mnt/tank/docs" {
+		t.Errorf("expected path '/mnt/tank/docs', got %v", capturedParams["path"])
+	}
+	if capturedParams["ro"] != true {
+		t.Errorf("expected ro true, got %v", capturedParams["ro"])
+	}
+
+	var model WebDAVShareResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "3" {
+		t.Errorf("expected ID '3', got %q", model.ID.ValueString())
+	}
+}
+
+func TestWebDAVShareResource_Create_UnsupportedVersion(t *testing.T) {
+	called := false
+
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: truenas.Version{Major: 24, Minor: 10, Patch: 2, Build: 4},
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				called = true
+				return nil, nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	p := defaultWebDAVShareParams()
+	p.ID = tftypes.UnknownValue
+	planValue := createWebDAVShareModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for TrueNAS 24.10")
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Summary(), "Unsupported TrueNAS Version") {
+		t.Errorf("expected version error, got %v", resp.Diagnostics)
+	}
+	if called {
+		t.Error("expected no API call on unsupported version")
+	}
+}
+
+func TestWebDAVShareResource_Create_APIError(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("path does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	p := defaultWebDAVShareParams()
+	p.ID = tftypes.UnknownValue
+	planValue := createWebDAVShareModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestWebDAVShareResource_Create_ValidationError(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, newValidationRPCError([]any{"sharing_webdav_create.path", "The path must reside within a pool mount point", float64(22)})
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	p := defaultWebDAVShareParams()
+	p.ID = tftypes.UnknownValue
+	planValue := createWebDAVShareModelValue(p)
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("path")) {
+		t.Errorf("expected error on the 'path' attribute, got %v", resp.Diagnostics)
+	}
+}
+
+func TestWebDAVShareResource_Read_Success(t *testing.T) {
+	var capturedMethod string
+
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				return json.RawMessage(`[` + testWebDAVShareJSON + `]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	p := defaultWebDAVShareParams()
+	p.Comment = "stale"
+	stateValue := createWebDAVShareModelValue(p)
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "sharing.webdav.query" {
+		t.Errorf("expected method 'sharing.webdav.query', got %q", capturedMethod)
+	}
+
+	var model WebDAVShareResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.Comment.ValueString() != "Shared documents" {
+		t.Errorf("expected comment 'Shared documents', got %q", model.Comment.ValueString())
+	}
+}
+
+func TestWebDAVShareResource_Read_NotFound(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return json.RawMessage(`[]`), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected state to be removed when share does not exist")
+	}
+}
+
+func TestWebDAVShareResource_Read_APIError(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestWebDAVShareResource_Update_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams []any
+
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params.([]any)
+				return json.RawMessage(testWebDAVShareJSON), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateParams := defaultWebDAVShareParams()
+	stateParams.RO = false
+	stateValue := createWebDAVShareModelValue(stateParams)
+	planValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "sharing.webdav.update" {
+		t.Errorf("expected method 'sharing.webdav.update', got %q", capturedMethod)
+	}
+	if capturedParams[0] != int64(3) {
+		t.Errorf("expected share ID 3, got %v", capturedParams[0])
+	}
+	if capturedParams[1].(map[string]any)["ro"] != true {
+		t.Errorf("expected ro true, got %v", capturedParams[1])
+	}
+}
+
+func TestWebDAVShareResource_Update_UnsupportedVersion(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			VersionVal: truenas.Version{Major: 25, Minor: 4},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: stateValue},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for TrueNAS 25.04")
+	}
+}
+
+func TestWebDAVShareResource_Delete_Success(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				capturedMethod = method
+				capturedParams = params
+				return json.RawMessage(`true`), nil
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "sharing.webdav.delete" {
+		t.Errorf("expected method 'sharing.webdav.delete', got %q", capturedMethod)
+	}
+	if capturedParams != int64(3) {
+		t.Errorf("expected share ID 3, got %v", capturedParams)
+	}
+}
+
+func TestWebDAVShareResource_Delete_NotFound(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("share 3 does not exist")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected not-found to be ignored, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWebDAVShareResource_Delete_APIError(t *testing.T) {
+	r := &WebDAVShareResource{
+		BaseResource: BaseResource{client: &client.MockClient{
+			CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+				return nil, errors.New("connection refused")
+			},
+		}},
+	}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	stateValue := createWebDAVShareModelValue(defaultWebDAVShareParams())
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+
+	r.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for API error")
+	}
+}
+
+func TestCheckWebDAVSupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		version truenas.Version
+		wantErr bool
+	}{
+		{"undetected", truenas.Version{}, false},
+		{"24.04", truenas.Version{Major: 24, Minor: 4, Patch: 2}, false},
+		{"24.10", truenas.Version{Major: 24, Minor: 10}, true},
+		{"25.04", truenas.Version{Major: 25, Minor: 4}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := checkWebDAVSupported(tt.version)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func importWebDAVShare(t *testing.T, id string, callFunc func(ctx context.Context, method string, params any) (json.RawMessage, error)) *resource.ImportStateResponse {
+	t.Helper()
+
+	r := &WebDAVShareResource{BaseResource: BaseResource{client: &client.MockClient{CallFunc: callFunc}}}
+
+	schemaResp := getWebDAVShareResourceSchema(t)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: createWebDAVShareModelValue(webdavShareModelParams{})},
+	}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+	return resp
+}
+
+func TestWebDAVShareResource_ImportState_ByID(t *testing.T) {
+	resp := importWebDAVShare(t, "3", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		t.Errorf("unexpected call to %q", method)
+		return nil, nil
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var model WebDAVShareResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "3" {
+		t.Errorf("expected ID '3', got %q", model.ID.ValueString())
+	}
+}
+
+func TestWebDAVShareResource_ImportState_ByPath(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	resp := importWebDAVShare(t, "path=/mnt/tank/docs/", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		capturedMethod = method
+		capturedParams = params
+		return json.RawMessage(`[` + testWebDAVShareJSON + `]`), nil
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if capturedMethod != "sharing.webdav.query" {
+		t.Errorf("expected method 'sharing.webdav.query', got %q", capturedMethod)
+	}
+	if got := fmt.Sprint(capturedParams); got != "[[[path = /mnt/tank/docs]]]" {
+		t.Errorf("unexpected query filter: %s", got)
+	}
+
+	var model WebDAVShareResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if model.ID.ValueString() != "3" {
+		t.Errorf("expected ID '3', got %q", model.ID.ValueString())
+	}
+}
+
+func TestWebDAVShareResource_ImportState_PathNotFound(t *testing.T) {
+	resp := importWebDAVShare(t, "path=/mnt/tank/missing", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return json.RawMessage(`[]`), nil
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a path with no share")
+	}
+}
+
+func TestWebDAVShareResource_ImportState_PathAmbiguous(t *testing.T) {
+	resp := importWebDAVShare(t, "path=/mnt/tank/docs", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return json.RawMessage(`[{"id": 3}, {"id": 7}]`), nil
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a path with several shares")
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, "3, 7") {
+		t.Errorf("expected share IDs in error, got %q", detail)
+	}
+}
+
+func TestWebDAVShareResource_ImportState_QueryError(t *testing.T) {
+	resp := importWebDAVShare(t, "path=/mnt/tank/docs", func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+		return nil, errors.New("connection refused")
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the query fails")
+	}
+}
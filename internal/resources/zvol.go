@@ -16,23 +16,33 @@ import (
 var _ resource.Resource = &ZvolResource{}
 var _ resource.ResourceWithConfigure = &ZvolResource{}
 var _ resource.ResourceWithImportState = &ZvolResource{}
+var _ resource.ResourceWithModifyPlan = &ZvolResource{}
+var _ resource.ResourceWithValidateConfig = &ZvolResource{}
+
+// zvolModeledProperties are the zvol properties set through their own
+// attribute, which properties must not repeat.
+var zvolModeledProperties = []string{"volsize", "volblocksize", "sparse", "compression", "comments"}
 
 type ZvolResource struct {
 	BaseResource
 }
 
 type ZvolResourceModel struct {
-	ID           types.String                `tfsdk:"id"`
-	Pool         types.String                `tfsdk:"pool"`
-	Path         types.String                `tfsdk:"path"`
-	Parent       types.String                `tfsdk:"parent"`
-	Volsize      customtypes.SizeStringValue `tfsdk:"volsize"`
-	Volblocksize types.String                `tfsdk:"volblocksize"`
-	Sparse       types.Bool                  `tfsdk:"sparse"`
-	ForceSize    types.Bool                  `tfsdk:"force_size"`
-	Compression  types.String                `tfsdk:"compression"`
-	Comments     types.String                `tfsdk:"comments"`
-	ForceDestroy types.Bool                  `tfsdk:"force_destroy"`
+	ID              types.String                 `tfsdk:"id"`
+	Pool            customtypes.DatasetPathValue `tfsdk:"pool"`
+	Path            customtypes.DatasetPathValue `tfsdk:"path"`
+	Parent          customtypes.DatasetPathValue `tfsdk:"parent"`
+	Volsize         customtypes.SizeStringValue  `tfsdk:"volsize"`
+	Volblocksize    types.String                 `tfsdk:"volblocksize"`
+	Sparse          types.Bool                   `tfsdk:"sparse"`
+	ForceSize       types.Bool                   `tfsdk:"force_size"`
+	Compression     types.String                 `tfsdk:"compression"`
+	Comments        types.String                 `tfsdk:"comments"`
+	ForceDestroy    types.Bool                   `tfsdk:"force_destroy"`
+	Recursive       types.Bool                   `tfsdk:"recursive"`
+	Force           types.Bool                   `tfsdk:"force"`
+	ProtectNonempty types.Bool                   `tfsdk:"protect_nonempty"`
+	Properties      types.Map                    `tfsdk:"properties"`
 }
 
 func NewZvolResource() resource.Resource {
@@ -86,9 +96,14 @@ func (r *ZvolResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		},
 	}
 	attrs["force_destroy"] = schema.BoolAttribute{
-		Description: "Force destroy including child datasets. Defaults to false.",
-		Optional:    true,
+		Description:        "Force destroy including child datasets. Defaults to false.",
+		Optional:           true,
+		DeprecationMessage: "Use recursive instead.",
 	}
+	for name, attr := range poolDatasetDestroySchema() {
+		attrs[name] = attr
+	}
+	attrs["properties"] = poolDatasetPropertiesSchema()
 
 	resp.Schema = schema.Schema{
 		Description: "Manages a ZFS volume (zvol) on TrueNAS. Zvols are block devices backed by ZFS, commonly used as VM disks or iSCSI targets.",
@@ -96,6 +111,50 @@ func (r *ZvolResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	}
 }
 
+func (r *ZvolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZvolResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePoolDatasetProperties(ctx, data.Properties, zvolModeledProperties, &resp.Diagnostics)
+}
+
+// ModifyPlan warns with the snapshot counts a recursive destroy would delete,
+// both when the zvol is being destroyed and when recursion is turned on.
+func (r *ZvolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to count before the zvol exists, or before the provider is configured
+	if req.State.Raw.IsNull() || r.services == nil {
+		return
+	}
+
+	var state ZvolResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRecursive := poolDatasetDestroyRecursive(state.Recursive, state.ForceDestroy)
+
+	if req.Plan.Raw.IsNull() {
+		if stateRecursive {
+			addPoolDatasetDestroyWarning(ctx, r.services.Snapshot, state.ID.ValueString(), true, &resp.Diagnostics)
+		}
+		return
+	}
+
+	var plan ZvolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !stateRecursive && poolDatasetDestroyRecursive(plan.Recursive, plan.ForceDestroy) {
+		addPoolDatasetDestroyWarning(ctx, r.services.Snapshot, state.ID.ValueString(), false, &resp.Diagnostics)
+	}
+}
+
 func (r *ZvolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ZvolResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -159,6 +218,13 @@ func (r *ZvolResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	mapZvolToModel(zvol, &data)
 
+	properties, diags := applyPoolDatasetProperties(ctx, r.client, zvol.ID, data.Properties, types.MapNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Properties = properties
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -184,14 +250,23 @@ func (r *ZvolResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	mapZvolToModel(zvol, &data)
 
+	// Read back only the properties under management
+	properties, diags := readPoolDatasetProperties(ctx, r.client, zvol.ID, data.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Properties = properties
+
 	// Populate pool/path from ID if not set (e.g., after import)
 	if data.Pool.IsNull() && data.Path.IsNull() && data.Parent.IsNull() {
 		pool, path := poolDatasetIDToParts(zvol.ID)
-		data.Pool = types.StringValue(pool)
-		data.Path = types.StringValue(path)
+		data.Pool = customtypes.NewDatasetPathValue(pool)
+		data.Path = customtypes.NewDatasetPathValue(path)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(r.reportDrift(ctx, req.State, resp.State)...)
 }
 
 func (r *ZvolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -266,6 +341,13 @@ func (r *ZvolResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		mapZvolToModel(zvol, &plan)
 	}
 
+	properties, diags := applyPoolDatasetProperties(ctx, r.client, zvolID, plan.Properties, state.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Properties = properties
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -277,10 +359,22 @@ func (r *ZvolResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	zvolID := data.ID.ValueString()
-	recursive := !data.ForceDestroy.IsNull() && data.ForceDestroy.ValueBool()
+	recursive := poolDatasetDestroyRecursive(data.Recursive, data.ForceDestroy)
+
+	resp.Diagnostics.Append(r.checkMaintenance(ctx, fmt.Sprintf("destroy of zvol %q", zvolID))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkPoolDatasetEmpty(ctx, r.services.Client, zvolID, data.ProtectNonempty)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var err error
-	if recursive {
+	if data.Force.ValueBool() {
+		err = deletePoolDatasetForced(ctx, r.services.Client, zvolID, recursive)
+	} else if recursive {
 		err = r.services.Dataset.DeleteDataset(ctx, zvolID, true)
 	} else {
 		err = r.services.Dataset.DeleteZvol(ctx, zvolID)
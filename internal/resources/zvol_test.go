@@ -2,11 +2,15 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	truenas "github.com/deevus/truenas-go"
+	"github.com/deevus/truenas-go/client"
 	"github.com/deevus/terraform-provider-truenas/internal/services"
+	customtypes "github.com/deevus/terraform-provider-truenas/internal/types"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -557,6 +561,167 @@ func TestZvolResource_Delete_ForceDestroy(t *testing.T) {
 	}
 }
 
+func TestZvolResource_Delete_Force(t *testing.T) {
+	var capturedMethod string
+	var capturedParams any
+
+	r := &ZvolResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					capturedMethod = method
+					capturedParams = params
+					return json.RawMessage(`true`), nil
+				},
+			},
+			Dataset: &truenas.MockDatasetService{
+				DeleteZvolFunc: func(ctx context.Context, id string) error {
+					t.Error("expected DeleteZvol not to be called with force")
+					return nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getZvolResourceSchema(t)
+	p := defaultZvolPlanParams()
+	p.ID = strPtr("tank/myvol")
+	p.Force = boolPtr(true)
+	stateValue := createZvolModelValue(p)
+
+	req := resource.DeleteRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+	}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if capturedMethod != "pool.dataset.delete" {
+		t.Errorf("expected method 'pool.dataset.delete', got %q", capturedMethod)
+	}
+	args, ok := capturedParams.([]any)
+	if !ok || len(args) != 2 {
+		t.Fatalf("expected [id, options], got %#v", capturedParams)
+	}
+	opts := args[1].(map[string]any)
+	if opts["force"] != true || opts["recursive"] != false {
+		t.Errorf("expected force without recursion, got %v", opts)
+	}
+}
+
+func TestZvolResource_Delete_ProtectNonempty(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantDelete bool
+	}{
+		{
+			name:       "empty",
+			query:      `[{"id": "tank/myvol", "usedbydataset": {"parsed": 57344}}]`,
+			wantDelete: true,
+		},
+		{
+			name:       "holds data",
+			query:      `[{"id": "tank/myvol", "usedbydataset": {"parsed": 10737418240}}]`,
+			wantDelete: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleteCalled bool
+			var queryParams any
+
+			r := &ZvolResource{
+				BaseResource: BaseResource{services: &services.TrueNASServices{
+					Client: &client.MockClient{
+						CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+							if method != "pool.dataset.query" {
+								t.Errorf("expected method 'pool.dataset.query', got %q", method)
+							}
+							queryParams = params
+							return json.RawMessage(tt.query), nil
+						},
+					},
+					Dataset: &truenas.MockDatasetService{
+						DeleteZvolFunc: func(ctx context.Context, id string) error {
+							deleteCalled = true
+							return nil
+						},
+					},
+				}},
+			}
+
+			schemaResp := getZvolResourceSchema(t)
+			p := defaultZvolPlanParams()
+			p.ID = strPtr("tank/myvol")
+			p.Protect = boolPtr(true)
+			stateValue := createZvolModelValue(p)
+
+			req := resource.DeleteRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+			}
+			resp := &resource.DeleteResponse{}
+
+			r.Delete(context.Background(), req, resp)
+
+			if queryParams == nil {
+				t.Fatal("expected the zvol to be queried before destroy")
+			}
+			if deleteCalled != tt.wantDelete {
+				t.Errorf("expected delete called = %v, got %v", tt.wantDelete, deleteCalled)
+			}
+			if resp.Diagnostics.HasError() == tt.wantDelete {
+				t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestZvolResource_ModifyPlan_RecursiveDestroyWarnsSnapshotCounts(t *testing.T) {
+	r := &ZvolResource{
+		BaseResource: BaseResource{services: &services.TrueNASServices{
+			Snapshot: &truenas.MockSnapshotService{
+				QueryFunc: func(ctx context.Context, filters [][]any) ([]truenas.Snapshot, error) {
+					return []truenas.Snapshot{
+						{ID: "tank/myvol@a", Dataset: "tank/myvol"},
+						{ID: "tank/myvol@b", Dataset: "tank/myvol"},
+					}, nil
+				},
+			},
+		}},
+	}
+
+	schemaResp := getZvolResourceSchema(t)
+	p := defaultZvolPlanParams()
+	p.ID = strPtr("tank/myvol")
+	p.Recursive = boolPtr(true)
+	stateValue := createZvolModelValue(p)
+
+	req := resource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: stateValue},
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(zvolObjectType(), nil)},
+	}
+	resp := &resource.ModifyPlanResponse{
+		Plan: req.Plan,
+	}
+
+	r.ModifyPlan(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected 1 warning, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Warnings()[0].Detail(); !strings.Contains(detail, "tank/myvol: 2") {
+		t.Errorf("expected snapshot count in warning, got %q", detail)
+	}
+}
+
 func TestZvolResource_Delete_APIError(t *testing.T) {
 	r := &ZvolResource{
 		BaseResource: BaseResource{services: &services.TrueNASServices{
@@ -1052,6 +1217,8 @@ func TestPoolDatasetFullName(t *testing.T) {
 		{"nothing", "", "", "", "", ""},
 		{"pool only", "tank", "", "", "", ""},
 		{"path only", "", "disk0", "", "", ""},
+		{"mount path parent", "", "disk0", "/mnt/tank/vms/", "", "tank/vms/disk0"},
+		{"slashes trimmed", "/tank/", "/vms/disk0/", "", "", "tank/vms/disk0"},
 	}
 
 	for _, tt := range tests {
@@ -1062,7 +1229,13 @@ func TestPoolDatasetFullName(t *testing.T) {
 				}
 				return types.StringValue(s)
 			}
-			got := poolDatasetFullName(toStr(tt.pool), toStr(tt.path), toStr(tt.parent), toStr(tt.nameAttr))
+			toPath := func(s string) customtypes.DatasetPathValue {
+				if s == "" {
+					return customtypes.NewDatasetPathNull()
+				}
+				return customtypes.NewDatasetPathValue(s)
+			}
+			got := poolDatasetFullName(toPath(tt.pool), toPath(tt.path), toPath(tt.parent), toStr(tt.nameAttr))
 			if got != tt.want {
 				t.Errorf("poolDatasetFullName() = %q, want %q", got, tt.want)
 			}
@@ -1111,17 +1284,21 @@ func getZvolResourceSchema(t *testing.T) resource.SchemaResponse {
 func zvolObjectType() tftypes.Object {
 	return tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"id":            tftypes.String,
-			"pool":          tftypes.String,
-			"path":          tftypes.String,
-			"parent":        tftypes.String,
-			"volsize":       tftypes.String,
-			"volblocksize":  tftypes.String,
-			"sparse":        tftypes.Bool,
-			"force_size":    tftypes.Bool,
-			"compression":   tftypes.String,
-			"comments":      tftypes.String,
-			"force_destroy": tftypes.Bool,
+			"id":               tftypes.String,
+			"pool":             tftypes.String,
+			"path":             tftypes.String,
+			"parent":           tftypes.String,
+			"volsize":          tftypes.String,
+			"volblocksize":     tftypes.String,
+			"sparse":           tftypes.Bool,
+			"force_size":       tftypes.Bool,
+			"compression":      tftypes.String,
+			"comments":         tftypes.String,
+			"force_destroy":    tftypes.Bool,
+			"recursive":        tftypes.Bool,
+			"force":            tftypes.Bool,
+			"protect_nonempty": tftypes.Bool,
+			"properties":       tftypes.Map{ElementType: tftypes.String},
 		},
 	}
 }
@@ -1138,6 +1315,10 @@ type zvolModelParams struct {
 	Compression  *string
 	Comments     *string
 	ForceDestroy *bool
+	Recursive    *bool
+	Force        *bool
+	Protect      *bool
+	Properties   map[string]string
 }
 
 func createZvolModelValue(p zvolModelParams) tftypes.Value {
@@ -1154,18 +1335,32 @@ func createZvolModelValue(p zvolModelParams) tftypes.Value {
 		return tftypes.NewValue(tftypes.Bool, *b)
 	}
 
+	propertiesType := tftypes.Map{ElementType: tftypes.String}
+	properties := tftypes.NewValue(propertiesType, nil)
+	if p.Properties != nil {
+		values := make(map[string]tftypes.Value, len(p.Properties))
+		for k, v := range p.Properties {
+			values[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		properties = tftypes.NewValue(propertiesType, values)
+	}
+
 	return tftypes.NewValue(zvolObjectType(), map[string]tftypes.Value{
-		"id":            strVal(p.ID),
-		"pool":          strVal(p.Pool),
-		"path":          strVal(p.Path),
-		"parent":        strVal(p.Parent),
-		"volsize":       strVal(p.Volsize),
-		"volblocksize":  strVal(p.Volblocksize),
-		"sparse":        boolVal(p.Sparse),
-		"force_size":    boolVal(p.ForceSize),
-		"compression":   strVal(p.Compression),
-		"comments":      strVal(p.Comments),
-		"force_destroy": boolVal(p.ForceDestroy),
+		"id":               strVal(p.ID),
+		"pool":             strVal(p.Pool),
+		"path":             strVal(p.Path),
+		"parent":           strVal(p.Parent),
+		"volsize":          strVal(p.Volsize),
+		"volblocksize":     strVal(p.Volblocksize),
+		"sparse":           boolVal(p.Sparse),
+		"force_size":       boolVal(p.ForceSize),
+		"compression":      strVal(p.Compression),
+		"comments":         strVal(p.Comments),
+		"force_destroy":    boolVal(p.ForceDestroy),
+		"recursive":        boolVal(p.Recursive),
+		"force":            boolVal(p.Force),
+		"protect_nonempty": boolVal(p.Protect),
+		"properties":       properties,
 	})
 }
 
@@ -1179,3 +1374,134 @@ func defaultZvolPlanParams() zvolModelParams {
 	}
 }
 
+func TestZvolResource_ValidateConfig_Properties(t *testing.T) {
+	r := NewZvolResource().(*ZvolResource)
+	schemaResp := getZvolResourceSchema(t)
+
+	p := defaultZvolPlanParams()
+	p.Properties = map[string]string{"volblocksize": "16K", "sync": "ALWAYS"}
+
+	req := resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: createZvolModelValue(p)},
+	}
+	resp := &resource.ValidateConfigResponse{}
+
+	r.ValidateConfig(context.Background(), req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.Errors()[0].Summary() != "Property Has Its Own Attribute" {
+		t.Errorf("unexpected error: %v", resp.Diagnostics)
+	}
+}
+
+func TestZvolResource_Create_WithProperties(t *testing.T) {
+	var updateParams any
+	r := &ZvolResource{
+		BaseResource: BaseResource{
+			client: &client.MockClient{
+				CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+					switch method {
+					case "pool.dataset.update":
+						updateParams = params
+						return json.RawMessage(`{}`), nil
+					case "pool.dataset.query":
+						return json.RawMessage(`[{"id": "tank/myvol", "sync": {"value": "ALWAYS"}}]`), nil
+					}
+					t.Errorf("unexpected method %q", method)
+					return nil, nil
+				},
+			},
+			services: &services.TrueNASServices{
+				Dataset: &truenas.MockDatasetService{
+					CreateZvolFunc: func(ctx context.Context, opts truenas.CreateZvolOpts) (*truenas.Zvol, error) {
+						return &truenas.Zvol{ID: "tank/myvol", Name: "tank/myvol", Volsize: 10737418240, Volblocksize: "16K"}, nil
+					},
+				},
+			},
+		},
+	}
+
+	schemaResp := getZvolResourceSchema(t)
+	p := defaultZvolPlanParams()
+	p.Properties = map[string]string{"sync": "always"}
+
+	req := resource.CreateRequest{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: createZvolModelValue(p)},
+	}
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	params, ok := updateParams.([]any)
+	if !ok || params[0] != "tank/myvol" || params[1].(map[string]any)["sync"] != "always" {
+		t.Errorf("unexpected pool.dataset.update params: %v", updateParams)
+	}
+
+	var model ZvolResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+	if v := model.Properties.Elements()["sync"]; v == nil || v.(types.String).ValueString() != "always" {
+		t.Errorf("expected sync 'always' in state, got %v", model.Properties)
+	}
+}
+
+func TestPoolDatasetPropertiesParams(t *testing.T) {
+	ctx := context.Background()
+	toMap := func(m map[string]string) types.Map {
+		if m == nil {
+			return types.MapNull(types.StringType)
+		}
+		v, diags := types.MapValueFrom(ctx, types.StringType, m)
+		if diags.HasError() {
+			t.Fatalf("failed to build map: %v", diags)
+		}
+		return v
+	}
+
+	tests := map[string]struct {
+		plan, state map[string]string
+		want        map[string]any
+	}{
+		"unmanaged": {
+			want: map[string]any{},
+		},
+		"added": {
+			plan: map[string]string{"copies": "2", "logbias": "THROUGHPUT"},
+			want: map[string]any{"copies": int64(2), "logbias": "THROUGHPUT"},
+		},
+		"case only": {
+			plan:  map[string]string{"logbias": "throughput"},
+			state: map[string]string{"logbias": "THROUGHPUT"},
+			want:  map[string]any{},
+		},
+		"changed and removed": {
+			plan:  map[string]string{"logbias": "LATENCY"},
+			state: map[string]string{"logbias": "THROUGHPUT", "sync": "ALWAYS"},
+			want:  map[string]any{"logbias": "LATENCY", "sync": "INHERIT"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := poolDatasetPropertiesParams(ctx, toMap(tt.plan), toMap(tt.state))
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s: expected %#v, got %#v", k, v, got[k])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import "context"
+
+// CommandResult is the outcome of a command run on the TrueNAS host.
+type CommandResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// CommandRunner runs shell commands on the TrueNAS host.
+type CommandRunner interface {
+	// Run executes command in a shell as the configured SSH user. A non-zero
+	// exit status is reported in the result, not as an error; errors are
+	// reserved for connection and session failures.
+	Run(ctx context.Context, command string) (*CommandResult, error)
+}
+
+// MockCommandRunner is a test double for CommandRunner.
+type MockCommandRunner struct {
+	RunFunc func(ctx context.Context, command string) (*CommandResult, error)
+}
+
+func (m *MockCommandRunner) Run(ctx context.Context, command string) (*CommandResult, error) {
+	if m.RunFunc != nil {
+		return m.RunFunc(ctx, command)
+	}
+	return &CommandResult{}, nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+// DefaultHeartbeatTimeout is how long a heartbeat waits for its response
+// before it is counted as missed.
+const DefaultHeartbeatTimeout = 10 * time.Second
+
+// HealthStats is a snapshot of the connection health recorded by a HealthMonitor.
+type HealthStats struct {
+	// Pings is the number of heartbeats sent.
+	Pings int64
+	// Missed is the number of heartbeats that failed or timed out.
+	Missed int64
+	// ConsecutiveMissed is the number of heartbeats missed since the last success.
+	ConsecutiveMissed int64
+
+	LastRTT    time.Duration
+	AverageRTT time.Duration
+	MaxRTT     time.Duration
+
+	// LastError is the error of the most recent missed heartbeat, if any.
+	LastError string
+}
+
+// HealthMonitor sends core.ping heartbeats over a client and records their
+// round-trip times and misses. It is safe for concurrent use.
+type HealthMonitor struct {
+	client  client.Client
+	timeout time.Duration
+
+	mu       sync.Mutex
+	stats    HealthStats
+	totalRTT time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewHealthMonitor creates a HealthMonitor for c. A timeout of 0 uses
+// DefaultHeartbeatTimeout.
+func NewHealthMonitor(c client.Client, timeout time.Duration) *HealthMonitor {
+	if timeout == 0 {
+		timeout = DefaultHeartbeatTimeout
+	}
+	return &HealthMonitor{
+		client:  c,
+		timeout: timeout,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start sends a heartbeat every interval in the background until Stop is called.
+func (m *HealthMonitor) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				_, _ = m.Ping(context.Background())
+			}
+		}
+	}()
+}
+
+// Stop ends background heartbeats. It is safe to call more than once.
+func (m *HealthMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// Ping sends a single heartbeat, records the outcome and returns the round-trip time.
+func (m *HealthMonitor) Ping(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := m.client.Call(ctx, "core.ping", nil)
+	rtt := time.Since(start)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stats.Pings++
+	if err != nil {
+		m.stats.Missed++
+		m.stats.ConsecutiveMissed++
+		m.stats.LastError = err.Error()
+		return 0, err
+	}
+
+	m.stats.ConsecutiveMissed = 0
+	m.stats.LastRTT = rtt
+	m.totalRTT += rtt
+	m.stats.AverageRTT = m.totalRTT / time.Duration(m.stats.Pings-m.stats.Missed)
+	if rtt > m.stats.MaxRTT {
+		m.stats.MaxRTT = rtt
+	}
+
+	return rtt, nil
+}
+
+// Stats returns a snapshot of the recorded health.
+func (m *HealthMonitor) Stats() HealthStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
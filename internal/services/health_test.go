@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deevus/truenas-go/client"
+)
+
+func TestHealthMonitor_Ping(t *testing.T) {
+	var method string
+	m := NewHealthMonitor(&client.MockClient{
+		CallFunc: func(ctx context.Context, m string, params any) (json.RawMessage, error) {
+			method = m
+			return json.RawMessage(`"pong"`), nil
+		},
+	}, 0)
+
+	if _, err := m.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if method != "core.ping" {
+		t.Errorf("expected method 'core.ping', got %q", method)
+	}
+
+	stats := m.Stats()
+	if stats.Pings != 1 || stats.Missed != 0 {
+		t.Errorf("expected 1 ping and 0 missed, got %d and %d", stats.Pings, stats.Missed)
+	}
+	if stats.AverageRTT != stats.LastRTT || stats.MaxRTT != stats.LastRTT {
+		t.Errorf("expected average and max to equal the only RTT, got %+v", stats)
+	}
+}
+
+func TestHealthMonitor_Ping_Missed(t *testing.T) {
+	fail := true
+	m := NewHealthMonitor(&client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			if fail {
+				return nil, errors.New("connection reset")
+			}
+			return json.RawMessage(`"pong"`), nil
+		},
+	}, 0)
+
+	m.Ping(context.Background())
+	m.Ping(context.Background())
+
+	stats := m.Stats()
+	if stats.Pings != 2 || stats.Missed != 2 || stats.ConsecutiveMissed != 2 {
+		t.Errorf("expected 2 pings, 2 missed, 2 consecutive, got %+v", stats)
+	}
+	if stats.LastError != "connection reset" {
+		t.Errorf("expected last error 'connection reset', got %q", stats.LastError)
+	}
+	if stats.AverageRTT != 0 {
+		t.Errorf("expected no average RTT without successful pings, got %s", stats.AverageRTT)
+	}
+
+	fail = false
+	m.Ping(context.Background())
+
+	stats = m.Stats()
+	if stats.Missed != 2 || stats.ConsecutiveMissed != 0 {
+		t.Errorf("expected 2 missed and 0 consecutive after success, got %+v", stats)
+	}
+}
+
+func TestHealthMonitor_Ping_Timeout(t *testing.T) {
+	m := NewHealthMonitor(&client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}, 10*time.Millisecond)
+
+	if _, err := m.Ping(context.Background()); err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	if stats := m.Stats(); stats.Missed != 1 {
+		t.Errorf("expected 1 missed heartbeat, got %d", stats.Missed)
+	}
+}
+
+func TestHealthMonitor_StartStop(t *testing.T) {
+	var calls atomic.Int64
+	m := NewHealthMonitor(&client.MockClient{
+		CallFunc: func(ctx context.Context, method string, params any) (json.RawMessage, error) {
+			calls.Add(1)
+			return json.RawMessage(`"pong"`), nil
+		},
+	}, 0)
+
+	m.Start(time.Millisecond)
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	m.Stop()
+	m.Stop()
+
+	if calls.Load() < 2 {
+		t.Fatalf("expected background heartbeats, got %d", calls.Load())
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"time"
+
 	truenas "github.com/deevus/truenas-go"
 	"github.com/deevus/truenas-go/client"
 )
@@ -8,6 +10,9 @@ import (
 // TrueNASServices holds typed service instances for all TrueNAS API namespaces.
 // Resources and datasources access services through this registry.
 type TrueNASServices struct {
+	// Host is the TrueNAS host name or address from the provider configuration.
+	Host string
+
 	// Client provides backward-compatible access to the raw client.Client
 	// for resources that haven't been migrated to typed services yet.
 	// Remove this field once all resources use typed service methods.
@@ -17,8 +22,25 @@ type TrueNASServices struct {
 	CloudSync  truenas.CloudSyncServiceAPI
 	Cron       truenas.CronServiceAPI
 	Dataset    truenas.DatasetServiceAPI
+	Docker     truenas.DockerServiceAPI
 	Filesystem truenas.FilesystemServiceAPI
+	Interface  truenas.InterfaceServiceAPI
 	Snapshot   truenas.SnapshotServiceAPI
+	System     truenas.SystemServiceAPI
 	Virt       truenas.VirtServiceAPI
 	VM         truenas.VMServiceAPI
+
+	// Exec runs shell commands on the host over the provider's SSH connection settings.
+	Exec CommandRunner
+
+	// Health records heartbeat round-trip times and misses for the connection.
+	Health *HealthMonitor
+
+	// ReportDrift makes resources warn about attributes changed outside Terraform when they are read.
+	ReportDrift bool
+
+	// MaintenanceGuard makes destructive pool and dataset operations refuse to run while a
+	// scrub, resilver or system update is in progress, after waiting up to MaintenanceTimeout.
+	MaintenanceGuard   bool
+	MaintenanceTimeout time.Duration
 }
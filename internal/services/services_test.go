@@ -13,9 +13,14 @@ func TestTrueNASServices_FieldTypes(t *testing.T) {
 		CloudSync:  &truenas.MockCloudSyncService{},
 		Cron:       &truenas.MockCronService{},
 		Dataset:    &truenas.MockDatasetService{},
+		Docker:     &truenas.MockDockerService{},
 		Filesystem: &truenas.MockFilesystemService{},
+		Interface:  &truenas.MockInterfaceService{},
 		Snapshot:   &truenas.MockSnapshotService{},
+		System:     &truenas.MockSystemService{},
 		Virt:       &truenas.MockVirtService{},
 		VM:         &truenas.MockVMService{},
+		Exec:       &MockCommandRunner{},
+		Health:     &HealthMonitor{},
 	}
 }
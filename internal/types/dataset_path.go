@@ -0,0 +1,191 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure interfaces are implemented.
+var (
+	_ basetypes.StringTypable     = DatasetPathType{}
+	_ basetypes.StringValuable    = DatasetPathValue{}
+	_ xattr.ValidateableAttribute = DatasetPathValue{}
+)
+
+// NormalizeDatasetPath returns the ZFS name for a dataset path. The /mnt/
+// mountpoint prefix and leading or trailing slashes are removed, so
+// "/mnt/tank/data/" and "tank/data" both become "tank/data". Case is kept, as
+// ZFS names are case-sensitive.
+func NormalizeDatasetPath(p string) string {
+	if rest, ok := strings.CutPrefix(p, "/mnt/"); ok {
+		p = rest
+	}
+	return strings.Trim(p, "/")
+}
+
+// ValidateDatasetPath returns an error if the normalized path is not a valid
+// ZFS dataset name: slash-separated components of letters, digits and
+// "_-.: " with no empty, "." or ".." components.
+func ValidateDatasetPath(p string) error {
+	normalized := NormalizeDatasetPath(p)
+	if normalized == "" {
+		return fmt.Errorf("dataset path %q is empty", p)
+	}
+
+	for _, component := range strings.Split(normalized, "/") {
+		if component == "" {
+			return fmt.Errorf("dataset path %q has an empty component", p)
+		}
+		if component == "." || component == ".." {
+			return fmt.Errorf("dataset path %q has a relative component %q", p, component)
+		}
+		for _, r := range component {
+			if !isDatasetNameRune(r) {
+				return fmt.Errorf("dataset path %q contains invalid character %q; use letters, digits and _-.: or space", p, r)
+			}
+		}
+	}
+	return nil
+}
+
+// isDatasetNameRune reports whether r may appear in a ZFS dataset name component.
+func isDatasetNameRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("_-.: ", r)
+}
+
+// DatasetPathType is a custom type for dataset paths that compares by
+// normalized ZFS name. This allows "/mnt/tank/data" and "tank/data" to be
+// considered equal.
+type DatasetPathType struct {
+	basetypes.StringType
+}
+
+// Equal returns true if the given type is equivalent.
+func (t DatasetPathType) Equal(o attr.Type) bool {
+	other, ok := o.(DatasetPathType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// String returns a human-readable string of the type.
+func (t DatasetPathType) String() string {
+	return "DatasetPathType"
+}
+
+// ValueType returns the value type.
+func (t DatasetPathType) ValueType(ctx context.Context) attr.Value {
+	return DatasetPathValue{}
+}
+
+// ValueFromString converts a StringValue to a DatasetPathValue.
+func (t DatasetPathType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DatasetPathValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a tftypes.Value to a DatasetPathValue.
+func (t DatasetPathType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable.(DatasetPathValue), nil
+}
+
+// DatasetPathValue is a custom string value that compares dataset paths by
+// normalized ZFS name.
+type DatasetPathValue struct {
+	basetypes.StringValue
+}
+
+// Type returns the type of this value.
+func (v DatasetPathValue) Type(ctx context.Context) attr.Type {
+	return DatasetPathType{}
+}
+
+// Equal returns true if the values are equal (including null/unknown state).
+func (v DatasetPathValue) Equal(o attr.Value) bool {
+	other, ok := o.(DatasetPathValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares two dataset paths by normalized ZFS name.
+func (v DatasetPathValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, d := newValuable.ToStringValue(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	// Handle null/unknown cases
+	if v.IsNull() && newValue.IsNull() {
+		return true, diags
+	}
+	if v.IsNull() || newValue.IsNull() {
+		return false, diags
+	}
+	if v.IsUnknown() || newValue.IsUnknown() {
+		return false, diags
+	}
+
+	return NormalizeDatasetPath(v.ValueString()) == NormalizeDatasetPath(newValue.ValueString()), diags
+}
+
+// ValidateAttribute rejects values that are not valid dataset paths.
+func (v DatasetPathValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if err := ValidateDatasetPath(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Dataset Path", err.Error())
+	}
+}
+
+// NormalizedValue returns the normalized ZFS name of the value.
+func (v DatasetPathValue) NormalizedValue() string {
+	return NormalizeDatasetPath(v.ValueString())
+}
+
+// NewDatasetPathValue creates a new DatasetPathValue with the given string.
+func NewDatasetPathValue(value string) DatasetPathValue {
+	return DatasetPathValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewDatasetPathNull creates a new null DatasetPathValue.
+func NewDatasetPathNull() DatasetPathValue {
+	return DatasetPathValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewDatasetPathUnknown creates a new unknown DatasetPathValue.
+func NewDatasetPathUnknown() DatasetPathValue {
+	return DatasetPathValue{StringValue: basetypes.NewStringUnknown()}
+}
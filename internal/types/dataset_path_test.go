@@ -0,0 +1,154 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestNormalizeDatasetPath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"tank/data", "tank/data"},
+		{"/mnt/tank/data", "tank/data"},
+		{"/mnt/tank/data/", "tank/data"},
+		{"tank/data/", "tank/data"},
+		{"/tank", "tank"},
+		{"mnt/data", "mnt/data"},
+		{"Tank/Media", "Tank/Media"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeDatasetPath(tt.in); got != tt.want {
+			t.Errorf("NormalizeDatasetPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateDatasetPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{"pool", "tank", false},
+		{"nested", "tank/apps/my-app_1.0:beta", false},
+		{"mount path", "/mnt/tank/data", false},
+		{"space", "tank/My Media", false},
+		{"empty", "", true},
+		{"only mount prefix", "/mnt/", true},
+		{"empty component", "tank//data", true},
+		{"parent component", "tank/../data", true},
+		{"snapshot", "tank/data@snap", true},
+		{"bookmark", "tank/data#mark", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDatasetPath(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDatasetPath(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatasetPathValue_StringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		value1   string
+		value2   string
+		expected bool
+	}{
+		{"same", "tank/data", "tank/data", true},
+		{"mount prefix", "/mnt/tank/data", "tank/data", true},
+		{"trailing slash", "tank/data/", "tank/data", true},
+		{"different", "tank/data", "tank/other", false},
+		{"case differs", "tank/Data", "tank/data", false},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := NewDatasetPathValue(tt.value1).StringSemanticEquals(ctx, NewDatasetPathValue(tt.value2))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if equal != tt.expected {
+				t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.value1, tt.value2, equal, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDatasetPathValue_StringSemanticEquals_NullUnknown(t *testing.T) {
+	ctx := context.Background()
+
+	equal, _ := NewDatasetPathNull().StringSemanticEquals(ctx, NewDatasetPathNull())
+	if !equal {
+		t.Error("expected null == null")
+	}
+
+	equal, _ = NewDatasetPathValue("tank").StringSemanticEquals(ctx, NewDatasetPathNull())
+	if equal {
+		t.Error("expected value != null")
+	}
+
+	equal, _ = NewDatasetPathValue("tank").StringSemanticEquals(ctx, NewDatasetPathUnknown())
+	if equal {
+		t.Error("expected value != unknown")
+	}
+}
+
+func TestDatasetPathValue_ValidateAttribute(t *testing.T) {
+	ctx := context.Background()
+	req := xattr.ValidateAttributeRequest{Path: path.Root("parent")}
+
+	resp := &xattr.ValidateAttributeResponse{}
+	NewDatasetPathValue("/mnt/tank/data").ValidateAttribute(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	resp = &xattr.ValidateAttributeResponse{}
+	NewDatasetPathValue("tank//data").ValidateAttribute(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected error for empty component")
+	}
+
+	resp = &xattr.ValidateAttributeResponse{}
+	NewDatasetPathUnknown().ValidateAttribute(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("unexpected error for unknown value: %v", resp.Diagnostics)
+	}
+}
+
+func TestDatasetPathType_ValueFromString(t *testing.T) {
+	val, diags := DatasetPathType{}.ValueFromString(context.Background(), basetypes.NewStringValue("tank/data"))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	pathVal, ok := val.(DatasetPathValue)
+	if !ok {
+		t.Fatalf("expected DatasetPathValue, got %T", val)
+	}
+	if pathVal.ValueString() != "tank/data" {
+		t.Errorf("expected 'tank/data', got %q", pathVal.ValueString())
+	}
+}
+
+func TestDatasetPathType_Equal(t *testing.T) {
+	if !(DatasetPathType{}).Equal(DatasetPathType{}) {
+		t.Error("expected DatasetPathType to equal itself")
+	}
+	if (DatasetPathType{}).Equal(basetypes.StringType{}) {
+		t.Error("expected DatasetPathType to not equal StringType")
+	}
+}
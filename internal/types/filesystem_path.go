@@ -0,0 +1,159 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure interfaces are implemented.
+var (
+	_ basetypes.StringTypable     = FilesystemPathType{}
+	_ basetypes.StringValuable    = FilesystemPathValue{}
+	_ xattr.ValidateableAttribute = FilesystemPathValue{}
+)
+
+// NormalizeFilesystemPath returns the cleaned form of an absolute path, so
+// "/mnt/tank//data/" and "/mnt/tank/data" are the same path.
+func NormalizeFilesystemPath(p string) string {
+	if p == "" {
+		return p
+	}
+	return path.Clean(p)
+}
+
+// ValidateFilesystemPath returns an error if p is not an absolute path.
+func ValidateFilesystemPath(p string) error {
+	if !strings.HasPrefix(p, "/") {
+		return fmt.Errorf("path %q must be absolute (e.g. '/mnt/tank/data')", p)
+	}
+	return nil
+}
+
+// FilesystemPathType is a custom type for absolute host paths that compares
+// by cleaned path. This allows "/mnt/tank/data/" and "/mnt/tank/data" to be
+// considered equal.
+type FilesystemPathType struct {
+	basetypes.StringType
+}
+
+// Equal returns true if the given type is equivalent.
+func (t FilesystemPathType) Equal(o attr.Type) bool {
+	other, ok := o.(FilesystemPathType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// String returns a human-readable string of the type.
+func (t FilesystemPathType) String() string {
+	return "FilesystemPathType"
+}
+
+// ValueType returns the value type.
+func (t FilesystemPathType) ValueType(ctx context.Context) attr.Value {
+	return FilesystemPathValue{}
+}
+
+// ValueFromString converts a StringValue to a FilesystemPathValue.
+func (t FilesystemPathType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return FilesystemPathValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a tftypes.Value to a FilesystemPathValue.
+func (t FilesystemPathType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable.(FilesystemPathValue), nil
+}
+
+// FilesystemPathValue is a custom string value that compares absolute paths
+// after cleaning.
+type FilesystemPathValue struct {
+	basetypes.StringValue
+}
+
+// Type returns the type of this value.
+func (v FilesystemPathValue) Type(ctx context.Context) attr.Type {
+	return FilesystemPathType{}
+}
+
+// Equal returns true if the values are equal (including null/unknown state).
+func (v FilesystemPathValue) Equal(o attr.Value) bool {
+	other, ok := o.(FilesystemPathValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares two paths after cleaning.
+func (v FilesystemPathValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, d := newValuable.ToStringValue(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	// Handle null/unknown cases
+	if v.IsNull() && newValue.IsNull() {
+		return true, diags
+	}
+	if v.IsNull() || newValue.IsNull() {
+		return false, diags
+	}
+	if v.IsUnknown() || newValue.IsUnknown() {
+		return false, diags
+	}
+
+	return NormalizeFilesystemPath(v.ValueString()) == NormalizeFilesystemPath(newValue.ValueString()), diags
+}
+
+// ValidateAttribute rejects relative paths.
+func (v FilesystemPathValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if err := ValidateFilesystemPath(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Path", err.Error())
+	}
+}
+
+// NewFilesystemPathValue creates a new FilesystemPathValue with the given string.
+func NewFilesystemPathValue(value string) FilesystemPathValue {
+	return FilesystemPathValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewFilesystemPathNull creates a new null FilesystemPathValue.
+func NewFilesystemPathNull() FilesystemPathValue {
+	return FilesystemPathValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewFilesystemPathUnknown creates a new unknown FilesystemPathValue.
+func NewFilesystemPathUnknown() FilesystemPathValue {
+	return FilesystemPathValue{StringValue: basetypes.NewStringUnknown()}
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestNormalizeFilesystemPath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"/mnt/tank/data", "/mnt/tank/data"},
+		{"/mnt/tank/data/", "/mnt/tank/data"},
+		{"/mnt/tank//data", "/mnt/tank/data"},
+		{"/mnt/tank/./data", "/mnt/tank/data"},
+		{"/dev/zvol/tank/vms/disk0", "/dev/zvol/tank/vms/disk0"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeFilesystemPath(tt.in); got != tt.want {
+			t.Errorf("NormalizeFilesystemPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFilesystemPathValue_StringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		value1   string
+		value2   string
+		expected bool
+	}{
+		{"same", "/mnt/tank/data", "/mnt/tank/data", true},
+		{"trailing slash", "/mnt/tank/data/", "/mnt/tank/data", true},
+		{"double slash", "/mnt/tank//data", "/mnt/tank/data", true},
+		{"different", "/mnt/tank/data", "/mnt/tank/other", false},
+		{"case differs", "/mnt/tank/Data", "/mnt/tank/data", false},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := NewFilesystemPathValue(tt.value1).StringSemanticEquals(ctx, NewFilesystemPathValue(tt.value2))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if equal != tt.expected {
+				t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.value1, tt.value2, equal, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilesystemPathValue_StringSemanticEquals_NullUnknown(t *testing.T) {
+	ctx := context.Background()
+
+	equal, _ := NewFilesystemPathNull().StringSemanticEquals(ctx, NewFilesystemPathNull())
+	if !equal {
+		t.Error("expected null == null")
+	}
+
+	equal, _ = NewFilesystemPathValue("/mnt/tank").StringSemanticEquals(ctx, NewFilesystemPathNull())
+	if equal {
+		t.Error("expected value != null")
+	}
+
+	equal, _ = NewFilesystemPathValue("/mnt/tank").StringSemanticEquals(ctx, NewFilesystemPathUnknown())
+	if equal {
+		t.Error("expected value != unknown")
+	}
+}
+
+func TestFilesystemPathValue_ValidateAttribute(t *testing.T) {
+	ctx := context.Background()
+	req := xattr.ValidateAttributeRequest{Path: path.Root("path")}
+
+	resp := &xattr.ValidateAttributeResponse{}
+	NewFilesystemPathValue("/mnt/tank/data").ValidateAttribute(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	resp = &xattr.ValidateAttributeResponse{}
+	NewFilesystemPathValue("tank/data").ValidateAttribute(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected error for relative path")
+	}
+
+	resp = &xattr.ValidateAttributeResponse{}
+	NewFilesystemPathNull().ValidateAttribute(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("unexpected error for null value: %v", resp.Diagnostics)
+	}
+}
+
+func TestFilesystemPathType_Equal(t *testing.T) {
+	if !(FilesystemPathType{}).Equal(FilesystemPathType{}) {
+		t.Error("expected FilesystemPathType to equal itself")
+	}
+	if (FilesystemPathType{}).Equal(basetypes.StringType{}) {
+		t.Error("expected FilesystemPathType to not equal StringType")
+	}
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure interfaces are implemented.
+var (
+	_ basetypes.Int64Typable                    = MemoryMiBType{}
+	_ basetypes.Int64ValuableWithSemanticEquals = MemoryMiBValue{}
+)
+
+// memoryBytesThreshold is the smallest value treated as a byte count rather
+// than MiB. 16 MiB in bytes corresponds to 16 TiB in MiB, which is larger than
+// any VM TrueNAS can host, so the two ranges do not overlap.
+const memoryBytesThreshold = 1 << 24
+
+// NormalizeMemoryMiB returns the memory size in MiB. Values that are only
+// plausible as a byte count are converted from bytes, everything else is
+// returned unchanged.
+func NormalizeMemoryMiB(v int64) int64 {
+	if v >= memoryBytesThreshold {
+		return v / (1 << 20)
+	}
+	return v
+}
+
+// MemoryMiBType is a custom type for memory sizes stored canonically in MiB.
+// This allows 4096 and 4294967296 (bytes) to be considered equal.
+type MemoryMiBType struct {
+	basetypes.Int64Type
+}
+
+// Equal returns true if the given type is equivalent.
+func (t MemoryMiBType) Equal(o attr.Type) bool {
+	other, ok := o.(MemoryMiBType)
+	if !ok {
+		return false
+	}
+	return t.Int64Type.Equal(other.Int64Type)
+}
+
+// String returns a human-readable string of the type.
+func (t MemoryMiBType) String() string {
+	return "MemoryMiBType"
+}
+
+// ValueType returns the value type.
+func (t MemoryMiBType) ValueType(ctx context.Context) attr.Value {
+	return MemoryMiBValue{}
+}
+
+// ValueFromInt64 converts an Int64Value to a MemoryMiBValue.
+func (t MemoryMiBType) ValueFromInt64(ctx context.Context, in basetypes.Int64Value) (basetypes.Int64Valuable, diag.Diagnostics) {
+	return MemoryMiBValue{Int64Value: in}, nil
+}
+
+// ValueFromTerraform converts a tftypes.Value to a MemoryMiBValue.
+func (t MemoryMiBType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.Int64Type.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	int64Value, ok := attrValue.(basetypes.Int64Value)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	int64Valuable, diags := t.ValueFromInt64(ctx, int64Value)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting Int64Value to Int64Valuable: %v", diags)
+	}
+
+	return int64Valuable.(MemoryMiBValue), nil
+}
+
+// MemoryMiBValue is a custom int64 value that compares memory sizes in MiB.
+type MemoryMiBValue struct {
+	basetypes.Int64Value
+}
+
+// Type returns the type of this value.
+func (v MemoryMiBValue) Type(ctx context.Context) attr.Type {
+	return MemoryMiBType{}
+}
+
+// Equal returns true if the values are equal (including null/unknown state).
+func (v MemoryMiBValue) Equal(o attr.Value) bool {
+	other, ok := o.(MemoryMiBValue)
+	if !ok {
+		return false
+	}
+	return v.Int64Value.Equal(other.Int64Value)
+}
+
+// Int64SemanticEquals compares two memory sizes after normalizing both to MiB.
+func (v MemoryMiBValue) Int64SemanticEquals(ctx context.Context, newValuable basetypes.Int64Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, d := newValuable.ToInt64Value(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	// Handle null/unknown cases
+	if v.IsNull() && newValue.IsNull() {
+		return true, diags
+	}
+	if v.IsNull() || newValue.IsNull() {
+		return false, diags
+	}
+	if v.IsUnknown() || newValue.IsUnknown() {
+		return false, diags
+	}
+
+	return NormalizeMemoryMiB(v.ValueInt64()) == NormalizeMemoryMiB(newValue.ValueInt64()), diags
+}
+
+// ValueMiB returns the value normalized to MiB.
+func (v MemoryMiBValue) ValueMiB() int64 {
+	return NormalizeMemoryMiB(v.ValueInt64())
+}
+
+// NewMemoryMiBValue creates a new MemoryMiBValue with the given value.
+func NewMemoryMiBValue(value int64) MemoryMiBValue {
+	return MemoryMiBValue{Int64Value: basetypes.NewInt64Value(value)}
+}
+
+// NewMemoryMiBNull creates a new null MemoryMiBValue.
+func NewMemoryMiBNull() MemoryMiBValue {
+	return MemoryMiBValue{Int64Value: basetypes.NewInt64Null()}
+}
+
+// NewMemoryMiBUnknown creates a new unknown MemoryMiBValue.
+func NewMemoryMiBUnknown() MemoryMiBValue {
+	return MemoryMiBValue{Int64Value: basetypes.NewInt64Unknown()}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalizeMemoryMiB(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       int64
+		expected int64
+	}{
+		{"minimum MiB", 20, 20},
+		{"4 GiB in MiB", 4096, 4096},
+		{"1 TiB in MiB", 1048576, 1048576},
+		{"4 GiB in bytes", 4294967296, 4096},
+		{"20 MiB in bytes", 20971520, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeMemoryMiB(tt.in); got != tt.expected {
+				t.Errorf("NormalizeMemoryMiB(%d) = %d, want %d", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMemoryMiBValue_Int64SemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		value1   MemoryMiBValue
+		value2   MemoryMiBValue
+		expected bool
+	}{
+		{"same MiB", NewMemoryMiBValue(4096), NewMemoryMiBValue(4096), true},
+		{"MiB vs bytes", NewMemoryMiBValue(4096), NewMemoryMiBValue(4294967296), true},
+		{"bytes vs MiB", NewMemoryMiBValue(4294967296), NewMemoryMiBValue(4096), true},
+		{"different", NewMemoryMiBValue(4096), NewMemoryMiBValue(8192), false},
+		{"both null", NewMemoryMiBNull(), NewMemoryMiBNull(), true},
+		{"null vs value", NewMemoryMiBNull(), NewMemoryMiBValue(4096), false},
+		{"unknown", NewMemoryMiBUnknown(), NewMemoryMiBValue(4096), false},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := tt.value1.Int64SemanticEquals(ctx, tt.value2)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if equal != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, equal)
+			}
+		})
+	}
+}
@@ -1,12 +1,12 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"log"
 
 	"github.com/deevus/terraform-provider-truenas/internal/provider"
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 var version = "dev"
@@ -17,12 +17,17 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/deevus/truenas",
-		Debug:   debug,
+	var opts []tf6server.ServeOpt
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	server := provider.NewServer(version)
+	err := tf6server.Serve("registry.terraform.io/deevus/truenas", func() tfprotov6.ProviderServer { return server }, opts...)
+
+	// Serve returns once Terraform is done with the provider
+	server.Close()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}